@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
+)
+
+// expireInvitationsCommand is the subcommand that expires the pending invites that are past their expiry time
+// and invalidates the temporary PINs that were sent with them. It is meant to be run on a schedule e.g
+//
+//	go run . expire-invitations
+const expireInvitationsCommand = "expire-invitations"
+
+// parseExpireInvitationsArgs checks that the expire invitations subcommand was given no flags or arguments
+func parseExpireInvitationsArgs(args []string, output io.Writer) error {
+	flags := flag.NewFlagSet(expireInvitationsCommand, flag.ContinueOnError)
+	flags.SetOutput(output)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	return nil
+}
+
+// expireInvitations runs the expire invitations subcommand
+func expireInvitations(ctx context.Context, args []string, output io.Writer) error {
+	if err := parseExpireInvitationsArgs(args, output); err != nil {
+		return err
+	}
+
+	pg, err := gorm.NewPGInstance()
+	if err != nil {
+		return fmt.Errorf("failed to initialize new PG instance: %v", err)
+	}
+	db := postgres.NewMyCareHubDb(pg, pg, pg, pg)
+
+	// the invites of every organisation are expired
+	if _, err := db.ExpireOverdueInvitations(helpers.ContextForAllOrganisations(ctx)); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(output, "expired the overdue invitations")
+	return err
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+func Test_parseExpireInvitationsArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case",
+			args:    []string{},
+			wantErr: false,
+		},
+		{
+			name:    "Sad case - unknown flag",
+			args:    []string{"-all"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case - unexpected argument",
+			args:    []string{"now"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := parseExpireInvitationsArgs(tt.args, io.Discard)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseExpireInvitationsArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
+)
+
+// grantSystemAdminCommand is the subcommand that makes a user the first system admin of a new deployment e.g
+//
+//	go run . grant-system-admin -user <user ID>
+//
+// It fails once there is a system admin. Further roles are assigned by the system admins through the
+// assignRoles mutation.
+const grantSystemAdminCommand = "grant-system-admin"
+
+// parseGrantSystemAdminArgs reads the grant system admin subcommand's flags and returns the user's ID
+func parseGrantSystemAdminArgs(args []string, output io.Writer) (string, error) {
+	flags := flag.NewFlagSet(grantSystemAdminCommand, flag.ContinueOnError)
+	flags.SetOutput(output)
+
+	userID := flags.String("user", "", "ID of the user to make the first system admin")
+
+	if err := flags.Parse(args); err != nil {
+		return "", err
+	}
+	if flags.NArg() > 0 {
+		return "", fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	if *userID == "" {
+		return "", fmt.Errorf("the -user flag is required")
+	}
+	return *userID, nil
+}
+
+// grantSystemAdmin runs the grant system admin subcommand
+func grantSystemAdmin(ctx context.Context, args []string, output io.Writer) error {
+	userID, err := parseGrantSystemAdminArgs(args, output)
+	if err != nil {
+		return err
+	}
+
+	pg, err := gorm.NewPGInstance()
+	if err != nil {
+		return fmt.Errorf("failed to initialize new PG instance: %v", err)
+	}
+	db := postgres.NewMyCareHubDb(pg, pg, pg, pg)
+
+	// system admins of any organisation count and the user is looked up in every organisation
+	if _, err := db.AssignFirstSystemAdmin(helpers.ContextForAllOrganisations(ctx), userID); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(output, "user %s is now a system admin\n", userID)
+	return err
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+func Test_parseGrantSystemAdminArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case",
+			args:    []string{"-user", "6ecbbc80-24c8-421a-9f1a-e14e12678ee0"},
+			want:    "6ecbbc80-24c8-421a-9f1a-e14e12678ee0",
+			wantErr: false,
+		},
+		{
+			name:    "Sad case - no user",
+			args:    []string{},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case - unknown flag",
+			args:    []string{"-role", "SYSTEM_ADMIN"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case - unexpected argument",
+			args:    []string{"-user", "6ecbbc80-24c8-421a-9f1a-e14e12678ee0", "now"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGrantSystemAdminArgs(tt.args, io.Discard)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseGrantSystemAdminArgs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseGrantSystemAdminArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/facility"
+)
+
+// importFacilitiesCommand is the subcommand that imports facilities from a Kenya Master Facility List export e.g
+//
+//	go run . import-facilities -file kmfl.csv -user <staff user ID> -dry-run
+const importFacilitiesCommand = "import-facilities"
+
+// facilityImportOptions are the command line options of the import facilities subcommand
+type facilityImportOptions struct {
+	file           string
+	format         enums.FacilityImportFormat
+	userID         string
+	organisationID string
+	dryRun         bool
+}
+
+// parseFacilityImportOptions reads the import facilities subcommand's flags. The format is taken from the
+// file's extension when it is not set.
+func parseFacilityImportOptions(args []string, output io.Writer) (*facilityImportOptions, error) {
+	flags := flag.NewFlagSet(importFacilitiesCommand, flag.ContinueOnError)
+	flags.SetOutput(output)
+
+	file := flags.String("file", "", "path to the KMFL CSV or JSON export")
+	format := flags.String("format", "", "format of the export, CSV or JSON. Defaults to the file's extension")
+	userID := flags.String("user", "", "ID of the user that the facility changes are recorded against")
+	organisationID := flags.String("organisation", "", "ID of the organisation to import the facilities into. Defaults to the default organisation")
+	dryRun := flags.Bool("dry-run", false, "report what would change without saving anything")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if *file == "" {
+		return nil, fmt.Errorf("the -file flag is required")
+	}
+	if *userID == "" {
+		return nil, fmt.Errorf("the -user flag is required")
+	}
+
+	importFormat := enums.FacilityImportFormat(strings.ToUpper(*format))
+	if *format == "" {
+		importFormat = enums.FacilityImportFormat(strings.ToUpper(strings.TrimPrefix(filepath.Ext(*file), ".")))
+	}
+	if !importFormat.IsValid() {
+		return nil, fmt.Errorf("unable to tell the format of %s, set the -format flag to CSV or JSON", *file)
+	}
+
+	return &facilityImportOptions{
+		file:           *file,
+		format:         importFormat,
+		userID:         *userID,
+		organisationID: *organisationID,
+		dryRun:         *dryRun,
+	}, nil
+}
+
+// importFacilities runs the import facilities subcommand and writes the import report to the output as JSON
+func importFacilities(ctx context.Context, args []string, output io.Writer) error {
+	options, err := parseFacilityImportOptions(args, output)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(options.file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", options.file, err)
+	}
+
+	pg, err := gorm.NewPGInstance()
+	if err != nil {
+		return fmt.Errorf("failed to initialize new PG instance: %v", err)
+	}
+	db := postgres.NewMyCareHubDb(pg, pg, pg, pg)
+	facilityUseCase := facility.NewFacilityUsecase(db, db, db, db)
+
+	organisationID := options.organisationID
+	if organisationID == "" {
+		organisationID = gorm.OrganizationID
+	}
+	ctx = helpers.ContextWithIdentity(ctx, &domain.Identity{
+		UserID:         options.userID,
+		OrganisationID: organisationID,
+	})
+
+	report, err := facilityUseCase.ImportFacilities(ctx, string(content), options.format, options.dryRun)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+)
+
+func Test_parseFacilityImportOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantFormat enums.FacilityImportFormat
+		wantDryRun bool
+		wantErr    bool
+	}{
+		{
+			name:       "Happy case - format from the file extension",
+			args:       []string{"-file", "kmfl.csv", "-user", "user-id", "-dry-run"},
+			wantFormat: enums.FacilityImportFormatCSV,
+			wantDryRun: true,
+			wantErr:    false,
+		},
+		{
+			name:       "Happy case - format flag",
+			args:       []string{"-file", "kmfl.txt", "-user", "user-id", "-format", "json"},
+			wantFormat: enums.FacilityImportFormatJSON,
+			wantErr:    false,
+		},
+		{
+			name:    "Sad case - missing file",
+			args:    []string{"-user", "user-id"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case - missing user",
+			args:    []string{"-file", "kmfl.csv"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case - unknown format",
+			args:    []string{"-file", "kmfl.xlsx", "-user", "user-id"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case - unknown flag",
+			args:    []string{"-file", "kmfl.csv", "-user", "user-id", "-force"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFacilityImportOptions(tt.args, io.Discard)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseFacilityImportOptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.format != tt.wantFormat || got.dryRun != tt.wantDryRun {
+				t.Errorf("parseFacilityImportOptions() = %+v, want format %v and dry run %v", got, tt.wantFormat, tt.wantDryRun)
+			}
+		})
+	}
+}
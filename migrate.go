@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
+)
+
+// migrateCommand is the subcommand that applies this service's migrations to the database. It is meant to be run
+// on each deployment, after the CMS's migrations e.g
+//
+//	go run . migrate
+const migrateCommand = "migrate"
+
+// parseMigrateArgs checks that the migrate subcommand was given no flags or arguments
+func parseMigrateArgs(args []string, output io.Writer) error {
+	flags := flag.NewFlagSet(migrateCommand, flag.ContinueOnError)
+	flags.SetOutput(output)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	return nil
+}
+
+// migrate runs the migrate subcommand and writes the names of the migrations it applied to the output
+func migrate(ctx context.Context, args []string, output io.Writer) error {
+	if err := parseMigrateArgs(args, output); err != nil {
+		return err
+	}
+
+	pg, err := gorm.NewPGInstance()
+	if err != nil {
+		return fmt.Errorf("failed to initialize new PG instance: %v", err)
+	}
+
+	// the migrations apply to the tables of every organisation
+	applied, err := pg.Migrate(helpers.ContextForAllOrganisations(ctx))
+	for _, name := range applied {
+		if _, err := fmt.Fprintf(output, "applied %s\n", name); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		_, err = fmt.Fprintln(output, "no migrations to apply")
+	}
+	return err
+}
@@ -7,4 +7,10 @@ const (
 
 	// OrganizationID is the default organization ID that's added to all models on the django side
 	OrganizationID = "DEFAULT_ORG_ID"
+
+	// DateLayout is the format of calendar dates, such as the dates of a facility's hours exceptions e.g 2021-12-25
+	DateLayout = "2006-01-02"
+
+	// TimeOfDayLayout is the format of the times that facilities open and close e.g 08:00
+	TimeOfDayLayout = "15:04"
 )
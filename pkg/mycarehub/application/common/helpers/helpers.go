@@ -5,11 +5,8 @@ import (
 	"crypto/cipher"
 	"encoding/base64"
 	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/savannahghi/feedlib"
-	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/serverutils"
@@ -43,11 +40,19 @@ func GetInviteLink(flavour feedlib.Flavour) (string, error) {
 	}
 }
 
-// CreateInviteMessage creates a new invite message
-func CreateInviteMessage(user *domain.User, inviteLink string, pin string) string {
-	message := fmt.Sprintf("You have been invited to My Afya Hub. Download the app on %v. Your single use pin is %v",
-		inviteLink, pin)
-	return message
+// CreateInviteMessage creates a new invite message in the user's preferred language
+func CreateInviteMessage(user *domain.User, inviteLink string, pin string) (string, error) {
+	return RenderMessage(user, InviteMessage, inviteLink, pin)
+}
+
+// CreateClientTransferMessage creates the message telling a client about the facility they have been transferred to.
+// The facility's county is used when it has no address.
+func CreateClientTransferMessage(user *domain.User, facility *domain.Facility) (string, error) {
+	location := facility.Address
+	if location == "" {
+		location = facility.County
+	}
+	return RenderMessage(user, ClientTransferMessage, facility.Name, location, facility.Phone)
 }
 
 func encode(b []byte) string {
@@ -88,17 +93,6 @@ func DecryptSensitiveData(text, MySecret string) (string, error) {
 	return string(plainText), nil
 }
 
-// GetPinExpiryDate returns the expiry date for the given pin
-func GetPinExpiryDate() (*time.Time, error) {
-	pinExpiryDays := serverutils.MustGetEnvVar("PIN_EXPIRY_DAYS")
-	pinExpiryInt, err := strconv.Atoi(pinExpiryDays)
-	if err != nil {
-		return nil, exceptions.InternalErr(fmt.Errorf("failed to convert PIN expiry days to int: %v", err))
-	}
-	expiryDate := time.Now().AddDate(0, 0, pinExpiryInt)
-
-	return &expiryDate, nil
-}
 // RestAPIResponseHelper returns custom standardised response for frontend response consistency
 func RestAPIResponseHelper(key string, value interface{}) *dto.RestEndpointResponses {
 	response := &dto.RestEndpointResponses{
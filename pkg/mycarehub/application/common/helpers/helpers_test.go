@@ -3,9 +3,9 @@ package helpers
 import (
 	"fmt"
 	"testing"
-	"time"
 
 	"github.com/brianvoe/gofakeit"
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
@@ -71,9 +71,10 @@ func TestCreateInviteMessage(t *testing.T) {
 		pin        string
 	}
 	tests := []struct {
-		name string
-		args args
-		want string
+		name    string
+		args    args
+		want    string
+		wantErr bool
 	}{
 		{
 			name: "default case",
@@ -85,18 +86,76 @@ func TestCreateInviteMessage(t *testing.T) {
 				inviteLink: inviteLink,
 				pin:        pin,
 			},
-			want: want,
+			want:    want,
+			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := CreateInviteMessage(tt.args.user, tt.args.inviteLink, tt.args.pin); got != tt.want {
+			got, err := CreateInviteMessage(tt.args.user, tt.args.inviteLink, tt.args.pin)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateInviteMessage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
 				t.Errorf("CreateInviteMessage() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestCreateClientTransferMessage(t *testing.T) {
+	type args struct {
+		user     *domain.User
+		facility *domain.Facility
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default case",
+			args: args{
+				user: &domain.User{FirstName: "Jon"},
+				facility: &domain.Facility{
+					Name:    "Kanairo Clinic",
+					Phone:   "+254711223344",
+					County:  "Nairobi",
+					Address: "Moi Avenue",
+				},
+			},
+			want: "Your care has been transferred to Kanairo Clinic, Moi Avenue. You can reach the facility on +254711223344",
+		},
+		{
+			name: "facility without an address",
+			args: args{
+				user: &domain.User{
+					Languages: []enumutils.Language{enumutils.LanguageSw},
+				},
+				facility: &domain.Facility{
+					Name:   "Kanairo Clinic",
+					Phone:  "+254711223344",
+					County: "Nairobi",
+				},
+			},
+			want: "Huduma yako imehamishiwa Kanairo Clinic, Nairobi. Unaweza kuwasiliana na kituo kupitia +254711223344",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CreateClientTransferMessage(tt.args.user, tt.args.facility)
+			if err != nil {
+				t.Errorf("CreateClientTransferMessage() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CreateClientTransferMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestEncryptSensitiveData(t *testing.T) {
 
 	text := "texttoencrypt"
@@ -196,28 +255,6 @@ func TestDecryptSensitiveData(t *testing.T) {
 	}
 }
 
-func TestGetPinExpiryDate(t *testing.T) {
-	tests := []struct {
-		name    string
-		want    *time.Time
-		wantErr bool
-	}{
-		{
-			name:    "default case",
-			wantErr: false,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			_, err := GetPinExpiryDate()
-			if (err != nil) != tt.wantErr {
-				t.Errorf("GetPinExpiryDate() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-		})
-	}
-}
-
 func TestRestAPIResponseHelper(t *testing.T) {
 	type args struct {
 		key   string
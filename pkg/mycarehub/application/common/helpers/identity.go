@@ -0,0 +1,43 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+// identityContextKey is the key under which the identity of the logged in user is stored on a request's context
+type identityContextKey struct{}
+
+// ContextWithIdentity returns a copy of the context that carries the identity of the logged in user
+func ContextWithIdentity(ctx context.Context, identity *domain.Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// GetIdentityFromContext returns the identity of the logged in user that was placed on the context
+// by the identity middleware
+func GetIdentityFromContext(ctx context.Context) (*domain.Identity, error) {
+	identity, ok := ctx.Value(identityContextKey{}).(*domain.Identity)
+	if !ok || identity == nil {
+		return nil, fmt.Errorf("no identity found on the context")
+	}
+	return identity, nil
+}
+
+// allOrganisationsContextKey marks a context whose database operations are not restricted to one organisation
+type allOrganisationsContextKey struct{}
+
+// ContextForAllOrganisations returns a copy of the context whose database operations span every organisation.
+// It is only meant for work that is not done on behalf of a logged in user e.g the command line tools, the jobs
+// run when the service starts and the routes used to log in. Any other context without an identity is refused
+// by the database.
+func ContextForAllOrganisations(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allOrganisationsContextKey{}, true)
+}
+
+// IsForAllOrganisations returns true if the context was marked with ContextForAllOrganisations
+func IsForAllOrganisations(ctx context.Context) bool {
+	forAll, _ := ctx.Value(allOrganisationsContextKey{}).(bool)
+	return forAll
+}
@@ -0,0 +1,106 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+func TestGetIdentityFromContext(t *testing.T) {
+	identity := &domain.Identity{
+		UID:      uuid.New().String(),
+		UserID:   uuid.New().String(),
+		UserType: enums.ClientUser,
+		ClientID: uuid.New().String(),
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *domain.Identity
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx: ContextWithIdentity(context.Background(), identity),
+			},
+			want:    identity,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no identity on the context",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - nil identity on the context",
+			args: args{
+				ctx: ContextWithIdentity(context.Background(), nil),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetIdentityFromContext(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetIdentityFromContext() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetIdentityFromContext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsForAllOrganisations(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "Happy case - context for all organisations",
+			args: args{
+				ctx: ContextForAllOrganisations(context.Background()),
+			},
+			want: true,
+		},
+		{
+			name: "Sad case - unmarked context",
+			args: args{
+				ctx: context.Background(),
+			},
+			want: false,
+		},
+		{
+			name: "Sad case - context with only an identity",
+			args: args{
+				ctx: ContextWithIdentity(context.Background(), &domain.Identity{UserID: uuid.New().String()}),
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsForAllOrganisations(tt.args.ctx); got != tt.want {
+				t.Errorf("IsForAllOrganisations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
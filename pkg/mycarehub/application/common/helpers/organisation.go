@@ -0,0 +1,40 @@
+package helpers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/serverutils"
+)
+
+const (
+	// DefaultDiaryCadenceHours is how often, in hours, a client may record a health diary entry
+	// when their organisation has not configured a cadence
+	DefaultDiaryCadenceHours = 24
+
+	// DefaultSMSSenderID is the sender ID used for SMS messages when an organisation has not configured one
+	DefaultSMSSenderID = enumutils.SenderIDBewell
+)
+
+// DefaultOrganisationSettings returns the platform wide settings that apply to an organisation
+// which has not overridden them
+func DefaultOrganisationSettings() (*domain.OrganisationSettings, error) {
+	pinExpiryDays, err := strconv.Atoi(serverutils.MustGetEnvVar("PIN_EXPIRY_DAYS"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert PIN expiry days to int: %v", err)
+	}
+
+	invitePINExpiryDays, err := strconv.Atoi(serverutils.MustGetEnvVar("INVITE_PIN_EXPIRY_DAYS"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert invite PIN expiry days to int: %v", err)
+	}
+
+	return &domain.OrganisationSettings{
+		PINExpiryDays:       pinExpiryDays,
+		InvitePINExpiryDays: invitePINExpiryDays,
+		DiaryCadenceHours:   DefaultDiaryCadenceHours,
+		SMSSenderID:         DefaultSMSSenderID,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package helpers
+
+import (
+	"testing"
+)
+
+func TestDefaultOrganisationSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		pinExpiryDays string
+		inviteDays    string
+		wantErr       bool
+	}{
+		{
+			name:          "Happy case",
+			pinExpiryDays: "30",
+			inviteDays:    "7",
+		},
+		{
+			name:          "Sad case - invalid PIN expiry days",
+			pinExpiryDays: "thirty",
+			inviteDays:    "7",
+			wantErr:       true,
+		},
+		{
+			name:          "Sad case - invalid invite PIN expiry days",
+			pinExpiryDays: "30",
+			inviteDays:    "seven",
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PIN_EXPIRY_DAYS", tt.pinExpiryDays)
+			t.Setenv("INVITE_PIN_EXPIRY_DAYS", tt.inviteDays)
+
+			got, err := DefaultOrganisationSettings()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DefaultOrganisationSettings() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.PINExpiryDays != 30 || got.InvitePINExpiryDays != 7 {
+				t.Errorf("DefaultOrganisationSettings() = %+v, expected the PIN expiry days from the environment", got)
+			}
+			if got.DiaryCadenceHours != DefaultDiaryCadenceHours || got.SMSSenderID != DefaultSMSSenderID {
+				t.Errorf("DefaultOrganisationSettings() = %+v, expected the default cadence and sender ID", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package helpers
+
+import (
+	"fmt"
+
+	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+// MessageType identifies the kind of SMS message sent to a user
+type MessageType string
+
+const (
+	// OTPMessage is the message carrying a phone verification code
+	OTPMessage MessageType = "OTP"
+
+	// InviteMessage is the message that invites a user to the app together with their single use PIN
+	InviteMessage MessageType = "INVITE"
+
+	// PINResetMessage is the message carrying the verification code used to reset a PIN
+	PINResetMessage MessageType = "PIN_RESET"
+
+	// ClientTransferMessage tells a client which facility they have been transferred to and how to reach it
+	ClientTransferMessage MessageType = "CLIENT_TRANSFER"
+)
+
+// DefaultLanguage is the language used when a user has no preferred language or
+// when a template has not been translated to the user's preferred language
+const DefaultLanguage = enumutils.LanguageEn
+
+// messageTemplates holds the format strings of each message type in every supported language.
+// Indexed verbs are used so that translations can re-order the arguments.
+var messageTemplates = map[MessageType]map[enumutils.Language]string{
+	OTPMessage: {
+		enumutils.LanguageEn: "%[1]v is your MyCareHub verification code",
+		enumutils.LanguageSw: "%[1]v ni nambari yako ya uthibitisho ya MyCareHub",
+	},
+	InviteMessage: {
+		enumutils.LanguageEn: "You have been invited to My Afya Hub. Download the app on %[1]v. Your single use pin is %[2]v",
+		enumutils.LanguageSw: "Umealikwa kujiunga na My Afya Hub. Pakua programu kupitia %[1]v. PIN yako ya matumizi moja ni %[2]v",
+	},
+	PINResetMessage: {
+		enumutils.LanguageEn: "%[1]v is your MyCareHub PIN reset verification code",
+		enumutils.LanguageSw: "%[1]v ni nambari yako ya uthibitisho ya kubadilisha PIN ya MyCareHub",
+	},
+	ClientTransferMessage: {
+		enumutils.LanguageEn: "Your care has been transferred to %[1]v, %[2]v. You can reach the facility on %[3]v",
+		enumutils.LanguageSw: "Huduma yako imehamishiwa %[1]v, %[2]v. Unaweza kuwasiliana na kituo kupitia %[3]v",
+	},
+}
+
+// GetPreferredLanguage returns the first valid language in a user's ordered list of languages.
+// It falls back to English when the user has not set any language.
+func GetPreferredLanguage(user *domain.User) enumutils.Language {
+	if user == nil {
+		return DefaultLanguage
+	}
+	for _, language := range user.Languages {
+		if language.IsValid() {
+			return language
+		}
+	}
+	return DefaultLanguage
+}
+
+// GetMessageTemplate returns the format string of a message type in the requested language.
+// English is returned when there is no translation for the requested language.
+func GetMessageTemplate(messageType MessageType, language enumutils.Language) (string, error) {
+	templates, ok := messageTemplates[messageType]
+	if !ok {
+		return "", fmt.Errorf("no message template found for message type: %v", messageType)
+	}
+	if template, ok := templates[language]; ok {
+		return template, nil
+	}
+	return templates[DefaultLanguage], nil
+}
+
+// RenderMessage renders a message of the given type in the user's preferred language
+func RenderMessage(user *domain.User, messageType MessageType, args ...interface{}) (string, error) {
+	template, err := GetMessageTemplate(messageType, GetPreferredLanguage(user))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(template, args...), nil
+}
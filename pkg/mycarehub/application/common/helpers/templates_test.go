@@ -0,0 +1,168 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+func TestGetPreferredLanguage(t *testing.T) {
+	type args struct {
+		user *domain.User
+	}
+	tests := []struct {
+		name string
+		args args
+		want enumutils.Language
+	}{
+		{
+			name: "Happy case - preferred language is set",
+			args: args{
+				user: &domain.User{
+					Languages: []enumutils.Language{enumutils.LanguageSw, enumutils.LanguageEn},
+				},
+			},
+			want: enumutils.LanguageSw,
+		},
+		{
+			name: "Happy case - no language set",
+			args: args{
+				user: &domain.User{},
+			},
+			want: enumutils.LanguageEn,
+		},
+		{
+			name: "Happy case - invalid languages are skipped",
+			args: args{
+				user: &domain.User{
+					Languages: []enumutils.Language{"fr", enumutils.LanguageSw},
+				},
+			},
+			want: enumutils.LanguageSw,
+		},
+		{
+			name: "Happy case - nil user",
+			args: args{
+				user: nil,
+			},
+			want: enumutils.LanguageEn,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetPreferredLanguage(tt.args.user); got != tt.want {
+				t.Errorf("GetPreferredLanguage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetMessageTemplate(t *testing.T) {
+	type args struct {
+		messageType MessageType
+		language    enumutils.Language
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Happy case - swahili template",
+			args: args{
+				messageType: OTPMessage,
+				language:    enumutils.LanguageSw,
+			},
+			want:    "%[1]v ni nambari yako ya uthibitisho ya MyCareHub",
+			wantErr: false,
+		},
+		{
+			name: "Happy case - falls back to english",
+			args: args{
+				messageType: PINResetMessage,
+				language:    enumutils.Language("fr"),
+			},
+			want:    "%[1]v is your MyCareHub PIN reset verification code",
+			wantErr: false,
+		},
+		{
+			name: "Sad case - unknown message type",
+			args: args{
+				messageType: MessageType("UNKNOWN"),
+				language:    enumutils.LanguageEn,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetMessageTemplate(tt.args.messageType, tt.args.language)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetMessageTemplate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetMessageTemplate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMessage(t *testing.T) {
+	type args struct {
+		user        *domain.User
+		messageType MessageType
+		args        []interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Happy case - english invite",
+			args: args{
+				user:        &domain.User{},
+				messageType: InviteMessage,
+				args:        []interface{}{"https://example.com", "1234"},
+			},
+			want:    "You have been invited to My Afya Hub. Download the app on https://example.com. Your single use pin is 1234",
+			wantErr: false,
+		},
+		{
+			name: "Happy case - swahili otp",
+			args: args{
+				user: &domain.User{
+					Languages: []enumutils.Language{enumutils.LanguageSw},
+				},
+				messageType: OTPMessage,
+				args:        []interface{}{"1234"},
+			},
+			want:    "1234 ni nambari yako ya uthibitisho ya MyCareHub",
+			wantErr: false,
+		},
+		{
+			name: "Sad case - unknown message type",
+			args: args{
+				user:        &domain.User{},
+				messageType: MessageType("UNKNOWN"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderMessage(tt.args.user, tt.args.messageType, tt.args.args...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RenderMessage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("RenderMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,12 @@
 package dto
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"gopkg.in/go-playground/validator.v9"
 )
@@ -14,6 +19,10 @@ type FacilityInput struct {
 	Active      bool   `json:"active"`
 	County      string `json:"county" validate:"required"`
 	Description string `json:"description" validate:"required,min=3,max=256"`
+
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+	Address   string   `json:"address" validate:"max=256"`
 }
 
 // Validate helps with validation of facility input fields
@@ -21,10 +30,231 @@ func (f *FacilityInput) Validate() error {
 	v := validator.New()
 
 	err := v.Struct(f)
+	if err != nil {
+		return err
+	}
+
+	if !enums.CountyType(f.County).IsValid() {
+		return fmt.Errorf("invalid county: %v", f.County)
+	}
+	return validateCoordinates(f.Latitude, f.Longitude)
+}
+
+// validateCoordinates checks that a location has both a latitude and a longitude, or neither, and that they are in range
+func validateCoordinates(latitude *float64, longitude *float64) error {
+	if latitude == nil && longitude == nil {
+		return nil
+	}
+	if latitude == nil || longitude == nil {
+		return fmt.Errorf("both the latitude and longitude must be provided")
+	}
+	if *latitude < -90 || *latitude > 90 {
+		return fmt.Errorf("invalid latitude %v, it must be between -90 and 90", *latitude)
+	}
+	if *longitude < -180 || *longitude > 180 {
+		return fmt.Errorf("invalid longitude %v, it must be between -180 and 180", *longitude)
+	}
+	return nil
+}
+
+// FacilityUpdateInput defines the facility details that can be changed.
+// Only the fields that are set are updated.
+type FacilityUpdateInput struct {
+	Name        *string           `json:"name" validate:"omitempty,min=3,max=100"`
+	Phone       *string           `json:"phone"`
+	County      *enums.CountyType `json:"county"`
+	Description *string           `json:"description" validate:"omitempty,min=3,max=256"`
+	Latitude    *float64          `json:"latitude"`
+	Longitude   *float64          `json:"longitude"`
+	Address     *string           `json:"address" validate:"omitempty,max=256"`
+	Active      *bool             `json:"active"`
+}
+
+// Validate helps with validation of FacilityUpdateInput fields
+func (f *FacilityUpdateInput) Validate() error {
+	if f.Name == nil && f.Phone == nil && f.County == nil && f.Description == nil &&
+		f.Latitude == nil && f.Longitude == nil && f.Address == nil && f.Active == nil {
+		return fmt.Errorf("at least one facility field must be provided")
+	}
+	if err := validator.New().Struct(f); err != nil {
+		return err
+	}
+	if f.Name != nil && *f.Name == "" {
+		return fmt.Errorf("facility name cannot be empty")
+	}
+	if f.Description != nil && *f.Description == "" {
+		return fmt.Errorf("facility description cannot be empty")
+	}
+	if f.Phone != nil && *f.Phone == "" {
+		return fmt.Errorf("facility phone cannot be empty")
+	}
+	if f.County != nil && !f.County.IsValid() {
+		return fmt.Errorf("invalid county: %v", *f.County)
+	}
+	return validateCoordinates(f.Latitude, f.Longitude)
+}
+
+// NearbyFacilitiesInput defines the location to search for facilities around and how the facilities found are returned
+type NearbyFacilitiesInput struct {
+	Latitude    float64         `json:"latitude"`
+	Longitude   float64         `json:"longitude"`
+	RadiusKm    float64         `json:"radiusKm" validate:"gt=0"`
+	Limit       int             `json:"limit" validate:"min=1,max=100"`
+	FilterInput []*FiltersInput `json:"filterInput"`
+	Sort        *SortsInput     `json:"sort"`
+}
+
+// Validate helps with validation of NearbyFacilitiesInput fields
+func (n *NearbyFacilitiesInput) Validate() error {
+	if err := validator.New().Struct(n); err != nil {
+		return err
+	}
+	if err := validateCoordinates(&n.Latitude, &n.Longitude); err != nil {
+		return err
+	}
+	for _, filter := range n.FilterInput {
+		if err := filter.Validate(); err != nil {
+			return err
+		}
+		if err := enums.ValidateFilterSortCategories(enums.FilterSortCategoryTypeFacility, filter.DataType); err != nil {
+			return fmt.Errorf("filter %v is not available for facilities: %v", filter.DataType, err)
+		}
+	}
+	if n.Sort != nil {
+		if err := enums.ValidateFilterSortCategories(enums.FilterSortCategoryTypeSortNearbyFacility, n.Sort.Field); err != nil {
+			return fmt.Errorf("facilities cannot be sorted by %v: %v", n.Sort.Field, err)
+		}
+		if n.Sort.Direction != "" && !n.Sort.Direction.IsValid() {
+			return fmt.Errorf("invalid sort direction: %v", n.Sort.Direction)
+		}
+	}
+	return nil
+}
+
+// FacilityOpeningHoursInput defines the hours that a facility is open on a day of the week.
+// The times are in Kenyan time e.g 08:00 to 17:00 and a facility that never closes is open from 00:00 to 24:00
+type FacilityOpeningHoursInput struct {
+	DayOfWeek enums.DayOfWeek `json:"dayOfWeek" validate:"required"`
+	OpensAt   string          `json:"opensAt" validate:"required"`
+	ClosesAt  string          `json:"closesAt" validate:"required"`
+}
+
+// Validate helps with validation of FacilityOpeningHoursInput fields
+func (h *FacilityOpeningHoursInput) Validate() error {
+	if err := validator.New().Struct(h); err != nil {
+		return err
+	}
+	if !h.DayOfWeek.IsValid() {
+		return fmt.Errorf("invalid day of the week: %v", h.DayOfWeek)
+	}
+	_, _, err := openingMinutes(h.OpensAt, h.ClosesAt)
+	return err
+}
+
+// ValidateFacilityOpeningHours validates a facility's weekly opening hours. A facility can open more than once
+// on the same day, e.g when it closes for lunch, as long as the opening hours do not overlap.
+func ValidateFacilityOpeningHours(openingHours []*FacilityOpeningHoursInput) error {
+	type period struct{ opens, closes int }
+	days := map[enums.DayOfWeek][]period{}
+	for _, hours := range openingHours {
+		if hours == nil {
+			return fmt.Errorf("opening hours cannot be empty")
+		}
+		if err := hours.Validate(); err != nil {
+			return err
+		}
+		opens, closes, _ := openingMinutes(hours.OpensAt, hours.ClosesAt)
+		for _, p := range days[hours.DayOfWeek] {
+			if opens < p.closes && p.opens < closes {
+				return fmt.Errorf("the opening hours on %v overlap", hours.DayOfWeek)
+			}
+		}
+		days[hours.DayOfWeek] = append(days[hours.DayOfWeek], period{opens: opens, closes: closes})
+	}
+	return nil
+}
+
+// openingMinutes converts a facility's opening and closing times to minutes after midnight.
+// It fails when the times are not in the HH:MM format or when the facility does not close after it opens.
+func openingMinutes(opensAt, closesAt string) (int, int, error) {
+	opens, err := minutesAfterMidnight(opensAt)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid opening time %v: %v", opensAt, err)
+	}
+	closes := 24 * 60
+	if closesAt != "24:00" {
+		closes, err = minutesAfterMidnight(closesAt)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid closing time %v: %v", closesAt, err)
+		}
+	}
+	if closes <= opens {
+		return 0, 0, fmt.Errorf("the closing time %v must be after the opening time %v", closesAt, opensAt)
+	}
+	return opens, closes, nil
+}
+
+// minutesAfterMidnight parses a HH:MM time of day
+func minutesAfterMidnight(value string) (int, error) {
+	t, err := time.Parse(common.TimeOfDayLayout, value)
+	if err != nil || len(value) != len(common.TimeOfDayLayout) {
+		return 0, fmt.Errorf("the time must be in the HH:MM format")
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// FacilityHoursExceptionInput defines a change to a facility's opening hours on a date, e.g 2021-12-25.
+// A facility that is not closed must have its opening and closing times for the day.
+type FacilityHoursExceptionInput struct {
+	Date     string  `json:"date" validate:"required"`
+	Closed   bool    `json:"closed"`
+	OpensAt  *string `json:"opensAt"`
+	ClosesAt *string `json:"closesAt"`
+	Reason   string  `json:"reason" validate:"max=256"`
+}
 
+// Validate helps with validation of FacilityHoursExceptionInput fields
+func (e *FacilityHoursExceptionInput) Validate() error {
+	if err := validator.New().Struct(e); err != nil {
+		return err
+	}
+	if _, err := time.Parse(common.DateLayout, e.Date); err != nil {
+		return fmt.Errorf("invalid date %v, it must be in the YYYY-MM-DD format", e.Date)
+	}
+	if e.Closed {
+		if e.OpensAt != nil || e.ClosesAt != nil {
+			return fmt.Errorf("a facility that is closed cannot have opening hours")
+		}
+		return nil
+	}
+	if e.OpensAt == nil || e.ClosesAt == nil {
+		return fmt.Errorf("both the opening and closing times must be provided when the facility is open")
+	}
+	_, _, err := openingMinutes(*e.OpensAt, *e.ClosesAt)
 	return err
 }
 
+// FacilityServiceInput defines a service that facilities can offer. The code is used to refer to the service
+// e.g when filtering facilities and is made of capital letters, digits and underscores e.g ART_REFILL
+type FacilityServiceInput struct {
+	Name        string `json:"name" validate:"required,min=3,max=100"`
+	Code        string `json:"code" validate:"required,max=50"`
+	Description string `json:"description" validate:"max=256"`
+}
+
+// Validate helps with validation of FacilityServiceInput fields
+func (s *FacilityServiceInput) Validate() error {
+	if err := validator.New().Struct(s); err != nil {
+		return err
+	}
+	for _, r := range s.Code {
+		if !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && r != '_' {
+			return fmt.Errorf("invalid service code %v, it can only contain capital letters, digits and underscores", s.Code)
+		}
+	}
+	return nil
+}
+
 // PaginationsInput contains fields required for pagination
 type PaginationsInput struct {
 	Limit       int        `json:"limit"`
@@ -56,6 +286,22 @@ func (f *FiltersInput) Validate() error {
 	return err
 }
 
+// FilterGroupInput is a set of filters combined with an operator. Filter groups are combined with each other,
+// and with any other filters, using AND
+type FilterGroupInput struct {
+	Operator enums.FilterOperator `json:"operator" validate:"required"`
+	Filters  []*FiltersInput      `json:"filters" validate:"required,min=1,dive,required"`
+}
+
+// Validate helps with validation of FilterGroupInput fields
+func (f *FilterGroupInput) Validate() error {
+	v := validator.New()
+
+	err := v.Struct(f)
+
+	return err
+}
+
 // SortsInput includes the fields required for sorting the different types of fields
 type SortsInput struct {
 	Direction enums.SortDataType       `json:"direction"`
@@ -195,18 +441,22 @@ func (f *UserResetPinInput) Validate() error {
 
 // ShareContentInput defines the field passed when sharing content
 type ShareContentInput struct {
-	UserID    string `json:"userID" validate:"required"`
-	ContentID int    `json:"contentID" validate:"required"`
-	Channel   string `json:"channel" validate:"required"`
+	UserID    string                    `json:"userID" validate:"required"`
+	ContentID int                       `json:"contentID" validate:"required"`
+	Channel   enums.ContentShareChannel `json:"channel" validate:"required"`
 }
 
 // Validate helps with validation of ShareContentInput fields
 func (f *ShareContentInput) Validate() error {
 	v := validator.New()
 
-	err := v.Struct(f)
-
-	return err
+	if err := v.Struct(f); err != nil {
+		return err
+	}
+	if !f.Channel.IsValid() {
+		return fmt.Errorf("invalid share channel: %v", f.Channel)
+	}
+	return nil
 }
 
 // RefreshTokenPayload is used when calling the REST API to
@@ -215,6 +465,62 @@ type RefreshTokenPayload struct {
 	UserID *string `json:"userID"`
 }
 
+// ContentSearchInput defines the text to search content for and how the content searched is narrowed down.
+// English searches match other forms of the same word e.g "effects" matches "effect" while Swahili searches
+// only match whole words.
+type ContentSearchInput struct {
+	Query       string             `json:"query" validate:"required,max=200"`
+	CategoryIDs []int              `json:"categoryIDs" validate:"dive,gt=0"`
+	ItemType    string             `json:"itemType"`
+	Language    enumutils.Language `json:"language"`
+}
+
+// Validate helps with validation of ContentSearchInput fields
+func (c *ContentSearchInput) Validate() error {
+	if err := validator.New().Struct(c); err != nil {
+		return err
+	}
+	if !c.Language.IsValid() {
+		return fmt.Errorf("invalid language: %v", c.Language)
+	}
+	return nil
+}
+
+// maxContentAnalyticsPeriod is the longest period that content analytics can be reported for at once
+const maxContentAnalyticsPeriod = 366 * 24 * time.Hour
+
+// ContentAnalyticsInput is the period, and optionally the categories, that content analytics are reported for.
+// The period starts at From and ends before To.
+type ContentAnalyticsInput struct {
+	From        time.Time `json:"from" validate:"required"`
+	To          time.Time `json:"to" validate:"required"`
+	CategoryIDs []int     `json:"categoryIDs" validate:"dive,gt=0"`
+}
+
+// Validate helps with validation of ContentAnalyticsInput fields
+func (c *ContentAnalyticsInput) Validate() error {
+	if err := validator.New().Struct(c); err != nil {
+		return err
+	}
+	if !c.To.After(c.From) {
+		return fmt.Errorf("the period must start before it ends")
+	}
+	if c.To.Sub(c.From) > maxContentAnalyticsPeriod {
+		return fmt.Errorf("the period cannot be longer than %v days", maxContentAnalyticsPeriod.Hours()/24)
+	}
+	return nil
+}
+
+// ContentWebhookPayload is sent by the CMS when a content page is published, unpublished or deleted
+type ContentWebhookPayload struct {
+	ContentID int `json:"contentID"`
+}
+
+// OpenSharedContentPayload is sent by the public web view when someone opens a shared content link
+type OpenSharedContentPayload struct {
+	Token string `json:"token"`
+}
+
 // FeedbackResponseInput defines the field passed when sending feedback
 type FeedbackResponseInput struct {
 	UserID           string
@@ -228,3 +534,163 @@ type FeedbackEmail struct {
 	Message          string
 	RequiresFollowUp string
 }
+
+// ClientRegistrationInput defines the fields passed when a staff member registers a client
+type ClientRegistrationInput struct {
+	Username       string           `json:"username" validate:"required,min=3,max=150"`
+	FirstName      string           `json:"firstName" validate:"required"`
+	MiddleName     string           `json:"middleName"`
+	LastName       string           `json:"lastName" validate:"required"`
+	Gender         enumutils.Gender `json:"gender" validate:"required"`
+	PhoneNumber    string           `json:"phoneNumber" validate:"required"`
+	FacilityID     string           `json:"facilityID" validate:"required"`
+	ClientType     string           `json:"clientType" validate:"required"`
+	EnrollmentDate *time.Time       `json:"enrollmentDate"`
+	Counselled     bool             `json:"counselled"`
+}
+
+// Validate helps with validation of ClientRegistrationInput fields
+func (f *ClientRegistrationInput) Validate() error {
+	v := validator.New()
+
+	err := v.Struct(f)
+
+	return err
+}
+
+// StaffRegistrationInput defines the fields passed when registering a staff member
+type StaffRegistrationInput struct {
+	Username    string           `json:"username" validate:"required,min=3,max=150"`
+	FirstName   string           `json:"firstName" validate:"required"`
+	MiddleName  string           `json:"middleName"`
+	LastName    string           `json:"lastName" validate:"required"`
+	Gender      enumutils.Gender `json:"gender" validate:"required"`
+	PhoneNumber string           `json:"phoneNumber" validate:"required"`
+	FacilityID  string           `json:"facilityID" validate:"required"`
+	StaffNumber string           `json:"staffNumber" validate:"required"`
+	Cadre       enums.StaffCadre `json:"cadre" validate:"required"`
+}
+
+// Validate helps with validation of StaffRegistrationInput fields
+func (f *StaffRegistrationInput) Validate() error {
+	v := validator.New()
+
+	err := v.Struct(f)
+
+	return err
+}
+
+// StaffProfileUpdateInput defines the staff profile fields that can be changed.
+// Only the fields that are set are updated.
+type StaffProfileUpdateInput struct {
+	StaffNumber       *string           `json:"staffNumber"`
+	Cadre             *enums.StaffCadre `json:"cadre"`
+	DefaultFacilityID *string           `json:"defaultFacilityID"`
+	Active            *bool             `json:"active"`
+}
+
+// Validate helps with validation of StaffProfileUpdateInput fields
+func (f *StaffProfileUpdateInput) Validate() error {
+	if f.StaffNumber == nil && f.Cadre == nil && f.DefaultFacilityID == nil && f.Active == nil {
+		return fmt.Errorf("at least one staff profile field must be provided")
+	}
+	if f.StaffNumber != nil && *f.StaffNumber == "" {
+		return fmt.Errorf("staff number cannot be empty")
+	}
+	if f.Cadre != nil && !f.Cadre.IsValid() {
+		return fmt.Errorf("invalid staff cadre: %v", *f.Cadre)
+	}
+	if f.DefaultFacilityID != nil && *f.DefaultFacilityID == "" {
+		return fmt.Errorf("default facility ID cannot be empty")
+	}
+	return nil
+}
+
+// OrganisationSettingsInput defines the organisation settings that can be set.
+// Settings that are not set fall back to the platform defaults.
+type OrganisationSettingsInput struct {
+	PINExpiryDays       *int                `json:"pinExpiryDays"`
+	InvitePINExpiryDays *int                `json:"invitePINExpiryDays"`
+	DiaryCadenceHours   *int                `json:"diaryCadenceHours"`
+	SMSSenderID         *enumutils.SenderID `json:"smsSenderID"`
+}
+
+// Validate helps with validation of OrganisationSettingsInput fields
+func (f *OrganisationSettingsInput) Validate() error {
+	if f.PINExpiryDays != nil && *f.PINExpiryDays <= 0 {
+		return fmt.Errorf("PIN expiry days must be greater than zero")
+	}
+	if f.InvitePINExpiryDays != nil && *f.InvitePINExpiryDays <= 0 {
+		return fmt.Errorf("invite PIN expiry days must be greater than zero")
+	}
+	if f.DiaryCadenceHours != nil && *f.DiaryCadenceHours <= 0 {
+		return fmt.Errorf("diary cadence hours must be greater than zero")
+	}
+	if f.SMSSenderID != nil && !f.SMSSenderID.IsValid() {
+		return fmt.Errorf("invalid SMS sender ID: %v", *f.SMSSenderID)
+	}
+	return nil
+}
+
+// OrganisationInput defines the fields passed when creating an organisation
+type OrganisationInput struct {
+	Name            string                     `json:"name" validate:"required"`
+	OrgCode         string                     `json:"orgCode" validate:"required"`
+	Code            int                        `json:"code" validate:"required"`
+	EmailAddress    string                     `json:"emailAddress" validate:"required,email"`
+	PhoneNumber     string                     `json:"phoneNumber" validate:"required"`
+	PostalAddress   string                     `json:"postalAddress"`
+	PhysicalAddress string                     `json:"physicalAddress"`
+	DefaultCountry  string                     `json:"defaultCountry" validate:"required"`
+	Settings        *OrganisationSettingsInput `json:"settings"`
+}
+
+// Validate helps with validation of OrganisationInput fields
+func (f *OrganisationInput) Validate() error {
+	v := validator.New()
+	if err := v.Struct(f); err != nil {
+		return err
+	}
+	if f.Settings != nil {
+		return f.Settings.Validate()
+	}
+	return nil
+}
+
+// OrganisationUpdateInput defines the organisation fields that can be changed.
+// Only the fields that are set are updated.
+type OrganisationUpdateInput struct {
+	Name            *string                    `json:"name"`
+	EmailAddress    *string                    `json:"emailAddress"`
+	PhoneNumber     *string                    `json:"phoneNumber"`
+	PostalAddress   *string                    `json:"postalAddress"`
+	PhysicalAddress *string                    `json:"physicalAddress"`
+	DefaultCountry  *string                    `json:"defaultCountry"`
+	Settings        *OrganisationSettingsInput `json:"settings"`
+}
+
+// Validate helps with validation of OrganisationUpdateInput fields
+func (f *OrganisationUpdateInput) Validate() error {
+	if f.Name == nil && f.EmailAddress == nil && f.PhoneNumber == nil && f.PostalAddress == nil &&
+		f.PhysicalAddress == nil && f.DefaultCountry == nil && f.Settings == nil {
+		return fmt.Errorf("at least one organisation field must be provided")
+	}
+	if f.Name != nil && *f.Name == "" {
+		return fmt.Errorf("organisation name cannot be empty")
+	}
+	if f.EmailAddress != nil {
+		if err := validator.New().Var(*f.EmailAddress, "required,email"); err != nil {
+			return fmt.Errorf("invalid email address: %v", err)
+		}
+	}
+	if f.PhoneNumber != nil && *f.PhoneNumber == "" {
+		return fmt.Errorf("phone number cannot be empty")
+	}
+	if f.DefaultCountry != nil && *f.DefaultCountry == "" {
+		return fmt.Errorf("default country cannot be empty")
+	}
+	if f.Settings != nil {
+		return f.Settings.Validate()
+	}
+	return nil
+}
@@ -1,9 +1,12 @@
 package dto
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
@@ -21,7 +24,12 @@ func TestFacilityInput_Validate(t *testing.T) {
 		Active      bool
 		County      string
 		Description string
+		Latitude    *float64
+		Longitude   *float64
 	}
+	latitude := -1.2921
+	longitude := 36.8219
+	invalidLatitude := 91.0
 	tests := []struct {
 		name    string
 		fields  fields
@@ -142,6 +150,59 @@ func TestFacilityInput_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid: unknown county",
+			fields: fields{
+				Name:        "test name",
+				Code:        22344,
+				Phone:       interserviceclient.TestUserPhoneNumber,
+				Active:      true,
+				County:      "Gotham",
+				Description: "test description",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid: with coordinates",
+			fields: fields{
+				Name:        "test name",
+				Code:        22344,
+				Phone:       interserviceclient.TestUserPhoneNumber,
+				Active:      true,
+				County:      "Nairobi",
+				Description: "test description",
+				Latitude:    &latitude,
+				Longitude:   &longitude,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid: latitude without longitude",
+			fields: fields{
+				Name:        "test name",
+				Code:        22344,
+				Phone:       interserviceclient.TestUserPhoneNumber,
+				Active:      true,
+				County:      "Nairobi",
+				Description: "test description",
+				Latitude:    &latitude,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: latitude out of range",
+			fields: fields{
+				Name:        "test name",
+				Code:        22344,
+				Phone:       interserviceclient.TestUserPhoneNumber,
+				Active:      true,
+				County:      "Nairobi",
+				Description: "test description",
+				Latitude:    &invalidLatitude,
+				Longitude:   &longitude,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -152,6 +213,8 @@ func TestFacilityInput_Validate(t *testing.T) {
 				Active:      tt.fields.Active,
 				County:      tt.fields.County,
 				Description: tt.fields.Description,
+				Latitude:    tt.fields.Latitude,
+				Longitude:   tt.fields.Longitude,
 			}
 			if err := f.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("FacilityInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -584,7 +647,7 @@ func TestShareContentInput_Validate(t *testing.T) {
 	type fields struct {
 		UserID    string
 		ContentID int
-		Channel   string
+		Channel   enums.ContentShareChannel
 	}
 	tests := []struct {
 		name    string
@@ -596,7 +659,7 @@ func TestShareContentInput_Validate(t *testing.T) {
 			fields: fields{
 				UserID:    "123",
 				ContentID: 123,
-				Channel:   "123",
+				Channel:   enums.ContentShareChannelWhatsApp,
 			},
 		},
 		{
@@ -607,6 +670,15 @@ func TestShareContentInput_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid: unknown share channel",
+			fields: fields{
+				UserID:    "123",
+				ContentID: 123,
+				Channel:   enums.ContentShareChannel("TELEGRAM"),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -622,3 +694,728 @@ func TestShareContentInput_Validate(t *testing.T) {
 	}
 }
 
+
+func TestClientRegistrationInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   ClientRegistrationInput
+		wantErr bool
+	}{
+		{
+			name: "valid: all required params passed",
+			input: ClientRegistrationInput{
+				Username:    gofakeit.Username(),
+				FirstName:   gofakeit.FirstName(),
+				LastName:    gofakeit.LastName(),
+				Gender:      enumutils.GenderFemale,
+				PhoneNumber: interserviceclient.TestUserPhoneNumber,
+				FacilityID:  ksuid.New().String(),
+				ClientType:  "PMTCT",
+			},
+		},
+		{
+			name: "invalid: short username",
+			input: ClientRegistrationInput{
+				Username:    "ab",
+				FirstName:   gofakeit.FirstName(),
+				LastName:    gofakeit.LastName(),
+				Gender:      enumutils.GenderFemale,
+				PhoneNumber: interserviceclient.TestUserPhoneNumber,
+				FacilityID:  ksuid.New().String(),
+				ClientType:  "PMTCT",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: missing facility",
+			input: ClientRegistrationInput{
+				Username:    gofakeit.Username(),
+				FirstName:   gofakeit.FirstName(),
+				LastName:    gofakeit.LastName(),
+				Gender:      enumutils.GenderFemale,
+				PhoneNumber: interserviceclient.TestUserPhoneNumber,
+				ClientType:  "PMTCT",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("ClientRegistrationInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStaffRegistrationInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   StaffRegistrationInput
+		wantErr bool
+	}{
+		{
+			name: "valid: all required params passed",
+			input: StaffRegistrationInput{
+				Username:    gofakeit.Username(),
+				FirstName:   gofakeit.FirstName(),
+				LastName:    gofakeit.LastName(),
+				Gender:      enumutils.GenderMale,
+				PhoneNumber: interserviceclient.TestUserPhoneNumber,
+				FacilityID:  ksuid.New().String(),
+				StaffNumber: ksuid.New().String(),
+				Cadre:       enums.StaffCadreNurse,
+			},
+		},
+		{
+			name: "invalid: missing staff number",
+			input: StaffRegistrationInput{
+				Username:    gofakeit.Username(),
+				FirstName:   gofakeit.FirstName(),
+				LastName:    gofakeit.LastName(),
+				Gender:      enumutils.GenderMale,
+				PhoneNumber: interserviceclient.TestUserPhoneNumber,
+				FacilityID:  ksuid.New().String(),
+				Cadre:       enums.StaffCadreNurse,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: missing cadre",
+			input: StaffRegistrationInput{
+				Username:    gofakeit.Username(),
+				FirstName:   gofakeit.FirstName(),
+				LastName:    gofakeit.LastName(),
+				Gender:      enumutils.GenderMale,
+				PhoneNumber: interserviceclient.TestUserPhoneNumber,
+				FacilityID:  ksuid.New().String(),
+				StaffNumber: ksuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("StaffRegistrationInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStaffProfileUpdateInput_Validate(t *testing.T) {
+	staffNumber := ksuid.New().String()
+	empty := ""
+	cadre := enums.StaffCadreClinician
+	invalidCadre := enums.StaffCadre("invalid")
+	active := false
+
+	tests := []struct {
+		name    string
+		input   StaffProfileUpdateInput
+		wantErr bool
+	}{
+		{
+			name: "valid: all fields passed",
+			input: StaffProfileUpdateInput{
+				StaffNumber:       &staffNumber,
+				Cadre:             &cadre,
+				DefaultFacilityID: &staffNumber,
+				Active:            &active,
+			},
+		},
+		{
+			name: "valid: only active status passed",
+			input: StaffProfileUpdateInput{
+				Active: &active,
+			},
+		},
+		{
+			name:    "invalid: no field passed",
+			input:   StaffProfileUpdateInput{},
+			wantErr: true,
+		},
+		{
+			name: "invalid: empty staff number",
+			input: StaffProfileUpdateInput{
+				StaffNumber: &empty,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: invalid cadre",
+			input: StaffProfileUpdateInput{
+				Cadre: &invalidCadre,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: empty default facility",
+			input: StaffProfileUpdateInput{
+				DefaultFacilityID: &empty,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("StaffProfileUpdateInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrganisationInput_Validate(t *testing.T) {
+	days := 14
+	zero := 0
+	senderID := enumutils.SenderIDSLADE360
+	invalidSenderID := enumutils.SenderID("invalid")
+
+	validInput := func() OrganisationInput {
+		return OrganisationInput{
+			Name:           "Test Organisation",
+			OrgCode:        "TEST",
+			Code:           1,
+			EmailAddress:   "test@example.com",
+			PhoneNumber:    "+254711223344",
+			DefaultCountry: "KEN",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		input   func() OrganisationInput
+		wantErr bool
+	}{
+		{
+			name:  "valid: without settings",
+			input: validInput,
+		},
+		{
+			name: "valid: with settings",
+			input: func() OrganisationInput {
+				input := validInput()
+				input.Settings = &OrganisationSettingsInput{
+					PINExpiryDays:       &days,
+					InvitePINExpiryDays: &days,
+					DiaryCadenceHours:   &days,
+					SMSSenderID:         &senderID,
+				}
+				return input
+			},
+		},
+		{
+			name: "invalid: missing name",
+			input: func() OrganisationInput {
+				input := validInput()
+				input.Name = ""
+				return input
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: bad email address",
+			input: func() OrganisationInput {
+				input := validInput()
+				input.EmailAddress = "not an email"
+				return input
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: zero PIN expiry days",
+			input: func() OrganisationInput {
+				input := validInput()
+				input.Settings = &OrganisationSettingsInput{PINExpiryDays: &zero}
+				return input
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: zero diary cadence",
+			input: func() OrganisationInput {
+				input := validInput()
+				input.Settings = &OrganisationSettingsInput{DiaryCadenceHours: &zero}
+				return input
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: invalid sender ID",
+			input: func() OrganisationInput {
+				input := validInput()
+				input.Settings = &OrganisationSettingsInput{SMSSenderID: &invalidSenderID}
+				return input
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tt.input()
+			if err := input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("OrganisationInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrganisationUpdateInput_Validate(t *testing.T) {
+	name := "Test Organisation"
+	email := "test@example.com"
+	invalidEmail := "not an email"
+	empty := ""
+	days := 14
+	zero := 0
+
+	tests := []struct {
+		name    string
+		input   OrganisationUpdateInput
+		wantErr bool
+	}{
+		{
+			name: "valid: name and email passed",
+			input: OrganisationUpdateInput{
+				Name:         &name,
+				EmailAddress: &email,
+			},
+		},
+		{
+			name: "valid: only settings passed",
+			input: OrganisationUpdateInput{
+				Settings: &OrganisationSettingsInput{InvitePINExpiryDays: &days},
+			},
+		},
+		{
+			name:    "invalid: no field passed",
+			input:   OrganisationUpdateInput{},
+			wantErr: true,
+		},
+		{
+			name: "invalid: empty name",
+			input: OrganisationUpdateInput{
+				Name: &empty,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: bad email address",
+			input: OrganisationUpdateInput{
+				EmailAddress: &invalidEmail,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: zero invite PIN expiry days",
+			input: OrganisationUpdateInput{
+				Settings: &OrganisationSettingsInput{InvitePINExpiryDays: &zero},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("OrganisationUpdateInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFacilityUpdateInput_Validate(t *testing.T) {
+	name := "test name"
+	description := "test description"
+	county := enums.CountyTypeNairobi
+	invalidCounty := enums.CountyType("Gotham")
+	shortName := "te"
+	latitude := -1.2921
+	longitude := 36.8219
+	empty := ""
+	inactive := false
+
+	tests := []struct {
+		name    string
+		input   FacilityUpdateInput
+		wantErr bool
+	}{
+		{
+			name: "valid: name and county passed",
+			input: FacilityUpdateInput{
+				Name:   &name,
+				County: &county,
+			},
+		},
+		{
+			name: "valid: only description passed",
+			input: FacilityUpdateInput{
+				Description: &description,
+			},
+		},
+		{
+			name: "valid: only active passed",
+			input: FacilityUpdateInput{
+				Active: &inactive,
+			},
+		},
+		{
+			name:    "invalid: no field passed",
+			input:   FacilityUpdateInput{},
+			wantErr: true,
+		},
+		{
+			name: "invalid: empty phone",
+			input: FacilityUpdateInput{
+				Phone: &empty,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: short name",
+			input: FacilityUpdateInput{
+				Name: &shortName,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: unknown county",
+			input: FacilityUpdateInput{
+				County: &invalidCounty,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid: only coordinates passed",
+			input: FacilityUpdateInput{
+				Latitude:  &latitude,
+				Longitude: &longitude,
+			},
+		},
+		{
+			name: "invalid: longitude without latitude",
+			input: FacilityUpdateInput{
+				Longitude: &longitude,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("FacilityUpdateInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNearbyFacilitiesInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   NearbyFacilitiesInput
+		wantErr bool
+	}{
+		{
+			name: "valid: location and filters",
+			input: NearbyFacilitiesInput{
+				Latitude:  -1.2921,
+				Longitude: 36.8219,
+				RadiusKm:  10,
+				Limit:     10,
+				FilterInput: []*FiltersInput{
+					{DataType: enums.FilterSortDataTypeCounty, Value: "Nairobi"},
+				},
+				Sort: &SortsInput{Field: enums.FilterSortDataTypeDistance, Direction: enums.SortDataTypeDesc},
+			},
+		},
+		{
+			name: "invalid: radius not passed",
+			input: NearbyFacilitiesInput{
+				Latitude:  -1.2921,
+				Longitude: 36.8219,
+				Limit:     10,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: limit too large",
+			input: NearbyFacilitiesInput{
+				Latitude:  -1.2921,
+				Longitude: 36.8219,
+				RadiusKm:  10,
+				Limit:     1000,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: longitude out of range",
+			input: NearbyFacilitiesInput{
+				Latitude:  -1.2921,
+				Longitude: 181,
+				RadiusKm:  10,
+				Limit:     10,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: filter not available for facilities",
+			input: NearbyFacilitiesInput{
+				Latitude:  -1.2921,
+				Longitude: 36.8219,
+				RadiusKm:  10,
+				Limit:     10,
+				FilterInput: []*FiltersInput{
+					{DataType: enums.FilterSortDataTypeDistance, Value: "10"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: sort not available for nearby facilities",
+			input: NearbyFacilitiesInput{
+				Latitude:  -1.2921,
+				Longitude: 36.8219,
+				RadiusKm:  10,
+				Limit:     10,
+				Sort:      &SortsInput{Field: enums.FilterSortDataTypeCreatedAt, Direction: enums.SortDataTypeAsc},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: sort direction",
+			input: NearbyFacilitiesInput{
+				Latitude:  -1.2921,
+				Longitude: 36.8219,
+				RadiusKm:  10,
+				Limit:     10,
+				Sort:      &SortsInput{Field: enums.FilterSortDataTypeName, Direction: enums.SortDataType("sideways")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("NearbyFacilitiesInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateFacilityOpeningHours(t *testing.T) {
+	tests := []struct {
+		name         string
+		openingHours []*FacilityOpeningHoursInput
+		wantErr      bool
+	}{
+		{
+			name: "valid: closed for lunch and open all day",
+			openingHours: []*FacilityOpeningHoursInput{
+				{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "08:00", ClosesAt: "13:00"},
+				{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "14:00", ClosesAt: "17:00"},
+				{DayOfWeek: enums.DayOfWeekTuesday, OpensAt: "00:00", ClosesAt: "24:00"},
+			},
+		},
+		{
+			name:         "valid: closed all week",
+			openingHours: []*FacilityOpeningHoursInput{},
+		},
+		{
+			name: "invalid: overlapping opening hours",
+			openingHours: []*FacilityOpeningHoursInput{
+				{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "08:00", ClosesAt: "13:00"},
+				{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "12:00", ClosesAt: "17:00"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: closes before it opens",
+			openingHours: []*FacilityOpeningHoursInput{
+				{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "17:00", ClosesAt: "08:00"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: time format",
+			openingHours: []*FacilityOpeningHoursInput{
+				{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "8am", ClosesAt: "17:00"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: day of the week",
+			openingHours: []*FacilityOpeningHoursInput{
+				{DayOfWeek: enums.DayOfWeek("FUNDAY"), OpensAt: "08:00", ClosesAt: "17:00"},
+			},
+			wantErr: true,
+		},
+		{
+			name:         "invalid: empty opening hours",
+			openingHours: []*FacilityOpeningHoursInput{nil},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateFacilityOpeningHours(tt.openingHours); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFacilityOpeningHours() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFacilityHoursExceptionInput_Validate(t *testing.T) {
+	opensAt := "09:00"
+	closesAt := "13:00"
+	tests := []struct {
+		name    string
+		input   FacilityHoursExceptionInput
+		wantErr bool
+	}{
+		{
+			name:  "valid: closed on a holiday",
+			input: FacilityHoursExceptionInput{Date: "2021-12-25", Closed: true, Reason: "Christmas"},
+		},
+		{
+			name:  "valid: shorter hours",
+			input: FacilityHoursExceptionInput{Date: "2021-12-24", OpensAt: &opensAt, ClosesAt: &closesAt},
+		},
+		{
+			name:    "invalid: date format",
+			input:   FacilityHoursExceptionInput{Date: "25/12/2021", Closed: true},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: closed with opening hours",
+			input:   FacilityHoursExceptionInput{Date: "2021-12-25", Closed: true, OpensAt: &opensAt, ClosesAt: &closesAt},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: open without a closing time",
+			input:   FacilityHoursExceptionInput{Date: "2021-12-24", OpensAt: &opensAt},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: closes before it opens",
+			input:   FacilityHoursExceptionInput{Date: "2021-12-24", OpensAt: &closesAt, ClosesAt: &opensAt},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("FacilityHoursExceptionInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFacilityServiceInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   FacilityServiceInput
+		wantErr bool
+	}{
+		{
+			name:  "valid: service",
+			input: FacilityServiceInput{Name: "ART refill", Code: "ART_REFILL"},
+		},
+		{
+			name:    "invalid: missing name",
+			input:   FacilityServiceInput{Code: "ART_REFILL"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: lower case code",
+			input:   FacilityServiceInput{Name: "ART refill", Code: "art-refill"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("FacilityServiceInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContentSearchInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   ContentSearchInput
+		wantErr bool
+	}{
+		{
+			name:  "valid: search",
+			input: ContentSearchInput{Query: "side effects", CategoryIDs: []int{1}, Language: enumutils.LanguageSw},
+		},
+		{
+			name:    "invalid: missing query",
+			input:   ContentSearchInput{Language: enumutils.LanguageEn},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: query is too long",
+			input:   ContentSearchInput{Query: strings.Repeat("a", 201), Language: enumutils.LanguageEn},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: category ID",
+			input:   ContentSearchInput{Query: "PrEP", CategoryIDs: []int{0}, Language: enumutils.LanguageEn},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: language",
+			input:   ContentSearchInput{Query: "PrEP"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("ContentSearchInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContentAnalyticsInput_Validate(t *testing.T) {
+	from := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		input   ContentAnalyticsInput
+		wantErr bool
+	}{
+		{
+			name:  "valid: a month",
+			input: ContentAnalyticsInput{From: from, To: from.AddDate(0, 1, 0), CategoryIDs: []int{1}},
+		},
+		{
+			name:    "invalid: missing period",
+			input:   ContentAnalyticsInput{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: period ends before it starts",
+			input:   ContentAnalyticsInput{From: from, To: from.AddDate(0, 0, -1)},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: period is too long",
+			input:   ContentAnalyticsInput{From: from, To: from.AddDate(2, 0, 0)},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: category ID",
+			input:   ContentAnalyticsInput{From: from, To: from.AddDate(0, 1, 0), CategoryIDs: []int{0}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("ContentAnalyticsInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
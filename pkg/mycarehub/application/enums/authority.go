@@ -0,0 +1,206 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// UserRoleType is a role that can be assigned to a user. A role grants a set of permissions.
+type UserRoleType string
+
+const (
+	// UserRoleTypeClient is assigned to clients. It only grants access to the client's own records
+	UserRoleTypeClient UserRoleType = "CLIENT"
+
+	// UserRoleTypeCHV is assigned to community health volunteers
+	UserRoleTypeCHV UserRoleType = "CHV"
+
+	// UserRoleTypeClinician is assigned to healthcare workers who attend to clients
+	UserRoleTypeClinician UserRoleType = "CLINICIAN"
+
+	// UserRoleTypeFacilityAdmin is assigned to staff who manage the users of their facilities
+	UserRoleTypeFacilityAdmin UserRoleType = "FACILITY_ADMIN"
+
+	// UserRoleTypeContentEditor is assigned to staff who write the content that clients read in the app
+	UserRoleTypeContentEditor UserRoleType = "CONTENT_EDITOR"
+
+	// UserRoleTypeSystemAdmin is assigned to staff who manage the whole platform
+	UserRoleTypeSystemAdmin UserRoleType = "SYSTEM_ADMIN"
+)
+
+// AllUserRoleType is a set of all valid user roles
+var AllUserRoleType = []UserRoleType{
+	UserRoleTypeClient,
+	UserRoleTypeCHV,
+	UserRoleTypeClinician,
+	UserRoleTypeFacilityAdmin,
+	UserRoleTypeContentEditor,
+	UserRoleTypeSystemAdmin,
+}
+
+// IsValid returns true if a user role is valid
+func (r UserRoleType) IsValid() bool {
+	switch r {
+	case UserRoleTypeClient, UserRoleTypeCHV, UserRoleTypeClinician, UserRoleTypeFacilityAdmin, UserRoleTypeContentEditor,
+		UserRoleTypeSystemAdmin:
+		return true
+	}
+	return false
+}
+
+// String converts the user role enum to a string
+func (r UserRoleType) String() string {
+	return string(r)
+}
+
+// UnmarshalGQL converts the supplied value to a user role
+func (r *UserRoleType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*r = UserRoleType(str)
+	if !r.IsValid() {
+		return fmt.Errorf("%s is not a valid UserRoleType", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the user role to the supplied writer
+func (r UserRoleType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(r.String()))
+}
+
+// rolePermissions lists the permissions granted by each role
+var rolePermissions = map[UserRoleType][]PermissionType{
+	UserRoleTypeClient: {},
+	UserRoleTypeCHV: {
+		PermissionTypeCanViewClientHealthDiary,
+		PermissionTypeCanActOnBehalfOfClient,
+	},
+	UserRoleTypeClinician: {
+		PermissionTypeCanInviteUser,
+		PermissionTypeCanViewClientHealthDiary,
+		PermissionTypeCanActOnBehalfOfClient,
+		PermissionTypeCanTransferClient,
+	},
+	UserRoleTypeFacilityAdmin: {
+		PermissionTypeCanRegisterUser,
+		PermissionTypeCanInviteUser,
+		PermissionTypeCanViewClientHealthDiary,
+		PermissionTypeCanActOnBehalfOfClient,
+		PermissionTypeCanTransferClient,
+	},
+	UserRoleTypeContentEditor: {
+		PermissionTypeCanViewContentAnalytics,
+		PermissionTypeCanModerateContentComments,
+	},
+	UserRoleTypeSystemAdmin: AllPermissionType,
+}
+
+// Permissions returns the permissions granted by a role
+func (r UserRoleType) Permissions() []PermissionType {
+	return rolePermissions[r]
+}
+
+// HasPermission returns true if the role grants the supplied permission
+func (r UserRoleType) HasPermission(permission PermissionType) bool {
+	for _, p := range r.Permissions() {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionType is an action that a user needs to be granted, through their roles, to perform
+type PermissionType string
+
+const (
+	// PermissionTypeCanManageFacility allows a user to create, delete, inactivate and reactivate facilities
+	PermissionTypeCanManageFacility PermissionType = "CAN_MANAGE_FACILITY"
+
+	// PermissionTypeCanRegisterUser allows a user to register clients and staff
+	PermissionTypeCanRegisterUser PermissionType = "CAN_REGISTER_USER"
+
+	// PermissionTypeCanInviteUser allows a user to invite users to the app and follow up on pending invites
+	PermissionTypeCanInviteUser PermissionType = "CAN_INVITE_USER"
+
+	// PermissionTypeCanViewClientHealthDiary allows a user to read and record the health diary of
+	// clients in the facilities they can access
+	PermissionTypeCanViewClientHealthDiary PermissionType = "CAN_VIEW_CLIENT_HEALTH_DIARY"
+
+	// PermissionTypeCanManageRoles allows a user to assign roles to and revoke roles from other users
+	PermissionTypeCanManageRoles PermissionType = "CAN_MANAGE_ROLES"
+
+	// PermissionTypeCanActOnBehalfOfClient allows staff to pass a client's user or client ID to operations
+	// that otherwise act on the logged in user e.g bookmarking content for a client without a smartphone
+	PermissionTypeCanActOnBehalfOfClient PermissionType = "CAN_ACT_ON_BEHALF_OF_CLIENT"
+
+	// PermissionTypeCanManageOrganisation allows a user to create, update and deactivate organisations
+	// and change their settings
+	PermissionTypeCanManageOrganisation PermissionType = "CAN_MANAGE_ORGANISATION"
+
+	// PermissionTypeCanTransferClient allows staff to transfer clients out of the facilities they can access
+	// and to accept or decline clients transferred into those facilities
+	PermissionTypeCanTransferClient PermissionType = "CAN_TRANSFER_CLIENT"
+
+	// PermissionTypeCanViewContentAnalytics allows a user to see and export how clients engage with content
+	PermissionTypeCanViewContentAnalytics PermissionType = "CAN_VIEW_CONTENT_ANALYTICS"
+
+	// PermissionTypeCanModerateContentComments allows a user to work through the queue of content comments that
+	// are awaiting moderation and to approve, reject or flag them
+	PermissionTypeCanModerateContentComments PermissionType = "CAN_MODERATE_CONTENT_COMMENTS"
+)
+
+// AllPermissionType is a set of all valid permissions
+var AllPermissionType = []PermissionType{
+	PermissionTypeCanManageFacility,
+	PermissionTypeCanRegisterUser,
+	PermissionTypeCanInviteUser,
+	PermissionTypeCanViewClientHealthDiary,
+	PermissionTypeCanManageRoles,
+	PermissionTypeCanActOnBehalfOfClient,
+	PermissionTypeCanManageOrganisation,
+	PermissionTypeCanTransferClient,
+	PermissionTypeCanViewContentAnalytics,
+	PermissionTypeCanModerateContentComments,
+}
+
+// IsValid returns true if a permission is valid
+func (p PermissionType) IsValid() bool {
+	switch p {
+	case PermissionTypeCanManageFacility, PermissionTypeCanRegisterUser, PermissionTypeCanInviteUser,
+		PermissionTypeCanViewClientHealthDiary, PermissionTypeCanManageRoles, PermissionTypeCanActOnBehalfOfClient,
+		PermissionTypeCanManageOrganisation, PermissionTypeCanTransferClient, PermissionTypeCanViewContentAnalytics,
+		PermissionTypeCanModerateContentComments:
+		return true
+	}
+	return false
+}
+
+// String converts the permission enum to a string
+func (p PermissionType) String() string {
+	return string(p)
+}
+
+// UnmarshalGQL converts the supplied value to a permission
+func (p *PermissionType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*p = PermissionType(str)
+	if !p.IsValid() {
+		return fmt.Errorf("%s is not a valid PermissionType", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the permission to the supplied writer
+func (p PermissionType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(p.String()))
+}
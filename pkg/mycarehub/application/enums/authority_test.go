@@ -0,0 +1,338 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestUserRoleType_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    UserRoleType
+		want string
+	}{
+		{
+			name: "CLINICIAN",
+			e:    UserRoleTypeClinician,
+			want: "CLINICIAN",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("UserRoleType.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserRoleType_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    UserRoleType
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    UserRoleTypeClinician,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    UserRoleType("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("UserRoleType.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserRoleType_UnmarshalGQL(t *testing.T) {
+	value := UserRoleTypeClinician
+	invalid := UserRoleType("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *UserRoleType
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "CLINICIAN",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("UserRoleType.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserRoleType_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     UserRoleType
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			e:     UserRoleTypeClinician,
+			b:     w,
+			wantW: strconv.Quote("CLINICIAN"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("UserRoleType.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestPermissionType_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    PermissionType
+		want string
+	}{
+		{
+			name: "CAN_INVITE_USER",
+			e:    PermissionTypeCanInviteUser,
+			want: "CAN_INVITE_USER",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("PermissionType.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermissionType_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    PermissionType
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    PermissionTypeCanInviteUser,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    PermissionType("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("PermissionType.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermissionType_UnmarshalGQL(t *testing.T) {
+	value := PermissionTypeCanInviteUser
+	invalid := PermissionType("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *PermissionType
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "CAN_INVITE_USER",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("PermissionType.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPermissionType_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     PermissionType
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			e:     PermissionTypeCanInviteUser,
+			b:     w,
+			wantW: strconv.Quote("CAN_INVITE_USER"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("PermissionType.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestUserRoleType_HasPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       UserRoleType
+		permission PermissionType
+		want       bool
+	}{
+		{
+			name:       "system admin has every permission",
+			role:       UserRoleTypeSystemAdmin,
+			permission: PermissionTypeCanManageRoles,
+			want:       true,
+		},
+		{
+			name:       "clinician can invite users",
+			role:       UserRoleTypeClinician,
+			permission: PermissionTypeCanInviteUser,
+			want:       true,
+		},
+		{
+			name:       "facility admin cannot manage facilities",
+			role:       UserRoleTypeFacilityAdmin,
+			permission: PermissionTypeCanManageFacility,
+			want:       false,
+		},
+		{
+			name:       "CHV can act on behalf of clients",
+			role:       UserRoleTypeCHV,
+			permission: PermissionTypeCanActOnBehalfOfClient,
+			want:       true,
+		},
+		{
+			name:       "facility admin cannot manage organisations",
+			role:       UserRoleTypeFacilityAdmin,
+			permission: PermissionTypeCanManageOrganisation,
+			want:       false,
+		},
+		{
+			name:       "clinician can transfer clients",
+			role:       UserRoleTypeClinician,
+			permission: PermissionTypeCanTransferClient,
+			want:       true,
+		},
+		{
+			name:       "CHV cannot transfer clients",
+			role:       UserRoleTypeCHV,
+			permission: PermissionTypeCanTransferClient,
+			want:       false,
+		},
+		{
+			name:       "content editor can view content analytics",
+			role:       UserRoleTypeContentEditor,
+			permission: PermissionTypeCanViewContentAnalytics,
+			want:       true,
+		},
+		{
+			name:       "clinician cannot view content analytics",
+			role:       UserRoleTypeClinician,
+			permission: PermissionTypeCanViewContentAnalytics,
+			want:       false,
+		},
+		{
+			name:       "content editor can moderate content comments",
+			role:       UserRoleTypeContentEditor,
+			permission: PermissionTypeCanModerateContentComments,
+			want:       true,
+		},
+		{
+			name:       "client cannot moderate content comments",
+			role:       UserRoleTypeClient,
+			permission: PermissionTypeCanModerateContentComments,
+			want:       false,
+		},
+		{
+			name:       "client has no permission",
+			role:       UserRoleTypeClient,
+			permission: PermissionTypeCanViewClientHealthDiary,
+			want:       false,
+		},
+		{
+			name:       "invalid role has no permission",
+			role:       UserRoleType("invalid"),
+			permission: PermissionTypeCanInviteUser,
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.role.HasPermission(tt.permission); got != tt.want {
+				t.Errorf("UserRoleType.HasPermission() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
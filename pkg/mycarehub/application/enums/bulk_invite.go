@@ -0,0 +1,119 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// BulkInviteJobStatus is the state of a background job that invites users uploaded in a CSV file
+type BulkInviteJobStatus string
+
+const (
+	// BulkInviteJobStatusPending means that the job has been created but no invite has been sent
+	BulkInviteJobStatusPending BulkInviteJobStatus = "PENDING"
+
+	// BulkInviteJobStatusInProgress means that the job is sending the invites
+	BulkInviteJobStatusInProgress BulkInviteJobStatus = "IN_PROGRESS"
+
+	// BulkInviteJobStatusCompleted means that every row in the job has been processed
+	BulkInviteJobStatusCompleted BulkInviteJobStatus = "COMPLETED"
+
+	// BulkInviteJobStatusFailed means that the job was interrupted e.g by a restart before every row was processed
+	BulkInviteJobStatusFailed BulkInviteJobStatus = "FAILED"
+)
+
+// AllBulkInviteJobStatus is a set of all valid bulk invite job statuses
+var AllBulkInviteJobStatus = []BulkInviteJobStatus{
+	BulkInviteJobStatusPending,
+	BulkInviteJobStatusInProgress,
+	BulkInviteJobStatusCompleted,
+	BulkInviteJobStatusFailed,
+}
+
+// IsValid returns true if a bulk invite job status is valid
+func (b BulkInviteJobStatus) IsValid() bool {
+	switch b {
+	case BulkInviteJobStatusPending, BulkInviteJobStatusInProgress, BulkInviteJobStatusCompleted, BulkInviteJobStatusFailed:
+		return true
+	}
+	return false
+}
+
+// String converts the bulk invite job status enum to a string
+func (b BulkInviteJobStatus) String() string {
+	return string(b)
+}
+
+// UnmarshalGQL converts the supplied value to a bulk invite job status
+func (b *BulkInviteJobStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*b = BulkInviteJobStatus(str)
+	if !b.IsValid() {
+		return fmt.Errorf("%s is not a valid BulkInviteJobStatus", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the bulk invite job status to the supplied writer
+func (b BulkInviteJobStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(b.String()))
+}
+
+// BulkInviteRowStatus is the outcome of inviting a single row of a bulk invite CSV file
+type BulkInviteRowStatus string
+
+const (
+	// BulkInviteRowStatusPending means that the row is yet to be processed
+	BulkInviteRowStatusPending BulkInviteRowStatus = "PENDING"
+
+	// BulkInviteRowStatusSuccess means that the user in the row was invited
+	BulkInviteRowStatusSuccess BulkInviteRowStatus = "SUCCESS"
+
+	// BulkInviteRowStatusFailed means that the row was invalid or the invite could not be sent
+	BulkInviteRowStatusFailed BulkInviteRowStatus = "FAILED"
+)
+
+// AllBulkInviteRowStatus is a set of all valid bulk invite row statuses
+var AllBulkInviteRowStatus = []BulkInviteRowStatus{
+	BulkInviteRowStatusPending,
+	BulkInviteRowStatusSuccess,
+	BulkInviteRowStatusFailed,
+}
+
+// IsValid returns true if a bulk invite row status is valid
+func (b BulkInviteRowStatus) IsValid() bool {
+	switch b {
+	case BulkInviteRowStatusPending, BulkInviteRowStatusSuccess, BulkInviteRowStatusFailed:
+		return true
+	}
+	return false
+}
+
+// String converts the bulk invite row status enum to a string
+func (b BulkInviteRowStatus) String() string {
+	return string(b)
+}
+
+// UnmarshalGQL converts the supplied value to a bulk invite row status
+func (b *BulkInviteRowStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*b = BulkInviteRowStatus(str)
+	if !b.IsValid() {
+		return fmt.Errorf("%s is not a valid BulkInviteRowStatus", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the bulk invite row status to the supplied writer
+func (b BulkInviteRowStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(b.String()))
+}
@@ -0,0 +1,248 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestBulkInviteJobStatus_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    BulkInviteJobStatus
+		want string
+	}{
+		{
+			name: "PENDING",
+			e:    BulkInviteJobStatusPending,
+			want: "PENDING",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("BulkInviteJobStatus.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkInviteJobStatus_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    BulkInviteJobStatus
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    BulkInviteJobStatusPending,
+			want: true,
+		},
+		{
+			name: "valid type - failed",
+			e:    BulkInviteJobStatusFailed,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    BulkInviteJobStatus("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("BulkInviteJobStatus.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkInviteJobStatus_UnmarshalGQL(t *testing.T) {
+	value := BulkInviteJobStatusPending
+	invalid := BulkInviteJobStatus("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *BulkInviteJobStatus
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "PENDING",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("BulkInviteJobStatus.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBulkInviteJobStatus_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     BulkInviteJobStatus
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			e:     BulkInviteJobStatusPending,
+			b:     w,
+			wantW: strconv.Quote("PENDING"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("BulkInviteJobStatus.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestBulkInviteRowStatus_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    BulkInviteRowStatus
+		want string
+	}{
+		{
+			name: "SUCCESS",
+			e:    BulkInviteRowStatusSuccess,
+			want: "SUCCESS",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("BulkInviteRowStatus.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkInviteRowStatus_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    BulkInviteRowStatus
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    BulkInviteRowStatusSuccess,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    BulkInviteRowStatus("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("BulkInviteRowStatus.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkInviteRowStatus_UnmarshalGQL(t *testing.T) {
+	value := BulkInviteRowStatusSuccess
+	invalid := BulkInviteRowStatus("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *BulkInviteRowStatus
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "SUCCESS",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("BulkInviteRowStatus.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBulkInviteRowStatus_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     BulkInviteRowStatus
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			e:     BulkInviteRowStatusSuccess,
+			b:     w,
+			wantW: strconv.Quote("SUCCESS"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("BulkInviteRowStatus.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
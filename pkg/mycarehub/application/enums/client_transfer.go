@@ -0,0 +1,61 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ClientTransferStatus is the stage of a request to move a client to another facility
+type ClientTransferStatus string
+
+const (
+	// ClientTransferStatusPending means that the receiving facility has not responded to the transfer
+	ClientTransferStatusPending ClientTransferStatus = "PENDING"
+
+	// ClientTransferStatusAccepted means that the receiving facility accepted the client
+	ClientTransferStatusAccepted ClientTransferStatus = "ACCEPTED"
+
+	// ClientTransferStatusDeclined means that the receiving facility turned down the transfer
+	ClientTransferStatusDeclined ClientTransferStatus = "DECLINED"
+)
+
+// AllClientTransferStatus is a set of all valid client transfer statuses
+var AllClientTransferStatus = []ClientTransferStatus{
+	ClientTransferStatusPending,
+	ClientTransferStatusAccepted,
+	ClientTransferStatusDeclined,
+}
+
+// IsValid returns true if a client transfer status is valid
+func (c ClientTransferStatus) IsValid() bool {
+	switch c {
+	case ClientTransferStatusPending, ClientTransferStatusAccepted, ClientTransferStatusDeclined:
+		return true
+	}
+	return false
+}
+
+// String converts the client transfer status enum to a string
+func (c ClientTransferStatus) String() string {
+	return string(c)
+}
+
+// UnmarshalGQL converts the supplied value to a client transfer status
+func (c *ClientTransferStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*c = ClientTransferStatus(str)
+	if !c.IsValid() {
+		return fmt.Errorf("%s is not a valid ClientTransferStatus", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the client transfer status to the supplied writer
+func (c ClientTransferStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(c.String()))
+}
@@ -0,0 +1,125 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestClientTransferStatus_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    ClientTransferStatus
+		want string
+	}{
+		{
+			name: "PENDING",
+			e:    ClientTransferStatusPending,
+			want: "PENDING",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("ClientTransferStatus.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientTransferStatus_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    ClientTransferStatus
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    ClientTransferStatusPending,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    ClientTransferStatus("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("ClientTransferStatus.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientTransferStatus_UnmarshalGQL(t *testing.T) {
+	value := ClientTransferStatusPending
+	invalid := ClientTransferStatus("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *ClientTransferStatus
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "PENDING",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("ClientTransferStatus.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientTransferStatus_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     ClientTransferStatus
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			e:     ClientTransferStatusPending,
+			b:     w,
+			wantW: strconv.Quote("PENDING"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("ClientTransferStatus.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
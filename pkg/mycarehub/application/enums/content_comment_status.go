@@ -0,0 +1,65 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ContentCommentStatus is where a comment on a content item is in moderation
+type ContentCommentStatus string
+
+const (
+	// ContentCommentStatusPending means that the comment is waiting for a moderator before other users can see it
+	ContentCommentStatusPending ContentCommentStatus = "PENDING"
+
+	// ContentCommentStatusApproved means that the comment can be seen by other users
+	ContentCommentStatusApproved ContentCommentStatus = "APPROVED"
+
+	// ContentCommentStatusRejected means that a moderator removed the comment
+	ContentCommentStatusRejected ContentCommentStatus = "REJECTED"
+
+	// ContentCommentStatusFlagged means that a moderator hid the comment until it is looked into further
+	ContentCommentStatusFlagged ContentCommentStatus = "FLAGGED"
+)
+
+// AllContentCommentStatus is a set of all valid content comment statuses
+var AllContentCommentStatus = []ContentCommentStatus{
+	ContentCommentStatusPending,
+	ContentCommentStatusApproved,
+	ContentCommentStatusRejected,
+	ContentCommentStatusFlagged,
+}
+
+// IsValid returns true if a content comment status is valid
+func (c ContentCommentStatus) IsValid() bool {
+	switch c {
+	case ContentCommentStatusPending, ContentCommentStatusApproved, ContentCommentStatusRejected, ContentCommentStatusFlagged:
+		return true
+	}
+	return false
+}
+
+// String converts the content comment status enum to a string
+func (c ContentCommentStatus) String() string {
+	return string(c)
+}
+
+// UnmarshalGQL converts the supplied value to a content comment status
+func (c *ContentCommentStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*c = ContentCommentStatus(str)
+	if !c.IsValid() {
+		return fmt.Errorf("%s is not a valid ContentCommentStatus", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the content comment status to the supplied writer
+func (c ContentCommentStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(c.String()))
+}
@@ -0,0 +1,125 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestContentCommentStatus_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    ContentCommentStatus
+		want string
+	}{
+		{
+			name: "PENDING",
+			e:    ContentCommentStatusPending,
+			want: "PENDING",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("ContentCommentStatus.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentCommentStatus_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    ContentCommentStatus
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    ContentCommentStatusPending,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    ContentCommentStatus("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("ContentCommentStatus.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentCommentStatus_UnmarshalGQL(t *testing.T) {
+	value := ContentCommentStatusPending
+	invalid := ContentCommentStatus("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *ContentCommentStatus
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "PENDING",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("ContentCommentStatus.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContentCommentStatus_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     ContentCommentStatus
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			e:     ContentCommentStatusPending,
+			b:     w,
+			wantW: strconv.Quote("PENDING"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("ContentCommentStatus.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
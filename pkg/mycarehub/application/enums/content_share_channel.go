@@ -0,0 +1,65 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ContentShareChannel is the medium through which a user shared a content item
+type ContentShareChannel string
+
+const (
+	// ContentShareChannelWhatsApp means that the content item was shared on WhatsApp
+	ContentShareChannelWhatsApp ContentShareChannel = "WHATSAPP"
+
+	// ContentShareChannelSMS means that the content item was shared by text message
+	ContentShareChannelSMS ContentShareChannel = "SMS"
+
+	// ContentShareChannelFacebook means that the content item was shared on Facebook
+	ContentShareChannelFacebook ContentShareChannel = "FACEBOOK"
+
+	// ContentShareChannelCopyLink means that the user copied the link to the content item
+	ContentShareChannelCopyLink ContentShareChannel = "COPY_LINK"
+)
+
+// AllContentShareChannel is a set of all valid content share channels
+var AllContentShareChannel = []ContentShareChannel{
+	ContentShareChannelWhatsApp,
+	ContentShareChannelSMS,
+	ContentShareChannelFacebook,
+	ContentShareChannelCopyLink,
+}
+
+// IsValid returns true if a content share channel is valid
+func (c ContentShareChannel) IsValid() bool {
+	switch c {
+	case ContentShareChannelWhatsApp, ContentShareChannelSMS, ContentShareChannelFacebook, ContentShareChannelCopyLink:
+		return true
+	}
+	return false
+}
+
+// String converts the content share channel enum to a string
+func (c ContentShareChannel) String() string {
+	return string(c)
+}
+
+// UnmarshalGQL converts the supplied value to a content share channel
+func (c *ContentShareChannel) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*c = ContentShareChannel(str)
+	if !c.IsValid() {
+		return fmt.Errorf("%s is not a valid ContentShareChannel", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the content share channel to the supplied writer
+func (c ContentShareChannel) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(c.String()))
+}
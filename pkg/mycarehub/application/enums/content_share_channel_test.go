@@ -0,0 +1,125 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestContentShareChannel_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    ContentShareChannel
+		want string
+	}{
+		{
+			name: "WHATSAPP",
+			e:    ContentShareChannelWhatsApp,
+			want: "WHATSAPP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("ContentShareChannel.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentShareChannel_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    ContentShareChannel
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    ContentShareChannelWhatsApp,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    ContentShareChannel("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("ContentShareChannel.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentShareChannel_UnmarshalGQL(t *testing.T) {
+	value := ContentShareChannelWhatsApp
+	invalid := ContentShareChannel("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *ContentShareChannel
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "WHATSAPP",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("ContentShareChannel.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContentShareChannel_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     ContentShareChannel
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			e:     ContentShareChannelWhatsApp,
+			b:     w,
+			wantW: strconv.Quote("WHATSAPP"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("ContentShareChannel.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ContentSortOrder is the order that content is listed in on the feed
+type ContentSortOrder string
+
+const (
+	// ContentSortOrderNewest lists the most recently published content first
+	ContentSortOrderNewest ContentSortOrder = "NEWEST"
+
+	// ContentSortOrderMostLiked lists the content with the most likes first
+	ContentSortOrderMostLiked ContentSortOrder = "MOST_LIKED"
+
+	// ContentSortOrderMostViewed lists the content with the most views first
+	ContentSortOrderMostViewed ContentSortOrder = "MOST_VIEWED"
+
+	// ContentSortOrderTrending lists the content with the most engagement over the last few days first
+	ContentSortOrderTrending ContentSortOrder = "TRENDING"
+)
+
+// AllContentSortOrder is a set of all valid content sort orders
+var AllContentSortOrder = []ContentSortOrder{
+	ContentSortOrderNewest,
+	ContentSortOrderMostLiked,
+	ContentSortOrderMostViewed,
+	ContentSortOrderTrending,
+}
+
+// IsValid returns true if a content sort order is valid
+func (c ContentSortOrder) IsValid() bool {
+	switch c {
+	case ContentSortOrderNewest, ContentSortOrderMostLiked, ContentSortOrderMostViewed, ContentSortOrderTrending:
+		return true
+	}
+	return false
+}
+
+// String converts the content sort order enum to a string
+func (c ContentSortOrder) String() string {
+	return string(c)
+}
+
+// UnmarshalGQL converts the supplied value to a content sort order
+func (c *ContentSortOrder) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*c = ContentSortOrder(str)
+	if !c.IsValid() {
+		return fmt.Errorf("%s is not a valid ContentSortOrder", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the content sort order to the supplied writer
+func (c ContentSortOrder) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(c.String()))
+}
@@ -0,0 +1,125 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestContentSortOrder_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    ContentSortOrder
+		want string
+	}{
+		{
+			name: "TRENDING",
+			e:    ContentSortOrderTrending,
+			want: "TRENDING",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("ContentSortOrder.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentSortOrder_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    ContentSortOrder
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    ContentSortOrderTrending,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    ContentSortOrder("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("ContentSortOrder.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentSortOrder_UnmarshalGQL(t *testing.T) {
+	value := ContentSortOrderTrending
+	invalid := ContentSortOrder("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *ContentSortOrder
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "TRENDING",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("ContentSortOrder.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContentSortOrder_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     ContentSortOrder
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			e:     ContentSortOrderTrending,
+			b:     w,
+			wantW: strconv.Quote("TRENDING"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("ContentSortOrder.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// DayOfWeek is a day of the week that a facility's opening hours apply to
+type DayOfWeek string
+
+const (
+	// DayOfWeekMonday is Monday
+	DayOfWeekMonday DayOfWeek = "MONDAY"
+
+	// DayOfWeekTuesday is Tuesday
+	DayOfWeekTuesday DayOfWeek = "TUESDAY"
+
+	// DayOfWeekWednesday is Wednesday
+	DayOfWeekWednesday DayOfWeek = "WEDNESDAY"
+
+	// DayOfWeekThursday is Thursday
+	DayOfWeekThursday DayOfWeek = "THURSDAY"
+
+	// DayOfWeekFriday is Friday
+	DayOfWeekFriday DayOfWeek = "FRIDAY"
+
+	// DayOfWeekSaturday is Saturday
+	DayOfWeekSaturday DayOfWeek = "SATURDAY"
+
+	// DayOfWeekSunday is Sunday
+	DayOfWeekSunday DayOfWeek = "SUNDAY"
+)
+
+// AllDayOfWeek is a set of all the days of the week, ordered the same way as time.Weekday
+var AllDayOfWeek = []DayOfWeek{
+	DayOfWeekSunday,
+	DayOfWeekMonday,
+	DayOfWeekTuesday,
+	DayOfWeekWednesday,
+	DayOfWeekThursday,
+	DayOfWeekFriday,
+	DayOfWeekSaturday,
+}
+
+// DayOfWeekFromWeekday converts a time.Weekday to a day of the week
+func DayOfWeekFromWeekday(weekday time.Weekday) DayOfWeek {
+	return AllDayOfWeek[weekday]
+}
+
+// IsValid returns true if a day of the week is valid
+func (d DayOfWeek) IsValid() bool {
+	switch d {
+	case DayOfWeekMonday,
+		DayOfWeekTuesday,
+		DayOfWeekWednesday,
+		DayOfWeekThursday,
+		DayOfWeekFriday,
+		DayOfWeekSaturday,
+		DayOfWeekSunday:
+		return true
+	}
+	return false
+}
+
+// String converts the day of the week enum to a string
+func (d DayOfWeek) String() string {
+	return string(d)
+}
+
+// UnmarshalGQL converts the supplied value to a day of the week
+func (d *DayOfWeek) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*d = DayOfWeek(str)
+	if !d.IsValid() {
+		return fmt.Errorf("%s is not a valid DayOfWeek", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the day of the week to the supplied writer
+func (d DayOfWeek) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(d.String()))
+}
@@ -0,0 +1,157 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDayOfWeek_String(t *testing.T) {
+	tests := []struct {
+		name string
+		d    DayOfWeek
+		want string
+	}{
+		{
+			name: "MONDAY",
+			d:    DayOfWeekMonday,
+			want: "MONDAY",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.String(); got != tt.want {
+				t.Errorf("DayOfWeek.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDayOfWeek_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		d    DayOfWeek
+		want bool
+	}{
+		{
+			name: "valid type",
+			d:    DayOfWeekSunday,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			d:    DayOfWeek("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.IsValid(); got != tt.want {
+				t.Errorf("DayOfWeek.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDayOfWeek_UnmarshalGQL(t *testing.T) {
+	value := DayOfWeekMonday
+	invalid := DayOfWeek("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		d       *DayOfWeek
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			d:    &value,
+			args: args{
+				v: "FRIDAY",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			d:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			d:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.d.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("DayOfWeek.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDayOfWeek_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		d     DayOfWeek
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			d:     DayOfWeekMonday,
+			b:     w,
+			wantW: strconv.Quote("MONDAY"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.d.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("DayOfWeek.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestDayOfWeekFromWeekday(t *testing.T) {
+	tests := []struct {
+		name    string
+		weekday time.Weekday
+		want    DayOfWeek
+	}{
+		{
+			name:    "Sunday",
+			weekday: time.Sunday,
+			want:    DayOfWeekSunday,
+		},
+		{
+			name:    "Wednesday",
+			weekday: time.Wednesday,
+			want:    DayOfWeekWednesday,
+		},
+		{
+			name:    "Saturday",
+			weekday: time.Saturday,
+			want:    DayOfWeekSaturday,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DayOfWeekFromWeekday(tt.weekday); got != tt.want {
+				t.Errorf("DayOfWeekFromWeekday() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
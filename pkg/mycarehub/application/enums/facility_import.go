@@ -0,0 +1,115 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// FacilityImportFormat is the file format of a Kenya Master Facility List (KMFL) export
+type FacilityImportFormat string
+
+const (
+	// FacilityImportFormatCSV is a KMFL export saved as a CSV file with a header row
+	FacilityImportFormatCSV FacilityImportFormat = "CSV"
+
+	// FacilityImportFormatJSON is a KMFL export saved as a JSON array of facilities
+	FacilityImportFormatJSON FacilityImportFormat = "JSON"
+)
+
+// AllFacilityImportFormat is a set of all valid facility import formats
+var AllFacilityImportFormat = []FacilityImportFormat{
+	FacilityImportFormatCSV,
+	FacilityImportFormatJSON,
+}
+
+// IsValid returns true if a facility import format is valid
+func (f FacilityImportFormat) IsValid() bool {
+	switch f {
+	case FacilityImportFormatCSV, FacilityImportFormatJSON:
+		return true
+	}
+	return false
+}
+
+// String converts the facility import format enum to a string
+func (f FacilityImportFormat) String() string {
+	return string(f)
+}
+
+// UnmarshalGQL converts the supplied value to a facility import format
+func (f *FacilityImportFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*f = FacilityImportFormat(str)
+	if !f.IsValid() {
+		return fmt.Errorf("%s is not a valid FacilityImportFormat", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the facility import format to the supplied writer
+func (f FacilityImportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(f.String()))
+}
+
+// FacilityImportStatus is the outcome of importing a single facility from a KMFL export
+type FacilityImportStatus string
+
+const (
+	// FacilityImportStatusCreated means that the facility did not exist and was created
+	FacilityImportStatusCreated FacilityImportStatus = "CREATED"
+
+	// FacilityImportStatusUpdated means that the facility existed and its details were changed
+	FacilityImportStatusUpdated FacilityImportStatus = "UPDATED"
+
+	// FacilityImportStatusUnchanged means that the facility existed with the same details
+	FacilityImportStatusUnchanged FacilityImportStatus = "UNCHANGED"
+
+	// FacilityImportStatusInvalid means that the row could not be read or saved
+	FacilityImportStatusInvalid FacilityImportStatus = "INVALID"
+)
+
+// AllFacilityImportStatus is a set of all valid facility import statuses
+var AllFacilityImportStatus = []FacilityImportStatus{
+	FacilityImportStatusCreated,
+	FacilityImportStatusUpdated,
+	FacilityImportStatusUnchanged,
+	FacilityImportStatusInvalid,
+}
+
+// IsValid returns true if a facility import status is valid
+func (f FacilityImportStatus) IsValid() bool {
+	switch f {
+	case FacilityImportStatusCreated, FacilityImportStatusUpdated, FacilityImportStatusUnchanged, FacilityImportStatusInvalid:
+		return true
+	}
+	return false
+}
+
+// String converts the facility import status enum to a string
+func (f FacilityImportStatus) String() string {
+	return string(f)
+}
+
+// UnmarshalGQL converts the supplied value to a facility import status
+func (f *FacilityImportStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*f = FacilityImportStatus(str)
+	if !f.IsValid() {
+		return fmt.Errorf("%s is not a valid FacilityImportStatus", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the facility import status to the supplied writer
+func (f FacilityImportStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(f.String()))
+}
@@ -0,0 +1,243 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestFacilityImportFormat_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    FacilityImportFormat
+		want string
+	}{
+		{
+			name: "CSV",
+			e:    FacilityImportFormatCSV,
+			want: "CSV",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("FacilityImportFormat.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFacilityImportFormat_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    FacilityImportFormat
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    FacilityImportFormatCSV,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    FacilityImportFormat("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("FacilityImportFormat.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFacilityImportFormat_UnmarshalGQL(t *testing.T) {
+	value := FacilityImportFormatCSV
+	invalid := FacilityImportFormat("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *FacilityImportFormat
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "CSV",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("FacilityImportFormat.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFacilityImportFormat_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     FacilityImportFormat
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			e:     FacilityImportFormatCSV,
+			b:     w,
+			wantW: strconv.Quote("CSV"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("FacilityImportFormat.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestFacilityImportStatus_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    FacilityImportStatus
+		want string
+	}{
+		{
+			name: "CREATED",
+			e:    FacilityImportStatusCreated,
+			want: "CREATED",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("FacilityImportStatus.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFacilityImportStatus_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    FacilityImportStatus
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    FacilityImportStatusCreated,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    FacilityImportStatus("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("FacilityImportStatus.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFacilityImportStatus_UnmarshalGQL(t *testing.T) {
+	value := FacilityImportStatusCreated
+	invalid := FacilityImportStatus("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *FacilityImportStatus
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "CREATED",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("FacilityImportStatus.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFacilityImportStatus_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     FacilityImportStatus
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			e:     FacilityImportStatusCreated,
+			b:     w,
+			wantW: strconv.Quote("CREATED"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("FacilityImportStatus.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
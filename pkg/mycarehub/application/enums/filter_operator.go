@@ -0,0 +1,57 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// FilterOperator is the logical operator used to combine the filters in a filter group
+type FilterOperator string
+
+const (
+	// FilterOperatorAnd matches the records that satisfy all the filters in a group
+	FilterOperatorAnd FilterOperator = "AND"
+
+	// FilterOperatorOr matches the records that satisfy any of the filters in a group
+	FilterOperatorOr FilterOperator = "OR"
+)
+
+// AllFilterOperators is a set of a valid and known filter operators
+var AllFilterOperators = []FilterOperator{
+	FilterOperatorAnd,
+	FilterOperatorOr,
+}
+
+// IsValid returns true if a filter operator is valid
+func (f FilterOperator) IsValid() bool {
+	switch f {
+	case FilterOperatorAnd, FilterOperatorOr:
+		return true
+	}
+	return false
+}
+
+// String converts the filter operator to a string
+func (f FilterOperator) String() string {
+	return string(f)
+}
+
+// UnmarshalGQL converts the supplied value to a filter operator.
+func (f *FilterOperator) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*f = FilterOperator(str)
+	if !f.IsValid() {
+		return fmt.Errorf("%s is not a valid FilterOperator", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the filter operator to the supplied writer
+func (f FilterOperator) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(f.String()))
+}
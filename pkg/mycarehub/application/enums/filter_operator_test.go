@@ -0,0 +1,126 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestFilterOperator_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    FilterOperator
+		want string
+	}{
+		{
+			name: "OR",
+			e:    FilterOperatorOr,
+			want: "OR",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("FilterOperator.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterOperator_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    FilterOperator
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    FilterOperatorOr,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    FilterOperator("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("FilterOperator.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterOperator_UnmarshalGQL(t *testing.T) {
+	value := FilterOperatorOr
+	invalid := FilterOperator("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *FilterOperator
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "OR",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("FilterOperator.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilterOperator_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     FilterOperator
+		b     *bytes.Buffer
+		wantW string
+		panic bool
+	}{
+		{
+			name:  "valid type enums",
+			e:     FilterOperatorOr,
+			b:     w,
+			wantW: strconv.Quote("OR"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("FilterOperator.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
@@ -29,15 +29,28 @@ const (
 	// FilterSortDataTypeCounty represents the County Filter data type
 	FilterSortDataTypeCounty FilterSortDataType = "county"
 
+	// FilterSortDataTypeDistance represents the distance from a location. It is computed and only used for sorting
+	FilterSortDataTypeDistance FilterSortDataType = "distance"
+
+	// FilterSortDataTypeService represents the code of a service offered by a facility. It is only used for filtering
+	FilterSortDataTypeService FilterSortDataType = "service"
+
+	// FilterSortDataTypeOpenNow represents whether a facility is open at the moment. It is only used for filtering
+	FilterSortDataTypeOpenNow FilterSortDataType = "open_now"
+
 	// Other Filter data Types
 )
 
 // FacilityFilterDataTypes represents a slice of all possible `FilterDataTypes` values
 var FacilityFilterDataTypes = []FilterSortDataType{
+	FilterSortDataTypeCreatedAt,
+	FilterSortDataTypeUpdatedAt,
 	FilterSortDataTypeName,
 	FilterSortDataTypeMFLCode,
 	FilterSortDataTypeActive,
 	FilterSortDataTypeCounty,
+	FilterSortDataTypeService,
+	FilterSortDataTypeOpenNow,
 }
 
 // FacilitySortDataTypes represents a slice of all possible `SortDataTypes` values
@@ -50,6 +63,15 @@ var FacilitySortDataTypes = []FilterSortDataType{
 	FilterSortDataTypeCounty,
 }
 
+// NearbyFacilitySortDataTypes represents a slice of all possible `SortDataTypes` values for nearby facilities
+var NearbyFacilitySortDataTypes = []FilterSortDataType{
+	FilterSortDataTypeDistance,
+	FilterSortDataTypeName,
+	FilterSortDataTypeMFLCode,
+	FilterSortDataTypeActive,
+	FilterSortDataTypeCounty,
+}
+
 // IsValid returns true if an Filter data type is valid
 func (e FilterSortDataType) IsValid() bool {
 	switch e {
@@ -58,7 +80,10 @@ func (e FilterSortDataType) IsValid() bool {
 		FilterSortDataTypeName,
 		FilterSortDataTypeMFLCode,
 		FilterSortDataTypeActive,
-		FilterSortDataTypeCounty:
+		FilterSortDataTypeCounty,
+		FilterSortDataTypeDistance,
+		FilterSortDataTypeService,
+		FilterSortDataTypeOpenNow:
 		return true
 	}
 	return false
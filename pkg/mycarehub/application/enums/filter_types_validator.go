@@ -18,6 +18,10 @@ var filterSortDataTypeCategories = []FilterSortDataTypeCategory{
 		FilterSortCategory: FilterSortCategoryTypeSortFacility,
 		FilterSort:         FacilitySortDataTypes,
 	},
+	{
+		FilterSortCategory: FilterSortCategoryTypeSortNearbyFacility,
+		FilterSort:         NearbyFacilitySortDataTypes,
+	},
 	// Other Filter/Sort categories
 }
 
@@ -41,10 +41,50 @@ func TestValidateFilterSortCategoriesss(t *testing.T) {
 		},
 		{
 			name: "invalid filter not in category",
+			args: args{
+				category: FilterSortCategoryTypeFacility,
+				filter:   FilterSortDataTypeDistance,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid nearby facility sort",
+			args: args{
+				category: FilterSortCategoryTypeSortNearbyFacility,
+				filter:   FilterSortDataTypeDistance,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid distance filter",
+			args: args{
+				category: FilterSortCategoryTypeFacility,
+				filter:   FilterSortDataTypeDistance,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid open now filter",
+			args: args{
+				category: FilterSortCategoryTypeFacility,
+				filter:   FilterSortDataTypeOpenNow,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid created date filter",
 			args: args{
 				category: FilterSortCategoryTypeFacility,
 				filter:   FilterSortDataTypeCreatedAt,
 			},
+			wantErr: false,
+		},
+		{
+			name: "invalid service sort",
+			args: args{
+				category: FilterSortCategoryTypeSortFacility,
+				filter:   FilterSortDataTypeService,
+			},
 			wantErr: true,
 		},
 		{
@@ -15,6 +15,9 @@ const (
 
 	// FilterSortCategoryTypeSortFacility represents a Facility Sort category type
 	FilterSortCategoryTypeSortFacility FilterSortCategoryType = "SortFacility"
+
+	// FilterSortCategoryTypeSortNearbyFacility represents a nearby Facility Sort category type
+	FilterSortCategoryTypeSortNearbyFacility FilterSortCategoryType = "SortNearbyFacility"
 	// Other Filter category Types
 )
 
@@ -22,13 +25,15 @@ const (
 var FacilityFilterCategoryTypes = []FilterSortCategoryType{
 	FilterSortCategoryTypeFacility,
 	FilterSortCategoryTypeSortFacility,
+	FilterSortCategoryTypeSortNearbyFacility,
 }
 
 // IsValid returns true if an Filter category type is valid
 func (e FilterSortCategoryType) IsValid() bool {
 	switch e {
 	case FilterSortCategoryTypeFacility,
-		FilterSortCategoryTypeSortFacility:
+		FilterSortCategoryTypeSortFacility,
+		FilterSortCategoryTypeSortNearbyFacility:
 		return true
 	}
 	return false
@@ -0,0 +1,71 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// InvitationStatus is the stage of an invitation sent to a user to join the app
+type InvitationStatus string
+
+const (
+	// InvitationStatusIssued means that a temporary PIN has been generated for the invite
+	InvitationStatusIssued InvitationStatus = "ISSUED"
+
+	// InvitationStatusDelivered means that the invite SMS has been handed over for delivery
+	InvitationStatusDelivered InvitationStatus = "DELIVERED"
+
+	// InvitationStatusAccepted means that the user replaced the temporary PIN with their own PIN
+	InvitationStatusAccepted InvitationStatus = "ACCEPTED"
+
+	// InvitationStatusExpired means that the invite was not accepted in time or a newer invite was sent
+	InvitationStatusExpired InvitationStatus = "EXPIRED"
+)
+
+// AllInvitationStatus is a set of all valid invitation statuses
+var AllInvitationStatus = []InvitationStatus{
+	InvitationStatusIssued,
+	InvitationStatusDelivered,
+	InvitationStatusAccepted,
+	InvitationStatusExpired,
+}
+
+// PendingInvitationStatuses are the statuses of invitations that the user can still accept
+var PendingInvitationStatuses = []InvitationStatus{
+	InvitationStatusIssued,
+	InvitationStatusDelivered,
+}
+
+// IsValid returns true if an invitation status is valid
+func (i InvitationStatus) IsValid() bool {
+	switch i {
+	case InvitationStatusIssued, InvitationStatusDelivered, InvitationStatusAccepted, InvitationStatusExpired:
+		return true
+	}
+	return false
+}
+
+// String converts the invitation status enum to a string
+func (i InvitationStatus) String() string {
+	return string(i)
+}
+
+// UnmarshalGQL converts the supplied value to an invitation status
+func (i *InvitationStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*i = InvitationStatus(str)
+	if !i.IsValid() {
+		return fmt.Errorf("%s is not a valid InvitationStatus", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the invitation status to the supplied writer
+func (i InvitationStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(i.String()))
+}
@@ -0,0 +1,73 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// StaffCadre is the professional cadre of a staff member e.g a clinician or a nurse
+type StaffCadre string
+
+const (
+	// StaffCadreClinician is a medical doctor or clinical officer
+	StaffCadreClinician StaffCadre = "CLINICIAN"
+
+	// StaffCadreNurse is a nurse
+	StaffCadreNurse StaffCadre = "NURSE"
+
+	// StaffCadreCounsellor is an adherence or HIV testing services counsellor
+	StaffCadreCounsellor StaffCadre = "COUNSELLOR"
+
+	// StaffCadrePharmacist is a pharmacist or pharmaceutical technologist
+	StaffCadrePharmacist StaffCadre = "PHARMACIST"
+
+	// StaffCadreCHV is a community health volunteer
+	StaffCadreCHV StaffCadre = "CHV"
+
+	// StaffCadreOther is any cadre that is not listed
+	StaffCadreOther StaffCadre = "OTHER"
+)
+
+// AllStaffCadre is a set of all valid staff cadres
+var AllStaffCadre = []StaffCadre{
+	StaffCadreClinician,
+	StaffCadreNurse,
+	StaffCadreCounsellor,
+	StaffCadrePharmacist,
+	StaffCadreCHV,
+	StaffCadreOther,
+}
+
+// IsValid returns true if a staff cadre is valid
+func (s StaffCadre) IsValid() bool {
+	switch s {
+	case StaffCadreClinician, StaffCadreNurse, StaffCadreCounsellor, StaffCadrePharmacist, StaffCadreCHV, StaffCadreOther:
+		return true
+	}
+	return false
+}
+
+// String converts the staff cadre enum to a string
+func (s StaffCadre) String() string {
+	return string(s)
+}
+
+// UnmarshalGQL converts the supplied value to a staff cadre
+func (s *StaffCadre) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*s = StaffCadre(str)
+	if !s.IsValid() {
+		return fmt.Errorf("%s is not a valid StaffCadre", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the staff cadre to the supplied writer
+func (s StaffCadre) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(s.String()))
+}
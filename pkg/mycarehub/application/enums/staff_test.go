@@ -0,0 +1,125 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestStaffCadre_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    StaffCadre
+		want string
+	}{
+		{
+			name: "CLINICIAN",
+			e:    StaffCadreClinician,
+			want: "CLINICIAN",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("StaffCadre.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStaffCadre_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    StaffCadre
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    StaffCadreClinician,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    StaffCadre("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("StaffCadre.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStaffCadre_UnmarshalGQL(t *testing.T) {
+	value := StaffCadreClinician
+	invalid := StaffCadre("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *StaffCadre
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "CLINICIAN",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("StaffCadre.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStaffCadre_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     StaffCadre
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			e:     StaffCadreClinician,
+			b:     w,
+			wantW: strconv.Quote("CLINICIAN"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("StaffCadre.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
@@ -342,3 +342,21 @@ func GetFAQContentErr(err error) error {
 		Code:    int(GetFAQContentError),
 	}
 }
+
+// UnauthorizedErr returns an error message when the logged in user is not allowed to perform an action
+func UnauthorizedErr(err error) error {
+	return &CustomError{
+		Err:     err,
+		Message: UnauthorizedErrorMsg,
+		Code:    int(UnauthorizedError),
+	}
+}
+
+// ItemAlreadyExistsErr returns an error message when an item that should be unique has already been saved
+func ItemAlreadyExistsErr(err error) error {
+	return &CustomError{
+		Err:     err,
+		Message: ItemAlreadyExistsErrorMsg,
+		Code:    int(ItemAlreadyExistsError),
+	}
+}
@@ -308,4 +308,14 @@ const (
 	// The FAQ content retrieval has failed'
 	// Its error code is 60
 	GetFAQContentError
+
+	// UnauthorizedError means that the logged in user is not allowed to perform an action'
+	// The action is restricted to other users'
+	// Its error code is 61
+	UnauthorizedError
+
+	// ItemAlreadyExistsError means that the system is unable to save an item because it should be unique'
+	// The item has already been saved'
+	// Its error code is 62
+	ItemAlreadyExistsError
 )
@@ -115,4 +115,10 @@ const (
 
 	// GetFAQContentErrorMsg is the error message displayed when a faq content is not found
 	GetFAQContentErrorMsg = "faq content not found"
+
+	// UnauthorizedErrorMsg is the error message displayed when a user is not allowed to perform an action
+	UnauthorizedErrorMsg = "user is not authorized to perform this action"
+
+	// ItemAlreadyExistsErrorMsg is the error message displayed when an item that should be unique already exists
+	ItemAlreadyExistsErrorMsg = "item already exists"
 )
@@ -87,5 +87,9 @@ func TestCustomErrors(t *testing.T) {
 	assert.NotNil(t, err)
 	err = exceptions.GetFAQContentErr(fmt.Errorf("error"))
 	assert.NotNil(t, err)
+	err = exceptions.UnauthorizedErr(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+	err = exceptions.ItemAlreadyExistsErr(fmt.Errorf("error"))
+	assert.NotNil(t, err)
 
 }
@@ -31,8 +31,9 @@ type ExternalMethodsExtension interface {
 	GenerateOTP(ctx context.Context) (string, error)
 	GenerateRetryOTP(ctx context.Context, payload *dto.SendRetryOTPPayload) (string, error)
 	SendSMSViaTwilio(ctx context.Context, phonenumber, message string) error
-	SendInviteSMS(ctx context.Context, phoneNumber, message string) error
+	SendInviteSMS(ctx context.Context, phoneNumber, message string, senderID enumutils.SenderID) error
 	SendFeedback(ctx context.Context, subject, feedbackMessage string) (bool, error)
+	GetLoggedInUserUID(ctx context.Context) (string, error)
 }
 
 // External type implements external methods
@@ -118,10 +119,10 @@ func (e *External) SendSMSViaTwilio(ctx context.Context, phonenumber, message st
 	return e.twilioExtension.SendSMS(ctx, phonenumber, message)
 }
 
-// SendInviteSMS is used to send an Invite SMS to a client
-func (e *External) SendInviteSMS(ctx context.Context, phoneNumber, message string) error {
+// SendInviteSMS is used to send an Invite SMS to a client. Kenyan numbers receive the SMS from the provided sender ID
+func (e *External) SendInviteSMS(ctx context.Context, phoneNumber, message string, senderID enumutils.SenderID) error {
 	if interserviceclient.IsKenyanNumber(phoneNumber) {
-		_, err := e.SendSMS(ctx, phoneNumber, message, enumutils.SenderIDBewell)
+		_, err := e.SendSMS(ctx, phoneNumber, message, senderID)
 		if err != nil {
 			return fmt.Errorf("failed to send invite sms to recipient")
 		}
@@ -144,3 +145,9 @@ func (e *External) SendFeedback(ctx context.Context, subject, feedbackMessage st
 
 	return true, nil
 }
+
+// GetLoggedInUserUID retrieves the UID of the logged in user from the Firebase token set on the context
+// by the authentication middleware
+func (e *External) GetLoggedInUserUID(ctx context.Context) (string, error) {
+	return firebasetools.GetLoggedInUserUID(ctx)
+}
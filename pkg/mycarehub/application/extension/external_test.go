@@ -6,6 +6,7 @@ import (
 	"os"
 	"testing"
 
+	"firebase.google.com/go/auth"
 	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
@@ -170,3 +171,46 @@ func TestExternal_ComparePIN(t *testing.T) {
 		})
 	}
 }
+
+func TestExternal_GetLoggedInUserUID(t *testing.T) {
+	uid := ksuid.New().String()
+	authenticatedContext := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uid})
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "valid: uid set on context",
+			args: args{
+				ctx: authenticatedContext,
+			},
+			want:    uid,
+			wantErr: false,
+		},
+		{
+			name: "invalid: missing auth token",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ext.GetLoggedInUserUID(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("External.GetLoggedInUserUID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("External.GetLoggedInUserUID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -24,8 +24,9 @@ type FakeExtensionImpl struct {
 	MockGenerateOTPFn                     func(ctx context.Context) (string, error)
 	MockGenerateRetryOTPFn                func(ctx context.Context, payload *dto.SendRetryOTPPayload) (string, error)
 	MockSendSMSViaTwilioFn                func(ctx context.Context, phonenumber, message string) error
-	MockSendInviteSMSFn                   func(ctx context.Context, phoneNumber, message string) error
+	MockSendInviteSMSFn                   func(ctx context.Context, phoneNumber, message string, senderID enumutils.SenderID) error
 	MockSendFeedbackFn                    func(ctx context.Context, subject, feedbackMessage string) (bool, error)
+	MockGetLoggedInUserUIDFn              func(ctx context.Context) (string, error)
 }
 
 // NewFakeExtension initializes a new instance of the external calls mock
@@ -75,12 +76,15 @@ func NewFakeExtension() *FakeExtensionImpl {
 		MockSendSMSViaTwilioFn: func(ctx context.Context, phonenumber, message string) error {
 			return nil
 		},
-		MockSendInviteSMSFn: func(ctx context.Context, phoneNumber, message string) error {
+		MockSendInviteSMSFn: func(ctx context.Context, phoneNumber, message string, senderID enumutils.SenderID) error {
 			return nil
 		},
 		MockSendFeedbackFn: func(ctx context.Context, subject, feedbackMessage string) (bool, error) {
 			return true, nil
 		},
+		MockGetLoggedInUserUIDFn: func(ctx context.Context) (string, error) {
+			return uuid.New().String(), nil
+		},
 	}
 }
 
@@ -135,11 +139,16 @@ func (f *FakeExtensionImpl) SendSMSViaTwilio(ctx context.Context, phonenumber, m
 }
 
 // SendInviteSMS mocks the implementation of sending an invite sms
-func (f *FakeExtensionImpl) SendInviteSMS(ctx context.Context, phoneNumber, message string) error {
-	return f.MockSendInviteSMSFn(ctx, phoneNumber, message)
+func (f *FakeExtensionImpl) SendInviteSMS(ctx context.Context, phoneNumber, message string, senderID enumutils.SenderID) error {
+	return f.MockSendInviteSMSFn(ctx, phoneNumber, message, senderID)
 }
 
 //SendFeedback mocks the implementation sending feedback
 func (f *FakeExtensionImpl) SendFeedback(ctx context.Context, subject, feedbackMessage string) (bool, error) {
 	return f.MockSendFeedbackFn(ctx, subject, feedbackMessage)
 }
+
+// GetLoggedInUserUID mocks the implementation of getting the logged in user UID
+func (f *FakeExtensionImpl) GetLoggedInUserUID(ctx context.Context) (string, error) {
+	return f.MockGetLoggedInUserUIDFn(ctx)
+}
@@ -40,6 +40,12 @@ func NextAllowedLoginTime(loginAttempts int) time.Time {
 
 // MakeRequest performs a http request and returns a response
 func MakeRequest(ctx context.Context, method string, path string, body interface{}) (*http.Response, error) {
+	return MakeRequestWithHeaders(ctx, method, path, body, nil)
+}
+
+// MakeRequestWithHeaders performs a http request with the provided headers set in addition to the default ones.
+// It is used for requests that need extra headers e.g conditional requests that send `If-None-Match`
+func MakeRequestWithHeaders(ctx context.Context, method string, path string, body interface{}, headers http.Header) (*http.Response, error) {
 	token := serverutils.MustGetEnvVar(DjangoAuthorizationToken)
 	client := http.Client{}
 	// A GET request should not send data when doing a request. We should use query parameters
@@ -55,6 +61,7 @@ func MakeRequest(ctx context.Context, method string, path string, body interface
 		req.Header.Set("Authorization", "Token "+token)
 		req.Header.Set("Accept", "application/json")
 		req.Header.Set("Content-Type", "application/json")
+		setHeaders(req, headers)
 
 		return client.Do(req)
 	}
@@ -73,6 +80,15 @@ func MakeRequest(ctx context.Context, method string, path string, body interface
 	req.Header.Set("Authorization", "Token "+token)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, headers)
 
 	return client.Do(req)
 }
+
+func setHeaders(req *http.Request, headers http.Header) {
+	for key, values := range headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+}
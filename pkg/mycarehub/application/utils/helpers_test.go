@@ -2,6 +2,8 @@ package utils
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -108,3 +110,65 @@ func TestMakeRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeRequestWithHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != `"v1"` || r.Header.Get("Accept") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer srv.Close()
+
+	type args struct {
+		ctx     context.Context
+		method  string
+		headers http.Header
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+	}{
+		{
+			name: "Happy case GET",
+			args: args{
+				ctx:     context.Background(),
+				method:  http.MethodGet,
+				headers: http.Header{"If-None-Match": []string{`"v1"`}},
+			},
+			wantStatus: http.StatusNotModified,
+		},
+		{
+			name: "Happy case POST",
+			args: args{
+				ctx:     context.Background(),
+				method:  http.MethodPost,
+				headers: http.Header{"If-None-Match": []string{`"v1"`}},
+			},
+			wantStatus: http.StatusNotModified,
+		},
+		{
+			name: "Sad case - headers not sent",
+			args: args{
+				ctx:    context.Background(),
+				method: http.MethodGet,
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MakeRequestWithHeaders(tt.args.ctx, tt.args.method, srv.URL, nil, tt.args.headers)
+			if err != nil {
+				t.Errorf("MakeRequestWithHeaders() error = %v", err)
+				return
+			}
+			defer got.Body.Close()
+			if got.StatusCode != tt.wantStatus {
+				t.Errorf("MakeRequestWithHeaders() status = %v, want %v", got.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
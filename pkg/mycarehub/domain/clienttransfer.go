@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+)
+
+// ClientTransfer is a request to move a client from their current facility to another facility.
+// The client is only moved once the receiving facility accepts the transfer.
+type ClientTransfer struct {
+	ID               string                     `json:"id"`
+	ClientID         string                     `json:"clientID"`
+	FromFacilityID   string                     `json:"fromFacilityID"`
+	ToFacilityID     string                     `json:"toFacilityID"`
+	Reason           string                     `json:"reason"`
+	Status           enums.ClientTransferStatus `json:"status"`
+	RequestedByID    string                     `json:"requestedByID"`
+	RequestedAt      time.Time                  `json:"requestedAt"`
+	RespondedByID    *string                    `json:"respondedByID"`
+	RespondedAt      *time.Time                 `json:"respondedAt"`
+	ResponseNote     string                     `json:"responseNote"`
+	ServiceRequestID *string                    `json:"serviceRequestID"`
+}
+
+// ClientFacilityPeriod is a period that a client was assigned to a facility.
+// The period at the client's current facility has no end date.
+type ClientFacilityPeriod struct {
+	ID         string     `json:"id"`
+	ClientID   string     `json:"clientID"`
+	FacilityID string     `json:"facilityID"`
+	StartDate  time.Time  `json:"startDate"`
+	EndDate    *time.Time `json:"endDate"`
+	TransferID *string    `json:"transferID"`
+}
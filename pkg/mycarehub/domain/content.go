@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+)
+
 // Content aggregates all content details into one payload that is returned from an API and
 // rendered on the front end
 type Content struct {
@@ -7,10 +13,154 @@ type Content struct {
 	Items []ContentItem `json:"items"`
 }
 
+// ContentSearchPage is a page of the content items that match a search, best match first
+type ContentSearchPage struct {
+	Pagination Pagination             `json:"pagination"`
+	Results    []*ContentSearchResult `json:"results"`
+}
+
+// ContentSearchResult is a content item that matches a search. The snippet is the part of the item's text that
+// matches the search with the matching words wrapped in <mark> tags
+type ContentSearchResult struct {
+	Item    ContentItem `json:"item"`
+	Rank    float64     `json:"rank"`
+	Snippet string      `json:"snippet"`
+}
+
+// ContentEngagementReport compares the engagement counters kept on content items with the engagement recorded
+// per user. When the report is fixed, the counters that had drifted have been rewritten.
+type ContentEngagementReport struct {
+	Drift                []*ContentCounterDrift `json:"drift"`
+	DuplicateEngagements int                    `json:"duplicateEngagements"`
+	Fixed                bool                   `json:"fixed"`
+}
+
+// ContentCounterDrift is a content item's counter e.g `like_count` that doesn't match the number of users who
+// have engaged with the item
+type ContentCounterDrift struct {
+	ContentID int    `json:"contentID"`
+	Counter   string `json:"counter"`
+	Recorded  int    `json:"recorded"`
+	Actual    int    `json:"actual"`
+}
+
+// ContentAnalytics reports how clients engaged with content between two times, per content item and per category
+type ContentAnalytics struct {
+	From       time.Time                   `json:"from"`
+	To         time.Time                   `json:"to"`
+	Items      []*ContentItemAnalytics     `json:"items"`
+	Categories []*ContentCategoryAnalytics `json:"categories"`
+}
+
+// ContentItemAnalytics is the engagement with a content item. Views counts the views recorded in the period while
+// unique viewers counts each user who viewed or read it once. Read durations only make up the average read. Likes,
+// bookmarks and shares are those made in the period that have not been undone.
+type ContentItemAnalytics struct {
+	ContentID          int     `json:"contentID"`
+	Title              string  `json:"title"`
+	Views              int     `json:"views"`
+	UniqueViewers      int     `json:"uniqueViewers"`
+	Likes              int     `json:"likes"`
+	Bookmarks          int     `json:"bookmarks"`
+	Shares             int     `json:"shares"`
+	AverageReadSeconds float64 `json:"averageReadSeconds"`
+}
+
+// ContentCategoryAnalytics is the engagement with the content items in a category. A user who viewed several
+// items in the category is counted once in unique viewers.
+type ContentCategoryAnalytics struct {
+	CategoryID         int     `json:"categoryID"`
+	Name               string  `json:"name"`
+	ContentItems       int     `json:"contentItems"`
+	Views              int     `json:"views"`
+	UniqueViewers      int     `json:"uniqueViewers"`
+	Likes              int     `json:"likes"`
+	Bookmarks          int     `json:"bookmarks"`
+	Shares             int     `json:"shares"`
+	AverageReadSeconds float64 `json:"averageReadSeconds"`
+}
+
+// ContentProgress is how far a user has got with a content item. Seconds spent adds up the time spent on the item
+// across reads while percent scrolled is the furthest the user has scrolled through it.
+type ContentProgress struct {
+	ContentID       int          `json:"contentID"`
+	SecondsSpent    int          `json:"secondsSpent"`
+	PercentScrolled float64      `json:"percentScrolled"`
+	Completed       bool         `json:"completed"`
+	CompletedAt     *time.Time   `json:"completedAt"`
+	UpdatedAt       time.Time    `json:"updatedAt"`
+	Item            *ContentItem `json:"item,omitempty"`
+}
+
+// ContentProgressUpdate is a user's progress with a content item after they report it. JustCompleted is only true
+// for the report that completed the item.
+type ContentProgressUpdate struct {
+	Progress      ContentProgress `json:"progress"`
+	JustCompleted bool            `json:"justCompleted"`
+}
+
+// ContentShareLink is a signed link to a content item that a user shared. The link opens the item in the app when
+// it is installed and falls back to the public web view otherwise.
+type ContentShareLink struct {
+	ID        string                    `json:"id"`
+	ContentID int                       `json:"contentID"`
+	UserID    string                    `json:"userID"`
+	Channel   enums.ContentShareChannel `json:"channel"`
+	Link      string                    `json:"link"`
+	CreatedAt time.Time                 `json:"createdAt"`
+}
+
+// ContentComment is a comment on a content item. Comments show the nickname of the user who wrote them rather
+// than their name. Replies are only set on the comments that start a thread and a removed comment keeps its place
+// in the thread without its body when it has replies.
+type ContentComment struct {
+	ID        string                     `json:"id"`
+	ContentID int                        `json:"contentID"`
+	UserID    string                     `json:"-"`
+	ParentID  *string                    `json:"parentID"`
+	Nickname  string                     `json:"nickname"`
+	Body      string                     `json:"body"`
+	Status    enums.ContentCommentStatus `json:"status"`
+	IsAuthor  bool                       `json:"isAuthor"`
+	Removed   bool                       `json:"removed"`
+	Deleted   bool                       `json:"-"`
+	EditedAt  *time.Time                 `json:"editedAt"`
+	CreatedAt time.Time                  `json:"createdAt"`
+	Replies   []*ContentComment          `json:"replies"`
+}
+
+// ContentCompletionThreshold is how far a user must get with a content item for it to count as completed. The
+// share of the time estimate is compared with the item's estimated reading time.
+type ContentCompletionThreshold struct {
+	PercentScrolled     float64
+	ShareOfTimeEstimate float64
+}
+
 // Meta holds the information that shows the total count of items returned from the API
 // The total count displayed is irrespective of pagination
 type Meta struct {
 	TotalCount int `json:"total_count"`
+
+	// NextCursor and PreviousCursor are used to fetch the pages either side of a page of the content feed
+	NextCursor     *string `json:"next_cursor,omitempty"`
+	PreviousCursor *string `json:"previous_cursor,omitempty"`
+}
+
+// ContentFeedPosition is where a content item sits in the content feed. The sort key is the value the feed is
+// ordered by e.g the item's like count when the most liked content comes first. Newest first feeds have no sort key.
+type ContentFeedPosition struct {
+	SortKey          int64     `json:"sortKey,omitempty"`
+	FirstPublishedAt time.Time `json:"firstPublishedAt"`
+	ContentID        int       `json:"contentID"`
+}
+
+// ContentFeedPage is a page of the content feed together with the position of each of its items
+type ContentFeedPage struct {
+	Content   *Content
+	Positions []*ContentFeedPosition
+
+	// HasMore is true when there is more content past the page in the direction it was read in
+	HasMore bool
 }
 
 // ContentItem holds all the information necessary relating to content item
@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+)
+
 // Facility models the details of healthcare facilities that are on the platform.
 //
 // e.g CCC clinics, Pharmacies.
@@ -16,6 +22,84 @@ type Facility struct {
 	Active      bool   `json:"active"`
 	County      string `json:"county"` // TODO: Controlled list of counties
 	Description string `json:"description"`
+
+	// the facility's location. Facilities without coordinates are left out of nearby facility searches
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+	Address   string   `json:"address"`
+
+	// OpeningHours is the facility's weekly schedule. A day without opening hours is a day the facility is closed.
+	// HoursExceptions override the schedule on specific dates, such as public holidays, and only upcoming
+	// exceptions are loaded with the facility.
+	OpeningHours    []*FacilityOpeningHours   `json:"openingHours"`
+	HoursExceptions []*FacilityHoursException `json:"hoursExceptions"`
+	Services        []*FacilityService        `json:"services"`
+}
+
+// FacilityOpeningHours are the hours that a facility is open on a day of the week.
+// The times are in Kenyan time using the 24 hour clock e.g 08:00 to 17:00
+type FacilityOpeningHours struct {
+	DayOfWeek enums.DayOfWeek `json:"dayOfWeek"`
+	OpensAt   string          `json:"opensAt"`
+	ClosesAt  string          `json:"closesAt"`
+}
+
+// FacilityHoursException changes a facility's opening hours on a date e.g a public holiday or a staff training day.
+// The facility is either closed for the whole day or open between the exception's hours.
+type FacilityHoursException struct {
+	Date     string  `json:"date"`
+	Closed   bool    `json:"closed"`
+	OpensAt  *string `json:"opensAt"`
+	ClosesAt *string `json:"closesAt"`
+	Reason   string  `json:"reason"`
+}
+
+// FacilityService is a service, such as ART refills or viral load testing, that facilities can offer.
+// Services are looked up by their code e.g ART_REFILL
+type FacilityService struct {
+	ID          *string `json:"id"`
+	Name        string  `json:"name"`
+	Code        string  `json:"code"`
+	Description string  `json:"description"`
+	Active      bool    `json:"active"`
+}
+
+// NearbyFacility is a facility found near a location together with its distance from that location
+type NearbyFacility struct {
+	Facility   Facility `json:"facility"`
+	DistanceKm float64  `json:"distanceKm"`
+}
+
+// FacilityHistory records a change that a user made to one of a facility's details
+type FacilityHistory struct {
+	ID         *string   `json:"id"`
+	FacilityID string    `json:"facilityID"`
+	Field      string    `json:"field"`
+	OldValue   string    `json:"oldValue"`
+	NewValue   string    `json:"newValue"`
+	ChangedBy  string    `json:"changedBy"`
+	ChangedAt  time.Time `json:"changedAt"`
+}
+
+// FacilityImportReport summarises the outcome of importing facilities from a Kenya Master Facility List export.
+// A dry run reports what would change without saving anything.
+type FacilityImportReport struct {
+	DryRun    bool                 `json:"dryRun"`
+	Total     int                  `json:"total"`
+	Created   int                  `json:"created"`
+	Updated   int                  `json:"updated"`
+	Unchanged int                  `json:"unchanged"`
+	Invalid   int                  `json:"invalid"`
+	Rows      []*FacilityImportRow `json:"rows"`
+}
+
+// FacilityImportRow is the outcome of importing a single facility in a KMFL export
+type FacilityImportRow struct {
+	RowNumber int                        `json:"rowNumber"`
+	MFLCode   int                        `json:"mflCode"`
+	Name      string                     `json:"name"`
+	Status    enums.FacilityImportStatus `json:"status"`
+	Error     string                     `json:"error"`
 }
 
 //FacilityPage returns a list of paginates facilities
@@ -3,45 +3,165 @@ package domain
 import (
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 )
 
+const (
+	// dateRangeSeparator separates the start and the end of a date range filter, as in ISO 8601 time intervals
+	dateRangeSeparator = "/"
+
+	// openDateRangeBound is used in place of the start or the end of a date range that is open on that side
+	openDateRangeBound = ".."
+)
+
+// FilterValidationError is returned when a filter is not valid. It names the offending filter
+type FilterValidationError struct {
+	Filter enums.FilterSortDataType
+	Err    error
+}
+
+// Error returns the reason the filter is not valid, prefixed by the filter's name
+func (e *FilterValidationError) Error() string {
+	return fmt.Sprintf("invalid %s filter: %v", e.Filter, e.Err)
+}
+
+// Unwrap returns the underlying validation error
+func (e *FilterValidationError) Unwrap() error {
+	return e.Err
+}
+
 // FiltersParam contains the inputs for filter parameters
 type FiltersParam struct {
 	Name     string
 	DataType enums.FilterSortDataType
-	Value    string // TODO: Clear spec on validation e.g dates must be ISO 8601. This is the actual data being filtered
+	Value    string // This is the actual data being filtered. Created and updated dates are ISO 8601 intervals, see DateRange
 }
 
 // Validate is a filter param method that performs validations
 func (f FiltersParam) Validate() error {
-	if f.DataType == enums.FilterSortDataTypeName {
+	if err := f.validateValue(); err != nil {
+		return &FilterValidationError{Filter: f.DataType, Err: err}
+	}
+	return nil
+}
+
+// ValidateForCategory validates the filter and checks that it can be used in the category e.g facilities
+func (f FiltersParam) ValidateForCategory(category enums.FilterSortCategoryType) error {
+	if err := enums.ValidateFilterSortCategories(category, f.DataType); err != nil {
+		return &FilterValidationError{Filter: f.DataType, Err: fmt.Errorf("filter is not available in %s: %v", category, err)}
+	}
+	return f.Validate()
+}
+
+func (f FiltersParam) validateValue() error {
+	switch f.DataType {
+	case enums.FilterSortDataTypeName:
 		if f.Value == "" {
 			return fmt.Errorf("name cannot be empty")
 		}
-	}
-	if f.DataType == enums.FilterSortDataTypeMFLCode {
+	case enums.FilterSortDataTypeMFLCode:
 		if f.Value == "" {
 			return fmt.Errorf("MFL code cannot be empty")
 		}
-	}
-	if f.DataType == enums.FilterSortDataTypeActive {
+		if _, err := strconv.Atoi(f.Value); err != nil {
+			return fmt.Errorf("MFL code %v is not a number", f.Value)
+		}
+	case enums.FilterSortDataTypeActive, enums.FilterSortDataTypeOpenNow:
 		_, err := strconv.ParseBool(f.Value)
 		if err != nil {
 			return fmt.Errorf("failed to convert to bool %v: %v", f.Value, err)
 		}
-	}
-	if f.DataType == enums.FilterSortDataTypeCounty {
+	case enums.FilterSortDataTypeCounty:
 		ok := enums.CountyType(f.Value).IsValid()
 		if !ok {
 			return fmt.Errorf("invalid county passed: %v", f.Value)
 		}
+	case enums.FilterSortDataTypeService:
+		if f.Value == "" {
+			return fmt.Errorf("service code cannot be empty")
+		}
+	case enums.FilterSortDataTypeCreatedAt, enums.FilterSortDataTypeUpdatedAt:
+		_, _, err := f.DateRange()
+		return err
+	}
+	return nil
+}
+
+// DateRange returns the bounds of a created or updated date filter. The value is an ISO 8601 interval whose start
+// and end are either dates e.g 2021-01-01/2021-03-31 or RFC 3339 timestamps. One side of the range can be left open
+// with "..", e.g 2021-01-01/.. matches everything from the start of 2021.
+//
+// The start is inclusive while the end returned is exclusive. An end given as a date includes the whole of that day.
+func (f FiltersParam) DateRange() (*time.Time, *time.Time, error) {
+	bounds := strings.Split(f.Value, dateRangeSeparator)
+	if len(bounds) != 2 {
+		return nil, nil, fmt.Errorf("date range %q must be a start and an end separated by %q", f.Value, dateRangeSeparator)
+	}
+
+	from, _, err := parseDateRangeBound(bounds[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	to, isDate, err := parseDateRangeBound(bounds[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	if from == nil && to == nil {
+		return nil, nil, fmt.Errorf("date range %q must have a start or an end", f.Value)
+	}
+	if to != nil && isDate {
+		end := to.AddDate(0, 0, 1)
+		to = &end
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, fmt.Errorf("date range %q must start before it ends", f.Value)
+	}
+	return from, to, nil
+}
+
+// parseDateRangeBound parses one side of a date range, reporting whether it was a date rather than a timestamp
+func parseDateRangeBound(bound string) (*time.Time, bool, error) {
+	bound = strings.TrimSpace(bound)
+	if bound == "" || bound == openDateRangeBound {
+		return nil, false, nil
+	}
+	if date, err := time.Parse(common.DateLayout, bound); err == nil {
+		return &date, true, nil
+	}
+	timestamp, err := time.Parse(time.RFC3339, bound)
+	if err != nil {
+		return nil, false, fmt.Errorf("%q is neither a YYYY-MM-DD date nor an RFC 3339 timestamp", bound)
+	}
+	return &timestamp, false, nil
+}
+
+// FilterGroup is a set of filters combined with an operator e.g facilities in Nairobi OR Kiambu.
+// Groups are combined with each other, and with any other filters, using AND
+type FilterGroup struct {
+	Operator enums.FilterOperator
+	Filters  []*FiltersParam
+}
+
+// ValidateForCategory validates the group and checks that each of its filters can be used in the category
+func (g FilterGroup) ValidateForCategory(category enums.FilterSortCategoryType) error {
+	if !g.Operator.IsValid() {
+		return fmt.Errorf("invalid filter group operator: %v", g.Operator)
+	}
+	if len(g.Filters) == 0 {
+		return fmt.Errorf("a filter group must have at least one filter")
+	}
+	for _, f := range g.Filters {
+		if f == nil {
+			return fmt.Errorf("a filter group cannot have an empty filter")
+		}
+		if err := f.ValidateForCategory(category); err != nil {
+			return err
+		}
 	}
-	// Validate enums
-	// TODO: Very strict validation of data <-> data type
-	// 	     this is a good candidate for TDD with unit tests
-	// TODO: make sure this is always called before filter params are used
 	return nil
 }
 
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+)
+
+func TestFiltersParam_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  FiltersParam
+		wantErr bool
+	}{
+		{
+			name:    "valid: MFL code",
+			filter:  FiltersParam{DataType: enums.FilterSortDataTypeMFLCode, Value: "1234"},
+			wantErr: false,
+		},
+		{
+			name:    "valid: created date range",
+			filter:  FiltersParam{DataType: enums.FilterSortDataTypeCreatedAt, Value: "2021-01-01/2021-03-31"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid: MFL code is not a number",
+			filter:  FiltersParam{DataType: enums.FilterSortDataTypeMFLCode, Value: "abc"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: county",
+			filter:  FiltersParam{DataType: enums.FilterSortDataTypeCounty, Value: "Atlantis"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: updated date range",
+			filter:  FiltersParam{DataType: enums.FilterSortDataTypeUpdatedAt, Value: "2021-01-01"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FiltersParam.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var filterErr *FilterValidationError
+			if tt.wantErr && (!errors.As(err, &filterErr) || filterErr.Filter != tt.filter.DataType) {
+				t.Errorf("expected the error to name the %v filter, got %v", tt.filter.DataType, err)
+			}
+		})
+	}
+}
+
+func TestFiltersParam_ValidateForCategory(t *testing.T) {
+	filter := FiltersParam{DataType: enums.FilterSortDataTypeDistance, Value: "10"}
+
+	err := filter.ValidateForCategory(enums.FilterSortCategoryTypeFacility)
+	var filterErr *FilterValidationError
+	if !errors.As(err, &filterErr) || filterErr.Filter != enums.FilterSortDataTypeDistance {
+		t.Errorf("expected the distance filter to be unavailable for facilities, got %v", err)
+	}
+}
+
+func TestFiltersParam_DateRange(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	endOfMarch := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	noon := time.Date(2021, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		wantFrom *time.Time
+		wantTo   *time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "dates include the whole of the last day",
+			value:    "2021-01-01/2021-03-31",
+			wantFrom: &start,
+			wantTo:   &endOfMarch,
+		},
+		{
+			name:     "timestamps are used as given",
+			value:    "2021-01-01T00:00:00Z/2021-03-31T12:00:00Z",
+			wantFrom: &start,
+			wantTo:   &noon,
+		},
+		{
+			name:     "open end",
+			value:    "2021-01-01/..",
+			wantFrom: &start,
+		},
+		{
+			name:   "open start",
+			value:  "../2021-03-31",
+			wantTo: &endOfMarch,
+		},
+		{
+			name:    "both sides open",
+			value:   "../..",
+			wantErr: true,
+		},
+		{
+			name:    "ends before it starts",
+			value:   "2021-03-31/2021-01-01",
+			wantErr: true,
+		},
+		{
+			name:    "not a date",
+			value:   "yesterday/today",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := FiltersParam{DataType: enums.FilterSortDataTypeCreatedAt, Value: tt.value}.DateRange()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FiltersParam.DateRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !sameTime(from, tt.wantFrom) || !sameTime(to, tt.wantTo) {
+				t.Errorf("FiltersParam.DateRange() = %v, %v, want %v, %v", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestFilterGroup_ValidateForCategory(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   FilterGroup
+		wantErr bool
+	}{
+		{
+			name: "valid: counties",
+			group: FilterGroup{
+				Operator: enums.FilterOperatorOr,
+				Filters: []*FiltersParam{
+					{DataType: enums.FilterSortDataTypeCounty, Value: enums.CountyTypeNairobi.String()},
+					{DataType: enums.FilterSortDataTypeCounty, Value: enums.CountyTypeKiambu.String()},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid: operator",
+			group: FilterGroup{
+				Operator: enums.FilterOperator("XOR"),
+				Filters:  []*FiltersParam{{DataType: enums.FilterSortDataTypeActive, Value: "true"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: no filters",
+			group:   FilterGroup{Operator: enums.FilterOperatorAnd},
+			wantErr: true,
+		},
+		{
+			name: "invalid: filter",
+			group: FilterGroup{
+				Operator: enums.FilterOperatorAnd,
+				Filters:  []*FiltersParam{{DataType: enums.FilterSortDataTypeActive, Value: "maybe"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.group.ValidateForCategory(enums.FilterSortCategoryTypeFacility); (err != nil) != tt.wantErr {
+				t.Errorf("FilterGroup.ValidateForCategory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func sameTime(got, want *time.Time) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return got.Equal(*want)
+}
@@ -24,6 +24,7 @@ type ClientServiceRequest struct {
 	InProgressAt   time.Time `json:"inProgressAt"`
 	ResolvedAt     time.Time `json:"resolvedAt"`
 	ClientID       string    `json:"clientID"`
+	FacilityID     string    `json:"facilityID"`
 	InProgressByID string    `json:"inProgressByID"`
 	ResolvedByID   string    `json:"resolvedByID"`
 }
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+)
+
+// BulkInviteJob tracks the progress of inviting the users uploaded in a CSV file
+type BulkInviteJob struct {
+	ID          string                    `json:"id"`
+	Status      enums.BulkInviteJobStatus `json:"status"`
+	Flavour     feedlib.Flavour           `json:"flavour"`
+	Total       int                       `json:"total"`
+	Succeeded   int                       `json:"succeeded"`
+	Failed      int                       `json:"failed"`
+	Rows        []*BulkInviteRow          `json:"rows"`
+	CreatedBy   string                    `json:"createdBy"`
+	CreatedAt   time.Time                 `json:"createdAt"`
+	CompletedAt *time.Time                `json:"completedAt"`
+}
+
+// BulkInviteRow is a single user to be invited in a bulk invite job and the outcome of the invite
+type BulkInviteRow struct {
+	ID          string                    `json:"id"`
+	RowNumber   int                       `json:"rowNumber"`
+	UserID      string                    `json:"userID"`
+	PhoneNumber string                    `json:"phoneNumber"`
+	Status      enums.BulkInviteRowStatus `json:"status"`
+	Error       string                    `json:"error"`
+}
+
+// Invitation records an invite sent to a user and tracks whether it was delivered and accepted
+type Invitation struct {
+	ID          string                 `json:"id"`
+	UserID      string                 `json:"userID"`
+	PhoneNumber string                 `json:"phoneNumber"`
+	Flavour     feedlib.Flavour        `json:"flavour"`
+	Status      enums.InvitationStatus `json:"status"`
+	IssuedAt    time.Time              `json:"issuedAt"`
+	DeliveredAt *time.Time             `json:"deliveredAt"`
+	AcceptedAt  *time.Time             `json:"acceptedAt"`
+	ExpiresAt   time.Time              `json:"expiresAt"`
+}
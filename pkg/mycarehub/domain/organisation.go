@@ -0,0 +1,34 @@
+package domain
+
+import "github.com/savannahghi/enumutils"
+
+// Organisation is a partner program whose users, facilities and content share a deployment
+type Organisation struct {
+	ID              *string `json:"id"`
+	Active          bool    `json:"active"`
+	OrgCode         string  `json:"orgCode"`
+	Code            int     `json:"code"`
+	Name            string  `json:"name"`
+	EmailAddress    string  `json:"emailAddress"`
+	PhoneNumber     string  `json:"phoneNumber"`
+	PostalAddress   string  `json:"postalAddress"`
+	PhysicalAddress string  `json:"physicalAddress"`
+	DefaultCountry  string  `json:"defaultCountry"`
+
+	Settings *OrganisationSettings `json:"settings"`
+}
+
+// OrganisationSettings are the settings that each organisation can tune for its users
+type OrganisationSettings struct {
+	// PINExpiryDays is the number of days a PIN set by a user is valid for
+	PINExpiryDays int `json:"pinExpiryDays"`
+
+	// InvitePINExpiryDays is the number of days the temporary PIN sent in an invite is valid for
+	InvitePINExpiryDays int `json:"invitePINExpiryDays"`
+
+	// DiaryCadenceHours is how long a client has to wait after recording their health diary before recording again
+	DiaryCadenceHours int `json:"diaryCadenceHours"`
+
+	// SMSSenderID is the sender ID of the SMS sent to the organisation's users
+	SMSSenderID enumutils.SenderID `json:"smsSenderID"`
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"github.com/savannahghi/enumutils"
@@ -8,6 +9,18 @@ import (
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 )
 
+var (
+	// ErrUsernameTaken is returned when a user is saved with a username that belongs to another user
+	ErrUsernameTaken = errors.New("username is already taken")
+
+	// ErrContactTaken is returned when a contact e.g a phone number is saved for a user when it belongs to
+	// another user
+	ErrContactTaken = errors.New("contact is already registered")
+
+	// ErrStaffNumberTaken is returned when a staff profile is saved with another staff member's staff number
+	ErrStaffNumberTaken = errors.New("staff number is already taken")
+)
+
 // User  holds details that both the client and staff have in common
 //
 // Client and Staff cannot exist without being a user
@@ -30,7 +43,7 @@ type User struct {
 	Contacts *Contact `json:"primaryContact"`
 
 	// for the preferred language list, order matters
-	// Languages []enumutils.Language `json:"languages"`
+	Languages []enumutils.Language `json:"languages"`
 
 	// PushTokens []string
 
@@ -59,6 +72,8 @@ type User struct {
 	Flavour         feedlib.Flavour `json:"flavour"`
 	Suspended       bool            `json:"suspended"`
 	Avatar          string          `json:"avatar"`
+
+	OrganisationID string `json:"organisationID"`
 }
 
 // ClientProfile holds the details of end users who are not using the system in
@@ -89,6 +104,38 @@ type ClientProfile struct {
 	CHVUserID string `json:"CHVUserID"`
 }
 
+// StaffProfile holds the details of users who use the system in a professional capacity
+// e.g clinicians and community health volunteers
+type StaffProfile struct {
+	ID     *string `json:"id"`
+	User   *User   `json:"user"`
+	UserID string  `json:"userID"`
+	Active bool    `json:"active"`
+
+	StaffNumber string           `json:"staffNumber"`
+	Cadre       enums.StaffCadre `json:"cadre"`
+
+	DefaultFacilityID string `json:"defaultFacilityID"`
+
+	// Facilities are all the facilities the staff member is allowed to access,
+	// including the default facility
+	Facilities []*Facility `json:"facilities"`
+
+	OrganisationID string `json:"organisationID"`
+}
+
+// Identity is the mycarehub user behind an authenticated request.
+// ClientID is only set for clients and StaffID only for staff.
+// All the data read and written during the request is scoped to the user's organisation.
+type Identity struct {
+	UID            string          `json:"uid"`
+	UserID         string          `json:"userID"`
+	UserType       enums.UsersType `json:"userType"`
+	ClientID       string          `json:"clientID"`
+	StaffID        string          `json:"staffID"`
+	OrganisationID string          `json:"organisationID"`
+}
+
 // AuthCredentials is the authentication credentials for a given user
 type AuthCredentials struct {
 	RefreshToken string `json:"refreshToken"`
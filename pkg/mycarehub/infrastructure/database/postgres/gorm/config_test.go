@@ -1,6 +1,7 @@
 package gorm_test
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -12,6 +13,7 @@ import (
 	"github.com/brianvoe/gofakeit"
 	"github.com/go-testfixtures/testfixtures/v3"
 	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
 )
@@ -74,15 +76,43 @@ var (
 	authorID   = "4181df12-ca96-4f28-b78b-8e8ad88b25df"
 	authorID2  = "4181df12-ca96-4f28-b78b-8e8ad88b25de"
 
+	// Invitation variables
+	invitationID  = "a1b2c3d4-58d4-11ec-bf63-0242ac130002"
+	invitationID2 = "a1b2c3d4-58d4-11ec-bf63-0242ac130003"
+
+	// Staff variables
+	staffID          = "c4b1f9a2-58d4-11ec-bf63-0242ac130002"
+	staffFacilityID2 = "4181df12-ca96-4f28-b78b-8e8ad88b25de"
+
+	// User role variables
+	userRoleID = "d7a5e1c0-58d4-11ec-bf63-0242ac130002"
+
 	// contact variables
 	// contactID = "bdc22436-e314-43f2-bb39-ba1ab332f9b0"
 )
 
+// newTestContext returns the context that the tests run with. The tests are not run on behalf of a logged in
+// user hence their statements span all the organisations.
+func newTestContext() context.Context {
+	return helpers.ContextForAllOrganisations(context.Background())
+}
+
+// newTestPGInstance returns a database instance whose statements that are not given a context, e.g those
+// used to set up and clean up the tests, span all the organisations
+func newTestPGInstance() (*gorm.PGInstance, error) {
+	pg, err := gorm.NewPGInstance()
+	if err != nil {
+		return nil, err
+	}
+	pg.DB = pg.DB.WithContext(newTestContext())
+	return pg, nil
+}
+
 func TestMain(m *testing.M) {
 	log.Println("setting up test database")
 	var err error
 
-	testingDB, err = gorm.NewPGInstance()
+	testingDB, err = newTestPGInstance()
 	if err != nil {
 		fmt.Println("failed to initialize db:", err)
 		os.Exit(1)
@@ -92,6 +122,10 @@ func TestMain(m *testing.M) {
 		fmt.Println("failed to initialize db:", err)
 		os.Exit(1)
 	}
+	if _, err = testingDB.Migrate(newTestContext()); err != nil {
+		fmt.Println("failed to migrate db:", err)
+		os.Exit(1)
+	}
 
 	// setup test variables
 	newExtension = extension.NewExternalMethodsImpl()
@@ -136,6 +170,13 @@ func TestMain(m *testing.M) {
 			"security_question_response_id2": securityQuestionResponseID2,
 			"security_question_response_id3": securityQuestionResponseID3,
 			"security_question_response_id4": securityQuestionResponseID4,
+
+			"invitation_id":  invitationID,
+			"invitation_id2": invitationID2,
+
+			"staff_id": staffID,
+
+			"user_role_id": userRoleID,
 		}),
 		// this is the directory containing the YAML files.
 		// The file name should be the same as the table name
@@ -153,7 +194,11 @@ func TestMain(m *testing.M) {
 			"../../../../../../fixtures/users_userotp.yml",
 			"../../../../../../fixtures/common_facility.yml",
 			"../../../../../../fixtures/users_userpin.yml",
+			"../../../../../../fixtures/users_invitation.yml",
 			"../../../../../../fixtures/clients_client.yml",
+			"../../../../../../fixtures/staff_staff.yml",
+			"../../../../../../fixtures/staff_staff_facilities.yml",
+			"../../../../../../fixtures/users_userrole.yml",
 		),
 		// uncomment when running tests locally, if your db is not a test db
 		// Ensure the testing db in the ci is named `test`
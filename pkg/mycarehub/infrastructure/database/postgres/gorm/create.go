@@ -2,9 +2,19 @@ package gorm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// uniqueViolationCode is the error code postgres raises when an insert or update duplicates a unique value
+const uniqueViolationCode = "23505"
+
 // Create contains all the methods used to perform a create operation in DB
 type Create interface {
 	GetOrCreateFacility(ctx context.Context, facility *Facility) (*Facility, error)
@@ -14,6 +24,16 @@ type Create interface {
 	SaveSecurityQuestionResponse(ctx context.Context, securityQuestionResponse []*SecurityQuestionResponse) error
 	CreateHealthDiaryEntry(ctx context.Context, healthDiaryInput *ClientHealthDiaryEntry) error
 	CreateServiceRequest(ctx context.Context, serviceRequestInput *ClientServiceRequest) error
+	SaveInvitation(ctx context.Context, invitation *Invitation) (*Invitation, error)
+	RegisterClient(ctx context.Context, user *User, contact *Contact, client *Client) (*Client, error)
+	RegisterStaff(ctx context.Context, user *User, contact *Contact, staff *StaffProfile) (*StaffProfile, error)
+	CreateStaffProfile(ctx context.Context, staff *StaffProfile, facilityIDs []string) (*StaffProfile, error)
+	AssignRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error)
+	AssignFirstSystemAdmin(ctx context.Context, userID string) (bool, error)
+	CreateOrganisation(ctx context.Context, organisation *Organisation) (*Organisation, error)
+	CreateFacilityService(ctx context.Context, service *FacilityService) (*FacilityService, error)
+	CreateClientTransfer(ctx context.Context, transfer *ClientTransfer, serviceRequest *ClientServiceRequest) (*ClientTransfer, error)
+	CreateBulkInviteJob(ctx context.Context, job *BulkInviteJob) (*BulkInviteJob, error)
 }
 
 // GetOrCreateFacility is used to get or create a facility
@@ -21,7 +41,7 @@ func (db *PGInstance) GetOrCreateFacility(ctx context.Context, facility *Facilit
 	if facility == nil {
 		return nil, fmt.Errorf("facility must be provided")
 	}
-	err := db.DB.Create(facility).Error
+	err := db.DB.WithContext(ctx).Create(facility).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to create a facility: %v", err)
 	}
@@ -33,7 +53,7 @@ func (db *PGInstance) SaveTemporaryUserPin(ctx context.Context, pinPayload *PIND
 	if pinPayload == nil {
 		return false, fmt.Errorf("pinPayload must be provided")
 	}
-	err := db.DB.Create(pinPayload).Error
+	err := db.DB.WithContext(ctx).Create(pinPayload).Error
 	if err != nil {
 		return false, fmt.Errorf("failed to save a pin: %v", err)
 	}
@@ -42,7 +62,7 @@ func (db *PGInstance) SaveTemporaryUserPin(ctx context.Context, pinPayload *PIND
 
 // SavePin saves the pin to the database
 func (db *PGInstance) SavePin(ctx context.Context, pinData *PINData) (bool, error) {
-	err := db.DB.Create(pinData).Error
+	err := db.DB.WithContext(ctx).Create(pinData).Error
 
 	if err != nil {
 		return false, fmt.Errorf("failed to save pin data: %v", err)
@@ -58,14 +78,14 @@ func (db *PGInstance) SaveOTP(ctx context.Context, otpInput *UserOTP) error {
 		return fmt.Errorf("phone number cannot be empty")
 	}
 
-	err := db.DB.Model(&UserOTP{}).Where(&UserOTP{PhoneNumber: otpInput.PhoneNumber, Flavour: otpInput.Flavour}).
+	err := db.DB.WithContext(ctx).Model(&UserOTP{}).Where(&UserOTP{PhoneNumber: otpInput.PhoneNumber, Flavour: otpInput.Flavour}).
 		Updates(map[string]interface{}{"is_valid": false}).Error
 	if err != nil {
 		return fmt.Errorf("failed to update OTP data: %v", err)
 	}
 
 	//Save the OTP by setting valid to true
-	err = db.DB.Create(otpInput).Error
+	err = db.DB.WithContext(ctx).Create(otpInput).Error
 	if err != nil {
 		return fmt.Errorf("failed to save otp data")
 	}
@@ -75,7 +95,7 @@ func (db *PGInstance) SaveOTP(ctx context.Context, otpInput *UserOTP) error {
 // SaveSecurityQuestionResponse saves the security question response to the database if it does not exist,
 // otherwise it updates the existing one
 func (db *PGInstance) SaveSecurityQuestionResponse(ctx context.Context, securityQuestionResponse []*SecurityQuestionResponse) error {
-	tx := db.DB.Begin()
+	tx := db.DB.WithContext(ctx).Begin()
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
@@ -114,7 +134,7 @@ func (db *PGInstance) SaveSecurityQuestionResponse(ctx context.Context, security
 // CreateHealthDiaryEntry records the health diary entries from a client. This is necessary for engagement with clients
 // on a day-by-day basis
 func (db *PGInstance) CreateHealthDiaryEntry(ctx context.Context, healthDiaryInput *ClientHealthDiaryEntry) error {
-	tx := db.DB.Begin()
+	tx := db.DB.WithContext(ctx).Begin()
 
 	err := tx.Create(healthDiaryInput).Error
 	if err != nil {
@@ -137,7 +157,7 @@ func (db *PGInstance) CreateServiceRequest(
 	ctx context.Context,
 	serviceRequestInput *ClientServiceRequest,
 ) error {
-	tx := db.DB.Begin()
+	tx := db.DB.WithContext(ctx).Begin()
 
 	err := tx.Create(serviceRequestInput).Error
 	if err != nil {
@@ -152,3 +172,386 @@ func (db *PGInstance) CreateServiceRequest(
 
 	return nil
 }
+
+// SaveInvitation records an invite that has been sent to a user
+func (db *PGInstance) SaveInvitation(ctx context.Context, invitation *Invitation) (*Invitation, error) {
+	if invitation == nil {
+		return nil, fmt.Errorf("invitation must be provided")
+	}
+	err := db.DB.WithContext(ctx).Create(invitation).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to save invitation: %v", err)
+	}
+	return invitation, nil
+}
+
+// createUserWithContact creates a user and their primary contact as part of a registration transaction
+func createUserWithContact(tx *gorm.DB, user *User, contact *Contact) error {
+	err := tx.Omit(clause.Associations).Create(user).Error
+	if isUniqueViolation(err) {
+		return fmt.Errorf("failed to create user: %w", domain.ErrUsernameTaken)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create user: %v", err)
+	}
+
+	contact.UserID = user.UserID
+	err = tx.Create(contact).Error
+	if isUniqueViolation(err) {
+		return fmt.Errorf("failed to create contact: %w", domain.ErrContactTaken)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create contact: %v", err)
+	}
+	return nil
+}
+
+// isUniqueViolation checks whether an error was raised by the database because a value already exists in a
+// column with a unique constraint
+func isUniqueViolation(err error) bool {
+	var pgErr interface{ SQLState() string }
+	return errors.As(err, &pgErr) && pgErr.SQLState() == uniqueViolationCode
+}
+
+// RegisterClient creates a client's user, contact and client profile records.
+// This is done in a transaction so that a user is never left without a client profile.
+func (db *PGInstance) RegisterClient(ctx context.Context, user *User, contact *Contact, client *Client) (*Client, error) {
+	if user == nil || contact == nil || client == nil {
+		return nil, fmt.Errorf("user, contact and client must be provided")
+	}
+
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return nil, fmt.Errorf("failed to initialize register client transaction: %v", err)
+	}
+
+	if err := createUserWithContact(tx, user, contact); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	client.UserID = user.UserID
+	err := tx.Omit(clause.Associations).Create(client).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create client profile: %v", err)
+	}
+
+	if client.FacilityID != "" {
+		err = tx.Create(&ClientFacility{ClientID: *client.ID, FacilityID: client.FacilityID, StartDate: time.Now()}).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to record client facility: %v", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("transaction commit to register client failed: %v", err)
+	}
+
+	user.Contacts = *contact
+	client.UserProfile = *user
+	return client, nil
+}
+
+// RegisterStaff creates a staff member's user, contact and staff profile records in a transaction
+func (db *PGInstance) RegisterStaff(ctx context.Context, user *User, contact *Contact, staff *StaffProfile) (*StaffProfile, error) {
+	if user == nil || contact == nil || staff == nil {
+		return nil, fmt.Errorf("user, contact and staff must be provided")
+	}
+
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return nil, fmt.Errorf("failed to initialize register staff transaction: %v", err)
+	}
+
+	if err := createUserWithContact(tx, user, contact); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	staff.UserID = user.UserID
+	err := tx.Omit(clause.Associations).Create(staff).Error
+	if isUniqueViolation(err) {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create staff profile: %w", domain.ErrStaffNumberTaken)
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create staff profile: %v", err)
+	}
+
+	if err := addStaffFacilities(tx, *staff.ID, []string{staff.DefaultFacilityID}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("transaction commit to register staff failed: %v", err)
+	}
+
+	user.Contacts = *contact
+	staff.UserProfile = *user
+	return staff, nil
+}
+
+// addStaffFacilities gives a staff member access to the supplied facilities as part of a transaction.
+// Facilities that the staff member can already access are skipped.
+func addStaffFacilities(tx *gorm.DB, staffID string, facilityIDs []string) error {
+	var existing []string
+	err := tx.Model(&StaffFacilities{}).Where(&StaffFacilities{StaffID: &staffID}).Pluck("facility_id", &existing).Error
+	if err != nil {
+		return fmt.Errorf("failed to get staff facilities: %v", err)
+	}
+	seen := map[string]bool{}
+	for _, facilityID := range existing {
+		seen[facilityID] = true
+	}
+
+	for _, facilityID := range facilityIDs {
+		if seen[facilityID] {
+			continue
+		}
+		seen[facilityID] = true
+
+		id := facilityID
+		err := tx.Create(&StaffFacilities{StaffID: &staffID, FacilityID: &id}).Error
+		if err != nil {
+			return fmt.Errorf("failed to add facility %v to staff: %v", facilityID, err)
+		}
+	}
+	return nil
+}
+
+// CreateStaffProfile creates a staff profile for an existing user and gives them access to the supplied
+// facilities. The default facility is always added to the facilities the staff member can access.
+func (db *PGInstance) CreateStaffProfile(ctx context.Context, staff *StaffProfile, facilityIDs []string) (*StaffProfile, error) {
+	if staff == nil {
+		return nil, fmt.Errorf("staff must be provided")
+	}
+
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return nil, fmt.Errorf("failed to initialize create staff profile transaction: %v", err)
+	}
+
+	err := tx.Omit(clause.Associations).Create(staff).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create staff profile: %v", err)
+	}
+
+	facilities := append([]string{staff.DefaultFacilityID}, facilityIDs...)
+	if err := addStaffFacilities(tx, *staff.ID, facilities); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("transaction commit to create staff profile failed: %v", err)
+	}
+	return staff, nil
+}
+
+// AssignRoles gives a user the supplied roles. Roles that the user already has are skipped.
+func (db *PGInstance) AssignRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return false, fmt.Errorf("failed to initialize assign roles transaction: %v", err)
+	}
+
+	var existing []enums.UserRoleType
+	err := tx.Model(&UserRole{}).Where(&UserRole{UserID: userID}).Pluck("role", &existing).Error
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to get user roles: %v", err)
+	}
+	assigned := map[enums.UserRoleType]bool{}
+	for _, role := range existing {
+		assigned[role] = true
+	}
+
+	for _, role := range roles {
+		if assigned[role] {
+			continue
+		}
+		assigned[role] = true
+
+		if err := tx.Create(&UserRole{UserID: userID, Role: role}).Error; err != nil {
+			tx.Rollback()
+			return false, fmt.Errorf("failed to assign role %v to user: %v", role, err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("transaction commit to assign roles failed: %v", err)
+	}
+	return true, nil
+}
+
+// AssignFirstSystemAdmin gives a user the system admin role when no user has been given it yet. It is how the
+// first user who can manage roles is created on a new deployment. The roles table is locked for the duration of
+// the transaction so that two users cannot both become the first system admin.
+func (db *PGInstance) AssignFirstSystemAdmin(ctx context.Context, userID string) (bool, error) {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return false, fmt.Errorf("failed to initialize assign system admin transaction: %v", err)
+	}
+
+	if err := tx.Exec("LOCK TABLE users_userrole IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to lock user roles: %v", err)
+	}
+
+	var admins int64
+	err := tx.Model(&UserRole{}).Where(&UserRole{Role: enums.UserRoleTypeSystemAdmin}).Count(&admins).Error
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to count system admins: %v", err)
+	}
+	if admins > 0 {
+		tx.Rollback()
+		return false, fmt.Errorf("a system admin already exists, ask them to assign the role")
+	}
+
+	var user User
+	if err := tx.Where(&User{UserID: &userID}).First(&user).Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to get user %s: %v", userID, err)
+	}
+
+	role := &UserRole{UserID: userID, Role: enums.UserRoleTypeSystemAdmin, OrganisationID: user.OrganisationID}
+	if err := tx.Create(role).Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to assign the system admin role: %v", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("transaction commit to assign system admin failed: %v", err)
+	}
+	return true, nil
+}
+
+// CreateOrganisation creates a new active organisation
+func (db *PGInstance) CreateOrganisation(ctx context.Context, organisation *Organisation) (*Organisation, error) {
+	if organisation == nil {
+		return nil, fmt.Errorf("organisation must be provided")
+	}
+	organisation.Active = true
+	if err := db.DB.WithContext(ctx).Create(organisation).Error; err != nil {
+		return nil, fmt.Errorf("failed to create organisation: %v", err)
+	}
+	return organisation, nil
+}
+
+// CreateFacilityService adds a service to the organisation's catalogue of services that facilities can offer
+func (db *PGInstance) CreateFacilityService(ctx context.Context, service *FacilityService) (*FacilityService, error) {
+	if service == nil {
+		return nil, fmt.Errorf("facility service must be provided")
+	}
+	if err := db.DB.WithContext(ctx).Create(service).Error; err != nil {
+		return nil, fmt.Errorf("failed to create facility service: %v", err)
+	}
+	return service, nil
+}
+
+// CreateClientTransfer records a pending transfer of a client together with the service request that asks the
+// receiving facility to accept the client. The client is locked so that only one transfer can be pending at a time.
+func (db *PGInstance) CreateClientTransfer(ctx context.Context, transfer *ClientTransfer, serviceRequest *ClientServiceRequest) (*ClientTransfer, error) {
+	if transfer == nil || serviceRequest == nil {
+		return nil, fmt.Errorf("client transfer and service request must be provided")
+	}
+
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return nil, fmt.Errorf("failed to initialize create client transfer transaction: %v", err)
+	}
+
+	var client Client
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&Client{ID: &transfer.ClientID}).First(&client).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get client: %v", err)
+	}
+	if client.FacilityID != transfer.FromFacilityID {
+		tx.Rollback()
+		return nil, fmt.Errorf("client %s is no longer assigned to facility %s", transfer.ClientID, transfer.FromFacilityID)
+	}
+
+	var pending int64
+	err = tx.Model(&ClientTransfer{}).Where(&ClientTransfer{ClientID: transfer.ClientID, Status: enums.ClientTransferStatusPending}).
+		Count(&pending).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to check pending client transfers: %v", err)
+	}
+	if pending > 0 {
+		tx.Rollback()
+		return nil, fmt.Errorf("client %s already has a pending transfer", transfer.ClientID)
+	}
+
+	if err := tx.Create(serviceRequest).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create client transfer service request: %v", err)
+	}
+
+	transfer.ServiceRequestID = serviceRequest.ID
+	transfer.Status = enums.ClientTransferStatusPending
+	if err := tx.Create(transfer).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create client transfer: %v", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("transaction commit to create client transfer failed: %v", err)
+	}
+	return transfer, nil
+}
+
+// CreateBulkInviteJob saves a bulk invite job together with its rows
+func (db *PGInstance) CreateBulkInviteJob(ctx context.Context, job *BulkInviteJob) (*BulkInviteJob, error) {
+	if job == nil {
+		return nil, fmt.Errorf("bulk invite job must be provided")
+	}
+	err := db.DB.WithContext(ctx).Create(job).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bulk invite job: %v", err)
+	}
+	return job, nil
+}
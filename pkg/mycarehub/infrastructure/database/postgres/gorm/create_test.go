@@ -2,19 +2,23 @@ package gorm_test
 
 import (
 	"context"
+	"errors"
 	"math/rand"
 	"testing"
 	"time"
 
 	"github.com/brianvoe/gofakeit"
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
 	"github.com/segmentio/ksuid"
 )
 
 func TestPGInstance_GetOrCreateFacility(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	name := ksuid.New().String()
 	code := rand.Intn(1000000)
@@ -70,7 +74,7 @@ func TestPGInstance_GetOrCreateFacility(t *testing.T) {
 		})
 	}
 	// teardown
-	pg, err := gorm.NewPGInstance()
+	pg, err := newTestPGInstance()
 	if err != nil {
 		t.Errorf("pgInstance.Teardown() = %v", err)
 	}
@@ -80,12 +84,12 @@ func TestPGInstance_GetOrCreateFacility(t *testing.T) {
 }
 
 func TestPGInstance_SaveTemporaryUserPin(t *testing.T) {
-	pg, err := gorm.NewPGInstance()
+	pg, err := newTestPGInstance()
 	if err != nil {
 		t.Errorf("pgInstance.Teardown() = %v", err)
 	}
 
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	flavour := feedlib.FlavourConsumer
 
@@ -148,9 +152,9 @@ func TestPGInstance_SaveTemporaryUserPin(t *testing.T) {
 }
 
 func TestPGInstance_SavePin(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
-	pg, err := gorm.NewPGInstance()
+	pg, err := newTestPGInstance()
 	if err != nil {
 		t.Errorf("pgInstance.Teardown() = %v", err)
 	}
@@ -258,9 +262,9 @@ func TestPGInstance_SavePin(t *testing.T) {
 
 func TestPGInstance_SaveSecurityQuestionResponse(t *testing.T) {
 
-	ctx := context.Background()
+	ctx := newTestContext()
 
-	pg, err := gorm.NewPGInstance()
+	pg, err := newTestPGInstance()
 	if err != nil {
 		t.Errorf("pgInstance.Teardown() = %v", err)
 	}
@@ -304,9 +308,9 @@ func TestPGInstance_SaveSecurityQuestionResponse(t *testing.T) {
 }
 
 func TestPGInstance_SaveOTP(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
-	pg, err := gorm.NewPGInstance()
+	pg, err := newTestPGInstance()
 	if err != nil {
 		t.Errorf("pgInstance.Teardown() = %v", err)
 	}
@@ -446,7 +450,7 @@ func TestPGInstance_SaveOTP(t *testing.T) {
 }
 
 func TestPGInstance_CreateServiceRequest(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	serviceRequestInput := &gorm.ClientServiceRequest{
 		Active:         false,
@@ -485,3 +489,639 @@ func TestPGInstance_CreateServiceRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestPGInstance_SaveInvitation(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx        context.Context
+		invitation *gorm.Invitation
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx: ctx,
+				invitation: &gorm.Invitation{
+					UserID:      userID,
+					PhoneNumber: testPhone,
+					Flavour:     testFlavour,
+					Status:      enums.InvitationStatusIssued,
+					IssuedAt:    time.Now(),
+					ExpiresAt:   time.Now().Add(time.Hour * 24),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - nil invitation",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.SaveInvitation(tt.args.ctx, tt.args.invitation)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.SaveInvitation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.ID == nil {
+				t.Errorf("expected the saved invitation to have an ID")
+			}
+		})
+	}
+}
+
+func TestPGInstance_RegisterClient(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx     context.Context
+		user    *gorm.User
+		contact *gorm.Contact
+		client  *gorm.Client
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx: ctx,
+				user: &gorm.User{
+					Username:  gofakeit.Username(),
+					FirstName: gofakeit.FirstName(),
+					LastName:  gofakeit.LastName(),
+					UserType:  enums.ClientUser,
+					Gender:    enumutils.GenderMale,
+					Active:    true,
+					Flavour:   testFlavour,
+				},
+				contact: &gorm.Contact{
+					ContactType:  "PHONE",
+					ContactValue: gofakeit.Phone(),
+					Active:       true,
+					OptedIn:      true,
+					Flavour:      testFlavour,
+				},
+				client: &gorm.Client{
+					Active:     true,
+					ClientType: "PMTCT",
+					FacilityID: facilityID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - phone number already registered",
+			args: args{
+				ctx: ctx,
+				user: &gorm.User{
+					Username:  gofakeit.Username(),
+					FirstName: gofakeit.FirstName(),
+					LastName:  gofakeit.LastName(),
+					UserType:  enums.ClientUser,
+					Gender:    enumutils.GenderMale,
+					Active:    true,
+					Flavour:   testFlavour,
+				},
+				contact: &gorm.Contact{
+					ContactType:  "PHONE",
+					ContactValue: testPhone,
+					Active:       true,
+					OptedIn:      true,
+					Flavour:      testFlavour,
+				},
+				client: &gorm.Client{
+					Active:     true,
+					ClientType: "PMTCT",
+					FacilityID: facilityID,
+				},
+			},
+			wantErr:   true,
+			wantErrIs: domain.ErrContactTaken,
+		},
+		{
+			name: "Sad case - username already taken",
+			args: args{
+				ctx: ctx,
+				user: &gorm.User{
+					Username:  "test user",
+					FirstName: gofakeit.FirstName(),
+					LastName:  gofakeit.LastName(),
+					UserType:  enums.ClientUser,
+					Gender:    enumutils.GenderMale,
+					Active:    true,
+					Flavour:   testFlavour,
+				},
+				contact: &gorm.Contact{
+					ContactType:  "PHONE",
+					ContactValue: gofakeit.Phone(),
+					Active:       true,
+					OptedIn:      true,
+					Flavour:      testFlavour,
+				},
+				client: &gorm.Client{
+					Active:     true,
+					ClientType: "PMTCT",
+					FacilityID: facilityID,
+				},
+			},
+			wantErr:   true,
+			wantErrIs: domain.ErrUsernameTaken,
+		},
+		{
+			name: "Sad case - missing client",
+			args: args{
+				ctx:     ctx,
+				user:    &gorm.User{Username: gofakeit.Username()},
+				contact: &gorm.Contact{ContactValue: gofakeit.Phone()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.RegisterClient(tt.args.ctx, tt.args.user, tt.args.contact, tt.args.client)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.RegisterClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("PGInstance.RegisterClient() error = %v, want %v", err, tt.wantErrIs)
+				return
+			}
+			if tt.wantErr {
+				if tt.args.user == nil || tt.wantErrIs == domain.ErrUsernameTaken {
+					return
+				}
+				var count int64
+				err := testingDB.DB.Model(&gorm.User{}).Where("username = ?", tt.args.user.Username).Count(&count).Error
+				if err != nil || count > 0 {
+					t.Errorf("expected the user created in a failed registration to be rolled back")
+				}
+				return
+			}
+			if got.ID == nil || *got.UserID != *got.UserProfile.UserID {
+				t.Errorf("PGInstance.RegisterClient() returned an incomplete client profile")
+			}
+		})
+	}
+}
+
+func TestPGInstance_RegisterStaff(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx     context.Context
+		user    *gorm.User
+		contact *gorm.Contact
+		staff   *gorm.StaffProfile
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx: ctx,
+				user: &gorm.User{
+					Username:  gofakeit.Username(),
+					FirstName: gofakeit.FirstName(),
+					LastName:  gofakeit.LastName(),
+					UserType:  enums.HealthcareWorkerUser,
+					Gender:    enumutils.GenderFemale,
+					Active:    true,
+					Flavour:   feedlib.FlavourPro,
+				},
+				contact: &gorm.Contact{
+					ContactType:  "PHONE",
+					ContactValue: gofakeit.Phone(),
+					Active:       true,
+					OptedIn:      true,
+					Flavour:      feedlib.FlavourPro,
+				},
+				staff: &gorm.StaffProfile{
+					Active:            true,
+					StaffNumber:       ksuid.New().String(),
+					Cadre:             enums.StaffCadreNurse,
+					DefaultFacilityID: facilityID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - missing staff",
+			args: args{
+				ctx:     ctx,
+				user:    &gorm.User{Username: gofakeit.Username()},
+				contact: &gorm.Contact{ContactValue: gofakeit.Phone()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.RegisterStaff(tt.args.ctx, tt.args.user, tt.args.contact, tt.args.staff)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.RegisterStaff() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.ID == nil {
+				t.Errorf("PGInstance.RegisterStaff() returned a staff profile without an ID")
+			}
+		})
+	}
+}
+
+func TestPGInstance_CreateStaffProfile(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx         context.Context
+		staff       *gorm.StaffProfile
+		facilityIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx: ctx,
+				staff: &gorm.StaffProfile{
+					UserID:            &userID2,
+					Active:            true,
+					StaffNumber:       ksuid.New().String(),
+					Cadre:             enums.StaffCadreCounsellor,
+					DefaultFacilityID: facilityID,
+				},
+				facilityIDs: []string{staffFacilityID2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - missing staff",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.CreateStaffProfile(tt.args.ctx, tt.args.staff, tt.args.facilityIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.CreateStaffProfile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			facilities, err := testingDB.GetStaffFacilities(ctx, *got.ID)
+			if err != nil {
+				t.Errorf("failed to get staff facilities: %v", err)
+				return
+			}
+			if len(facilities) != 2 {
+				t.Errorf("expected the staff member to access 2 facilities, got %v", len(facilities))
+			}
+		})
+	}
+}
+
+func TestPGInstance_AssignRoles(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		roles  []enums.UserRoleType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: userID,
+				roles:  []enums.UserRoleType{enums.UserRoleTypeClinician, enums.UserRoleTypeFacilityAdmin},
+			},
+			want:    true,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.AssignRoles(tt.args.ctx, tt.args.userID, tt.args.roles)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.AssignRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.AssignRoles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPGInstance_AssignFirstSystemAdmin(t *testing.T) {
+	ctx := newTestContext()
+
+	if _, err := testingDB.AssignRoles(ctx, userID2, []enums.UserRoleType{enums.UserRoleTypeSystemAdmin}); err != nil {
+		t.Errorf("failed to assign system admin role: %v", err)
+		return
+	}
+	defer func() {
+		if _, err := testingDB.RevokeRoles(ctx, userID2, []enums.UserRoleType{enums.UserRoleTypeSystemAdmin}); err != nil {
+			t.Errorf("failed to revoke system admin role: %v", err)
+		}
+	}()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Sad case - a system admin already exists",
+			args: args{
+				ctx:    ctx,
+				userID: userID,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.AssignFirstSystemAdmin(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.AssignFirstSystemAdmin() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.AssignFirstSystemAdmin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPGInstance_CreateOrganisation(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	inviteDays := 14
+	senderID := enumutils.SenderIDSLADE360.String()
+	organisation := &gorm.Organisation{
+		OrgCode:             ksuid.New().String(),
+		Code:                rand.Intn(1000000),
+		OrganisationName:    gofakeit.Company(),
+		EmailAddress:        gofakeit.Email(),
+		PhoneNumber:         "+254711223344",
+		DefaultCountry:      "KEN",
+		InvitePINExpiryDays: &inviteDays,
+		SMSSenderID:         &senderID,
+	}
+
+	type args struct {
+		ctx          context.Context
+		organisation *gorm.Organisation
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:          ctx,
+				organisation: organisation,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no organisation",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.CreateOrganisation(tt.args.ctx, tt.args.organisation)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.CreateOrganisation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.OrganisationID == nil || !got.Active) {
+				t.Errorf("expected an active organisation with an ID, got %+v", got)
+			}
+		})
+	}
+
+	// TearDown
+	if organisation.OrganisationID != nil {
+		if err = pg.DB.Where("id", *organisation.OrganisationID).Unscoped().Delete(&gorm.Organisation{}).Error; err != nil {
+			t.Errorf("failed to delete organisation: %v", err)
+		}
+	}
+}
+
+func TestPGInstance_CreateFacilityService(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	code := "TEST_" + ksuid.New().String()
+
+	type args struct {
+		ctx     context.Context
+		service *gorm.FacilityService
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				service: &gorm.FacilityService{Name: gofakeit.BS(), Code: code, Active: true},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - duplicate code",
+			args: args{
+				ctx:     ctx,
+				service: &gorm.FacilityService{Name: gofakeit.BS(), Code: code, Active: true},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.CreateFacilityService(tt.args.ctx, tt.args.service)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.CreateFacilityService() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.ID == nil {
+				t.Errorf("expected the facility service to have an ID")
+			}
+		})
+	}
+
+	// TearDown
+	if err = pg.DB.Where("code", code).Unscoped().Delete(&gorm.FacilityService{}).Error; err != nil {
+		t.Errorf("failed to delete facility service: %v", err)
+	}
+}
+
+func TestPGInstance_CreateClientTransfer(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	toFacility := &gorm.Facility{
+		Name:        ksuid.New().String(),
+		Code:        rand.Intn(1000000),
+		Active:      true,
+		County:      "Nairobi",
+		Description: gofakeit.HipsterSentence(15),
+	}
+	if err = pg.DB.Create(toFacility).Error; err != nil {
+		t.Errorf("failed to create facility: %v", err)
+		return
+	}
+
+	newTransfer := func(fromFacilityID string) *gorm.ClientTransfer {
+		return &gorm.ClientTransfer{
+			ClientID:       clientID,
+			FromFacilityID: fromFacilityID,
+			ToFacilityID:   *toFacility.FacilityID,
+			Reason:         "Client relocated",
+			RequestedByID:  staffID,
+			RequestedAt:    time.Now(),
+		}
+	}
+	newServiceRequest := func() *gorm.ClientServiceRequest {
+		return &gorm.ClientServiceRequest{
+			Active:      true,
+			RequestType: "CLIENT_TRANSFER",
+			Request:     "Accept the transfer of a client",
+			Status:      "PENDING",
+			ClientID:    clientID,
+			FacilityID:  toFacility.FacilityID,
+		}
+	}
+
+	type args struct {
+		ctx            context.Context
+		transfer       *gorm.ClientTransfer
+		serviceRequest *gorm.ClientServiceRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:            ctx,
+				transfer:       newTransfer(facilityID),
+				serviceRequest: newServiceRequest(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - client already has a pending transfer",
+			args: args{
+				ctx:            ctx,
+				transfer:       newTransfer(facilityID),
+				serviceRequest: newServiceRequest(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - client is not at the sending facility",
+			args: args{
+				ctx:            ctx,
+				transfer:       newTransfer(*toFacility.FacilityID),
+				serviceRequest: newServiceRequest(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.CreateClientTransfer(tt.args.ctx, tt.args.transfer, tt.args.serviceRequest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.CreateClientTransfer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Status != enums.ClientTransferStatusPending {
+				t.Errorf("expected a pending transfer, got %v", got.Status)
+			}
+			if got.ServiceRequestID == nil || *got.ServiceRequestID != *tt.args.serviceRequest.ID {
+				t.Errorf("expected the transfer to reference the service request it raised")
+			}
+		})
+	}
+
+	// TearDown
+	if err = pg.DB.Where("to_facility_id", toFacility.FacilityID).Unscoped().Delete(&gorm.ClientTransfer{}).Error; err != nil {
+		t.Errorf("failed to delete client transfers: %v", err)
+	}
+	if err = pg.DB.Where("facility_id", toFacility.FacilityID).Unscoped().Delete(&gorm.ClientServiceRequest{}).Error; err != nil {
+		t.Errorf("failed to delete service requests: %v", err)
+	}
+	if err = pg.DB.Where("id", toFacility.FacilityID).Unscoped().Delete(&gorm.Facility{}).Error; err != nil {
+		t.Errorf("failed to delete facility: %v", err)
+	}
+}
@@ -1,10 +1,12 @@
 package gorm
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
 
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/serverutils"
 	log "github.com/sirupsen/logrus"
 
@@ -59,6 +61,9 @@ type connectionConfig struct {
 // PGInstance box for postgres client. We use this instead of a global variable
 type PGInstance struct {
 	DB *gorm.DB
+
+	// hasPostGIS is true when the PostGIS extension is installed and can be used to compute distances
+	hasPostGIS bool
 }
 
 // NewPGInstance creates a new instance of postgres client
@@ -67,11 +72,25 @@ func NewPGInstance() (*PGInstance, error) {
 	if db == nil {
 		return nil, fmt.Errorf("failed to start database: %v", db)
 	}
-	pg := &PGInstance{DB: db}
+	if err := registerOrganisationCallbacks(db); err != nil {
+		return nil, err
+	}
+	pg := &PGInstance{DB: db, hasPostGIS: hasPostGIS(db)}
 
 	return pg, nil
 }
 
+// hasPostGIS returns true when the PostGIS extension is installed in the database
+func hasPostGIS(db *gorm.DB) bool {
+	var installed bool
+	err := db.WithContext(helpers.ContextForAllOrganisations(context.Background())).Raw("SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'postgis')").Scan(&installed).Error
+	if err != nil {
+		log.Printf("failed to check whether the PostGIS extension is installed: %v", err)
+		return false
+	}
+	return installed
+}
+
 // isLocalDB returns true if the service is currently configured to use a local
 // database.
 func isLocalDB() bool {
@@ -3,11 +3,17 @@ package gorm
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 )
 
 // Delete represents all `delete` ops to the database
 type Delete interface {
 	DeleteFacility(ctx context.Context, mflcode int) (bool, error)
+	RevokeRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error)
+	DeleteFacilityHoursException(ctx context.Context, mflCode int, date time.Time) (bool, error)
 }
 
 // DeleteFacility will do the actual deletion of a facility from the database
@@ -16,10 +22,37 @@ func (db *PGInstance) DeleteFacility(ctx context.Context, mflcode int) (bool, er
 	if mflcode == 0 {
 		return false, fmt.Errorf("MFL code cannot be empty")
 	}
-	err := db.DB.Where("mfl_code", mflcode).Delete(&Facility{}).Error
+	err := db.DB.WithContext(ctx).Where("mfl_code", mflcode).Delete(&Facility{}).Error
 	if err != nil {
 		return false, fmt.Errorf("an error occurred while deleting: %v", err)
 	}
 
 	return true, nil
 }
+
+// RevokeRoles removes the supplied roles from a user
+func (db *PGInstance) RevokeRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+	err := db.DB.WithContext(ctx).Where(&UserRole{UserID: userID}).Where("role IN ?", roles).Delete(&UserRole{}).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke user roles: %v", err)
+	}
+	return true, nil
+}
+
+// DeleteFacilityHoursException removes a facility's hours exception on the supplied date so that the facility's
+// weekly opening hours apply on that date again
+func (db *PGInstance) DeleteFacilityHoursException(ctx context.Context, mflCode int, date time.Time) (bool, error) {
+	var facility Facility
+	if err := db.DB.WithContext(ctx).Where(&Facility{Code: mflCode}).First(&facility).Error; err != nil {
+		return false, fmt.Errorf("failed to get facility by MFL Code %v: %v", mflCode, err)
+	}
+	result := db.DB.WithContext(ctx).Where(&FacilityHoursException{FacilityID: *facility.FacilityID}).Where("date = ?", date).
+		Delete(&FacilityHoursException{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to delete facility hours exception: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, fmt.Errorf("facility %v has no hours exception on %v", mflCode, date.Format(common.DateLayout))
+	}
+	return true, nil
+}
@@ -4,15 +4,17 @@ import (
 	"context"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
 	"github.com/segmentio/ksuid"
 )
 
 func TestPGInstance_DeleteFacility(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	ID := uuid.New().String()
 	name := ksuid.New().String()
@@ -76,3 +78,127 @@ func TestPGInstance_DeleteFacility(t *testing.T) {
 		})
 	}
 }
+
+func TestPGInstance_RevokeRoles(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		roles  []enums.UserRoleType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: userID2,
+				roles:  []enums.UserRoleType{enums.UserRoleTypeClient},
+			},
+			want:    true,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.RevokeRoles(tt.args.ctx, tt.args.userID, tt.args.roles)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.RevokeRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.RevokeRoles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPGInstance_DeleteFacilityHoursException(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	facility := &gorm.Facility{
+		Name:        ksuid.New().String(),
+		Code:        rand.Intn(1000000),
+		Active:      true,
+		County:      "Nairobi",
+		Description: gofakeit.HipsterSentence(15),
+	}
+	if err = pg.DB.Create(facility).Error; err != nil {
+		t.Errorf("failed to create facility: %v", err)
+		return
+	}
+	date := time.Date(2030, 12, 25, 0, 0, 0, 0, time.UTC)
+	if err = testingDB.SetFacilityHoursException(ctx, facility.Code, &gorm.FacilityHoursException{Date: date, Closed: true}); err != nil {
+		t.Errorf("failed to set facility hours exception: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx     context.Context
+		mflCode int
+		date    time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				mflCode: facility.Code,
+				date:    date,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - exception already deleted",
+			args: args{
+				ctx:     ctx,
+				mflCode: facility.Code,
+				date:    date,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - facility not found",
+			args: args{
+				ctx:     ctx,
+				mflCode: -1,
+				date:    date,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.DeleteFacilityHoursException(tt.args.ctx, tt.args.mflCode, tt.args.date)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.DeleteFacilityHoursException() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.DeleteFacilityHoursException() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// TearDown
+	if err = pg.DB.Where("id", facility.FacilityID).Unscoped().Delete(&gorm.Facility{}).Error; err != nil {
+		t.Errorf("failed to delete facility: %v", err)
+	}
+}
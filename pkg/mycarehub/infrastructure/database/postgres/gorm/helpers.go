@@ -9,6 +9,15 @@ import (
 
 // paginate is a helper function that helps with querying paginated results
 func paginate(value interface{}, pagination *domain.Pagination, count int64, db *gorm.DB) func(db *gorm.DB) *gorm.DB {
+	setPageDetails(pagination, count)
+
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Offset(pagination.GetOffset()).Limit(pagination.GetLimit()).Order(pagination.GetSort())
+	}
+}
+
+// setPageDetails works out the number of pages and the pages next to the current page from the number of results
+func setPageDetails(pagination *domain.Pagination, count int64) {
 	pagination.Count = count
 
 	// If no limit is specified, default to 10
@@ -35,10 +44,6 @@ func paginate(value interface{}, pagination *domain.Pagination, count int64, db
 	if previousPage == 0 {
 		pagination.PreviousPage = nil
 	}
-
-	return func(db *gorm.DB) *gorm.DB {
-		return db.Offset(pagination.GetOffset()).Limit(pagination.GetLimit()).Order(pagination.GetSort())
-	}
 }
 
 // parse filter param values to map[string]interface{}
@@ -0,0 +1,120 @@
+package gorm
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+)
+
+// migrationFiles are the SQL migrations that this service makes to the schema. Most of the schema belongs to the
+// CMS hence these are limited to the constraints and indexes that this service relies on.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the key of the advisory lock that is held while a migration is applied so that two
+// deployments can't apply the same migration at the same time
+const migrationLockID = 5204173
+
+// SchemaMigration records a migration that has been applied
+type SchemaMigration struct {
+	Name      string    `gorm:"primaryKey;column:name"`
+	AppliedAt time.Time `gorm:"column:applied_at"`
+}
+
+// TableName customizes how the table name is generated
+func (SchemaMigration) TableName() string {
+	return "mycarehub_schema_migration"
+}
+
+// migrationNames returns the names of the embedded migrations in the order they are applied
+func migrationNames() ([]string, error) {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the migrations: %v", err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Migrate applies the migrations that have not been applied yet in the order of their names and returns the names
+// of those it applied. Each migration is applied in its own transaction together with the record that it was applied.
+func (db *PGInstance) Migrate(ctx context.Context) ([]string, error) {
+	err := db.DB.WithContext(ctx).Exec(
+		"CREATE TABLE IF NOT EXISTS " + SchemaMigration{}.TableName() +
+			" (name text PRIMARY KEY, applied_at timestamp with time zone NOT NULL)",
+	).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the schema migrations table: %v", err)
+	}
+
+	names, err := migrationNames()
+	if err != nil {
+		return nil, err
+	}
+	applied := []string{}
+	for _, name := range names {
+		statements, err := migrationFiles.ReadFile(path.Join("migrations", name))
+		if err != nil {
+			return applied, fmt.Errorf("failed to read migration %s: %v", name, err)
+		}
+		ok, err := db.applyMigration(ctx, name, string(statements))
+		if err != nil {
+			return applied, err
+		}
+		if ok {
+			applied = append(applied, name)
+		}
+	}
+	return applied, nil
+}
+
+// applyMigration runs the migration's statements unless it has already been applied and reports whether it ran
+func (db *PGInstance) applyMigration(ctx context.Context, name string, statements string) (bool, error) {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return false, fmt.Errorf("failed to initialize database transaction: %v", err)
+	}
+
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockID).Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to lock the schema migrations: %v", err)
+	}
+
+	var count int64
+	if err := tx.Model(&SchemaMigration{}).Where(&SchemaMigration{Name: name}).Count(&count).Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to check whether migration %s was applied: %v", name, err)
+	}
+	if count > 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := tx.Exec(statements).Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to apply migration %s: %v", name, err)
+	}
+	if err := tx.Create(&SchemaMigration{Name: name, AppliedAt: time.Now()}).Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to record migration %s: %v", name, err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to commit migration %s: %v", name, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,46 @@
+package gorm_test
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPGInstance_Migrate(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantApplied int
+		wantErr     bool
+	}{
+		{
+			name: "Happy case - migrations that were applied are not applied again",
+			args: args{
+				ctx: newTestContext(),
+			},
+			wantApplied: 0,
+			wantErr:     false,
+		},
+		{
+			name: "Sad case - context without a logged in user",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.Migrate(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.Migrate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != tt.wantApplied {
+				t.Errorf("expected %v migrations to be applied, got %v", tt.wantApplied, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+-- A user's like, bookmark or share of a content item is only recorded once. The duplicates recorded before this
+-- was enforced are removed first, keeping the user's active row or else their earliest one. Run
+-- `reconcile-content-engagement` afterwards to rewrite the counters that the duplicates inflated.
+
+DELETE FROM content_contentlike AS duplicate USING content_contentlike AS original
+WHERE duplicate.user_id = original.user_id AND duplicate.content_item_id = original.content_item_id
+AND (NOT duplicate.active, duplicate.created, duplicate.id) > (NOT original.active, original.created, original.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS content_contentlike_user_content_item_uniq ON content_contentlike (user_id, content_item_id) WHERE active;
+
+DELETE FROM content_contentbookmark AS duplicate USING content_contentbookmark AS original
+WHERE duplicate.user_id = original.user_id AND duplicate.content_item_id = original.content_item_id
+AND (NOT duplicate.active, duplicate.created, duplicate.id) > (NOT original.active, original.created, original.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS content_contentbookmark_user_content_item_uniq ON content_contentbookmark (user_id, content_item_id) WHERE active;
+
+DELETE FROM content_contentshare AS duplicate USING content_contentshare AS original
+WHERE duplicate.user_id = original.user_id AND duplicate.content_item_id = original.content_item_id
+AND (NOT duplicate.active, duplicate.created, duplicate.id) > (NOT original.active, original.created, original.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS content_contentshare_user_content_item_uniq ON content_contentshare (user_id, content_item_id) WHERE active;
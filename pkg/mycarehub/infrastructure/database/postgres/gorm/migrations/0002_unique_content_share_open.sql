@@ -0,0 +1,9 @@
+-- A user who opens a shared link again is only recorded the first time. The repeated opens recorded before this
+-- was enforced are removed first, keeping the user's earliest one. Opens by people who aren't logged in are all kept.
+
+DELETE FROM content_contentshareopen AS repeat USING content_contentshareopen AS original
+WHERE repeat.share_link_id = original.share_link_id AND repeat.opened_by_id = original.opened_by_id
+AND (repeat.created, repeat.id) > (original.created, original.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS content_contentshareopen_share_link_opened_by_uniq
+ON content_contentshareopen (share_link_id, opened_by_id) WHERE opened_by_id IS NOT NULL;
@@ -21,7 +21,7 @@ type GormMock struct {
 	MockRetrieveFacilityByMFLCodeFn               func(ctx context.Context, MFLCode int, isActive bool) (*gorm.Facility, error)
 	MockGetFacilitiesFn                           func(ctx context.Context) ([]gorm.Facility, error)
 	MockDeleteFacilityFn                          func(ctx context.Context, mflCode int) (bool, error)
-	MockListFacilitiesFn                          func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, pagination *domain.FacilityPage) (*domain.FacilityPage, error)
+	MockListFacilitiesFn                          func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, filterGroups []*domain.FilterGroup, pagination *domain.FacilityPage) (*domain.FacilityPage, error)
 	MockGetUserProfileByPhoneNumberFn             func(ctx context.Context, phoneNumber string) (*gorm.User, error)
 	MockGetUserPINByUserIDFn                      func(ctx context.Context, userID string) (*gorm.PINData, error)
 	MockInactivateFacilityFn                      func(ctx context.Context, mflCode *int) (bool, error)
@@ -53,7 +53,7 @@ type GormMock struct {
 	MockGetContactByUserIDFn                      func(ctx context.Context, userID *string, contactType string) (*gorm.Contact, error)
 	MockUpdateIsCorrectSecurityQuestionResponseFn func(ctx context.Context, userID string, isCorrectSecurityQuestionResponse bool) (bool, error)
 	MockListContentCategoriesFn                   func(ctx context.Context) ([]*domain.ContentItemCategory, error)
-	MockShareContentFn                            func(ctx context.Context, input dto.ShareContentInput) (bool, error)
+	MockShareContentFn                            func(ctx context.Context, input dto.ShareContentInput) (*gorm.ContentShareLink, error)
 	MockBookmarkContentFn                         func(ctx context.Context, userID string, contentID int) (bool, error)
 	MockUnBookmarkContentFn                       func(ctx context.Context, userID string, contentID int) (bool, error)
 	MockCheckWhetherUserHasLikedContentFn         func(ctx context.Context, userID string, contentID int) (bool, error)
@@ -63,11 +63,75 @@ type GormMock struct {
 	MockViewContentFn                             func(ctx context.Context, userID string, contentID int) (bool, error)
 	MockCreateHealthDiaryEntryFn                  func(ctx context.Context, healthDiaryInput *gorm.ClientHealthDiaryEntry) error
 	MockCreateServiceRequestFn                    func(ctx context.Context, serviceRequestInput *gorm.ClientServiceRequest) error
-	MockCanRecordHeathDiaryFn                     func(ctx context.Context, clientID string) (bool, error)
+	MockCanRecordHeathDiaryFn                     func(ctx context.Context, clientID string, cadence time.Duration) (bool, error)
 	MockGetClientHealthDiaryQuoteFn               func(ctx context.Context) (*gorm.ClientHealthDiaryQuote, error)
 	MockCheckIfUserBookmarkedContentFn            func(ctx context.Context, userID string, contentID int) (bool, error)
 	MockGetClientHealthDiaryEntriesFn             func(ctx context.Context, clientID string) ([]*gorm.ClientHealthDiaryEntry, error)
 	MockGetFAQContentFn                           func(ctx context.Context, flavour feedlib.Flavour, limit *int) ([]*gorm.FAQ, error)
+	MockUpdateUserLanguagesFn                     func(ctx context.Context, userID string, languages []string) (bool, error)
+	MockSaveInvitationFn                          func(ctx context.Context, invitation *gorm.Invitation) (*gorm.Invitation, error)
+	MockGetLatestUserInvitationFn                 func(ctx context.Context, userID string, flavour feedlib.Flavour) (*gorm.Invitation, error)
+	MockListPendingInvitationsFn                  func(ctx context.Context, facilityID string) ([]*gorm.Invitation, error)
+	MockUpdateInvitationStatusFn                  func(ctx context.Context, invitationID string, status enums.InvitationStatus) (bool, error)
+	MockCloseUserInvitationsFn                    func(ctx context.Context, userID string, flavour feedlib.Flavour, status enums.InvitationStatus) (bool, error)
+	MockExpireOverdueInvitationsFn                func(ctx context.Context) (bool, error)
+	MockRegisterClientFn                          func(ctx context.Context, user *gorm.User, contact *gorm.Contact, client *gorm.Client) (*gorm.Client, error)
+	MockRegisterStaffFn                           func(ctx context.Context, user *gorm.User, contact *gorm.Contact, staff *gorm.StaffProfile) (*gorm.StaffProfile, error)
+	MockCreateStaffProfileFn                      func(ctx context.Context, staff *gorm.StaffProfile, facilityIDs []string) (*gorm.StaffProfile, error)
+	MockGetStaffProfileByUserIDFn                 func(ctx context.Context, userID string) (*gorm.StaffProfile, error)
+	MockGetStaffProfileByStaffIDFn                func(ctx context.Context, staffID string) (*gorm.StaffProfile, error)
+	MockGetStaffFacilitiesFn                      func(ctx context.Context, staffID string) ([]*gorm.Facility, error)
+	MockUpdateStaffProfileFn                      func(ctx context.Context, staffID string, input *dto.StaffProfileUpdateInput) (bool, error)
+	MockAddStaffFacilitiesFn                      func(ctx context.Context, staffID string, facilityIDs []string) (bool, error)
+	MockRemoveStaffFacilitiesFn                   func(ctx context.Context, staffID string, facilityIDs []string) (bool, error)
+	MockAssignRolesFn                             func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error)
+	MockGetUserRolesFn                            func(ctx context.Context, userID string) ([]*gorm.UserRole, error)
+	MockGetClientProfileByClientIDFn              func(ctx context.Context, clientID string) (*gorm.Client, error)
+	MockRevokeRolesFn                             func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error)
+	MockCreateOrganisationFn                      func(ctx context.Context, organisation *gorm.Organisation) (*gorm.Organisation, error)
+	MockGetOrganisationFn                         func(ctx context.Context, organisationID string) (*gorm.Organisation, error)
+	MockListOrganisationsFn                       func(ctx context.Context) ([]*gorm.Organisation, error)
+	MockUpdateOrganisationFn                      func(ctx context.Context, organisationID string, input *dto.OrganisationUpdateInput) (bool, error)
+	MockDeactivateOrganisationFn                  func(ctx context.Context, organisationID string) (bool, error)
+	MockUpdateFacilityFn                          func(ctx context.Context, mflCode int, input *dto.FacilityUpdateInput, changedBy string) (*gorm.Facility, error)
+	MockGetFacilityHistoryFn                      func(ctx context.Context, mflCode int) ([]*gorm.FacilityHistory, error)
+	MockUpsertFacilitiesFn                        func(ctx context.Context, facilities []*gorm.Facility, changedBy string, dryRun bool) ([]*domain.FacilityImportRow, error)
+	MockNearbyFacilitiesFn                        func(ctx context.Context, latitude, longitude, radiusKm float64, limit int, filter []*domain.FiltersParam, sort *domain.SortParam) ([]*gorm.NearbyFacility, error)
+	MockSetFacilityOpeningHoursFn                 func(ctx context.Context, mflCode int, openingHours []*gorm.FacilityOpeningHours) error
+	MockSetFacilityHoursExceptionFn               func(ctx context.Context, mflCode int, exception *gorm.FacilityHoursException) error
+	MockSetFacilityServicesFn                     func(ctx context.Context, mflCode int, serviceCodes []string) error
+	MockCreateFacilityServiceFn                   func(ctx context.Context, service *gorm.FacilityService) (*gorm.FacilityService, error)
+	MockListFacilityServicesFn                    func(ctx context.Context) ([]*gorm.FacilityService, error)
+	MockDeleteFacilityHoursExceptionFn            func(ctx context.Context, mflCode int, date time.Time) (bool, error)
+	MockCreateClientTransferFn                    func(ctx context.Context, transfer *gorm.ClientTransfer, serviceRequest *gorm.ClientServiceRequest) (*gorm.ClientTransfer, error)
+	MockGetClientTransferByIDFn                   func(ctx context.Context, transferID string) (*gorm.ClientTransfer, error)
+	MockCheckClientHasPendingTransferFn           func(ctx context.Context, clientID string) (bool, error)
+	MockListPendingClientTransfersFn              func(ctx context.Context, facilityID string) ([]*gorm.ClientTransfer, error)
+	MockGetClientFacilityHistoryFn                func(ctx context.Context, clientID string) ([]*gorm.ClientFacility, error)
+	MockRespondToClientTransferFn                 func(ctx context.Context, transferID string, status enums.ClientTransferStatus, respondedByID string, note string) (*gorm.ClientTransfer, error)
+	MockListContentItemsFn                        func(ctx context.Context, categoryID *int, contentIDs []int, limit int) ([]*gorm.ContentItemDetails, int64, error)
+	MockSearchContentFn                           func(ctx context.Context, input *dto.ContentSearchInput, pagination *domain.Pagination) (*gorm.ContentSearchPage, error)
+	MockListRecommendedContentItemsFn             func(ctx context.Context, userID string, limit int) ([]*gorm.ContentItemDetails, error)
+	MockListContentFeedFn                         func(ctx context.Context, categoryID *int, sort enums.ContentSortOrder, trendingDays int, after *domain.ContentFeedPosition, before *domain.ContentFeedPosition, limit int) (*gorm.ContentFeedPage, error)
+	MockReconcileContentEngagementFn              func(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error)
+	MockGetContentAnalyticsFn                     func(ctx context.Context, input *dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error)
+	MockRecordContentReadDurationFn               func(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error)
+	MockRecordContentProgressFn                   func(ctx context.Context, report *gorm.ContentProgress, threshold domain.ContentCompletionThreshold) (*gorm.ContentProgress, bool, error)
+	MockListContentInProgressFn                   func(ctx context.Context, userID string, limit int) ([]*gorm.ContentInProgress, error)
+	MockRecordContentShareOpenFn                  func(ctx context.Context, shareLinkID string, openedByID *string) (*gorm.ContentShareLink, error)
+	MockGetContentCommentByIDFn                   func(ctx context.Context, commentID string) (*gorm.ContentCommentDetails, error)
+	MockListContentCommentsFn                     func(ctx context.Context, contentID int) ([]*gorm.ContentCommentDetails, error)
+	MockListContentCommentsByStatusFn             func(ctx context.Context, statuses []enums.ContentCommentStatus, limit int) ([]*gorm.ContentCommentDetails, error)
+	MockCreateContentCommentFn                    func(ctx context.Context, comment *gorm.ContentComment) (*gorm.ContentCommentDetails, error)
+	MockEditContentCommentFn                      func(ctx context.Context, commentID string, body string, status enums.ContentCommentStatus) (*gorm.ContentCommentDetails, error)
+	MockDeleteContentCommentFn                    func(ctx context.Context, commentID string) (bool, error)
+	MockModerateContentCommentFn                  func(ctx context.Context, commentID string, status enums.ContentCommentStatus, moderatorID string) (*gorm.ContentCommentDetails, error)
+	MockCreateBulkInviteJobFn                     func(ctx context.Context, job *gorm.BulkInviteJob) (*gorm.BulkInviteJob, error)
+	MockGetBulkInviteJobFn                        func(ctx context.Context, jobID string) (*gorm.BulkInviteJob, error)
+	MockUpdateBulkInviteJobStatusFn               func(ctx context.Context, jobID string, status enums.BulkInviteJobStatus) (bool, error)
+	MockRecordBulkInviteRowOutcomeFn              func(ctx context.Context, jobID string, rowID string, status enums.BulkInviteRowStatus, errorMessage string) (bool, error)
+	MockFailInterruptedBulkInviteJobsFn           func(ctx context.Context, staleAfter time.Duration) (int, error)
+	MockAssignFirstSystemAdminFn                  func(ctx context.Context, userID string) (bool, error)
 }
 
 // NewGormMock initializes a new instance of `GormMock` then mocking the case of success.
@@ -127,6 +191,15 @@ func NewGormMock() *GormMock {
 		ID: &UUID,
 	}
 
+	staff := &gorm.StaffProfile{
+		ID:                &UUID,
+		UserID:            &UUID,
+		Active:            true,
+		StaffNumber:       ksuid.New().String(),
+		Cadre:             enums.StaffCadreClinician,
+		DefaultFacilityID: UUID,
+	}
+
 	pinData := &gorm.PINData{
 		PINDataID: &ID,
 		UserID:    gofakeit.UUID(),
@@ -163,7 +236,7 @@ func NewGormMock() *GormMock {
 		MockRetrieveFacilityByMFLCodeFn: func(ctx context.Context, MFLCode int, isActive bool) (*gorm.Facility, error) {
 			return facility, nil
 		},
-		MockListFacilitiesFn: func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
+		MockListFacilitiesFn: func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, filterGroups []*domain.FilterGroup, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
 			return facilitiesPage, nil
 		},
 
@@ -313,8 +386,14 @@ func NewGormMock() *GormMock {
 		MockListContentCategoriesFn: func(ctx context.Context) ([]*domain.ContentItemCategory, error) {
 			return []*domain.ContentItemCategory{contentItemCategory}, nil
 		},
-		MockShareContentFn: func(ctx context.Context, input dto.ShareContentInput) (bool, error) {
-			return true, nil
+		MockShareContentFn: func(ctx context.Context, input dto.ShareContentInput) (*gorm.ContentShareLink, error) {
+			id := uuid.New().String()
+			return &gorm.ContentShareLink{
+				ID:        &id,
+				ContentID: input.ContentID,
+				UserID:    input.UserID,
+				Channel:   input.Channel,
+			}, nil
 		}, MockGetUserBookmarkedContentFn: func(ctx context.Context, userID string) ([]*gorm.ContentItem, error) {
 			return []*gorm.ContentItem{
 				{
@@ -343,7 +422,7 @@ func NewGormMock() *GormMock {
 		MockCreateServiceRequestFn: func(ctx context.Context, serviceRequestInput *gorm.ClientServiceRequest) error {
 			return nil
 		},
-		MockCanRecordHeathDiaryFn: func(ctx context.Context, clientID string) (bool, error) {
+		MockCanRecordHeathDiaryFn: func(ctx context.Context, clientID string, cadence time.Duration) (bool, error) {
 			return true, nil
 		},
 		MockGetClientHealthDiaryQuoteFn: func(ctx context.Context) (*gorm.ClientHealthDiaryQuote, error) {
@@ -378,6 +457,588 @@ func NewGormMock() *GormMock {
 			}, nil
 
 		},
+		MockUpdateUserLanguagesFn: func(ctx context.Context, userID string, languages []string) (bool, error) {
+			return true, nil
+		},
+		MockSaveInvitationFn: func(ctx context.Context, invitation *gorm.Invitation) (*gorm.Invitation, error) {
+			id := uuid.New().String()
+			invitation.ID = &id
+			return invitation, nil
+		},
+		MockGetLatestUserInvitationFn: func(ctx context.Context, userID string, flavour feedlib.Flavour) (*gorm.Invitation, error) {
+			id := uuid.New().String()
+			return &gorm.Invitation{
+				ID:          &id,
+				UserID:      userID,
+				PhoneNumber: gofakeit.Phone(),
+				Flavour:     flavour,
+				Status:      enums.InvitationStatusDelivered,
+				IssuedAt:    time.Now(),
+				ExpiresAt:   time.Now().Add(time.Hour * 24),
+			}, nil
+		},
+		MockListPendingInvitationsFn: func(ctx context.Context, facilityID string) ([]*gorm.Invitation, error) {
+			id := uuid.New().String()
+			return []*gorm.Invitation{
+				{
+					ID:          &id,
+					UserID:      uuid.New().String(),
+					PhoneNumber: gofakeit.Phone(),
+					Flavour:     feedlib.FlavourConsumer,
+					Status:      enums.InvitationStatusIssued,
+					IssuedAt:    time.Now(),
+					ExpiresAt:   time.Now().Add(time.Hour * 24),
+				},
+			}, nil
+		},
+		MockUpdateInvitationStatusFn: func(ctx context.Context, invitationID string, status enums.InvitationStatus) (bool, error) {
+			return true, nil
+		},
+		MockCloseUserInvitationsFn: func(ctx context.Context, userID string, flavour feedlib.Flavour, status enums.InvitationStatus) (bool, error) {
+			return true, nil
+		},
+		MockExpireOverdueInvitationsFn: func(ctx context.Context) (bool, error) {
+			return true, nil
+		},
+		MockRegisterClientFn: func(ctx context.Context, user *gorm.User, contact *gorm.Contact, client *gorm.Client) (*gorm.Client, error) {
+			id := uuid.New().String()
+			user.UserID = &id
+			client.ID = &id
+			client.UserID = &id
+			contact.UserID = &id
+			user.Contacts = *contact
+			client.UserProfile = *user
+			return client, nil
+		},
+		MockRegisterStaffFn: func(ctx context.Context, user *gorm.User, contact *gorm.Contact, staff *gorm.StaffProfile) (*gorm.StaffProfile, error) {
+			id := uuid.New().String()
+			user.UserID = &id
+			staff.ID = &id
+			staff.UserID = &id
+			contact.UserID = &id
+			user.Contacts = *contact
+			staff.UserProfile = *user
+			return staff, nil
+		},
+		MockCreateStaffProfileFn: func(ctx context.Context, staff *gorm.StaffProfile, facilityIDs []string) (*gorm.StaffProfile, error) {
+			id := uuid.New().String()
+			staff.ID = &id
+			return staff, nil
+		},
+		MockGetStaffProfileByUserIDFn: func(ctx context.Context, userID string) (*gorm.StaffProfile, error) {
+			return staff, nil
+		},
+		MockGetStaffProfileByStaffIDFn: func(ctx context.Context, staffID string) (*gorm.StaffProfile, error) {
+			return staff, nil
+		},
+		MockGetStaffFacilitiesFn: func(ctx context.Context, staffID string) ([]*gorm.Facility, error) {
+			return []*gorm.Facility{facility}, nil
+		},
+		MockUpdateStaffProfileFn: func(ctx context.Context, staffID string, input *dto.StaffProfileUpdateInput) (bool, error) {
+			return true, nil
+		},
+		MockAddStaffFacilitiesFn: func(ctx context.Context, staffID string, facilityIDs []string) (bool, error) {
+			return true, nil
+		},
+		MockRemoveStaffFacilitiesFn: func(ctx context.Context, staffID string, facilityIDs []string) (bool, error) {
+			return true, nil
+		},
+		MockAssignRolesFn: func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+			return true, nil
+		},
+		MockGetUserRolesFn: func(ctx context.Context, userID string) ([]*gorm.UserRole, error) {
+			return []*gorm.UserRole{
+				{
+					ID:     &UUID,
+					UserID: userID,
+					Role:   enums.UserRoleTypeClinician,
+				},
+			}, nil
+		},
+		MockGetClientProfileByClientIDFn: func(ctx context.Context, clientID string) (*gorm.Client, error) {
+			return client, nil
+		},
+		MockRevokeRolesFn: func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+			return true, nil
+		},
+		MockCreateOrganisationFn: func(ctx context.Context, organisation *gorm.Organisation) (*gorm.Organisation, error) {
+			return organisation, nil
+		},
+		MockGetOrganisationFn: func(ctx context.Context, organisationID string) (*gorm.Organisation, error) {
+			id := uuid.New().String()
+			return &gorm.Organisation{
+				OrganisationID:   &id,
+				Active:           true,
+				OrgCode:          "test",
+				Code:             1,
+				OrganisationName: gofakeit.Company(),
+				EmailAddress:     gofakeit.Email(),
+				PhoneNumber:      "+254711223344",
+				DefaultCountry:   "KEN",
+			}, nil
+		},
+		MockListOrganisationsFn: func(ctx context.Context) ([]*gorm.Organisation, error) {
+			id := uuid.New().String()
+			return []*gorm.Organisation{
+				{
+					OrganisationID:   &id,
+					Active:           true,
+					OrganisationName: gofakeit.Company(),
+				},
+			}, nil
+		},
+		MockUpdateOrganisationFn: func(ctx context.Context, organisationID string, input *dto.OrganisationUpdateInput) (bool, error) {
+			return true, nil
+		},
+		MockDeactivateOrganisationFn: func(ctx context.Context, organisationID string) (bool, error) {
+			return true, nil
+		},
+		MockUpdateFacilityFn: func(ctx context.Context, mflCode int, input *dto.FacilityUpdateInput, changedBy string) (*gorm.Facility, error) {
+			id := uuid.New().String()
+			return &gorm.Facility{
+				FacilityID:  &id,
+				Name:        gofakeit.Name(),
+				Code:        mflCode,
+				Active:      true,
+				County:      "Nairobi",
+				Phone:       "+254711223344",
+				Description: gofakeit.HipsterSentence(15),
+			}, nil
+		},
+		MockGetFacilityHistoryFn: func(ctx context.Context, mflCode int) ([]*gorm.FacilityHistory, error) {
+			id := uuid.New().String()
+			return []*gorm.FacilityHistory{
+				{
+					ID:         &id,
+					FacilityID: uuid.New().String(),
+					Field:      "name",
+					OldValue:   gofakeit.Name(),
+					NewValue:   gofakeit.Name(),
+					ChangedBy:  uuid.New().String(),
+					ChangedAt:  time.Now(),
+				},
+			}, nil
+		},
+		MockUpsertFacilitiesFn: func(ctx context.Context, facilities []*gorm.Facility, changedBy string, dryRun bool) ([]*domain.FacilityImportRow, error) {
+			rows := []*domain.FacilityImportRow{}
+			for _, facility := range facilities {
+				rows = append(rows, &domain.FacilityImportRow{
+					MFLCode: facility.Code,
+					Name:    facility.Name,
+					Status:  enums.FacilityImportStatusCreated,
+				})
+			}
+			return rows, nil
+		},
+		MockNearbyFacilitiesFn: func(ctx context.Context, latitude, longitude, radiusKm float64, limit int, filter []*domain.FiltersParam, sort *domain.SortParam) ([]*gorm.NearbyFacility, error) {
+			id := uuid.New().String()
+			return []*gorm.NearbyFacility{
+				{
+					Facility: gorm.Facility{
+						FacilityID:  &id,
+						Name:        gofakeit.Name(),
+						Code:        gofakeit.Number(1000, 100000),
+						Active:      true,
+						County:      "Nairobi",
+						Phone:       "+254711223344",
+						Description: gofakeit.HipsterSentence(15),
+						Latitude:    &latitude,
+						Longitude:   &longitude,
+					},
+					DistanceKm: 1.5,
+				},
+			}, nil
+		},
+		MockSetFacilityOpeningHoursFn: func(ctx context.Context, mflCode int, openingHours []*gorm.FacilityOpeningHours) error {
+			return nil
+		},
+		MockSetFacilityHoursExceptionFn: func(ctx context.Context, mflCode int, exception *gorm.FacilityHoursException) error {
+			return nil
+		},
+		MockSetFacilityServicesFn: func(ctx context.Context, mflCode int, serviceCodes []string) error {
+			return nil
+		},
+		MockCreateFacilityServiceFn: func(ctx context.Context, service *gorm.FacilityService) (*gorm.FacilityService, error) {
+			id := uuid.New().String()
+			service.ID = &id
+			return service, nil
+		},
+		MockListFacilityServicesFn: func(ctx context.Context) ([]*gorm.FacilityService, error) {
+			id := uuid.New().String()
+			return []*gorm.FacilityService{
+				{
+					ID:     &id,
+					Name:   "ART refill",
+					Code:   "ART_REFILL",
+					Active: true,
+				},
+			}, nil
+		},
+		MockDeleteFacilityHoursExceptionFn: func(ctx context.Context, mflCode int, date time.Time) (bool, error) {
+			return true, nil
+		},
+		MockCreateClientTransferFn: func(ctx context.Context, transfer *gorm.ClientTransfer, serviceRequest *gorm.ClientServiceRequest) (*gorm.ClientTransfer, error) {
+			id := uuid.New().String()
+			transfer.ID = &id
+			transfer.ServiceRequestID = serviceRequest.ID
+			transfer.Status = enums.ClientTransferStatusPending
+			return transfer, nil
+		},
+		MockGetClientTransferByIDFn: func(ctx context.Context, transferID string) (*gorm.ClientTransfer, error) {
+			id := uuid.New().String()
+			return &gorm.ClientTransfer{
+				ID:             &id,
+				ClientID:       uuid.New().String(),
+				FromFacilityID: uuid.New().String(),
+				ToFacilityID:   uuid.New().String(),
+				Reason:         "Client relocated",
+				Status:         enums.ClientTransferStatusPending,
+				RequestedByID:  uuid.New().String(),
+				RequestedAt:    time.Now(),
+			}, nil
+		},
+		MockCheckClientHasPendingTransferFn: func(ctx context.Context, clientID string) (bool, error) {
+			return false, nil
+		},
+		MockListPendingClientTransfersFn: func(ctx context.Context, facilityID string) ([]*gorm.ClientTransfer, error) {
+			id := uuid.New().String()
+			return []*gorm.ClientTransfer{
+				{
+					ID:             &id,
+					ClientID:       uuid.New().String(),
+					FromFacilityID: uuid.New().String(),
+					ToFacilityID:   facilityID,
+					Reason:         "Client relocated",
+					Status:         enums.ClientTransferStatusPending,
+					RequestedByID:  uuid.New().String(),
+					RequestedAt:    time.Now(),
+				},
+			}, nil
+		},
+		MockGetClientFacilityHistoryFn: func(ctx context.Context, clientID string) ([]*gorm.ClientFacility, error) {
+			id := uuid.New().String()
+			return []*gorm.ClientFacility{
+				{
+					ID:         &id,
+					ClientID:   clientID,
+					FacilityID: uuid.New().String(),
+					StartDate:  time.Now(),
+				},
+			}, nil
+		},
+		MockRespondToClientTransferFn: func(ctx context.Context, transferID string, status enums.ClientTransferStatus, respondedByID string, note string) (*gorm.ClientTransfer, error) {
+			now := time.Now()
+			return &gorm.ClientTransfer{
+				ID:             &transferID,
+				ClientID:       uuid.New().String(),
+				FromFacilityID: uuid.New().String(),
+				ToFacilityID:   uuid.New().String(),
+				Reason:         "Client relocated",
+				Status:         status,
+				RequestedByID:  uuid.New().String(),
+				RequestedAt:    now,
+				RespondedByID:  &respondedByID,
+				RespondedAt:    &now,
+				ResponseNote:   note,
+			}, nil
+		},
+		MockListContentItemsFn: func(ctx context.Context, categoryID *int, contentIDs []int, limit int) ([]*gorm.ContentItemDetails, int64, error) {
+			contentItems := []*gorm.ContentItemDetails{
+				{
+					ContentItem: gorm.ContentItem{
+						PagePtrID: 10,
+						Date:      time.Now(),
+						ItemType:  "ARTICLE",
+						Body:      gofakeit.Sentence(20),
+						AuthorID:  uuid.New().String(),
+					},
+					Page: gorm.WagtailCorePage{
+						WagtailCorePageID: 10,
+						Title:             gofakeit.Sentence(5),
+						Slug:              "test-slug",
+						Live:              true,
+					},
+					HeroImage: &gorm.WagtailImages{
+						ID:    1,
+						Title: gofakeit.Name(),
+						File:  "images/hero.png",
+					},
+					Categories: []*gorm.ContentCategoryDetails{
+						{
+							Category: gorm.ContentItemCategory{ID: 1, Name: "Welcome"},
+						},
+					},
+				},
+			}
+			return contentItems, int64(len(contentItems)), nil
+		},
+		MockSearchContentFn: func(ctx context.Context, input *dto.ContentSearchInput, pagination *domain.Pagination) (*gorm.ContentSearchPage, error) {
+			return &gorm.ContentSearchPage{
+				Pagination: domain.Pagination{Limit: 10, CurrentPage: 1, Count: 1, TotalPages: 1},
+				Results: []*gorm.ContentSearchHit{
+					{
+						Details: &gorm.ContentItemDetails{
+							ContentItem: gorm.ContentItem{
+								PagePtrID: 10,
+								Date:      time.Now(),
+								ItemType:  "ARTICLE",
+								Body:      gofakeit.Sentence(20),
+							},
+							Page: gorm.WagtailCorePage{
+								WagtailCorePageID: 10,
+								Title:             gofakeit.Sentence(5),
+								Live:              true,
+							},
+							TagNames: []string{"diet"},
+						},
+						Rank:    0.5,
+						Snippet: "a healthy <mark>diet</mark>",
+					},
+				},
+			}, nil
+		},
+		MockListRecommendedContentItemsFn: func(ctx context.Context, userID string, limit int) ([]*gorm.ContentItemDetails, error) {
+			return []*gorm.ContentItemDetails{
+				{
+					ContentItem: gorm.ContentItem{
+						PagePtrID: 10,
+						Date:      time.Now(),
+						ItemType:  "ARTICLE",
+						Body:      gofakeit.Sentence(20),
+					},
+					Page: gorm.WagtailCorePage{
+						WagtailCorePageID: 10,
+						Title:             gofakeit.Sentence(5),
+						Live:              true,
+					},
+				},
+			}, nil
+		},
+		MockListContentFeedFn: func(ctx context.Context, categoryID *int, sort enums.ContentSortOrder, trendingDays int, after *domain.ContentFeedPosition, before *domain.ContentFeedPosition, limit int) (*gorm.ContentFeedPage, error) {
+			contentItems := []*gorm.ContentItemDetails{
+				{
+					ContentItem: gorm.ContentItem{
+						PagePtrID: 10,
+						Date:      time.Now(),
+						ItemType:  "ARTICLE",
+						LikeCount: 5,
+					},
+					Page: gorm.WagtailCorePage{
+						WagtailCorePageID: 10,
+						Title:             gofakeit.Sentence(5),
+						Live:              true,
+					},
+				},
+			}
+			return &gorm.ContentFeedPage{
+				Items: contentItems,
+				Positions: []*domain.ContentFeedPosition{
+					{SortKey: 5, FirstPublishedAt: time.Now(), ContentID: 10},
+				},
+				Total: int64(len(contentItems)),
+			}, nil
+		},
+		MockReconcileContentEngagementFn: func(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error) {
+			return &domain.ContentEngagementReport{
+				Drift: []*domain.ContentCounterDrift{
+					{ContentID: 10, Counter: "like_count", Recorded: 2, Actual: 1},
+				},
+			}, nil
+		},
+		MockGetContentAnalyticsFn: func(ctx context.Context, input *dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error) {
+			return &domain.ContentAnalytics{
+				From: input.From,
+				To:   input.To,
+				Items: []*domain.ContentItemAnalytics{
+					{
+						ContentID:          10,
+						Title:              "PrEP and you",
+						Views:              10,
+						UniqueViewers:      4,
+						Likes:              2,
+						Bookmarks:          1,
+						Shares:             1,
+						AverageReadSeconds: 95.5,
+					},
+				},
+				Categories: []*domain.ContentCategoryAnalytics{
+					{
+						CategoryID:         1,
+						Name:               "Prevention",
+						ContentItems:       1,
+						Views:              10,
+						UniqueViewers:      4,
+						Likes:              2,
+						Bookmarks:          1,
+						Shares:             1,
+						AverageReadSeconds: 95.5,
+					},
+				},
+			}, nil
+		},
+		MockRecordContentReadDurationFn: func(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error) {
+			return true, nil
+		},
+		MockRecordContentProgressFn: func(ctx context.Context, report *gorm.ContentProgress, threshold domain.ContentCompletionThreshold) (*gorm.ContentProgress, bool, error) {
+			id := uuid.New().String()
+			return &gorm.ContentProgress{
+				ID:              &id,
+				Active:          true,
+				ContentID:       report.ContentID,
+				UserID:          report.UserID,
+				SecondsSpent:    report.SecondsSpent,
+				PercentScrolled: report.PercentScrolled,
+			}, false, nil
+		},
+		MockListContentInProgressFn: func(ctx context.Context, userID string, limit int) ([]*gorm.ContentInProgress, error) {
+			id := uuid.New().String()
+			return []*gorm.ContentInProgress{
+				{
+					Progress: &gorm.ContentProgress{
+						ID:              &id,
+						Active:          true,
+						ContentID:       10,
+						UserID:          userID,
+						SecondsSpent:    60,
+						PercentScrolled: 40,
+					},
+					Details: &gorm.ContentItemDetails{
+						ContentItem: gorm.ContentItem{PagePtrID: 10},
+					},
+				},
+			}, nil
+		},
+		MockRecordContentShareOpenFn: func(ctx context.Context, shareLinkID string, openedByID *string) (*gorm.ContentShareLink, error) {
+			return &gorm.ContentShareLink{
+				ID:        &shareLinkID,
+				ContentID: 10,
+				UserID:    gofakeit.UUID(),
+				Channel:   enums.ContentShareChannelWhatsApp,
+			}, nil
+		},
+		MockGetContentCommentByIDFn: func(ctx context.Context, commentID string) (*gorm.ContentCommentDetails, error) {
+			return &gorm.ContentCommentDetails{
+				Comment: &gorm.ContentComment{
+					ID:        &commentID,
+					Active:    true,
+					ContentID: 10,
+					UserID:    uuid.New().String(),
+					Body:      "How long should I take the medication?",
+					Status:    enums.ContentCommentStatusApproved,
+				},
+				Nickname: gofakeit.Username(),
+			}, nil
+		},
+		MockListContentCommentsFn: func(ctx context.Context, contentID int) ([]*gorm.ContentCommentDetails, error) {
+			id := uuid.New().String()
+			return []*gorm.ContentCommentDetails{
+				{
+					Comment: &gorm.ContentComment{
+						ID:        &id,
+						Active:    true,
+						ContentID: contentID,
+						UserID:    uuid.New().String(),
+						Body:      "How long should I take the medication?",
+						Status:    enums.ContentCommentStatusApproved,
+					},
+					Nickname: gofakeit.Username(),
+				},
+			}, nil
+		},
+		MockListContentCommentsByStatusFn: func(ctx context.Context, statuses []enums.ContentCommentStatus, limit int) ([]*gorm.ContentCommentDetails, error) {
+			id := uuid.New().String()
+			return []*gorm.ContentCommentDetails{
+				{
+					Comment: &gorm.ContentComment{
+						ID:        &id,
+						Active:    true,
+						ContentID: 10,
+						UserID:    uuid.New().String(),
+						Body:      "How long should I take the medication?",
+						Status:    enums.ContentCommentStatusPending,
+					},
+					Nickname: gofakeit.Username(),
+				},
+			}, nil
+		},
+		MockCreateContentCommentFn: func(ctx context.Context, comment *gorm.ContentComment) (*gorm.ContentCommentDetails, error) {
+			id := uuid.New().String()
+			comment.ID = &id
+			comment.Active = true
+			return &gorm.ContentCommentDetails{Comment: comment, Nickname: gofakeit.Username()}, nil
+		},
+		MockEditContentCommentFn: func(ctx context.Context, commentID string, body string, status enums.ContentCommentStatus) (*gorm.ContentCommentDetails, error) {
+			editedAt := time.Now()
+			return &gorm.ContentCommentDetails{
+				Comment: &gorm.ContentComment{
+					ID:        &commentID,
+					Active:    true,
+					ContentID: 10,
+					UserID:    uuid.New().String(),
+					Body:      body,
+					Status:    status,
+					EditedAt:  &editedAt,
+				},
+				Nickname: gofakeit.Username(),
+			}, nil
+		},
+		MockDeleteContentCommentFn: func(ctx context.Context, commentID string) (bool, error) {
+			return true, nil
+		},
+		MockModerateContentCommentFn: func(ctx context.Context, commentID string, status enums.ContentCommentStatus, moderatorID string) (*gorm.ContentCommentDetails, error) {
+			moderatedAt := time.Now()
+			return &gorm.ContentCommentDetails{
+				Comment: &gorm.ContentComment{
+					ID:            &commentID,
+					Active:        true,
+					ContentID:     10,
+					UserID:        uuid.New().String(),
+					Body:          "How long should I take the medication?",
+					Status:        status,
+					ModeratedByID: &moderatorID,
+					ModeratedAt:   &moderatedAt,
+				},
+				Nickname: gofakeit.Username(),
+			}, nil
+		},
+		MockCreateBulkInviteJobFn: func(ctx context.Context, job *gorm.BulkInviteJob) (*gorm.BulkInviteJob, error) {
+			id := uuid.New().String()
+			job.ID = &id
+			return job, nil
+		},
+		MockGetBulkInviteJobFn: func(ctx context.Context, jobID string) (*gorm.BulkInviteJob, error) {
+			rowID := uuid.New().String()
+			return &gorm.BulkInviteJob{
+				ID:          &jobID,
+				Status:      enums.BulkInviteJobStatusCompleted,
+				Flavour:     feedlib.FlavourConsumer,
+				Total:       1,
+				Succeeded:   1,
+				CreatedByID: uuid.New().String(),
+				Rows: []*gorm.BulkInviteRow{
+					{
+						ID:          &rowID,
+						JobID:       &jobID,
+						RowNumber:   1,
+						UserID:      uuid.New().String(),
+						PhoneNumber: gofakeit.Phone(),
+						Status:      enums.BulkInviteRowStatusSuccess,
+					},
+				},
+			}, nil
+		},
+		MockUpdateBulkInviteJobStatusFn: func(ctx context.Context, jobID string, status enums.BulkInviteJobStatus) (bool, error) {
+			return true, nil
+		},
+		MockRecordBulkInviteRowOutcomeFn: func(ctx context.Context, jobID string, rowID string, status enums.BulkInviteRowStatus, errorMessage string) (bool, error) {
+			return true, nil
+		},
+		MockFailInterruptedBulkInviteJobsFn: func(ctx context.Context, staleAfter time.Duration) (int, error) {
+			return 1, nil
+		},
+		MockAssignFirstSystemAdminFn: func(ctx context.Context, userID string) (bool, error) {
+			return true, nil
+		},
 	}
 }
 
@@ -413,8 +1074,8 @@ func (gm *GormMock) DeleteFacility(ctx context.Context, mflcode int) (bool, erro
 }
 
 // ListFacilities mocks the implementation of  ListFacilities method.
-func (gm *GormMock) ListFacilities(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
-	return gm.MockListFacilitiesFn(ctx, searchTerm, filter, pagination)
+func (gm *GormMock) ListFacilities(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, filterGroups []*domain.FilterGroup, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
+	return gm.MockListFacilitiesFn(ctx, searchTerm, filter, filterGroups, pagination)
 }
 
 // GetUserProfileByPhoneNumber mocks the implementation of retrieving a user profile by phonenumber
@@ -568,7 +1229,7 @@ func (gm *GormMock) ListContentCategories(ctx context.Context) ([]*domain.Conten
 }
 
 // ShareContent mocks the implementation of sharing the content
-func (gm *GormMock) ShareContent(ctx context.Context, input dto.ShareContentInput) (bool, error) {
+func (gm *GormMock) ShareContent(ctx context.Context, input dto.ShareContentInput) (*gorm.ContentShareLink, error) {
 	return gm.MockShareContentFn(ctx, input)
 }
 
@@ -613,8 +1274,8 @@ func (gm *GormMock) CreateServiceRequest(ctx context.Context, serviceRequestInpu
 }
 
 // CanRecordHeathDiary mocks the implementation of checking if a user can record a health diary
-func (gm *GormMock) CanRecordHeathDiary(ctx context.Context, userID string) (bool, error) {
-	return gm.MockCanRecordHeathDiaryFn(ctx, userID)
+func (gm *GormMock) CanRecordHeathDiary(ctx context.Context, userID string, cadence time.Duration) (bool, error) {
+	return gm.MockCanRecordHeathDiaryFn(ctx, userID, cadence)
 }
 
 // GetClientHealthDiaryQuote mocks the implementation of getting a client's health diary quote
@@ -636,3 +1297,323 @@ func (gm *GormMock) GetClientHealthDiaryEntries(ctx context.Context, clientID st
 func (gm *GormMock) GetFAQContent(ctx context.Context, flavour feedlib.Flavour, limit *int) ([]*gorm.FAQ, error) {
 	return gm.MockGetFAQContentFn(ctx, flavour, limit)
 }
+
+// UpdateUserLanguages mocks the implementation of updating a user's preferred languages
+func (gm *GormMock) UpdateUserLanguages(ctx context.Context, userID string, languages []string) (bool, error) {
+	return gm.MockUpdateUserLanguagesFn(ctx, userID, languages)
+}
+
+// SaveInvitation mocks the implementation of saving an invitation
+func (gm *GormMock) SaveInvitation(ctx context.Context, invitation *gorm.Invitation) (*gorm.Invitation, error) {
+	return gm.MockSaveInvitationFn(ctx, invitation)
+}
+
+// GetLatestUserInvitation mocks the implementation of getting the latest invitation sent to a user
+func (gm *GormMock) GetLatestUserInvitation(ctx context.Context, userID string, flavour feedlib.Flavour) (*gorm.Invitation, error) {
+	return gm.MockGetLatestUserInvitationFn(ctx, userID, flavour)
+}
+
+// ListPendingInvitations mocks the implementation of listing the pending invitations of a facility
+func (gm *GormMock) ListPendingInvitations(ctx context.Context, facilityID string) ([]*gorm.Invitation, error) {
+	return gm.MockListPendingInvitationsFn(ctx, facilityID)
+}
+
+// UpdateInvitationStatus mocks the implementation of updating the status of an invitation
+func (gm *GormMock) UpdateInvitationStatus(ctx context.Context, invitationID string, status enums.InvitationStatus) (bool, error) {
+	return gm.MockUpdateInvitationStatusFn(ctx, invitationID, status)
+}
+
+// CloseUserInvitations mocks the implementation of closing a user's pending invitations
+func (gm *GormMock) CloseUserInvitations(ctx context.Context, userID string, flavour feedlib.Flavour, status enums.InvitationStatus) (bool, error) {
+	return gm.MockCloseUserInvitationsFn(ctx, userID, flavour, status)
+}
+
+// ExpireOverdueInvitations mocks the implementation of expiring overdue invitations
+func (gm *GormMock) ExpireOverdueInvitations(ctx context.Context) (bool, error) {
+	return gm.MockExpireOverdueInvitationsFn(ctx)
+}
+
+// RegisterClient mocks the implementation of registering a client
+func (gm *GormMock) RegisterClient(ctx context.Context, user *gorm.User, contact *gorm.Contact, client *gorm.Client) (*gorm.Client, error) {
+	return gm.MockRegisterClientFn(ctx, user, contact, client)
+}
+
+// RegisterStaff mocks the implementation of registering a staff member
+func (gm *GormMock) RegisterStaff(ctx context.Context, user *gorm.User, contact *gorm.Contact, staff *gorm.StaffProfile) (*gorm.StaffProfile, error) {
+	return gm.MockRegisterStaffFn(ctx, user, contact, staff)
+}
+
+// CreateStaffProfile mocks the implementation of creating a staff profile
+func (gm *GormMock) CreateStaffProfile(ctx context.Context, staff *gorm.StaffProfile, facilityIDs []string) (*gorm.StaffProfile, error) {
+	return gm.MockCreateStaffProfileFn(ctx, staff, facilityIDs)
+}
+
+// GetStaffProfileByUserID mocks the implementation of getting a staff profile by user ID
+func (gm *GormMock) GetStaffProfileByUserID(ctx context.Context, userID string) (*gorm.StaffProfile, error) {
+	return gm.MockGetStaffProfileByUserIDFn(ctx, userID)
+}
+
+// GetStaffProfileByStaffID mocks the implementation of getting a staff profile by its ID
+func (gm *GormMock) GetStaffProfileByStaffID(ctx context.Context, staffID string) (*gorm.StaffProfile, error) {
+	return gm.MockGetStaffProfileByStaffIDFn(ctx, staffID)
+}
+
+// GetStaffFacilities mocks the implementation of getting the facilities a staff member can access
+func (gm *GormMock) GetStaffFacilities(ctx context.Context, staffID string) ([]*gorm.Facility, error) {
+	return gm.MockGetStaffFacilitiesFn(ctx, staffID)
+}
+
+// UpdateStaffProfile mocks the implementation of updating a staff profile
+func (gm *GormMock) UpdateStaffProfile(ctx context.Context, staffID string, input *dto.StaffProfileUpdateInput) (bool, error) {
+	return gm.MockUpdateStaffProfileFn(ctx, staffID, input)
+}
+
+// AddStaffFacilities mocks the implementation of giving a staff member access to facilities
+func (gm *GormMock) AddStaffFacilities(ctx context.Context, staffID string, facilityIDs []string) (bool, error) {
+	return gm.MockAddStaffFacilitiesFn(ctx, staffID, facilityIDs)
+}
+
+// RemoveStaffFacilities mocks the implementation of revoking a staff member's access to facilities
+func (gm *GormMock) RemoveStaffFacilities(ctx context.Context, staffID string, facilityIDs []string) (bool, error) {
+	return gm.MockRemoveStaffFacilitiesFn(ctx, staffID, facilityIDs)
+}
+
+// AssignRoles mocks the implementation of assigning roles to a user
+func (gm *GormMock) AssignRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+	return gm.MockAssignRolesFn(ctx, userID, roles)
+}
+
+// GetUserRoles mocks the implementation of getting the roles assigned to a user
+func (gm *GormMock) GetUserRoles(ctx context.Context, userID string) ([]*gorm.UserRole, error) {
+	return gm.MockGetUserRolesFn(ctx, userID)
+}
+
+// GetClientProfileByClientID mocks the implementation of getting a client profile by its ID
+func (gm *GormMock) GetClientProfileByClientID(ctx context.Context, clientID string) (*gorm.Client, error) {
+	return gm.MockGetClientProfileByClientIDFn(ctx, clientID)
+}
+
+// RevokeRoles mocks the implementation of revoking a user's roles
+func (gm *GormMock) RevokeRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+	return gm.MockRevokeRolesFn(ctx, userID, roles)
+}
+
+// CreateOrganisation mocks the implementation of creating an organisation
+func (gm *GormMock) CreateOrganisation(ctx context.Context, organisation *gorm.Organisation) (*gorm.Organisation, error) {
+	return gm.MockCreateOrganisationFn(ctx, organisation)
+}
+
+// GetOrganisation mocks the implementation of fetching an organisation
+func (gm *GormMock) GetOrganisation(ctx context.Context, organisationID string) (*gorm.Organisation, error) {
+	return gm.MockGetOrganisationFn(ctx, organisationID)
+}
+
+// ListOrganisations mocks the implementation of listing organisations
+func (gm *GormMock) ListOrganisations(ctx context.Context) ([]*gorm.Organisation, error) {
+	return gm.MockListOrganisationsFn(ctx)
+}
+
+// UpdateOrganisation mocks the implementation of updating an organisation
+func (gm *GormMock) UpdateOrganisation(ctx context.Context, organisationID string, input *dto.OrganisationUpdateInput) (bool, error) {
+	return gm.MockUpdateOrganisationFn(ctx, organisationID, input)
+}
+
+// DeactivateOrganisation mocks the implementation of deactivating an organisation
+func (gm *GormMock) DeactivateOrganisation(ctx context.Context, organisationID string) (bool, error) {
+	return gm.MockDeactivateOrganisationFn(ctx, organisationID)
+}
+
+// UpdateFacility mocks the implementation of updating the details of a facility
+func (gm *GormMock) UpdateFacility(ctx context.Context, mflCode int, input *dto.FacilityUpdateInput, changedBy string) (*gorm.Facility, error) {
+	return gm.MockUpdateFacilityFn(ctx, mflCode, input, changedBy)
+}
+
+// GetFacilityHistory mocks the implementation of fetching the changes made to a facility
+func (gm *GormMock) GetFacilityHistory(ctx context.Context, mflCode int) ([]*gorm.FacilityHistory, error) {
+	return gm.MockGetFacilityHistoryFn(ctx, mflCode)
+}
+
+// UpsertFacilities mocks the implementation of creating or updating facilities from a KMFL export
+func (gm *GormMock) UpsertFacilities(ctx context.Context, facilities []*gorm.Facility, changedBy string, dryRun bool) ([]*domain.FacilityImportRow, error) {
+	return gm.MockUpsertFacilitiesFn(ctx, facilities, changedBy, dryRun)
+}
+
+// NearbyFacilities mocks the implementation of fetching the facilities near a location
+func (gm *GormMock) NearbyFacilities(ctx context.Context, latitude, longitude, radiusKm float64, limit int, filter []*domain.FiltersParam, sort *domain.SortParam) ([]*gorm.NearbyFacility, error) {
+	return gm.MockNearbyFacilitiesFn(ctx, latitude, longitude, radiusKm, limit, filter, sort)
+}
+
+// SetFacilityOpeningHours mocks the implementation of replacing a facility's opening hours
+func (gm *GormMock) SetFacilityOpeningHours(ctx context.Context, mflCode int, openingHours []*gorm.FacilityOpeningHours) error {
+	return gm.MockSetFacilityOpeningHoursFn(ctx, mflCode, openingHours)
+}
+
+// SetFacilityHoursException mocks the implementation of saving an exception to a facility's opening hours
+func (gm *GormMock) SetFacilityHoursException(ctx context.Context, mflCode int, exception *gorm.FacilityHoursException) error {
+	return gm.MockSetFacilityHoursExceptionFn(ctx, mflCode, exception)
+}
+
+// SetFacilityServices mocks the implementation of replacing the services a facility offers
+func (gm *GormMock) SetFacilityServices(ctx context.Context, mflCode int, serviceCodes []string) error {
+	return gm.MockSetFacilityServicesFn(ctx, mflCode, serviceCodes)
+}
+
+// CreateFacilityService mocks the implementation of adding a service to the catalogue
+func (gm *GormMock) CreateFacilityService(ctx context.Context, service *gorm.FacilityService) (*gorm.FacilityService, error) {
+	return gm.MockCreateFacilityServiceFn(ctx, service)
+}
+
+// ListFacilityServices mocks the implementation of listing the catalogue of facility services
+func (gm *GormMock) ListFacilityServices(ctx context.Context) ([]*gorm.FacilityService, error) {
+	return gm.MockListFacilityServicesFn(ctx)
+}
+
+// DeleteFacilityHoursException mocks the implementation of removing a facility's hours exception
+func (gm *GormMock) DeleteFacilityHoursException(ctx context.Context, mflCode int, date time.Time) (bool, error) {
+	return gm.MockDeleteFacilityHoursExceptionFn(ctx, mflCode, date)
+}
+
+// CreateClientTransfer mocks the implementation of recording a client transfer and its service request
+func (gm *GormMock) CreateClientTransfer(ctx context.Context, transfer *gorm.ClientTransfer, serviceRequest *gorm.ClientServiceRequest) (*gorm.ClientTransfer, error) {
+	return gm.MockCreateClientTransferFn(ctx, transfer, serviceRequest)
+}
+
+// GetClientTransferByID mocks the implementation of fetching a client transfer by its ID
+func (gm *GormMock) GetClientTransferByID(ctx context.Context, transferID string) (*gorm.ClientTransfer, error) {
+	return gm.MockGetClientTransferByIDFn(ctx, transferID)
+}
+
+// CheckClientHasPendingTransfer mocks the implementation of checking whether a client has a pending transfer
+func (gm *GormMock) CheckClientHasPendingTransfer(ctx context.Context, clientID string) (bool, error) {
+	return gm.MockCheckClientHasPendingTransferFn(ctx, clientID)
+}
+
+// ListPendingClientTransfers mocks the implementation of listing the pending transfers into a facility
+func (gm *GormMock) ListPendingClientTransfers(ctx context.Context, facilityID string) ([]*gorm.ClientTransfer, error) {
+	return gm.MockListPendingClientTransfersFn(ctx, facilityID)
+}
+
+// GetClientFacilityHistory mocks the implementation of fetching the facilities a client has been assigned to
+func (gm *GormMock) GetClientFacilityHistory(ctx context.Context, clientID string) ([]*gorm.ClientFacility, error) {
+	return gm.MockGetClientFacilityHistoryFn(ctx, clientID)
+}
+
+// RespondToClientTransfer mocks the implementation of accepting or declining a client transfer
+func (gm *GormMock) RespondToClientTransfer(ctx context.Context, transferID string, status enums.ClientTransferStatus, respondedByID string, note string) (*gorm.ClientTransfer, error) {
+	return gm.MockRespondToClientTransferFn(ctx, transferID, status, respondedByID, note)
+}
+
+// ListContentItems mocks the implementation of reading content items from the tables shared with the CMS
+func (gm *GormMock) ListContentItems(ctx context.Context, categoryID *int, contentIDs []int, limit int) ([]*gorm.ContentItemDetails, int64, error) {
+	return gm.MockListContentItemsFn(ctx, categoryID, contentIDs, limit)
+}
+
+// SearchContent mocks the implementation of searching content in the tables shared with the CMS
+func (gm *GormMock) SearchContent(ctx context.Context, input *dto.ContentSearchInput, pagination *domain.Pagination) (*gorm.ContentSearchPage, error) {
+	return gm.MockSearchContentFn(ctx, input, pagination)
+}
+
+// ListRecommendedContentItems mocks the implementation of recommending content from a user's engagement history
+func (gm *GormMock) ListRecommendedContentItems(ctx context.Context, userID string, limit int) ([]*gorm.ContentItemDetails, error) {
+	return gm.MockListRecommendedContentItemsFn(ctx, userID, limit)
+}
+
+// ListContentFeed mocks the implementation of listing a page of the content feed
+func (gm *GormMock) ListContentFeed(ctx context.Context, categoryID *int, sort enums.ContentSortOrder, trendingDays int, after *domain.ContentFeedPosition, before *domain.ContentFeedPosition, limit int) (*gorm.ContentFeedPage, error) {
+	return gm.MockListContentFeedFn(ctx, categoryID, sort, trendingDays, after, before, limit)
+}
+
+// ReconcileContentEngagement mocks the implementation of reconciling the content engagement counters
+func (gm *GormMock) ReconcileContentEngagement(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error) {
+	return gm.MockReconcileContentEngagementFn(ctx, dryRun)
+}
+
+// GetContentAnalytics mocks the implementation of getting content analytics
+func (gm *GormMock) GetContentAnalytics(ctx context.Context, input *dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error) {
+	return gm.MockGetContentAnalyticsFn(ctx, input)
+}
+
+// RecordContentReadDuration mocks the implementation of recording how long a user spent reading content
+func (gm *GormMock) RecordContentReadDuration(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error) {
+	return gm.MockRecordContentReadDurationFn(ctx, userID, contentID, durationSeconds)
+}
+
+// RecordContentProgress mocks the implementation of recording a user's progress with content
+func (gm *GormMock) RecordContentProgress(ctx context.Context, report *gorm.ContentProgress, threshold domain.ContentCompletionThreshold) (*gorm.ContentProgress, bool, error) {
+	return gm.MockRecordContentProgressFn(ctx, report, threshold)
+}
+
+// ListContentInProgress mocks the implementation of listing the content a user has started reading
+func (gm *GormMock) ListContentInProgress(ctx context.Context, userID string, limit int) ([]*gorm.ContentInProgress, error) {
+	return gm.MockListContentInProgressFn(ctx, userID, limit)
+}
+
+// RecordContentShareOpen mocks the implementation of recording that a shared link was opened
+func (gm *GormMock) RecordContentShareOpen(ctx context.Context, shareLinkID string, openedByID *string) (*gorm.ContentShareLink, error) {
+	return gm.MockRecordContentShareOpenFn(ctx, shareLinkID, openedByID)
+}
+
+// GetContentCommentByID mocks the implementation of fetching a content comment by its ID
+func (gm *GormMock) GetContentCommentByID(ctx context.Context, commentID string) (*gorm.ContentCommentDetails, error) {
+	return gm.MockGetContentCommentByIDFn(ctx, commentID)
+}
+
+// ListContentComments mocks the implementation of listing the comments on a content item
+func (gm *GormMock) ListContentComments(ctx context.Context, contentID int) ([]*gorm.ContentCommentDetails, error) {
+	return gm.MockListContentCommentsFn(ctx, contentID)
+}
+
+// ListContentCommentsByStatus mocks the implementation of listing the content comments in the supplied statuses
+func (gm *GormMock) ListContentCommentsByStatus(ctx context.Context, statuses []enums.ContentCommentStatus, limit int) ([]*gorm.ContentCommentDetails, error) {
+	return gm.MockListContentCommentsByStatusFn(ctx, statuses, limit)
+}
+
+// CreateContentComment mocks the implementation of creating a content comment
+func (gm *GormMock) CreateContentComment(ctx context.Context, comment *gorm.ContentComment) (*gorm.ContentCommentDetails, error) {
+	return gm.MockCreateContentCommentFn(ctx, comment)
+}
+
+// EditContentComment mocks the implementation of editing a content comment
+func (gm *GormMock) EditContentComment(ctx context.Context, commentID string, body string, status enums.ContentCommentStatus) (*gorm.ContentCommentDetails, error) {
+	return gm.MockEditContentCommentFn(ctx, commentID, body, status)
+}
+
+// DeleteContentComment mocks the implementation of deleting a content comment
+func (gm *GormMock) DeleteContentComment(ctx context.Context, commentID string) (bool, error) {
+	return gm.MockDeleteContentCommentFn(ctx, commentID)
+}
+
+// ModerateContentComment mocks the implementation of moderating a content comment
+func (gm *GormMock) ModerateContentComment(ctx context.Context, commentID string, status enums.ContentCommentStatus, moderatorID string) (*gorm.ContentCommentDetails, error) {
+	return gm.MockModerateContentCommentFn(ctx, commentID, status, moderatorID)
+}
+
+// CreateBulkInviteJob CreateBulkInviteJob mocks the implementation of saving a bulk invite job
+func (gm *GormMock) CreateBulkInviteJob(ctx context.Context, job *gorm.BulkInviteJob) (*gorm.BulkInviteJob, error) {
+	return gm.MockCreateBulkInviteJobFn(ctx, job)
+}
+
+// GetBulkInviteJob GetBulkInviteJob mocks the implementation of getting a bulk invite job
+func (gm *GormMock) GetBulkInviteJob(ctx context.Context, jobID string) (*gorm.BulkInviteJob, error) {
+	return gm.MockGetBulkInviteJobFn(ctx, jobID)
+}
+
+// UpdateBulkInviteJobStatus UpdateBulkInviteJobStatus mocks the implementation of updating the status of a bulk invite job
+func (gm *GormMock) UpdateBulkInviteJobStatus(ctx context.Context, jobID string, status enums.BulkInviteJobStatus) (bool, error) {
+	return gm.MockUpdateBulkInviteJobStatusFn(ctx, jobID, status)
+}
+
+// RecordBulkInviteRowOutcome RecordBulkInviteRowOutcome mocks the implementation of recording the outcome of a bulk invite row
+func (gm *GormMock) RecordBulkInviteRowOutcome(ctx context.Context, jobID string, rowID string, status enums.BulkInviteRowStatus, errorMessage string) (bool, error) {
+	return gm.MockRecordBulkInviteRowOutcomeFn(ctx, jobID, rowID, status, errorMessage)
+}
+
+// FailInterruptedBulkInviteJobs mocks the implementation of failing the bulk invite jobs that were interrupted
+func (gm *GormMock) FailInterruptedBulkInviteJobs(ctx context.Context, staleAfter time.Duration) (int, error) {
+	return gm.MockFailInterruptedBulkInviteJobsFn(ctx, staleAfter)
+}
+
+// AssignFirstSystemAdmin mocks the implementation of assigning the first system admin
+func (gm *GormMock) AssignFirstSystemAdmin(ctx context.Context, userID string) (bool, error) {
+	return gm.MockAssignFirstSystemAdminFn(ctx, userID)
+}
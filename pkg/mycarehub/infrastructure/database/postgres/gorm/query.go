@@ -2,15 +2,20 @@ package gorm
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -18,8 +23,11 @@ import (
 type Query interface {
 	RetrieveFacility(ctx context.Context, id *string, isActive bool) (*Facility, error)
 	RetrieveFacilityByMFLCode(ctx context.Context, MFLCode int, isActive bool) (*Facility, error)
+	// Deprecated: GetFacilities returns every facility. Use ListFacilities, which is paginated.
 	GetFacilities(ctx context.Context) ([]Facility, error)
-	ListFacilities(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, pagination *domain.FacilityPage) (*domain.FacilityPage, error)
+	ListFacilities(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, filterGroups []*domain.FilterGroup, pagination *domain.FacilityPage) (*domain.FacilityPage, error)
+	NearbyFacilities(ctx context.Context, latitude, longitude, radiusKm float64, limit int, filter []*domain.FiltersParam, sort *domain.SortParam) ([]*NearbyFacility, error)
+	ListFacilityServices(ctx context.Context) ([]*FacilityService, error)
 	GetUserProfileByPhoneNumber(ctx context.Context, phoneNumber string) (*User, error)
 	GetUserPINByUserID(ctx context.Context, userID string) (*PINData, error)
 	GetUserProfileByUserID(ctx context.Context, userID string) (*User, error)
@@ -37,17 +45,41 @@ type Query interface {
 	GetContactByUserID(ctx context.Context, userID *string, contactType string) (*Contact, error)
 	ListContentCategories(ctx context.Context) ([]*domain.ContentItemCategory, error)
 	GetUserBookmarkedContent(ctx context.Context, userID string) ([]*ContentItem, error)
-	CanRecordHeathDiary(ctx context.Context, clientID string) (bool, error)
+	ListContentItems(ctx context.Context, categoryID *int, contentIDs []int, limit int) ([]*ContentItemDetails, int64, error)
+	SearchContent(ctx context.Context, input *dto.ContentSearchInput, pagination *domain.Pagination) (*ContentSearchPage, error)
+	ListRecommendedContentItems(ctx context.Context, userID string, limit int) ([]*ContentItemDetails, error)
+	ListContentFeed(ctx context.Context, categoryID *int, sort enums.ContentSortOrder, trendingDays int, after *domain.ContentFeedPosition, before *domain.ContentFeedPosition, limit int) (*ContentFeedPage, error)
+	GetContentAnalytics(ctx context.Context, input *dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error)
+	ListContentInProgress(ctx context.Context, userID string, limit int) ([]*ContentInProgress, error)
+	GetContentCommentByID(ctx context.Context, commentID string) (*ContentCommentDetails, error)
+	ListContentComments(ctx context.Context, contentID int) ([]*ContentCommentDetails, error)
+	ListContentCommentsByStatus(ctx context.Context, statuses []enums.ContentCommentStatus, limit int) ([]*ContentCommentDetails, error)
+	CanRecordHeathDiary(ctx context.Context, clientID string, cadence time.Duration) (bool, error)
 	GetClientHealthDiaryQuote(ctx context.Context) (*ClientHealthDiaryQuote, error)
 	CheckIfUserBookmarkedContent(ctx context.Context, userID string, contentID int) (bool, error)
 	GetClientHealthDiaryEntries(ctx context.Context, clientID string) ([]*ClientHealthDiaryEntry, error)
 	GetFAQContent(ctx context.Context, flavour feedlib.Flavour, limit *int) ([]*FAQ, error)
+	GetLatestUserInvitation(ctx context.Context, userID string, flavour feedlib.Flavour) (*Invitation, error)
+	ListPendingInvitations(ctx context.Context, facilityID string) ([]*Invitation, error)
+	GetStaffProfileByUserID(ctx context.Context, userID string) (*StaffProfile, error)
+	GetStaffProfileByStaffID(ctx context.Context, staffID string) (*StaffProfile, error)
+	GetStaffFacilities(ctx context.Context, staffID string) ([]*Facility, error)
+	GetUserRoles(ctx context.Context, userID string) ([]*UserRole, error)
+	GetClientProfileByClientID(ctx context.Context, clientID string) (*Client, error)
+	GetOrganisation(ctx context.Context, organisationID string) (*Organisation, error)
+	GetFacilityHistory(ctx context.Context, mflCode int) ([]*FacilityHistory, error)
+	ListOrganisations(ctx context.Context) ([]*Organisation, error)
+	GetClientTransferByID(ctx context.Context, transferID string) (*ClientTransfer, error)
+	CheckClientHasPendingTransfer(ctx context.Context, clientID string) (bool, error)
+	ListPendingClientTransfers(ctx context.Context, facilityID string) ([]*ClientTransfer, error)
+	GetClientFacilityHistory(ctx context.Context, clientID string) ([]*ClientFacility, error)
+	GetBulkInviteJob(ctx context.Context, jobID string) (*BulkInviteJob, error)
 }
 
 // CheckWhetherUserHasLikedContent performs a operation to check whether user has liked the content
 func (db *PGInstance) CheckWhetherUserHasLikedContent(ctx context.Context, userID string, contentID int) (bool, error) {
 	var contentItemLike ContentLike
-	if err := db.DB.Where(&ContentLike{UserID: userID, ContentID: contentID}).First(&contentItemLike).Error; err != nil {
+	if err := db.DB.WithContext(ctx).Where(&ContentLike{UserID: userID, ContentID: contentID}).First(&contentItemLike).Error; err != nil {
 		if strings.Contains(err.Error(), "record not found") {
 			return false, nil
 		}
@@ -62,7 +94,7 @@ func (db *PGInstance) CheckWhetherUserHasLikedContent(ctx context.Context, userI
 func (db *PGInstance) ListContentCategories(ctx context.Context) ([]*domain.ContentItemCategory, error) {
 	var contentItemCategories []*ContentItemCategory
 
-	err := db.DB.Find(&contentItemCategories).Error
+	err := db.DB.WithContext(ctx).Find(&contentItemCategories).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to query all content categories %v", err)
 	}
@@ -70,7 +102,7 @@ func (db *PGInstance) ListContentCategories(ctx context.Context) ([]*domain.Cont
 	var domainContentItemCategory []*domain.ContentItemCategory
 	for _, contentCategory := range contentItemCategories {
 		var wagtailImage *WagtailImages
-		err := db.DB.Model(&WagtailImages{}).Where(&WagtailImages{ID: contentCategory.IconID}).Find(&wagtailImage).Error
+		err := db.DB.WithContext(ctx).Model(&WagtailImages{}).Where(&WagtailImages{ID: contentCategory.IconID}).Find(&wagtailImage).Error
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch wagtail images %v", err)
 		}
@@ -92,7 +124,7 @@ func (db *PGInstance) RetrieveFacility(ctx context.Context, id *string, isActive
 		return nil, fmt.Errorf("facility id cannot be nil")
 	}
 	var facility Facility
-	err := db.DB.Where(&Facility{FacilityID: id, Active: isActive}).First(&facility).Error
+	err := db.DB.WithContext(ctx).Scopes(withFacilityDetails(time.Now())).Where(&Facility{FacilityID: id, Active: isActive}).First(&facility).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get facility by ID %v: %v", id, err)
 	}
@@ -105,7 +137,7 @@ func (db *PGInstance) CheckIfPhoneNumberExists(ctx context.Context, phone string
 	if phone == "" || !flavour.IsValid() {
 		return false, fmt.Errorf("invalid flavour: %v", flavour)
 	}
-	err := db.DB.Model(&Contact{}).Where(&Contact{ContactValue: phone, OptedIn: isOptedIn, Flavour: flavour}).First(&contact).Error
+	err := db.DB.WithContext(ctx).Model(&Contact{}).Where(&Contact{ContactValue: phone, OptedIn: isOptedIn, Flavour: flavour}).First(&contact).Error
 	if err != nil {
 		if strings.Contains(err.Error(), "record not found") {
 			return false, nil
@@ -121,16 +153,19 @@ func (db *PGInstance) RetrieveFacilityByMFLCode(ctx context.Context, MFLCode int
 		return nil, fmt.Errorf("facility mfl code cannot be nil")
 	}
 	var facility Facility
-	if err := db.DB.Where(&Facility{Code: MFLCode, Active: isActive}).First(&facility).Error; err != nil {
+	err := db.DB.WithContext(ctx).Scopes(withFacilityDetails(time.Now())).Where(&Facility{Code: MFLCode, Active: isActive}).First(&facility).Error
+	if err != nil {
 		return nil, fmt.Errorf("failed to get facility by MFL Code %v and status %v: %v", MFLCode, isActive, err)
 	}
 	return &facility, nil
 }
 
 // GetFacilities fetches all the healthcare facilities in the platform.
+//
+// Deprecated: GetFacilities loads every facility at once. Use ListFacilities, which is paginated.
 func (db *PGInstance) GetFacilities(ctx context.Context) ([]Facility, error) {
 	var facility []Facility
-	err := db.DB.Find(&facility).Error
+	err := db.DB.WithContext(ctx).Find(&facility).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to query all facilities %v", err)
 	}
@@ -143,7 +178,7 @@ func (db *PGInstance) GetSecurityQuestions(ctx context.Context, flavour feedlib.
 		return nil, fmt.Errorf("flavour cannot be empty")
 	}
 	var securityQuestion []*SecurityQuestion
-	err := db.DB.Where(&SecurityQuestion{Flavour: flavour}).Find(&securityQuestion).Error
+	err := db.DB.WithContext(ctx).Where(&SecurityQuestion{Flavour: flavour}).Find(&securityQuestion).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to query all security questions %v", err)
 	}
@@ -151,9 +186,13 @@ func (db *PGInstance) GetSecurityQuestions(ctx context.Context, flavour feedlib.
 }
 
 // ListFacilities lists all facilities, the results returned are
-// from search, and provided filters. they are also paginated
+// from search, and provided filters. they are also paginated.
+//
+// The search term is matched against the facility's name, county and description, and with Postgres full-text
+// search against its name and description. Unless a sort is requested, the facilities that match the search best
+// come first. The filter groups are combined with each other and with the filters using AND.
 func (db *PGInstance) ListFacilities(
-	ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
+	ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, filterGroups []*domain.FilterGroup, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
 	var facilities []Facility
 	// this will keep track of the results for pagination
 	// Count query is unreliable for this since it is returning the count for all rows instead of results
@@ -162,16 +201,22 @@ func (db *PGInstance) ListFacilities(
 	facilitiesOutput := []domain.Facility{}
 
 	for _, f := range filter {
-		err := f.Validate()
-		if err != nil {
-			return nil, fmt.Errorf("failed to validate filter %v: %v", f.Value, err)
+		if err := f.ValidateForCategory(enums.FilterSortCategoryTypeFacility); err != nil {
+			return nil, err
 		}
-		err = enums.ValidateFilterSortCategories(enums.FilterSortCategoryTypeFacility, f.DataType)
-		if err != nil {
-			return nil, fmt.Errorf("filter param %v is not available in facilities: %v", f.Value, err)
+	}
+	for _, g := range filterGroups {
+		if err := g.ValidateForCategory(enums.FilterSortCategoryTypeFacility); err != nil {
+			return nil, err
 		}
 	}
 
+	term := ""
+	if searchTerm != nil {
+		term = strings.TrimSpace(*searchTerm)
+	}
+	rankBySearch := term != "" && (pagination.Pagination.Sort == nil || pagination.Pagination.Sort.Field == "")
+
 	paginatedFacilities := domain.FacilityPage{
 		Pagination: domain.Pagination{
 			Limit:        pagination.Pagination.Limit,
@@ -185,9 +230,9 @@ func (db *PGInstance) ListFacilities(
 		Facilities: pagination.Facilities,
 	}
 
-	mappedFilterParams := filterParamsToMap(filter)
+	now := time.Now()
 
-	tx := db.DB.Begin()
+	tx := db.DB.WithContext(ctx).Begin()
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
@@ -198,19 +243,26 @@ func (db *PGInstance) ListFacilities(
 		return nil, fmt.Errorf("failed to initialize filter facilities transaction %v", err)
 	}
 
-	tx.Where(
-		"name ~* ? OR county ~* ? OR description ~* ?",
-		*searchTerm, *searchTerm, *searchTerm,
-	).Where(mappedFilterParams).Find(&facilities).Find(&facilities)
+	if err := tx.Scopes(
+		searchFacilities(term), facilityFilters(filter, now), facilityFilterGroups(filterGroups, now),
+	).Find(&facilities).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to search facilities: %v", err)
+	}
 
 	resultCount = int64(len(facilities))
 
-	tx.Scopes(
+	query := tx.Scopes(searchFacilities(term), facilityFilters(filter, now), facilityFilterGroups(filterGroups, now))
+	if rankBySearch {
+		query = query.Scopes(rankFacilitiesBySearch(term))
+	}
+	if err := query.Scopes(
 		paginate(facilities, &paginatedFacilities.Pagination, resultCount, db.DB),
-	).Where(
-		"name ~* ?  OR county ~* ? OR description ~* ?",
-		*searchTerm, *searchTerm, *searchTerm,
-	).Where(mappedFilterParams).Find(&facilities)
+		withFacilityDetails(now),
+	).Find(&facilities).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to list facilities: %v", err)
+	}
 
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
@@ -225,7 +277,11 @@ func (db *PGInstance) ListFacilities(
 			Active:      f.Active,
 			County:      f.County,
 			Description: f.Description,
+			Latitude:    f.Latitude,
+			Longitude:   f.Longitude,
+			Address:     f.Address,
 		}
+		facility.OpeningHours, facility.HoursExceptions, facility.Services = facilityDetailsToDomain(&f)
 		facilitiesOutput = append(facilitiesOutput, facility)
 	}
 
@@ -243,7 +299,7 @@ func (db *PGInstance) ListFacilities(
 // GetUserProfileByPhoneNumber retrieves a user profile using their phonenumber
 func (db *PGInstance) GetUserProfileByPhoneNumber(ctx context.Context, phoneNumber string) (*User, error) {
 	var user User
-	if err := db.DB.Joins("JOIN common_contact on users_user.id = common_contact.user_id").Where("common_contact.contact_value = ?", phoneNumber).Preload(clause.Associations).First(&user).Error; err != nil {
+	if err := db.DB.WithContext(ctx).Joins("JOIN common_contact on users_user.id = common_contact.user_id").Where("common_contact.contact_value = ?", phoneNumber).Preload(clause.Associations).First(&user).Error; err != nil {
 		return nil, fmt.Errorf("failed to get user by phonenumber %v: %v", phoneNumber, err)
 	}
 	return &user, nil
@@ -252,7 +308,7 @@ func (db *PGInstance) GetUserProfileByPhoneNumber(ctx context.Context, phoneNumb
 // GetUserPINByUserID fetches a user's pin using the user ID
 func (db *PGInstance) GetUserPINByUserID(ctx context.Context, userID string) (*PINData, error) {
 	var pin PINData
-	if err := db.DB.Where(&PINData{UserID: userID, IsValid: true}).First(&pin).Error; err != nil {
+	if err := db.DB.WithContext(ctx).Where(&PINData{UserID: userID, IsValid: true}).First(&pin).Error; err != nil {
 		return nil, fmt.Errorf("failed to get pin: %v", err)
 	}
 	return &pin, nil
@@ -262,7 +318,7 @@ func (db *PGInstance) GetUserPINByUserID(ctx context.Context, userID string) (*P
 func (db *PGInstance) GetCurrentTerms(ctx context.Context) (*TermsOfService, error) {
 	var termsOfService TermsOfService
 	validTo := time.Now()
-	if err := db.DB.Model(&TermsOfService{}).Where("valid_to > ?", validTo).Or("valid_to = ?", nil).Order("valid_to desc").First(&termsOfService).Error; err != nil {
+	if err := db.DB.WithContext(ctx).Model(&TermsOfService{}).Where("valid_to > ?", validTo).Or("valid_to = ?", nil).Order("valid_to desc").First(&termsOfService).Error; err != nil {
 		return nil, fmt.Errorf("failed to get the current terms : %v", err)
 	}
 
@@ -275,7 +331,7 @@ func (db *PGInstance) GetUserProfileByUserID(ctx context.Context, userID string)
 		return nil, fmt.Errorf("userID cannot be empty")
 	}
 	var user User
-	if err := db.DB.Where(&User{UserID: &userID}).First(&user).Error; err != nil {
+	if err := db.DB.WithContext(ctx).Where(&User{UserID: &userID}).First(&user).Error; err != nil {
 		return nil, fmt.Errorf("failed to get user by user ID %v: %v", userID, err)
 	}
 	return &user, nil
@@ -284,7 +340,7 @@ func (db *PGInstance) GetUserProfileByUserID(ctx context.Context, userID string)
 // GetSecurityQuestionByID fetches a security question using the security question ID
 func (db *PGInstance) GetSecurityQuestionByID(ctx context.Context, securityQuestionID *string) (*SecurityQuestion, error) {
 	var securityQuestion SecurityQuestion
-	if err := db.DB.Where(&SecurityQuestion{SecurityQuestionID: securityQuestionID}).First(&securityQuestion).Error; err != nil {
+	if err := db.DB.WithContext(ctx).Where(&SecurityQuestion{SecurityQuestionID: securityQuestionID}).First(&securityQuestion).Error; err != nil {
 		return nil, fmt.Errorf("failed to get security question by ID %v: %v", securityQuestionID, err)
 	}
 	return &securityQuestion, nil
@@ -293,7 +349,7 @@ func (db *PGInstance) GetSecurityQuestionByID(ctx context.Context, securityQuest
 // GetSecurityQuestionResponseByID returns the security question response
 func (db *PGInstance) GetSecurityQuestionResponseByID(ctx context.Context, questionID string) (*SecurityQuestionResponse, error) {
 	var questionResponse SecurityQuestionResponse
-	if err := db.DB.Where(&SecurityQuestionResponse{QuestionID: questionID}).First(&questionResponse).Error; err != nil {
+	if err := db.DB.WithContext(ctx).Where(&SecurityQuestionResponse{QuestionID: questionID}).First(&questionResponse).Error; err != nil {
 		return nil, fmt.Errorf("failed to get the security question response by ID")
 	}
 	return &questionResponse, nil
@@ -309,7 +365,7 @@ func (db *PGInstance) VerifyOTP(ctx context.Context, payload *dto.VerifyOTPInput
 		return false, exceptions.InvalidFlavourDefinedError()
 	}
 
-	err := db.DB.Model(&UserOTP{}).Where(&UserOTP{PhoneNumber: payload.PhoneNumber, Valid: true, OTP: payload.OTP, Flavour: payload.Flavour}).First(&userOTP).Error
+	err := db.DB.WithContext(ctx).Model(&UserOTP{}).Where(&UserOTP{PhoneNumber: payload.PhoneNumber, Valid: true, OTP: payload.OTP, Flavour: payload.Flavour}).First(&userOTP).Error
 	if err != nil {
 		if strings.Contains(err.Error(), "record not found") {
 			return false, nil
@@ -323,7 +379,7 @@ func (db *PGInstance) VerifyOTP(ctx context.Context, payload *dto.VerifyOTPInput
 // GetClientProfileByUserID returns the client profile based on the user ID provided
 func (db *PGInstance) GetClientProfileByUserID(ctx context.Context, userID string) (*Client, error) {
 	var client Client
-	if err := db.DB.Where(&Client{UserID: &userID}).Preload(clause.Associations).First(&client).Error; err != nil {
+	if err := db.DB.WithContext(ctx).Where(&Client{UserID: &userID}).Preload(clause.Associations).First(&client).Error; err != nil {
 		return nil, fmt.Errorf("failed to get client by user ID %v: %v", userID, err)
 	}
 	return &client, nil
@@ -332,7 +388,7 @@ func (db *PGInstance) GetClientProfileByUserID(ctx context.Context, userID strin
 // CheckUserHasPin performs a look up on the pins table to check whether a user has a pin
 func (db *PGInstance) CheckUserHasPin(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error) {
 	var pin PINData
-	if err := db.DB.Where(&PINData{UserID: userID, Flavour: flavour}).Find(&pin).Error; err != nil {
+	if err := db.DB.WithContext(ctx).Where(&PINData{UserID: userID, Flavour: flavour}).Find(&pin).Error; err != nil {
 		return false, err
 	}
 	return true, nil
@@ -341,7 +397,7 @@ func (db *PGInstance) CheckUserHasPin(ctx context.Context, userID string, flavou
 // GetOTP fetches an OTP from the database
 func (db *PGInstance) GetOTP(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (*UserOTP, error) {
 	var userOTP UserOTP
-	if err := db.DB.Where(&UserOTP{PhoneNumber: phoneNumber, Flavour: flavour}).First(&userOTP).Error; err != nil {
+	if err := db.DB.WithContext(ctx).Where(&UserOTP{PhoneNumber: phoneNumber, Flavour: flavour}).First(&userOTP).Error; err != nil {
 		return nil, fmt.Errorf("failed to get otp: %v", err)
 	}
 	return &userOTP, nil
@@ -350,7 +406,7 @@ func (db *PGInstance) GetOTP(ctx context.Context, phoneNumber string, flavour fe
 // GetUserSecurityQuestionsResponses fetches the security question responses that the user has responded to
 func (db *PGInstance) GetUserSecurityQuestionsResponses(ctx context.Context, userID string) ([]*SecurityQuestionResponse, error) {
 	var securityQuestionResponses []*SecurityQuestionResponse
-	if err := db.DB.Where(&SecurityQuestionResponse{UserID: userID, Active: true}).Find(&securityQuestionResponses).Error; err != nil {
+	if err := db.DB.WithContext(ctx).Where(&SecurityQuestionResponse{UserID: userID, Active: true}).Find(&securityQuestionResponses).Error; err != nil {
 		return nil, fmt.Errorf("failed to get security questions: %v", err)
 	}
 	return securityQuestionResponses, nil
@@ -370,7 +426,7 @@ func (db *PGInstance) GetContactByUserID(ctx context.Context, userID *string, co
 	if contactType != "PHONE" && contactType != "EMAIL" {
 		return nil, fmt.Errorf("contact type must be PHONE or EMAIL")
 	}
-	if err := db.DB.Where(&Contact{UserID: userID, ContactType: contactType}).First(&contact).Error; err != nil {
+	if err := db.DB.WithContext(ctx).Where(&Contact{UserID: userID, ContactType: contactType}).First(&contact).Error; err != nil {
 		return nil, fmt.Errorf("failed to get contact: %v", err)
 	}
 	return &contact, nil
@@ -379,7 +435,7 @@ func (db *PGInstance) GetContactByUserID(ctx context.Context, userID *string, co
 // GetUserBookmarkedContent retrieves a user's pinned content from the database
 func (db *PGInstance) GetUserBookmarkedContent(ctx context.Context, userID string) ([]*ContentItem, error) {
 	var contentItem []*ContentItem
-	err := db.DB.Joins("JOIN content_contentbookmark ON content_contentitem.page_ptr_id = content_contentbookmark.content_item_id").
+	err := db.DB.WithContext(ctx).Joins("JOIN content_contentbookmark ON content_contentitem.page_ptr_id = content_contentbookmark.content_item_id").
 		Where("content_contentbookmark.user_id = ?", userID).Preload(clause.Associations).Find(&contentItem).Error
 	if err != nil {
 		return nil, err
@@ -388,16 +444,16 @@ func (db *PGInstance) GetUserBookmarkedContent(ctx context.Context, userID strin
 }
 
 // CanRecordHeathDiary checks whether a user can record a health diary
-// if the last record is less than 24 hours ago, the user cannot record a new entry
-// if the last record is more than 24 hours ago, the user can record a new entry
-func (db *PGInstance) CanRecordHeathDiary(ctx context.Context, clientID string) (bool, error) {
+// if the last record is more recent than the organisation's diary cadence, the user cannot record a new entry
+// otherwise the user can record a new entry
+func (db *PGInstance) CanRecordHeathDiary(ctx context.Context, clientID string, cadence time.Duration) (bool, error) {
 	var clientHealthDiaryEntry []*ClientHealthDiaryEntry
-	err := db.DB.Where("client_id = ?", clientID).Order("created desc").Find(&clientHealthDiaryEntry).Error
+	err := db.DB.WithContext(ctx).Where("client_id = ?", clientID).Order("created desc").Find(&clientHealthDiaryEntry).Error
 	if err != nil {
 		return false, fmt.Errorf("failed to get client health diary: %v", err)
 	}
 	if len(clientHealthDiaryEntry) > 0 {
-		if time.Since(clientHealthDiaryEntry[0].CreatedAt) < time.Hour*24 {
+		if time.Since(clientHealthDiaryEntry[0].CreatedAt) < cadence {
 			return false, nil
 		}
 	}
@@ -409,7 +465,7 @@ func (db *PGInstance) CanRecordHeathDiary(ctx context.Context, clientID string)
 // it should be a random quote from the health diary
 func (db *PGInstance) GetClientHealthDiaryQuote(ctx context.Context) (*ClientHealthDiaryQuote, error) {
 	var healthDiaryQuote ClientHealthDiaryQuote
-	err := db.DB.Where("active = true").Order("RANDOM()").First(&healthDiaryQuote).Error
+	err := db.DB.WithContext(ctx).Where("active = true").Order("RANDOM()").First(&healthDiaryQuote).Error
 	if err != nil {
 		return nil, err
 	}
@@ -419,7 +475,7 @@ func (db *PGInstance) GetClientHealthDiaryQuote(ctx context.Context) (*ClientHea
 // CheckIfUserBookmarkedContent fetches a user's pinned content from the database
 func (db *PGInstance) CheckIfUserBookmarkedContent(ctx context.Context, userID string, contentID int) (bool, error) {
 	var contentBookmark ContentBookmark
-	err := db.DB.Where(&ContentBookmark{ContentID: contentID, UserID: userID}).First(&contentBookmark).Error
+	err := db.DB.WithContext(ctx).Where(&ContentBookmark{ContentID: contentID, UserID: userID}).First(&contentBookmark).Error
 	if err != nil {
 		if strings.Contains(err.Error(), "record not found") {
 			return false, nil
@@ -432,7 +488,7 @@ func (db *PGInstance) CheckIfUserBookmarkedContent(ctx context.Context, userID s
 // GetClientHealthDiaryEntries gets all health diary entries that belong to a specific client
 func (db *PGInstance) GetClientHealthDiaryEntries(ctx context.Context, clientID string) ([]*ClientHealthDiaryEntry, error) {
 	var healthDiaryEntry []*ClientHealthDiaryEntry
-	err := db.DB.Where(&ClientHealthDiaryEntry{ClientID: clientID, Active: true}).
+	err := db.DB.WithContext(ctx).Where(&ClientHealthDiaryEntry{ClientID: clientID, Active: true}).
 		Order(clause.OrderByColumn{Column: clause.Column{Name: "created"}, Desc: true}).Find(&healthDiaryEntry).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all client health diary entries: %v", err)
@@ -444,10 +500,1261 @@ func (db *PGInstance) GetClientHealthDiaryEntries(ctx context.Context, clientID
 // when the limit is not provided, it defaults to 10
 func (db *PGInstance) GetFAQContent(ctx context.Context, flavour feedlib.Flavour, limit *int) ([]*FAQ, error) {
 	var faq []*FAQ
-	err := db.DB.Where(&FAQ{Flavour: flavour, Active: true}).
+	err := db.DB.WithContext(ctx).Where(&FAQ{Flavour: flavour, Active: true}).
 		Order(clause.OrderByColumn{Column: clause.Column{Name: "created"}, Desc: true}).Limit(*limit).Find(&faq).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get FAQ content: %v", err)
 	}
 	return faq, nil
 }
+
+// GetLatestUserInvitation fetches the most recent invite sent to a user
+func (db *PGInstance) GetLatestUserInvitation(ctx context.Context, userID string, flavour feedlib.Flavour) (*Invitation, error) {
+	var invitation Invitation
+	err := db.DB.WithContext(ctx).Where(&Invitation{UserID: userID, Flavour: flavour}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "issued_at"}, Desc: true}).First(&invitation).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest user invitation: %w", err)
+	}
+	return &invitation, nil
+}
+
+// ListPendingInvitations fetches the invites that are yet to be accepted by the clients of a facility. Invites
+// that are past their expiry time are left out even when they have not been marked as expired yet.
+func (db *PGInstance) ListPendingInvitations(ctx context.Context, facilityID string) ([]*Invitation, error) {
+	var invitations []*Invitation
+	err := db.DB.WithContext(ctx).Where("status IN ?", enums.PendingInvitationStatuses).Where("expires_at > ?", time.Now()).
+		Where("user_id IN (?)", db.DB.WithContext(ctx).Model(&Client{}).Select("user_id").Where(&Client{FacilityID: facilityID})).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "issued_at"}, Desc: true}).Find(&invitations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending invitations: %v", err)
+	}
+	return invitations, nil
+}
+
+// GetStaffProfileByUserID fetches the staff profile of a user together with their user details
+func (db *PGInstance) GetStaffProfileByUserID(ctx context.Context, userID string) (*StaffProfile, error) {
+	var staff StaffProfile
+	if err := db.DB.WithContext(ctx).Where(&StaffProfile{UserID: &userID}).Preload(clause.Associations).First(&staff).Error; err != nil {
+		return nil, fmt.Errorf("failed to get staff by user ID %v: %v", userID, err)
+	}
+	return &staff, nil
+}
+
+// GetStaffProfileByStaffID fetches a staff profile using its ID
+func (db *PGInstance) GetStaffProfileByStaffID(ctx context.Context, staffID string) (*StaffProfile, error) {
+	var staff StaffProfile
+	if err := db.DB.WithContext(ctx).Where(&StaffProfile{ID: &staffID}).Preload(clause.Associations).First(&staff).Error; err != nil {
+		return nil, fmt.Errorf("failed to get staff by ID %v: %v", staffID, err)
+	}
+	return &staff, nil
+}
+
+// GetStaffFacilities fetches all the facilities that a staff member is allowed to access
+func (db *PGInstance) GetStaffFacilities(ctx context.Context, staffID string) ([]*Facility, error) {
+	var facilities []*Facility
+	err := db.DB.WithContext(ctx).Where("id IN (?)", db.DB.WithContext(ctx).Model(&StaffFacilities{}).Select("facility_id").Where(&StaffFacilities{StaffID: &staffID})).
+		Order("name").Find(&facilities).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staff facilities: %v", err)
+	}
+	return facilities, nil
+}
+
+// GetUserRoles fetches all the roles assigned to a user
+func (db *PGInstance) GetUserRoles(ctx context.Context, userID string) ([]*UserRole, error) {
+	var roles []*UserRole
+	if err := db.DB.WithContext(ctx).Where(&UserRole{UserID: userID}).Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %v", err)
+	}
+	return roles, nil
+}
+
+// GetClientProfileByClientID fetches a client profile using its ID
+func (db *PGInstance) GetClientProfileByClientID(ctx context.Context, clientID string) (*Client, error) {
+	var client Client
+	if err := db.DB.WithContext(ctx).Where(&Client{ID: &clientID}).Preload(clause.Associations).First(&client).Error; err != nil {
+		return nil, fmt.Errorf("failed to get client by ID %v: %v", clientID, err)
+	}
+	return &client, nil
+}
+
+// GetOrganisation fetches an organisation using its ID
+func (db *PGInstance) GetOrganisation(ctx context.Context, organisationID string) (*Organisation, error) {
+	var organisation Organisation
+	if err := db.DB.WithContext(ctx).Where(&Organisation{OrganisationID: &organisationID}).First(&organisation).Error; err != nil {
+		return nil, fmt.Errorf("failed to get organisation %v: %v", organisationID, err)
+	}
+	return &organisation, nil
+}
+
+// ListOrganisations fetches all the organisations ordered by name
+func (db *PGInstance) ListOrganisations(ctx context.Context) ([]*Organisation, error) {
+	var organisations []*Organisation
+	if err := db.DB.WithContext(ctx).Order("organisation_name").Find(&organisations).Error; err != nil {
+		return nil, fmt.Errorf("failed to list organisations: %v", err)
+	}
+	return organisations, nil
+}
+
+// GetClientTransferByID fetches a client transfer using its ID
+func (db *PGInstance) GetClientTransferByID(ctx context.Context, transferID string) (*ClientTransfer, error) {
+	var transfer ClientTransfer
+	if err := db.DB.WithContext(ctx).Where(&ClientTransfer{ID: &transferID}).First(&transfer).Error; err != nil {
+		return nil, fmt.Errorf("failed to get client transfer %v: %v", transferID, err)
+	}
+	return &transfer, nil
+}
+
+// CheckClientHasPendingTransfer checks whether a client has a transfer that the receiving facility has not responded to
+func (db *PGInstance) CheckClientHasPendingTransfer(ctx context.Context, clientID string) (bool, error) {
+	var count int64
+	err := db.DB.WithContext(ctx).Model(&ClientTransfer{}).
+		Where(&ClientTransfer{ClientID: clientID, Status: enums.ClientTransferStatusPending}).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check pending transfers of client %v: %v", clientID, err)
+	}
+	return count > 0, nil
+}
+
+// ListPendingClientTransfers fetches the transfers into a facility that are awaiting its response, oldest first
+func (db *PGInstance) ListPendingClientTransfers(ctx context.Context, facilityID string) ([]*ClientTransfer, error) {
+	var transfers []*ClientTransfer
+	err := db.DB.WithContext(ctx).
+		Where(&ClientTransfer{ToFacilityID: facilityID, Status: enums.ClientTransferStatusPending}).
+		Order("requested_at").Find(&transfers).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending transfers into facility %v: %v", facilityID, err)
+	}
+	return transfers, nil
+}
+
+// GetClientFacilityHistory fetches the periods a client was assigned to each facility, most recent first
+func (db *PGInstance) GetClientFacilityHistory(ctx context.Context, clientID string) ([]*ClientFacility, error) {
+	var history []*ClientFacility
+	err := db.DB.WithContext(ctx).Where(&ClientFacility{ClientID: clientID}).
+		Order("start_date desc").Find(&history).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the facility history of client %v: %v", clientID, err)
+	}
+	return history, nil
+}
+
+// ContentItemDetails is a content item together with the wagtail records that are needed to render it
+type ContentItemDetails struct {
+	ContentItem ContentItem
+	Page        WagtailCorePage
+	Author      ContentAuthor
+	HeroImage   *WagtailImages
+	Categories  []*ContentCategoryDetails
+	TagNames    []string
+}
+
+// ContentCategoryDetails is a content item category together with its icon
+type ContentCategoryDetails struct {
+	Category ContentItemCategory
+	Icon     *WagtailImages
+}
+
+// ListContentItems reads published content items straight from the tables shared with the CMS, newest first.
+// The items can be narrowed down to a category or to specific content items. The total count is irrespective of the limit
+func (db *PGInstance) ListContentItems(ctx context.Context, categoryID *int, contentIDs []int, limit int) ([]*ContentItemDetails, int64, error) {
+	tx := db.publishedContentItems(ctx, categoryID)
+	if len(contentIDs) > 0 {
+		tx = tx.Where("content_contentitem.page_ptr_id IN ?", contentIDs)
+	}
+	// the conditions are shared by the count and the listing
+	tx = tx.Session(&gorm.Session{})
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count content items: %v", err)
+	}
+
+	var contentItems []*ContentItem
+	if limit > 0 {
+		tx = tx.Limit(limit)
+	}
+	err := tx.Order("wagtailcore_page.first_published_at DESC NULLS LAST").Order("content_contentitem.page_ptr_id DESC").
+		Find(&contentItems).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list content items: %v", err)
+	}
+
+	details, err := db.contentItemDetails(ctx, contentItems)
+	if err != nil {
+		return nil, 0, err
+	}
+	return details, total, nil
+}
+
+// publishedContentItems is the query for the content items that are live on the CMS, optionally in a category
+func (db *PGInstance) publishedContentItems(ctx context.Context, categoryID *int) *gorm.DB {
+	tx := db.DB.WithContext(ctx).Model(&ContentItem{}).
+		Joins("JOIN wagtailcore_page ON wagtailcore_page.id = content_contentitem.page_ptr_id").
+		Where("wagtailcore_page.live = ? AND wagtailcore_page.expired = ?", true, false)
+	if categoryID != nil {
+		tx = tx.Where("content_contentitem.page_ptr_id IN (?)", db.DB.Model(&ContentContentItemCategories{}).
+			Select("contentitem_id").Where("contentitemcategory_id = ?", *categoryID))
+	}
+	return tx
+}
+
+// recentEngagement counts the views, likes, bookmarks and shares that a content item has received in the logged
+// in user's organisation over the last number of days
+var recentEngagement = `LEFT JOIN LATERAL (
+	SELECT count(*) AS engagement FROM (
+		SELECT created, organisation_id FROM content_contentview WHERE content_item_id = content_contentitem.page_ptr_id AND active
+		UNION ALL SELECT created, organisation_id FROM content_contentlike WHERE content_item_id = content_contentitem.page_ptr_id AND active
+		UNION ALL SELECT created, organisation_id FROM content_contentbookmark WHERE content_item_id = content_contentitem.page_ptr_id AND active
+		UNION ALL SELECT created, organisation_id FROM content_contentshare WHERE content_item_id = content_contentitem.page_ptr_id AND active
+	) AS engagement WHERE engagement.created >= now() - make_interval(days => @days) AND ` + inOrganisation("engagement.organisation_id") + `
+) AS recent_engagement ON true`
+
+// contentFeedSortKeys are what each order of the content feed is sorted by before the publication date and ID
+// that break ties. Newest first feeds are only sorted by the publication date and ID
+var contentFeedSortKeys = map[enums.ContentSortOrder]string{
+	enums.ContentSortOrderNewest:     "",
+	enums.ContentSortOrderMostLiked:  "content_contentitem.like_count",
+	enums.ContentSortOrderMostViewed: "content_contentitem.view_count",
+	enums.ContentSortOrderTrending:   "recent_engagement.engagement",
+}
+
+// contentFeedPublishedAt is when a content item was first published. Live pages always have a publication date;
+// the start of the epoch stands in for a missing one so that the position of every item can be compared
+const contentFeedPublishedAt = "coalesce(wagtailcore_page.first_published_at, to_timestamp(0))"
+
+// ContentFeedPage is a page of the content feed together with the position of each item in the feed
+type ContentFeedPage struct {
+	Items     []*ContentItemDetails
+	Positions []*domain.ContentFeedPosition
+	Total     int64
+
+	// HasMore is true when there is more content past the page in the direction it was read in
+	HasMore bool
+}
+
+// contentFeedRow is the position of a content item in the content feed
+type contentFeedRow struct {
+	ContentID        int       `gorm:"column:content_id"`
+	SortKey          int64     `gorm:"column:sort_key"`
+	FirstPublishedAt time.Time `gorm:"column:first_published_at"`
+}
+
+// ListContentFeed lists a page of the published content in the feed's sort order. The page starts after the
+// given position in the feed, or ends before it, and the first page is listed when there is no position.
+// Trending content is ranked by its engagement over the last trending days. Ties are listed newest first.
+//
+// Pages are read from a position rather than an offset so that content which is published, or which moves up or
+// down the feed, while the feed is being read doesn't cause the items of other pages to be repeated or skipped
+func (db *PGInstance) ListContentFeed(
+	ctx context.Context,
+	categoryID *int,
+	sort enums.ContentSortOrder,
+	trendingDays int,
+	after *domain.ContentFeedPosition,
+	before *domain.ContentFeedPosition,
+	limit int,
+) (*ContentFeedPage, error) {
+	sortKey, ok := contentFeedSortKeys[sort]
+	if !ok {
+		return nil, fmt.Errorf("invalid content sort order: %v", sort)
+	}
+	if after != nil && before != nil {
+		return nil, fmt.Errorf("a page of the content feed is read either after or before a position, not both")
+	}
+
+	// the conditions are shared by the count and the listing
+	tx := db.publishedContentItems(ctx, categoryID).Session(&gorm.Session{})
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count content items: %v", err)
+	}
+
+	if sort == enums.ContentSortOrderTrending {
+		tx = tx.Joins(recentEngagement, sql.Named("days", trendingDays), organisationArg(ctx))
+	}
+
+	columns := []string{contentFeedPublishedAt, "content_contentitem.page_ptr_id"}
+	selectedKey := "0"
+	if sortKey != "" {
+		columns = append([]string{sortKey}, columns...)
+		selectedKey = sortKey
+	}
+	positionOf := func(position *domain.ContentFeedPosition) []interface{} {
+		values := []interface{}{position.FirstPublishedAt, position.ContentID}
+		if sortKey != "" {
+			values = append([]interface{}{position.SortKey}, values...)
+		}
+		return values
+	}
+
+	// the feed is listed in descending order hence the page before a position is read in ascending order
+	key := "(" + strings.Join(columns, ", ") + ")"
+	direction := "DESC"
+	switch {
+	case after != nil:
+		tx = tx.Where(key+" < ?", positionOf(after))
+	case before != nil:
+		tx = tx.Where(key+" > ?", positionOf(before))
+		direction = "ASC"
+	}
+	for _, column := range columns {
+		tx = tx.Order(column + " " + direction)
+	}
+
+	var rows []*contentFeedRow
+	err := tx.Select(
+		"content_contentitem.page_ptr_id AS content_id, " +
+			selectedKey + " AS sort_key, " +
+			contentFeedPublishedAt + " AS first_published_at",
+	).Limit(limit + 1).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content items: %v", err)
+	}
+
+	page := &ContentFeedPage{
+		Items:     []*ContentItemDetails{},
+		Positions: []*domain.ContentFeedPosition{},
+		Total:     total,
+		HasMore:   len(rows) > limit,
+	}
+	if page.HasMore {
+		rows = rows[:limit]
+	}
+	if before != nil {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	if len(rows) == 0 {
+		return page, nil
+	}
+
+	contentIDs := []int{}
+	for _, row := range rows {
+		contentIDs = append(contentIDs, row.ContentID)
+	}
+	detailsByID, err := db.contentItemDetailsByID(ctx, contentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		item, ok := detailsByID[row.ContentID]
+		if !ok {
+			continue
+		}
+		page.Items = append(page.Items, item)
+		page.Positions = append(page.Positions, &domain.ContentFeedPosition{
+			SortKey:          row.SortKey,
+			FirstPublishedAt: row.FirstPublishedAt,
+			ContentID:        row.ContentID,
+		})
+	}
+	return page, nil
+}
+
+// contentItemDetails loads the pages, authors, images, categories and tags of the content items
+func (db *PGInstance) contentItemDetails(ctx context.Context, contentItems []*ContentItem) ([]*ContentItemDetails, error) {
+	if len(contentItems) == 0 {
+		return []*ContentItemDetails{}, nil
+	}
+
+	pageIDs := []int{}
+	authorIDs := []string{}
+	imageIDs := []int{}
+	for _, contentItem := range contentItems {
+		pageIDs = append(pageIDs, contentItem.PagePtrID)
+		authorIDs = append(authorIDs, contentItem.AuthorID)
+		if contentItem.HeroImageID != nil {
+			imageID, err := strconv.Atoi(*contentItem.HeroImageID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid hero image ID %v: %v", *contentItem.HeroImageID, err)
+			}
+			imageIDs = append(imageIDs, imageID)
+		}
+	}
+
+	var pages []*WagtailCorePage
+	if err := db.DB.WithContext(ctx).Where("id IN ?", pageIDs).Find(&pages).Error; err != nil {
+		return nil, fmt.Errorf("failed to get content pages: %v", err)
+	}
+	pagesByID := map[int]*WagtailCorePage{}
+	for _, page := range pages {
+		pagesByID[page.WagtailCorePageID] = page
+	}
+
+	var authors []*ContentAuthor
+	if err := db.DB.WithContext(ctx).Where("id IN ?", authorIDs).Find(&authors).Error; err != nil {
+		return nil, fmt.Errorf("failed to get content authors: %v", err)
+	}
+	authorsByID := map[string]*ContentAuthor{}
+	for _, author := range authors {
+		authorsByID[*author.ContentAuthorID] = author
+	}
+
+	var itemCategories []*ContentContentItemCategories
+	if err := db.DB.WithContext(ctx).Where("contentitem_id IN ?", pageIDs).Find(&itemCategories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get content item categories: %v", err)
+	}
+	categoryIDs := []int{}
+	for _, itemCategory := range itemCategories {
+		categoryIDs = append(categoryIDs, itemCategory.ContentItemCategoryID)
+	}
+	categoriesByID := map[int]*ContentItemCategory{}
+	if len(categoryIDs) > 0 {
+		var categories []*ContentItemCategory
+		if err := db.DB.WithContext(ctx).Where("id IN ?", categoryIDs).Find(&categories).Error; err != nil {
+			return nil, fmt.Errorf("failed to get content categories: %v", err)
+		}
+		for _, category := range categories {
+			categoriesByID[category.ID] = category
+			imageIDs = append(imageIDs, category.IconID)
+		}
+	}
+
+	imagesByID := map[int]*WagtailImages{}
+	if len(imageIDs) > 0 {
+		var images []*WagtailImages
+		if err := db.DB.WithContext(ctx).Where("id IN ?", imageIDs).Find(&images).Error; err != nil {
+			return nil, fmt.Errorf("failed to get content images: %v", err)
+		}
+		for _, image := range images {
+			imagesByID[image.ID] = image
+		}
+	}
+
+	var itemTags []*ContentItemTag
+	if err := db.DB.WithContext(ctx).Where("content_object_id IN ?", pageIDs).Find(&itemTags).Error; err != nil {
+		return nil, fmt.Errorf("failed to get content item tags: %v", err)
+	}
+	tagIDs := []int{}
+	for _, itemTag := range itemTags {
+		tagIDs = append(tagIDs, itemTag.TagID)
+	}
+	tagsByID := map[int]*Tag{}
+	if len(tagIDs) > 0 {
+		var tags []*Tag
+		if err := db.DB.WithContext(ctx).Where("id IN ?", tagIDs).Find(&tags).Error; err != nil {
+			return nil, fmt.Errorf("failed to get content tags: %v", err)
+		}
+		for _, tag := range tags {
+			tagsByID[tag.ID] = tag
+		}
+	}
+	tagsByItem := map[int][]string{}
+	for _, itemTag := range itemTags {
+		if tag, ok := tagsByID[itemTag.TagID]; ok {
+			tagsByItem[itemTag.ContentItemID] = append(tagsByItem[itemTag.ContentItemID], tag.Name)
+		}
+	}
+
+	categoriesByItem := map[int][]*ContentCategoryDetails{}
+	for _, itemCategory := range itemCategories {
+		category, ok := categoriesByID[itemCategory.ContentItemCategoryID]
+		if !ok || itemCategory.ContentItemID == nil {
+			continue
+		}
+		categoriesByItem[*itemCategory.ContentItemID] = append(categoriesByItem[*itemCategory.ContentItemID], &ContentCategoryDetails{
+			Category: *category,
+			Icon:     imagesByID[category.IconID],
+		})
+	}
+
+	details := []*ContentItemDetails{}
+	for _, contentItem := range contentItems {
+		page, ok := pagesByID[contentItem.PagePtrID]
+		if !ok {
+			continue
+		}
+		item := &ContentItemDetails{
+			ContentItem: *contentItem,
+			Page:        *page,
+			Categories:  categoriesByItem[contentItem.PagePtrID],
+			TagNames:    tagsByItem[contentItem.PagePtrID],
+		}
+		if author, ok := authorsByID[contentItem.AuthorID]; ok {
+			item.Author = *author
+		}
+		if contentItem.HeroImageID != nil {
+			// the hero image ID has already been validated above
+			imageID, _ := strconv.Atoi(*contentItem.HeroImageID)
+			item.HeroImage = imagesByID[imageID]
+		}
+		details = append(details, item)
+	}
+
+	return details, nil
+}
+
+// ContentSearchPage is a page of the content items that match a search, best match first
+type ContentSearchPage struct {
+	Pagination domain.Pagination
+	Results    []*ContentSearchHit
+}
+
+// ContentSearchHit is a content item that matches a search together with its rank and highlighted snippet
+type ContentSearchHit struct {
+	Details *ContentItemDetails
+	Rank    float64
+	Snippet string
+}
+
+// contentSearchMatch is a row of the content search query
+type contentSearchMatch struct {
+	ContentID int     `gorm:"column:content_id"`
+	Rank      float64 `gorm:"column:rank"`
+	Snippet   string  `gorm:"column:snippet"`
+}
+
+// textSearchConfigs are the Postgres text search configurations used for each language. Postgres does not ship
+// with a Swahili stemmer and the schema owned by the CMS does not install one, so Swahili content is matched on
+// whole words without stemming them
+var textSearchConfigs = map[enumutils.Language]string{
+	enumutils.LanguageEn: "'english'::regconfig",
+	enumutils.LanguageSw: "'simple'::regconfig",
+}
+
+// contentTags is a lateral join that collects the names of a content item's tags
+const contentTags = `LEFT JOIN LATERAL (
+	SELECT string_agg(taggit_tag.name, ' ') AS names FROM content_contentitemtag
+	JOIN taggit_tag ON taggit_tag.id = content_contentitemtag.tag_id
+	WHERE content_contentitemtag.content_object_id = content_contentitem.page_ptr_id
+) AS content_tags ON true`
+
+// contentPlainText is a content item's intro and body with the HTML tags of the body removed
+const contentPlainText = "coalesce(content_contentitem.intro, '') || ' ' || " +
+	"regexp_replace(coalesce(content_contentitem.body, ''), '<[^>]*>', ' ', 'g')"
+
+// contentSearchDocument is the text that content is searched and ranked against. Matches in the title count
+// the most, followed by the tags, the intro and then the body
+func contentSearchDocument(config string) string {
+	return fmt.Sprintf(
+		"setweight(to_tsvector(%[1]s, coalesce(wagtailcore_page.title, '')), 'A') || "+
+			"setweight(to_tsvector(%[1]s, coalesce(content_tags.names, '')), 'B') || "+
+			"setweight(to_tsvector(%[1]s, coalesce(content_contentitem.intro, '')), 'C') || "+
+			"setweight(to_tsvector(%[1]s, regexp_replace(coalesce(content_contentitem.body, ''), '<[^>]*>', ' ', 'g')), 'D')",
+		config,
+	)
+}
+
+// SearchContent searches published content with Postgres full-text search over the title, intro, body and tag names.
+// The query can use web search syntax e.g "quoted phrases", OR and -excluded words. The results are ranked with the
+// best match first and come with a snippet of the matching text.
+//
+// The content tables belong to the CMS and have no full-text index, so every search computes the search document
+// of each published content item. An index on the document has to be added by the CMS's migrations.
+func (db *PGInstance) SearchContent(ctx context.Context, input *dto.ContentSearchInput, pagination *domain.Pagination) (*ContentSearchPage, error) {
+	config, ok := textSearchConfigs[input.Language]
+	if !ok {
+		return nil, fmt.Errorf("content cannot be searched in %v", input.Language)
+	}
+	document := contentSearchDocument(config)
+	query := "websearch_to_tsquery(" + config + ", ?)"
+
+	tx := db.DB.WithContext(ctx).Table("content_contentitem").
+		Joins("JOIN wagtailcore_page ON wagtailcore_page.id = content_contentitem.page_ptr_id").
+		Joins(contentTags).
+		Where("wagtailcore_page.live = ? AND wagtailcore_page.expired = ?", true, false).
+		Where(document+" @@ "+query, input.Query)
+	if len(input.CategoryIDs) > 0 {
+		tx = tx.Where("content_contentitem.page_ptr_id IN (?)", db.DB.Model(&ContentContentItemCategories{}).
+			Select("contentitem_id").Where("contentitemcategory_id IN ?", input.CategoryIDs))
+	}
+	if input.ItemType != "" {
+		tx = tx.Where("content_contentitem.item_type = ?", input.ItemType)
+	}
+	// the conditions are shared by the count and the search
+	tx = tx.Session(&gorm.Session{})
+
+	var count int64
+	if err := tx.Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("failed to count content matching the search: %v", err)
+	}
+
+	page := &ContentSearchPage{
+		Pagination: domain.Pagination{
+			Limit:       pagination.Limit,
+			CurrentPage: pagination.CurrentPage,
+		},
+		Results: []*ContentSearchHit{},
+	}
+	setPageDetails(&page.Pagination, count)
+
+	var matches []*contentSearchMatch
+	err := tx.Select(
+		"content_contentitem.page_ptr_id AS content_id, "+
+			"ts_rank_cd("+document+", "+query+") AS rank, "+
+			"ts_headline("+config+", "+contentPlainText+", "+query+", "+
+			"'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10') AS snippet",
+		input.Query, input.Query,
+	).Order("rank DESC").Order("wagtailcore_page.first_published_at DESC NULLS LAST").Order("content_contentitem.page_ptr_id DESC").
+		Offset(page.Pagination.GetOffset()).Limit(page.Pagination.GetLimit()).Scan(&matches).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to search content: %v", err)
+	}
+	if len(matches) == 0 {
+		return page, nil
+	}
+
+	contentIDs := []int{}
+	for _, match := range matches {
+		contentIDs = append(contentIDs, match.ContentID)
+	}
+	detailsByID, err := db.contentItemDetailsByID(ctx, contentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, match := range matches {
+		item, ok := detailsByID[match.ContentID]
+		if !ok {
+			continue
+		}
+		page.Results = append(page.Results, &ContentSearchHit{
+			Details: item,
+			Rank:    match.Rank,
+			Snippet: match.Snippet,
+		})
+	}
+
+	return page, nil
+}
+
+// contentItemDetailsByID loads the content items with the given IDs together with their details, keyed by ID
+func (db *PGInstance) contentItemDetailsByID(ctx context.Context, contentIDs []int) (map[int]*ContentItemDetails, error) {
+	var contentItems []*ContentItem
+	if err := db.DB.WithContext(ctx).Where("page_ptr_id IN ?", contentIDs).Find(&contentItems).Error; err != nil {
+		return nil, fmt.Errorf("failed to get content items: %v", err)
+	}
+	details, err := db.contentItemDetails(ctx, contentItems)
+	if err != nil {
+		return nil, err
+	}
+
+	detailsByID := map[int]*ContentItemDetails{}
+	for _, item := range details {
+		detailsByID[item.ContentItem.PagePtrID] = item
+	}
+	return detailsByID, nil
+}
+
+// contentRecommendations scores published content that the user has not viewed. An item's score adds up the share
+// of the user's engagement that went to the item's categories, the share that went to the item's tags and the share
+// of clients of the same client type as the user who engaged with the item. Engagement is weighted by how much interest it shows, from a view to a share. A user without any engagement
+// gets the content that is popular with similar clients, or the newest content when there is none. Only the clients
+// and engagement of the logged in user's organisation are considered.
+var contentRecommendations = `
+WITH engagement AS (
+	SELECT content_item_id, 1 AS weight FROM content_contentview WHERE user_id = @user AND active
+	UNION ALL SELECT content_item_id, 3 FROM content_contentlike WHERE user_id = @user AND active
+	UNION ALL SELECT content_item_id, 4 FROM content_contentbookmark WHERE user_id = @user AND active
+	UNION ALL SELECT content_item_id, 5 FROM content_contentshare WHERE user_id = @user AND active
+),
+total_engagement AS (
+	SELECT greatest(coalesce(sum(weight), 0), 1)::float AS weight FROM engagement
+),
+category_affinity AS (
+	SELECT categories.contentitemcategory_id AS category_id, sum(engagement.weight) / (SELECT weight FROM total_engagement) AS affinity
+	FROM engagement JOIN content_contentitem_categories AS categories ON categories.contentitem_id = engagement.content_item_id
+	GROUP BY categories.contentitemcategory_id
+),
+tag_affinity AS (
+	SELECT tags.tag_id, sum(engagement.weight) / (SELECT weight FROM total_engagement) AS affinity
+	FROM engagement JOIN content_contentitemtag AS tags ON tags.content_object_id = engagement.content_item_id
+	GROUP BY tags.tag_id
+),
+peers AS (
+	SELECT DISTINCT peer.user_id FROM clients_client AS peer
+	JOIN clients_client AS client ON client.client_type = peer.client_type
+	WHERE client.user_id = @user AND peer.user_id <> @user AND peer.active
+	AND ` + inOrganisation("peer.organisation_id") + `
+),
+peer_popularity AS (
+	SELECT content_item_id, count(DISTINCT user_id)::float / greatest((SELECT count(*) FROM peers), 1) AS popularity
+	FROM (
+		SELECT content_item_id, user_id, organisation_id FROM content_contentview WHERE active
+		UNION ALL SELECT content_item_id, user_id, organisation_id FROM content_contentlike WHERE active
+		UNION ALL SELECT content_item_id, user_id, organisation_id FROM content_contentbookmark WHERE active
+		UNION ALL SELECT content_item_id, user_id, organisation_id FROM content_contentshare WHERE active
+	) AS peer_engagement
+	WHERE user_id IN (SELECT user_id FROM peers) AND ` + inOrganisation("peer_engagement.organisation_id") + `
+	GROUP BY content_item_id
+)
+SELECT content_id, score FROM (
+	SELECT
+		content_contentitem.page_ptr_id AS content_id,
+		wagtailcore_page.first_published_at,
+		coalesce((
+			SELECT sum(category_affinity.affinity) FROM category_affinity
+			JOIN content_contentitem_categories AS categories ON categories.contentitemcategory_id = category_affinity.category_id
+			WHERE categories.contentitem_id = content_contentitem.page_ptr_id
+		), 0) + coalesce((
+			SELECT sum(tag_affinity.affinity) FROM tag_affinity
+			JOIN content_contentitemtag AS tags ON tags.tag_id = tag_affinity.tag_id
+			WHERE tags.content_object_id = content_contentitem.page_ptr_id
+		), 0) + coalesce(peer_popularity.popularity, 0) AS score
+	FROM content_contentitem
+	JOIN wagtailcore_page ON wagtailcore_page.id = content_contentitem.page_ptr_id
+	LEFT JOIN peer_popularity ON peer_popularity.content_item_id = content_contentitem.page_ptr_id
+	WHERE wagtailcore_page.live AND NOT wagtailcore_page.expired
+	AND NOT EXISTS (
+		SELECT 1 FROM content_contentview
+		WHERE content_contentview.content_item_id = content_contentitem.page_ptr_id AND content_contentview.user_id = @user
+	)
+) AS recommendations
+ORDER BY score DESC, first_published_at DESC NULLS LAST, content_id DESC
+LIMIT @limit`
+
+// contentRecommendation is a row of the content recommendations query
+type contentRecommendation struct {
+	ContentID int     `gorm:"column:content_id"`
+	Score     float64 `gorm:"column:score"`
+}
+
+// ListRecommendedContentItems returns the published content that the user is most likely to be interested in,
+// best first, based on the categories and tags of the content they have engaged with and on what is popular
+// with clients of the same type. Content the user has already viewed is left out
+func (db *PGInstance) ListRecommendedContentItems(ctx context.Context, userID string, limit int) ([]*ContentItemDetails, error) {
+	var recommendations []*contentRecommendation
+	err := db.DB.WithContext(ctx).Raw(contentRecommendations, sql.Named("user", userID), sql.Named("limit", limit), organisationArg(ctx)).
+		Scan(&recommendations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to recommend content: %v", err)
+	}
+	if len(recommendations) == 0 {
+		return []*ContentItemDetails{}, nil
+	}
+
+	contentIDs := []int{}
+	for _, recommendation := range recommendations {
+		contentIDs = append(contentIDs, recommendation.ContentID)
+	}
+	detailsByID, err := db.contentItemDetailsByID(ctx, contentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	contentItems := []*ContentItemDetails{}
+	for _, contentID := range contentIDs {
+		if item, ok := detailsByID[contentID]; ok {
+			contentItems = append(contentItems, item)
+		}
+	}
+	return contentItems, nil
+}
+
+// ContentInProgress is a content item that a user has started reading, together with their progress
+type ContentInProgress struct {
+	Progress *ContentProgress
+	Details  *ContentItemDetails
+}
+
+// ListContentInProgress returns the published content items that the user has started but not completed, the
+// most recently read first
+func (db *PGInstance) ListContentInProgress(ctx context.Context, userID string, limit int) ([]*ContentInProgress, error) {
+	var progress []*ContentProgress
+	err := db.DB.WithContext(ctx).
+		Joins("JOIN wagtailcore_page ON wagtailcore_page.id = content_contentprogress.content_item_id").
+		Where("content_contentprogress.user_id = ? AND content_contentprogress.active", userID).
+		Where("content_contentprogress.completed_at IS NULL").
+		Where("wagtailcore_page.live AND NOT wagtailcore_page.expired").
+		Order("content_contentprogress.updated DESC").
+		Limit(limit).
+		Find(&progress).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content in progress: %v", err)
+	}
+	if len(progress) == 0 {
+		return []*ContentInProgress{}, nil
+	}
+
+	contentIDs := []int{}
+	for _, p := range progress {
+		contentIDs = append(contentIDs, p.ContentID)
+	}
+	detailsByID, err := db.contentItemDetailsByID(ctx, contentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	inProgress := []*ContentInProgress{}
+	for _, p := range progress {
+		if details, ok := detailsByID[p.ContentID]; ok {
+			inProgress = append(inProgress, &ContentInProgress{Progress: p, Details: details})
+		}
+	}
+	return inProgress, nil
+}
+
+// ContentCommentDetails is a content comment together with the nickname of the user who wrote it
+type ContentCommentDetails struct {
+	Comment  *ContentComment
+	Nickname string
+}
+
+// GetContentCommentByID fetches a content comment, including one that has been deleted, by its ID
+func (db *PGInstance) GetContentCommentByID(ctx context.Context, commentID string) (*ContentCommentDetails, error) {
+	var comment ContentComment
+	if err := db.DB.WithContext(ctx).Where(&ContentComment{ID: &commentID}).First(&comment).Error; err != nil {
+		return nil, fmt.Errorf("failed to get content comment %v: %v", commentID, err)
+	}
+	comments, err := db.withCommentNicknames(ctx, []*ContentComment{&comment})
+	if err != nil {
+		return nil, err
+	}
+	return comments[0], nil
+}
+
+// ListContentComments fetches all the comments on a content item, oldest first, whatever their status. The
+// caller decides which of them the user can see
+func (db *PGInstance) ListContentComments(ctx context.Context, contentID int) ([]*ContentCommentDetails, error) {
+	var comments []*ContentComment
+	err := db.DB.WithContext(ctx).Where(&ContentComment{ContentID: contentID}).Order("created").Find(&comments).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the comments on content item %v: %v", contentID, err)
+	}
+	return db.withCommentNicknames(ctx, comments)
+}
+
+// ListContentCommentsByStatus fetches the comments that have not been deleted and are in one of the supplied
+// statuses, oldest first so that moderators work through them in the order that they were written
+func (db *PGInstance) ListContentCommentsByStatus(ctx context.Context, statuses []enums.ContentCommentStatus, limit int) ([]*ContentCommentDetails, error) {
+	var comments []*ContentComment
+	err := db.DB.WithContext(ctx).
+		Where("active AND status IN ?", statuses).
+		Order("created").
+		Limit(limit).
+		Find(&comments).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content comments by status: %v", err)
+	}
+	return db.withCommentNicknames(ctx, comments)
+}
+
+// withCommentNicknames pairs each comment with the nickname of the user who wrote it
+func (db *PGInstance) withCommentNicknames(ctx context.Context, comments []*ContentComment) ([]*ContentCommentDetails, error) {
+	userIDs := []string{}
+	for _, comment := range comments {
+		userIDs = append(userIDs, comment.UserID)
+	}
+
+	nicknames := map[string]string{}
+	if len(userIDs) > 0 {
+		var users []*User
+		if err := db.DB.WithContext(ctx).Select("id", "username").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+			return nil, fmt.Errorf("failed to get the nicknames of the commenters: %v", err)
+		}
+		for _, user := range users {
+			nicknames[*user.UserID] = user.Username
+		}
+	}
+
+	details := []*ContentCommentDetails{}
+	for _, comment := range comments {
+		details = append(details, &ContentCommentDetails{Comment: comment, Nickname: nicknames[comment.UserID]})
+	}
+	return details, nil
+}
+
+// contentAnalyticsEngagement selects the content items reported on and their engagement within the period. The
+// first placeholder is the condition that engagement rows must meet and the second narrows down the content items.
+const contentAnalyticsEngagement = `
+WITH items AS (
+	SELECT content_contentitem.page_ptr_id AS content_id, wagtailcore_page.title
+	FROM content_contentitem
+	JOIN wagtailcore_page ON wagtailcore_page.id = content_contentitem.page_ptr_id
+	%[2]s
+),
+views AS (
+	SELECT content_item_id, user_id FROM content_contentview WHERE %[1]s
+),
+reads AS (
+	SELECT content_item_id, user_id, duration_seconds FROM content_contentreadevent WHERE %[1]s
+),
+viewers AS (
+	SELECT content_item_id, user_id FROM views
+	UNION SELECT content_item_id, user_id FROM reads
+),
+likes AS (
+	SELECT content_item_id FROM content_contentlike WHERE %[1]s
+),
+bookmarks AS (
+	SELECT content_item_id FROM content_contentbookmark WHERE %[1]s
+),
+shares AS (
+	SELECT content_item_id FROM content_contentshare WHERE %[1]s
+),
+item_categories AS (
+	SELECT categories.contentitemcategory_id AS category_id, categories.contentitem_id AS content_id
+	FROM content_contentitem_categories AS categories
+	JOIN items ON items.content_id = categories.contentitem_id
+)`
+
+// contentItemAnalytics reports the engagement with each content item, most viewed first
+const contentItemAnalytics = `
+SELECT
+	items.content_id,
+	items.title,
+	(SELECT count(*) FROM views WHERE views.content_item_id = items.content_id) AS views,
+	(SELECT count(*) FROM viewers WHERE viewers.content_item_id = items.content_id) AS unique_viewers,
+	(SELECT count(*) FROM likes WHERE likes.content_item_id = items.content_id) AS likes,
+	(SELECT count(*) FROM bookmarks WHERE bookmarks.content_item_id = items.content_id) AS bookmarks,
+	(SELECT count(*) FROM shares WHERE shares.content_item_id = items.content_id) AS shares,
+	(SELECT coalesce(avg(duration_seconds), 0)::float FROM reads WHERE reads.content_item_id = items.content_id) AS average_read_seconds
+FROM items
+ORDER BY views DESC, unique_viewers DESC, items.content_id`
+
+// contentCategoryAnalytics reports the engagement with the content items of each category, most viewed first
+const contentCategoryAnalytics = `
+SELECT
+	category.id AS category_id,
+	category.name,
+	count(item_categories.content_id) AS content_items,
+	(
+		SELECT count(*) FROM views JOIN item_categories AS c ON c.content_id = views.content_item_id
+		WHERE c.category_id = category.id
+	) AS views,
+	(
+		SELECT count(DISTINCT viewers.user_id) FROM viewers JOIN item_categories AS c ON c.content_id = viewers.content_item_id
+		WHERE c.category_id = category.id
+	) AS unique_viewers,
+	(
+		SELECT count(*) FROM likes JOIN item_categories AS c ON c.content_id = likes.content_item_id
+		WHERE c.category_id = category.id
+	) AS likes,
+	(
+		SELECT count(*) FROM bookmarks JOIN item_categories AS c ON c.content_id = bookmarks.content_item_id
+		WHERE c.category_id = category.id
+	) AS bookmarks,
+	(
+		SELECT count(*) FROM shares JOIN item_categories AS c ON c.content_id = shares.content_item_id
+		WHERE c.category_id = category.id
+	) AS shares,
+	(
+		SELECT coalesce(avg(reads.duration_seconds), 0)::float FROM reads JOIN item_categories AS c ON c.content_id = reads.content_item_id
+		WHERE c.category_id = category.id
+	) AS average_read_seconds
+FROM content_contentitemcategory AS category
+JOIN item_categories ON item_categories.category_id = category.id
+GROUP BY category.id, category.name
+ORDER BY views DESC, unique_viewers DESC, category.id`
+
+// GetContentAnalytics reports the engagement with content, per content item and per category, between the start
+// and the end of the period. Engagement that belongs to other organisations is left out.
+func (db *PGInstance) GetContentAnalytics(ctx context.Context, input *dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error) {
+	args := []interface{}{sql.Named("from", input.From), sql.Named("to", input.To), organisationArg(ctx)}
+
+	engagementCondition := "active AND created >= @from AND created < @to AND " + inOrganisation(organisationColumn)
+	itemCondition := ""
+	if len(input.CategoryIDs) > 0 {
+		itemCondition = "WHERE EXISTS (SELECT 1 FROM content_contentitem_categories AS categories " +
+			"WHERE categories.contentitem_id = content_contentitem.page_ptr_id AND categories.contentitemcategory_id IN @categories)"
+		args = append(args, sql.Named("categories", input.CategoryIDs))
+	}
+	engagement := fmt.Sprintf(contentAnalyticsEngagement, engagementCondition, itemCondition)
+
+	analytics := &domain.ContentAnalytics{
+		From:       input.From,
+		To:         input.To,
+		Items:      []*domain.ContentItemAnalytics{},
+		Categories: []*domain.ContentCategoryAnalytics{},
+	}
+	if err := db.DB.WithContext(ctx).Raw(engagement+contentItemAnalytics, args...).Scan(&analytics.Items).Error; err != nil {
+		return nil, fmt.Errorf("failed to get content item analytics: %v", err)
+	}
+	if err := db.DB.WithContext(ctx).Raw(engagement+contentCategoryAnalytics, args...).Scan(&analytics.Categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get content category analytics: %v", err)
+	}
+	return analytics, nil
+}
+
+// earthRadiusKm is the mean radius of the earth used to compute the distance between two points
+const earthRadiusKm = 6371
+
+// facilityDistanceKm returns the SQL expression for the distance in kilometres between a facility and a location.
+// PostGIS is used when it is installed, otherwise the distance is computed using the haversine formula.
+func (db *PGInstance) facilityDistanceKm(latitude, longitude float64) clause.Expr {
+	if db.hasPostGIS {
+		return gorm.Expr("ST_DistanceSphere(ST_MakePoint(longitude, latitude), ST_MakePoint(?, ?)) / 1000", longitude, latitude)
+	}
+	// LEAST guards ASIN against rounding errors that push its argument above 1
+	return gorm.Expr(
+		"? * 2 * ASIN(LEAST(1, SQRT(POWER(SIN(RADIANS(latitude - ?) / 2), 2) + "+
+			"COS(RADIANS(?)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - ?) / 2), 2))))",
+		earthRadiusKm, latitude, latitude, longitude,
+	)
+}
+
+// NearbyFacilities fetches the facilities within the radius of a location that match the filters. The facilities
+// are sorted by their distance from the location, nearest first, unless another sort is requested.
+func (db *PGInstance) NearbyFacilities(
+	ctx context.Context, latitude, longitude, radiusKm float64, limit int, filter []*domain.FiltersParam, sort *domain.SortParam) ([]*NearbyFacility, error) {
+	for _, f := range filter {
+		if err := f.ValidateForCategory(enums.FilterSortCategoryTypeFacility); err != nil {
+			return nil, err
+		}
+	}
+
+	order := "distance_km asc"
+	if sort != nil && sort.Field != "" {
+		if err := enums.ValidateFilterSortCategories(enums.FilterSortCategoryTypeSortNearbyFacility, sort.Field); err != nil {
+			return nil, fmt.Errorf("sort param %v is not available in nearby facilities: %v", sort.Field, err)
+		}
+		direction := enums.SortDataTypeAsc
+		if sort.Direction.IsValid() {
+			direction = sort.Direction
+		}
+		order = fmt.Sprintf("%s %s", sort.Field, direction)
+		if sort.Field != enums.FilterSortDataTypeDistance {
+			order += ", distance_km asc"
+		}
+	}
+
+	now := time.Now()
+	distance := db.facilityDistanceKm(latitude, longitude)
+	var facilities []*NearbyFacility
+	err := db.DB.WithContext(ctx).Model(&Facility{}).
+		Scopes(withFacilityDetails(now), facilityFilters(filter, now)).
+		Select("*, ? AS distance_km", distance).
+		Where("latitude IS NOT NULL AND longitude IS NOT NULL").
+		Where("? <= ?", distance, radiusKm).
+		Order(order).
+		Limit(limit).
+		Find(&facilities).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get facilities near %v,%v: %v", latitude, longitude, err)
+	}
+	return facilities, nil
+}
+
+// facilityTimeZone is the time zone of facilities' opening hours. Kenya does not observe daylight saving time.
+var facilityTimeZone = time.FixedZone("EAT", 3*60*60)
+
+// openingHoursOrder sorts a facility's opening hours from Monday to Sunday
+const openingHoursOrder = "CASE day_of_week WHEN 'MONDAY' THEN 1 WHEN 'TUESDAY' THEN 2 WHEN 'WEDNESDAY' THEN 3 " +
+	"WHEN 'THURSDAY' THEN 4 WHEN 'FRIDAY' THEN 5 WHEN 'SATURDAY' THEN 6 ELSE 7 END, opens_at"
+
+// withFacilityDetails loads a facility's opening hours, the hours exceptions from today onwards and the services
+// that it offers
+func withFacilityDetails(now time.Time) func(db *gorm.DB) *gorm.DB {
+	today := now.In(facilityTimeZone).Format(common.DateLayout)
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Preload("OpeningHours", func(db *gorm.DB) *gorm.DB {
+				return db.Order(openingHoursOrder)
+			}).
+			Preload("HoursExceptions", func(db *gorm.DB) *gorm.DB {
+				return db.Where("date >= ?", today).Order("date")
+			}).
+			Preload("Services", func(db *gorm.DB) *gorm.DB {
+				return db.Order("name")
+			})
+	}
+}
+
+// facilitySearchDocument is the text that a facility's full-text search matches and ranks against
+const facilitySearchDocument = "to_tsvector('english', coalesce(name, '') || ' ' || coalesce(description, ''))"
+
+// searchFacilities matches the facilities whose name, county or description contains the search term, or whose
+// name and description match it using full-text search. The term can use web search syntax e.g "quoted phrases",
+// OR and -excluded words.
+func searchFacilities(term string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if term == "" {
+			return db
+		}
+		return db.Where(clause.NamedExpr{
+			SQL: "(name ILIKE @pattern OR county ILIKE @pattern OR description ILIKE @pattern OR " +
+				facilitySearchDocument + " @@ websearch_to_tsquery('english', @term))",
+			Vars: []interface{}{sql.Named("pattern", containsPattern(term)), sql.Named("term", term)},
+		})
+	}
+}
+
+// rankFacilitiesBySearch sorts the facilities that match the search term best first
+func rankFacilitiesBySearch(term string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Select("*, ts_rank("+facilitySearchDocument+", websearch_to_tsquery('english', ?)) AS search_rank", term).
+			Order("search_rank desc")
+	}
+}
+
+// containsPattern is a LIKE pattern that matches values containing the term. LIKE wildcards in the term are
+// matched literally.
+func containsPattern(term string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(term) + "%"
+}
+
+// facilityFilters applies the facility filters to a query. The service and open now filters are looked up in
+// the tables of the services and opening hours while the rest are facility columns.
+func facilityFilters(filter []*domain.FiltersParam, now time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, f := range filter {
+			db = db.Where(facilityFilterCondition(f, now))
+		}
+		return db
+	}
+}
+
+// facilityFilterGroups applies the groups of facility filters to a query. The filters in a group are combined
+// with the group's operator.
+func facilityFilterGroups(groups []*domain.FilterGroup, now time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, g := range groups {
+			conditions := []clause.Expression{}
+			for _, f := range g.Filters {
+				conditions = append(conditions, facilityFilterCondition(f, now))
+			}
+			switch {
+			case len(conditions) == 1:
+				// a single OR condition would be joined to the rest of the query with OR
+				db = db.Where(conditions[0])
+			case g.Operator == enums.FilterOperatorOr:
+				db = db.Where(clause.Or(conditions...))
+			default:
+				db = db.Where(clause.And(conditions...))
+			}
+		}
+		return db
+	}
+}
+
+// facilityFilterCondition is the condition that a facility matches a filter
+func facilityFilterCondition(f *domain.FiltersParam, now time.Time) clause.Expression {
+	column := clause.Column{Table: clause.CurrentTable, Name: f.DataType.String()}
+	switch f.DataType {
+	case enums.FilterSortDataTypeService:
+		return offersService(f.Value)
+	case enums.FilterSortDataTypeOpenNow:
+		if open, _ := strconv.ParseBool(f.Value); open {
+			return isOpenAt(now)
+		}
+		return clause.Not(isOpenAt(now))
+	case enums.FilterSortDataTypeCreatedAt, enums.FilterSortDataTypeUpdatedAt:
+		// the filters are validated before they are applied
+		from, to, _ := f.DateRange()
+		bounds := []clause.Expression{}
+		if from != nil {
+			bounds = append(bounds, clause.Gte{Column: column, Value: *from})
+		}
+		if to != nil {
+			bounds = append(bounds, clause.Lt{Column: column, Value: *to})
+		}
+		return clause.And(bounds...)
+	default:
+		return clause.Eq{Column: column, Value: f.Value}
+	}
+}
+
+// offersService is the condition for a facility offering the active service with the supplied code
+func offersService(code string) clause.Expr {
+	return gorm.Expr(
+		"EXISTS (SELECT 1 FROM common_facility_services fs JOIN common_facilityservice s ON s.id = fs.service_id "+
+			"WHERE fs.facility_id = common_facility.id AND s.code = ? AND s.active)",
+		code,
+	)
+}
+
+// isOpenAt is the condition for a facility being open at the supplied time. An exception on the day replaces
+// the facility's opening hours for that day of the week.
+func isOpenAt(now time.Time) clause.NamedExpr {
+	now = now.In(facilityTimeZone)
+	return clause.NamedExpr{
+		SQL: "(CASE WHEN EXISTS (SELECT 1 FROM common_facilityhoursexception e " +
+			"WHERE e.facility_id = common_facility.id AND e.date = @date) " +
+			"THEN EXISTS (SELECT 1 FROM common_facilityhoursexception e " +
+			"WHERE e.facility_id = common_facility.id AND e.date = @date AND NOT e.closed AND e.opens_at <= @time AND e.closes_at > @time) " +
+			"ELSE EXISTS (SELECT 1 FROM common_facilityopeninghours h " +
+			"WHERE h.facility_id = common_facility.id AND h.day_of_week = @day AND h.opens_at <= @time AND h.closes_at > @time) END)",
+		Vars: []interface{}{
+			sql.Named("date", now.Format(common.DateLayout)),
+			sql.Named("day", enums.DayOfWeekFromWeekday(now.Weekday()).String()),
+			sql.Named("time", now.Format("15:04:05")),
+		},
+	}
+}
+
+// facilityDetailsToDomain maps a facility's opening hours, hours exceptions and services to their domain models
+func facilityDetailsToDomain(facility *Facility) ([]*domain.FacilityOpeningHours, []*domain.FacilityHoursException, []*domain.FacilityService) {
+	openingHours := []*domain.FacilityOpeningHours{}
+	for _, hours := range facility.OpeningHours {
+		openingHours = append(openingHours, &domain.FacilityOpeningHours{
+			DayOfWeek: hours.DayOfWeek,
+			OpensAt:   formatTimeOfDay(hours.OpensAt),
+			ClosesAt:  formatTimeOfDay(hours.ClosesAt),
+		})
+	}
+	hoursExceptions := []*domain.FacilityHoursException{}
+	for _, exception := range facility.HoursExceptions {
+		hoursException := &domain.FacilityHoursException{
+			Date:   exception.Date.Format(common.DateLayout),
+			Closed: exception.Closed,
+			Reason: exception.Reason,
+		}
+		if exception.OpensAt != nil && exception.ClosesAt != nil {
+			opensAt, closesAt := formatTimeOfDay(*exception.OpensAt), formatTimeOfDay(*exception.ClosesAt)
+			hoursException.OpensAt, hoursException.ClosesAt = &opensAt, &closesAt
+		}
+		hoursExceptions = append(hoursExceptions, hoursException)
+	}
+	services := []*domain.FacilityService{}
+	for _, service := range facility.Services {
+		services = append(services, &domain.FacilityService{
+			ID:          service.ID,
+			Name:        service.Name,
+			Code:        service.Code,
+			Description: service.Description,
+			Active:      service.Active,
+		})
+	}
+	return openingHours, hoursExceptions, services
+}
+
+// formatTimeOfDay drops the seconds from a time read from the database e.g 08:00:00 becomes 08:00
+func formatTimeOfDay(value string) string {
+	if len(value) > len(common.TimeOfDayLayout) {
+		return value[:len(common.TimeOfDayLayout)]
+	}
+	return value
+}
+
+// ListFacilityServices fetches the catalogue of services that facilities can offer, ordered by name
+func (db *PGInstance) ListFacilityServices(ctx context.Context) ([]*FacilityService, error) {
+	var services []*FacilityService
+	if err := db.DB.WithContext(ctx).Order("name").Find(&services).Error; err != nil {
+		return nil, fmt.Errorf("failed to list facility services: %v", err)
+	}
+	return services, nil
+}
+
+// GetFacilityHistory fetches the changes made to the details of the facility with the supplied MFL code,
+// the most recent first
+func (db *PGInstance) GetFacilityHistory(ctx context.Context, mflCode int) ([]*FacilityHistory, error) {
+	var history []*FacilityHistory
+	err := db.DB.WithContext(ctx).
+		Joins("JOIN common_facility ON common_facility.id = common_facilityhistory.facility_id").
+		Where("common_facility.mfl_code = ?", mflCode).
+		Order("common_facilityhistory.changed_at desc").
+		Find(&history).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the history of facility %v: %v", mflCode, err)
+	}
+	return history, nil
+}
+
+// GetBulkInviteJob fetches a bulk invite job and its rows in the order they appear in the CSV file
+func (db *PGInstance) GetBulkInviteJob(ctx context.Context, jobID string) (*BulkInviteJob, error) {
+	var job BulkInviteJob
+	err := db.DB.WithContext(ctx).Where(&BulkInviteJob{ID: &jobID}).
+		Preload("Rows", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("row_number ASC")
+		}).First(&job).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bulk invite job: %v", err)
+	}
+	return &job, nil
+}
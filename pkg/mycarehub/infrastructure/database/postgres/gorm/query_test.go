@@ -2,21 +2,27 @@ package gorm_test
 
 import (
 	"context"
+	"math/rand"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
+	"github.com/segmentio/ksuid"
 )
 
 func TestPGInstance_RetrieveFacility(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 	fakeID := "1234"
 
 	type args struct {
@@ -73,7 +79,7 @@ func TestPGInstance_RetrieveFacility(t *testing.T) {
 }
 
 func TestPGInstance_RetrieveFacilityByMFLCode(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx      context.Context
@@ -120,12 +126,13 @@ func TestPGInstance_RetrieveFacilityByMFLCode(t *testing.T) {
 }
 
 func TestPGInstance_ListFacilities(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	d := testingDB
 
 	noSearchTerm := ""
 	searchTerm := "ro"
+	fullTextSearchTerm := "\"health centre\" -dental"
 
 	noFilterInput := []*domain.FiltersParam{}
 
@@ -245,6 +252,45 @@ func TestPGInstance_ListFacilities(t *testing.T) {
 		},
 	}
 
+	filterGroups := []*domain.FilterGroup{
+		{
+			Operator: enums.FilterOperatorOr,
+			Filters: []*domain.FiltersParam{
+				{
+					Name:     enums.FilterSortDataTypeCounty.String(),
+					DataType: enums.FilterSortDataTypeCounty,
+					Value:    enums.CountyTypeNairobi.String(),
+				},
+				{
+					Name:     enums.FilterSortDataTypeCounty.String(),
+					DataType: enums.FilterSortDataTypeCounty,
+					Value:    enums.CountyTypeKiambu.String(),
+				},
+			},
+		},
+		{
+			Operator: enums.FilterOperatorAnd,
+			Filters: []*domain.FiltersParam{
+				{
+					Name:     enums.FilterSortDataTypeCreatedAt.String(),
+					DataType: enums.FilterSortDataTypeCreatedAt,
+					Value:    "2000-01-01/..",
+				},
+				{
+					Name:     enums.FilterSortDataTypeUpdatedAt.String(),
+					DataType: enums.FilterSortDataTypeUpdatedAt,
+					Value:    "../" + time.Now().Format(time.RFC3339),
+				},
+			},
+		},
+	}
+	filterGroupsInvalidOperator := []*domain.FilterGroup{
+		{
+			Operator: enums.FilterOperator("XOR"),
+			Filters:  filterInput,
+		},
+	}
+
 	noSortValues := domain.SortParam{
 		Field:     enums.FilterSortDataTypeCreatedAt,
 		Direction: enums.SortDataTypeAsc,
@@ -286,6 +332,7 @@ func TestPGInstance_ListFacilities(t *testing.T) {
 		ctx              context.Context
 		searchTerm       *string
 		filterInput      []*domain.FiltersParam
+		filterGroups     []*domain.FilterGroup
 		PaginationsInput domain.FacilityPage
 	}
 	tests := []struct {
@@ -386,6 +433,38 @@ func TestPGInstance_ListFacilities(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid: with a full-text searchterm ranked by relevance",
+			args: args{
+				ctx:              ctx,
+				searchTerm:       &fullTextSearchTerm,
+				filterInput:      noFilterInput,
+				PaginationsInput: domain.FacilityPage{Pagination: domain.Pagination{Limit: 1, CurrentPage: 1, Sort: &domain.SortParam{}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid: with filter groups",
+			args: args{
+				ctx:              ctx,
+				searchTerm:       &noSearchTerm,
+				filterInput:      noFilterInput,
+				filterGroups:     filterGroups,
+				PaginationsInput: paginationInput,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid: filter group with an invalid operator",
+			args: args{
+				ctx:              ctx,
+				searchTerm:       &noSearchTerm,
+				filterInput:      noFilterInput,
+				filterGroups:     filterGroupsInvalidOperator,
+				PaginationsInput: paginationInput,
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid: with invalid sort",
 			args: args{
@@ -440,7 +519,7 @@ func TestPGInstance_ListFacilities(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := d.ListFacilities(tt.args.ctx, tt.args.searchTerm, tt.args.filterInput, &tt.args.PaginationsInput)
+			got, err := d.ListFacilities(tt.args.ctx, tt.args.searchTerm, tt.args.filterInput, tt.args.filterGroups, &tt.args.PaginationsInput)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("PGInstance.ListFacilities() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -454,7 +533,7 @@ func TestPGInstance_ListFacilities(t *testing.T) {
 }
 
 func TestPGInstance_GetFacilities(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx context.Context
@@ -486,7 +565,7 @@ func TestPGInstance_GetFacilities(t *testing.T) {
 }
 
 func TestPGInstance_GetSecurityQuestions(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx     context.Context
@@ -536,7 +615,7 @@ func TestPGInstance_GetSecurityQuestions(t *testing.T) {
 }
 
 func TestPGInstance_GetSecurityQuestionByID(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx                context.Context
@@ -572,7 +651,7 @@ func TestPGInstance_GetSecurityQuestionByID(t *testing.T) {
 }
 
 func TestPGInstance_CheckIfPhoneNumberExists(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx       context.Context
@@ -628,7 +707,7 @@ func TestPGInstance_CheckIfPhoneNumberExists(t *testing.T) {
 }
 
 func TestPGInstance_VerifyOTP(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	flavour := feedlib.FlavourConsumer
 
@@ -743,7 +822,7 @@ func TestPGInstance_VerifyOTP(t *testing.T) {
 }
 
 func TestPGInstance_GetClientProfileByUserID(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	invalidID := uuid.New().String()
 
@@ -789,7 +868,7 @@ func TestPGInstance_GetClientProfileByUserID(t *testing.T) {
 }
 
 func TestPGInstance_GetOTP(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx         context.Context
@@ -840,9 +919,9 @@ func TestPGInstance_GetOTP(t *testing.T) {
 }
 
 func TestPGInstance_GetUserSecurityQuestionsResponses(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
-	pg, err := gorm.NewPGInstance()
+	pg, err := newTestPGInstance()
 	if err != nil {
 		t.Errorf("failed to initialize new PG instance: %v", err)
 		return
@@ -896,7 +975,7 @@ func TestPGInstance_GetUserSecurityQuestionsResponses(t *testing.T) {
 }
 
 func TestPGInstance_GetContactByUserID(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	ID := uuid.New().String()
 
@@ -954,9 +1033,9 @@ func TestPGInstance_GetContactByUserID(t *testing.T) {
 }
 
 func TestPGInstance_CheckWhetherUserHasLikedContent(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
-	pg, err := gorm.NewPGInstance()
+	pg, err := newTestPGInstance()
 	if err != nil {
 		t.Errorf("pgInstance.Teardown() = %v", err)
 	}
@@ -1030,7 +1109,7 @@ func TestPGInstance_CheckWhetherUserHasLikedContent(t *testing.T) {
 }
 
 func TestPGInstance_GetUserProfileByUserID(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx    context.Context
@@ -1082,9 +1161,9 @@ func TestPGInstance_GetUserProfileByUserID(t *testing.T) {
 }
 
 func TestPGInstance_GetClientHealthDiaryQuote(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
-	pg, err := gorm.NewPGInstance()
+	pg, err := newTestPGInstance()
 	if err != nil {
 		t.Errorf("pgInstance.Teardown() = %v", err)
 	}
@@ -1152,9 +1231,9 @@ func TestPGInstance_GetClientHealthDiaryQuote(t *testing.T) {
 }
 
 func TestPGInstance_CanRecordHeathDiary(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
-	pg, err := gorm.NewPGInstance()
+	pg, err := newTestPGInstance()
 	if err != nil {
 		t.Errorf("pgInstance.Teardown() = %v", err)
 	}
@@ -1242,7 +1321,7 @@ func TestPGInstance_CanRecordHeathDiary(t *testing.T) {
 					t.Errorf("failed to create user: %v", err)
 				}
 			}
-			got, err := testingDB.CanRecordHeathDiary(tt.args.ctx, tt.args.clientID)
+			got, err := testingDB.CanRecordHeathDiary(tt.args.ctx, tt.args.clientID, time.Hour*24)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("PGInstance.CanRecordHeathDiary() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1259,9 +1338,9 @@ func TestPGInstance_CanRecordHeathDiary(t *testing.T) {
 }
 
 func TestPGInstance_CheckIfUserBookmarkedContent(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
-	pg, err := gorm.NewPGInstance()
+	pg, err := newTestPGInstance()
 	if err != nil {
 		t.Errorf("pgInstance.Teardown() = %v", err)
 	}
@@ -1326,7 +1405,7 @@ func TestPGInstance_ListContentCategories(t *testing.T) {
 		{
 			name: "happy case: get all content categories",
 			args: args{
-				ctx: context.Background(),
+				ctx: newTestContext(),
 			},
 			wantErr: false,
 		},
@@ -1355,7 +1434,7 @@ func TestPGInstance_GetUserProfileByPhoneNumber(t *testing.T) {
 		{
 			name: "happy case: get user profile by phone number",
 			args: args{
-				ctx:         context.Background(),
+				ctx:         newTestContext(),
 				phoneNumber: testPhone,
 			},
 			wantErr: false,
@@ -1389,7 +1468,7 @@ func TestPGInstance_GetUserPINByUserID(t *testing.T) {
 		{
 			name: "happy case: get user pin by user id",
 			args: args{
-				ctx:    context.Background(),
+				ctx:    newTestContext(),
 				userID: userID,
 			},
 			wantErr: false,
@@ -1423,7 +1502,7 @@ func TestPGInstance_GetSecurityQuestionResponseByID(t *testing.T) {
 		{
 			name: "happy case: get security question response by id",
 			args: args{
-				ctx:        context.Background(),
+				ctx:        newTestContext(),
 				questionID: securityQuestionID,
 			},
 			wantErr: false,
@@ -1459,7 +1538,7 @@ func TestPGInstance_CheckUserHasPin(t *testing.T) {
 		{
 			name: "happy case: check user has pin",
 			args: args{
-				ctx:     context.Background(),
+				ctx:     newTestContext(),
 				userID:  userID,
 				flavour: feedlib.FlavourConsumer,
 			},
@@ -1494,7 +1573,7 @@ func TestPGInstance_GetUserBookmarkedContent(t *testing.T) {
 		{
 			name: "happy case: get user bookmarked content",
 			args: args{
-				ctx:    context.Background(),
+				ctx:    newTestContext(),
 				userID: userID,
 			},
 			wantErr: false,
@@ -1528,7 +1607,7 @@ func TestPGInstance_GetClientHealthDiaryEntries(t *testing.T) {
 		{
 			name: "happy case: get client health diary entries",
 			args: args{
-				ctx:      context.Background(),
+				ctx:      newTestContext(),
 				clientID: clientID,
 			},
 			wantErr: false,
@@ -1550,9 +1629,9 @@ func TestPGInstance_GetClientHealthDiaryEntries(t *testing.T) {
 }
 
 func TestPGInstance_GetFAQContent(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 	limit := 10
-	pg, err := gorm.NewPGInstance()
+	pg, err := newTestPGInstance()
 	if err != nil {
 		t.Errorf("pgInstance.Teardown() = %v", err)
 	}
@@ -1608,3 +1687,1346 @@ func TestPGInstance_GetFAQContent(t *testing.T) {
 		t.Errorf("failed to delete record = %v", err)
 	}
 }
+
+func TestPGInstance_GetLatestUserInvitation(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx     context.Context
+		userID  string
+		flavour feedlib.Flavour
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				userID:  userID,
+				flavour: testFlavour,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - user has no invitation",
+			args: args{
+				ctx:     ctx,
+				userID:  uuid.New().String(),
+				flavour: testFlavour,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetLatestUserInvitation(tt.args.ctx, tt.args.userID, tt.args.flavour)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetLatestUserInvitation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.UserID != tt.args.userID {
+				t.Errorf("PGInstance.GetLatestUserInvitation() userID = %v, want %v", got.UserID, tt.args.userID)
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListPendingInvitations(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx        context.Context
+		facilityID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				facilityID: facilityID,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListPendingInvitations(tt.args.ctx, tt.args.facilityID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListPendingInvitations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for _, invitation := range got {
+				if invitation.Status != enums.InvitationStatusIssued && invitation.Status != enums.InvitationStatusDelivered {
+					t.Errorf("expected only pending invitations, got %v", invitation.Status)
+				}
+				if !invitation.ExpiresAt.After(time.Now()) {
+					t.Errorf("expected only invitations that have not expired, got one that expired at %v", invitation.ExpiresAt)
+				}
+			}
+		})
+	}
+}
+
+func TestPGInstance_GetStaffProfileByUserID(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: userID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - user is not staff",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetStaffProfileByUserID(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetStaffProfileByUserID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && *got.ID != staffID {
+				t.Errorf("PGInstance.GetStaffProfileByUserID() = %v, want %v", *got.ID, staffID)
+			}
+		})
+	}
+}
+
+func TestPGInstance_GetStaffProfileByStaffID(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx     context.Context
+		staffID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				staffID: staffID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - staff not found",
+			args: args{
+				ctx:     ctx,
+				staffID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetStaffProfileByStaffID(tt.args.ctx, tt.args.staffID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetStaffProfileByStaffID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && *got.UserID != userID {
+				t.Errorf("PGInstance.GetStaffProfileByStaffID() = %v, want %v", *got.UserID, userID)
+			}
+		})
+	}
+}
+
+func TestPGInstance_GetStaffFacilities(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx     context.Context
+		staffID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				staffID: staffID,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetStaffFacilities(tt.args.ctx, tt.args.staffID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetStaffFacilities() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected staff facilities to be returned")
+			}
+		})
+	}
+}
+
+func TestPGInstance_GetUserRoles(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: userID,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetUserRoles(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetUserRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected user roles to be returned")
+			}
+		})
+	}
+}
+
+func TestPGInstance_GetClientProfileByClientID(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx      context.Context
+		clientID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:      ctx,
+				clientID: clientID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - client does not exist",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetClientProfileByClientID(tt.args.ctx, tt.args.clientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetClientProfileByClientID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && *got.ID != clientID {
+				t.Errorf("PGInstance.GetClientProfileByClientID() = %v, want %v", *got.ID, clientID)
+			}
+		})
+	}
+}
+
+func TestPGInstance_GetOrganisation(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx            context.Context
+		organisationID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:            ctx,
+				organisationID: orgID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - organisation not found",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetOrganisation(tt.args.ctx, tt.args.organisationID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetOrganisation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && *got.OrganisationID != tt.args.organisationID {
+				t.Errorf("PGInstance.GetOrganisation() = %v, want %v", *got.OrganisationID, tt.args.organisationID)
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListOrganisations(t *testing.T) {
+	ctx := newTestContext()
+
+	got, err := testingDB.ListOrganisations(ctx)
+	if err != nil {
+		t.Errorf("PGInstance.ListOrganisations() error = %v", err)
+		return
+	}
+	if len(got) == 0 {
+		t.Errorf("expected the fixture organisation to be listed")
+	}
+}
+
+func TestPGInstance_GetFacilityHistory(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx     context.Context
+		mflCode int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				mflCode: mflCode,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - unknown facility",
+			args: args{
+				ctx:     ctx,
+				mflCode: -1,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetFacilityHistory(tt.args.ctx, tt.args.mflCode)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetFacilityHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.name == "Happy case - unknown facility" && len(got) != 0 {
+				t.Errorf("expected no history for an unknown facility, got %v entries", len(got))
+			}
+		})
+	}
+}
+
+func TestPGInstance_NearbyFacilities(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	newFacility := func(latitude, longitude float64) *gorm.Facility {
+		return &gorm.Facility{
+			Name:        ksuid.New().String(),
+			Code:        rand.Intn(1000000),
+			Active:      true,
+			County:      "Nairobi",
+			Description: gofakeit.HipsterSentence(15),
+			Latitude:    &latitude,
+			Longitude:   &longitude,
+		}
+	}
+	// Kenyatta National Hospital, a facility about 2km away from it and one in Mombasa
+	nearest := newFacility(-1.3010, 36.8070)
+	near := newFacility(-1.2864, 36.8172)
+	far := newFacility(-4.0435, 39.6682)
+	for _, facility := range []*gorm.Facility{nearest, near, far} {
+		if err = pg.DB.Create(facility).Error; err != nil {
+			t.Errorf("failed to create facility: %v", err)
+			return
+		}
+	}
+
+	type args struct {
+		ctx      context.Context
+		radiusKm float64
+		limit    int
+		filter   []*domain.FiltersParam
+		sort     *domain.SortParam
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCodes []int
+		wantErr   bool
+	}{
+		{
+			name: "Happy case - nearest first",
+			args: args{
+				ctx:      ctx,
+				radiusKm: 10,
+				limit:    10,
+				filter: []*domain.FiltersParam{
+					{Name: "county", DataType: enums.FilterSortDataTypeCounty, Value: "Nairobi"},
+				},
+			},
+			wantCodes: []int{nearest.Code, near.Code},
+			wantErr:   false,
+		},
+		{
+			name: "Happy case - furthest first",
+			args: args{
+				ctx:      ctx,
+				radiusKm: 1000,
+				limit:    10,
+				sort:     &domain.SortParam{Field: enums.FilterSortDataTypeDistance, Direction: enums.SortDataTypeDesc},
+			},
+			wantCodes: []int{far.Code, near.Code, nearest.Code},
+			wantErr:   false,
+		},
+		{
+			name: "Happy case - limited",
+			args: args{
+				ctx:      ctx,
+				radiusKm: 1000,
+				limit:    1,
+			},
+			wantCodes: []int{nearest.Code},
+			wantErr:   false,
+		},
+		{
+			name: "Sad case - invalid sort",
+			args: args{
+				ctx:      ctx,
+				radiusKm: 10,
+				limit:    10,
+				sort:     &domain.SortParam{Field: enums.FilterSortDataTypeCreatedAt},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.NearbyFacilities(tt.args.ctx, *nearest.Latitude, *nearest.Longitude, tt.args.radiusKm, tt.args.limit, tt.args.filter, tt.args.sort)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.NearbyFacilities() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			codes := []int{}
+			for _, facility := range got {
+				codes = append(codes, facility.Code)
+			}
+			if !reflect.DeepEqual(codes, tt.wantCodes) {
+				t.Errorf("PGInstance.NearbyFacilities() returned facilities %v, want %v", codes, tt.wantCodes)
+			}
+		})
+	}
+
+	// TearDown
+	if err = pg.DB.Where("mfl_code IN ?", []int{nearest.Code, near.Code, far.Code}).Unscoped().Delete(&gorm.Facility{}).Error; err != nil {
+		t.Errorf("failed to delete facilities: %v", err)
+	}
+}
+
+func TestPGInstance_ListFacilityServices(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	service, err := testingDB.CreateFacilityService(ctx, &gorm.FacilityService{
+		Name:   gofakeit.BS(),
+		Code:   "TEST_" + strconv.Itoa(rand.Intn(1000000)),
+		Active: true,
+	})
+	if err != nil {
+		t.Errorf("failed to create facility service: %v", err)
+		return
+	}
+
+	got, err := testingDB.ListFacilityServices(ctx)
+	if err != nil {
+		t.Errorf("PGInstance.ListFacilityServices() error = %v", err)
+		return
+	}
+	found := false
+	for _, s := range got {
+		if *s.ID == *service.ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the created service to be listed")
+	}
+
+	// TearDown
+	if err = pg.DB.Where("id", service.ID).Unscoped().Delete(&gorm.FacilityService{}).Error; err != nil {
+		t.Errorf("failed to delete facility service: %v", err)
+	}
+}
+
+func TestPGInstance_ClientTransferQueries(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	toFacility := &gorm.Facility{
+		Name:        ksuid.New().String(),
+		Code:        rand.Intn(1000000),
+		Active:      true,
+		County:      "Nairobi",
+		Description: gofakeit.HipsterSentence(15),
+	}
+	if err = pg.DB.Create(toFacility).Error; err != nil {
+		t.Errorf("failed to create facility: %v", err)
+		return
+	}
+	transfer := &gorm.ClientTransfer{
+		ClientID:       clientID2,
+		FromFacilityID: facilityID,
+		ToFacilityID:   *toFacility.FacilityID,
+		Reason:         "Client relocated",
+		Status:         enums.ClientTransferStatusPending,
+		RequestedByID:  staffID,
+		RequestedAt:    time.Now(),
+	}
+	if err = pg.DB.Create(transfer).Error; err != nil {
+		t.Errorf("failed to create client transfer: %v", err)
+		return
+	}
+	period := &gorm.ClientFacility{
+		ClientID:   clientID2,
+		FacilityID: facilityID,
+		StartDate:  time.Now(),
+	}
+	if err = pg.DB.Create(period).Error; err != nil {
+		t.Errorf("failed to create client facility period: %v", err)
+		return
+	}
+
+	t.Run("GetClientTransferByID", func(t *testing.T) {
+		got, err := testingDB.GetClientTransferByID(ctx, *transfer.ID)
+		if err != nil {
+			t.Errorf("PGInstance.GetClientTransferByID() error = %v", err)
+			return
+		}
+		if got.ToFacilityID != *toFacility.FacilityID {
+			t.Errorf("expected the transfer to facility %v, got %v", *toFacility.FacilityID, got.ToFacilityID)
+		}
+
+		if _, err = testingDB.GetClientTransferByID(ctx, uuid.New().String()); err == nil {
+			t.Errorf("expected an error for a transfer that does not exist")
+		}
+	})
+
+	t.Run("CheckClientHasPendingTransfer", func(t *testing.T) {
+		got, err := testingDB.CheckClientHasPendingTransfer(ctx, clientID2)
+		if err != nil {
+			t.Errorf("PGInstance.CheckClientHasPendingTransfer() error = %v", err)
+			return
+		}
+		if !got {
+			t.Errorf("expected the client to have a pending transfer")
+		}
+	})
+
+	t.Run("ListPendingClientTransfers", func(t *testing.T) {
+		got, err := testingDB.ListPendingClientTransfers(ctx, *toFacility.FacilityID)
+		if err != nil {
+			t.Errorf("PGInstance.ListPendingClientTransfers() error = %v", err)
+			return
+		}
+		if len(got) != 1 {
+			t.Errorf("expected 1 pending transfer, got %v", len(got))
+		}
+	})
+
+	t.Run("GetClientFacilityHistory", func(t *testing.T) {
+		got, err := testingDB.GetClientFacilityHistory(ctx, clientID2)
+		if err != nil {
+			t.Errorf("PGInstance.GetClientFacilityHistory() error = %v", err)
+			return
+		}
+		if len(got) == 0 {
+			t.Errorf("expected the client to have a facility history")
+		}
+	})
+
+	// TearDown
+	if err = pg.DB.Where("id", period.ID).Unscoped().Delete(&gorm.ClientFacility{}).Error; err != nil {
+		t.Errorf("failed to delete client facility period: %v", err)
+	}
+	if err = pg.DB.Where("id", transfer.ID).Unscoped().Delete(&gorm.ClientTransfer{}).Error; err != nil {
+		t.Errorf("failed to delete client transfer: %v", err)
+	}
+	if err = pg.DB.Where("id", toFacility.FacilityID).Unscoped().Delete(&gorm.Facility{}).Error; err != nil {
+		t.Errorf("failed to delete facility: %v", err)
+	}
+}
+
+func TestPGInstance_ListContentItems(t *testing.T) {
+	ctx := newTestContext()
+	invalidCategoryID := -1
+
+	type args struct {
+		ctx        context.Context
+		categoryID *int
+		contentIDs []int
+		limit      int
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCount int
+		wantTotal int64
+		wantErr   bool
+	}{
+		{
+			name: "Happy case - list content items",
+			args: args{
+				ctx:        ctx,
+				contentIDs: []int{contentID, contentID2},
+				limit:      10,
+			},
+			wantCount: 2,
+			wantTotal: 2,
+			wantErr:   false,
+		},
+		{
+			name: "Happy case - limit is applied after counting",
+			args: args{
+				ctx:        ctx,
+				contentIDs: []int{contentID, contentID2},
+				limit:      1,
+			},
+			wantCount: 1,
+			wantTotal: 2,
+			wantErr:   false,
+		},
+		{
+			name: "Happy case - no content in the category",
+			args: args{
+				ctx:        ctx,
+				categoryID: &invalidCategoryID,
+				limit:      10,
+			},
+			wantCount: 0,
+			wantTotal: 0,
+			wantErr:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := testingDB.ListContentItems(tt.args.ctx, tt.args.categoryID, tt.args.contentIDs, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListContentItems() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.wantCount || total != tt.wantTotal {
+				t.Errorf("expected %v of %v content items, got %v of %v", tt.wantCount, tt.wantTotal, len(got), total)
+				return
+			}
+			for _, item := range got {
+				if item.Page.Title == "" || item.Author.Name == "" {
+					t.Errorf("expected content item %v to include its page and author", item.ContentItem.PagePtrID)
+				}
+			}
+		})
+	}
+}
+
+func TestPGInstance_SearchContent(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx        context.Context
+		input      *dto.ContentSearchInput
+		pagination *domain.Pagination
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCount int
+		wantTotal int64
+		// wantHighlight is set when the search matches the intro or body, which the snippet is taken from
+		wantHighlight bool
+		wantErr       bool
+	}{
+		{
+			name: "Happy case - search the body",
+			args: args{
+				ctx:        ctx,
+				input:      &dto.ContentSearchInput{Query: "quick foxes", Language: enumutils.LanguageEn},
+				pagination: &domain.Pagination{Limit: 10, CurrentPage: 1},
+			},
+			wantCount:     2,
+			wantTotal:     2,
+			wantHighlight: true,
+			wantErr:       false,
+		},
+		{
+			name: "Happy case - search a phrase in the title",
+			args: args{
+				ctx:        ctx,
+				input:      &dto.ContentSearchInput{Query: `"test title2"`, Language: enumutils.LanguageEn},
+				pagination: &domain.Pagination{Limit: 10, CurrentPage: 1},
+			},
+			wantCount: 1,
+			wantTotal: 1,
+			wantErr:   false,
+		},
+		{
+			name: "Happy case - page size is applied after counting",
+			args: args{
+				ctx:        ctx,
+				input:      &dto.ContentSearchInput{Query: "fox", Language: enumutils.LanguageSw},
+				pagination: &domain.Pagination{Limit: 1, CurrentPage: 1},
+			},
+			wantCount: 1,
+			wantTotal: 2,
+			wantErr:   false,
+		},
+		{
+			name: "Happy case - no content in the category",
+			args: args{
+				ctx:        ctx,
+				input:      &dto.ContentSearchInput{Query: "fox", CategoryIDs: []int{-1}, Language: enumutils.LanguageEn},
+				pagination: &domain.Pagination{Limit: 10, CurrentPage: 1},
+			},
+			wantCount: 0,
+			wantTotal: 0,
+			wantErr:   false,
+		},
+		{
+			name: "Sad case - unsupported language",
+			args: args{
+				ctx:        ctx,
+				input:      &dto.ContentSearchInput{Query: "fox", Language: enumutils.Language("invalid")},
+				pagination: &domain.Pagination{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.SearchContent(tt.args.ctx, tt.args.input, tt.args.pagination)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.SearchContent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got.Results) != tt.wantCount || got.Pagination.Count != tt.wantTotal {
+				t.Errorf("expected %v of %v search results, got %v of %v", tt.wantCount, tt.wantTotal, len(got.Results), got.Pagination.Count)
+				return
+			}
+			for _, result := range got.Results {
+				if result.Details.Page.Title == "" || result.Rank <= 0 {
+					t.Errorf("expected search result %v to include its page and rank", result.Details.ContentItem.PagePtrID)
+				}
+				if tt.wantHighlight && !strings.Contains(result.Snippet, "<mark>") {
+					t.Errorf("expected search result %v to have a highlighted snippet, got %v", result.Details.ContentItem.PagePtrID, result.Snippet)
+				}
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListRecommendedContentItems(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("pgInstance.Teardown() = %v", err)
+		return
+	}
+
+	// the user has viewed the first item while a client of the same type has liked the second
+	contentView := &gorm.ContentView{
+		Active:         true,
+		ContentID:      contentID,
+		UserID:         userID,
+		OrganisationID: orgID,
+	}
+	if err = pg.DB.Create(contentView).Error; err != nil {
+		t.Errorf("failed to create content view: %v", err)
+		return
+	}
+	contentLike := &gorm.ContentLike{
+		Active:         true,
+		ContentID:      contentID2,
+		UserID:         userID2,
+		OrganisationID: orgID,
+	}
+	if err = pg.DB.Create(contentLike).Error; err != nil {
+		t.Errorf("failed to create content like: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		limit  int
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantFirst int
+		wantErr   bool
+	}{
+		{
+			name: "Happy case - content popular with similar clients comes first",
+			args: args{
+				ctx:    ctx,
+				userID: userID,
+				limit:  10,
+			},
+			wantFirst: contentID2,
+			wantErr:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListRecommendedContentItems(tt.args.ctx, tt.args.userID, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListRecommendedContentItems() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) == 0 || got[0].ContentItem.PagePtrID != tt.wantFirst {
+				t.Errorf("expected content item %v to be recommended first, got %v", tt.wantFirst, got)
+				return
+			}
+			for _, item := range got {
+				if item.ContentItem.PagePtrID == contentID {
+					t.Errorf("expected content the user has viewed to be left out")
+				}
+			}
+		})
+	}
+
+	// TearDown
+	if err = pg.DB.Where("id", contentView.ContentViewID).Unscoped().Delete(&gorm.ContentView{}).Error; err != nil {
+		t.Errorf("failed to delete content view: %v", err)
+	}
+	if err = pg.DB.Where("id", contentLike.ContentLikeID).Unscoped().Delete(&gorm.ContentLike{}).Error; err != nil {
+		t.Errorf("failed to delete content like: %v", err)
+	}
+}
+
+func TestPGInstance_ListContentFeed(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("pgInstance.Teardown() = %v", err)
+		return
+	}
+
+	// the second item has been viewed recently while the first has more likes
+	contentView := &gorm.ContentView{
+		Active:         true,
+		ContentID:      contentID2,
+		UserID:         userID,
+		OrganisationID: orgID,
+	}
+	if err = pg.DB.Create(contentView).Error; err != nil {
+		t.Errorf("failed to create content view: %v", err)
+		return
+	}
+	if err = pg.DB.Model(&gorm.ContentItem{}).Where("page_ptr_id", contentID).Update("like_count", 1000).Error; err != nil {
+		t.Errorf("failed to update content like count: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx          context.Context
+		sort         enums.ContentSortOrder
+		trendingDays int
+		limit        int
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantFirst int
+		wantErr   bool
+	}{
+		{
+			name: "Happy case - most liked",
+			args: args{
+				ctx:   ctx,
+				sort:  enums.ContentSortOrderMostLiked,
+				limit: 1,
+			},
+			wantFirst: contentID,
+			wantErr:   false,
+		},
+		{
+			name: "Happy case - trending",
+			args: args{
+				ctx:          ctx,
+				sort:         enums.ContentSortOrderTrending,
+				trendingDays: 7,
+				limit:        1,
+			},
+			wantFirst: contentID2,
+			wantErr:   false,
+		},
+		{
+			name: "Sad case - invalid sort order",
+			args: args{
+				ctx:   ctx,
+				sort:  enums.ContentSortOrder("invalid"),
+				limit: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListContentFeed(tt.args.ctx, nil, tt.args.sort, tt.args.trendingDays, nil, nil, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListContentFeed() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got.Items) != 1 || len(got.Positions) != 1 || got.Total < 2 || !got.HasMore {
+				t.Errorf("expected a page of 1 of at least 2 content items, got %v of %v", len(got.Items), got.Total)
+				return
+			}
+			if got.Items[0].ContentItem.PagePtrID != tt.wantFirst {
+				t.Errorf("expected content item %v first, got %v", tt.wantFirst, got.Items[0].ContentItem.PagePtrID)
+			}
+		})
+	}
+
+	// the page after the most liked item doesn't repeat it and the page before the next item is the first page
+	first, err := testingDB.ListContentFeed(ctx, nil, enums.ContentSortOrderMostLiked, 0, nil, nil, 1)
+	if err != nil || len(first.Positions) != 1 {
+		t.Errorf("failed to list the first page of the content feed: %v", err)
+		return
+	}
+	next, err := testingDB.ListContentFeed(ctx, nil, enums.ContentSortOrderMostLiked, 0, first.Positions[0], nil, 1)
+	if err != nil || len(next.Positions) != 1 {
+		t.Errorf("failed to list the page after the first page of the content feed: %v", err)
+		return
+	}
+	if next.Positions[0].ContentID == contentID {
+		t.Errorf("expected the page after content item %v to leave it out", contentID)
+	}
+	previous, err := testingDB.ListContentFeed(ctx, nil, enums.ContentSortOrderMostLiked, 0, nil, next.Positions[0], 1)
+	if err != nil || len(previous.Positions) != 1 {
+		t.Errorf("failed to list the page before the second page of the content feed: %v", err)
+		return
+	}
+	if previous.Positions[0].ContentID != contentID || previous.HasMore {
+		t.Errorf("expected the page before the second page to be the first page, got %v", previous.Positions[0])
+	}
+
+	// TearDown
+	if err = pg.DB.Where("id", contentView.ContentViewID).Unscoped().Delete(&gorm.ContentView{}).Error; err != nil {
+		t.Errorf("failed to delete content view: %v", err)
+	}
+	if err = pg.DB.Model(&gorm.ContentItem{}).Where("page_ptr_id", contentID).Update("like_count", 0).Error; err != nil {
+		t.Errorf("failed to reset content like count: %v", err)
+	}
+}
+
+func TestPGInstance_GetContentAnalytics(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("pgInstance.Teardown() = %v", err)
+		return
+	}
+
+	readEvents := []*gorm.ContentReadEvent{
+		{Active: true, ContentID: contentID, UserID: userID, DurationSeconds: 60, OrganisationID: orgID},
+		{Active: true, ContentID: contentID, UserID: userID, DurationSeconds: 120, OrganisationID: orgID},
+		{Active: true, ContentID: contentID, UserID: userID2, DurationSeconds: 30, OrganisationID: orgID},
+	}
+	if err = pg.DB.Create(&readEvents).Error; err != nil {
+		t.Errorf("failed to create content read events: %v", err)
+		return
+	}
+	// the second user's read has no recorded view hence it isn't counted as a view
+	contentView := &gorm.ContentView{Active: true, ContentID: contentID, UserID: userID, OrganisationID: orgID}
+	if err = pg.DB.Create(contentView).Error; err != nil {
+		t.Errorf("failed to create content view: %v", err)
+		return
+	}
+
+	now := time.Now()
+	type args struct {
+		ctx   context.Context
+		input *dto.ContentAnalyticsInput
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantViews       int
+		wantViewers     int
+		wantAverageRead float64
+		wantErr         bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx: ctx,
+				input: &dto.ContentAnalyticsInput{
+					From: now.Add(-time.Hour),
+					To:   now.Add(time.Hour),
+				},
+			},
+			wantViews:       1,
+			wantViewers:     2,
+			wantAverageRead: 70,
+			wantErr:         false,
+		},
+		{
+			name: "Happy case - period without engagement",
+			args: args{
+				ctx: ctx,
+				input: &dto.ContentAnalyticsInput{
+					From: now.AddDate(-1, 0, 0),
+					To:   now.AddDate(-1, 1, 0),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - engagement of other organisations is left out",
+			args: args{
+				ctx: helpers.ContextWithIdentity(ctx, &domain.Identity{
+					UserID:         uuid.New().String(),
+					OrganisationID: uuid.New().String(),
+				}),
+				input: &dto.ContentAnalyticsInput{
+					From: now.Add(-time.Hour),
+					To:   now.Add(time.Hour),
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetContentAnalytics(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetContentAnalytics() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			for _, item := range got.Items {
+				if item.ContentID != contentID {
+					continue
+				}
+				if item.Views != tt.wantViews || item.UniqueViewers != tt.wantViewers {
+					t.Errorf("expected %v views by %v viewers, got %v views by %v viewers", tt.wantViews, tt.wantViewers, item.Views, item.UniqueViewers)
+				}
+				if item.AverageReadSeconds != tt.wantAverageRead {
+					t.Errorf("expected an average read of %v seconds, got %v", tt.wantAverageRead, item.AverageReadSeconds)
+				}
+			}
+		})
+	}
+
+	// TearDown
+	for _, readEvent := range readEvents {
+		if err = pg.DB.Where("id", readEvent.ContentReadEventID).Unscoped().Delete(&gorm.ContentReadEvent{}).Error; err != nil {
+			t.Errorf("failed to delete content read event: %v", err)
+		}
+	}
+	if err = pg.DB.Where("id", contentView.ContentViewID).Unscoped().Delete(&gorm.ContentView{}).Error; err != nil {
+		t.Errorf("failed to delete content view: %v", err)
+	}
+}
+
+func TestPGInstance_ListContentInProgress(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("pgInstance.Teardown() = %v", err)
+		return
+	}
+
+	completedAt := time.Now()
+	progress := []*gorm.ContentProgress{
+		{Active: true, UserID: userID, ContentID: contentID, SecondsSpent: 30, PercentScrolled: 40, OrganisationID: orgID},
+		{Active: true, UserID: userID, ContentID: contentID2, SecondsSpent: 90, PercentScrolled: 100, CompletedAt: &completedAt, OrganisationID: orgID},
+	}
+	if err = pg.DB.Create(&progress).Error; err != nil {
+		t.Errorf("failed to create content progress: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		limit  int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantIDs []int
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: userID,
+				limit:  10,
+			},
+			wantIDs: []int{contentID},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - nothing in progress",
+			args: args{
+				ctx:    ctx,
+				userID: userID2,
+				limit:  10,
+			},
+			wantIDs: []int{},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListContentInProgress(tt.args.ctx, tt.args.userID, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListContentInProgress() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.wantIDs) {
+				t.Errorf("expected %v content items in progress, got %v", len(tt.wantIDs), len(got))
+				return
+			}
+			for i, inProgress := range got {
+				if inProgress.Details.ContentItem.PagePtrID != tt.wantIDs[i] {
+					t.Errorf("expected content item %v, got %v", tt.wantIDs[i], inProgress.Details.ContentItem.PagePtrID)
+				}
+			}
+		})
+	}
+
+	// TearDown
+	if err = pg.DB.Where("user_id", userID).Unscoped().Delete(&gorm.ContentProgress{}).Error; err != nil {
+		t.Errorf("failed to delete content progress: %v", err)
+	}
+}
+
+func TestPGInstance_ListContentComments(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("pgInstance.Teardown() = %v", err)
+		return
+	}
+
+	comments := []*gorm.ContentComment{
+		{Active: true, ContentID: contentID, UserID: userID, Body: "How long should I take the medication?", Status: enums.ContentCommentStatusApproved, OrganisationID: orgID},
+		{Active: true, ContentID: contentID, UserID: userID2, Body: "Ask your clinician", Status: enums.ContentCommentStatusPending, OrganisationID: orgID},
+	}
+	if err = pg.DB.Create(&comments).Error; err != nil {
+		t.Errorf("failed to create content comments: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx       context.Context
+		contentID int
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				contentID: contentID,
+			},
+			wantCount: 2,
+			wantErr:   false,
+		},
+		{
+			name: "Happy case - no comments",
+			args: args{
+				ctx:       ctx,
+				contentID: contentID2,
+			},
+			wantCount: 0,
+			wantErr:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListContentComments(tt.args.ctx, tt.args.contentID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListContentComments() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.wantCount {
+				t.Errorf("expected %v content comments, got %v", tt.wantCount, len(got))
+				return
+			}
+			for _, comment := range got {
+				if comment.Nickname == "" {
+					t.Errorf("expected the comment to have the nickname of the user who wrote it")
+				}
+			}
+		})
+	}
+
+	// TearDown
+	if err = pg.DB.Where("content_item_id", contentID).Unscoped().Delete(&gorm.ContentComment{}).Error; err != nil {
+		t.Errorf("failed to delete content comments: %v", err)
+	}
+}
+
+func TestPGInstance_ListContentCommentsByStatus(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("pgInstance.Teardown() = %v", err)
+		return
+	}
+
+	comments := []*gorm.ContentComment{
+		{Active: true, ContentID: contentID, UserID: userID, Body: "How long should I take the medication?", Status: enums.ContentCommentStatusApproved, OrganisationID: orgID},
+		{Active: true, ContentID: contentID, UserID: userID2, Body: "Ask your clinician", Status: enums.ContentCommentStatusPending, OrganisationID: orgID},
+		{Active: false, ContentID: contentID, UserID: userID2, Body: "Ask your doctor", Status: enums.ContentCommentStatusPending, OrganisationID: orgID},
+	}
+	if err = pg.DB.Create(&comments).Error; err != nil {
+		t.Errorf("failed to create content comments: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx      context.Context
+		statuses []enums.ContentCommentStatus
+		limit    int
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:      ctx,
+				statuses: []enums.ContentCommentStatus{enums.ContentCommentStatusPending, enums.ContentCommentStatusFlagged},
+				limit:    10,
+			},
+			wantCount: 1,
+			wantErr:   false,
+		},
+		{
+			name: "Happy case - no comments in the status",
+			args: args{
+				ctx:      ctx,
+				statuses: []enums.ContentCommentStatus{enums.ContentCommentStatusRejected},
+				limit:    10,
+			},
+			wantCount: 0,
+			wantErr:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListContentCommentsByStatus(tt.args.ctx, tt.args.statuses, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListContentCommentsByStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.wantCount {
+				t.Errorf("expected %v content comments, got %v", tt.wantCount, len(got))
+			}
+		})
+	}
+
+	// TearDown
+	if err = pg.DB.Where("content_item_id", contentID).Unscoped().Delete(&gorm.ContentComment{}).Error; err != nil {
+		t.Errorf("failed to delete content comments: %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common"
@@ -36,19 +37,26 @@ type Facility struct {
 	// unique within this structure
 	Name string `gorm:"column:name;unique;not null"`
 	// MFL Code for Kenyan facilities, globally unique
-	Code           int    `gorm:"unique;column:mfl_code;not null"`
-	Active         bool   `gorm:"column:active;not null"`
-	County         string `gorm:"column:county;not null"` // TODO: Controlled list of counties
-	Phone          string `gorm:"column:phone"`
-	Description    string `gorm:"column:description;not null"`
-	OrganisationID string `gorm:"column:organisation_id"`
+	Code           int      `gorm:"unique;column:mfl_code;not null"`
+	Active         bool     `gorm:"column:active;not null"`
+	County         string   `gorm:"column:county;not null"` // TODO: Controlled list of counties
+	Phone          string   `gorm:"column:phone"`
+	Description    string   `gorm:"column:description;not null"`
+	Latitude       *float64 `gorm:"column:latitude"`
+	Longitude      *float64 `gorm:"column:longitude"`
+	Address        string   `gorm:"column:address"`
+	OrganisationID string   `gorm:"column:organisation_id"`
+
+	OpeningHours    []*FacilityOpeningHours   `gorm:"foreignKey:FacilityID;references:FacilityID"`
+	HoursExceptions []*FacilityHoursException `gorm:"foreignKey:FacilityID;references:FacilityID"`
+	Services        []*FacilityService        `gorm:"many2many:common_facility_services;joinForeignKey:FacilityID;joinReferences:ServiceID"`
 }
 
 // BeforeCreate is a hook run before creating a new facility
 func (f *Facility) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	f.FacilityID = &id
-	f.OrganisationID = OrganizationID
+	f.OrganisationID = organisationIDFromTx(tx)
 	return
 }
 
@@ -57,6 +65,132 @@ func (Facility) TableName() string {
 	return common.FacilityTableName
 }
 
+// NearbyFacility is a facility found near a location together with its distance, in kilometres, from the location.
+// It is read from the facility table and is not a table of its own.
+type NearbyFacility struct {
+	Facility
+	DistanceKm float64 `gorm:"column:distance_km"`
+}
+
+// FacilityHistory maps the schema for the table that records the changes made to a facility's details.
+// Each changed field is recorded in its own row.
+type FacilityHistory struct {
+	Base
+
+	ID             *string   `gorm:"primaryKey;unique;column:id"`
+	FacilityID     string    `gorm:"column:facility_id;not null"`
+	Field          string    `gorm:"column:field;not null"`
+	OldValue       string    `gorm:"column:old_value"`
+	NewValue       string    `gorm:"column:new_value"`
+	ChangedBy      string    `gorm:"column:changed_by;not null"`
+	ChangedAt      time.Time `gorm:"column:changed_at;not null"`
+	OrganisationID string    `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before recording a change to a facility
+func (h *FacilityHistory) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	h.ID = &id
+	h.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (FacilityHistory) TableName() string {
+	return "common_facilityhistory"
+}
+
+// FacilityOpeningHours maps the schema for the table that stores the hours that a facility is open on a day of the week
+type FacilityOpeningHours struct {
+	Base
+
+	ID             *string         `gorm:"primaryKey;unique;column:id"`
+	FacilityID     string          `gorm:"column:facility_id;not null"`
+	DayOfWeek      enums.DayOfWeek `gorm:"column:day_of_week;not null"`
+	OpensAt        string          `gorm:"column:opens_at;type:time;not null"`
+	ClosesAt       string          `gorm:"column:closes_at;type:time;not null"`
+	OrganisationID string          `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before adding a facility's opening hours
+func (h *FacilityOpeningHours) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	h.ID = &id
+	h.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (FacilityOpeningHours) TableName() string {
+	return "common_facilityopeninghours"
+}
+
+// FacilityHoursException maps the schema for the table that stores the changes to a facility's opening hours on
+// specific dates. A facility has at most one exception on a date.
+type FacilityHoursException struct {
+	Base
+
+	ID             *string   `gorm:"primaryKey;unique;column:id"`
+	FacilityID     string    `gorm:"column:facility_id;not null;uniqueIndex:facility_hours_exception_date"`
+	Date           time.Time `gorm:"column:date;type:date;not null;uniqueIndex:facility_hours_exception_date"`
+	Closed         bool      `gorm:"column:closed;not null"`
+	OpensAt        *string   `gorm:"column:opens_at;type:time"`
+	ClosesAt       *string   `gorm:"column:closes_at;type:time"`
+	Reason         string    `gorm:"column:reason"`
+	OrganisationID string    `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before adding an exception to a facility's opening hours
+func (e *FacilityHoursException) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	e.ID = &id
+	e.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (FacilityHoursException) TableName() string {
+	return "common_facilityhoursexception"
+}
+
+// FacilityService maps the schema for the table that stores the catalogue of services that facilities can offer.
+// Each organisation has its own catalogue and the service codes are unique within it.
+type FacilityService struct {
+	Base
+
+	ID             *string `gorm:"primaryKey;unique;column:id"`
+	Name           string  `gorm:"column:name;not null"`
+	Code           string  `gorm:"column:code;not null;uniqueIndex:facility_service_code"`
+	Description    string  `gorm:"column:description"`
+	Active         bool    `gorm:"column:active;not null"`
+	OrganisationID string  `gorm:"column:organisation_id;uniqueIndex:facility_service_code"`
+}
+
+// BeforeCreate is a hook run before adding a service to the catalogue
+func (s *FacilityService) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	s.ID = &id
+	s.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (FacilityService) TableName() string {
+	return "common_facilityservice"
+}
+
+// FacilityServices maps the join table of the services that a facility offers
+type FacilityServices struct {
+	ID         int     `gorm:"primaryKey;column:id;autoincrement"`
+	FacilityID *string `gorm:"column:facility_id;not null"`
+	ServiceID  *string `gorm:"column:service_id;not null"`
+}
+
+// TableName references the table that we map data from
+func (FacilityServices) TableName() string {
+	return "common_facility_services"
+}
+
 // User represents the table data structure for a user
 type User struct {
 	// Base
@@ -80,8 +214,8 @@ type User struct {
 
 	Contacts Contact `gorm:"ForeignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;not null"` // TODO: validate, ensure
 
-	// // for the preferred language list, order matters
-	// Languages pq.StringArray `gorm:"type:text[];column:languages;not null"` // TODO: turn this into a slice of enums, start small (en, sw)
+	// for the preferred language list, order matters
+	Languages pq.StringArray `gorm:"type:text[];column:languages"`
 
 	PushTokens []string `gorm:"type:text[];column:push_tokens"`
 
@@ -126,7 +260,7 @@ type User struct {
 func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	u.UserID = &id
-	u.OrganisationID = OrganizationID
+	u.OrganisationID = organisationIDFromTx(tx)
 	salt, _ := extension.NewExternalMethodsImpl().EncryptPIN(ksuid.New().String(), nil)
 	bytePass := []byte(salt)
 	u.Password = string(bytePass[0:127])
@@ -174,7 +308,7 @@ type Contact struct {
 func (c *Contact) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	c.ContactID = &id
-	c.OrganisationID = OrganizationID
+	c.OrganisationID = organisationIDFromTx(tx)
 	return
 }
 
@@ -218,8 +352,9 @@ func (TermsOfService) TableName() string {
 	return "users_termsofservice"
 }
 
-// Organisation maps the organization table. This will be useful when running integration
-// tests since many models have an organization ID as a foreign key.
+// Organisation maps the organization table. Most models have an organization ID as a foreign key.
+//
+// The settings columns are nullable. The platform defaults are used for the settings that are not set.
 type Organisation struct {
 	Base
 
@@ -233,11 +368,19 @@ type Organisation struct {
 	PostalAddress    string  `gorm:"column:postal_address"`
 	PhysicalAddress  string  `gorm:"column:physical_address"`
 	DefaultCountry   string  `gorm:"column:default_country"`
+
+	PINExpiryDays       *int    `gorm:"column:pin_expiry_days"`
+	InvitePINExpiryDays *int    `gorm:"column:invite_pin_expiry_days"`
+	DiaryCadenceHours   *int    `gorm:"column:diary_cadence_hours"`
+	SMSSenderID         *string `gorm:"column:sms_sender_id"`
 }
 
 // BeforeCreate is a hook run before creating a new organisation
 func (t *Organisation) BeforeCreate(tx *gorm.DB) (err error) {
-	t.OrganisationID = &OrganizationID
+	if t.OrganisationID == nil {
+		id := uuid.New().String()
+		t.OrganisationID = &id
+	}
 	return
 }
 
@@ -266,7 +409,7 @@ type SecurityQuestion struct {
 func (s *SecurityQuestion) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	s.SecurityQuestionID = &id
-	s.OrganisationID = OrganizationID
+	s.OrganisationID = organisationIDFromTx(tx)
 	return
 }
 
@@ -315,7 +458,7 @@ type SecurityQuestionResponse struct {
 func (s *SecurityQuestionResponse) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	s.ResponseID = id
-	s.OrganisationID = OrganizationID
+	s.OrganisationID = organisationIDFromTx(tx)
 	s.Timestamp = time.Now()
 	// is_correct default to true since the user setting the security question responses will initially set
 	// them correctly
@@ -368,7 +511,7 @@ type Client struct {
 func (c *Client) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	c.ID = &id
-	c.OrganisationID = OrganizationID
+	c.OrganisationID = organisationIDFromTx(tx)
 	return
 }
 
@@ -377,6 +520,66 @@ func (Client) TableName() string {
 	return "clients_client"
 }
 
+// ClientTransfer maps the schema for the table that stores the requests to move a client to another facility.
+// The receiving facility accepts or declines the transfer through the service request raised with it.
+type ClientTransfer struct {
+	Base
+
+	ID               *string                    `gorm:"primaryKey;unique;column:id"`
+	ClientID         string                     `gorm:"column:client_id;not null"`
+	FromFacilityID   string                     `gorm:"column:from_facility_id;not null"`
+	ToFacilityID     string                     `gorm:"column:to_facility_id;not null"`
+	Reason           string                     `gorm:"column:reason;not null"`
+	Status           enums.ClientTransferStatus `gorm:"column:status;not null"`
+	RequestedByID    string                     `gorm:"column:requested_by_id;not null"`
+	RequestedAt      time.Time                  `gorm:"column:requested_at;not null"`
+	RespondedByID    *string                    `gorm:"column:responded_by_id"`
+	RespondedAt      *time.Time                 `gorm:"column:responded_at"`
+	ResponseNote     string                     `gorm:"column:response_note"`
+	ServiceRequestID *string                    `gorm:"column:service_request_id"`
+	OrganisationID   string                     `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before creating a client transfer
+func (c *ClientTransfer) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	c.ID = &id
+	c.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (ClientTransfer) TableName() string {
+	return "clients_clienttransfer"
+}
+
+// ClientFacility maps the schema for the table that records the periods a client was assigned to each facility.
+// The current facility's period has no end date.
+type ClientFacility struct {
+	Base
+
+	ID             *string    `gorm:"primaryKey;unique;column:id"`
+	ClientID       string     `gorm:"column:client_id;not null"`
+	FacilityID     string     `gorm:"column:facility_id;not null"`
+	StartDate      time.Time  `gorm:"column:start_date;not null"`
+	EndDate        *time.Time `gorm:"column:end_date"`
+	TransferID     *string    `gorm:"column:transfer_id"`
+	OrganisationID string     `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before recording a client's facility period
+func (c *ClientFacility) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	c.ID = &id
+	c.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (ClientFacility) TableName() string {
+	return "clients_clientfacility"
+}
+
 // ContentItemCategory maps the schema for the table that stores the content item category
 type ContentItemCategory struct {
 	ID     int    `gorm:"unique;column:id;autoincrement"`
@@ -402,7 +605,7 @@ type ContentAuthor struct {
 func (c *ContentAuthor) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	c.ContentAuthorID = &id
-	c.OrganisationID = OrganizationID
+	c.OrganisationID = organisationIDFromTx(tx)
 	return
 }
 
@@ -446,7 +649,7 @@ type ContentShare struct {
 func (c *ContentShare) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	c.ContentShareID = &id
-	c.OrganisationID = OrganizationID
+	c.OrganisationID = organisationIDFromTx(tx)
 	return
 }
 
@@ -469,7 +672,7 @@ type ContentBookmark struct {
 func (c *ContentBookmark) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	c.ContentBookmarkID = &id
-	c.OrganisationID = OrganizationID
+	c.OrganisationID = organisationIDFromTx(tx)
 	return
 }
 
@@ -480,24 +683,25 @@ func (ContentBookmark) TableName() string {
 
 // WagtailCorePage models the details of core wagtail fields
 type WagtailCorePage struct {
-	WagtailCorePageID     int    `gorm:"unique;column:id;autoincrement"`
-	Path                  string `gorm:"column:path"`
-	Depth                 int    `gorm:"column:depth"`
-	Numchild              int    `gorm:"column:numchild"`
-	Title                 string `gorm:"column:title"`
-	Slug                  string `gorm:"column:slug"`
-	Live                  bool   `gorm:"column:live"`
-	HasUnpublishedChanges bool   `gorm:"column:has_unpublished_changes"`
-	URLPath               string `gorm:"column:url_path"`
-	SEOTitle              string `gorm:"column:seo_title"`
-	ShowInMenus           bool   `gorm:"column:show_in_menus"`
-	SearchDescription     string `gorm:"column:search_description"`
-	Expired               bool   `gorm:"column:expired"`
-	ContentTypeID         int    `gorm:"column:content_type_id"` // default to 1 => wagtailcore page
-	Locked                bool   `gorm:"column:locked"`
-	DraftTitle            string `gorm:"column:draft_title"`
-	TranslationKey        string `gorm:"column:translation_key"`
-	LocaleID              int    `gorm:"column:locale_id"` // default to 1 => en
+	WagtailCorePageID     int        `gorm:"unique;column:id;autoincrement"`
+	Path                  string     `gorm:"column:path"`
+	Depth                 int        `gorm:"column:depth"`
+	Numchild              int        `gorm:"column:numchild"`
+	Title                 string     `gorm:"column:title"`
+	Slug                  string     `gorm:"column:slug"`
+	Live                  bool       `gorm:"column:live"`
+	HasUnpublishedChanges bool       `gorm:"column:has_unpublished_changes"`
+	URLPath               string     `gorm:"column:url_path"`
+	SEOTitle              string     `gorm:"column:seo_title"`
+	ShowInMenus           bool       `gorm:"column:show_in_menus"`
+	SearchDescription     string     `gorm:"column:search_description"`
+	Expired               bool       `gorm:"column:expired"`
+	ContentTypeID         int        `gorm:"column:content_type_id"` // default to 1 => wagtailcore page
+	Locked                bool       `gorm:"column:locked"`
+	DraftTitle            string     `gorm:"column:draft_title"`
+	TranslationKey        string     `gorm:"column:translation_key"`
+	LocaleID              int        `gorm:"column:locale_id"` // default to 1 => en
+	FirstPublishedAt      *time.Time `gorm:"column:first_published_at"`
 }
 
 // TableName references the table that we map data from
@@ -519,7 +723,7 @@ type ContentLike struct {
 func (c *ContentLike) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	c.ContentLikeID = id
-	c.OrganisationID = OrganizationID
+	c.OrganisationID = organisationIDFromTx(tx)
 	return
 }
 
@@ -542,7 +746,7 @@ type ContentView struct {
 func (c *ContentView) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	c.ContentViewID = &id
-	c.OrganisationID = OrganizationID
+	c.OrganisationID = organisationIDFromTx(tx)
 	return
 }
 
@@ -551,6 +755,157 @@ func (ContentView) TableName() string {
 	return "content_contentview"
 }
 
+// ContentReadEvent records how long a user spent reading a content item each time they read it
+type ContentReadEvent struct {
+	Base
+	ContentReadEventID string `gorm:"column:id"`
+	Active             bool   `gorm:"column:active"`
+	ContentID          int    `gorm:"column:content_item_id"`
+	UserID             string `gorm:"column:user_id"`
+	DurationSeconds    int    `gorm:"column:duration_seconds"`
+	OrganisationID     string `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before recording a read
+func (c *ContentReadEvent) BeforeCreate(tx *gorm.DB) (err error) {
+	c.ContentReadEventID = uuid.New().String()
+	c.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (ContentReadEvent) TableName() string {
+	return "content_contentreadevent"
+}
+
+// ContentProgress is how far a user has got with a content item. There is one row per user and content item.
+type ContentProgress struct {
+	Base
+	ID              *string    `gorm:"primaryKey;unique;column:id"`
+	Active          bool       `gorm:"column:active"`
+	ContentID       int        `gorm:"column:content_item_id"`
+	UserID          string     `gorm:"column:user_id"`
+	SecondsSpent    int        `gorm:"column:seconds_spent"`
+	PercentScrolled float64    `gorm:"column:percent_scrolled"`
+	CompletedAt     *time.Time `gorm:"column:completed_at"`
+	OrganisationID  string     `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before saving a user's first progress with a content item
+func (c *ContentProgress) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	c.ID = &id
+	c.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (ContentProgress) TableName() string {
+	return "content_contentprogress"
+}
+
+// ContentCompletionEvent is recorded once, when a user completes a content item. Programs that reward clients for
+// reading e.g with points pick up the completions from this table.
+type ContentCompletionEvent struct {
+	Base
+	ID             *string `gorm:"primaryKey;unique;column:id"`
+	ContentID      int     `gorm:"column:content_item_id"`
+	UserID         string  `gorm:"column:user_id"`
+	OrganisationID string  `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before recording a completion
+func (c *ContentCompletionEvent) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	c.ID = &id
+	c.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (ContentCompletionEvent) TableName() string {
+	return "content_contentcompletionevent"
+}
+
+// ContentShareLink is a link to a content item that a user shared on a channel. Every share gets its own link so
+// that opening it can be attributed to the user who shared it
+type ContentShareLink struct {
+	Base
+	ID             *string                   `gorm:"primaryKey;unique;column:id"`
+	ContentID      int                       `gorm:"column:content_item_id"`
+	UserID         string                    `gorm:"column:user_id"`
+	Channel        enums.ContentShareChannel `gorm:"column:channel"`
+	OrganisationID string                    `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before creating a share link
+func (c *ContentShareLink) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	c.ID = &id
+	c.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (ContentShareLink) TableName() string {
+	return "content_contentsharelink"
+}
+
+// ContentShareOpen records that a shared link was opened. OpenedByID is empty when the link was opened on the
+// public web view by someone who isn't logged in
+type ContentShareOpen struct {
+	Base
+	ID             *string `gorm:"primaryKey;unique;column:id"`
+	ShareLinkID    string  `gorm:"column:share_link_id"`
+	OpenedByID     *string `gorm:"column:opened_by_id"`
+	OrganisationID string  `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before recording that a shared link was opened. The organisation is the one the
+// link was shared in since the person opening it may not be logged in
+func (c *ContentShareOpen) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	c.ID = &id
+	if c.OrganisationID == "" {
+		c.OrganisationID = organisationIDFromTx(tx)
+	}
+	return
+}
+
+// TableName references the table that we map data from
+func (ContentShareOpen) TableName() string {
+	return "content_contentshareopen"
+}
+
+// ContentComment is a comment on a content item. Replies to a comment point to it through the parent ID
+type ContentComment struct {
+	Base
+	ID             *string                    `gorm:"primaryKey;unique;column:id"`
+	Active         bool                       `gorm:"column:active"`
+	ContentID      int                        `gorm:"column:content_item_id"`
+	UserID         string                     `gorm:"column:user_id"`
+	ParentID       *string                    `gorm:"column:parent_id"`
+	Body           string                     `gorm:"column:body"`
+	Status         enums.ContentCommentStatus `gorm:"column:status"`
+	EditedAt       *time.Time                 `gorm:"column:edited_at"`
+	ModeratedByID  *string                    `gorm:"column:moderated_by_id"`
+	ModeratedAt    *time.Time                 `gorm:"column:moderated_at"`
+	OrganisationID string                     `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before creating a content comment
+func (c *ContentComment) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	c.ID = &id
+	c.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (ContentComment) TableName() string {
+	return "content_contentcomment"
+}
+
 // WagtailImages models the details of core wagtail image table
 type WagtailImages struct {
 	ID               int       `gorm:"primaryKey;column:id;autoincrement"`
@@ -592,7 +947,7 @@ type ClientHealthDiaryEntry struct {
 func (c *ClientHealthDiaryEntry) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	c.ClientHealthDiaryEntryID = &id
-	c.OrganisationID = OrganizationID
+	c.OrganisationID = organisationIDFromTx(tx)
 	return
 }
 
@@ -614,6 +969,7 @@ type ClientServiceRequest struct {
 	InProgressAt time.Time `gorm:"column:in_progress_at"`
 	ResolvedAt   time.Time `gorm:"column:resolved_at"`
 	ClientID     string    `gorm:"column:client_id"`
+	FacilityID   *string   `gorm:"column:facility_id"`
 	// InProgressByID string    `gorm:"column:in_progress_by_id"`
 	OrganisationID string `gorm:"column:organisation_id"`
 	// ResolvedByID   string    `gorm:"column:resolved_by_id"`
@@ -623,7 +979,7 @@ type ClientServiceRequest struct {
 func (c *ClientServiceRequest) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	c.ID = &id
-	c.OrganisationID = OrganizationID
+	c.OrganisationID = organisationIDFromTx(tx)
 	return
 }
 
@@ -646,7 +1002,7 @@ type ClientHealthDiaryQuote struct {
 func (c *ClientHealthDiaryQuote) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	c.ClientHealthDiaryQuoteID = &id
-	c.OrganisationID = OrganizationID
+	c.OrganisationID = organisationIDFromTx(tx)
 	return
 }
 
@@ -671,7 +1027,7 @@ type FAQ struct {
 func (c *FAQ) BeforeCreate(tx *gorm.DB) (err error) {
 	id := uuid.New().String()
 	c.FAQID = &id
-	c.OrganisationID = OrganizationID
+	c.OrganisationID = organisationIDFromTx(tx)
 	return
 }
 
@@ -690,3 +1046,186 @@ type ContentContentItemCategories struct {
 func (ContentContentItemCategories) TableName() string {
 	return "content_contentitem_categories"
 }
+
+// ContentItemTag links a content item to one of its tags
+type ContentItemTag struct {
+	ID            int `gorm:"primaryKey;column:id;autoincrement"`
+	ContentItemID int `gorm:"column:content_object_id"`
+	TagID         int `gorm:"column:tag_id"`
+}
+
+// TableName references the table that we map data from
+func (ContentItemTag) TableName() string {
+	return "content_contentitemtag"
+}
+
+// Tag maps the table of the tags that are used to label content in the CMS
+type Tag struct {
+	ID   int    `gorm:"primaryKey;column:id;autoincrement"`
+	Name string `gorm:"column:name"`
+	Slug string `gorm:"column:slug"`
+}
+
+// TableName references the table that we map data from
+func (Tag) TableName() string {
+	return "taggit_tag"
+}
+
+// Invitation maps the schema for the table that tracks the invites sent to users
+type Invitation struct {
+	Base
+
+	ID             *string                `gorm:"primaryKey;unique;column:id"`
+	UserID         string                 `gorm:"column:user_id;not null"`
+	PhoneNumber    string                 `gorm:"column:phone_number;not null"`
+	Flavour        feedlib.Flavour        `gorm:"column:flavour;not null"`
+	Status         enums.InvitationStatus `gorm:"column:status;not null"`
+	IssuedAt       time.Time              `gorm:"column:issued_at;not null"`
+	DeliveredAt    *time.Time             `gorm:"column:delivered_at"`
+	AcceptedAt     *time.Time             `gorm:"column:accepted_at"`
+	ExpiresAt      time.Time              `gorm:"column:expires_at;not null"`
+	OrganisationID string                 `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before creating an invitation
+func (i *Invitation) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	i.ID = &id
+	i.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (Invitation) TableName() string {
+	return "users_invitation"
+}
+
+// BulkInviteJob is the progress of inviting the users uploaded in a CSV file. Jobs are kept in the database so that
+// they can be polled from any instance and survive a restart.
+type BulkInviteJob struct {
+	Base
+
+	ID             *string                   `gorm:"primaryKey;unique;column:id"`
+	Status         enums.BulkInviteJobStatus `gorm:"column:status;not null"`
+	Flavour        feedlib.Flavour           `gorm:"column:flavour;not null"`
+	Total          int                       `gorm:"column:total;not null"`
+	Succeeded      int                       `gorm:"column:succeeded;not null"`
+	Failed         int                       `gorm:"column:failed;not null"`
+	CreatedByID    string                    `gorm:"column:created_by_id;not null"`
+	CompletedAt    *time.Time                `gorm:"column:completed_at"`
+	OrganisationID string                    `gorm:"column:organisation_id"`
+
+	Rows []*BulkInviteRow `gorm:"foreignKey:JobID"`
+}
+
+// BeforeCreate is a hook run before creating a bulk invite job
+func (b *BulkInviteJob) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	b.ID = &id
+	b.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (BulkInviteJob) TableName() string {
+	return "users_bulkinvitejob"
+}
+
+// BulkInviteRow is a single user to be invited in a bulk invite job and the outcome of the invite
+type BulkInviteRow struct {
+	Base
+
+	ID             *string                   `gorm:"primaryKey;unique;column:id"`
+	JobID          *string                   `gorm:"column:job_id;not null"`
+	RowNumber      int                       `gorm:"column:row_number;not null"`
+	UserID         string                    `gorm:"column:user_id"`
+	PhoneNumber    string                    `gorm:"column:phone_number"`
+	Status         enums.BulkInviteRowStatus `gorm:"column:status;not null"`
+	Error          string                    `gorm:"column:error"`
+	OrganisationID string                    `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before creating a bulk invite row
+func (b *BulkInviteRow) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	b.ID = &id
+	b.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (BulkInviteRow) TableName() string {
+	return "users_bulkinviterow"
+}
+
+// StaffProfile contains all the information a staff should have about themselves
+type StaffProfile struct {
+	Base
+
+	ID *string `gorm:"primaryKey;unique;column:id"`
+
+	UserProfile User `gorm:"ForeignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;not null"`
+
+	UserID *string `gorm:"column:user_id;not null"`
+
+	Active bool `gorm:"column:active"`
+
+	StaffNumber string `gorm:"unique;column:staff_number;not null"`
+
+	Cadre enums.StaffCadre `gorm:"column:cadre;not null"`
+
+	DefaultFacilityID string `gorm:"column:default_facility_id;not null"`
+
+	OrganisationID string `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before creating a staff profile
+func (s *StaffProfile) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	s.ID = &id
+	s.OrganisationID = organisationIDFromTx(tx)
+	return
+}
+
+// TableName references the table that we map data from
+func (StaffProfile) TableName() string {
+	return "staff_staff"
+}
+
+// StaffFacilities maps the join table of the facilities that a staff member is allowed to access
+type StaffFacilities struct {
+	ID         int     `gorm:"primaryKey;column:id;autoincrement"`
+	StaffID    *string `gorm:"column:staff_id;not null"`
+	FacilityID *string `gorm:"column:facility_id;not null"`
+}
+
+// TableName references the table that we map data from
+func (StaffFacilities) TableName() string {
+	return "staff_staff_facilities"
+}
+
+// UserRole maps the schema for the table that stores the roles assigned to users
+type UserRole struct {
+	Base
+
+	ID             *string            `gorm:"primaryKey;unique;column:id"`
+	UserID         string             `gorm:"column:user_id;not null"`
+	Role           enums.UserRoleType `gorm:"column:role;not null"`
+	OrganisationID string             `gorm:"column:organisation_id"`
+}
+
+// BeforeCreate is a hook run before assigning a role to a user. Roles that are not assigned to an organisation
+// belong to the organisation of the logged in user.
+func (r *UserRole) BeforeCreate(tx *gorm.DB) (err error) {
+	id := uuid.New().String()
+	r.ID = &id
+	if r.OrganisationID == "" {
+		r.OrganisationID = organisationIDFromTx(tx)
+	}
+	return
+}
+
+// TableName references the table that we map data from
+func (UserRole) TableName() string {
+	return "users_userrole"
+}
@@ -0,0 +1,119 @@
+package gorm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// organisationColumn is the column that ties a row to the organisation that owns it
+const organisationColumn = "organisation_id"
+
+// errNoOrganisation is the error that statements fail with when their context neither carries the identity of
+// a logged in user nor was marked as spanning all the organisations
+var errNoOrganisation = errors.New("the statement is not tied to an organisation: the context has no identity and was not marked for all organisations")
+
+// organisationFromContext returns the organisation of the user making the request. It is false for requests
+// that are not tied to a logged in user e.g logging in or verifying a phone number.
+func organisationFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	identity, err := helpers.GetIdentityFromContext(ctx)
+	if err != nil || identity.OrganisationID == "" {
+		return "", false
+	}
+	return identity.OrganisationID, true
+}
+
+// organisationIDFromTx returns the organisation that a row created in the transaction belongs to.
+// Rows created for all organisations, e.g by the command line tools, belong to the default organisation.
+func organisationIDFromTx(tx *gorm.DB) string {
+	if organisationID, ok := organisationFromContext(tx.Statement.Context); ok {
+		return organisationID
+	}
+	return OrganizationID
+}
+
+// organisationArg is the named argument that raw queries compare organisation columns against. It is empty
+// for contexts marked for all organisations. Statements whose context has neither are failed before they run.
+func organisationArg(ctx context.Context) sql.NamedArg {
+	organisationID, _ := organisationFromContext(ctx)
+	return sql.Named("organisation", organisationID)
+}
+
+// inOrganisation is the raw query condition that restricts an organisation column to the logged in user's
+// organisation. It refers to the organisationArg argument and, like the callbacks, does not scope contexts
+// marked for all organisations.
+func inOrganisation(column string) string {
+	return fmt.Sprintf("(@organisation = '' OR %s = @organisation)", column)
+}
+
+// requireOrganisation fails a statement whose context neither carries a logged in user's organisation nor
+// was marked for all organisations, so that a request whose identity was lost can never read or change the
+// data of every organisation. It runs for every statement, including raw ones.
+func requireOrganisation(tx *gorm.DB) {
+	if _, ok := organisationFromContext(tx.Statement.Context); ok {
+		return
+	}
+	if tx.Statement.Context != nil && helpers.IsForAllOrganisations(tx.Statement.Context) {
+		return
+	}
+	_ = tx.AddError(errNoOrganisation)
+}
+
+// scopeToOrganisation restricts a statement on a table with an organisation column to the rows of the
+// logged in user's organisation. Statements whose context has no organisation are failed instead unless the
+// context was marked for all organisations.
+//
+// Only statements on a model are scoped. Raw queries, queries started with Table and the tables that a
+// statement joins to have no schema to look the organisation column up in, so they must restrict every
+// tenant table they read with inOrganisation.
+func scopeToOrganisation(tx *gorm.DB) {
+	requireOrganisation(tx)
+	if tx.Error != nil || tx.Statement.Schema == nil {
+		return
+	}
+	field := tx.Statement.Schema.LookUpField(organisationColumn)
+	if field == nil || field.DBName != organisationColumn {
+		return
+	}
+	organisationID, ok := organisationFromContext(tx.Statement.Context)
+	if !ok {
+		return
+	}
+	tx.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: organisationColumn}, Value: organisationID},
+	}})
+}
+
+// registerOrganisationCallbacks scopes every query, update and delete to the logged in user's organisation
+// so that organisations sharing a deployment cannot read or change each other's data. Creates and raw
+// statements are not scoped but still need an organisation or a context marked for all organisations.
+func registerOrganisationCallbacks(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("mycarehub:organisation_create", requireOrganisation); err != nil {
+		return fmt.Errorf("failed to register organisation create callback: %v", err)
+	}
+	if err := callbacks.Raw().Before("gorm:raw").Register("mycarehub:organisation_raw", requireOrganisation); err != nil {
+		return fmt.Errorf("failed to register organisation raw callback: %v", err)
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("mycarehub:organisation_query", scopeToOrganisation); err != nil {
+		return fmt.Errorf("failed to register organisation query callback: %v", err)
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("mycarehub:organisation_row", scopeToOrganisation); err != nil {
+		return fmt.Errorf("failed to register organisation row callback: %v", err)
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("mycarehub:organisation_update", scopeToOrganisation); err != nil {
+		return fmt.Errorf("failed to register organisation update callback: %v", err)
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("mycarehub:organisation_delete", scopeToOrganisation); err != nil {
+		return fmt.Errorf("failed to register organisation delete callback: %v", err)
+	}
+	return nil
+}
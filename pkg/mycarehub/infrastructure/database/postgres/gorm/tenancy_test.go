@@ -0,0 +1,124 @@
+package gorm_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
+	"github.com/segmentio/ksuid"
+)
+
+func TestPGInstance_OrganisationScope(t *testing.T) {
+	ownOrganisationCtx := helpers.ContextWithIdentity(context.Background(), &domain.Identity{
+		UserID:         userID,
+		OrganisationID: orgID,
+	})
+	otherOrganisationCtx := helpers.ContextWithIdentity(context.Background(), &domain.Identity{
+		UserID:         uuid.New().String(),
+		OrganisationID: uuid.New().String(),
+	})
+
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case - user in the logged in user's organisation",
+			args: args{
+				ctx:    ownOrganisationCtx,
+				userID: userID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - context for all organisations is not scoped",
+			args: args{
+				ctx:    helpers.ContextForAllOrganisations(context.Background()),
+				userID: userID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - context without a logged in user",
+			args: args{
+				ctx:    context.Background(),
+				userID: userID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - user in another organisation",
+			args: args{
+				ctx:    otherOrganisationCtx,
+				userID: userID,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.GetUserProfileByUserID(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetUserProfileByUserID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_OrganisationStamp(t *testing.T) {
+	ctx := helpers.ContextWithIdentity(context.Background(), &domain.Identity{
+		UserID:         userID,
+		OrganisationID: orgID,
+	})
+
+	facility := &gorm.Facility{
+		Name:        ksuid.New().String(),
+		Code:        rand.Intn(1000000),
+		Active:      true,
+		County:      "Nairobi",
+		Description: gofakeit.HipsterSentence(15),
+	}
+
+	got, err := testingDB.GetOrCreateFacility(ctx, facility)
+	if err != nil {
+		t.Errorf("PGInstance.GetOrCreateFacility() error = %v", err)
+		return
+	}
+	if got.OrganisationID != orgID {
+		t.Errorf("expected the facility to belong to organisation %v, got %v", orgID, got.OrganisationID)
+	}
+
+	if _, err := testingDB.DeleteFacility(ctx, got.Code); err != nil {
+		t.Errorf("failed to clean up facility: %v", err)
+	}
+}
+
+func TestPGInstance_OrganisationRequired(t *testing.T) {
+	ctx := context.Background()
+
+	// statements on tables without an organisation column and creates are not scoped but still need an organisation
+	if _, err := testingDB.ListContentCategories(ctx); err == nil {
+		t.Errorf("expected a query without an organisation to fail")
+	}
+
+	facility := &gorm.Facility{
+		Name:        ksuid.New().String(),
+		Code:        rand.Intn(1000000),
+		Active:      true,
+		County:      "Nairobi",
+		Description: gofakeit.HipsterSentence(15),
+	}
+	if _, err := testingDB.GetOrCreateFacility(ctx, facility); err == nil {
+		t.Errorf("expected a create without an organisation to fail")
+	}
+}
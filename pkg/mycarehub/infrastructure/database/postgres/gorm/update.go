@@ -2,18 +2,30 @@ package gorm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Update represents all `update` operations to the database
 type Update interface {
 	InactivateFacility(ctx context.Context, mflCode *int) (bool, error)
 	ReactivateFacility(ctx context.Context, mflCode *int) (bool, error)
+	UpdateFacility(ctx context.Context, mflCode int, input *dto.FacilityUpdateInput, changedBy string) (*Facility, error)
+	UpsertFacilities(ctx context.Context, facilities []*Facility, changedBy string, dryRun bool) ([]*domain.FacilityImportRow, error)
+	SetFacilityOpeningHours(ctx context.Context, mflCode int, openingHours []*FacilityOpeningHours) error
+	SetFacilityHoursException(ctx context.Context, mflCode int, exception *FacilityHoursException) error
+	SetFacilityServices(ctx context.Context, mflCode int, serviceCodes []string) error
 	AcceptTerms(ctx context.Context, userID *string, termsID *int) (bool, error)
 	UpdateUserFailedLoginCount(ctx context.Context, userID string, failedLoginAttempts int) error
 	UpdateUserLastFailedLoginTime(ctx context.Context, userID string) error
@@ -23,120 +35,59 @@ type Update interface {
 	UpdateUserPinChangeRequiredStatus(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error)
 	InvalidatePIN(ctx context.Context, userID string) (bool, error)
 	UpdateIsCorrectSecurityQuestionResponse(ctx context.Context, userID string, isCorrectSecurityQuestionResponse bool) (bool, error)
-	ShareContent(ctx context.Context, input dto.ShareContentInput) (bool, error)
+	ShareContent(ctx context.Context, input dto.ShareContentInput) (*ContentShareLink, error)
+	RecordContentShareOpen(ctx context.Context, shareLinkID string, openedByID *string) (*ContentShareLink, error)
+	CreateContentComment(ctx context.Context, comment *ContentComment) (*ContentCommentDetails, error)
+	EditContentComment(ctx context.Context, commentID string, body string, status enums.ContentCommentStatus) (*ContentCommentDetails, error)
+	DeleteContentComment(ctx context.Context, commentID string) (bool, error)
+	ModerateContentComment(ctx context.Context, commentID string, status enums.ContentCommentStatus, moderatorID string) (*ContentCommentDetails, error)
 	BookmarkContent(ctx context.Context, userID string, contentID int) (bool, error)
 	UnBookmarkContent(ctx context.Context, userID string, contentID int) (bool, error)
 	LikeContent(context context.Context, userID string, contentID int) (bool, error)
 	UnlikeContent(context context.Context, userID string, contentID int) (bool, error)
 	ViewContent(ctx context.Context, userID string, contentID int) (bool, error)
+	RecordContentReadDuration(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error)
+	RecordContentProgress(ctx context.Context, report *ContentProgress, threshold domain.ContentCompletionThreshold) (*ContentProgress, bool, error)
+	UpdateUserLanguages(ctx context.Context, userID string, languages []string) (bool, error)
+	UpdateInvitationStatus(ctx context.Context, invitationID string, status enums.InvitationStatus) (bool, error)
+	CloseUserInvitations(ctx context.Context, userID string, flavour feedlib.Flavour, status enums.InvitationStatus) (bool, error)
+	ExpireOverdueInvitations(ctx context.Context) (bool, error)
+	UpdateStaffProfile(ctx context.Context, staffID string, input *dto.StaffProfileUpdateInput) (bool, error)
+	AddStaffFacilities(ctx context.Context, staffID string, facilityIDs []string) (bool, error)
+	RemoveStaffFacilities(ctx context.Context, staffID string, facilityIDs []string) (bool, error)
+	UpdateOrganisation(ctx context.Context, organisationID string, input *dto.OrganisationUpdateInput) (bool, error)
+	DeactivateOrganisation(ctx context.Context, organisationID string) (bool, error)
+	RespondToClientTransfer(ctx context.Context, transferID string, status enums.ClientTransferStatus, respondedByID string, note string) (*ClientTransfer, error)
+	ReconcileContentEngagement(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error)
+	UpdateBulkInviteJobStatus(ctx context.Context, jobID string, status enums.BulkInviteJobStatus) (bool, error)
+	FailInterruptedBulkInviteJobs(ctx context.Context, staleAfter time.Duration) (int, error)
+	RecordBulkInviteRowOutcome(ctx context.Context, jobID string, rowID string, status enums.BulkInviteRowStatus, errorMessage string) (bool, error)
 }
 
-// LikeContent perfoms the actual database operation to update content like. The operation
-// is carried out in a transaction.
-func (db *PGInstance) LikeContent(context context.Context, userID string, contentID int) (bool, error) {
+// LikeContent records that the user likes the content item and increments the item's like count in one
+// transaction. Liking an item that the user already likes changes nothing.
+func (db *PGInstance) LikeContent(ctx context.Context, userID string, contentID int) (bool, error) {
 	if userID == "" || contentID == 0 {
 		return false, fmt.Errorf("userID or contentID cannot be empty")
 	}
 
-	contentLike := &ContentLike{
-		UserID:    userID,
-		ContentID: contentID,
-	}
-
-	tx := db.DB.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-	if err := tx.Error; err != nil {
-		return false, fmt.Errorf("failed to initialize like transaction")
-	}
-
-	var contentItem ContentItem
-	if err := tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: contentID}).First(&contentItem).Error; err != nil {
-		return false, fmt.Errorf("unable to get content item: %v", err)
-	}
-
-	var likeCount = contentItem.LikeCount
-	err := tx.Model(&ContentLike{}).Create(&ContentLike{ContentID: contentID, UserID: userID, Active: true}).Error
-	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
-			return true, nil
-		}
-		return false, fmt.Errorf("unable to update content likes: %v", err)
-	}
-
-	err = tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: contentID}).
-		Updates(map[string]interface{}{
-			"like_count": likeCount + 1,
-		}).Error
-	if err != nil {
-		return false, fmt.Errorf("unable to update like count in content item table: %v", err)
-	}
-
-	err = tx.Where(contentLike).FirstOrCreate(contentLike).Error
+	err := db.addContentEngagement(ctx, contentLikes, contentID, userID, &ContentLike{ContentID: contentID, UserID: userID, Active: true})
 	if err != nil {
-		tx.Rollback()
-		return false, fmt.Errorf("failed to save or get like content: %v", err)
-	}
-
-	if err := tx.Commit().Error; err != nil {
-		tx.Rollback()
-		return false, fmt.Errorf("transaction commit to like count failed: %v", err)
+		return false, fmt.Errorf("unable to like content: %v", err)
 	}
-
 	return true, nil
 }
 
-// UnlikeContent perfoms the actual database operation to update content unlike. The operation
-// is carried out in a transaction.
-func (db *PGInstance) UnlikeContent(context context.Context, userID string, contentID int) (bool, error) {
+// UnlikeContent removes the user's like from the content item and decrements the item's like count in one
+// transaction. Unliking an item that the user doesn't like changes nothing.
+func (db *PGInstance) UnlikeContent(ctx context.Context, userID string, contentID int) (bool, error) {
 	if userID == "" || contentID == 0 {
 		return false, fmt.Errorf("userID or contentID cannot be empty")
 	}
 
-	tx := db.DB.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-	if err := tx.Error; err != nil {
-		return false, fmt.Errorf("failed to initialize like transaction")
-	}
-
-	var contentLike ContentLike
-	if err := tx.Model(&ContentLike{}).Where(&ContentLike{UserID: userID, ContentID: contentID}).First(&contentLike).Error; err != nil {
-		if strings.Contains(err.Error(), "record not found") {
-			return true, nil
-		}
-		return false, fmt.Errorf("unable to get content like for the specifies user: %v", err)
-	}
-
-	var contentItem ContentItem
-	if err := tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: contentID}).First(&contentItem).Error; err != nil {
-		return false, fmt.Errorf("unable to get content item: %v", err)
-	}
-
-	err := tx.Where("user_id = ?", userID, "content_item_id = ?", contentID).Delete(&ContentLike{}).Error
-	if err != nil {
-		return false, fmt.Errorf("unable to delete content likes: %v", err)
-	}
-
-	err = tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: contentID}).
-		Updates(map[string]interface{}{
-			"like_count": contentItem.LikeCount - 1,
-		}).Error
-	if err != nil {
-		return false, fmt.Errorf("unable to update like count in content item table: %v", err)
-	}
-
-	if err := tx.Commit().Error; err != nil {
-		tx.Rollback()
-		return false, fmt.Errorf("transaction commit to like count failed: %v", err)
+	if err := db.removeContentEngagement(ctx, contentLikes, contentID, userID); err != nil {
+		return false, fmt.Errorf("unable to unlike content: %v", err)
 	}
-
 	return true, nil
 }
 
@@ -146,7 +97,7 @@ func (db *PGInstance) ReactivateFacility(ctx context.Context, mflCode *int) (boo
 		return false, fmt.Errorf("mflCode cannot be empty")
 	}
 
-	err := db.DB.Model(&Facility{}).Where(&Facility{Code: *mflCode, Active: false}).
+	err := db.DB.WithContext(ctx).Model(&Facility{}).Where(&Facility{Code: *mflCode, Active: false}).
 		Updates(&Facility{Active: true}).Error
 	if err != nil {
 		return false, err
@@ -161,7 +112,7 @@ func (db *PGInstance) InactivateFacility(ctx context.Context, mflCode *int) (boo
 		return false, fmt.Errorf("mflCode cannot be empty")
 	}
 
-	err := db.DB.Model(&Facility{}).Where(&Facility{Code: *mflCode, Active: true}).
+	err := db.DB.WithContext(ctx).Model(&Facility{}).Where(&Facility{Code: *mflCode, Active: true}).
 		Updates(&Facility{Active: false}).Error
 	if err != nil {
 		return false, err
@@ -176,7 +127,7 @@ func (db *PGInstance) AcceptTerms(ctx context.Context, userID *string, termsID *
 		return false, fmt.Errorf("userID or termsID cannot be nil")
 	}
 
-	if err := db.DB.Model(&User{}).Where(&User{UserID: userID}).
+	if err := db.DB.WithContext(ctx).Model(&User{}).Where(&User{UserID: userID}).
 		Updates(&User{TermsAccepted: true, AcceptedTermsID: termsID}).Error; err != nil {
 		return false, fmt.Errorf("an error occurred while updating the user: %v", err)
 	}
@@ -187,7 +138,7 @@ func (db *PGInstance) AcceptTerms(ctx context.Context, userID *string, termsID *
 // UpdateUserFailedLoginCount updates the user's failed login count field in an event where a user fails to
 // log into the app
 func (db *PGInstance) UpdateUserFailedLoginCount(ctx context.Context, userID string, failedLoginAttempts int) error {
-	err := db.DB.Model(&User{}).Where(&User{UserID: &userID}).Updates(map[string]interface{}{
+	err := db.DB.WithContext(ctx).Model(&User{}).Where(&User{UserID: &userID}).Updates(map[string]interface{}{
 		"failed_login_count": failedLoginAttempts,
 	}).Error
 	if err != nil {
@@ -199,7 +150,7 @@ func (db *PGInstance) UpdateUserFailedLoginCount(ctx context.Context, userID str
 // UpdateUserLastFailedLoginTime updates the user's last failed login time
 func (db *PGInstance) UpdateUserLastFailedLoginTime(ctx context.Context, userID string) error {
 	currentTime := time.Now()
-	err := db.DB.Model(&User{}).Where(&User{UserID: &userID}).Updates(&User{LastFailedLogin: &currentTime}).Error
+	err := db.DB.WithContext(ctx).Model(&User{}).Where(&User{UserID: &userID}).Updates(&User{LastFailedLogin: &currentTime}).Error
 	if err != nil {
 		return err
 	}
@@ -209,7 +160,7 @@ func (db *PGInstance) UpdateUserLastFailedLoginTime(ctx context.Context, userID
 // UpdateUserNextAllowedLoginTime updates the user's next allowed login time. This field is used to check whether we can
 // allow a user to log in immediately or wait for some time before retrying the login process.
 func (db *PGInstance) UpdateUserNextAllowedLoginTime(ctx context.Context, userID string, nextAllowedLoginTime time.Time) error {
-	err := db.DB.Model(&User{}).Where(&User{UserID: &userID}).Updates(&User{NextAllowedLogin: &nextAllowedLoginTime}).Error
+	err := db.DB.WithContext(ctx).Model(&User{}).Where(&User{UserID: &userID}).Updates(&User{NextAllowedLogin: &nextAllowedLoginTime}).Error
 	if err != nil {
 		return err
 	}
@@ -220,7 +171,7 @@ func (db *PGInstance) UpdateUserNextAllowedLoginTime(ctx context.Context, userID
 // successfully logs into the app
 func (db *PGInstance) UpdateUserLastSuccessfulLoginTime(ctx context.Context, userID string) error {
 	currentTime := time.Now()
-	err := db.DB.Model(&User{}).Where(&User{UserID: &userID}).Updates(&User{LastSuccessfulLogin: &currentTime}).Error
+	err := db.DB.WithContext(ctx).Model(&User{}).Where(&User{UserID: &userID}).Updates(&User{LastSuccessfulLogin: &currentTime}).Error
 	if err != nil {
 		return err
 	}
@@ -232,7 +183,7 @@ func (db *PGInstance) SetNickName(ctx context.Context, userID *string, nickname
 	if userID == nil || nickname == nil {
 		return false, fmt.Errorf("userID or nickname cannot be nil")
 	}
-	err := db.DB.Model(&User{}).Where(&User{UserID: userID}).Updates(&User{Username: *nickname}).Error
+	err := db.DB.WithContext(ctx).Model(&User{}).Where(&User{UserID: userID}).Updates(&User{Username: *nickname}).Error
 	if err != nil {
 		return false, fmt.Errorf("failed to set nickname")
 	}
@@ -240,10 +191,25 @@ func (db *PGInstance) SetNickName(ctx context.Context, userID *string, nickname
 	return true, nil
 }
 
+// UpdateUserLanguages replaces the user's ordered list of preferred languages
+func (db *PGInstance) UpdateUserLanguages(ctx context.Context, userID string, languages []string) (bool, error) {
+	if userID == "" {
+		return false, fmt.Errorf("userID cannot be empty")
+	}
+	err := db.DB.WithContext(ctx).Model(&User{}).Where(&User{UserID: &userID}).Updates(map[string]interface{}{
+		"languages": pq.StringArray(languages),
+	}).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to update user languages: %v", err)
+	}
+
+	return true, nil
+}
+
 // UpdateUserPinChangeRequiredStatus updates the user's pin change required from true to false. It'll be used to
 // determine the onboarding journey for a user.
 func (db *PGInstance) UpdateUserPinChangeRequiredStatus(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error) {
-	err := db.DB.Model(&User{}).Where(&User{UserID: &userID, Flavour: flavour}).Updates(map[string]interface{}{
+	err := db.DB.WithContext(ctx).Model(&User{}).Where(&User{UserID: &userID, Flavour: flavour}).Updates(map[string]interface{}{
 		"pin_change_required":        false,
 		"has_set_pin":                true,
 		"has_set_security_questions": true,
@@ -261,7 +227,7 @@ func (db *PGInstance) InvalidatePIN(ctx context.Context, userID string) (bool, e
 		return false, fmt.Errorf("userID cannot be empty")
 
 	}
-	err := db.DB.Model(&PINData{}).Where(&PINData{UserID: userID, IsValid: true}).Select("active").Updates(PINData{IsValid: false}).Error
+	err := db.DB.WithContext(ctx).Model(&PINData{}).Where(&PINData{UserID: userID, IsValid: true}).Select("active").Updates(PINData{IsValid: false}).Error
 	if err != nil {
 		return false, fmt.Errorf("an error occurred while invalidating the pin: %v", err)
 	}
@@ -274,7 +240,7 @@ func (db *PGInstance) UpdateIsCorrectSecurityQuestionResponse(ctx context.Contex
 		return false, fmt.Errorf("userID cannot be empty")
 
 	}
-	err := db.DB.Model(&SecurityQuestionResponse{}).Where(&SecurityQuestionResponse{UserID: userID}).Updates(map[string]interface{}{
+	err := db.DB.WithContext(ctx).Model(&SecurityQuestionResponse{}).Where(&SecurityQuestionResponse{UserID: userID}).Updates(map[string]interface{}{
 		"is_correct": isCorrectSecurityQuestionResponse,
 	}).Error
 	if err != nil {
@@ -283,222 +249,1321 @@ func (db *PGInstance) UpdateIsCorrectSecurityQuestionResponse(ctx context.Contex
 	return true, nil
 }
 
-// ShareContent  updates the user shared content count
-func (db *PGInstance) ShareContent(ctx context.Context, input dto.ShareContentInput) (bool, error) {
+// ShareContent records that the user shared the content item and increments the item's share count in one
+// transaction. The share count is the number of users who have shared the item hence sharing it again changes nothing.
+// Every share gets a new link on the channel it was shared on so that opening it can be attributed to the user.
+func (db *PGInstance) ShareContent(ctx context.Context, input dto.ShareContentInput) (*ContentShareLink, error) {
 	if input.ContentID == 0 || input.UserID == "" {
+		return nil, fmt.Errorf("contentID or userID cannot be nil")
+	}
+
+	contentShare := &ContentShare{Active: true, ContentID: input.ContentID, UserID: input.UserID}
+	if err := db.addContentEngagement(ctx, contentShares, input.ContentID, input.UserID, contentShare); err != nil {
+		return nil, fmt.Errorf("unable to share content: %v", err)
+	}
+
+	shareLink := &ContentShareLink{ContentID: input.ContentID, UserID: input.UserID, Channel: input.Channel}
+	if err := db.DB.WithContext(ctx).Create(shareLink).Error; err != nil {
+		return nil, fmt.Errorf("unable to create content share link: %v", err)
+	}
+	return shareLink, nil
+}
+
+// RecordContentShareOpen records that a shared link was opened and returns the link. Users opening the links that
+// they shared are not recorded since the opens are attributed to the user who shared the link, and a user who opens
+// a link again is only recorded the first time.
+func (db *PGInstance) RecordContentShareOpen(ctx context.Context, shareLinkID string, openedByID *string) (*ContentShareLink, error) {
+	if shareLinkID == "" {
+		return nil, fmt.Errorf("shareLinkID cannot be empty")
+	}
+
+	var shareLink ContentShareLink
+	if err := db.DB.WithContext(ctx).Where(&ContentShareLink{ID: &shareLinkID}).First(&shareLink).Error; err != nil {
+		return nil, fmt.Errorf("failed to get content share link: %v", err)
+	}
+	if openedByID != nil && *openedByID == shareLink.UserID {
+		return &shareLink, nil
+	}
+
+	// the unique index on the link and the user who opened it leaves a repeated open unrecorded
+	shareOpen := &ContentShareOpen{ShareLinkID: shareLinkID, OpenedByID: openedByID, OrganisationID: shareLink.OrganisationID}
+	err := db.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "share_link_id"}, {Name: "opened_by_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "opened_by_id IS NOT NULL"}}},
+		DoNothing:   true,
+	}).Create(shareOpen).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to record content share open: %v", err)
+	}
+	return &shareLink, nil
+}
+
+// CreateContentComment saves a comment on a content item
+func (db *PGInstance) CreateContentComment(ctx context.Context, comment *ContentComment) (*ContentCommentDetails, error) {
+	if comment.ContentID == 0 || comment.UserID == "" {
+		return nil, fmt.Errorf("contentID or userID cannot be nil")
+	}
+	comment.Active = true
+	if err := db.DB.WithContext(ctx).Create(comment).Error; err != nil {
+		return nil, fmt.Errorf("failed to create content comment: %v", err)
+	}
+	return db.GetContentCommentByID(ctx, *comment.ID)
+}
+
+// EditContentComment replaces the body of a comment that hasn't been deleted. The status is passed since an edit
+// can send the comment back to moderation
+func (db *PGInstance) EditContentComment(ctx context.Context, commentID string, body string, status enums.ContentCommentStatus) (*ContentCommentDetails, error) {
+	if commentID == "" {
+		return nil, fmt.Errorf("commentID cannot be empty")
+	}
+	result := db.DB.WithContext(ctx).Model(&ContentComment{}).Where("id = ? AND active", commentID).Updates(map[string]interface{}{
+		"body":      body,
+		"status":    status,
+		"edited_at": time.Now(),
+	})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to edit content comment: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("content comment %v does not exist or has been deleted", commentID)
+	}
+	return db.GetContentCommentByID(ctx, commentID)
+}
+
+// DeleteContentComment marks a comment as deleted. The row is kept so that the replies to it stay in their thread
+func (db *PGInstance) DeleteContentComment(ctx context.Context, commentID string) (bool, error) {
+	if commentID == "" {
+		return false, fmt.Errorf("commentID cannot be empty")
+	}
+	result := db.DB.WithContext(ctx).Model(&ContentComment{}).Where("id = ? AND active", commentID).Updates(map[string]interface{}{
+		"active": false,
+	})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to delete content comment: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, fmt.Errorf("content comment %v does not exist or has been deleted", commentID)
+	}
+	return true, nil
+}
+
+// ModerateContentComment moves a comment to the status that a moderator chose and records who moderated it
+func (db *PGInstance) ModerateContentComment(ctx context.Context, commentID string, status enums.ContentCommentStatus, moderatorID string) (*ContentCommentDetails, error) {
+	if commentID == "" || moderatorID == "" {
+		return nil, fmt.Errorf("commentID or moderatorID cannot be empty")
+	}
+	result := db.DB.WithContext(ctx).Model(&ContentComment{}).Where("id = ? AND active", commentID).Updates(map[string]interface{}{
+		"status":          status,
+		"moderated_by_id": moderatorID,
+		"moderated_at":    time.Now(),
+	})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to moderate content comment: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("content comment %v does not exist or has been deleted", commentID)
+	}
+	return db.GetContentCommentByID(ctx, commentID)
+}
+
+// BookmarkContent records the user's bookmark on the content item and increments the item's bookmark count in
+// one transaction. Bookmarking an item that the user has already bookmarked changes nothing.
+func (db *PGInstance) BookmarkContent(ctx context.Context, userID string, contentID int) (bool, error) {
+	if contentID == 0 || userID == "" {
+		return false, fmt.Errorf("contentID or userID cannot be nil")
+	}
+
+	contentBookmark := &ContentBookmark{Active: true, ContentID: contentID, UserID: userID}
+	if err := db.addContentEngagement(ctx, contentBookmarks, contentID, userID, contentBookmark); err != nil {
+		return false, fmt.Errorf("unable to bookmark content: %v", err)
+	}
+	return true, nil
+}
+
+// UnBookmarkContent removes the user's bookmark from the content item and decrements the item's bookmark count
+// in one transaction. Removing a bookmark that doesn't exist changes nothing.
+func (db *PGInstance) UnBookmarkContent(ctx context.Context, userID string, contentID int) (bool, error) {
+	if contentID == 0 || userID == "" {
+		return false, fmt.Errorf("contentID or userID cannot be nil")
+	}
+
+	if err := db.removeContentEngagement(ctx, contentBookmarks, contentID, userID); err != nil {
+		return false, fmt.Errorf("unable to remove content bookmark: %v", err)
+	}
+	return true, nil
+}
+
+// ViewContent records that the user viewed the content item and increments the item's view count in one
+// transaction. The view count counts every view while the user's view is only recorded once.
+func (db *PGInstance) ViewContent(ctx context.Context, userID string, contentID int) (bool, error) {
+	if contentID == 0 || userID == "" {
+		return false, fmt.Errorf("contentID or userID cannot be nil")
+	}
+
+	contentView := &ContentView{Active: true, ContentID: contentID, UserID: userID}
+	if err := db.addContentEngagement(ctx, contentViews, contentID, userID, contentView); err != nil {
+		return false, fmt.Errorf("unable to view content: %v", err)
+	}
+	return true, nil
+}
+
+// RecordContentReadDuration records how long the user spent reading the content item. Unlike views, every read is
+// recorded so that the time spent on an item can be averaged over its reads.
+func (db *PGInstance) RecordContentReadDuration(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error) {
+	if contentID == 0 || userID == "" {
 		return false, fmt.Errorf("contentID or userID cannot be nil")
 	}
 
-	var (
-		contentItem  ContentItem
-		contentShare *ContentShare
-	)
+	readEvent := &ContentReadEvent{
+		Active:          true,
+		ContentID:       contentID,
+		UserID:          userID,
+		DurationSeconds: durationSeconds,
+	}
+	if err := db.DB.WithContext(ctx).Create(readEvent).Error; err != nil {
+		return false, fmt.Errorf("unable to record content read duration: %v", err)
+	}
+	return true, nil
+}
 
-	contentShare = &ContentShare{
-		Active:    true,
-		ContentID: input.ContentID,
-		UserID:    input.UserID,
+// RecordContentProgress adds the time spent in the report to the user's progress with the content item and keeps the
+// furthest they have scrolled. The first report that takes the progress past the threshold marks the item completed
+// and records a completion event in the same transaction, so an item is only ever completed once per user. The
+// returned flag is true for that report.
+func (db *PGInstance) RecordContentProgress(ctx context.Context, report *ContentProgress, threshold domain.ContentCompletionThreshold) (*ContentProgress, bool, error) {
+	if report.ContentID == 0 || report.UserID == "" {
+		return nil, false, fmt.Errorf("contentID or userID cannot be nil")
 	}
 
-	tx := db.DB.Begin()
+	tx := db.DB.WithContext(ctx).Begin()
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
 		}
 	}()
 	if err := tx.Error; err != nil {
-		return false, fmt.Errorf("failied initialize database transaction %v", err)
+		return nil, false, fmt.Errorf("failed to initialize database transaction: %v", err)
 	}
 
-	if err := tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: contentShare.ContentID}).First(&contentItem).Error; err != nil {
+	// the lock on the item serializes the reports of the item's readers so that a completion isn't recorded twice
+	var contentItem ContentItem
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&ContentItem{PagePtrID: report.ContentID}).First(&contentItem).Error; err != nil {
 		tx.Rollback()
-		return false, fmt.Errorf("failed to get content item: %v", err)
+		return nil, false, fmt.Errorf("failed to get content item: %v", err)
 	}
 
-	updatedShareCount := contentItem.ShareCount + 1
-
-	err := tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: contentShare.ContentID}).Updates(map[string]interface{}{
-		"share_count": updatedShareCount,
-	}).Error
-	if err != nil {
+	var progress ContentProgress
+	err := tx.Where(&ContentProgress{UserID: report.UserID, ContentID: report.ContentID}).First(&progress).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		progress = ContentProgress{Active: true, UserID: report.UserID, ContentID: report.ContentID}
+	} else if err != nil {
 		tx.Rollback()
-		return false, fmt.Errorf("failed to update content share count: %v", err)
+		return nil, false, fmt.Errorf("failed to get content progress: %v", err)
 	}
 
-	err = tx.Where(contentShare).FirstOrCreate(contentShare).Error
-	if err != nil {
+	progress.SecondsSpent += report.SecondsSpent
+	if report.PercentScrolled > progress.PercentScrolled {
+		progress.PercentScrolled = report.PercentScrolled
+	}
+
+	justCompleted := progress.CompletedAt == nil &&
+		progress.PercentScrolled >= threshold.PercentScrolled &&
+		float64(progress.SecondsSpent) >= threshold.ShareOfTimeEstimate*float64(contentItem.TimeEstimateSeconds)
+	if justCompleted {
+		completedAt := time.Now()
+		progress.CompletedAt = &completedAt
+	}
+
+	if err := tx.Save(&progress).Error; err != nil {
 		tx.Rollback()
-		return false, fmt.Errorf("failed to save or get share content: %v", err)
+		return nil, false, fmt.Errorf("failed to save content progress: %v", err)
+	}
+	if justCompleted {
+		completion := &ContentCompletionEvent{ContentID: report.ContentID, UserID: report.UserID}
+		if err := tx.Create(completion).Error; err != nil {
+			tx.Rollback()
+			return nil, false, fmt.Errorf("failed to record content completion: %v", err)
+		}
 	}
 
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
-		return false, fmt.Errorf("transaction commit to share count failed: %v", err)
+		return nil, false, fmt.Errorf("failed to commit content progress transaction: %v", err)
 	}
+	return &progress, justCompleted, nil
+}
 
-	return true, nil
+// contentEngagement is a kind of engagement with content. Each user's engagement with an item is kept as a row
+// in the engagement's table while the item keeps a count of them in its counter column. A counter that counts
+// repeats counts every engagement rather than the users who engaged.
+type contentEngagement struct {
+	table         string
+	counter       string
+	countsRepeats bool
 }
 
-//BookmarkContent enable a user to set a bookmark on a content
-func (db *PGInstance) BookmarkContent(ctx context.Context, userID string, contentID int) (bool, error) {
-	if contentID == 0 || userID == "" {
-		return false, fmt.Errorf("contentID or userID cannot be nil")
-	}
-	var (
-		contentItem     ContentItem
-		contentBookmark *ContentBookmark
-	)
+var (
+	contentLikes     = contentEngagement{table: "content_contentlike", counter: "like_count"}
+	contentBookmarks = contentEngagement{table: "content_contentbookmark", counter: "bookmark_count"}
+	contentShares    = contentEngagement{table: "content_contentshare", counter: "share_count"}
+	contentViews     = contentEngagement{table: "content_contentview", counter: "view_count", countsRepeats: true}
+
+	// contentEngagements are all the kinds of engagement that content items keep a count of
+	contentEngagements = []contentEngagement{contentLikes, contentBookmarks, contentShares, contentViews}
+)
 
-	contentBookmark = &ContentBookmark{
-		UserID:    userID,
-		ContentID: contentID,
+// lockContentItem locks the content item until the transaction ends. Engagement with an item is recorded while
+// holding the lock so that concurrent requests can't record the same engagement twice or lose counter updates.
+func lockContentItem(tx *gorm.DB, contentID int) error {
+	var contentItem ContentItem
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&ContentItem{PagePtrID: contentID}).First(&contentItem).Error; err != nil {
+		return fmt.Errorf("failed to get content item: %v", err)
 	}
+	return nil
+}
 
-	tx := db.DB.Begin()
+// addContentEngagement saves the user's engagement row, or reactivates it if it was deactivated, and increments the
+// item's counter. A counter that doesn't count repeats is left as it is if the user's engagement is already active.
+func (db *PGInstance) addContentEngagement(ctx context.Context, engagement contentEngagement, contentID int, userID string, row interface{}) error {
+	tx := db.DB.WithContext(ctx).Begin()
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
 		}
 	}()
 	if err := tx.Error; err != nil {
-		return false, fmt.Errorf("failied initialize database transaction %v", err)
+		return fmt.Errorf("failed to initialize database transaction: %v", err)
 	}
 
-	if err := tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: contentID}).First(&contentItem).Error; err != nil {
+	if err := lockContentItem(tx, contentID); err != nil {
 		tx.Rollback()
-		return false, fmt.Errorf("failed to get content item: %v", err)
+		return err
 	}
 
-	var updatedBookmarkCount = contentItem.BookmarkCount
-	if err := tx.Model(&ContentBookmark{}).Where(&ContentBookmark{UserID: userID, ContentID: contentID}).First(&contentBookmark).Error; err != nil {
-		updatedBookmarkCount++
+	var existing []struct{ Active bool }
+	err := tx.Table(engagement.table).Select("active").Where("user_id = ? AND content_item_id = ?", userID, contentID).Scan(&existing).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to check for existing engagement: %v", err)
+	}
+	active := false
+	for _, row := range existing {
+		active = active || row.Active
 	}
 
-	err := tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: contentID}).Updates(map[string]interface{}{
-		"bookmark_count": updatedBookmarkCount,
-	}).Error
-	if err != nil {
+	switch {
+	case len(existing) == 0:
+		if err := tx.Create(row).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to save engagement: %v", err)
+		}
+	case !active:
+		// only one of the rows is reactivated since a user's engagement can only be active once
+		earliest := tx.Table(engagement.table).Select("id").Where("user_id = ? AND content_item_id = ?", userID, contentID).
+			Order("created, id").Limit(1)
+		err := tx.Table(engagement.table).Where("id = (?)", earliest).Update("active", true).Error
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to reactivate engagement: %v", err)
+		}
+	case !engagement.countsRepeats:
 		tx.Rollback()
-		return false, fmt.Errorf("failed to update content share count: %v", err)
+		return nil
 	}
 
-	err = tx.Where(contentBookmark).FirstOrCreate(contentBookmark).Error
+	err = tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: contentID}).
+		UpdateColumn(engagement.counter, gorm.Expr(engagement.counter+" + 1")).Error
 	if err != nil {
 		tx.Rollback()
-		return false, fmt.Errorf("failed to save or get share content: %v", err)
+		return fmt.Errorf("failed to update %s: %v", engagement.counter, err)
 	}
 
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
-		return false, fmt.Errorf("transaction commit to share count failed: %v", err)
+		return fmt.Errorf("failed to commit engagement transaction: %v", err)
 	}
-
-	return true, nil
+	return nil
 }
 
-// UnBookmarkContent removes a bookmark from a content
-func (db *PGInstance) UnBookmarkContent(ctx context.Context, userID string, contentID int) (bool, error) {
-	if contentID == 0 || userID == "" {
-		return false, fmt.Errorf("contentID or userID cannot be nil")
+// removeContentEngagement deletes the user's engagement rows and decrements the item's counter by the number of
+// active rows deleted
+func (db *PGInstance) removeContentEngagement(ctx context.Context, engagement contentEngagement, contentID int, userID string) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return fmt.Errorf("failed to initialize database transaction: %v", err)
+	}
+
+	if err := lockContentItem(tx, contentID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	result := tx.Exec("DELETE FROM "+engagement.table+" WHERE user_id = ? AND content_item_id = ? AND active", userID, contentID)
+	if result.Error != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete engagement: %v", result.Error)
+	}
+	if err := tx.Exec("DELETE FROM "+engagement.table+" WHERE user_id = ? AND content_item_id = ?", userID, contentID).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete inactive engagement: %v", err)
+	}
+	if result.RowsAffected > 0 {
+		err := tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: contentID}).
+			UpdateColumn(engagement.counter, gorm.Expr("greatest("+engagement.counter+" - ?, 0)", result.RowsAffected)).Error
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to update %s: %v", engagement.counter, err)
+		}
 	}
-	var (
-		contentItem     ContentItem
-		contentBookmark *ContentBookmark
-	)
 
-	contentBookmark = &ContentBookmark{
-		UserID:    userID,
-		ContentID: contentID,
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to commit engagement transaction: %v", err)
 	}
+	return nil
+}
 
-	tx := db.DB.Begin()
+// ReconcileContentEngagement reports the content items whose engagement counters don't match the number of users
+// whose engagement with them is active, along with any engagement recorded more than once for the same user and
+// item. A counter that counts repeats is only reported when it is below the number of users. Unless it is a dry run,
+// the counters are rewritten to the number of users in the same transaction, while content items and engagement
+// are locked so that engagement recorded in the meantime isn't lost.
+func (db *PGInstance) ReconcileContentEngagement(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error) {
+	tx := db.DB.WithContext(ctx).Begin()
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
 		}
 	}()
 	if err := tx.Error; err != nil {
-		return false, fmt.Errorf("failied initialize database transaction %v", err)
+		return nil, fmt.Errorf("failed to initialize database transaction: %v", err)
 	}
 
-	if err := tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: contentID}).First(&contentItem).Error; err != nil {
-		tx.Rollback()
-		return false, fmt.Errorf("failed to get content item: %v", err)
+	if !dryRun {
+		// content items are locked first, in the same order as engagement is recorded, so as not to deadlock
+		tables := []string{}
+		for _, engagement := range contentEngagements {
+			tables = append(tables, engagement.table)
+		}
+		if err := tx.Exec("LOCK TABLE content_contentitem IN EXCLUSIVE MODE").Error; err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to lock the content items: %v", err)
+		}
+		if err := tx.Exec("LOCK TABLE " + strings.Join(tables, ", ") + " IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to lock the engagement tables: %v", err)
+		}
 	}
 
-	var updatedBookmarkCount = contentItem.BookmarkCount
-
-	if err := tx.Model(&ContentBookmark{}).Where(&ContentBookmark{UserID: userID, ContentID: contentID}).First(&contentBookmark).Error; err == nil {
-		updatedBookmarkCount--
-
-		err := tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: contentID}).Updates(map[string]interface{}{
-			"bookmark_count": updatedBookmarkCount,
-		}).Error
+	report := &domain.ContentEngagementReport{Drift: []*domain.ContentCounterDrift{}, Fixed: !dryRun}
+	for _, engagement := range contentEngagements {
+		var duplicates int
+		err := tx.Raw(
+			"SELECT count(*) - count(DISTINCT (user_id, content_item_id)) FROM " + engagement.table + " WHERE active",
+		).Scan(&duplicates).Error
 		if err != nil {
 			tx.Rollback()
-			return false, fmt.Errorf("failed to update content share count: %v", err)
+			return nil, fmt.Errorf("failed to count duplicate engagement in %s: %v", engagement.table, err)
 		}
+		report.DuplicateEngagements += duplicates
 
-		err = tx.Delete(contentBookmark).Error
+		comparison := "<>"
+		if engagement.countsRepeats {
+			comparison = "<"
+		}
+		var drift []*domain.ContentCounterDrift
+		err = tx.Raw(fmt.Sprintf(
+			"SELECT content_contentitem.page_ptr_id AS content_id, content_contentitem.%[1]s AS recorded, "+
+				"count(DISTINCT %[2]s.user_id) AS actual FROM content_contentitem "+
+				"LEFT JOIN %[2]s ON %[2]s.content_item_id = content_contentitem.page_ptr_id AND %[2]s.active "+
+				"GROUP BY content_contentitem.page_ptr_id, content_contentitem.%[1]s "+
+				"HAVING content_contentitem.%[1]s %[3]s count(DISTINCT %[2]s.user_id) "+
+				"ORDER BY content_contentitem.page_ptr_id",
+			engagement.counter, engagement.table, comparison,
+		)).Scan(&drift).Error
 		if err != nil {
 			tx.Rollback()
-			return false, fmt.Errorf("failed to delete content bookmark: %v", err)
+			return nil, fmt.Errorf("failed to compare %s with %s: %v", engagement.counter, engagement.table, err)
+		}
+		for _, itemDrift := range drift {
+			itemDrift.Counter = engagement.counter
+			if dryRun {
+				continue
+			}
+			err := tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: itemDrift.ContentID}).
+				UpdateColumn(engagement.counter, itemDrift.Actual).Error
+			if err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("failed to rewrite %s: %v", engagement.counter, err)
+			}
 		}
+		report.Drift = append(report.Drift, drift...)
 	}
 
+	if dryRun {
+		tx.Rollback()
+		return report, nil
+	}
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
-		return false, fmt.Errorf("transaction commit to share count failed: %v", err)
+		return nil, fmt.Errorf("failed to commit the engagement counters: %v", err)
 	}
-	return true, nil
+	return report, nil
 }
 
-// ViewContent gets a specific content and updates the count each time it is viewed
-func (db *PGInstance) ViewContent(ctx context.Context, userID string, contentID int) (bool, error) {
-	var (
-		contentItem ContentItem
-		contentView *ContentView
-	)
+// invitationStatusUpdates returns the columns to update when an invitation moves to the given status
+func invitationStatusUpdates(status enums.InvitationStatus) map[string]interface{} {
+	updates := map[string]interface{}{
+		"status": status,
+	}
+	switch status {
+	case enums.InvitationStatusDelivered:
+		updates["delivered_at"] = time.Now()
+	case enums.InvitationStatusAccepted:
+		updates["accepted_at"] = time.Now()
+	}
+	return updates
+}
+
+// UpdateInvitationStatus moves an invitation to a new status and records when it happened
+func (db *PGInstance) UpdateInvitationStatus(ctx context.Context, invitationID string, status enums.InvitationStatus) (bool, error) {
+	if invitationID == "" || !status.IsValid() {
+		return false, fmt.Errorf("invitationID and a valid status must be provided")
+	}
+	err := db.DB.WithContext(ctx).Model(&Invitation{}).Where(&Invitation{ID: &invitationID}).Updates(invitationStatusUpdates(status)).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to update invitation status: %v", err)
+	}
+	return true, nil
+}
 
-	contentView = &ContentView{
-		Active:    true,
-		ContentID: contentID,
-		UserID:    userID,
+// CloseUserInvitations moves all of a user's pending invitations to an accepted or expired status
+func (db *PGInstance) CloseUserInvitations(ctx context.Context, userID string, flavour feedlib.Flavour, status enums.InvitationStatus) (bool, error) {
+	if userID == "" || !flavour.IsValid() {
+		return false, fmt.Errorf("userID and a valid flavour must be provided")
+	}
+	if status != enums.InvitationStatusAccepted && status != enums.InvitationStatusExpired {
+		return false, fmt.Errorf("invitations can only be closed as accepted or expired, got %v", status)
 	}
+	err := db.DB.WithContext(ctx).Model(&Invitation{}).Where(&Invitation{UserID: userID, Flavour: flavour}).
+		Where("status IN ?", enums.PendingInvitationStatuses).Updates(invitationStatusUpdates(status)).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to close user invitations: %v", err)
+	}
+	return true, nil
+}
 
-	tx := db.DB.Begin()
+// ExpireOverdueInvitations marks the pending invitations whose expiry time has passed as expired and
+// invalidates the temporary PINs that were sent with them. The operation is carried out in a transaction.
+func (db *PGInstance) ExpireOverdueInvitations(ctx context.Context) (bool, error) {
+	tx := db.DB.WithContext(ctx).Begin()
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
 		}
 	}()
 	if err := tx.Error; err != nil {
-		return false, fmt.Errorf("failied initialize database transaction %v", err)
+		return false, fmt.Errorf("failed to initialize expire invitations transaction")
 	}
 
-	if err := tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: contentView.ContentID}).First(&contentItem).Error; err != nil {
-		return false, fmt.Errorf("failed to get content item: %v", err)
+	var overdue []*Invitation
+	err := tx.Where("status IN ?", enums.PendingInvitationStatuses).Where("expires_at < ?", time.Now()).Find(&overdue).Error
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to get overdue invitations: %v", err)
+	}
+	if len(overdue) == 0 {
+		tx.Rollback()
+		return true, nil
 	}
 
-	updatedViewCount := contentItem.ViewCount + 1
+	invitationIDs := []string{}
+	for _, invitation := range overdue {
+		invitationIDs = append(invitationIDs, *invitation.ID)
 
-	err := tx.Model(&ContentItem{}).Where(&ContentItem{PagePtrID: contentView.ContentID}).Updates(map[string]interface{}{
-		"view_count": updatedViewCount,
-	}).Error
-	if err != nil {
-		return false, fmt.Errorf("failed to update content view count: %v", err)
+		err = tx.Model(&PINData{}).Where(&PINData{UserID: invitation.UserID, Flavour: invitation.Flavour, IsValid: true}).
+			Select("active").Updates(PINData{IsValid: false}).Error
+		if err != nil {
+			tx.Rollback()
+			return false, fmt.Errorf("failed to invalidate temporary pin: %v", err)
+		}
 	}
 
-	err = tx.Where(contentView).FirstOrCreate(contentView).Error
+	err = tx.Model(&Invitation{}).Where("id IN ?", invitationIDs).Updates(invitationStatusUpdates(enums.InvitationStatusExpired)).Error
 	if err != nil {
 		tx.Rollback()
-		return false, fmt.Errorf("failed to save view content count: %v", err)
+		return false, fmt.Errorf("failed to expire invitations: %v", err)
 	}
 
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
-		return false, fmt.Errorf("transaction commit to view count/content failed: %v", err)
+		return false, fmt.Errorf("transaction commit to expire invitations failed: %v", err)
+	}
+	return true, nil
+}
+
+// staffProfileUpdates builds the columns to change from the staff profile fields that were set
+func staffProfileUpdates(input *dto.StaffProfileUpdateInput) map[string]interface{} {
+	updates := map[string]interface{}{}
+	if input.StaffNumber != nil {
+		updates["staff_number"] = *input.StaffNumber
+	}
+	if input.Cadre != nil {
+		updates["cadre"] = *input.Cadre
+	}
+	if input.DefaultFacilityID != nil {
+		updates["default_facility_id"] = *input.DefaultFacilityID
+	}
+	if input.Active != nil {
+		updates["active"] = *input.Active
+	}
+	return updates
+}
+
+// UpdateStaffProfile changes the supplied fields of a staff profile. A new default facility is also
+// added to the facilities that the staff member can access.
+func (db *PGInstance) UpdateStaffProfile(ctx context.Context, staffID string, input *dto.StaffProfileUpdateInput) (bool, error) {
+	if staffID == "" || input == nil {
+		return false, fmt.Errorf("staffID and update input must be provided")
+	}
+	updates := staffProfileUpdates(input)
+	if len(updates) == 0 {
+		return false, fmt.Errorf("no staff profile field to update")
+	}
+
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return false, fmt.Errorf("failed to initialize update staff profile transaction: %v", err)
 	}
 
+	result := tx.Model(&StaffProfile{}).Where(&StaffProfile{ID: &staffID}).Updates(updates)
+	if result.Error != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to update staff profile: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, fmt.Errorf("staff profile %v not found", staffID)
+	}
+
+	if input.DefaultFacilityID != nil {
+		if err := addStaffFacilities(tx, staffID, []string{*input.DefaultFacilityID}); err != nil {
+			tx.Rollback()
+			return false, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("transaction commit to update staff profile failed: %v", err)
+	}
+	return true, nil
+}
+
+// AddStaffFacilities gives a staff member access to more facilities
+func (db *PGInstance) AddStaffFacilities(ctx context.Context, staffID string, facilityIDs []string) (bool, error) {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return false, fmt.Errorf("failed to initialize add staff facilities transaction: %v", err)
+	}
+
+	if err := addStaffFacilities(tx, staffID, facilityIDs); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("transaction commit to add staff facilities failed: %v", err)
+	}
+	return true, nil
+}
+
+// RemoveStaffFacilities revokes a staff member's access to the supplied facilities.
+// A staff member cannot lose access to their default facility.
+func (db *PGInstance) RemoveStaffFacilities(ctx context.Context, staffID string, facilityIDs []string) (bool, error) {
+	var staff StaffProfile
+	if err := db.DB.WithContext(ctx).Where(&StaffProfile{ID: &staffID}).First(&staff).Error; err != nil {
+		return false, fmt.Errorf("failed to get staff by ID %v: %v", staffID, err)
+	}
+	for _, facilityID := range facilityIDs {
+		if facilityID == staff.DefaultFacilityID {
+			return false, fmt.Errorf("cannot remove the staff member's default facility %v", facilityID)
+		}
+	}
+
+	err := db.DB.WithContext(ctx).Where(&StaffFacilities{StaffID: &staffID}).Where("facility_id IN ?", facilityIDs).
+		Delete(&StaffFacilities{}).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to remove staff facilities: %v", err)
+	}
+	return true, nil
+}
+
+// organisationUpdates builds the columns to change from the organisation fields that were set
+func organisationUpdates(input *dto.OrganisationUpdateInput) map[string]interface{} {
+	updates := map[string]interface{}{}
+	if input.Name != nil {
+		updates["organisation_name"] = *input.Name
+	}
+	if input.EmailAddress != nil {
+		updates["email_address"] = *input.EmailAddress
+	}
+	if input.PhoneNumber != nil {
+		updates["phone_number"] = *input.PhoneNumber
+	}
+	if input.PostalAddress != nil {
+		updates["postal_address"] = *input.PostalAddress
+	}
+	if input.PhysicalAddress != nil {
+		updates["physical_address"] = *input.PhysicalAddress
+	}
+	if input.DefaultCountry != nil {
+		updates["default_country"] = *input.DefaultCountry
+	}
+	if settings := input.Settings; settings != nil {
+		if settings.PINExpiryDays != nil {
+			updates["pin_expiry_days"] = *settings.PINExpiryDays
+		}
+		if settings.InvitePINExpiryDays != nil {
+			updates["invite_pin_expiry_days"] = *settings.InvitePINExpiryDays
+		}
+		if settings.DiaryCadenceHours != nil {
+			updates["diary_cadence_hours"] = *settings.DiaryCadenceHours
+		}
+		if settings.SMSSenderID != nil {
+			updates["sms_sender_id"] = settings.SMSSenderID.String()
+		}
+	}
+	return updates
+}
+
+// UpdateOrganisation changes the supplied fields and settings of an organisation
+func (db *PGInstance) UpdateOrganisation(ctx context.Context, organisationID string, input *dto.OrganisationUpdateInput) (bool, error) {
+	if organisationID == "" || input == nil {
+		return false, fmt.Errorf("organisationID and update input must be provided")
+	}
+	updates := organisationUpdates(input)
+	if len(updates) == 0 {
+		return false, fmt.Errorf("no organisation field to update")
+	}
+
+	result := db.DB.WithContext(ctx).Model(&Organisation{}).Where(&Organisation{OrganisationID: &organisationID}).Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update organisation: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, fmt.Errorf("organisation %v not found", organisationID)
+	}
+	return true, nil
+}
+
+// DeactivateOrganisation marks an organisation as inactive
+func (db *PGInstance) DeactivateOrganisation(ctx context.Context, organisationID string) (bool, error) {
+	if organisationID == "" {
+		return false, fmt.Errorf("organisationID must be provided")
+	}
+
+	result := db.DB.WithContext(ctx).Model(&Organisation{}).Where(&Organisation{OrganisationID: &organisationID}).
+		Select("active").Updates(&Organisation{Active: false})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to deactivate organisation: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, fmt.Errorf("organisation %v not found", organisationID)
+	}
+	return true, nil
+}
+
+// RespondToClientTransfer records the receiving facility's response to a pending client transfer and resolves the
+// service request raised with it. Accepting a transfer closes the client's period at their current facility, opens
+// a period at the receiving facility and makes it the client's current facility.
+func (db *PGInstance) RespondToClientTransfer(ctx context.Context, transferID string, status enums.ClientTransferStatus, respondedByID string, note string) (*ClientTransfer, error) {
+	if status != enums.ClientTransferStatusAccepted && status != enums.ClientTransferStatusDeclined {
+		return nil, fmt.Errorf("a client transfer can only be accepted or declined, got: %v", status)
+	}
+
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return nil, fmt.Errorf("failed to initialize respond to client transfer transaction: %v", err)
+	}
+
+	var transfer ClientTransfer
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&ClientTransfer{ID: &transferID}).First(&transfer).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get client transfer %v: %v", transferID, err)
+	}
+	if transfer.Status != enums.ClientTransferStatusPending {
+		tx.Rollback()
+		return nil, fmt.Errorf("client transfer %v has already been %v", transferID, strings.ToLower(transfer.Status.String()))
+	}
+
+	now := time.Now()
+	if status == enums.ClientTransferStatusAccepted {
+		if err := moveClientToFacility(tx, &transfer, now); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	transfer.Status = status
+	transfer.RespondedByID = &respondedByID
+	transfer.RespondedAt = &now
+	transfer.ResponseNote = note
+	err = tx.Model(&transfer).Select("status", "responded_by_id", "responded_at", "response_note").Updates(&transfer).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update client transfer: %v", err)
+	}
+
+	if transfer.ServiceRequestID != nil {
+		err = tx.Model(&ClientServiceRequest{}).Where(&ClientServiceRequest{ID: transfer.ServiceRequestID}).
+			Updates(map[string]interface{}{"status": "RESOLVED", "resolved_at": now}).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to resolve client transfer service request: %v", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("transaction commit to respond to client transfer failed: %v", err)
+	}
+	return &transfer, nil
+}
+
+// moveClientToFacility assigns the client of an accepted transfer to the receiving facility as part of a transaction.
+// Clients registered before facility periods were recorded get a period at their current facility that starts
+// on the day they were registered.
+func moveClientToFacility(tx *gorm.DB, transfer *ClientTransfer, now time.Time) error {
+	var client Client
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&Client{ID: &transfer.ClientID}).First(&client).Error
+	if err != nil {
+		return fmt.Errorf("failed to get client: %v", err)
+	}
+	if client.FacilityID != transfer.FromFacilityID {
+		return fmt.Errorf("client %s is no longer assigned to facility %s", transfer.ClientID, transfer.FromFacilityID)
+	}
+
+	result := tx.Model(&ClientFacility{}).Where(&ClientFacility{ClientID: transfer.ClientID}).Where("end_date IS NULL").
+		Update("end_date", now)
+	if result.Error != nil {
+		return fmt.Errorf("failed to end the client's current facility period: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		err = tx.Create(&ClientFacility{
+			ClientID:   transfer.ClientID,
+			FacilityID: transfer.FromFacilityID,
+			StartDate:  client.CreatedAt,
+			EndDate:    &now,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to record the client's previous facility period: %v", err)
+		}
+	}
+
+	err = tx.Create(&ClientFacility{
+		ClientID:   transfer.ClientID,
+		FacilityID: transfer.ToFacilityID,
+		StartDate:  now,
+		TransferID: transfer.ID,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record the client's new facility period: %v", err)
+	}
+
+	err = tx.Model(&Client{}).Where(&Client{ID: &transfer.ClientID}).Update("current_facility_id", transfer.ToFacilityID).Error
+	if err != nil {
+		return fmt.Errorf("failed to update the client's current facility: %v", err)
+	}
+	return nil
+}
+
+// facilityChanges applies the fields of the input that differ from the facility's current details to the
+// facility. It returns the columns to update together with a history entry for every changed field.
+func facilityChanges(facility *Facility, input *dto.FacilityUpdateInput) (map[string]interface{}, []*FacilityHistory) {
+	updates := map[string]interface{}{}
+	history := []*FacilityHistory{}
+
+	change := func(column string, current *string, value *string) {
+		if value == nil || *value == *current {
+			return
+		}
+		history = append(history, &FacilityHistory{Field: column, OldValue: *current, NewValue: *value})
+		updates[column] = *value
+		*current = *value
+	}
+
+	change("name", &facility.Name, input.Name)
+	change("phone", &facility.Phone, input.Phone)
+	if input.County != nil {
+		county := input.County.String()
+		change("county", &facility.County, &county)
+	}
+	change("description", &facility.Description, input.Description)
+	change("address", &facility.Address, input.Address)
+
+	changeCoordinate := func(column string, current **float64, value *float64) {
+		if value == nil || (*current != nil && **current == *value) {
+			return
+		}
+		history = append(history, &FacilityHistory{Field: column, OldValue: formatCoordinate(*current), NewValue: formatCoordinate(value)})
+		updates[column] = *value
+		coordinate := *value
+		*current = &coordinate
+	}
+
+	changeCoordinate("latitude", &facility.Latitude, input.Latitude)
+	changeCoordinate("longitude", &facility.Longitude, input.Longitude)
+
+	if input.Active != nil && *input.Active != facility.Active {
+		history = append(history, &FacilityHistory{Field: "active", OldValue: strconv.FormatBool(facility.Active), NewValue: strconv.FormatBool(*input.Active)})
+		updates["active"] = *input.Active
+		facility.Active = *input.Active
+	}
+
+	return updates, history
+}
+
+// formatCoordinate writes a coordinate for the facility history. A missing coordinate is left empty.
+func formatCoordinate(coordinate *float64) string {
+	if coordinate == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*coordinate, 'f', -1, 64)
+}
+
+// saveFacilityChanges updates the facility's columns and records each change against the user who made it
+func saveFacilityChanges(tx *gorm.DB, facility *Facility, updates map[string]interface{}, history []*FacilityHistory, changedBy string) error {
+	if err := tx.Model(&Facility{}).Where(&Facility{FacilityID: facility.FacilityID}).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update facility: %v", err)
+	}
+
+	changedAt := time.Now()
+	for _, entry := range history {
+		entry.FacilityID = *facility.FacilityID
+		entry.ChangedBy = changedBy
+		entry.ChangedAt = changedAt
+	}
+	if err := tx.Create(&history).Error; err != nil {
+		return fmt.Errorf("failed to record facility history: %v", err)
+	}
+	return nil
+}
+
+// UpdateFacility changes the details of the facility with the supplied MFL code and records each change,
+// together with the user who made it, in the facility history
+func (db *PGInstance) UpdateFacility(ctx context.Context, mflCode int, input *dto.FacilityUpdateInput, changedBy string) (*Facility, error) {
+	if mflCode == 0 || input == nil || changedBy == "" {
+		return nil, fmt.Errorf("mflCode, update input and the user making the change must be provided")
+	}
+
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return nil, fmt.Errorf("failed to initialize update facility transaction: %v", err)
+	}
+
+	var facility Facility
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&Facility{Code: mflCode}).First(&facility).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get facility by MFL Code %v: %v", mflCode, err)
+	}
+
+	updates, history := facilityChanges(&facility, input)
+	if len(updates) == 0 {
+		tx.Rollback()
+		return &facility, nil
+	}
+
+	if err := saveFacilityChanges(tx, &facility, updates, history, changedBy); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("transaction commit to update facility failed: %v", err)
+	}
+	return &facility, nil
+}
+
+// UpsertFacilities creates the facilities whose MFL codes are not saved yet and updates the details of the rest,
+// recording what changed in the facility history. Every facility is saved within its own savepoint so that one
+// which cannot be saved is reported as invalid without discarding the others. A dry run rolls back every change.
+func (db *PGInstance) UpsertFacilities(ctx context.Context, facilities []*Facility, changedBy string, dryRun bool) ([]*domain.FacilityImportRow, error) {
+	if changedBy == "" {
+		return nil, fmt.Errorf("the user importing the facilities must be provided")
+	}
+
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return nil, fmt.Errorf("failed to initialize upsert facilities transaction: %v", err)
+	}
+
+	rows := []*domain.FacilityImportRow{}
+	for i, facility := range facilities {
+		row := &domain.FacilityImportRow{
+			MFLCode: facility.Code,
+			Name:    facility.Name,
+		}
+
+		savepoint := fmt.Sprintf("facility_%d", i)
+		if err := tx.SavePoint(savepoint).Error; err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to create savepoint for facility %v: %v", facility.Code, err)
+		}
+
+		status, err := upsertFacility(tx, facility, changedBy)
+		if err != nil {
+			if err := tx.RollbackTo(savepoint).Error; err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("failed to roll back to savepoint for facility %v: %v", facility.Code, err)
+			}
+			row.Status = enums.FacilityImportStatusInvalid
+			row.Error = err.Error()
+		} else {
+			row.Status = status
+		}
+		rows = append(rows, row)
+	}
+
+	if dryRun {
+		if err := tx.Rollback().Error; err != nil {
+			return nil, fmt.Errorf("failed to roll back facility import dry run: %v", err)
+		}
+		return rows, nil
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("transaction commit to upsert facilities failed: %v", err)
+	}
+	return rows, nil
+}
+
+// upsertFacility creates the facility if its MFL code is not saved, otherwise it updates the saved facility's details
+func upsertFacility(tx *gorm.DB, facility *Facility, changedBy string) (enums.FacilityImportStatus, error) {
+	var existing Facility
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&Facility{Code: facility.Code}).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if err := tx.Create(facility).Error; err != nil {
+			return "", fmt.Errorf("failed to create facility: %v", err)
+		}
+		return enums.FacilityImportStatusCreated, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get facility by MFL Code %v: %v", facility.Code, err)
+	}
+
+	county := enums.CountyType(facility.County)
+	input := &dto.FacilityUpdateInput{
+		Name:        &facility.Name,
+		Phone:       &facility.Phone,
+		County:      &county,
+		Description: &facility.Description,
+		Latitude:    facility.Latitude,
+		Longitude:   facility.Longitude,
+		Active:      &facility.Active,
+	}
+	// an export without an address keeps the address that was saved
+	if facility.Address != "" {
+		input.Address = &facility.Address
+	}
+	updates, history := facilityChanges(&existing, input)
+	if len(updates) == 0 {
+		return enums.FacilityImportStatusUnchanged, nil
+	}
+	if err := saveFacilityChanges(tx, &existing, updates, history, changedBy); err != nil {
+		return "", err
+	}
+	return enums.FacilityImportStatusUpdated, nil
+}
+
+// lockFacility fetches the facility with the supplied MFL code and locks it until the transaction ends
+func lockFacility(tx *gorm.DB, mflCode int) (*Facility, error) {
+	var facility Facility
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&Facility{Code: mflCode}).First(&facility).Error; err != nil {
+		return nil, fmt.Errorf("failed to get facility by MFL Code %v: %v", mflCode, err)
+	}
+	return &facility, nil
+}
+
+// SetFacilityOpeningHours replaces a facility's weekly opening hours with the supplied ones
+func (db *PGInstance) SetFacilityOpeningHours(ctx context.Context, mflCode int, openingHours []*FacilityOpeningHours) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return fmt.Errorf("failed to initialize set facility opening hours transaction: %v", err)
+	}
+
+	facility, err := lockFacility(tx, mflCode)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Where(&FacilityOpeningHours{FacilityID: *facility.FacilityID}).Delete(&FacilityOpeningHours{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to remove facility opening hours: %v", err)
+	}
+	for _, hours := range openingHours {
+		hours.FacilityID = *facility.FacilityID
+	}
+	if len(openingHours) > 0 {
+		if err := tx.Create(&openingHours).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to save facility opening hours: %v", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("transaction commit to set facility opening hours failed: %v", err)
+	}
+	return nil
+}
+
+// SetFacilityHoursException saves an exception to a facility's opening hours, replacing the facility's
+// exception on the same date if there is one
+func (db *PGInstance) SetFacilityHoursException(ctx context.Context, mflCode int, exception *FacilityHoursException) error {
+	if exception == nil {
+		return fmt.Errorf("facility hours exception must be provided")
+	}
+	var facility Facility
+	if err := db.DB.WithContext(ctx).Where(&Facility{Code: mflCode}).First(&facility).Error; err != nil {
+		return fmt.Errorf("failed to get facility by MFL Code %v: %v", mflCode, err)
+	}
+
+	exception.FacilityID = *facility.FacilityID
+	err := db.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "facility_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"closed", "opens_at", "closes_at", "reason", "updated"}),
+	}).Create(exception).Error
+	if err != nil {
+		return fmt.Errorf("failed to save facility hours exception: %v", err)
+	}
+	return nil
+}
+
+// SetFacilityServices replaces the services that a facility offers with the active services that have the
+// supplied codes. It fails if any of the codes is not in the catalogue.
+func (db *PGInstance) SetFacilityServices(ctx context.Context, mflCode int, serviceCodes []string) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return fmt.Errorf("failed to initialize set facility services transaction: %v", err)
+	}
+
+	facility, err := lockFacility(tx, mflCode)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var services []*FacilityService
+	if len(serviceCodes) > 0 {
+		if err := tx.Where("code IN ?", serviceCodes).Where(&FacilityService{Active: true}).Find(&services).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to get facility services: %v", err)
+		}
+	}
+	found := map[string]bool{}
+	for _, service := range services {
+		found[service.Code] = true
+	}
+	for _, code := range serviceCodes {
+		if !found[code] {
+			tx.Rollback()
+			return fmt.Errorf("no active facility service with the code %v", code)
+		}
+	}
+
+	if err := tx.Where(&FacilityServices{FacilityID: facility.FacilityID}).Delete(&FacilityServices{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to remove facility services: %v", err)
+	}
+	for _, service := range services {
+		if err := tx.Create(&FacilityServices{FacilityID: facility.FacilityID, ServiceID: service.ID}).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to add facility service %v: %v", service.Code, err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("transaction commit to set facility services failed: %v", err)
+	}
+	return nil
+}
+
+// UpdateBulkInviteJobStatus moves a bulk invite job to a new status. A completed job is stamped with the time it
+// completed. Only a job in progress can be completed so that a job failed in the meantime stays failed.
+func (db *PGInstance) UpdateBulkInviteJobStatus(ctx context.Context, jobID string, status enums.BulkInviteJobStatus) (bool, error) {
+	if jobID == "" || !status.IsValid() {
+		return false, fmt.Errorf("jobID and a valid status must be provided")
+	}
+	tx := db.DB.WithContext(ctx).Model(&BulkInviteJob{}).Where(&BulkInviteJob{ID: &jobID})
+	updates := map[string]interface{}{"status": status}
+	if status == enums.BulkInviteJobStatusCompleted {
+		updates["completed_at"] = time.Now()
+		tx = tx.Where("status = ?", enums.BulkInviteJobStatusInProgress)
+	}
+	result := tx.Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update bulk invite job status: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, fmt.Errorf("bulk invite job %s does not exist or can't be moved to %v", jobID, status)
+	}
+	return true, nil
+}
+
+// FailInterruptedBulkInviteJobs fails the pending and in progress bulk invite jobs that have not recorded any
+// progress within the stale period, together with their rows that were not processed, and returns how many jobs
+// were failed. A job records progress with every row hence a job that has gone quiet was interrupted e.g by a
+// restart of the instance running it.
+func (db *PGInstance) FailInterruptedBulkInviteJobs(ctx context.Context, staleAfter time.Duration) (int, error) {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return 0, fmt.Errorf("failed to initialize database transaction: %v", err)
+	}
+
+	var jobIDs []string
+	err := tx.Model(&BulkInviteJob{}).Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status IN ? AND updated < ?", []enums.BulkInviteJobStatus{enums.BulkInviteJobStatusPending, enums.BulkInviteJobStatusInProgress}, time.Now().Add(-staleAfter)).
+		Pluck("id", &jobIDs).Error
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("failed to find interrupted bulk invite jobs: %v", err)
+	}
+	if len(jobIDs) == 0 {
+		tx.Rollback()
+		return 0, nil
+	}
+
+	err = tx.Exec(
+		"UPDATE users_bulkinvitejob SET status = ?, completed_at = ?, updated = ?, failed = failed + ("+
+			"SELECT count(*) FROM users_bulkinviterow WHERE users_bulkinviterow.job_id = users_bulkinvitejob.id AND users_bulkinviterow.status = ?"+
+			") WHERE id IN ?",
+		enums.BulkInviteJobStatusFailed, time.Now(), time.Now(), enums.BulkInviteRowStatusPending, jobIDs,
+	).Error
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("failed to fail interrupted bulk invite jobs: %v", err)
+	}
+	err = tx.Model(&BulkInviteRow{}).Where("job_id IN ? AND status = ?", jobIDs, enums.BulkInviteRowStatusPending).
+		Updates(map[string]interface{}{"status": enums.BulkInviteRowStatusFailed, "error": "the job was interrupted before the row was processed"}).Error
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("failed to fail the rows of interrupted bulk invite jobs: %v", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return len(jobIDs), nil
+}
+
+// RecordBulkInviteRowOutcome saves whether a row of a bulk invite job was invited and adds it to the job's succeeded
+// or failed count in the same transaction
+func (db *PGInstance) RecordBulkInviteRowOutcome(
+	ctx context.Context,
+	jobID string,
+	rowID string,
+	status enums.BulkInviteRowStatus,
+	errorMessage string,
+) (bool, error) {
+	if jobID == "" || rowID == "" {
+		return false, fmt.Errorf("jobID and rowID must be provided")
+	}
+	counter := "failed"
+	switch status {
+	case enums.BulkInviteRowStatusSuccess:
+		counter = "succeeded"
+	case enums.BulkInviteRowStatusFailed:
+	default:
+		return false, fmt.Errorf("a row can only be recorded as a success or a failure, got %v", status)
+	}
+
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return false, fmt.Errorf("failed to initialize database transaction: %v", err)
+	}
+
+	// only a pending row is recorded so that a row is never counted twice
+	result := tx.Model(&BulkInviteRow{}).Where(&BulkInviteRow{ID: &rowID, JobID: &jobID}).
+		Where("status = ?", enums.BulkInviteRowStatusPending).
+		Updates(map[string]interface{}{"status": status, "error": errorMessage})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to update bulk invite row: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, fmt.Errorf("bulk invite row %s is not pending", rowID)
+	}
+
+	// the job's updated time is how an interrupted job is told apart from one that is still running
+	err := tx.Model(&BulkInviteJob{}).Where(&BulkInviteJob{ID: &jobID}).
+		UpdateColumns(map[string]interface{}{counter: gorm.Expr(counter+" + ?", 1), "updated": time.Now()}).Error
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to update bulk invite job count: %v", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to commit transaction: %v", err)
+	}
 	return true, nil
 }
@@ -2,6 +2,8 @@ package gorm_test
 
 import (
 	"context"
+	"math/rand"
+	"strconv"
 	"testing"
 	"time"
 
@@ -9,12 +11,15 @@ import (
 	"github.com/google/uuid"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
 	"github.com/segmentio/ksuid"
 )
 
 func TestPGInstance_InactivateFacility(t *testing.T) {
 
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx     context.Context
@@ -61,7 +66,7 @@ func TestPGInstance_InactivateFacility(t *testing.T) {
 
 func TestPGInstance_ReactivateFacility(t *testing.T) {
 
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx     context.Context
@@ -107,7 +112,7 @@ func TestPGInstance_ReactivateFacility(t *testing.T) {
 }
 
 func TestPGInstance_SetNickname(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	invalidUserID := ksuid.New().String()
 	invalidNickname := gofakeit.HipsterSentence(50)
@@ -180,7 +185,7 @@ func TestPGInstance_SetNickname(t *testing.T) {
 }
 
 func TestPGInstance_InvalidatePIN(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx    context.Context
@@ -226,7 +231,7 @@ func TestPGInstance_InvalidatePIN(t *testing.T) {
 }
 
 func TestPGInstance_UpdateIsCorrectSecurityQuestionResponse(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx                               context.Context
@@ -276,7 +281,7 @@ func TestPGInstance_UpdateIsCorrectSecurityQuestionResponse(t *testing.T) {
 }
 
 func TestPGInstance_AcceptTerms(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx     context.Context
@@ -323,7 +328,7 @@ func TestPGInstance_AcceptTerms(t *testing.T) {
 }
 
 func TestPGInstance_UpdateUserFailedLoginCount(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx                 context.Context
@@ -355,7 +360,7 @@ func TestPGInstance_UpdateUserFailedLoginCount(t *testing.T) {
 }
 
 func TestPGInstance_UpdateUserLastFailedLoginTime(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx    context.Context
@@ -384,7 +389,7 @@ func TestPGInstance_UpdateUserLastFailedLoginTime(t *testing.T) {
 }
 
 func TestPGInstance_UpdateUserNextAllowedLoginTime(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx                  context.Context
@@ -415,7 +420,7 @@ func TestPGInstance_UpdateUserNextAllowedLoginTime(t *testing.T) {
 	}
 }
 func TestPGInstance_ShareContent(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx   context.Context
@@ -424,7 +429,6 @@ func TestPGInstance_ShareContent(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		want    bool
 		wantErr bool
 	}{
 		{
@@ -434,9 +438,9 @@ func TestPGInstance_ShareContent(t *testing.T) {
 				input: dto.ShareContentInput{
 					UserID:    userID,
 					ContentID: contentID,
+					Channel:   enums.ContentShareChannelWhatsApp,
 				},
 			},
-			want:    true,
 			wantErr: false,
 		},
 		{
@@ -445,7 +449,6 @@ func TestPGInstance_ShareContent(t *testing.T) {
 				ctx:   ctx,
 				input: dto.ShareContentInput{},
 			},
-			want:    false,
 			wantErr: true,
 		},
 	}
@@ -456,8 +459,11 @@ func TestPGInstance_ShareContent(t *testing.T) {
 				t.Errorf("PGInstance.ShareContent() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != tt.want {
-				t.Errorf("PGInstance.ShareContent() = %v, want %v", got, tt.want)
+			if tt.wantErr {
+				return
+			}
+			if got.ID == nil || got.Channel != tt.args.input.Channel {
+				t.Errorf("expected a share link on %v, got %v", tt.args.input.Channel, got)
 			}
 		})
 	}
@@ -465,7 +471,7 @@ func TestPGInstance_ShareContent(t *testing.T) {
 }
 
 func TestPGInstance_BookmarkContent(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		ctx       context.Context
@@ -524,7 +530,7 @@ func TestPGInstance_BookmarkContent(t *testing.T) {
 }
 
 func TestPGInstance_UnBookmarkContent(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	_, err := testingDB.BookmarkContent(ctx, userID, contentID2)
 	if err != nil {
@@ -589,7 +595,7 @@ func TestPGInstance_UnBookmarkContent(t *testing.T) {
 }
 
 func TestPGInstance_UpdateUserPinChangeRequiredStatus(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 	flavour := feedlib.FlavourConsumer
 
 	type args struct {
@@ -631,7 +637,7 @@ func TestPGInstance_UpdateUserPinChangeRequiredStatus(t *testing.T) {
 }
 
 func TestPGInstance_LikeContent(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	type args struct {
 		context   context.Context
@@ -701,7 +707,7 @@ func TestPGInstance_LikeContent(t *testing.T) {
 }
 
 func TestPGInstance_UnlikeContent(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
 
 	_, err := testingDB.LikeContent(ctx, userID, contentID2)
 	if err != nil {
@@ -775,7 +781,13 @@ func TestPGInstance_UnlikeContent(t *testing.T) {
 	}
 }
 func TestPGInstance_ViewContent(t *testing.T) {
-	ctx := context.Background()
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
 
 	type args struct {
 		ctx       context.Context
@@ -819,4 +831,2139 @@ func TestPGInstance_ViewContent(t *testing.T) {
 			}
 		})
 	}
+
+	// every view is counted while the user's view is only recorded once
+	viewCount := func() int {
+		var contentItem gorm.ContentItem
+		if err := pg.DB.Where("page_ptr_id", contentID).First(&contentItem).Error; err != nil {
+			t.Errorf("failed to get content item: %v", err)
+		}
+		return contentItem.ViewCount
+	}
+	before := viewCount()
+	if _, err := testingDB.ViewContent(ctx, userID, contentID); err != nil {
+		t.Errorf("failed to view content: %v", err)
+		return
+	}
+	if got := viewCount(); got != before+1 {
+		t.Errorf("expected viewing again to increment the view count to %v, got %v", before+1, got)
+	}
+	var views int64
+	if err := pg.DB.Model(&gorm.ContentView{}).Where("user_id = ? AND content_item_id = ?", userID, contentID).Count(&views).Error; err != nil {
+		t.Errorf("failed to count content views: %v", err)
+		return
+	}
+	if views != 1 {
+		t.Errorf("expected the user's view to be recorded once, got %v", views)
+	}
+}
+
+func TestPGInstance_UpdateUserLanguages(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx       context.Context
+		userID    string
+		languages []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				languages: []string{"sw", "en"},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx:       ctx,
+				languages: []string{"sw"},
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.UpdateUserLanguages(tt.args.ctx, tt.args.userID, tt.args.languages)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.UpdateUserLanguages() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.UpdateUserLanguages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPGInstance_UpdateInvitationStatus(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx          context.Context
+		invitationID string
+		status       enums.InvitationStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:          ctx,
+				invitationID: invitationID,
+				status:       enums.InvitationStatusDelivered,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - invalid status",
+			args: args{
+				ctx:          ctx,
+				invitationID: invitationID,
+				status:       "invalid",
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.UpdateInvitationStatus(tt.args.ctx, tt.args.invitationID, tt.args.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.UpdateInvitationStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.UpdateInvitationStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPGInstance_ExpireOverdueInvitations(t *testing.T) {
+	ctx := newTestContext()
+
+	got, err := testingDB.ExpireOverdueInvitations(ctx)
+	if err != nil {
+		t.Errorf("PGInstance.ExpireOverdueInvitations() error = %v", err)
+		return
+	}
+	if !got {
+		t.Errorf("PGInstance.ExpireOverdueInvitations() = %v, want true", got)
+	}
+
+	var invitation gorm.Invitation
+	err = testingDB.DB.Where(&gorm.Invitation{ID: &invitationID2}).First(&invitation).Error
+	if err != nil {
+		t.Errorf("failed to get invitation: %v", err)
+		return
+	}
+	if invitation.Status != enums.InvitationStatusExpired {
+		t.Errorf("expected overdue invitation to be expired, got %v", invitation.Status)
+	}
+}
+
+func TestPGInstance_CloseUserInvitations(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx     context.Context
+		userID  string
+		flavour feedlib.Flavour
+		status  enums.InvitationStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				userID:  userID,
+				flavour: testFlavour,
+				status:  enums.InvitationStatusAccepted,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - pending status",
+			args: args{
+				ctx:     ctx,
+				userID:  userID,
+				flavour: testFlavour,
+				status:  enums.InvitationStatusIssued,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.CloseUserInvitations(tt.args.ctx, tt.args.userID, tt.args.flavour, tt.args.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.CloseUserInvitations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.CloseUserInvitations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPGInstance_UpdateStaffProfile(t *testing.T) {
+	ctx := newTestContext()
+	cadre := enums.StaffCadrePharmacist
+	defaultFacilityID := facilityID
+
+	type args struct {
+		ctx     context.Context
+		staffID string
+		input   *dto.StaffProfileUpdateInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				staffID: staffID,
+				input: &dto.StaffProfileUpdateInput{
+					Cadre:             &cadre,
+					DefaultFacilityID: &defaultFacilityID,
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - staff not found",
+			args: args{
+				ctx:     ctx,
+				staffID: uuid.New().String(),
+				input:   &dto.StaffProfileUpdateInput{Cadre: &cadre},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no field to update",
+			args: args{
+				ctx:     ctx,
+				staffID: staffID,
+				input:   &dto.StaffProfileUpdateInput{},
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.UpdateStaffProfile(tt.args.ctx, tt.args.staffID, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.UpdateStaffProfile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.UpdateStaffProfile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPGInstance_RemoveStaffFacilities(t *testing.T) {
+	ctx := newTestContext()
+
+	type args struct {
+		ctx         context.Context
+		staffID     string
+		facilityIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:         ctx,
+				staffID:     staffID,
+				facilityIDs: []string{staffFacilityID2},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - cannot remove default facility",
+			args: args{
+				ctx:         ctx,
+				staffID:     staffID,
+				facilityIDs: []string{facilityID},
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.RemoveStaffFacilities(tt.args.ctx, tt.args.staffID, tt.args.facilityIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.RemoveStaffFacilities() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.RemoveStaffFacilities() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPGInstance_AddStaffFacilities(t *testing.T) {
+	ctx := newTestContext()
+
+	got, err := testingDB.AddStaffFacilities(ctx, staffID, []string{staffFacilityID2, facilityID})
+	if err != nil {
+		t.Errorf("PGInstance.AddStaffFacilities() error = %v", err)
+		return
+	}
+	if !got {
+		t.Errorf("expected staff facilities to be added")
+	}
+}
+
+func TestPGInstance_UpdateOrganisation(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	organisation := &gorm.Organisation{
+		OrgCode:          ksuid.New().String(),
+		OrganisationName: gofakeit.Company(),
+		EmailAddress:     gofakeit.Email(),
+		PhoneNumber:      "+254711223344",
+		DefaultCountry:   "KEN",
+	}
+	if err = pg.DB.Create(organisation).Error; err != nil {
+		t.Errorf("failed to create organisation: %v", err)
+		return
+	}
+
+	name := gofakeit.Company()
+	cadence := 48
+
+	type args struct {
+		ctx            context.Context
+		organisationID string
+		input          *dto.OrganisationUpdateInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:            ctx,
+				organisationID: *organisation.OrganisationID,
+				input: &dto.OrganisationUpdateInput{
+					Name:     &name,
+					Settings: &dto.OrganisationSettingsInput{DiaryCadenceHours: &cadence},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - organisation not found",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+				input:          &dto.OrganisationUpdateInput{Name: &name},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no field to update",
+			args: args{
+				ctx:            ctx,
+				organisationID: *organisation.OrganisationID,
+				input:          &dto.OrganisationUpdateInput{},
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.UpdateOrganisation(tt.args.ctx, tt.args.organisationID, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.UpdateOrganisation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.UpdateOrganisation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	updated, err := testingDB.GetOrganisation(ctx, *organisation.OrganisationID)
+	if err != nil {
+		t.Errorf("failed to get organisation: %v", err)
+		return
+	}
+	if updated.OrganisationName != name || updated.DiaryCadenceHours == nil || *updated.DiaryCadenceHours != cadence {
+		t.Errorf("expected the organisation name and diary cadence to be updated, got %+v", updated)
+	}
+
+	// TearDown
+	if err = pg.DB.Where("id", *organisation.OrganisationID).Unscoped().Delete(&gorm.Organisation{}).Error; err != nil {
+		t.Errorf("failed to delete organisation: %v", err)
+	}
+}
+
+func TestPGInstance_DeactivateOrganisation(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	organisation := &gorm.Organisation{
+		Active:           true,
+		OrgCode:          ksuid.New().String(),
+		OrganisationName: gofakeit.Company(),
+		EmailAddress:     gofakeit.Email(),
+		PhoneNumber:      "+254711223344",
+		DefaultCountry:   "KEN",
+	}
+	if err = pg.DB.Create(organisation).Error; err != nil {
+		t.Errorf("failed to create organisation: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx            context.Context
+		organisationID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:            ctx,
+				organisationID: *organisation.OrganisationID,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - organisation not found",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.DeactivateOrganisation(tt.args.ctx, tt.args.organisationID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.DeactivateOrganisation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.DeactivateOrganisation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// TearDown
+	if err = pg.DB.Where("id", *organisation.OrganisationID).Unscoped().Delete(&gorm.Organisation{}).Error; err != nil {
+		t.Errorf("failed to delete organisation: %v", err)
+	}
+}
+
+func TestPGInstance_UpdateFacility(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	facility := &gorm.Facility{
+		Name:        ksuid.New().String(),
+		Code:        rand.Intn(1000000),
+		Active:      true,
+		County:      "Nairobi",
+		Description: gofakeit.HipsterSentence(15),
+	}
+	if err = pg.DB.Create(facility).Error; err != nil {
+		t.Errorf("failed to create facility: %v", err)
+		return
+	}
+
+	name := ksuid.New().String()
+	county := enums.CountyTypeKiambu
+	changedBy := uuid.New().String()
+
+	type args struct {
+		ctx       context.Context
+		mflCode   int
+		input     *dto.FacilityUpdateInput
+		changedBy string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				mflCode:   facility.Code,
+				input:     &dto.FacilityUpdateInput{Name: &name, County: &county},
+				changedBy: changedBy,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - nothing changed",
+			args: args{
+				ctx:       ctx,
+				mflCode:   facility.Code,
+				input:     &dto.FacilityUpdateInput{Name: &name},
+				changedBy: changedBy,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - facility not found",
+			args: args{
+				ctx:       ctx,
+				mflCode:   -1,
+				input:     &dto.FacilityUpdateInput{Name: &name},
+				changedBy: changedBy,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no changedBy",
+			args: args{
+				ctx:     ctx,
+				mflCode: facility.Code,
+				input:   &dto.FacilityUpdateInput{Name: &name},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.UpdateFacility(tt.args.ctx, tt.args.mflCode, tt.args.input, tt.args.changedBy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.UpdateFacility() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.Name != name || got.County != string(county)) {
+				t.Errorf("expected the facility name and county to be updated, got %+v", got)
+			}
+		})
+	}
+
+	history, err := testingDB.GetFacilityHistory(ctx, facility.Code)
+	if err != nil {
+		t.Errorf("failed to get facility history: %v", err)
+		return
+	}
+	if len(history) != 2 {
+		t.Errorf("expected the name and county changes to be recorded once, got %v entries", len(history))
+	}
+	for _, entry := range history {
+		if entry.ChangedBy != changedBy {
+			t.Errorf("expected the change to be recorded against %v, got %v", changedBy, entry.ChangedBy)
+		}
+	}
+
+	// TearDown
+	if err = pg.DB.Where("facility_id", facility.FacilityID).Unscoped().Delete(&gorm.FacilityHistory{}).Error; err != nil {
+		t.Errorf("failed to delete facility history: %v", err)
+	}
+	if err = pg.DB.Where("id", facility.FacilityID).Unscoped().Delete(&gorm.Facility{}).Error; err != nil {
+		t.Errorf("failed to delete facility: %v", err)
+	}
+}
+
+func TestPGInstance_UpsertFacilities(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	existing := &gorm.Facility{
+		Name:        ksuid.New().String(),
+		Code:        rand.Intn(1000000),
+		Active:      true,
+		County:      "Nairobi",
+		Phone:       "+254711223344",
+		Description: gofakeit.HipsterSentence(15),
+	}
+	if err = pg.DB.Create(existing).Error; err != nil {
+		t.Errorf("failed to create facility: %v", err)
+		return
+	}
+
+	unchanged := *existing
+	updated := *existing
+	updated.Name = ksuid.New().String()
+	closed := updated
+	closed.Active = false
+	created := &gorm.Facility{
+		Name:        ksuid.New().String(),
+		Code:        rand.Intn(1000000),
+		Active:      true,
+		County:      "Kiambu",
+		Phone:       "+254711223355",
+		Description: gofakeit.HipsterSentence(15),
+	}
+	duplicateName := &gorm.Facility{
+		Name:        existing.Name,
+		Code:        rand.Intn(1000000),
+		Active:      true,
+		County:      "Kiambu",
+		Phone:       "+254711223355",
+		Description: gofakeit.HipsterSentence(15),
+	}
+	changedBy := uuid.New().String()
+
+	type args struct {
+		ctx        context.Context
+		facilities []*gorm.Facility
+		changedBy  string
+		dryRun     bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []enums.FacilityImportStatus
+		wantErr bool
+	}{
+		{
+			name: "Happy case - dry run",
+			args: args{
+				ctx:        ctx,
+				facilities: []*gorm.Facility{&unchanged, duplicateName},
+				changedBy:  changedBy,
+				dryRun:     true,
+			},
+			want:    []enums.FacilityImportStatus{enums.FacilityImportStatusUnchanged, enums.FacilityImportStatusInvalid},
+			wantErr: false,
+		},
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				facilities: []*gorm.Facility{&updated, created},
+				changedBy:  changedBy,
+			},
+			want:    []enums.FacilityImportStatus{enums.FacilityImportStatusUpdated, enums.FacilityImportStatusCreated},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - closed facility is inactivated",
+			args: args{
+				ctx:        ctx,
+				facilities: []*gorm.Facility{&closed},
+				changedBy:  changedBy,
+			},
+			want:    []enums.FacilityImportStatus{enums.FacilityImportStatusUpdated},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no changedBy",
+			args: args{
+				ctx:        ctx,
+				facilities: []*gorm.Facility{created},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.UpsertFacilities(tt.args.ctx, tt.args.facilities, tt.args.changedBy, tt.args.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.UpsertFacilities() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("expected %v results, got %v", len(tt.want), len(got))
+				return
+			}
+			for i, row := range got {
+				if row.Status != tt.want[i] {
+					t.Errorf("expected facility %v to be %v, got %v: %v", row.MFLCode, tt.want[i], row.Status, row.Error)
+				}
+			}
+		})
+	}
+
+	history, err := testingDB.GetFacilityHistory(ctx, existing.Code)
+	if err != nil {
+		t.Errorf("failed to get facility history: %v", err)
+		return
+	}
+	if len(history) != 2 {
+		t.Errorf("expected the name and active changes to be recorded once each, got %v entries", len(history))
+	}
+
+	// TearDown
+	if err = pg.DB.Where("facility_id", existing.FacilityID).Unscoped().Delete(&gorm.FacilityHistory{}).Error; err != nil {
+		t.Errorf("failed to delete facility history: %v", err)
+	}
+	if err = pg.DB.Where("mfl_code IN ?", []int{existing.Code, created.Code}).Unscoped().Delete(&gorm.Facility{}).Error; err != nil {
+		t.Errorf("failed to delete facilities: %v", err)
+	}
+}
+
+func TestPGInstance_SetFacilityOpeningHours(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	facility := &gorm.Facility{
+		Name:        ksuid.New().String(),
+		Code:        rand.Intn(1000000),
+		Active:      true,
+		County:      "Nairobi",
+		Description: gofakeit.HipsterSentence(15),
+	}
+	if err = pg.DB.Create(facility).Error; err != nil {
+		t.Errorf("failed to create facility: %v", err)
+		return
+	}
+
+	allDay := []*gorm.FacilityOpeningHours{}
+	for _, day := range enums.AllDayOfWeek {
+		allDay = append(allDay, &gorm.FacilityOpeningHours{DayOfWeek: day, OpensAt: "00:00", ClosesAt: "24:00"})
+	}
+
+	type args struct {
+		ctx          context.Context
+		mflCode      int
+		openingHours []*gorm.FacilityOpeningHours
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				mflCode: facility.Code,
+				openingHours: []*gorm.FacilityOpeningHours{
+					{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "08:00", ClosesAt: "17:00"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - replace opening hours",
+			args: args{
+				ctx:          ctx,
+				mflCode:      facility.Code,
+				openingHours: allDay,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - facility not found",
+			args: args{
+				ctx:          ctx,
+				mflCode:      -1,
+				openingHours: allDay,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.SetFacilityOpeningHours(tt.args.ctx, tt.args.mflCode, tt.args.openingHours); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.SetFacilityOpeningHours() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	got, err := testingDB.RetrieveFacilityByMFLCode(ctx, facility.Code, true)
+	if err != nil {
+		t.Errorf("failed to retrieve facility: %v", err)
+		return
+	}
+	if len(got.OpeningHours) != len(allDay) {
+		t.Errorf("expected the opening hours to be replaced, got %v entries", len(got.OpeningHours))
+	}
+
+	openNow := []*domain.FiltersParam{
+		{
+			Name:     enums.FilterSortDataTypeMFLCode.String(),
+			DataType: enums.FilterSortDataTypeMFLCode,
+			Value:    strconv.Itoa(facility.Code),
+		},
+		{
+			Name:     enums.FilterSortDataTypeOpenNow.String(),
+			DataType: enums.FilterSortDataTypeOpenNow,
+			Value:    "true",
+		},
+	}
+	page, err := testingDB.ListFacilities(ctx, nil, openNow, nil, &domain.FacilityPage{
+		Pagination: domain.Pagination{
+			Limit:       10,
+			CurrentPage: 1,
+			Sort:        &domain.SortParam{Field: enums.FilterSortDataTypeCreatedAt, Direction: enums.SortDataTypeAsc},
+		},
+	})
+	if err != nil {
+		t.Errorf("failed to list open facilities: %v", err)
+		return
+	}
+	if len(page.Facilities) != 1 {
+		t.Errorf("expected a facility open all day to be open now, got %v facilities", len(page.Facilities))
+	}
+
+	// TearDown
+	if err = pg.DB.Where("facility_id", facility.FacilityID).Unscoped().Delete(&gorm.FacilityOpeningHours{}).Error; err != nil {
+		t.Errorf("failed to delete facility opening hours: %v", err)
+	}
+	if err = pg.DB.Where("id", facility.FacilityID).Unscoped().Delete(&gorm.Facility{}).Error; err != nil {
+		t.Errorf("failed to delete facility: %v", err)
+	}
+}
+
+func TestPGInstance_SetFacilityHoursException(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	facility := &gorm.Facility{
+		Name:        ksuid.New().String(),
+		Code:        rand.Intn(1000000),
+		Active:      true,
+		County:      "Nairobi",
+		Description: gofakeit.HipsterSentence(15),
+	}
+	if err = pg.DB.Create(facility).Error; err != nil {
+		t.Errorf("failed to create facility: %v", err)
+		return
+	}
+	allDay := []*gorm.FacilityOpeningHours{}
+	for _, day := range enums.AllDayOfWeek {
+		allDay = append(allDay, &gorm.FacilityOpeningHours{DayOfWeek: day, OpensAt: "00:00", ClosesAt: "24:00"})
+	}
+	if err = testingDB.SetFacilityOpeningHours(ctx, facility.Code, allDay); err != nil {
+		t.Errorf("failed to set facility opening hours: %v", err)
+		return
+	}
+
+	now := time.Now().In(time.FixedZone("EAT", 3*60*60))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	opensAt := "00:00"
+	closesAt := "00:01"
+
+	type args struct {
+		ctx       context.Context
+		mflCode   int
+		exception *gorm.FacilityHoursException
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				mflCode: facility.Code,
+				exception: &gorm.FacilityHoursException{
+					Date:     today,
+					OpensAt:  &opensAt,
+					ClosesAt: &closesAt,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - replace the exception for the date",
+			args: args{
+				ctx:     ctx,
+				mflCode: facility.Code,
+				exception: &gorm.FacilityHoursException{
+					Date:   today,
+					Closed: true,
+					Reason: "Public holiday",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - facility not found",
+			args: args{
+				ctx:     ctx,
+				mflCode: -1,
+				exception: &gorm.FacilityHoursException{
+					Date:   today,
+					Closed: true,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.SetFacilityHoursException(tt.args.ctx, tt.args.mflCode, tt.args.exception); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.SetFacilityHoursException() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	got, err := testingDB.RetrieveFacilityByMFLCode(ctx, facility.Code, true)
+	if err != nil {
+		t.Errorf("failed to retrieve facility: %v", err)
+		return
+	}
+	if len(got.HoursExceptions) != 1 || !got.HoursExceptions[0].Closed {
+		t.Errorf("expected a single closed exception for today, got %+v", got.HoursExceptions)
+	}
+
+	closedNow := []*domain.FiltersParam{
+		{
+			Name:     enums.FilterSortDataTypeMFLCode.String(),
+			DataType: enums.FilterSortDataTypeMFLCode,
+			Value:    strconv.Itoa(facility.Code),
+		},
+		{
+			Name:     enums.FilterSortDataTypeOpenNow.String(),
+			DataType: enums.FilterSortDataTypeOpenNow,
+			Value:    "false",
+		},
+	}
+	page, err := testingDB.ListFacilities(ctx, nil, closedNow, nil, &domain.FacilityPage{
+		Pagination: domain.Pagination{
+			Limit:       10,
+			CurrentPage: 1,
+			Sort:        &domain.SortParam{Field: enums.FilterSortDataTypeCreatedAt, Direction: enums.SortDataTypeAsc},
+		},
+	})
+	if err != nil {
+		t.Errorf("failed to list closed facilities: %v", err)
+		return
+	}
+	if len(page.Facilities) != 1 {
+		t.Errorf("expected a facility closed for the day to be closed now, got %v facilities", len(page.Facilities))
+	}
+
+	// TearDown
+	if err = pg.DB.Where("facility_id", facility.FacilityID).Unscoped().Delete(&gorm.FacilityHoursException{}).Error; err != nil {
+		t.Errorf("failed to delete facility hours exception: %v", err)
+	}
+	if err = pg.DB.Where("facility_id", facility.FacilityID).Unscoped().Delete(&gorm.FacilityOpeningHours{}).Error; err != nil {
+		t.Errorf("failed to delete facility opening hours: %v", err)
+	}
+	if err = pg.DB.Where("id", facility.FacilityID).Unscoped().Delete(&gorm.Facility{}).Error; err != nil {
+		t.Errorf("failed to delete facility: %v", err)
+	}
+}
+
+func TestPGInstance_SetFacilityServices(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	facility := &gorm.Facility{
+		Name:        ksuid.New().String(),
+		Code:        rand.Intn(1000000),
+		Active:      true,
+		County:      "Nairobi",
+		Description: gofakeit.HipsterSentence(15),
+	}
+	if err = pg.DB.Create(facility).Error; err != nil {
+		t.Errorf("failed to create facility: %v", err)
+		return
+	}
+	service, err := testingDB.CreateFacilityService(ctx, &gorm.FacilityService{
+		Name:   gofakeit.BS(),
+		Code:   "TEST_" + strconv.Itoa(rand.Intn(1000000)),
+		Active: true,
+	})
+	if err != nil {
+		t.Errorf("failed to create facility service: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx          context.Context
+		mflCode      int
+		serviceCodes []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:          ctx,
+				mflCode:      facility.Code,
+				serviceCodes: []string{service.Code},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - unknown service",
+			args: args{
+				ctx:          ctx,
+				mflCode:      facility.Code,
+				serviceCodes: []string{ksuid.New().String()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - facility not found",
+			args: args{
+				ctx:          ctx,
+				mflCode:      -1,
+				serviceCodes: []string{service.Code},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.SetFacilityServices(tt.args.ctx, tt.args.mflCode, tt.args.serviceCodes); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.SetFacilityServices() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	offersService := []*domain.FiltersParam{
+		{
+			Name:     enums.FilterSortDataTypeService.String(),
+			DataType: enums.FilterSortDataTypeService,
+			Value:    service.Code,
+		},
+	}
+	page, err := testingDB.ListFacilities(ctx, nil, offersService, nil, &domain.FacilityPage{
+		Pagination: domain.Pagination{
+			Limit:       10,
+			CurrentPage: 1,
+			Sort:        &domain.SortParam{Field: enums.FilterSortDataTypeCreatedAt, Direction: enums.SortDataTypeAsc},
+		},
+	})
+	if err != nil {
+		t.Errorf("failed to list facilities offering the service: %v", err)
+		return
+	}
+	if len(page.Facilities) != 1 || *page.Facilities[0].ID != *facility.FacilityID {
+		t.Errorf("expected only the facility offering the service, got %v facilities", len(page.Facilities))
+	}
+
+	// TearDown
+	if err = pg.DB.Where("facility_id", facility.FacilityID).Unscoped().Delete(&gorm.FacilityServices{}).Error; err != nil {
+		t.Errorf("failed to delete facility services: %v", err)
+	}
+	if err = pg.DB.Where("id", service.ID).Unscoped().Delete(&gorm.FacilityService{}).Error; err != nil {
+		t.Errorf("failed to delete facility service: %v", err)
+	}
+	if err = pg.DB.Where("id", facility.FacilityID).Unscoped().Delete(&gorm.Facility{}).Error; err != nil {
+		t.Errorf("failed to delete facility: %v", err)
+	}
+}
+
+func TestPGInstance_RespondToClientTransfer(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	toFacility := &gorm.Facility{
+		Name:        ksuid.New().String(),
+		Code:        rand.Intn(1000000),
+		Active:      true,
+		County:      "Nairobi",
+		Description: gofakeit.HipsterSentence(15),
+	}
+	if err = pg.DB.Create(toFacility).Error; err != nil {
+		t.Errorf("failed to create facility: %v", err)
+		return
+	}
+
+	requestTransfer := func() (*gorm.ClientTransfer, error) {
+		transfer := &gorm.ClientTransfer{
+			ClientID:       clientID,
+			FromFacilityID: facilityID,
+			ToFacilityID:   *toFacility.FacilityID,
+			Reason:         "Client relocated",
+			RequestedByID:  staffID,
+			RequestedAt:    time.Now(),
+		}
+		serviceRequest := &gorm.ClientServiceRequest{
+			Active:      true,
+			RequestType: "CLIENT_TRANSFER",
+			Request:     "Accept the transfer of a client",
+			Status:      "PENDING",
+			ClientID:    clientID,
+			FacilityID:  toFacility.FacilityID,
+		}
+		return testingDB.CreateClientTransfer(ctx, transfer, serviceRequest)
+	}
+
+	declined, err := requestTransfer()
+	if err != nil {
+		t.Errorf("failed to request client transfer: %v", err)
+		return
+	}
+	got, err := testingDB.RespondToClientTransfer(ctx, *declined.ID, enums.ClientTransferStatusDeclined, staffID, "The facility is full")
+	if err != nil {
+		t.Errorf("PGInstance.RespondToClientTransfer() error = %v", err)
+		return
+	}
+	if got.Status != enums.ClientTransferStatusDeclined {
+		t.Errorf("expected the transfer to be declined, got %v", got.Status)
+	}
+	if _, err = testingDB.RespondToClientTransfer(ctx, *declined.ID, enums.ClientTransferStatusAccepted, staffID, ""); err == nil {
+		t.Errorf("expected an error when responding to a transfer that is no longer pending")
+	}
+
+	accepted, err := requestTransfer()
+	if err != nil {
+		t.Errorf("failed to request client transfer: %v", err)
+		return
+	}
+	if _, err = testingDB.RespondToClientTransfer(ctx, *accepted.ID, enums.ClientTransferStatusPending, staffID, ""); err == nil {
+		t.Errorf("expected an error when responding with a pending status")
+	}
+	got, err = testingDB.RespondToClientTransfer(ctx, *accepted.ID, enums.ClientTransferStatusAccepted, staffID, "")
+	if err != nil {
+		t.Errorf("PGInstance.RespondToClientTransfer() error = %v", err)
+		return
+	}
+	if got.Status != enums.ClientTransferStatusAccepted {
+		t.Errorf("expected the transfer to be accepted, got %v", got.Status)
+	}
+
+	var client gorm.Client
+	if err = pg.DB.Where("id", clientID).First(&client).Error; err != nil {
+		t.Errorf("failed to get client: %v", err)
+		return
+	}
+	if client.FacilityID != *toFacility.FacilityID {
+		t.Errorf("expected the client to be moved to facility %v, got %v", *toFacility.FacilityID, client.FacilityID)
+	}
+	history, err := testingDB.GetClientFacilityHistory(ctx, clientID)
+	if err != nil {
+		t.Errorf("failed to get client facility history: %v", err)
+		return
+	}
+	if len(history) < 2 || history[0].FacilityID != *toFacility.FacilityID || history[0].EndDate != nil {
+		t.Errorf("expected the client's current period to be at the receiving facility")
+	}
+
+	// TearDown
+	if err = pg.DB.Model(&gorm.Client{}).Where("id", clientID).Update("current_facility_id", facilityID).Error; err != nil {
+		t.Errorf("failed to restore client facility: %v", err)
+	}
+	if err = pg.DB.Where("client_id", clientID).Unscoped().Delete(&gorm.ClientFacility{}).Error; err != nil {
+		t.Errorf("failed to delete client facility periods: %v", err)
+	}
+	if err = pg.DB.Where("to_facility_id", toFacility.FacilityID).Unscoped().Delete(&gorm.ClientTransfer{}).Error; err != nil {
+		t.Errorf("failed to delete client transfers: %v", err)
+	}
+	if err = pg.DB.Where("facility_id", toFacility.FacilityID).Unscoped().Delete(&gorm.ClientServiceRequest{}).Error; err != nil {
+		t.Errorf("failed to delete service requests: %v", err)
+	}
+	if err = pg.DB.Where("id", toFacility.FacilityID).Unscoped().Delete(&gorm.Facility{}).Error; err != nil {
+		t.Errorf("failed to delete facility: %v", err)
+	}
+}
+
+func TestPGInstance_ContentEngagementIsIdempotent(t *testing.T) {
+	ctx := newTestContext()
+
+	likeCount := func() int {
+		var contentItem gorm.ContentItem
+		if err := testingDB.DB.Where("page_ptr_id", contentID2).First(&contentItem).Error; err != nil {
+			t.Errorf("failed to get content item: %v", err)
+		}
+		return contentItem.LikeCount
+	}
+	before := likeCount()
+
+	for i := 0; i < 2; i++ {
+		if _, err := testingDB.LikeContent(ctx, userID2, contentID2); err != nil {
+			t.Errorf("failed to like content: %v", err)
+			return
+		}
+	}
+	if got := likeCount(); got != before+1 {
+		t.Errorf("expected liking twice to count once, like count went from %v to %v", before, got)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := testingDB.UnlikeContent(ctx, userID2, contentID2); err != nil {
+			t.Errorf("failed to unlike content: %v", err)
+			return
+		}
+	}
+	if got := likeCount(); got != before {
+		t.Errorf("expected unliking to restore the like count to %v, got %v", before, got)
+	}
+}
+
+func TestPGInstance_ReconcileContentEngagement(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	var contentItem gorm.ContentItem
+	if err = pg.DB.Where("page_ptr_id", contentID2).First(&contentItem).Error; err != nil {
+		t.Errorf("failed to get content item: %v", err)
+		return
+	}
+	if err = pg.DB.Model(&gorm.ContentItem{}).Where("page_ptr_id", contentID2).Update("share_count", contentItem.ShareCount+100).Error; err != nil {
+		t.Errorf("failed to update share count: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx    context.Context
+		dryRun bool
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantDrift bool
+		wantFixed bool
+		wantErr   bool
+	}{
+		{
+			name: "Happy case - dry run reports drift",
+			args: args{
+				ctx:    ctx,
+				dryRun: true,
+			},
+			wantDrift: true,
+			wantFixed: false,
+			wantErr:   false,
+		},
+		{
+			name: "Happy case - drift is reported again after a dry run and the counters are rewritten",
+			args: args{
+				ctx:    ctx,
+				dryRun: false,
+			},
+			wantDrift: true,
+			wantFixed: true,
+			wantErr:   false,
+		},
+		{
+			name: "Happy case - no drift once the counters have been rewritten",
+			args: args{
+				ctx:    ctx,
+				dryRun: true,
+			},
+			wantDrift: false,
+			wantFixed: false,
+			wantErr:   false,
+		},
+		{
+			name: "Sad case - context without a logged in user",
+			args: args{
+				ctx:    context.Background(),
+				dryRun: true,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ReconcileContentEngagement(tt.args.ctx, tt.args.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ReconcileContentEngagement() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			drifted := false
+			for _, drift := range got.Drift {
+				if drift.ContentID == contentID2 && drift.Counter == "share_count" {
+					drifted = true
+				}
+			}
+			if drifted != tt.wantDrift {
+				t.Errorf("expected share count drift to be %v, got %v", tt.wantDrift, drifted)
+			}
+			if got.Fixed != tt.wantFixed {
+				t.Errorf("expected the report to be fixed %v, got %v", tt.wantFixed, got.Fixed)
+			}
+		})
+	}
+
+	if err = pg.DB.Model(&gorm.ContentItem{}).Where("page_ptr_id", contentID2).Update("share_count", contentItem.ShareCount).Error; err != nil {
+		t.Errorf("failed to restore share count: %v", err)
+		return
+	}
+}
+
+func TestPGInstance_RecordContentReadDuration(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx             context.Context
+		userID          string
+		contentID       int
+		durationSeconds int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:             ctx,
+				userID:          userID,
+				contentID:       contentID,
+				durationSeconds: 90,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx:             ctx,
+				contentID:       contentID,
+				durationSeconds: 90,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no contentID",
+			args: args{
+				ctx:             ctx,
+				userID:          userID,
+				durationSeconds: 90,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.RecordContentReadDuration(tt.args.ctx, tt.args.userID, tt.args.contentID, tt.args.durationSeconds)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.RecordContentReadDuration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.RecordContentReadDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// TearDown
+	if err = pg.DB.Where("user_id = ? AND content_item_id = ?", userID, contentID).Unscoped().Delete(&gorm.ContentReadEvent{}).Error; err != nil {
+		t.Errorf("failed to delete content read events: %v", err)
+	}
+}
+
+func TestPGInstance_RecordContentProgress(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	// the fixture content items take 90 seconds to read
+	threshold := domain.ContentCompletionThreshold{PercentScrolled: 90, ShareOfTimeEstimate: 0.5}
+
+	type args struct {
+		ctx    context.Context
+		report *gorm.ContentProgress
+	}
+	tests := []struct {
+		name              string
+		args              args
+		wantSecondsSpent  int
+		wantJustCompleted bool
+		wantErr           bool
+	}{
+		{
+			name: "Happy case - started reading",
+			args: args{
+				ctx:    ctx,
+				report: &gorm.ContentProgress{UserID: userID2, ContentID: contentID, SecondsSpent: 30, PercentScrolled: 50},
+			},
+			wantSecondsSpent:  30,
+			wantJustCompleted: false,
+			wantErr:           false,
+		},
+		{
+			name: "Happy case - scrolled to the end too quickly",
+			args: args{
+				ctx:    ctx,
+				report: &gorm.ContentProgress{UserID: userID2, ContentID: contentID, SecondsSpent: 5, PercentScrolled: 100},
+			},
+			wantSecondsSpent:  35,
+			wantJustCompleted: false,
+			wantErr:           false,
+		},
+		{
+			name: "Happy case - completed",
+			args: args{
+				ctx:    ctx,
+				report: &gorm.ContentProgress{UserID: userID2, ContentID: contentID, SecondsSpent: 20, PercentScrolled: 60},
+			},
+			wantSecondsSpent:  55,
+			wantJustCompleted: true,
+			wantErr:           false,
+		},
+		{
+			name: "Happy case - already completed",
+			args: args{
+				ctx:    ctx,
+				report: &gorm.ContentProgress{UserID: userID2, ContentID: contentID, SecondsSpent: 10, PercentScrolled: 100},
+			},
+			wantSecondsSpent:  65,
+			wantJustCompleted: false,
+			wantErr:           false,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx:    ctx,
+				report: &gorm.ContentProgress{ContentID: contentID, SecondsSpent: 10, PercentScrolled: 10},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, justCompleted, err := testingDB.RecordContentProgress(tt.args.ctx, tt.args.report, threshold)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.RecordContentProgress() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.SecondsSpent != tt.wantSecondsSpent {
+				t.Errorf("expected %v seconds spent, got %v", tt.wantSecondsSpent, got.SecondsSpent)
+			}
+			if justCompleted != tt.wantJustCompleted {
+				t.Errorf("expected just completed to be %v, got %v", tt.wantJustCompleted, justCompleted)
+			}
+		})
+	}
+
+	var completions int64
+	if err = pg.DB.Model(&gorm.ContentCompletionEvent{}).Where("user_id = ? AND content_item_id = ?", userID2, contentID).Count(&completions).Error; err != nil {
+		t.Errorf("failed to count content completions: %v", err)
+	}
+	if completions != 1 {
+		t.Errorf("expected one content completion, got %v", completions)
+	}
+
+	// TearDown
+	if err = pg.DB.Where("user_id = ? AND content_item_id = ?", userID2, contentID).Unscoped().Delete(&gorm.ContentCompletionEvent{}).Error; err != nil {
+		t.Errorf("failed to delete content completions: %v", err)
+	}
+	if err = pg.DB.Where("user_id = ? AND content_item_id = ?", userID2, contentID).Unscoped().Delete(&gorm.ContentProgress{}).Error; err != nil {
+		t.Errorf("failed to delete content progress: %v", err)
+	}
+}
+
+func TestPGInstance_RecordContentShareOpen(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	shareLink, err := testingDB.ShareContent(ctx, dto.ShareContentInput{UserID: userID, ContentID: contentID, Channel: enums.ContentShareChannelSMS})
+	if err != nil {
+		t.Errorf("failed to share content: %v", err)
+		return
+	}
+	sharedBy := userID
+	openedBy := userID2
+
+	type args struct {
+		ctx         context.Context
+		shareLinkID string
+		openedByID  *string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case - opened by another user",
+			args: args{
+				ctx:         ctx,
+				shareLinkID: *shareLink.ID,
+				openedByID:  &openedBy,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - opened again by the same user",
+			args: args{
+				ctx:         ctx,
+				shareLinkID: *shareLink.ID,
+				openedByID:  &openedBy,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - opened on the web",
+			args: args{
+				ctx:         ctx,
+				shareLinkID: *shareLink.ID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - opened by the sharer",
+			args: args{
+				ctx:         ctx,
+				shareLinkID: *shareLink.ID,
+				openedByID:  &sharedBy,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - unknown share link",
+			args: args{
+				ctx:         ctx,
+				shareLinkID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.RecordContentShareOpen(tt.args.ctx, tt.args.shareLinkID, tt.args.openedByID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.RecordContentShareOpen() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.UserID != userID {
+				t.Errorf("expected the link to be attributed to %v, got %v", userID, got.UserID)
+			}
+		})
+	}
+
+	var opens int64
+	if err = pg.DB.Model(&gorm.ContentShareOpen{}).Where("share_link_id = ?", *shareLink.ID).Count(&opens).Error; err != nil {
+		t.Errorf("failed to count content share opens: %v", err)
+	}
+	if opens != 2 {
+		t.Errorf("expected two content share opens, got %v", opens)
+	}
+
+	// TearDown
+	if err = pg.DB.Where("share_link_id = ?", *shareLink.ID).Unscoped().Delete(&gorm.ContentShareOpen{}).Error; err != nil {
+		t.Errorf("failed to delete content share opens: %v", err)
+	}
+	if err = pg.DB.Where("id = ?", *shareLink.ID).Unscoped().Delete(&gorm.ContentShareLink{}).Error; err != nil {
+		t.Errorf("failed to delete content share link: %v", err)
+	}
+}
+
+func TestPGInstance_CreateContentComment(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx     context.Context
+		comment *gorm.ContentComment
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx: ctx,
+				comment: &gorm.ContentComment{
+					ContentID: contentID,
+					UserID:    userID,
+					Body:      "How long should I take the medication?",
+					Status:    enums.ContentCommentStatusApproved,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx: ctx,
+				comment: &gorm.ContentComment{
+					ContentID: contentID,
+					Body:      "How long should I take the medication?",
+					Status:    enums.ContentCommentStatusApproved,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.CreateContentComment(tt.args.ctx, tt.args.comment)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.CreateContentComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Nickname == "" {
+				t.Errorf("expected the comment to have the nickname of the user who wrote it")
+			}
+		})
+	}
+
+	// TearDown
+	if err = pg.DB.Where("user_id = ? AND content_item_id = ?", userID, contentID).Unscoped().Delete(&gorm.ContentComment{}).Error; err != nil {
+		t.Errorf("failed to delete content comments: %v", err)
+	}
+}
+
+func TestPGInstance_EditContentComment(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	comment, err := testingDB.CreateContentComment(ctx, &gorm.ContentComment{
+		ContentID: contentID,
+		UserID:    userID,
+		Body:      "How long should I take the medication?",
+		Status:    enums.ContentCommentStatusApproved,
+	})
+	if err != nil {
+		t.Errorf("failed to create content comment: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx       context.Context
+		commentID string
+		body      string
+		status    enums.ContentCommentStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				commentID: *comment.Comment.ID,
+				body:      "How long should I take the medication for?",
+				status:    enums.ContentCommentStatusApproved,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - comment does not exist",
+			args: args{
+				ctx:       ctx,
+				commentID: uuid.New().String(),
+				body:      "How long should I take the medication for?",
+				status:    enums.ContentCommentStatusApproved,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.EditContentComment(tt.args.ctx, tt.args.commentID, tt.args.body, tt.args.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.EditContentComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.Comment.Body != tt.args.body || got.Comment.EditedAt == nil) {
+				t.Errorf("expected the comment to be edited, got %v", got.Comment)
+			}
+		})
+	}
+
+	// TearDown
+	if err = pg.DB.Where("id = ?", *comment.Comment.ID).Unscoped().Delete(&gorm.ContentComment{}).Error; err != nil {
+		t.Errorf("failed to delete content comment: %v", err)
+	}
+}
+
+func TestPGInstance_ModerateContentComment(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	comment, err := testingDB.CreateContentComment(ctx, &gorm.ContentComment{
+		ContentID: contentID,
+		UserID:    userID,
+		Body:      "How long should I take the medication?",
+		Status:    enums.ContentCommentStatusPending,
+	})
+	if err != nil {
+		t.Errorf("failed to create content comment: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx         context.Context
+		commentID   string
+		status      enums.ContentCommentStatus
+		moderatorID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:         ctx,
+				commentID:   *comment.Comment.ID,
+				status:      enums.ContentCommentStatusApproved,
+				moderatorID: userID2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no moderator",
+			args: args{
+				ctx:       ctx,
+				commentID: *comment.Comment.ID,
+				status:    enums.ContentCommentStatusApproved,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - comment does not exist",
+			args: args{
+				ctx:         ctx,
+				commentID:   uuid.New().String(),
+				status:      enums.ContentCommentStatusRejected,
+				moderatorID: userID2,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ModerateContentComment(tt.args.ctx, tt.args.commentID, tt.args.status, tt.args.moderatorID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ModerateContentComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.Comment.Status != tt.args.status || got.Comment.ModeratedByID == nil) {
+				t.Errorf("expected the comment to be moderated, got %v", got.Comment)
+			}
+		})
+	}
+
+	// TearDown
+	if err = pg.DB.Where("id = ?", *comment.Comment.ID).Unscoped().Delete(&gorm.ContentComment{}).Error; err != nil {
+		t.Errorf("failed to delete content comment: %v", err)
+	}
+}
+
+func TestPGInstance_DeleteContentComment(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	comment, err := testingDB.CreateContentComment(ctx, &gorm.ContentComment{
+		ContentID: contentID,
+		UserID:    userID,
+		Body:      "How long should I take the medication?",
+		Status:    enums.ContentCommentStatusApproved,
+	})
+	if err != nil {
+		t.Errorf("failed to create content comment: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx       context.Context
+		commentID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				commentID: *comment.Comment.ID,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - already deleted",
+			args: args{
+				ctx:       ctx,
+				commentID: *comment.Comment.ID,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no commentID",
+			args: args{
+				ctx: ctx,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.DeleteContentComment(tt.args.ctx, tt.args.commentID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.DeleteContentComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.DeleteContentComment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	deleted, err := testingDB.GetContentCommentByID(ctx, *comment.Comment.ID)
+	if err != nil {
+		t.Errorf("failed to get deleted content comment: %v", err)
+	} else if deleted.Comment.Active {
+		t.Errorf("expected the content comment to be marked as deleted")
+	}
+
+	// TearDown
+	if err = pg.DB.Where("id = ?", *comment.Comment.ID).Unscoped().Delete(&gorm.ContentComment{}).Error; err != nil {
+		t.Errorf("failed to delete content comment: %v", err)
+	}
+}
+
+func TestPGInstance_RecordBulkInviteRowOutcome(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	job, err := testingDB.CreateBulkInviteJob(ctx, &gorm.BulkInviteJob{
+		Status:      enums.BulkInviteJobStatusPending,
+		Flavour:     testFlavour,
+		Total:       2,
+		CreatedByID: userID,
+		Rows: []*gorm.BulkInviteRow{
+			{RowNumber: 1, UserID: userID, PhoneNumber: testPhone, Status: enums.BulkInviteRowStatusPending},
+			{RowNumber: 2, UserID: userID2, PhoneNumber: testPhone, Status: enums.BulkInviteRowStatusPending},
+		},
+	})
+	if err != nil {
+		t.Errorf("failed to create bulk invite job: %v", err)
+		return
+	}
+
+	if _, err = testingDB.UpdateBulkInviteJobStatus(ctx, *job.ID, enums.BulkInviteJobStatusInProgress); err != nil {
+		t.Errorf("failed to start bulk invite job: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx          context.Context
+		jobID        string
+		rowID        string
+		status       enums.BulkInviteRowStatus
+		errorMessage string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case - success",
+			args: args{
+				ctx:    ctx,
+				jobID:  *job.ID,
+				rowID:  *job.Rows[0].ID,
+				status: enums.BulkInviteRowStatusSuccess,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - failure",
+			args: args{
+				ctx:          ctx,
+				jobID:        *job.ID,
+				rowID:        *job.Rows[1].ID,
+				status:       enums.BulkInviteRowStatusFailed,
+				errorMessage: "invalid phone number",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - row is no longer pending",
+			args: args{
+				ctx:    ctx,
+				jobID:  *job.ID,
+				rowID:  *job.Rows[0].ID,
+				status: enums.BulkInviteRowStatusSuccess,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid status",
+			args: args{
+				ctx:    ctx,
+				jobID:  *job.ID,
+				rowID:  *job.Rows[0].ID,
+				status: enums.BulkInviteRowStatusPending,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.RecordBulkInviteRowOutcome(tt.args.ctx, tt.args.jobID, tt.args.rowID, tt.args.status, tt.args.errorMessage)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.RecordBulkInviteRowOutcome() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.RecordBulkInviteRowOutcome() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	completed, err := testingDB.UpdateBulkInviteJobStatus(ctx, *job.ID, enums.BulkInviteJobStatusCompleted)
+	if err != nil || !completed {
+		t.Errorf("failed to complete bulk invite job: %v", err)
+	}
+
+	saved, err := testingDB.GetBulkInviteJob(ctx, *job.ID)
+	if err != nil {
+		t.Errorf("failed to get bulk invite job: %v", err)
+		return
+	}
+	if saved.Succeeded != 1 || saved.Failed != 1 || saved.CompletedAt == nil {
+		t.Errorf("expected a completed job with one success and one failure, got %v", saved)
+	}
+	if saved.Rows[1].Error != "invalid phone number" {
+		t.Errorf("expected the failed row to keep its error, got %v", saved.Rows[1].Error)
+	}
+
+	// TearDown
+	if err = pg.DB.Where("job_id = ?", *job.ID).Unscoped().Delete(&gorm.BulkInviteRow{}).Error; err != nil {
+		t.Errorf("failed to delete bulk invite rows: %v", err)
+	}
+	if err = pg.DB.Where("id = ?", *job.ID).Unscoped().Delete(&gorm.BulkInviteJob{}).Error; err != nil {
+		t.Errorf("failed to delete bulk invite job: %v", err)
+	}
+}
+
+func TestPGInstance_FailInterruptedBulkInviteJobs(t *testing.T) {
+	ctx := newTestContext()
+
+	pg, err := newTestPGInstance()
+	if err != nil {
+		t.Errorf("failed to initialize new PG instance: %v", err)
+		return
+	}
+
+	job, err := testingDB.CreateBulkInviteJob(ctx, &gorm.BulkInviteJob{
+		Status:      enums.BulkInviteJobStatusPending,
+		Flavour:     testFlavour,
+		Total:       2,
+		CreatedByID: userID,
+		Rows: []*gorm.BulkInviteRow{
+			{RowNumber: 1, UserID: userID, PhoneNumber: testPhone, Status: enums.BulkInviteRowStatusPending},
+			{RowNumber: 2, UserID: userID2, PhoneNumber: testPhone, Status: enums.BulkInviteRowStatusPending},
+		},
+	})
+	if err != nil {
+		t.Errorf("failed to create bulk invite job: %v", err)
+		return
+	}
+	if _, err = testingDB.UpdateBulkInviteJobStatus(ctx, *job.ID, enums.BulkInviteJobStatusInProgress); err != nil {
+		t.Errorf("failed to start bulk invite job: %v", err)
+		return
+	}
+	if _, err = testingDB.RecordBulkInviteRowOutcome(ctx, *job.ID, *job.Rows[0].ID, enums.BulkInviteRowStatusSuccess, ""); err != nil {
+		t.Errorf("failed to record bulk invite row outcome: %v", err)
+		return
+	}
+
+	// the job has just recorded progress hence it is still running
+	failed, err := testingDB.FailInterruptedBulkInviteJobs(ctx, time.Hour)
+	if err != nil {
+		t.Errorf("PGInstance.FailInterruptedBulkInviteJobs() error = %v", err)
+		return
+	}
+	saved, err := testingDB.GetBulkInviteJob(ctx, *job.ID)
+	if err != nil {
+		t.Errorf("failed to get bulk invite job: %v", err)
+		return
+	}
+	if saved.Status != enums.BulkInviteJobStatusInProgress {
+		t.Errorf("expected a running job to be left in progress, got %v after failing %v jobs", saved.Status, failed)
+	}
+
+	if err = pg.DB.Model(&gorm.BulkInviteJob{}).Where("id = ?", *job.ID).UpdateColumn("updated", time.Now().Add(-2*time.Hour)).Error; err != nil {
+		t.Errorf("failed to age bulk invite job: %v", err)
+		return
+	}
+	failed, err = testingDB.FailInterruptedBulkInviteJobs(ctx, time.Hour)
+	if err != nil {
+		t.Errorf("PGInstance.FailInterruptedBulkInviteJobs() error = %v", err)
+		return
+	}
+	if failed < 1 {
+		t.Errorf("expected the interrupted job to be failed")
+	}
+	saved, err = testingDB.GetBulkInviteJob(ctx, *job.ID)
+	if err != nil {
+		t.Errorf("failed to get bulk invite job: %v", err)
+		return
+	}
+	if saved.Status != enums.BulkInviteJobStatusFailed || saved.Succeeded != 1 || saved.Failed != 1 || saved.CompletedAt == nil {
+		t.Errorf("expected a failed job with one success and one failure, got %v", saved)
+	}
+	if saved.Rows[0].Status != enums.BulkInviteRowStatusSuccess || saved.Rows[1].Status != enums.BulkInviteRowStatusFailed {
+		t.Errorf("expected only the unprocessed row to be failed, got %v and %v", saved.Rows[0].Status, saved.Rows[1].Status)
+	}
+	if _, err = testingDB.UpdateBulkInviteJobStatus(ctx, *job.ID, enums.BulkInviteJobStatusCompleted); err == nil {
+		t.Errorf("expected a failed job not to be completed")
+	}
+
+	// TearDown
+	if err = pg.DB.Where("job_id = ?", *job.ID).Unscoped().Delete(&gorm.BulkInviteRow{}).Error; err != nil {
+		t.Errorf("failed to delete bulk invite rows: %v", err)
+	}
+	if err = pg.DB.Where("id = ?", *job.ID).Unscoped().Delete(&gorm.BulkInviteJob{}).Error; err != nil {
+		t.Errorf("failed to delete bulk invite job: %v", err)
+	}
 }
@@ -1,8 +1,16 @@
 package postgres
 
 import (
+	"context"
+	"time"
+
+	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
+	"github.com/savannahghi/serverutils"
 )
 
 // a helper method to create mapped user
@@ -15,6 +23,11 @@ func createMapUser(userObject *gorm.User) *domain.User {
 		OptedIn:      userObject.Contacts.OptedIn,
 	}
 
+	languages := []enumutils.Language{}
+	for _, language := range userObject.Languages {
+		languages = append(languages, enumutils.Language(language))
+	}
+
 	user := &domain.User{
 		ID:                     userObject.UserID,
 		Username:               userObject.Username,
@@ -24,6 +37,7 @@ func createMapUser(userObject *gorm.User) *domain.User {
 		Gender:                 userObject.Gender,
 		UserType:               userObject.UserType,
 		Contacts:               contact,
+		Languages:              languages,
 		Active:                 userObject.Active,
 		LastSuccessfulLogin:    userObject.LastSuccessfulLogin,
 		LastFailedLogin:        userObject.LastFailedLogin,
@@ -35,6 +49,7 @@ func createMapUser(userObject *gorm.User) *domain.User {
 		HasSetPin:              userObject.HasSetPin,
 		HasSetSecurityQuestion: userObject.HasSetSecurityQuestion,
 		IsPhoneVerified:        userObject.IsPhoneVerified,
+		OrganisationID:         userObject.OrganisationID,
 	}
 	return user
 }
@@ -46,14 +61,91 @@ func (d *MyCareHubDb) mapFacilityObjectToDomain(facilityObject *gorm.Facility) *
 		return nil
 	}
 
-	return &domain.Facility{
-		ID:          facilityObject.FacilityID,
-		Name:        facilityObject.Name,
-		Code:        facilityObject.Code,
-		Phone:       facilityObject.Phone,
-		Active:      facilityObject.Active,
-		County:      facilityObject.County,
-		Description: facilityObject.Description,
+	facility := &domain.Facility{
+		ID:              facilityObject.FacilityID,
+		Name:            facilityObject.Name,
+		Code:            facilityObject.Code,
+		Phone:           facilityObject.Phone,
+		Active:          facilityObject.Active,
+		County:          facilityObject.County,
+		Description:     facilityObject.Description,
+		Latitude:        facilityObject.Latitude,
+		Longitude:       facilityObject.Longitude,
+		Address:         facilityObject.Address,
+		OpeningHours:    []*domain.FacilityOpeningHours{},
+		HoursExceptions: []*domain.FacilityHoursException{},
+		Services:        []*domain.FacilityService{},
+	}
+	for _, hours := range facilityObject.OpeningHours {
+		facility.OpeningHours = append(facility.OpeningHours, &domain.FacilityOpeningHours{
+			DayOfWeek: hours.DayOfWeek,
+			OpensAt:   formatTimeOfDay(hours.OpensAt),
+			ClosesAt:  formatTimeOfDay(hours.ClosesAt),
+		})
+	}
+	for _, exception := range facilityObject.HoursExceptions {
+		hoursException := &domain.FacilityHoursException{
+			Date:   exception.Date.Format(common.DateLayout),
+			Closed: exception.Closed,
+			Reason: exception.Reason,
+		}
+		if exception.OpensAt != nil && exception.ClosesAt != nil {
+			opensAt, closesAt := formatTimeOfDay(*exception.OpensAt), formatTimeOfDay(*exception.ClosesAt)
+			hoursException.OpensAt, hoursException.ClosesAt = &opensAt, &closesAt
+		}
+		facility.HoursExceptions = append(facility.HoursExceptions, hoursException)
+	}
+	for _, service := range facilityObject.Services {
+		facility.Services = append(facility.Services, mapFacilityServiceToDomain(service))
+	}
+	return facility
+}
+
+// formatTimeOfDay drops the seconds from a time read from the database e.g 08:00:00 becomes 08:00
+func formatTimeOfDay(value string) string {
+	if len(value) > len(common.TimeOfDayLayout) {
+		return value[:len(common.TimeOfDayLayout)]
+	}
+	return value
+}
+
+// mapFacilityServiceToDomain maps a db facility service to a domain model
+func mapFacilityServiceToDomain(service *gorm.FacilityService) *domain.FacilityService {
+	return &domain.FacilityService{
+		ID:          service.ID,
+		Name:        service.Name,
+		Code:        service.Code,
+		Description: service.Description,
+		Active:      service.Active,
+	}
+}
+
+// mapFiltersInputToParams maps the filters in a request to the filter params used in queries, skipping empty filters
+func mapFiltersInputToParams(filterInput []*dto.FiltersInput) []*domain.FiltersParam {
+	filters := []*domain.FiltersParam{}
+	for _, f := range filterInput {
+		if f == nil {
+			continue
+		}
+		filters = append(filters, &domain.FiltersParam{
+			Name:     string(f.DataType),
+			DataType: f.DataType,
+			Value:    f.Value,
+		})
+	}
+	return filters
+}
+
+// mapFacilityHistoryToDomain maps a db facility history entry to a domain model
+func mapFacilityHistoryToDomain(history *gorm.FacilityHistory) *domain.FacilityHistory {
+	return &domain.FacilityHistory{
+		ID:         history.ID,
+		FacilityID: history.FacilityID,
+		Field:      history.Field,
+		OldValue:   history.OldValue,
+		NewValue:   history.NewValue,
+		ChangedBy:  history.ChangedBy,
+		ChangedAt:  history.ChangedAt,
 	}
 }
 
@@ -64,3 +156,357 @@ func (d *MyCareHubDb) mapProfileObjectToDomain(profileObject *gorm.User) *domain
 	}
 	return createMapUser(profileObject)
 }
+
+// mapInvitationObjectToDomain maps the db invitation to a domain model
+func mapInvitationObjectToDomain(invitation *gorm.Invitation) *domain.Invitation {
+	if invitation == nil {
+		return nil
+	}
+	return &domain.Invitation{
+		ID:          *invitation.ID,
+		UserID:      invitation.UserID,
+		PhoneNumber: invitation.PhoneNumber,
+		Flavour:     invitation.Flavour,
+		Status:      invitation.Status,
+		IssuedAt:    invitation.IssuedAt,
+		DeliveredAt: invitation.DeliveredAt,
+		AcceptedAt:  invitation.AcceptedAt,
+		ExpiresAt:   invitation.ExpiresAt,
+	}
+}
+
+// mapRegistrationUserToObject maps a domain user and their primary contact to the db models
+// that are created when registering the user
+func mapRegistrationUserToObject(user *domain.User) (*gorm.User, *gorm.Contact) {
+	languages := []string{}
+	for _, language := range user.Languages {
+		languages = append(languages, language.String())
+	}
+
+	userObject := &gorm.User{
+		Username:   user.Username,
+		FirstName:  user.FirstName,
+		MiddleName: user.MiddleName,
+		LastName:   user.LastName,
+		UserType:   user.UserType,
+		Gender:     user.Gender,
+		Active:     user.Active,
+		Languages:  languages,
+		Flavour:    user.Flavour,
+	}
+	contactObject := &gorm.Contact{
+		ContactType:  user.Contacts.ContactType,
+		ContactValue: user.Contacts.ContactValue,
+		Active:       user.Contacts.Active,
+		OptedIn:      user.Contacts.OptedIn,
+		Flavour:      user.Flavour,
+	}
+	return userObject, contactObject
+}
+
+// mapClientObjectToDomain maps the db client profile to a domain model
+func mapClientObjectToDomain(client *gorm.Client) *domain.ClientProfile {
+	if client == nil {
+		return nil
+	}
+
+	var userID string
+	if client.UserID != nil {
+		userID = *client.UserID
+	}
+	return &domain.ClientProfile{
+		ID:                      client.ID,
+		User:                    createMapUser(&client.UserProfile),
+		Active:                  client.Active,
+		ClientType:              client.ClientType,
+		UserID:                  userID,
+		TreatmentEnrollmentDate: client.TreatmentEnrollmentDate,
+		FHIRPatientID:           client.FHIRPatientID,
+		HealthRecordID:          client.HealthRecordID,
+		TreatmentBuddy:          client.TreatmentBuddy,
+		ClientCounselled:        client.ClientCounselled,
+		OrganisationID:          client.OrganisationID,
+		FacilityID:              client.FacilityID,
+		CHVUserID:               client.CHVUserID,
+	}
+}
+
+// mapStaffObjectToDomain maps the db staff profile to a domain model
+func mapStaffObjectToDomain(staff *gorm.StaffProfile) *domain.StaffProfile {
+	if staff == nil {
+		return nil
+	}
+
+	var userID string
+	if staff.UserID != nil {
+		userID = *staff.UserID
+	}
+	return &domain.StaffProfile{
+		ID:                staff.ID,
+		User:              createMapUser(&staff.UserProfile),
+		UserID:            userID,
+		Active:            staff.Active,
+		StaffNumber:       staff.StaffNumber,
+		Cadre:             staff.Cadre,
+		DefaultFacilityID: staff.DefaultFacilityID,
+		OrganisationID:    staff.OrganisationID,
+	}
+}
+
+// mapStaffWithFacilitiesToDomain maps the db staff profile to a domain model together with
+// the facilities that the staff member can access
+func (d *MyCareHubDb) mapStaffWithFacilitiesToDomain(ctx context.Context, staff *gorm.StaffProfile) (*domain.StaffProfile, error) {
+	staffProfile := mapStaffObjectToDomain(staff)
+	if staffProfile == nil || staffProfile.ID == nil {
+		return staffProfile, nil
+	}
+
+	facilities, err := d.GetStaffFacilities(ctx, *staffProfile.ID)
+	if err != nil {
+		return nil, err
+	}
+	staffProfile.Facilities = facilities
+	return staffProfile, nil
+}
+
+// mapOrganisationToDomain maps the db organisation to a domain model. The platform defaults
+// are used for the settings that the organisation has not set
+func mapOrganisationToDomain(organisation *gorm.Organisation) (*domain.Organisation, error) {
+	settings, err := helpers.DefaultOrganisationSettings()
+	if err != nil {
+		return nil, err
+	}
+	if organisation.PINExpiryDays != nil {
+		settings.PINExpiryDays = *organisation.PINExpiryDays
+	}
+	if organisation.InvitePINExpiryDays != nil {
+		settings.InvitePINExpiryDays = *organisation.InvitePINExpiryDays
+	}
+	if organisation.DiaryCadenceHours != nil {
+		settings.DiaryCadenceHours = *organisation.DiaryCadenceHours
+	}
+	if organisation.SMSSenderID != nil {
+		settings.SMSSenderID = enumutils.SenderID(*organisation.SMSSenderID)
+	}
+
+	return &domain.Organisation{
+		ID:              organisation.OrganisationID,
+		Active:          organisation.Active,
+		OrgCode:         organisation.OrgCode,
+		Code:            organisation.Code,
+		Name:            organisation.OrganisationName,
+		EmailAddress:    organisation.EmailAddress,
+		PhoneNumber:     organisation.PhoneNumber,
+		PostalAddress:   organisation.PostalAddress,
+		PhysicalAddress: organisation.PhysicalAddress,
+		DefaultCountry:  organisation.DefaultCountry,
+		Settings:        settings,
+	}, nil
+}
+
+// mapServiceRequestToObject maps a domain service request to the db model. Service requests that are not
+// raised with a particular facility have no facility ID
+func mapServiceRequestToObject(serviceRequest *domain.ClientServiceRequest) *gorm.ClientServiceRequest {
+	serviceRequestObject := &gorm.ClientServiceRequest{
+		Active:       serviceRequest.Active,
+		RequestType:  serviceRequest.RequestType,
+		Request:      serviceRequest.Request,
+		Status:       serviceRequest.Status,
+		InProgressAt: serviceRequest.InProgressAt,
+		ResolvedAt:   serviceRequest.ResolvedAt,
+		ClientID:     serviceRequest.ClientID,
+	}
+	if serviceRequest.FacilityID != "" {
+		serviceRequestObject.FacilityID = &serviceRequest.FacilityID
+	}
+	return serviceRequestObject
+}
+
+// mapClientTransferToDomain maps a db client transfer to a domain model
+func mapClientTransferToDomain(transfer *gorm.ClientTransfer) *domain.ClientTransfer {
+	var id string
+	if transfer.ID != nil {
+		id = *transfer.ID
+	}
+	return &domain.ClientTransfer{
+		ID:               id,
+		ClientID:         transfer.ClientID,
+		FromFacilityID:   transfer.FromFacilityID,
+		ToFacilityID:     transfer.ToFacilityID,
+		Reason:           transfer.Reason,
+		Status:           transfer.Status,
+		RequestedByID:    transfer.RequestedByID,
+		RequestedAt:      transfer.RequestedAt,
+		RespondedByID:    transfer.RespondedByID,
+		RespondedAt:      transfer.RespondedAt,
+		ResponseNote:     transfer.ResponseNote,
+		ServiceRequestID: transfer.ServiceRequestID,
+	}
+}
+
+// mapClientFacilityToDomain maps a db client facility period to a domain model
+func mapClientFacilityToDomain(period *gorm.ClientFacility) *domain.ClientFacilityPeriod {
+	var id string
+	if period.ID != nil {
+		id = *period.ID
+	}
+	return &domain.ClientFacilityPeriod{
+		ID:         id,
+		ClientID:   period.ClientID,
+		FacilityID: period.FacilityID,
+		StartDate:  period.StartDate,
+		EndDate:    period.EndDate,
+		TransferID: period.TransferID,
+	}
+}
+
+// mapContentProgressToDomain maps a db content progress to a domain model
+func mapContentProgressToDomain(progress *gorm.ContentProgress) *domain.ContentProgress {
+	return &domain.ContentProgress{
+		ContentID:       progress.ContentID,
+		SecondsSpent:    progress.SecondsSpent,
+		PercentScrolled: progress.PercentScrolled,
+		Completed:       progress.CompletedAt != nil,
+		CompletedAt:     progress.CompletedAt,
+		UpdatedAt:       progress.UpdatedAt,
+	}
+}
+
+// mapContentShareLinkToDomain maps a content share link read from the database to the domain model. The link
+// itself is signed by the content usecase
+func mapContentShareLinkToDomain(shareLink *gorm.ContentShareLink) *domain.ContentShareLink {
+	return &domain.ContentShareLink{
+		ID:        *shareLink.ID,
+		ContentID: shareLink.ContentID,
+		UserID:    shareLink.UserID,
+		Channel:   shareLink.Channel,
+		CreatedAt: shareLink.CreatedAt,
+	}
+}
+
+// mapContentCommentToDomain maps a content comment read from the database to the domain model
+func mapContentCommentToDomain(details *gorm.ContentCommentDetails) *domain.ContentComment {
+	return &domain.ContentComment{
+		ID:        *details.Comment.ID,
+		ContentID: details.Comment.ContentID,
+		UserID:    details.Comment.UserID,
+		ParentID:  details.Comment.ParentID,
+		Nickname:  details.Nickname,
+		Body:      details.Comment.Body,
+		Status:    details.Comment.Status,
+		Deleted:   !details.Comment.Active,
+		EditedAt:  details.Comment.EditedAt,
+		CreatedAt: details.Comment.CreatedAt,
+		Replies:   []*domain.ContentComment{},
+	}
+}
+
+// mapContentCommentsToDomain maps content comments read from the database to the domain model
+func mapContentCommentsToDomain(comments []*gorm.ContentCommentDetails) []*domain.ContentComment {
+	mapped := []*domain.ContentComment{}
+	for _, comment := range comments {
+		mapped = append(mapped, mapContentCommentToDomain(comment))
+	}
+	return mapped
+}
+
+// mapContentItemsDetailsToContent builds a page of content from content items read from the database
+func mapContentItemsDetailsToContent(contentItems []*gorm.ContentItemDetails, totalCount int) *domain.Content {
+	storageURL := serverutils.MustGetEnvVar(helpers.GoogleCloudStorageURL)
+	content := &domain.Content{
+		Meta:  domain.Meta{TotalCount: totalCount},
+		Items: []domain.ContentItem{},
+	}
+	for _, contentItem := range contentItems {
+		content.Items = append(content.Items, mapContentItemDetailsToDomain(contentItem, storageURL))
+	}
+	return content
+}
+
+// mapContentItemDetailsToDomain builds a content item the same way the CMS API would present it.
+// Image files are stored relative to the storage bucket hence the bucket URL is prepended to them
+func mapContentItemDetailsToDomain(item *gorm.ContentItemDetails, storageURL string) domain.ContentItem {
+	contentItem := domain.ContentItem{
+		ID: item.ContentItem.PagePtrID,
+		Meta: domain.ContentMeta{
+			ContentType:       "content.ContentItem",
+			Slug:              item.Page.Slug,
+			ShowInMenus:       item.Page.ShowInMenus,
+			SEOTitle:          item.Page.SEOTitle,
+			SearchDescription: item.Page.SearchDescription,
+		},
+		Title:               item.Page.Title,
+		Date:                item.ContentItem.Date.Format("2006-01-02"),
+		Intro:               item.ContentItem.Intro,
+		Author:              domain.Author{ID: item.ContentItem.AuthorID},
+		AuthorName:          item.Author.Name,
+		ItemType:            item.ContentItem.ItemType,
+		TimeEstimateSeconds: item.ContentItem.TimeEstimateSeconds,
+		Body:                item.ContentItem.Body,
+		TagNames:            item.TagNames,
+		LikeCount:           item.ContentItem.LikeCount,
+		BookmarkCount:       item.ContentItem.BookmarkCount,
+		ViewCount:           item.ContentItem.ViewCount,
+		ShareCount:          item.ContentItem.ShareCount,
+		Documents:           []domain.Document{},
+		CategoryDetails:     []domain.CategoryDetail{},
+		FeaturedMedia:       []domain.FeaturedMedia{},
+		GalleryImages:       []domain.GalleryImage{},
+	}
+	if contentItem.TagNames == nil {
+		contentItem.TagNames = []string{}
+	}
+	if item.Page.FirstPublishedAt != nil {
+		contentItem.Meta.FirstPublishedAt = item.Page.FirstPublishedAt.Format(time.RFC3339)
+	}
+	if item.HeroImage != nil {
+		contentItem.HeroImage = domain.HeroImage{
+			ID:    item.HeroImage.ID,
+			Title: item.HeroImage.Title,
+		}
+		contentItem.HeroImageRendition = domain.HeroImageRendition{
+			URL:    storageURL + item.HeroImage.File,
+			Width:  item.HeroImage.Width,
+			Height: item.HeroImage.Height,
+			Alt:    item.HeroImage.Title,
+		}
+	}
+	for _, category := range item.Categories {
+		categoryDetail := domain.CategoryDetail{
+			ID:           category.Category.ID,
+			CategoryName: category.Category.Name,
+		}
+		if category.Icon != nil {
+			categoryDetail.CategoryIcon = storageURL + category.Icon.File
+		}
+		contentItem.CategoryDetails = append(contentItem.CategoryDetails, categoryDetail)
+	}
+	return contentItem
+}
+
+// mapBulkInviteJobToDomain maps a bulk invite job and its rows to the domain model
+func mapBulkInviteJobToDomain(job *gorm.BulkInviteJob) *domain.BulkInviteJob {
+	rows := []*domain.BulkInviteRow{}
+	for _, row := range job.Rows {
+		rows = append(rows, &domain.BulkInviteRow{
+			ID:          *row.ID,
+			RowNumber:   row.RowNumber,
+			UserID:      row.UserID,
+			PhoneNumber: row.PhoneNumber,
+			Status:      row.Status,
+			Error:       row.Error,
+		})
+	}
+	return &domain.BulkInviteJob{
+		ID:          *job.ID,
+		Status:      job.Status,
+		Flavour:     job.Flavour,
+		Total:       job.Total,
+		Succeeded:   job.Succeeded,
+		Failed:      job.Failed,
+		Rows:        rows,
+		CreatedBy:   job.CreatedByID,
+		CreatedAt:   job.CreatedAt,
+		CompletedAt: job.CompletedAt,
+	}
+}
@@ -2,7 +2,12 @@ package postgres
 
 import (
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
 	"github.com/segmentio/ksuid"
 )
@@ -38,3 +43,107 @@ func Test_createMapUser(t *testing.T) {
 		})
 	}
 }
+
+func Test_mapOrganisationToDomain(t *testing.T) {
+	id := uuid.New().String()
+	pinExpiryDays := 90
+	senderID := enumutils.SenderIDSLADE360.String()
+
+	defaults, err := helpers.DefaultOrganisationSettings()
+	if err != nil {
+		t.Errorf("failed to get default organisation settings: %v", err)
+		return
+	}
+
+	tests := []struct {
+		name                 string
+		organisation         *gorm.Organisation
+		wantPINExpiryDays    int
+		wantInviteExpiryDays int
+		wantSenderID         enumutils.SenderID
+	}{
+		{
+			name: "Happy case - settings not set use the defaults",
+			organisation: &gorm.Organisation{
+				OrganisationID:   &id,
+				OrganisationName: "Test Organisation",
+			},
+			wantPINExpiryDays:    defaults.PINExpiryDays,
+			wantInviteExpiryDays: defaults.InvitePINExpiryDays,
+			wantSenderID:         defaults.SMSSenderID,
+		},
+		{
+			name: "Happy case - settings set override the defaults",
+			organisation: &gorm.Organisation{
+				OrganisationID:   &id,
+				OrganisationName: "Test Organisation",
+				PINExpiryDays:    &pinExpiryDays,
+				SMSSenderID:      &senderID,
+			},
+			wantPINExpiryDays:    pinExpiryDays,
+			wantInviteExpiryDays: defaults.InvitePINExpiryDays,
+			wantSenderID:         enumutils.SenderIDSLADE360,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mapOrganisationToDomain(tt.organisation)
+			if err != nil {
+				t.Errorf("mapOrganisationToDomain() error = %v", err)
+				return
+			}
+			if got.Name != tt.organisation.OrganisationName || *got.ID != id {
+				t.Errorf("mapOrganisationToDomain() = %+v, expected the organisation details to be mapped", got)
+			}
+			if got.Settings.PINExpiryDays != tt.wantPINExpiryDays {
+				t.Errorf("expected PIN expiry days %v, got %v", tt.wantPINExpiryDays, got.Settings.PINExpiryDays)
+			}
+			if got.Settings.InvitePINExpiryDays != tt.wantInviteExpiryDays {
+				t.Errorf("expected invite PIN expiry days %v, got %v", tt.wantInviteExpiryDays, got.Settings.InvitePINExpiryDays)
+			}
+			if got.Settings.SMSSenderID != tt.wantSenderID {
+				t.Errorf("expected sender ID %v, got %v", tt.wantSenderID, got.Settings.SMSSenderID)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_mapFacilityObjectToDomain(t *testing.T) {
+	id := uuid.New().String()
+	opensAt := "09:00:00"
+	closesAt := "12:30:00"
+	d := &MyCareHubDb{}
+
+	facility := d.mapFacilityObjectToDomain(&gorm.Facility{
+		FacilityID: &id,
+		Name:       "Test Facility",
+		OpeningHours: []*gorm.FacilityOpeningHours{
+			{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "08:00:00", ClosesAt: "24:00:00"},
+		},
+		HoursExceptions: []*gorm.FacilityHoursException{
+			{Date: time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC), OpensAt: &opensAt, ClosesAt: &closesAt},
+			{Date: time.Date(2021, 12, 25, 0, 0, 0, 0, time.UTC), Closed: true, Reason: "Christmas"},
+		},
+		Services: []*gorm.FacilityService{
+			{ID: &id, Name: "ART refill", Code: "ART_REFILL", Active: true},
+		},
+	})
+
+	if len(facility.OpeningHours) != 1 || facility.OpeningHours[0].OpensAt != "08:00" || facility.OpeningHours[0].ClosesAt != "24:00" {
+		t.Errorf("mapFacilityObjectToDomain() opening hours = %+v", facility.OpeningHours)
+	}
+	if len(facility.HoursExceptions) != 2 {
+		t.Errorf("mapFacilityObjectToDomain() got %v hours exceptions, want 2", len(facility.HoursExceptions))
+		return
+	}
+	shorterHours, holiday := facility.HoursExceptions[0], facility.HoursExceptions[1]
+	if shorterHours.Date != "2021-12-24" || *shorterHours.OpensAt != "09:00" || *shorterHours.ClosesAt != "12:30" {
+		t.Errorf("mapFacilityObjectToDomain() hours exception = %+v", shorterHours)
+	}
+	if holiday.Date != "2021-12-25" || !holiday.Closed || holiday.OpensAt != nil {
+		t.Errorf("mapFacilityObjectToDomain() hours exception = %+v", holiday)
+	}
+	if len(facility.Services) != 1 || facility.Services[0].Code != "ART_REFILL" {
+		t.Errorf("mapFacilityObjectToDomain() services = %+v", facility.Services)
+	}
+}
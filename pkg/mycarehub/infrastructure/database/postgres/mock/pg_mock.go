@@ -20,7 +20,7 @@ type PostgresMock struct {
 	MockGetOrCreateFacilityFn                     func(ctx context.Context, facility *dto.FacilityInput) (*domain.Facility, error)
 	MockGetFacilitiesFn                           func(ctx context.Context) ([]*domain.Facility, error)
 	MockRetrieveFacilityFn                        func(ctx context.Context, id *string, isActive bool) (*domain.Facility, error)
-	ListFacilitiesFn                              func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error)
+	ListFacilitiesFn                              func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, filterGroups []*dto.FilterGroupInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error)
 	MockDeleteFacilityFn                          func(ctx context.Context, id int) (bool, error)
 	MockRetrieveFacilityByMFLCodeFn               func(ctx context.Context, MFLCode int, isActive bool) (*domain.Facility, error)
 	MockGetUserProfileByPhoneNumberFn             func(ctx context.Context, phoneNumber string) (*domain.User, error)
@@ -54,7 +54,7 @@ type PostgresMock struct {
 	MockGetContactByUserIDFn                      func(ctx context.Context, userID *string, contactType string) (*domain.Contact, error)
 	MockUpdateIsCorrectSecurityQuestionResponseFn func(ctx context.Context, userID string, isCorrectSecurityQuestionResponse bool) (bool, error)
 	MockListContentCategoriesFn                   func(ctx context.Context) ([]*domain.ContentItemCategory, error)
-	MockShareContentFn                            func(ctx context.Context, input dto.ShareContentInput) (bool, error)
+	MockShareContentFn                            func(ctx context.Context, input dto.ShareContentInput) (*domain.ContentShareLink, error)
 	MockBookmarkContentFn                         func(ctx context.Context, userID string, contentID int) (bool, error)
 	MockUnBookmarkContentFn                       func(ctx context.Context, userID string, contentID int) (bool, error)
 	MockGetUserBookmarkedContentFn                func(ctx context.Context, userID string) ([]*domain.ContentItem, error)
@@ -65,11 +65,76 @@ type PostgresMock struct {
 	MockViewContentFn                             func(ctx context.Context, userID string, contentID int) (bool, error)
 	MockCreateHealthDiaryEntryFn                  func(ctx context.Context, healthDiaryInput *domain.ClientHealthDiaryEntry) error
 	MockCreateServiceRequestFn                    func(ctx context.Context, serviceRequestInput *domain.ClientServiceRequest) error
-	MockCanRecordHeathDiaryFn                     func(ctx context.Context, userID string) (bool, error)
+	MockCanRecordHeathDiaryFn                     func(ctx context.Context, userID string, cadence time.Duration) (bool, error)
 	MockGetClientHealthDiaryQuoteFn               func(ctx context.Context) (*domain.ClientHealthDiaryQuote, error)
 	MockCheckIfUserBookmarkedContentFn            func(ctx context.Context, userID string, contentID int) (bool, error)
 	MockGetClientHealthDiaryEntriesFn             func(ctx context.Context, clientID string) ([]*domain.ClientHealthDiaryEntry, error)
 	MockGetFAQContentFn                           func(ctx context.Context, flavour feedlib.Flavour, limit *int) ([]*domain.FAQ, error)
+	MockUpdateUserLanguagesFn                     func(ctx context.Context, userID string, languages []enumutils.Language) (bool, error)
+	MockSaveInvitationFn                          func(ctx context.Context, invitation *domain.Invitation) (*domain.Invitation, error)
+	MockGetLatestUserInvitationFn                 func(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.Invitation, error)
+	MockListPendingInvitationsFn                  func(ctx context.Context, facilityID string) ([]*domain.Invitation, error)
+	MockUpdateInvitationStatusFn                  func(ctx context.Context, invitationID string, status enums.InvitationStatus) (bool, error)
+	MockCloseUserInvitationsFn                    func(ctx context.Context, userID string, flavour feedlib.Flavour, status enums.InvitationStatus) (bool, error)
+	MockExpireOverdueInvitationsFn                func(ctx context.Context) (bool, error)
+	MockRegisterClientFn                          func(ctx context.Context, client *domain.ClientProfile) (*domain.ClientProfile, error)
+	MockRegisterStaffFn                           func(ctx context.Context, staff *domain.StaffProfile) (*domain.StaffProfile, error)
+	MockCreateStaffProfileFn                      func(ctx context.Context, staff *domain.StaffProfile) (*domain.StaffProfile, error)
+	MockGetStaffProfileByUserIDFn                 func(ctx context.Context, userID string) (*domain.StaffProfile, error)
+	MockGetStaffProfileByStaffIDFn                func(ctx context.Context, staffID string) (*domain.StaffProfile, error)
+	MockGetStaffFacilitiesFn                      func(ctx context.Context, staffID string) ([]*domain.Facility, error)
+	MockUpdateStaffProfileFn                      func(ctx context.Context, staffID string, input *dto.StaffProfileUpdateInput) (bool, error)
+	MockAddStaffFacilitiesFn                      func(ctx context.Context, staffID string, facilityIDs []string) (bool, error)
+	MockRemoveStaffFacilitiesFn                   func(ctx context.Context, staffID string, facilityIDs []string) (bool, error)
+	MockAssignRolesFn                             func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error)
+	MockRevokeRolesFn                             func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error)
+	MockGetUserRolesFn                            func(ctx context.Context, userID string) ([]enums.UserRoleType, error)
+	MockGetClientProfileByClientIDFn              func(ctx context.Context, clientID string) (*domain.ClientProfile, error)
+	MockCreateOrganisationFn                      func(ctx context.Context, input *dto.OrganisationInput) (*domain.Organisation, error)
+	MockGetOrganisationFn                         func(ctx context.Context, organisationID string) (*domain.Organisation, error)
+	MockListOrganisationsFn                       func(ctx context.Context) ([]*domain.Organisation, error)
+	MockGetOrganisationSettingsFn                 func(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error)
+	MockUpdateOrganisationFn                      func(ctx context.Context, organisationID string, input *dto.OrganisationUpdateInput) (bool, error)
+	MockDeactivateOrganisationFn                  func(ctx context.Context, organisationID string) (bool, error)
+	MockUpdateFacilityFn                          func(ctx context.Context, mflCode int, input *dto.FacilityUpdateInput, changedBy string) (*domain.Facility, error)
+	MockGetFacilityHistoryFn                      func(ctx context.Context, mflCode int) ([]*domain.FacilityHistory, error)
+	MockUpsertFacilitiesFn                        func(ctx context.Context, facilities []*dto.FacilityInput, changedBy string, dryRun bool) ([]*domain.FacilityImportRow, error)
+	MockNearbyFacilitiesFn                        func(ctx context.Context, input *dto.NearbyFacilitiesInput) ([]*domain.NearbyFacility, error)
+	MockSetFacilityOpeningHoursFn                 func(ctx context.Context, mflCode int, openingHours []*dto.FacilityOpeningHoursInput) (bool, error)
+	MockSetFacilityHoursExceptionFn               func(ctx context.Context, mflCode int, input *dto.FacilityHoursExceptionInput) (bool, error)
+	MockSetFacilityServicesFn                     func(ctx context.Context, mflCode int, serviceCodes []string) (bool, error)
+	MockCreateFacilityServiceFn                   func(ctx context.Context, input *dto.FacilityServiceInput) (*domain.FacilityService, error)
+	MockListFacilityServicesFn                    func(ctx context.Context) ([]*domain.FacilityService, error)
+	MockDeleteFacilityHoursExceptionFn            func(ctx context.Context, mflCode int, date string) (bool, error)
+	MockCreateClientTransferFn                    func(ctx context.Context, transfer *domain.ClientTransfer, serviceRequest *domain.ClientServiceRequest) (*domain.ClientTransfer, error)
+	MockGetClientTransferByIDFn                   func(ctx context.Context, transferID string) (*domain.ClientTransfer, error)
+	MockCheckClientHasPendingTransferFn           func(ctx context.Context, clientID string) (bool, error)
+	MockListPendingClientTransfersFn              func(ctx context.Context, facilityID string) ([]*domain.ClientTransfer, error)
+	MockGetClientFacilityHistoryFn                func(ctx context.Context, clientID string) ([]*domain.ClientFacilityPeriod, error)
+	MockRespondToClientTransferFn                 func(ctx context.Context, transferID string, status enums.ClientTransferStatus, respondedByID string, note string) (*domain.ClientTransfer, error)
+	MockListContentItemsFn                        func(ctx context.Context, categoryID *int, contentIDs []int, limit int) (*domain.Content, error)
+	MockSearchContentFn                           func(ctx context.Context, input *dto.ContentSearchInput, paginationsInput *dto.PaginationsInput) (*domain.ContentSearchPage, error)
+	MockListRecommendedContentFn                  func(ctx context.Context, userID string, limit int) (*domain.Content, error)
+	MockListContentFeedFn                         func(ctx context.Context, categoryID *int, sort enums.ContentSortOrder, trendingDays int, after *domain.ContentFeedPosition, before *domain.ContentFeedPosition, limit int) (*domain.ContentFeedPage, error)
+	MockReconcileContentEngagementFn              func(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error)
+	MockGetContentAnalyticsFn                     func(ctx context.Context, input *dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error)
+	MockRecordContentReadDurationFn               func(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error)
+	MockRecordContentProgressFn                   func(ctx context.Context, userID string, contentID int, secondsSpent int, percentScrolled float64, threshold domain.ContentCompletionThreshold) (*domain.ContentProgressUpdate, error)
+	MockListContentInProgressFn                   func(ctx context.Context, userID string, limit int) ([]*domain.ContentProgress, error)
+	MockRecordContentShareOpenFn                  func(ctx context.Context, shareLinkID string, openedByID *string) (*domain.ContentShareLink, error)
+	MockGetContentCommentByIDFn                   func(ctx context.Context, commentID string) (*domain.ContentComment, error)
+	MockListContentCommentsFn                     func(ctx context.Context, contentID int) ([]*domain.ContentComment, error)
+	MockListContentCommentsByStatusFn             func(ctx context.Context, statuses []enums.ContentCommentStatus, limit int) ([]*domain.ContentComment, error)
+	MockCreateContentCommentFn                    func(ctx context.Context, userID string, contentID int, parentID *string, body string, status enums.ContentCommentStatus) (*domain.ContentComment, error)
+	MockEditContentCommentFn                      func(ctx context.Context, commentID string, body string, status enums.ContentCommentStatus) (*domain.ContentComment, error)
+	MockDeleteContentCommentFn                    func(ctx context.Context, commentID string) (bool, error)
+	MockModerateContentCommentFn                  func(ctx context.Context, commentID string, status enums.ContentCommentStatus, moderatorID string) (*domain.ContentComment, error)
+	MockCreateBulkInviteJobFn                     func(ctx context.Context, job *domain.BulkInviteJob) (*domain.BulkInviteJob, error)
+	MockGetBulkInviteJobFn                        func(ctx context.Context, jobID string) (*domain.BulkInviteJob, error)
+	MockUpdateBulkInviteJobStatusFn               func(ctx context.Context, jobID string, status enums.BulkInviteJobStatus) (bool, error)
+	MockRecordBulkInviteRowOutcomeFn              func(ctx context.Context, jobID string, rowID string, status enums.BulkInviteRowStatus, errorMessage string) (bool, error)
+	MockFailInterruptedBulkInviteJobsFn           func(ctx context.Context, staleAfter time.Duration) (int, error)
+	MockAssignFirstSystemAdminFn                  func(ctx context.Context, userID string) (bool, error)
 }
 
 // NewPostgresMock initializes a new instance of `GormMock` then mocking the case of success.
@@ -137,6 +202,17 @@ func NewPostgresMock() *PostgresMock {
 		ID: &ID,
 	}
 
+	staff := &domain.StaffProfile{
+		ID:                &ID,
+		User:              userProfile,
+		UserID:            ID,
+		Active:            true,
+		StaffNumber:       gofakeit.SSN(),
+		Cadre:             enums.StaffCadreClinician,
+		DefaultFacilityID: ID,
+		Facilities:        facilitiesList,
+	}
+
 	contentItemCategoryID := 1
 	contentItemCategory := &domain.ContentItemCategory{
 		ID:      contentItemCategoryID,
@@ -154,7 +230,7 @@ func NewPostgresMock() *PostgresMock {
 		MockRetrieveFacilityFn: func(ctx context.Context, id *string, isActive bool) (*domain.Facility, error) {
 			return facilityInput, nil
 		},
-		ListFacilitiesFn: func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
+		ListFacilitiesFn: func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, filterGroups []*dto.FilterGroupInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
 			return facilitiesPage, nil
 		},
 		MockDeleteFacilityFn: func(ctx context.Context, id int) (bool, error) {
@@ -327,8 +403,14 @@ func NewPostgresMock() *PostgresMock {
 		MockListContentCategoriesFn: func(ctx context.Context) ([]*domain.ContentItemCategory, error) {
 			return []*domain.ContentItemCategory{contentItemCategory}, nil
 		},
-		MockShareContentFn: func(ctx context.Context, input dto.ShareContentInput) (bool, error) {
-			return true, nil
+		MockShareContentFn: func(ctx context.Context, input dto.ShareContentInput) (*domain.ContentShareLink, error) {
+			return &domain.ContentShareLink{
+				ID:        uuid.New().String(),
+				ContentID: input.ContentID,
+				UserID:    input.UserID,
+				Channel:   input.Channel,
+				CreatedAt: time.Now(),
+			}, nil
 		},
 		MockBookmarkContentFn: func(ctx context.Context, userID string, contentID int) (bool, error) {
 			return true, nil
@@ -364,7 +446,7 @@ func NewPostgresMock() *PostgresMock {
 		MockCreateServiceRequestFn: func(ctx context.Context, serviceRequestInput *domain.ClientServiceRequest) error {
 			return nil
 		},
-		MockCanRecordHeathDiaryFn: func(ctx context.Context, userID string) (bool, error) {
+		MockCanRecordHeathDiaryFn: func(ctx context.Context, userID string, cadence time.Duration) (bool, error) {
 			return true, nil
 		},
 		MockGetClientHealthDiaryQuoteFn: func(ctx context.Context) (*domain.ClientHealthDiaryQuote, error) {
@@ -394,6 +476,548 @@ func NewPostgresMock() *PostgresMock {
 				},
 			}, nil
 		},
+		MockUpdateUserLanguagesFn: func(ctx context.Context, userID string, languages []enumutils.Language) (bool, error) {
+			return true, nil
+		},
+		MockSaveInvitationFn: func(ctx context.Context, invitation *domain.Invitation) (*domain.Invitation, error) {
+			invitation.ID = uuid.New().String()
+			return invitation, nil
+		},
+		MockGetLatestUserInvitationFn: func(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.Invitation, error) {
+			return &domain.Invitation{
+				ID:          uuid.New().String(),
+				UserID:      userID,
+				PhoneNumber: interserviceclient.TestUserPhoneNumber,
+				Flavour:     flavour,
+				Status:      enums.InvitationStatusDelivered,
+				IssuedAt:    time.Now(),
+				ExpiresAt:   time.Now().Add(time.Hour * 24),
+			}, nil
+		},
+		MockListPendingInvitationsFn: func(ctx context.Context, facilityID string) ([]*domain.Invitation, error) {
+			return []*domain.Invitation{
+				{
+					ID:          uuid.New().String(),
+					UserID:      uuid.New().String(),
+					PhoneNumber: interserviceclient.TestUserPhoneNumber,
+					Flavour:     feedlib.FlavourConsumer,
+					Status:      enums.InvitationStatusIssued,
+					IssuedAt:    time.Now(),
+					ExpiresAt:   time.Now().Add(time.Hour * 24),
+				},
+			}, nil
+		},
+		MockUpdateInvitationStatusFn: func(ctx context.Context, invitationID string, status enums.InvitationStatus) (bool, error) {
+			return true, nil
+		},
+		MockCloseUserInvitationsFn: func(ctx context.Context, userID string, flavour feedlib.Flavour, status enums.InvitationStatus) (bool, error) {
+			return true, nil
+		},
+		MockExpireOverdueInvitationsFn: func(ctx context.Context) (bool, error) {
+			return true, nil
+		},
+		MockRegisterClientFn: func(ctx context.Context, client *domain.ClientProfile) (*domain.ClientProfile, error) {
+			id := uuid.New().String()
+			client.ID = &id
+			client.UserID = id
+			client.User.ID = &id
+			return client, nil
+		},
+		MockRegisterStaffFn: func(ctx context.Context, staff *domain.StaffProfile) (*domain.StaffProfile, error) {
+			id := uuid.New().String()
+			staff.ID = &id
+			staff.UserID = id
+			staff.User.ID = &id
+			return staff, nil
+		},
+		MockCreateStaffProfileFn: func(ctx context.Context, staff *domain.StaffProfile) (*domain.StaffProfile, error) {
+			id := uuid.New().String()
+			staff.ID = &id
+			return staff, nil
+		},
+		MockGetStaffProfileByUserIDFn: func(ctx context.Context, userID string) (*domain.StaffProfile, error) {
+			return staff, nil
+		},
+		MockGetStaffProfileByStaffIDFn: func(ctx context.Context, staffID string) (*domain.StaffProfile, error) {
+			return staff, nil
+		},
+		MockGetStaffFacilitiesFn: func(ctx context.Context, staffID string) ([]*domain.Facility, error) {
+			return facilitiesList, nil
+		},
+		MockUpdateStaffProfileFn: func(ctx context.Context, staffID string, input *dto.StaffProfileUpdateInput) (bool, error) {
+			return true, nil
+		},
+		MockAddStaffFacilitiesFn: func(ctx context.Context, staffID string, facilityIDs []string) (bool, error) {
+			return true, nil
+		},
+		MockRemoveStaffFacilitiesFn: func(ctx context.Context, staffID string, facilityIDs []string) (bool, error) {
+			return true, nil
+		},
+		MockAssignRolesFn: func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+			return true, nil
+		},
+		MockRevokeRolesFn: func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+			return true, nil
+		},
+		MockGetUserRolesFn: func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+			return []enums.UserRoleType{enums.UserRoleTypeClinician}, nil
+		},
+		MockGetClientProfileByClientIDFn: func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+			return client, nil
+		},
+		MockCreateOrganisationFn: func(ctx context.Context, input *dto.OrganisationInput) (*domain.Organisation, error) {
+			id := uuid.New().String()
+			settings := &domain.OrganisationSettings{
+				PINExpiryDays:       30,
+				InvitePINExpiryDays: 7,
+				DiaryCadenceHours:   24,
+				SMSSenderID:         enumutils.SenderIDBewell,
+			}
+			return &domain.Organisation{
+				ID:             &id,
+				Active:         true,
+				OrgCode:        "test",
+				Code:           1,
+				Name:           gofakeit.Company(),
+				EmailAddress:   gofakeit.Email(),
+				PhoneNumber:    "+254711223344",
+				DefaultCountry: "KEN",
+				Settings:       settings,
+			}, nil
+		},
+		MockGetOrganisationFn: func(ctx context.Context, organisationID string) (*domain.Organisation, error) {
+			id := uuid.New().String()
+			settings := &domain.OrganisationSettings{
+				PINExpiryDays:       30,
+				InvitePINExpiryDays: 7,
+				DiaryCadenceHours:   24,
+				SMSSenderID:         enumutils.SenderIDBewell,
+			}
+			return &domain.Organisation{
+				ID:             &id,
+				Active:         true,
+				OrgCode:        "test",
+				Code:           1,
+				Name:           gofakeit.Company(),
+				EmailAddress:   gofakeit.Email(),
+				PhoneNumber:    "+254711223344",
+				DefaultCountry: "KEN",
+				Settings:       settings,
+			}, nil
+		},
+		MockListOrganisationsFn: func(ctx context.Context) ([]*domain.Organisation, error) {
+			id := uuid.New().String()
+			settings := &domain.OrganisationSettings{
+				PINExpiryDays:       30,
+				InvitePINExpiryDays: 7,
+				DiaryCadenceHours:   24,
+				SMSSenderID:         enumutils.SenderIDBewell,
+			}
+			return []*domain.Organisation{
+				{
+					ID:       &id,
+					Active:   true,
+					Name:     gofakeit.Company(),
+					Settings: settings,
+				},
+			}, nil
+		},
+		MockGetOrganisationSettingsFn: func(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error) {
+			return &domain.OrganisationSettings{
+				PINExpiryDays:       30,
+				InvitePINExpiryDays: 7,
+				DiaryCadenceHours:   24,
+				SMSSenderID:         enumutils.SenderIDBewell,
+			}, nil
+		},
+		MockUpdateOrganisationFn: func(ctx context.Context, organisationID string, input *dto.OrganisationUpdateInput) (bool, error) {
+			return true, nil
+		},
+		MockDeactivateOrganisationFn: func(ctx context.Context, organisationID string) (bool, error) {
+			return true, nil
+		},
+		MockUpdateFacilityFn: func(ctx context.Context, mflCode int, input *dto.FacilityUpdateInput, changedBy string) (*domain.Facility, error) {
+			return facilityInput, nil
+		},
+		MockGetFacilityHistoryFn: func(ctx context.Context, mflCode int) ([]*domain.FacilityHistory, error) {
+			id := uuid.New().String()
+			return []*domain.FacilityHistory{
+				{
+					ID:         &id,
+					FacilityID: *facilityInput.ID,
+					Field:      "name",
+					OldValue:   gofakeit.Name(),
+					NewValue:   facilityInput.Name,
+					ChangedBy:  uuid.New().String(),
+					ChangedAt:  time.Now(),
+				},
+			}, nil
+		},
+		MockUpsertFacilitiesFn: func(ctx context.Context, facilities []*dto.FacilityInput, changedBy string, dryRun bool) ([]*domain.FacilityImportRow, error) {
+			rows := []*domain.FacilityImportRow{}
+			for _, facility := range facilities {
+				rows = append(rows, &domain.FacilityImportRow{
+					MFLCode: facility.Code,
+					Name:    facility.Name,
+					Status:  enums.FacilityImportStatusCreated,
+				})
+			}
+			return rows, nil
+		},
+		MockNearbyFacilitiesFn: func(ctx context.Context, input *dto.NearbyFacilitiesInput) ([]*domain.NearbyFacility, error) {
+			return []*domain.NearbyFacility{
+				{
+					Facility:   *facilityInput,
+					DistanceKm: 1.5,
+				},
+			}, nil
+		},
+		MockSetFacilityOpeningHoursFn: func(ctx context.Context, mflCode int, openingHours []*dto.FacilityOpeningHoursInput) (bool, error) {
+			return true, nil
+		},
+		MockSetFacilityHoursExceptionFn: func(ctx context.Context, mflCode int, input *dto.FacilityHoursExceptionInput) (bool, error) {
+			return true, nil
+		},
+		MockSetFacilityServicesFn: func(ctx context.Context, mflCode int, serviceCodes []string) (bool, error) {
+			return true, nil
+		},
+		MockCreateFacilityServiceFn: func(ctx context.Context, input *dto.FacilityServiceInput) (*domain.FacilityService, error) {
+			id := uuid.New().String()
+			return &domain.FacilityService{
+				ID:          &id,
+				Name:        input.Name,
+				Code:        input.Code,
+				Description: input.Description,
+				Active:      true,
+			}, nil
+		},
+		MockListFacilityServicesFn: func(ctx context.Context) ([]*domain.FacilityService, error) {
+			id := uuid.New().String()
+			return []*domain.FacilityService{
+				{
+					ID:     &id,
+					Name:   "ART refill",
+					Code:   "ART_REFILL",
+					Active: true,
+				},
+			}, nil
+		},
+		MockDeleteFacilityHoursExceptionFn: func(ctx context.Context, mflCode int, date string) (bool, error) {
+			return true, nil
+		},
+		MockCreateClientTransferFn: func(ctx context.Context, transfer *domain.ClientTransfer, serviceRequest *domain.ClientServiceRequest) (*domain.ClientTransfer, error) {
+			serviceRequestID := uuid.New().String()
+			transfer.ID = uuid.New().String()
+			transfer.Status = enums.ClientTransferStatusPending
+			transfer.ServiceRequestID = &serviceRequestID
+			return transfer, nil
+		},
+		MockGetClientTransferByIDFn: func(ctx context.Context, transferID string) (*domain.ClientTransfer, error) {
+			serviceRequestID := uuid.New().String()
+			return &domain.ClientTransfer{
+				ID:               transferID,
+				ClientID:         uuid.New().String(),
+				FromFacilityID:   uuid.New().String(),
+				ToFacilityID:     uuid.New().String(),
+				Reason:           "Client relocated",
+				Status:           enums.ClientTransferStatusPending,
+				RequestedByID:    uuid.New().String(),
+				RequestedAt:      time.Now(),
+				ServiceRequestID: &serviceRequestID,
+			}, nil
+		},
+		MockCheckClientHasPendingTransferFn: func(ctx context.Context, clientID string) (bool, error) {
+			return false, nil
+		},
+		MockListPendingClientTransfersFn: func(ctx context.Context, facilityID string) ([]*domain.ClientTransfer, error) {
+			return []*domain.ClientTransfer{
+				{
+					ID:             uuid.New().String(),
+					ClientID:       uuid.New().String(),
+					FromFacilityID: uuid.New().String(),
+					ToFacilityID:   facilityID,
+					Reason:         "Client relocated",
+					Status:         enums.ClientTransferStatusPending,
+					RequestedByID:  uuid.New().String(),
+					RequestedAt:    time.Now(),
+				},
+			}, nil
+		},
+		MockGetClientFacilityHistoryFn: func(ctx context.Context, clientID string) ([]*domain.ClientFacilityPeriod, error) {
+			return []*domain.ClientFacilityPeriod{
+				{
+					ID:         uuid.New().String(),
+					ClientID:   clientID,
+					FacilityID: uuid.New().String(),
+					StartDate:  time.Now(),
+				},
+			}, nil
+		},
+		MockRespondToClientTransferFn: func(ctx context.Context, transferID string, status enums.ClientTransferStatus, respondedByID string, note string) (*domain.ClientTransfer, error) {
+			now := time.Now()
+			return &domain.ClientTransfer{
+				ID:             transferID,
+				ClientID:       uuid.New().String(),
+				FromFacilityID: uuid.New().String(),
+				ToFacilityID:   uuid.New().String(),
+				Reason:         "Client relocated",
+				Status:         status,
+				RequestedByID:  uuid.New().String(),
+				RequestedAt:    now,
+				RespondedByID:  &respondedByID,
+				RespondedAt:    &now,
+				ResponseNote:   note,
+			}, nil
+		},
+		MockListContentItemsFn: func(ctx context.Context, categoryID *int, contentIDs []int, limit int) (*domain.Content, error) {
+			return &domain.Content{
+				Meta: domain.Meta{TotalCount: 1},
+				Items: []domain.ContentItem{
+					{
+						ID:       10,
+						Title:    gofakeit.Sentence(5),
+						ItemType: "ARTICLE",
+					},
+				},
+			}, nil
+		},
+		MockSearchContentFn: func(ctx context.Context, input *dto.ContentSearchInput, paginationsInput *dto.PaginationsInput) (*domain.ContentSearchPage, error) {
+			return &domain.ContentSearchPage{
+				Pagination: domain.Pagination{Limit: 10, CurrentPage: 1, Count: 1, TotalPages: 1},
+				Results: []*domain.ContentSearchResult{
+					{
+						Item: domain.ContentItem{
+							ID:       10,
+							Title:    gofakeit.Sentence(5),
+							ItemType: "ARTICLE",
+						},
+						Rank:    0.5,
+						Snippet: "a healthy <mark>diet</mark>",
+					},
+				},
+			}, nil
+		},
+		MockListRecommendedContentFn: func(ctx context.Context, userID string, limit int) (*domain.Content, error) {
+			return &domain.Content{
+				Meta: domain.Meta{TotalCount: 1},
+				Items: []domain.ContentItem{
+					{
+						ID:       10,
+						Title:    gofakeit.Sentence(5),
+						ItemType: "ARTICLE",
+					},
+				},
+			}, nil
+		},
+		MockListContentFeedFn: func(ctx context.Context, categoryID *int, sort enums.ContentSortOrder, trendingDays int, after *domain.ContentFeedPosition, before *domain.ContentFeedPosition, limit int) (*domain.ContentFeedPage, error) {
+			return &domain.ContentFeedPage{
+				Content: &domain.Content{
+					Meta: domain.Meta{TotalCount: 1},
+					Items: []domain.ContentItem{
+						{
+							ID:       10,
+							Title:    gofakeit.Sentence(5),
+							ItemType: "ARTICLE",
+						},
+					},
+				},
+				Positions: []*domain.ContentFeedPosition{
+					{FirstPublishedAt: time.Now(), ContentID: 10},
+				},
+			}, nil
+		},
+		MockReconcileContentEngagementFn: func(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error) {
+			return &domain.ContentEngagementReport{
+				Drift: []*domain.ContentCounterDrift{
+					{ContentID: 10, Counter: "like_count", Recorded: 2, Actual: 1},
+				},
+			}, nil
+		},
+		MockGetContentAnalyticsFn: func(ctx context.Context, input *dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error) {
+			return &domain.ContentAnalytics{
+				From: input.From,
+				To:   input.To,
+				Items: []*domain.ContentItemAnalytics{
+					{
+						ContentID:          10,
+						Title:              "PrEP and you",
+						Views:              10,
+						UniqueViewers:      4,
+						Likes:              2,
+						Bookmarks:          1,
+						Shares:             1,
+						AverageReadSeconds: 95.5,
+					},
+				},
+				Categories: []*domain.ContentCategoryAnalytics{
+					{
+						CategoryID:         1,
+						Name:               "Prevention",
+						ContentItems:       1,
+						Views:              10,
+						UniqueViewers:      4,
+						Likes:              2,
+						Bookmarks:          1,
+						Shares:             1,
+						AverageReadSeconds: 95.5,
+					},
+				},
+			}, nil
+		},
+		MockRecordContentReadDurationFn: func(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error) {
+			return true, nil
+		},
+		MockRecordContentProgressFn: func(ctx context.Context, userID string, contentID int, secondsSpent int, percentScrolled float64, threshold domain.ContentCompletionThreshold) (*domain.ContentProgressUpdate, error) {
+			return &domain.ContentProgressUpdate{
+				Progress: domain.ContentProgress{
+					ContentID:       contentID,
+					SecondsSpent:    secondsSpent,
+					PercentScrolled: percentScrolled,
+					UpdatedAt:       time.Now(),
+				},
+			}, nil
+		},
+		MockListContentInProgressFn: func(ctx context.Context, userID string, limit int) ([]*domain.ContentProgress, error) {
+			return []*domain.ContentProgress{
+				{
+					ContentID:       10,
+					SecondsSpent:    60,
+					PercentScrolled: 40,
+					UpdatedAt:       time.Now(),
+					Item:            &domain.ContentItem{ID: 10},
+				},
+			}, nil
+		},
+		MockRecordContentShareOpenFn: func(ctx context.Context, shareLinkID string, openedByID *string) (*domain.ContentShareLink, error) {
+			return &domain.ContentShareLink{
+				ID:        shareLinkID,
+				ContentID: 10,
+				UserID:    uuid.New().String(),
+				Channel:   enums.ContentShareChannelWhatsApp,
+				CreatedAt: time.Now(),
+			}, nil
+		},
+		MockGetContentCommentByIDFn: func(ctx context.Context, commentID string) (*domain.ContentComment, error) {
+			return &domain.ContentComment{
+				ID:        commentID,
+				ContentID: 10,
+				UserID:    uuid.New().String(),
+				Nickname:  gofakeit.Username(),
+				Body:      "How long should I take the medication?",
+				Status:    enums.ContentCommentStatusApproved,
+				CreatedAt: time.Now(),
+				Replies:   []*domain.ContentComment{},
+			}, nil
+		},
+		MockListContentCommentsFn: func(ctx context.Context, contentID int) ([]*domain.ContentComment, error) {
+			return []*domain.ContentComment{
+				{
+					ID:        uuid.New().String(),
+					ContentID: contentID,
+					UserID:    uuid.New().String(),
+					Nickname:  gofakeit.Username(),
+					Body:      "How long should I take the medication?",
+					Status:    enums.ContentCommentStatusApproved,
+					CreatedAt: time.Now(),
+					Replies:   []*domain.ContentComment{},
+				},
+			}, nil
+		},
+		MockListContentCommentsByStatusFn: func(ctx context.Context, statuses []enums.ContentCommentStatus, limit int) ([]*domain.ContentComment, error) {
+			return []*domain.ContentComment{
+				{
+					ID:        uuid.New().String(),
+					ContentID: 10,
+					UserID:    uuid.New().String(),
+					Nickname:  gofakeit.Username(),
+					Body:      "How long should I take the medication?",
+					Status:    enums.ContentCommentStatusPending,
+					CreatedAt: time.Now(),
+					Replies:   []*domain.ContentComment{},
+				},
+			}, nil
+		},
+		MockCreateContentCommentFn: func(ctx context.Context, userID string, contentID int, parentID *string, body string, status enums.ContentCommentStatus) (*domain.ContentComment, error) {
+			return &domain.ContentComment{
+				ID:        uuid.New().String(),
+				ContentID: contentID,
+				UserID:    userID,
+				ParentID:  parentID,
+				Nickname:  gofakeit.Username(),
+				Body:      body,
+				Status:    status,
+				CreatedAt: time.Now(),
+				Replies:   []*domain.ContentComment{},
+			}, nil
+		},
+		MockEditContentCommentFn: func(ctx context.Context, commentID string, body string, status enums.ContentCommentStatus) (*domain.ContentComment, error) {
+			editedAt := time.Now()
+			return &domain.ContentComment{
+				ID:        commentID,
+				ContentID: 10,
+				UserID:    uuid.New().String(),
+				Nickname:  gofakeit.Username(),
+				Body:      body,
+				Status:    status,
+				EditedAt:  &editedAt,
+				CreatedAt: time.Now(),
+				Replies:   []*domain.ContentComment{},
+			}, nil
+		},
+		MockDeleteContentCommentFn: func(ctx context.Context, commentID string) (bool, error) {
+			return true, nil
+		},
+		MockModerateContentCommentFn: func(ctx context.Context, commentID string, status enums.ContentCommentStatus, moderatorID string) (*domain.ContentComment, error) {
+			return &domain.ContentComment{
+				ID:        commentID,
+				ContentID: 10,
+				UserID:    uuid.New().String(),
+				Nickname:  gofakeit.Username(),
+				Body:      "How long should I take the medication?",
+				Status:    status,
+				CreatedAt: time.Now(),
+				Replies:   []*domain.ContentComment{},
+			}, nil
+		},
+		MockCreateBulkInviteJobFn: func(ctx context.Context, job *domain.BulkInviteJob) (*domain.BulkInviteJob, error) {
+			job.ID = uuid.New().String()
+			for _, row := range job.Rows {
+				row.ID = uuid.New().String()
+			}
+			return job, nil
+		},
+		MockGetBulkInviteJobFn: func(ctx context.Context, jobID string) (*domain.BulkInviteJob, error) {
+			return &domain.BulkInviteJob{
+				ID:        jobID,
+				Status:    enums.BulkInviteJobStatusCompleted,
+				Flavour:   feedlib.FlavourConsumer,
+				Total:     1,
+				Succeeded: 1,
+				Rows: []*domain.BulkInviteRow{
+					{
+						ID:          uuid.New().String(),
+						RowNumber:   1,
+						UserID:      uuid.New().String(),
+						PhoneNumber: gofakeit.Phone(),
+						Status:      enums.BulkInviteRowStatusSuccess,
+					},
+				},
+				CreatedBy: uuid.New().String(),
+				CreatedAt: time.Now(),
+			}, nil
+		},
+		MockUpdateBulkInviteJobStatusFn: func(ctx context.Context, jobID string, status enums.BulkInviteJobStatus) (bool, error) {
+			return true, nil
+		},
+		MockRecordBulkInviteRowOutcomeFn: func(ctx context.Context, jobID string, rowID string, status enums.BulkInviteRowStatus, errorMessage string) (bool, error) {
+			return true, nil
+		},
+		MockFailInterruptedBulkInviteJobsFn: func(ctx context.Context, staleAfter time.Duration) (int, error) {
+			return 1, nil
+		},
+		MockAssignFirstSystemAdminFn: func(ctx context.Context, userID string) (bool, error) {
+			return true, nil
+		},
 	}
 }
 
@@ -417,9 +1041,10 @@ func (gm *PostgresMock) ListFacilities(
 	ctx context.Context,
 	searchTerm *string,
 	filterInput []*dto.FiltersInput,
+	filterGroups []*dto.FilterGroupInput,
 	paginationsInput *dto.PaginationsInput,
 ) (*domain.FacilityPage, error) {
-	return gm.ListFacilitiesFn(ctx, searchTerm, filterInput, paginationsInput)
+	return gm.ListFacilitiesFn(ctx, searchTerm, filterInput, filterGroups, paginationsInput)
 }
 
 // GetFacilities mocks the implementation of `gorm's` GetFacilities method
@@ -593,7 +1218,7 @@ func (gm *PostgresMock) ListContentCategories(ctx context.Context) ([]*domain.Co
 }
 
 // ShareContent mock the implementation share content
-func (gm *PostgresMock) ShareContent(ctx context.Context, input dto.ShareContentInput) (bool, error) {
+func (gm *PostgresMock) ShareContent(ctx context.Context, input dto.ShareContentInput) (*domain.ContentShareLink, error) {
 	return gm.MockShareContentFn(ctx, input)
 }
 
@@ -643,8 +1268,8 @@ func (gm *PostgresMock) CreateServiceRequest(ctx context.Context, serviceRequest
 }
 
 // CanRecordHeathDiary mocks the implementation of checking if a user can record a health diary
-func (gm *PostgresMock) CanRecordHeathDiary(ctx context.Context, userID string) (bool, error) {
-	return gm.MockCanRecordHeathDiaryFn(ctx, userID)
+func (gm *PostgresMock) CanRecordHeathDiary(ctx context.Context, userID string, cadence time.Duration) (bool, error) {
+	return gm.MockCanRecordHeathDiaryFn(ctx, userID, cadence)
 }
 
 // GetClientHealthDiaryQuote mocks the implementation of fetching client health diary quote
@@ -666,3 +1291,328 @@ func (gm *PostgresMock) GetClientHealthDiaryEntries(ctx context.Context, clientI
 func (gm *PostgresMock) GetFAQContent(ctx context.Context, flavour feedlib.Flavour, limit *int) ([]*domain.FAQ, error) {
 	return gm.MockGetFAQContentFn(ctx, flavour, limit)
 }
+
+// UpdateUserLanguages mocks the implementation of updating a user's preferred languages
+func (gm *PostgresMock) UpdateUserLanguages(ctx context.Context, userID string, languages []enumutils.Language) (bool, error) {
+	return gm.MockUpdateUserLanguagesFn(ctx, userID, languages)
+}
+
+// SaveInvitation mocks the implementation of saving an invitation
+func (gm *PostgresMock) SaveInvitation(ctx context.Context, invitation *domain.Invitation) (*domain.Invitation, error) {
+	return gm.MockSaveInvitationFn(ctx, invitation)
+}
+
+// GetLatestUserInvitation mocks the implementation of getting the latest invitation sent to a user
+func (gm *PostgresMock) GetLatestUserInvitation(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.Invitation, error) {
+	return gm.MockGetLatestUserInvitationFn(ctx, userID, flavour)
+}
+
+// ListPendingInvitations mocks the implementation of listing the pending invitations of a facility
+func (gm *PostgresMock) ListPendingInvitations(ctx context.Context, facilityID string) ([]*domain.Invitation, error) {
+	return gm.MockListPendingInvitationsFn(ctx, facilityID)
+}
+
+// UpdateInvitationStatus mocks the implementation of updating the status of an invitation
+func (gm *PostgresMock) UpdateInvitationStatus(ctx context.Context, invitationID string, status enums.InvitationStatus) (bool, error) {
+	return gm.MockUpdateInvitationStatusFn(ctx, invitationID, status)
+}
+
+// CloseUserInvitations mocks the implementation of closing a user's pending invitations
+func (gm *PostgresMock) CloseUserInvitations(ctx context.Context, userID string, flavour feedlib.Flavour, status enums.InvitationStatus) (bool, error) {
+	return gm.MockCloseUserInvitationsFn(ctx, userID, flavour, status)
+}
+
+// ExpireOverdueInvitations mocks the implementation of expiring overdue invitations
+func (gm *PostgresMock) ExpireOverdueInvitations(ctx context.Context) (bool, error) {
+	return gm.MockExpireOverdueInvitationsFn(ctx)
+}
+
+// RegisterClient mocks the implementation of registering a client
+func (gm *PostgresMock) RegisterClient(ctx context.Context, client *domain.ClientProfile) (*domain.ClientProfile, error) {
+	return gm.MockRegisterClientFn(ctx, client)
+}
+
+// RegisterStaff mocks the implementation of registering a staff member
+func (gm *PostgresMock) RegisterStaff(ctx context.Context, staff *domain.StaffProfile) (*domain.StaffProfile, error) {
+	return gm.MockRegisterStaffFn(ctx, staff)
+}
+
+// CreateStaffProfile mocks the implementation of creating a staff profile
+func (gm *PostgresMock) CreateStaffProfile(ctx context.Context, staff *domain.StaffProfile) (*domain.StaffProfile, error) {
+	return gm.MockCreateStaffProfileFn(ctx, staff)
+}
+
+// GetStaffProfileByUserID mocks the implementation of getting a staff profile by user ID
+func (gm *PostgresMock) GetStaffProfileByUserID(ctx context.Context, userID string) (*domain.StaffProfile, error) {
+	return gm.MockGetStaffProfileByUserIDFn(ctx, userID)
+}
+
+// GetStaffProfileByStaffID mocks the implementation of getting a staff profile by its ID
+func (gm *PostgresMock) GetStaffProfileByStaffID(ctx context.Context, staffID string) (*domain.StaffProfile, error) {
+	return gm.MockGetStaffProfileByStaffIDFn(ctx, staffID)
+}
+
+// GetStaffFacilities mocks the implementation of getting the facilities a staff member can access
+func (gm *PostgresMock) GetStaffFacilities(ctx context.Context, staffID string) ([]*domain.Facility, error) {
+	return gm.MockGetStaffFacilitiesFn(ctx, staffID)
+}
+
+// UpdateStaffProfile mocks the implementation of updating a staff profile
+func (gm *PostgresMock) UpdateStaffProfile(ctx context.Context, staffID string, input *dto.StaffProfileUpdateInput) (bool, error) {
+	return gm.MockUpdateStaffProfileFn(ctx, staffID, input)
+}
+
+// AddStaffFacilities mocks the implementation of giving a staff member access to facilities
+func (gm *PostgresMock) AddStaffFacilities(ctx context.Context, staffID string, facilityIDs []string) (bool, error) {
+	return gm.MockAddStaffFacilitiesFn(ctx, staffID, facilityIDs)
+}
+
+// RemoveStaffFacilities mocks the implementation of revoking a staff member's access to facilities
+func (gm *PostgresMock) RemoveStaffFacilities(ctx context.Context, staffID string, facilityIDs []string) (bool, error) {
+	return gm.MockRemoveStaffFacilitiesFn(ctx, staffID, facilityIDs)
+}
+
+// AssignRoles mocks the implementation of assigning roles to a user
+func (gm *PostgresMock) AssignRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+	return gm.MockAssignRolesFn(ctx, userID, roles)
+}
+
+// RevokeRoles mocks the implementation of revoking a user's roles
+func (gm *PostgresMock) RevokeRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+	return gm.MockRevokeRolesFn(ctx, userID, roles)
+}
+
+// GetUserRoles mocks the implementation of getting the roles assigned to a user
+func (gm *PostgresMock) GetUserRoles(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+	return gm.MockGetUserRolesFn(ctx, userID)
+}
+
+// GetClientProfileByClientID mocks the implementation of getting a client profile by its ID
+func (gm *PostgresMock) GetClientProfileByClientID(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+	return gm.MockGetClientProfileByClientIDFn(ctx, clientID)
+}
+
+// CreateOrganisation mocks the implementation of creating an organisation
+func (gm *PostgresMock) CreateOrganisation(ctx context.Context, input *dto.OrganisationInput) (*domain.Organisation, error) {
+	return gm.MockCreateOrganisationFn(ctx, input)
+}
+
+// GetOrganisation mocks the implementation of fetching an organisation
+func (gm *PostgresMock) GetOrganisation(ctx context.Context, organisationID string) (*domain.Organisation, error) {
+	return gm.MockGetOrganisationFn(ctx, organisationID)
+}
+
+// ListOrganisations mocks the implementation of listing organisations
+func (gm *PostgresMock) ListOrganisations(ctx context.Context) ([]*domain.Organisation, error) {
+	return gm.MockListOrganisationsFn(ctx)
+}
+
+// GetOrganisationSettings mocks the implementation of fetching the settings of an organisation
+func (gm *PostgresMock) GetOrganisationSettings(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error) {
+	return gm.MockGetOrganisationSettingsFn(ctx, organisationID)
+}
+
+// UpdateOrganisation mocks the implementation of updating an organisation
+func (gm *PostgresMock) UpdateOrganisation(ctx context.Context, organisationID string, input *dto.OrganisationUpdateInput) (bool, error) {
+	return gm.MockUpdateOrganisationFn(ctx, organisationID, input)
+}
+
+// DeactivateOrganisation mocks the implementation of deactivating an organisation
+func (gm *PostgresMock) DeactivateOrganisation(ctx context.Context, organisationID string) (bool, error) {
+	return gm.MockDeactivateOrganisationFn(ctx, organisationID)
+}
+
+// UpdateFacility mocks the implementation of updating the details of a facility
+func (gm *PostgresMock) UpdateFacility(ctx context.Context, mflCode int, input *dto.FacilityUpdateInput, changedBy string) (*domain.Facility, error) {
+	return gm.MockUpdateFacilityFn(ctx, mflCode, input, changedBy)
+}
+
+// GetFacilityHistory mocks the implementation of fetching the changes made to a facility
+func (gm *PostgresMock) GetFacilityHistory(ctx context.Context, mflCode int) ([]*domain.FacilityHistory, error) {
+	return gm.MockGetFacilityHistoryFn(ctx, mflCode)
+}
+
+// UpsertFacilities mocks the implementation of creating or updating facilities from a KMFL export
+func (gm *PostgresMock) UpsertFacilities(ctx context.Context, facilities []*dto.FacilityInput, changedBy string, dryRun bool) ([]*domain.FacilityImportRow, error) {
+	return gm.MockUpsertFacilitiesFn(ctx, facilities, changedBy, dryRun)
+}
+
+// NearbyFacilities mocks the implementation of fetching the facilities near a location
+func (gm *PostgresMock) NearbyFacilities(ctx context.Context, input *dto.NearbyFacilitiesInput) ([]*domain.NearbyFacility, error) {
+	return gm.MockNearbyFacilitiesFn(ctx, input)
+}
+
+// SetFacilityOpeningHours mocks the implementation of replacing a facility's opening hours
+func (gm *PostgresMock) SetFacilityOpeningHours(ctx context.Context, mflCode int, openingHours []*dto.FacilityOpeningHoursInput) (bool, error) {
+	return gm.MockSetFacilityOpeningHoursFn(ctx, mflCode, openingHours)
+}
+
+// SetFacilityHoursException mocks the implementation of changing a facility's opening hours on a date
+func (gm *PostgresMock) SetFacilityHoursException(ctx context.Context, mflCode int, input *dto.FacilityHoursExceptionInput) (bool, error) {
+	return gm.MockSetFacilityHoursExceptionFn(ctx, mflCode, input)
+}
+
+// SetFacilityServices mocks the implementation of replacing the services a facility offers
+func (gm *PostgresMock) SetFacilityServices(ctx context.Context, mflCode int, serviceCodes []string) (bool, error) {
+	return gm.MockSetFacilityServicesFn(ctx, mflCode, serviceCodes)
+}
+
+// CreateFacilityService mocks the implementation of adding a service to the catalogue
+func (gm *PostgresMock) CreateFacilityService(ctx context.Context, input *dto.FacilityServiceInput) (*domain.FacilityService, error) {
+	return gm.MockCreateFacilityServiceFn(ctx, input)
+}
+
+// ListFacilityServices mocks the implementation of listing the catalogue of facility services
+func (gm *PostgresMock) ListFacilityServices(ctx context.Context) ([]*domain.FacilityService, error) {
+	return gm.MockListFacilityServicesFn(ctx)
+}
+
+// DeleteFacilityHoursException mocks the implementation of removing a facility's hours exception
+func (gm *PostgresMock) DeleteFacilityHoursException(ctx context.Context, mflCode int, date string) (bool, error) {
+	return gm.MockDeleteFacilityHoursExceptionFn(ctx, mflCode, date)
+}
+
+// CreateClientTransfer mocks the implementation of recording a client transfer and its service request
+func (gm *PostgresMock) CreateClientTransfer(ctx context.Context, transfer *domain.ClientTransfer, serviceRequest *domain.ClientServiceRequest) (*domain.ClientTransfer, error) {
+	return gm.MockCreateClientTransferFn(ctx, transfer, serviceRequest)
+}
+
+// GetClientTransferByID mocks the implementation of fetching a client transfer by its ID
+func (gm *PostgresMock) GetClientTransferByID(ctx context.Context, transferID string) (*domain.ClientTransfer, error) {
+	return gm.MockGetClientTransferByIDFn(ctx, transferID)
+}
+
+// CheckClientHasPendingTransfer mocks the implementation of checking whether a client has a pending transfer
+func (gm *PostgresMock) CheckClientHasPendingTransfer(ctx context.Context, clientID string) (bool, error) {
+	return gm.MockCheckClientHasPendingTransferFn(ctx, clientID)
+}
+
+// ListPendingClientTransfers mocks the implementation of listing the pending transfers into a facility
+func (gm *PostgresMock) ListPendingClientTransfers(ctx context.Context, facilityID string) ([]*domain.ClientTransfer, error) {
+	return gm.MockListPendingClientTransfersFn(ctx, facilityID)
+}
+
+// GetClientFacilityHistory mocks the implementation of fetching the facilities a client has been assigned to
+func (gm *PostgresMock) GetClientFacilityHistory(ctx context.Context, clientID string) ([]*domain.ClientFacilityPeriod, error) {
+	return gm.MockGetClientFacilityHistoryFn(ctx, clientID)
+}
+
+// RespondToClientTransfer mocks the implementation of accepting or declining a client transfer
+func (gm *PostgresMock) RespondToClientTransfer(ctx context.Context, transferID string, status enums.ClientTransferStatus, respondedByID string, note string) (*domain.ClientTransfer, error) {
+	return gm.MockRespondToClientTransferFn(ctx, transferID, status, respondedByID, note)
+}
+
+// ListContentItems mocks the implementation of reading content items from the tables shared with the CMS
+func (gm *PostgresMock) ListContentItems(ctx context.Context, categoryID *int, contentIDs []int, limit int) (*domain.Content, error) {
+	return gm.MockListContentItemsFn(ctx, categoryID, contentIDs, limit)
+}
+
+// SearchContent mocks the implementation of searching content in the tables shared with the CMS
+func (gm *PostgresMock) SearchContent(ctx context.Context, input *dto.ContentSearchInput, paginationsInput *dto.PaginationsInput) (*domain.ContentSearchPage, error) {
+	return gm.MockSearchContentFn(ctx, input, paginationsInput)
+}
+
+// ListRecommendedContent mocks the implementation of recommending content from a user's engagement history
+func (gm *PostgresMock) ListRecommendedContent(ctx context.Context, userID string, limit int) (*domain.Content, error) {
+	return gm.MockListRecommendedContentFn(ctx, userID, limit)
+}
+
+// ListContentFeed mocks the implementation of listing a page of the content feed
+func (gm *PostgresMock) ListContentFeed(ctx context.Context, categoryID *int, sort enums.ContentSortOrder, trendingDays int, after *domain.ContentFeedPosition, before *domain.ContentFeedPosition, limit int) (*domain.ContentFeedPage, error) {
+	return gm.MockListContentFeedFn(ctx, categoryID, sort, trendingDays, after, before, limit)
+}
+
+// ReconcileContentEngagement mocks the implementation of reconciling the content engagement counters
+func (gm *PostgresMock) ReconcileContentEngagement(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error) {
+	return gm.MockReconcileContentEngagementFn(ctx, dryRun)
+}
+
+// GetContentAnalytics mocks the implementation of getting content analytics
+func (gm *PostgresMock) GetContentAnalytics(ctx context.Context, input *dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error) {
+	return gm.MockGetContentAnalyticsFn(ctx, input)
+}
+
+// RecordContentReadDuration mocks the implementation of recording how long a user spent reading content
+func (gm *PostgresMock) RecordContentReadDuration(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error) {
+	return gm.MockRecordContentReadDurationFn(ctx, userID, contentID, durationSeconds)
+}
+
+// RecordContentProgress mocks the implementation of recording a user's progress with content
+func (gm *PostgresMock) RecordContentProgress(ctx context.Context, userID string, contentID int, secondsSpent int, percentScrolled float64, threshold domain.ContentCompletionThreshold) (*domain.ContentProgressUpdate, error) {
+	return gm.MockRecordContentProgressFn(ctx, userID, contentID, secondsSpent, percentScrolled, threshold)
+}
+
+// ListContentInProgress mocks the implementation of listing the content a user has started reading
+func (gm *PostgresMock) ListContentInProgress(ctx context.Context, userID string, limit int) ([]*domain.ContentProgress, error) {
+	return gm.MockListContentInProgressFn(ctx, userID, limit)
+}
+
+// RecordContentShareOpen mocks the implementation of recording that a shared link was opened
+func (gm *PostgresMock) RecordContentShareOpen(ctx context.Context, shareLinkID string, openedByID *string) (*domain.ContentShareLink, error) {
+	return gm.MockRecordContentShareOpenFn(ctx, shareLinkID, openedByID)
+}
+
+// GetContentCommentByID mocks the implementation of fetching a content comment by its ID
+func (gm *PostgresMock) GetContentCommentByID(ctx context.Context, commentID string) (*domain.ContentComment, error) {
+	return gm.MockGetContentCommentByIDFn(ctx, commentID)
+}
+
+// ListContentComments mocks the implementation of listing the comments on a content item
+func (gm *PostgresMock) ListContentComments(ctx context.Context, contentID int) ([]*domain.ContentComment, error) {
+	return gm.MockListContentCommentsFn(ctx, contentID)
+}
+
+// ListContentCommentsByStatus mocks the implementation of listing the content comments in the supplied statuses
+func (gm *PostgresMock) ListContentCommentsByStatus(ctx context.Context, statuses []enums.ContentCommentStatus, limit int) ([]*domain.ContentComment, error) {
+	return gm.MockListContentCommentsByStatusFn(ctx, statuses, limit)
+}
+
+// CreateContentComment mocks the implementation of creating a content comment
+func (gm *PostgresMock) CreateContentComment(ctx context.Context, userID string, contentID int, parentID *string, body string, status enums.ContentCommentStatus) (*domain.ContentComment, error) {
+	return gm.MockCreateContentCommentFn(ctx, userID, contentID, parentID, body, status)
+}
+
+// EditContentComment mocks the implementation of editing a content comment
+func (gm *PostgresMock) EditContentComment(ctx context.Context, commentID string, body string, status enums.ContentCommentStatus) (*domain.ContentComment, error) {
+	return gm.MockEditContentCommentFn(ctx, commentID, body, status)
+}
+
+// DeleteContentComment mocks the implementation of deleting a content comment
+func (gm *PostgresMock) DeleteContentComment(ctx context.Context, commentID string) (bool, error) {
+	return gm.MockDeleteContentCommentFn(ctx, commentID)
+}
+
+// ModerateContentComment mocks the implementation of moderating a content comment
+func (gm *PostgresMock) ModerateContentComment(ctx context.Context, commentID string, status enums.ContentCommentStatus, moderatorID string) (*domain.ContentComment, error) {
+	return gm.MockModerateContentCommentFn(ctx, commentID, status, moderatorID)
+}
+
+// CreateBulkInviteJob CreateBulkInviteJob mocks the implementation of saving a bulk invite job
+func (gm *PostgresMock) CreateBulkInviteJob(ctx context.Context, job *domain.BulkInviteJob) (*domain.BulkInviteJob, error) {
+	return gm.MockCreateBulkInviteJobFn(ctx, job)
+}
+
+// GetBulkInviteJob GetBulkInviteJob mocks the implementation of getting a bulk invite job
+func (gm *PostgresMock) GetBulkInviteJob(ctx context.Context, jobID string) (*domain.BulkInviteJob, error) {
+	return gm.MockGetBulkInviteJobFn(ctx, jobID)
+}
+
+// UpdateBulkInviteJobStatus UpdateBulkInviteJobStatus mocks the implementation of updating the status of a bulk invite job
+func (gm *PostgresMock) UpdateBulkInviteJobStatus(ctx context.Context, jobID string, status enums.BulkInviteJobStatus) (bool, error) {
+	return gm.MockUpdateBulkInviteJobStatusFn(ctx, jobID, status)
+}
+
+// RecordBulkInviteRowOutcome RecordBulkInviteRowOutcome mocks the implementation of recording the outcome of a bulk invite row
+func (gm *PostgresMock) RecordBulkInviteRowOutcome(ctx context.Context, jobID string, rowID string, status enums.BulkInviteRowStatus, errorMessage string) (bool, error) {
+	return gm.MockRecordBulkInviteRowOutcomeFn(ctx, jobID, rowID, status, errorMessage)
+}
+
+// FailInterruptedBulkInviteJobs mocks the implementation of failing the bulk invite jobs that were interrupted
+func (gm *PostgresMock) FailInterruptedBulkInviteJobs(ctx context.Context, staleAfter time.Duration) (int, error) {
+	return gm.MockFailInterruptedBulkInviteJobsFn(ctx, staleAfter)
+}
+
+// AssignFirstSystemAdmin mocks the implementation of assigning the first system admin
+func (gm *PostgresMock) AssignFirstSystemAdmin(ctx context.Context, userID string) (bool, error) {
+	return gm.MockAssignFirstSystemAdminFn(ctx, userID)
+}
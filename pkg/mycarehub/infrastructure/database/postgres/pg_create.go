@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
 )
@@ -27,6 +28,9 @@ func (d *MyCareHubDb) GetOrCreateFacility(ctx context.Context, facility *dto.Fac
 		County:      facility.County,
 		Phone:       facility.Phone,
 		Description: facility.Description,
+		Latitude:    facility.Latitude,
+		Longitude:   facility.Longitude,
+		Address:     facility.Address,
 	}
 
 	facilitySession, err := d.create.GetOrCreateFacility(ctx, facilityObj)
@@ -147,15 +151,7 @@ func (d *MyCareHubDb) CreateServiceRequest(
 	ctx context.Context,
 	serviceRequestInput *domain.ClientServiceRequest,
 ) error {
-	serviceRequest := &gorm.ClientServiceRequest{
-		Active:       serviceRequestInput.Active,
-		RequestType:  serviceRequestInput.RequestType,
-		Request:      serviceRequestInput.Request,
-		Status:       serviceRequestInput.Status,
-		InProgressAt: serviceRequestInput.InProgressAt,
-		ResolvedAt:   serviceRequestInput.ResolvedAt,
-		ClientID:     serviceRequestInput.ClientID,
-	}
+	serviceRequest := mapServiceRequestToObject(serviceRequestInput)
 
 	err := d.create.CreateServiceRequest(ctx, serviceRequest)
 	if err != nil {
@@ -164,3 +160,242 @@ func (d *MyCareHubDb) CreateServiceRequest(
 
 	return nil
 }
+
+// SaveInvitation records an invite that has been sent to a user
+func (d *MyCareHubDb) SaveInvitation(ctx context.Context, invitation *domain.Invitation) (*domain.Invitation, error) {
+	if invitation == nil {
+		return nil, fmt.Errorf("invitation must be provided")
+	}
+	if invitation.UserID == "" || !invitation.Flavour.IsValid() || !invitation.Status.IsValid() {
+		return nil, fmt.Errorf("invitation must have a userID, a valid flavour and a valid status")
+	}
+
+	invitationObj := &gorm.Invitation{
+		UserID:      invitation.UserID,
+		PhoneNumber: invitation.PhoneNumber,
+		Flavour:     invitation.Flavour,
+		Status:      invitation.Status,
+		IssuedAt:    invitation.IssuedAt,
+		DeliveredAt: invitation.DeliveredAt,
+		AcceptedAt:  invitation.AcceptedAt,
+		ExpiresAt:   invitation.ExpiresAt,
+	}
+
+	savedInvitation, err := d.create.SaveInvitation(ctx, invitationObj)
+	if err != nil {
+		return nil, err
+	}
+	return mapInvitationObjectToDomain(savedInvitation), nil
+}
+
+// RegisterClient creates the user, contact and client profile records of a new client
+func (d *MyCareHubDb) RegisterClient(ctx context.Context, client *domain.ClientProfile) (*domain.ClientProfile, error) {
+	if client == nil || client.User == nil || client.User.Contacts == nil {
+		return nil, fmt.Errorf("client, user and contact details must be provided")
+	}
+
+	user, contact := mapRegistrationUserToObject(client.User)
+	clientObject := &gorm.Client{
+		Active:                  client.Active,
+		ClientType:              client.ClientType,
+		TreatmentEnrollmentDate: client.TreatmentEnrollmentDate,
+		ClientCounselled:        client.ClientCounselled,
+		FacilityID:              client.FacilityID,
+		CHVUserID:               client.CHVUserID,
+	}
+
+	registeredClient, err := d.create.RegisterClient(ctx, user, contact, clientObject)
+	if err != nil {
+		return nil, err
+	}
+	return mapClientObjectToDomain(registeredClient), nil
+}
+
+// RegisterStaff creates the user, contact and staff profile records of a new staff member
+func (d *MyCareHubDb) RegisterStaff(ctx context.Context, staff *domain.StaffProfile) (*domain.StaffProfile, error) {
+	if staff == nil || staff.User == nil || staff.User.Contacts == nil {
+		return nil, fmt.Errorf("staff, user and contact details must be provided")
+	}
+
+	user, contact := mapRegistrationUserToObject(staff.User)
+	staffObject := &gorm.StaffProfile{
+		Active:            staff.Active,
+		StaffNumber:       staff.StaffNumber,
+		Cadre:             staff.Cadre,
+		DefaultFacilityID: staff.DefaultFacilityID,
+	}
+
+	registeredStaff, err := d.create.RegisterStaff(ctx, user, contact, staffObject)
+	if err != nil {
+		return nil, err
+	}
+	return d.mapStaffWithFacilitiesToDomain(ctx, registeredStaff)
+}
+
+// CreateStaffProfile creates a staff profile for an existing user. The staff member is given access to
+// their default facility and any other facility in the profile's list of facilities.
+func (d *MyCareHubDb) CreateStaffProfile(ctx context.Context, staff *domain.StaffProfile) (*domain.StaffProfile, error) {
+	if staff == nil {
+		return nil, fmt.Errorf("staff must be provided")
+	}
+	if staff.UserID == "" || staff.StaffNumber == "" || staff.DefaultFacilityID == "" {
+		return nil, fmt.Errorf("userID, staff number and default facility must be defined")
+	}
+	if !staff.Cadre.IsValid() {
+		return nil, fmt.Errorf("invalid staff cadre: %v", staff.Cadre)
+	}
+
+	facilityIDs := []string{}
+	for _, facility := range staff.Facilities {
+		if facility == nil || facility.ID == nil {
+			return nil, fmt.Errorf("staff facilities must have an ID")
+		}
+		facilityIDs = append(facilityIDs, *facility.ID)
+	}
+
+	staffObject := &gorm.StaffProfile{
+		UserID:            &staff.UserID,
+		Active:            staff.Active,
+		StaffNumber:       staff.StaffNumber,
+		Cadre:             staff.Cadre,
+		DefaultFacilityID: staff.DefaultFacilityID,
+	}
+	createdStaff, err := d.create.CreateStaffProfile(ctx, staffObject, facilityIDs)
+	if err != nil {
+		return nil, err
+	}
+	return d.mapStaffWithFacilitiesToDomain(ctx, createdStaff)
+}
+
+// AssignRoles gives a user the supplied roles
+func (d *MyCareHubDb) AssignRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+	if userID == "" || len(roles) == 0 {
+		return false, fmt.Errorf("userID and roles must be defined")
+	}
+	for _, role := range roles {
+		if !role.IsValid() {
+			return false, fmt.Errorf("invalid role: %v", role)
+		}
+	}
+	return d.create.AssignRoles(ctx, userID, roles)
+}
+
+// AssignFirstSystemAdmin gives a user the system admin role when there is no system admin yet
+func (d *MyCareHubDb) AssignFirstSystemAdmin(ctx context.Context, userID string) (bool, error) {
+	if userID == "" {
+		return false, fmt.Errorf("userID must be defined")
+	}
+	return d.create.AssignFirstSystemAdmin(ctx, userID)
+}
+
+// CreateOrganisation creates a new organisation. Only the settings that are provided are stored,
+// the rest follow the platform defaults
+func (d *MyCareHubDb) CreateOrganisation(ctx context.Context, input *dto.OrganisationInput) (*domain.Organisation, error) {
+	if input == nil {
+		return nil, fmt.Errorf("organisation input must be provided")
+	}
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	organisation := &gorm.Organisation{
+		OrgCode:          input.OrgCode,
+		Code:             input.Code,
+		OrganisationName: input.Name,
+		EmailAddress:     input.EmailAddress,
+		PhoneNumber:      input.PhoneNumber,
+		PostalAddress:    input.PostalAddress,
+		PhysicalAddress:  input.PhysicalAddress,
+		DefaultCountry:   input.DefaultCountry,
+	}
+	if settings := input.Settings; settings != nil {
+		organisation.PINExpiryDays = settings.PINExpiryDays
+		organisation.InvitePINExpiryDays = settings.InvitePINExpiryDays
+		organisation.DiaryCadenceHours = settings.DiaryCadenceHours
+		if settings.SMSSenderID != nil {
+			senderID := settings.SMSSenderID.String()
+			organisation.SMSSenderID = &senderID
+		}
+	}
+
+	created, err := d.create.CreateOrganisation(ctx, organisation)
+	if err != nil {
+		return nil, err
+	}
+	return mapOrganisationToDomain(created)
+}
+
+// CreateFacilityService adds a service to the catalogue of services that facilities can offer
+func (d *MyCareHubDb) CreateFacilityService(ctx context.Context, input *dto.FacilityServiceInput) (*domain.FacilityService, error) {
+	if input == nil {
+		return nil, fmt.Errorf("facility service input must be provided")
+	}
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	service, err := d.create.CreateFacilityService(ctx, &gorm.FacilityService{
+		Name:        input.Name,
+		Code:        input.Code,
+		Description: input.Description,
+		Active:      true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapFacilityServiceToDomain(service), nil
+}
+
+// CreateClientTransfer records a pending client transfer together with the service request raised with the receiving facility
+func (d *MyCareHubDb) CreateClientTransfer(ctx context.Context, transfer *domain.ClientTransfer, serviceRequest *domain.ClientServiceRequest) (*domain.ClientTransfer, error) {
+	if transfer == nil || serviceRequest == nil {
+		return nil, fmt.Errorf("client transfer and service request must be provided")
+	}
+
+	created, err := d.create.CreateClientTransfer(ctx, &gorm.ClientTransfer{
+		ClientID:       transfer.ClientID,
+		FromFacilityID: transfer.FromFacilityID,
+		ToFacilityID:   transfer.ToFacilityID,
+		Reason:         transfer.Reason,
+		RequestedByID:  transfer.RequestedByID,
+		RequestedAt:    transfer.RequestedAt,
+	}, mapServiceRequestToObject(serviceRequest))
+	if err != nil {
+		return nil, err
+	}
+	return mapClientTransferToDomain(created), nil
+}
+
+// CreateBulkInviteJob saves a bulk invite job together with its rows
+func (d *MyCareHubDb) CreateBulkInviteJob(ctx context.Context, job *domain.BulkInviteJob) (*domain.BulkInviteJob, error) {
+	if job == nil {
+		return nil, fmt.Errorf("bulk invite job must be provided")
+	}
+	if !job.Status.IsValid() || !job.Flavour.IsValid() || job.CreatedBy == "" {
+		return nil, fmt.Errorf("bulk invite job must have a valid status, a valid flavour and its creator")
+	}
+
+	jobObj := &gorm.BulkInviteJob{
+		Status:      job.Status,
+		Flavour:     job.Flavour,
+		Total:       job.Total,
+		Succeeded:   job.Succeeded,
+		Failed:      job.Failed,
+		CreatedByID: job.CreatedBy,
+	}
+	for _, row := range job.Rows {
+		jobObj.Rows = append(jobObj.Rows, &gorm.BulkInviteRow{
+			RowNumber:   row.RowNumber,
+			UserID:      row.UserID,
+			PhoneNumber: row.PhoneNumber,
+			Status:      row.Status,
+			Error:       row.Error,
+		})
+	}
+
+	createdJob, err := d.create.CreateBulkInviteJob(ctx, jobObj)
+	if err != nil {
+		return nil, err
+	}
+	return mapBulkInviteJobToDomain(createdJob), nil
+}
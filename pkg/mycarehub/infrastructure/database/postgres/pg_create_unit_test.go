@@ -506,3 +506,873 @@ func TestMyCareHubDb_CreateServiceRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestMyCareHubDb_SaveInvitation(t *testing.T) {
+	ctx := context.Background()
+
+	invitation := &domain.Invitation{
+		UserID:      uuid.New().String(),
+		PhoneNumber: interserviceclient.TestUserPhoneNumber,
+		Flavour:     feedlib.FlavourConsumer,
+		Status:      enums.InvitationStatusIssued,
+		IssuedAt:    time.Now(),
+		ExpiresAt:   time.Now().Add(time.Hour * 24),
+	}
+
+	type args struct {
+		ctx        context.Context
+		invitation *domain.Invitation
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				invitation: invitation,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:        ctx,
+				invitation: invitation,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - nil invitation",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid status",
+			args: args{
+				ctx: ctx,
+				invitation: &domain.Invitation{
+					UserID:  uuid.New().String(),
+					Flavour: feedlib.FlavourConsumer,
+					Status:  "invalid",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockSaveInvitationFn = func(ctx context.Context, invitation *gorm.Invitation) (*gorm.Invitation, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.SaveInvitation(tt.args.ctx, tt.args.invitation)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.SaveInvitation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.ID == "" {
+				t.Errorf("expected the saved invitation to have an ID")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_RegisterClient(t *testing.T) {
+	ctx := context.Background()
+
+	newClient := func() *domain.ClientProfile {
+		return &domain.ClientProfile{
+			Active:     true,
+			ClientType: "PMTCT",
+			FacilityID: uuid.New().String(),
+			User: &domain.User{
+				Username:  gofakeit.Username(),
+				FirstName: gofakeit.FirstName(),
+				LastName:  gofakeit.LastName(),
+				UserType:  enums.ClientUser,
+				Flavour:   feedlib.FlavourConsumer,
+				Contacts: &domain.Contact{
+					ContactType:  "PHONE",
+					ContactValue: interserviceclient.TestUserPhoneNumber,
+					Active:       true,
+					OptedIn:      true,
+				},
+			},
+		}
+	}
+
+	type args struct {
+		ctx    context.Context
+		client *domain.ClientProfile
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				client: newClient(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:    ctx,
+				client: newClient(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - missing contact",
+			args: args{
+				ctx: ctx,
+				client: &domain.ClientProfile{
+					User: &domain.User{Username: gofakeit.Username()},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockRegisterClientFn = func(ctx context.Context, user *gorm.User, contact *gorm.Contact, client *gorm.Client) (*gorm.Client, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.RegisterClient(tt.args.ctx, tt.args.client)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.RegisterClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.ID == nil || got.User == nil || got.User.Contacts.ContactValue != tt.args.client.User.Contacts.ContactValue {
+				t.Errorf("MyCareHubDb.RegisterClient() returned an incomplete client profile: %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_RegisterStaff(t *testing.T) {
+	ctx := context.Background()
+
+	newStaff := func() *domain.StaffProfile {
+		return &domain.StaffProfile{
+			Active:            true,
+			StaffNumber:       uuid.New().String(),
+			DefaultFacilityID: uuid.New().String(),
+			User: &domain.User{
+				Username:  gofakeit.Username(),
+				FirstName: gofakeit.FirstName(),
+				LastName:  gofakeit.LastName(),
+				UserType:  enums.HealthcareWorkerUser,
+				Flavour:   feedlib.FlavourPro,
+				Contacts: &domain.Contact{
+					ContactType:  "PHONE",
+					ContactValue: interserviceclient.TestUserPhoneNumber,
+					Active:       true,
+					OptedIn:      true,
+				},
+			},
+		}
+	}
+
+	type args struct {
+		ctx   context.Context
+		staff *domain.StaffProfile
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:   ctx,
+				staff: newStaff(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:   ctx,
+				staff: newStaff(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - missing user",
+			args: args{
+				ctx:   ctx,
+				staff: &domain.StaffProfile{StaffNumber: uuid.New().String()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockRegisterStaffFn = func(ctx context.Context, user *gorm.User, contact *gorm.Contact, staff *gorm.StaffProfile) (*gorm.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.RegisterStaff(tt.args.ctx, tt.args.staff)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.RegisterStaff() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.ID == nil || got.StaffNumber != tt.args.staff.StaffNumber) {
+				t.Errorf("MyCareHubDb.RegisterStaff() returned an incomplete staff profile: %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CreateStaffProfile(t *testing.T) {
+	ctx := context.Background()
+	facilityID := uuid.New().String()
+
+	validStaff := &domain.StaffProfile{
+		UserID:            uuid.New().String(),
+		Active:            true,
+		StaffNumber:       gofakeit.SSN(),
+		Cadre:             enums.StaffCadreNurse,
+		DefaultFacilityID: facilityID,
+		Facilities: []*domain.Facility{
+			{
+				ID: &facilityID,
+			},
+		},
+	}
+
+	type args struct {
+		ctx   context.Context
+		staff *domain.StaffProfile
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:   ctx,
+				staff: validStaff,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:   ctx,
+				staff: validStaff,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to get staff facilities",
+			args: args{
+				ctx:   ctx,
+				staff: validStaff,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no staff",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no staff number",
+			args: args{
+				ctx: ctx,
+				staff: &domain.StaffProfile{
+					UserID:            uuid.New().String(),
+					Cadre:             enums.StaffCadreNurse,
+					DefaultFacilityID: facilityID,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid cadre",
+			args: args{
+				ctx: ctx,
+				staff: &domain.StaffProfile{
+					UserID:            uuid.New().String(),
+					StaffNumber:       gofakeit.SSN(),
+					Cadre:             enums.StaffCadre("invalid"),
+					DefaultFacilityID: facilityID,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - facility without ID",
+			args: args{
+				ctx: ctx,
+				staff: &domain.StaffProfile{
+					UserID:            uuid.New().String(),
+					StaffNumber:       gofakeit.SSN(),
+					Cadre:             enums.StaffCadreNurse,
+					DefaultFacilityID: facilityID,
+					Facilities:        []*domain.Facility{{Name: gofakeit.Name()}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockCreateStaffProfileFn = func(ctx context.Context, staff *gorm.StaffProfile, facilityIDs []string) (*gorm.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case - failed to get staff facilities" {
+				fakeGorm.MockGetStaffFacilitiesFn = func(ctx context.Context, staffID string) ([]*gorm.Facility, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.CreateStaffProfile(tt.args.ctx, tt.args.staff)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CreateStaffProfile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got == nil || got.ID == nil || len(got.Facilities) == 0) {
+				t.Errorf("expected a staff profile with facilities to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_AssignRoles(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		roles  []enums.UserRoleType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				roles:  []enums.UserRoleType{enums.UserRoleTypeClinician},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				roles:  []enums.UserRoleType{enums.UserRoleTypeClinician},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid role",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				roles:  []enums.UserRoleType{"invalid"},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx:   ctx,
+				roles: []enums.UserRoleType{enums.UserRoleTypeClinician},
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockAssignRolesFn = func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.AssignRoles(tt.args.ctx, tt.args.userID, tt.args.roles)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.AssignRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.AssignRoles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_AssignFirstSystemAdmin(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx: ctx,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockAssignFirstSystemAdminFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, fmt.Errorf("a system admin already exists")
+				}
+			}
+
+			got, err := d.AssignFirstSystemAdmin(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.AssignFirstSystemAdmin() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.AssignFirstSystemAdmin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CreateOrganisation(t *testing.T) {
+	ctx := context.Background()
+	days := 14
+
+	validInput := &dto.OrganisationInput{
+		Name:           gofakeit.Company(),
+		OrgCode:        "TEST",
+		Code:           1,
+		EmailAddress:   gofakeit.Email(),
+		PhoneNumber:    interserviceclient.TestUserPhoneNumber,
+		DefaultCountry: "KEN",
+		Settings: &dto.OrganisationSettingsInput{
+			InvitePINExpiryDays: &days,
+		},
+	}
+
+	type args struct {
+		ctx   context.Context
+		input *dto.OrganisationInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - missing input",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid input",
+			args: args{
+				ctx: ctx,
+				input: &dto.OrganisationInput{
+					Name: gofakeit.Company(),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockCreateOrganisationFn = func(ctx context.Context, organisation *gorm.Organisation) (*gorm.Organisation, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.CreateOrganisation(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CreateOrganisation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Settings.InvitePINExpiryDays != days {
+				t.Errorf("expected the invite PIN expiry days to be %v, got %v", days, got.Settings.InvitePINExpiryDays)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CreateFacilityService(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx   context.Context
+		input *dto.FacilityServiceInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx: ctx,
+				input: &dto.FacilityServiceInput{
+					Name:        "ART refill",
+					Code:        "ART_REFILL",
+					Description: "Antiretroviral therapy refills",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - nil input",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid code",
+			args: args{
+				ctx: ctx,
+				input: &dto.FacilityServiceInput{
+					Name: "ART refill",
+					Code: "art refill",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to create service",
+			args: args{
+				ctx: ctx,
+				input: &dto.FacilityServiceInput{
+					Name: "ART refill",
+					Code: "ART_REFILL",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to create service" {
+				fakeGorm.MockCreateFacilityServiceFn = func(ctx context.Context, service *gorm.FacilityService) (*gorm.FacilityService, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.CreateFacilityService(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CreateFacilityService() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected a facility service to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CreateClientTransfer(t *testing.T) {
+	ctx := context.Background()
+
+	facilityID := uuid.New().String()
+	transfer := &domain.ClientTransfer{
+		ClientID:       uuid.New().String(),
+		FromFacilityID: uuid.New().String(),
+		ToFacilityID:   facilityID,
+		Reason:         "Client relocated",
+		RequestedByID:  uuid.New().String(),
+		RequestedAt:    time.Now(),
+	}
+	serviceRequest := &domain.ClientServiceRequest{
+		Active:      true,
+		RequestType: "CLIENT_TRANSFER",
+		Status:      "PENDING",
+		ClientID:    transfer.ClientID,
+		FacilityID:  facilityID,
+	}
+
+	type args struct {
+		ctx            context.Context
+		transfer       *domain.ClientTransfer
+		serviceRequest *domain.ClientServiceRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:            ctx,
+				transfer:       transfer,
+				serviceRequest: serviceRequest,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - missing service request",
+			args: args{
+				ctx:      ctx,
+				transfer: transfer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to create transfer",
+			args: args{
+				ctx:            ctx,
+				transfer:       transfer,
+				serviceRequest: serviceRequest,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			var serviceRequestObject *gorm.ClientServiceRequest
+			fakeGorm.MockCreateClientTransferFn = func(ctx context.Context, transfer *gorm.ClientTransfer, serviceRequest *gorm.ClientServiceRequest) (*gorm.ClientTransfer, error) {
+				serviceRequestObject = serviceRequest
+				id := uuid.New().String()
+				transfer.ID = &id
+				transfer.Status = enums.ClientTransferStatusPending
+				return transfer, nil
+			}
+
+			if tt.name == "Sad case - failed to create transfer" {
+				fakeGorm.MockCreateClientTransferFn = func(ctx context.Context, transfer *gorm.ClientTransfer, serviceRequest *gorm.ClientServiceRequest) (*gorm.ClientTransfer, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.CreateClientTransfer(tt.args.ctx, tt.args.transfer, tt.args.serviceRequest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CreateClientTransfer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.ID == "" || got.Status != enums.ClientTransferStatusPending {
+				t.Errorf("expected a pending transfer with an ID, got %v", got)
+			}
+			if serviceRequestObject.FacilityID == nil || *serviceRequestObject.FacilityID != facilityID {
+				t.Errorf("expected the service request to be raised with facility %v", facilityID)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CreateBulkInviteJob(t *testing.T) {
+	ctx := context.Background()
+
+	job := &domain.BulkInviteJob{
+		Status:  enums.BulkInviteJobStatusPending,
+		Flavour: feedlib.FlavourConsumer,
+		Total:   2,
+		Failed:  1,
+		Rows: []*domain.BulkInviteRow{
+			{
+				RowNumber:   1,
+				UserID:      uuid.New().String(),
+				PhoneNumber: interserviceclient.TestUserPhoneNumber,
+				Status:      enums.BulkInviteRowStatusPending,
+			},
+			{
+				RowNumber: 2,
+				Status:    enums.BulkInviteRowStatusFailed,
+				Error:     "expected 2 columns, found 1",
+			},
+		},
+		CreatedBy: uuid.New().String(),
+	}
+
+	type args struct {
+		ctx context.Context
+		job *domain.BulkInviteJob
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx: ctx,
+				job: job,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx: ctx,
+				job: job,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - nil job",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no creator",
+			args: args{
+				ctx: ctx,
+				job: &domain.BulkInviteJob{
+					Status:  enums.BulkInviteJobStatusPending,
+					Flavour: feedlib.FlavourConsumer,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			fakeGorm.MockCreateBulkInviteJobFn = func(ctx context.Context, job *gorm.BulkInviteJob) (*gorm.BulkInviteJob, error) {
+				id := uuid.New().String()
+				job.ID = &id
+				for _, row := range job.Rows {
+					rowID := uuid.New().String()
+					row.ID = &rowID
+					row.JobID = &id
+				}
+				return job, nil
+			}
+			if tt.name == "Sad case" {
+				fakeGorm.MockCreateBulkInviteJobFn = func(ctx context.Context, job *gorm.BulkInviteJob) (*gorm.BulkInviteJob, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.CreateBulkInviteJob(tt.args.ctx, tt.args.job)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CreateBulkInviteJob() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.ID == "" || len(got.Rows) != len(tt.args.job.Rows) || got.Rows[0].ID == "" {
+				t.Errorf("expected the saved job and its rows to have IDs, got %v", got)
+			}
+		})
+	}
+}
@@ -3,6 +3,10 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 )
 
 // DeleteFacility does the actual delete of a facility from the database.
@@ -12,3 +16,23 @@ func (d *MyCareHubDb) DeleteFacility(ctx context.Context, id int) (bool, error)
 	}
 	return d.delete.DeleteFacility(ctx, id)
 }
+
+// RevokeRoles removes the supplied roles from a user
+func (d *MyCareHubDb) RevokeRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+	if userID == "" || len(roles) == 0 {
+		return false, fmt.Errorf("userID and roles must be defined")
+	}
+	return d.delete.RevokeRoles(ctx, userID, roles)
+}
+
+// DeleteFacilityHoursException removes a facility's hours exception on a date
+func (d *MyCareHubDb) DeleteFacilityHoursException(ctx context.Context, mflCode int, date string) (bool, error) {
+	if mflCode == 0 {
+		return false, fmt.Errorf("facility's MFL Code cannot be empty")
+	}
+	exceptionDate, err := time.Parse(common.DateLayout, date)
+	if err != nil {
+		return false, fmt.Errorf("invalid date %v, it must be in the YYYY-MM-DD format", date)
+	}
+	return d.delete.DeleteFacilityHoursException(ctx, mflCode, exceptionDate)
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
+	"github.com/google/uuid"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	gormMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm/mock"
 )
 
@@ -128,3 +131,144 @@ func TestMyCareHubDb_DeleteFacility_Unittest(t *testing.T) {
 		})
 	}
 }
+
+func TestMyCareHubDb_RevokeRoles(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		roles  []enums.UserRoleType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				roles:  []enums.UserRoleType{enums.UserRoleTypeClinician},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				roles:  []enums.UserRoleType{enums.UserRoleTypeClinician},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no roles",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockRevokeRolesFn = func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.RevokeRoles(tt.args.ctx, tt.args.userID, tt.args.roles)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.RevokeRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.RevokeRoles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_DeleteFacilityHoursException(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx     context.Context
+		mflCode int
+		date    string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				date:    "2021-12-25",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - empty MFL code",
+			args: args{
+				ctx:  ctx,
+				date: "2021-12-25",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid date",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				date:    "25/12/2021",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to delete hours exception",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				date:    "2021-12-25",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to delete hours exception" {
+				fakeGorm.MockDeleteFacilityHoursExceptionFn = func(ctx context.Context, mflCode int, date time.Time) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.DeleteFacilityHoursException(tt.args.ctx, tt.args.mflCode, tt.args.date)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.DeleteFacilityHoursException() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.DeleteFacilityHoursException() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,10 +3,12 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
 	"github.com/savannahghi/serverutils"
@@ -21,8 +23,11 @@ func (d *MyCareHubDb) CheckWhetherUserHasLikedContent(ctx context.Context, userI
 }
 
 //GetFacilities returns a slice of healthcare facilities in the platform.
+//
+// Deprecated: GetFacilities loads every facility at once. Use ListFacilities, which is paginated.
 func (d *MyCareHubDb) GetFacilities(ctx context.Context) ([]*domain.Facility, error) {
 	var facility []*domain.Facility
+	//lint:ignore SA1019 this is the deprecated path that is kept until its callers move to ListFacilities
 	facilities, err := d.query.GetFacilities(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get facilities: %v", err)
@@ -40,6 +45,9 @@ func (d *MyCareHubDb) GetFacilities(ctx context.Context) ([]*domain.Facility, er
 			Active:      m.Active,
 			County:      m.County,
 			Description: m.Description,
+			Latitude:    m.Latitude,
+			Longitude:   m.Longitude,
+			Address:     m.Address,
 		}
 
 		facility = append(facility, &singleFacility)
@@ -74,10 +82,28 @@ func (d *MyCareHubDb) RetrieveFacilityByMFLCode(ctx context.Context, MFLCode int
 	return d.mapFacilityObjectToDomain(facilitySession), nil
 }
 
+// GetFacilityHistory fetches the changes made to the details of a facility, the most recent first
+func (d *MyCareHubDb) GetFacilityHistory(ctx context.Context, mflCode int) ([]*domain.FacilityHistory, error) {
+	if mflCode == 0 {
+		return nil, fmt.Errorf("facility's MFL Code cannot be empty")
+	}
+	history, err := d.query.GetFacilityHistory(ctx, mflCode)
+	if err != nil {
+		return nil, err
+	}
+
+	domainHistory := []*domain.FacilityHistory{}
+	for _, entry := range history {
+		domainHistory = append(domainHistory, mapFacilityHistoryToDomain(entry))
+	}
+	return domainHistory, nil
+}
+
 // ListFacilities gets facilities that are filtered from search and filter,
-// the results are also paginated
+// the results are also paginated. An invalid filter is reported with a *domain.FilterValidationError
+// that names it.
 func (d *MyCareHubDb) ListFacilities(
-	ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
+	ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, filterGroups []*dto.FilterGroupInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
 	// if user did not provide current page, throw an error
 	if err := paginationsInput.Validate(); err != nil {
 		return nil, fmt.Errorf("pagination input validation failed: %v", err)
@@ -94,23 +120,67 @@ func (d *MyCareHubDb) ListFacilities(
 			Sort:        sortOutput,
 		},
 	}
-	filtersOutput := []*domain.FiltersParam{}
-	for _, f := range filterInput {
-		filter := &domain.FiltersParam{
-			Name:     string(f.DataType),
-			DataType: f.DataType,
-			Value:    f.Value,
+	filtersOutput := mapFiltersInputToParams(filterInput)
+	for _, f := range filtersOutput {
+		if err := f.ValidateForCategory(enums.FilterSortCategoryTypeFacility); err != nil {
+			return nil, err
+		}
+	}
+	groupsOutput := []*domain.FilterGroup{}
+	for _, g := range filterGroups {
+		if g == nil {
+			continue
 		}
-		filtersOutput = append(filtersOutput, filter)
+		group := &domain.FilterGroup{
+			Operator: g.Operator,
+			Filters:  mapFiltersInputToParams(g.Filters),
+		}
+		if err := group.ValidateForCategory(enums.FilterSortCategoryTypeFacility); err != nil {
+			return nil, err
+		}
+		groupsOutput = append(groupsOutput, group)
 	}
 
-	facilities, err := d.query.ListFacilities(ctx, searchTerm, filtersOutput, &paginationOutput)
+	facilities, err := d.query.ListFacilities(ctx, searchTerm, filtersOutput, groupsOutput, &paginationOutput)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get facilities: %v", err)
 	}
 	return facilities, nil
 }
 
+// NearbyFacilities fetches the facilities within the radius of a location together with their distance from it
+func (d *MyCareHubDb) NearbyFacilities(ctx context.Context, input *dto.NearbyFacilitiesInput) ([]*domain.NearbyFacility, error) {
+	if input == nil {
+		return nil, fmt.Errorf("nearby facilities input must be provided")
+	}
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	filters := mapFiltersInputToParams(input.FilterInput)
+	var sort *domain.SortParam
+	if input.Sort != nil {
+		sort = &domain.SortParam{
+			Field:     input.Sort.Field,
+			Direction: input.Sort.Direction,
+		}
+	}
+
+	facilities, err := d.query.NearbyFacilities(ctx, input.Latitude, input.Longitude, input.RadiusKm, input.Limit, filters, sort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nearby facilities: %v", err)
+	}
+
+	nearbyFacilities := []*domain.NearbyFacility{}
+	for _, facility := range facilities {
+		nearbyFacilities = append(nearbyFacilities, &domain.NearbyFacility{
+			Facility:   *d.mapFacilityObjectToDomain(&facility.Facility),
+			DistanceKm: facility.DistanceKm,
+		})
+	}
+	return nearbyFacilities, nil
+}
+
 // GetUserProfileByPhoneNumber fetches and returns a userprofile using their phonenumber
 func (d *MyCareHubDb) GetUserProfileByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.User, error) {
 	if phoneNumber == "" {
@@ -276,21 +346,7 @@ func (d *MyCareHubDb) GetClientProfileByUserID(ctx context.Context, userID strin
 		return nil, err
 	}
 
-	user := createMapUser(&response.UserProfile)
-	return &domain.ClientProfile{
-		ID:                      response.ID,
-		User:                    user,
-		Active:                  response.Active,
-		ClientType:              response.ClientType,
-		TreatmentEnrollmentDate: response.TreatmentEnrollmentDate,
-		FHIRPatientID:           response.FHIRPatientID,
-		HealthRecordID:          response.HealthRecordID,
-		TreatmentBuddy:          response.TreatmentBuddy,
-		ClientCounselled:        response.ClientCounselled,
-		OrganisationID:          response.OrganisationID,
-		FacilityID:              response.FacilityID,
-		CHVUserID:               response.CHVUserID,
-	}, nil
+	return mapClientObjectToDomain(response), nil
 }
 
 // CheckUserHasPin performs a look up on the pins table to check whether a user has a pin
@@ -451,9 +507,161 @@ func (d *MyCareHubDb) GetUserBookmarkedContent(ctx context.Context, userID strin
 	return domainContent, nil
 }
 
+// ListContentItems reads published content from the database tables shared with the CMS. It is used when the CMS
+// API can't be reached. The category and content IDs are optional filters
+func (d *MyCareHubDb) ListContentItems(ctx context.Context, categoryID *int, contentIDs []int, limit int) (*domain.Content, error) {
+	contentItems, total, err := d.query.ListContentItems(ctx, categoryID, contentIDs, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content items: %v", err)
+	}
+
+	return mapContentItemsDetailsToContent(contentItems, int(total)), nil
+}
+
+// ListRecommendedContent returns the published content recommended for the user from their engagement history
+func (d *MyCareHubDb) ListRecommendedContent(ctx context.Context, userID string, limit int) (*domain.Content, error) {
+	contentItems, err := d.query.ListRecommendedContentItems(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recommended content: %v", err)
+	}
+
+	return mapContentItemsDetailsToContent(contentItems, len(contentItems)), nil
+}
+
+// ListContentFeed reads a page of the content feed from the database tables shared with the CMS in the given order.
+// The page starts after the given position or ends before it
+func (d *MyCareHubDb) ListContentFeed(
+	ctx context.Context,
+	categoryID *int,
+	sort enums.ContentSortOrder,
+	trendingDays int,
+	after *domain.ContentFeedPosition,
+	before *domain.ContentFeedPosition,
+	limit int,
+) (*domain.ContentFeedPage, error) {
+	if !sort.IsValid() {
+		return nil, fmt.Errorf("invalid content sort order: %v", sort)
+	}
+	page, err := d.query.ListContentFeed(ctx, categoryID, sort, trendingDays, after, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the content feed: %v", err)
+	}
+
+	return &domain.ContentFeedPage{
+		Content:   mapContentItemsDetailsToContent(page.Items, int(page.Total)),
+		Positions: page.Positions,
+		HasMore:   page.HasMore,
+	}, nil
+}
+
+// GetContentAnalytics reports how clients engaged with content, per content item and per category, over a period
+func (d *MyCareHubDb) GetContentAnalytics(ctx context.Context, input *dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error) {
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("content analytics input validation failed: %v", err)
+	}
+
+	analytics, err := d.query.GetContentAnalytics(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content analytics: %v", err)
+	}
+	return analytics, nil
+}
+
+// ListContentInProgress returns the content items that a user has started but not completed together with their
+// progress, the most recently read first
+func (d *MyCareHubDb) ListContentInProgress(ctx context.Context, userID string, limit int) ([]*domain.ContentProgress, error) {
+	inProgress, err := d.query.ListContentInProgress(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content in progress: %v", err)
+	}
+
+	storageURL := serverutils.MustGetEnvVar(helpers.GoogleCloudStorageURL)
+	progress := []*domain.ContentProgress{}
+	for _, p := range inProgress {
+		contentProgress := mapContentProgressToDomain(p.Progress)
+		item := mapContentItemDetailsToDomain(p.Details, storageURL)
+		contentProgress.Item = &item
+		progress = append(progress, contentProgress)
+	}
+	return progress, nil
+}
+
+// GetContentCommentByID fetches a content comment by its ID
+func (d *MyCareHubDb) GetContentCommentByID(ctx context.Context, commentID string) (*domain.ContentComment, error) {
+	if commentID == "" {
+		return nil, fmt.Errorf("commentID cannot be empty")
+	}
+	comment, err := d.query.GetContentCommentByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	return mapContentCommentToDomain(comment), nil
+}
+
+// ListContentComments fetches all the comments on a content item, oldest first
+func (d *MyCareHubDb) ListContentComments(ctx context.Context, contentID int) ([]*domain.ContentComment, error) {
+	comments, err := d.query.ListContentComments(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	return mapContentCommentsToDomain(comments), nil
+}
+
+// ListContentCommentsByStatus fetches the content comments in the supplied statuses, oldest first
+func (d *MyCareHubDb) ListContentCommentsByStatus(ctx context.Context, statuses []enums.ContentCommentStatus, limit int) ([]*domain.ContentComment, error) {
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("at least one comment status must be provided")
+	}
+	for _, status := range statuses {
+		if !status.IsValid() {
+			return nil, fmt.Errorf("invalid content comment status: %v", status)
+		}
+	}
+	comments, err := d.query.ListContentCommentsByStatus(ctx, statuses, limit)
+	if err != nil {
+		return nil, err
+	}
+	return mapContentCommentsToDomain(comments), nil
+}
+
+// SearchContent searches published content in the database tables shared with the CMS and returns a page of
+// the matching content items ranked with the best match first
+func (d *MyCareHubDb) SearchContent(ctx context.Context, input *dto.ContentSearchInput, paginationsInput *dto.PaginationsInput) (*domain.ContentSearchPage, error) {
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("content search input validation failed: %v", err)
+	}
+	if err := paginationsInput.Validate(); err != nil {
+		return nil, fmt.Errorf("pagination input validation failed: %v", err)
+	}
+
+	pagination := &domain.Pagination{
+		Limit:       paginationsInput.Limit,
+		CurrentPage: paginationsInput.CurrentPage,
+	}
+	page, err := d.query.SearchContent(ctx, input, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search content: %v", err)
+	}
+
+	storageURL := serverutils.MustGetEnvVar(helpers.GoogleCloudStorageURL)
+	searchPage := &domain.ContentSearchPage{
+		Pagination: page.Pagination,
+		Results:    []*domain.ContentSearchResult{},
+	}
+	for _, hit := range page.Results {
+		searchPage.Results = append(searchPage.Results, &domain.ContentSearchResult{
+			Item:    mapContentItemDetailsToDomain(hit.Details, storageURL),
+			Rank:    hit.Rank,
+			Snippet: hit.Snippet,
+		})
+	}
+
+	return searchPage, nil
+}
+
 // CanRecordHeathDiary is used to check if the user can record their health diary
-func (d *MyCareHubDb) CanRecordHeathDiary(ctx context.Context, userID string) (bool, error) {
-	canRecord, err := d.query.CanRecordHeathDiary(ctx, userID)
+func (d *MyCareHubDb) CanRecordHeathDiary(ctx context.Context, userID string, cadence time.Duration) (bool, error) {
+	canRecord, err := d.query.CanRecordHeathDiary(ctx, userID, cadence)
 	if err != nil {
 		return false, err
 	}
@@ -530,3 +738,221 @@ func (d *MyCareHubDb) GetFAQContent(ctx context.Context, flavour feedlib.Flavour
 
 	return faq, nil
 }
+
+// GetLatestUserInvitation fetches the most recent invite sent to a user
+func (d *MyCareHubDb) GetLatestUserInvitation(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.Invitation, error) {
+	if userID == "" || !flavour.IsValid() {
+		return nil, fmt.Errorf("userID and a valid flavour must be provided")
+	}
+	invitation, err := d.query.GetLatestUserInvitation(ctx, userID, flavour)
+	if err != nil {
+		return nil, err
+	}
+	return mapInvitationObjectToDomain(invitation), nil
+}
+
+// ListPendingInvitations fetches the invites that have not been accepted by the clients of a facility
+func (d *MyCareHubDb) ListPendingInvitations(ctx context.Context, facilityID string) ([]*domain.Invitation, error) {
+	if facilityID == "" {
+		return nil, fmt.Errorf("facilityID must be provided")
+	}
+	invitations, err := d.query.ListPendingInvitations(ctx, facilityID)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingInvitations := []*domain.Invitation{}
+	for _, invitation := range invitations {
+		pendingInvitations = append(pendingInvitations, mapInvitationObjectToDomain(invitation))
+	}
+	return pendingInvitations, nil
+}
+
+// GetStaffProfileByUserID fetches a user's staff profile together with the facilities they can access
+func (d *MyCareHubDb) GetStaffProfileByUserID(ctx context.Context, userID string) (*domain.StaffProfile, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID must be defined")
+	}
+	staff, err := d.query.GetStaffProfileByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return d.mapStaffWithFacilitiesToDomain(ctx, staff)
+}
+
+// GetStaffProfileByStaffID fetches a staff profile together with the facilities the staff member can access
+func (d *MyCareHubDb) GetStaffProfileByStaffID(ctx context.Context, staffID string) (*domain.StaffProfile, error) {
+	if staffID == "" {
+		return nil, fmt.Errorf("staff ID must be defined")
+	}
+	staff, err := d.query.GetStaffProfileByStaffID(ctx, staffID)
+	if err != nil {
+		return nil, err
+	}
+	return d.mapStaffWithFacilitiesToDomain(ctx, staff)
+}
+
+// GetStaffFacilities fetches the facilities that a staff member is allowed to access
+func (d *MyCareHubDb) GetStaffFacilities(ctx context.Context, staffID string) ([]*domain.Facility, error) {
+	if staffID == "" {
+		return nil, fmt.Errorf("staff ID must be defined")
+	}
+	facilities, err := d.query.GetStaffFacilities(ctx, staffID)
+	if err != nil {
+		return nil, err
+	}
+
+	staffFacilities := []*domain.Facility{}
+	for _, facility := range facilities {
+		staffFacilities = append(staffFacilities, d.mapFacilityObjectToDomain(facility))
+	}
+	return staffFacilities, nil
+}
+
+// GetUserRoles fetches the roles assigned to a user
+func (d *MyCareHubDb) GetUserRoles(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID must be defined")
+	}
+	userRoles, err := d.query.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := []enums.UserRoleType{}
+	for _, userRole := range userRoles {
+		roles = append(roles, userRole.Role)
+	}
+	return roles, nil
+}
+
+// GetClientProfileByClientID fetches a client profile using its ID
+func (d *MyCareHubDb) GetClientProfileByClientID(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+	if clientID == "" {
+		return nil, fmt.Errorf("client ID must be defined")
+	}
+	client, err := d.query.GetClientProfileByClientID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return mapClientObjectToDomain(client), nil
+}
+
+// GetOrganisation fetches an organisation together with its effective settings
+func (d *MyCareHubDb) GetOrganisation(ctx context.Context, organisationID string) (*domain.Organisation, error) {
+	if organisationID == "" {
+		return nil, fmt.Errorf("organisation ID must be defined")
+	}
+	organisation, err := d.query.GetOrganisation(ctx, organisationID)
+	if err != nil {
+		return nil, err
+	}
+	return mapOrganisationToDomain(organisation)
+}
+
+// ListOrganisations fetches all the organisations on the platform
+func (d *MyCareHubDb) ListOrganisations(ctx context.Context) ([]*domain.Organisation, error) {
+	organisations, err := d.query.ListOrganisations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	domainOrganisations := []*domain.Organisation{}
+	for _, organisation := range organisations {
+		domainOrganisation, err := mapOrganisationToDomain(organisation)
+		if err != nil {
+			return nil, err
+		}
+		domainOrganisations = append(domainOrganisations, domainOrganisation)
+	}
+	return domainOrganisations, nil
+}
+
+// GetOrganisationSettings returns the settings that apply to the users of an organisation
+func (d *MyCareHubDb) GetOrganisationSettings(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error) {
+	organisation, err := d.GetOrganisation(ctx, organisationID)
+	if err != nil {
+		return nil, err
+	}
+	return organisation.Settings, nil
+}
+
+// ListFacilityServices fetches the catalogue of services that facilities can offer
+func (d *MyCareHubDb) ListFacilityServices(ctx context.Context) ([]*domain.FacilityService, error) {
+	services, err := d.query.ListFacilityServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	facilityServices := []*domain.FacilityService{}
+	for _, service := range services {
+		facilityServices = append(facilityServices, mapFacilityServiceToDomain(service))
+	}
+	return facilityServices, nil
+}
+
+// GetClientTransferByID fetches a client transfer using its ID
+func (d *MyCareHubDb) GetClientTransferByID(ctx context.Context, transferID string) (*domain.ClientTransfer, error) {
+	if transferID == "" {
+		return nil, fmt.Errorf("client transfer ID must be defined")
+	}
+	transfer, err := d.query.GetClientTransferByID(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	return mapClientTransferToDomain(transfer), nil
+}
+
+// CheckClientHasPendingTransfer checks whether a client has a transfer awaiting the receiving facility's response
+func (d *MyCareHubDb) CheckClientHasPendingTransfer(ctx context.Context, clientID string) (bool, error) {
+	if clientID == "" {
+		return false, fmt.Errorf("client ID must be defined")
+	}
+	return d.query.CheckClientHasPendingTransfer(ctx, clientID)
+}
+
+// ListPendingClientTransfers fetches the transfers into a facility that it has not responded to
+func (d *MyCareHubDb) ListPendingClientTransfers(ctx context.Context, facilityID string) ([]*domain.ClientTransfer, error) {
+	if facilityID == "" {
+		return nil, fmt.Errorf("facility ID must be defined")
+	}
+	transfers, err := d.query.ListPendingClientTransfers(ctx, facilityID)
+	if err != nil {
+		return nil, err
+	}
+
+	domainTransfers := []*domain.ClientTransfer{}
+	for _, transfer := range transfers {
+		domainTransfers = append(domainTransfers, mapClientTransferToDomain(transfer))
+	}
+	return domainTransfers, nil
+}
+
+// GetClientFacilityHistory fetches the periods a client was assigned to each facility
+func (d *MyCareHubDb) GetClientFacilityHistory(ctx context.Context, clientID string) ([]*domain.ClientFacilityPeriod, error) {
+	if clientID == "" {
+		return nil, fmt.Errorf("client ID must be defined")
+	}
+	history, err := d.query.GetClientFacilityHistory(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	periods := []*domain.ClientFacilityPeriod{}
+	for _, period := range history {
+		periods = append(periods, mapClientFacilityToDomain(period))
+	}
+	return periods, nil
+}
+
+// GetBulkInviteJob fetches a bulk invite job and its rows
+func (d *MyCareHubDb) GetBulkInviteJob(ctx context.Context, jobID string) (*domain.BulkInviteJob, error) {
+	if jobID == "" {
+		return nil, fmt.Errorf("jobID must be provided")
+	}
+	job, err := d.query.GetBulkInviteJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return mapBulkInviteJobToDomain(job), nil
+}
@@ -2,12 +2,16 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
@@ -440,6 +444,33 @@ func TestOnboardingDb_ListFacilities(t *testing.T) {
 		},
 	}
 
+	filterGroups := []*dto.FilterGroupInput{
+		{
+			Operator: enums.FilterOperatorOr,
+			Filters: []*dto.FiltersInput{
+				{
+					DataType: enums.FilterSortDataTypeCounty,
+					Value:    enums.CountyTypeNairobi.String(),
+				},
+				{
+					DataType: enums.FilterSortDataTypeCreatedAt,
+					Value:    "2021-01-01/..",
+				},
+			},
+		},
+	}
+	filterGroupsInvalidDateRange := []*dto.FilterGroupInput{
+		{
+			Operator: enums.FilterOperatorAnd,
+			Filters: []*dto.FiltersInput{
+				{
+					DataType: enums.FilterSortDataTypeUpdatedAt,
+					Value:    "2021-01-01",
+				},
+			},
+		},
+	}
+
 	paginationInput := dto.PaginationsInput{
 		Limit:       1,
 		CurrentPage: 1,
@@ -464,6 +495,7 @@ func TestOnboardingDb_ListFacilities(t *testing.T) {
 		ctx              context.Context
 		searchTerm       *string
 		filterInput      []*dto.FiltersInput
+		filterGroups     []*dto.FilterGroupInput
 		paginationsInput *dto.PaginationsInput
 	}
 	tests := []struct {
@@ -515,6 +547,18 @@ func TestOnboardingDb_ListFacilities(t *testing.T) {
 			wantErr: false,
 		},
 
+		{
+			name: "valid: with filter groups",
+			args: args{
+				ctx:              ctx,
+				searchTerm:       &searchTerm,
+				filterInput:      filterInput,
+				filterGroups:     filterGroups,
+				paginationsInput: &paginationInput,
+			},
+			wantErr: false,
+		},
+
 		{
 			name: "Sad case",
 			args: args{
@@ -576,6 +620,17 @@ func TestOnboardingDb_ListFacilities(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid: filter group with an invalid date range",
+			args: args{
+				ctx:              ctx,
+				searchTerm:       &searchTerm,
+				filterInput:      noFilterInput,
+				filterGroups:     filterGroupsInvalidDateRange,
+				paginationsInput: &paginationInput,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -590,53 +645,58 @@ func TestOnboardingDb_ListFacilities(t *testing.T) {
 						Description: facility.Description,
 					}, nil
 				}
-				fakeGorm.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
+				fakeGorm.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, filterGroups []*domain.FilterGroup, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
 					return nil, fmt.Errorf("current page not provided")
 				}
 			}
 
 			if tt.name == "invalid: missing current page" {
-				fakeGorm.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
+				fakeGorm.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, filterGroups []*domain.FilterGroup, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
 					return nil, fmt.Errorf("failed to list facilities")
 				}
 
 			}
 			if tt.name == "invalid: missing current page" {
-				fakeGorm.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
+				fakeGorm.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, filterGroups []*domain.FilterGroup, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
 					return nil, fmt.Errorf("failed to list facilities")
 				}
 
 			}
 			if tt.name == "invalid: empty name passed" {
-				fakeGorm.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
+				fakeGorm.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, filterGroups []*domain.FilterGroup, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
 					return nil, fmt.Errorf("failed to list facilities")
 				}
 
 			}
 			if tt.name == "invalid: empty MFL code" {
-				fakeGorm.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
+				fakeGorm.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, filterGroups []*domain.FilterGroup, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
 					return nil, fmt.Errorf("failed to list facilities")
 				}
 
 			}
 			if tt.name == "invalid: invalid bool" {
-				fakeGorm.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
+				fakeGorm.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, filterGroups []*domain.FilterGroup, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
 					return nil, fmt.Errorf("failed to list facilities")
 				}
 
 			}
 			if tt.name == "invalid: invalid county" {
-				fakeGorm.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
+				fakeGorm.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filter []*domain.FiltersParam, filterGroups []*domain.FilterGroup, pagination *domain.FacilityPage) (*domain.FacilityPage, error) {
 					return nil, fmt.Errorf("failed to list facilities")
 				}
 
 			}
 
-			got, err := d.ListFacilities(tt.args.ctx, tt.args.searchTerm, tt.args.filterInput, tt.args.paginationsInput)
+			got, err := d.ListFacilities(tt.args.ctx, tt.args.searchTerm, tt.args.filterInput, tt.args.filterGroups, tt.args.paginationsInput)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("OnboardingDb.ListFacilities() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			var filterErr *domain.FilterValidationError
+			if tt.name == "invalid: invalid county" && (!errors.As(err, &filterErr) || filterErr.Filter != enums.FilterSortDataTypeCounty) {
+				t.Errorf("expected the error to name the county filter, got %v", err)
+				return
+			}
 			if tt.wantErr && got != nil {
 				t.Errorf("expected facilities to be nil for %v", tt.name)
 				return
@@ -2259,7 +2319,7 @@ func TestMyCareHubDb_CanRecordHeathDiary(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			var fakeGorm = gormMock.NewGormMock()
 			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
-			got, err := d.CanRecordHeathDiary(tt.args.ctx, tt.args.userID)
+			got, err := d.CanRecordHeathDiary(tt.args.ctx, tt.args.userID, time.Hour*24)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("MyCareHubDb.CanRecordHeathDiary() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -2419,3 +2479,1667 @@ func TestMyCareHubDb_GetFAQContent(t *testing.T) {
 		})
 	}
 }
+
+func TestMyCareHubDb_GetLatestUserInvitation(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx     context.Context
+		userID  string
+		flavour feedlib.Flavour
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				userID:  uuid.New().String(),
+				flavour: feedlib.FlavourConsumer,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:     ctx,
+				userID:  uuid.New().String(),
+				flavour: feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid flavour",
+			args: args{
+				ctx:     ctx,
+				userID:  uuid.New().String(),
+				flavour: "invalid",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockGetLatestUserInvitationFn = func(ctx context.Context, userID string, flavour feedlib.Flavour) (*gorm.Invitation, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.GetLatestUserInvitation(tt.args.ctx, tt.args.userID, tt.args.flavour)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetLatestUserInvitation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected an invitation to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListPendingInvitations(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx        context.Context
+		facilityID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				facilityID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:        ctx,
+				facilityID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no facilityID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockListPendingInvitationsFn = func(ctx context.Context, facilityID string) ([]*gorm.Invitation, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ListPendingInvitations(tt.args.ctx, tt.args.facilityID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListPendingInvitations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected pending invitations to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetStaffProfileByUserID(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to get staff facilities",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockGetStaffProfileByUserIDFn = func(ctx context.Context, userID string) (*gorm.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case - failed to get staff facilities" {
+				fakeGorm.MockGetStaffFacilitiesFn = func(ctx context.Context, staffID string) ([]*gorm.Facility, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.GetStaffProfileByUserID(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetStaffProfileByUserID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got == nil || len(got.Facilities) == 0) {
+				t.Errorf("expected a staff profile with facilities to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetStaffProfileByStaffID(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx     context.Context
+		staffID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				staffID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:     ctx,
+				staffID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no staffID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockGetStaffProfileByStaffIDFn = func(ctx context.Context, staffID string) (*gorm.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.GetStaffProfileByStaffID(tt.args.ctx, tt.args.staffID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetStaffProfileByStaffID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected a staff profile to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetStaffFacilities(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx     context.Context
+		staffID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				staffID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:     ctx,
+				staffID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no staffID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockGetStaffFacilitiesFn = func(ctx context.Context, staffID string) ([]*gorm.Facility, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.GetStaffFacilities(tt.args.ctx, tt.args.staffID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetStaffFacilities() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected staff facilities to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetUserRoles(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]*gorm.UserRole, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.GetUserRoles(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetUserRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected user roles to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetClientProfileByClientID(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx      context.Context
+		clientID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no clientID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*gorm.Client, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.GetClientProfileByClientID(tt.args.ctx, tt.args.clientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetClientProfileByClientID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected a client profile to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetOrganisation(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx            context.Context
+		organisationID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no organisationID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockGetOrganisationFn = func(ctx context.Context, organisationID string) (*gorm.Organisation, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.GetOrganisation(tt.args.ctx, tt.args.organisationID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetOrganisation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Settings == nil {
+				t.Errorf("expected the organisation settings to be set")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListOrganisations(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case",
+			wantErr: false,
+		},
+		{
+			name:    "Sad case",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockListOrganisationsFn = func(ctx context.Context) ([]*gorm.Organisation, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ListOrganisations(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListOrganisations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected organisations to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetOrganisationSettings(t *testing.T) {
+	ctx := context.Background()
+	cadence := 48
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case",
+			wantErr: false,
+		},
+		{
+			name:    "Sad case",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			fakeGorm.MockGetOrganisationFn = func(ctx context.Context, organisationID string) (*gorm.Organisation, error) {
+				return &gorm.Organisation{OrganisationID: &organisationID, DiaryCadenceHours: &cadence}, nil
+			}
+			if tt.name == "Sad case" {
+				fakeGorm.MockGetOrganisationFn = func(ctx context.Context, organisationID string) (*gorm.Organisation, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.GetOrganisationSettings(ctx, uuid.New().String())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetOrganisationSettings() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.DiaryCadenceHours != cadence {
+				t.Errorf("expected the diary cadence to be %v, got %v", cadence, got.DiaryCadenceHours)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetFacilityHistory(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx     context.Context
+		mflCode int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no MFL code",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockGetFacilityHistoryFn = func(ctx context.Context, mflCode int) ([]*gorm.FacilityHistory, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.GetFacilityHistory(tt.args.ctx, tt.args.mflCode)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetFacilityHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected facility history to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_NearbyFacilities(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx   context.Context
+		input *dto.NearbyFacilitiesInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx: ctx,
+				input: &dto.NearbyFacilitiesInput{
+					Latitude:  -1.2921,
+					Longitude: 36.8219,
+					RadiusKm:  10,
+					Limit:     10,
+					FilterInput: []*dto.FiltersInput{
+						{DataType: enums.FilterSortDataTypeCounty, Value: "Nairobi"},
+					},
+					Sort: &dto.SortsInput{Field: enums.FilterSortDataTypeDistance, Direction: enums.SortDataTypeAsc},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx: ctx,
+				input: &dto.NearbyFacilitiesInput{
+					Latitude:  -1.2921,
+					Longitude: 36.8219,
+					RadiusKm:  10,
+					Limit:     10,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no input",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid input",
+			args: args{
+				ctx: ctx,
+				input: &dto.NearbyFacilitiesInput{
+					Latitude:  -1.2921,
+					Longitude: 36.8219,
+					Limit:     10,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockNearbyFacilitiesFn = func(ctx context.Context, latitude, longitude, radiusKm float64, limit int, filter []*domain.FiltersParam, sort *domain.SortParam) ([]*gorm.NearbyFacility, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.NearbyFacilities(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.NearbyFacilities() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (len(got) == 0 || got[0].Facility.Latitude == nil) {
+				t.Errorf("expected nearby facilities with their coordinates to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListFacilityServices(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockListFacilityServicesFn = func(ctx context.Context) ([]*gorm.FacilityService, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ListFacilityServices(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListFacilityServices() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected facility services to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetClientTransferByID(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx        context.Context
+		transferID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				transferID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - missing transfer ID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to get transfer",
+			args: args{
+				ctx:        ctx,
+				transferID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to get transfer" {
+				fakeGorm.MockGetClientTransferByIDFn = func(ctx context.Context, transferID string) (*gorm.ClientTransfer, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.GetClientTransferByID(tt.args.ctx, tt.args.transferID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetClientTransferByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected a client transfer to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CheckClientHasPendingTransfer(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx      context.Context
+		clientID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - missing client ID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to check pending transfers",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			fakeGorm.MockCheckClientHasPendingTransferFn = func(ctx context.Context, clientID string) (bool, error) {
+				return true, nil
+			}
+			if tt.name == "Sad case - failed to check pending transfers" {
+				fakeGorm.MockCheckClientHasPendingTransferFn = func(ctx context.Context, clientID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.CheckClientHasPendingTransfer(tt.args.ctx, tt.args.clientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CheckClientHasPendingTransfer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.CheckClientHasPendingTransfer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListPendingClientTransfers(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx        context.Context
+		facilityID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				facilityID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - missing facility ID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to list transfers",
+			args: args{
+				ctx:        ctx,
+				facilityID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to list transfers" {
+				fakeGorm.MockListPendingClientTransfersFn = func(ctx context.Context, facilityID string) ([]*gorm.ClientTransfer, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ListPendingClientTransfers(tt.args.ctx, tt.args.facilityID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListPendingClientTransfers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != 1 {
+				t.Errorf("expected 1 pending transfer, got %v", len(got))
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetClientFacilityHistory(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx      context.Context
+		clientID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - missing client ID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to get history",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to get history" {
+				fakeGorm.MockGetClientFacilityHistoryFn = func(ctx context.Context, clientID string) ([]*gorm.ClientFacility, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.GetClientFacilityHistory(tt.args.ctx, tt.args.clientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetClientFacilityHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != 1 {
+				t.Errorf("expected 1 facility period, got %v", len(got))
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListContentItems(t *testing.T) {
+	ctx := context.Background()
+	categoryID := 1
+
+	type args struct {
+		ctx        context.Context
+		categoryID *int
+		contentIDs []int
+		limit      int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				categoryID: &categoryID,
+				limit:      10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - failed to list content items",
+			args: args{
+				ctx:        ctx,
+				contentIDs: []int{10},
+				limit:      1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to list content items" {
+				fakeGorm.MockListContentItemsFn = func(ctx context.Context, categoryID *int, contentIDs []int, limit int) ([]*gorm.ContentItemDetails, int64, error) {
+					return nil, 0, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ListContentItems(tt.args.ctx, tt.args.categoryID, tt.args.contentIDs, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListContentItems() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Meta.TotalCount != 1 || len(got.Items) != 1 {
+				t.Errorf("expected 1 content item, got %v", len(got.Items))
+				return
+			}
+			item := got.Items[0]
+			if !strings.HasSuffix(item.HeroImageRendition.URL, "images/hero.png") || item.HeroImageRendition.URL == "images/hero.png" {
+				t.Errorf("expected the hero image to be served from the storage bucket, got %v", item.HeroImageRendition.URL)
+			}
+			if len(item.CategoryDetails) != 1 || item.Title == "" {
+				t.Errorf("expected the content item to include its page title and categories")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_SearchContent(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx              context.Context
+		input            *dto.ContentSearchInput
+		paginationsInput *dto.PaginationsInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx: ctx,
+				input: &dto.ContentSearchInput{
+					Query:       "side effects",
+					CategoryIDs: []int{1},
+					Language:    enumutils.LanguageEn,
+				},
+				paginationsInput: &dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - empty query",
+			args: args{
+				ctx: ctx,
+				input: &dto.ContentSearchInput{
+					Language: enumutils.LanguageEn,
+				},
+				paginationsInput: &dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid language",
+			args: args{
+				ctx: ctx,
+				input: &dto.ContentSearchInput{
+					Query:    "PrEP",
+					Language: enumutils.Language("invalid"),
+				},
+				paginationsInput: &dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - missing current page",
+			args: args{
+				ctx: ctx,
+				input: &dto.ContentSearchInput{
+					Query:    "PrEP",
+					Language: enumutils.LanguageSw,
+				},
+				paginationsInput: &dto.PaginationsInput{Limit: 10},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to search content",
+			args: args{
+				ctx: ctx,
+				input: &dto.ContentSearchInput{
+					Query:    "PrEP",
+					Language: enumutils.LanguageSw,
+				},
+				paginationsInput: &dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to search content" {
+				fakeGorm.MockSearchContentFn = func(ctx context.Context, input *dto.ContentSearchInput, pagination *domain.Pagination) (*gorm.ContentSearchPage, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.SearchContent(tt.args.ctx, tt.args.input, tt.args.paginationsInput)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.SearchContent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Pagination.Count != 1 || len(got.Results) != 1 {
+				t.Errorf("expected 1 search result, got %v", len(got.Results))
+				return
+			}
+			result := got.Results[0]
+			if result.Item.ID != 10 || result.Rank == 0 || !strings.Contains(result.Snippet, "<mark>") {
+				t.Errorf("expected the result to include the content item, its rank and a highlighted snippet, got %+v", result)
+			}
+			if len(result.Item.TagNames) != 1 {
+				t.Errorf("expected the content item to include its tags, got %v", result.Item.TagNames)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListRecommendedContent(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		limit  int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				limit:  10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - failed to list recommended content",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				limit:  10,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to list recommended content" {
+				fakeGorm.MockListRecommendedContentItemsFn = func(ctx context.Context, userID string, limit int) ([]*gorm.ContentItemDetails, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ListRecommendedContent(tt.args.ctx, tt.args.userID, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListRecommendedContent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.Meta.TotalCount != 1 || len(got.Items) != 1) {
+				t.Errorf("expected 1 recommended content item, got %v", len(got.Items))
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListContentFeed(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx          context.Context
+		categoryID   *int
+		sort         enums.ContentSortOrder
+		trendingDays int
+		after        *domain.ContentFeedPosition
+		limit        int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:          ctx,
+				sort:         enums.ContentSortOrderTrending,
+				trendingDays: 7,
+				after:        &domain.ContentFeedPosition{SortKey: 12, FirstPublishedAt: time.Now(), ContentID: 4},
+				limit:        10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - invalid sort order",
+			args: args{
+				ctx:   ctx,
+				sort:  enums.ContentSortOrder("invalid"),
+				limit: 10,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to list the content feed",
+			args: args{
+				ctx:   ctx,
+				sort:  enums.ContentSortOrderMostViewed,
+				limit: 10,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to list the content feed" {
+				fakeGorm.MockListContentFeedFn = func(ctx context.Context, categoryID *int, sort enums.ContentSortOrder, trendingDays int, after *domain.ContentFeedPosition, before *domain.ContentFeedPosition, limit int) (*gorm.ContentFeedPage, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ListContentFeed(tt.args.ctx, tt.args.categoryID, tt.args.sort, tt.args.trendingDays, tt.args.after, nil, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListContentFeed() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.Content.Meta.TotalCount != 1 || len(got.Content.Items) != 1 || len(got.Positions) != 1) {
+				t.Errorf("expected 1 content item and its position, got %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetContentAnalytics(t *testing.T) {
+	ctx := context.Background()
+	from := time.Now().AddDate(0, -1, 0)
+
+	type args struct {
+		ctx   context.Context
+		input *dto.ContentAnalyticsInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:   ctx,
+				input: &dto.ContentAnalyticsInput{From: from, To: time.Now()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - invalid period",
+			args: args{
+				ctx:   ctx,
+				input: &dto.ContentAnalyticsInput{From: time.Now(), To: from},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to get content analytics",
+			args: args{
+				ctx:   ctx,
+				input: &dto.ContentAnalyticsInput{From: from, To: time.Now()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to get content analytics" {
+				fakeGorm.MockGetContentAnalyticsFn = func(ctx context.Context, input *dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.GetContentAnalytics(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetContentAnalytics() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (len(got.Items) == 0 || len(got.Categories) == 0) {
+				t.Errorf("expected content item and category analytics, got %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListContentInProgress(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		limit  int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				limit:  10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - failed to list content in progress",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				limit:  10,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to list content in progress" {
+				fakeGorm.MockListContentInProgressFn = func(ctx context.Context, userID string, limit int) ([]*gorm.ContentInProgress, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ListContentInProgress(tt.args.ctx, tt.args.userID, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListContentInProgress() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (len(got) != 1 || got[0].Item == nil) {
+				t.Errorf("expected a content item in progress, got %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetContentCommentByID(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx       context.Context
+		commentID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				commentID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no commentID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to get content comment",
+			args: args{
+				ctx:       ctx,
+				commentID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to get content comment" {
+				fakeGorm.MockGetContentCommentByIDFn = func(ctx context.Context, commentID string) (*gorm.ContentCommentDetails, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.GetContentCommentByID(tt.args.ctx, tt.args.commentID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetContentCommentByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.ID != tt.args.commentID || got.Nickname == "") {
+				t.Errorf("expected comment %v with the author's nickname, got %v", tt.args.commentID, got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListContentComments(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx       context.Context
+		contentID int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				contentID: 10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - failed to list content comments",
+			args: args{
+				ctx:       ctx,
+				contentID: 10,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to list content comments" {
+				fakeGorm.MockListContentCommentsFn = func(ctx context.Context, contentID int) ([]*gorm.ContentCommentDetails, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ListContentComments(tt.args.ctx, tt.args.contentID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListContentComments() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected content comments to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListContentCommentsByStatus(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx      context.Context
+		statuses []enums.ContentCommentStatus
+		limit    int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:      ctx,
+				statuses: []enums.ContentCommentStatus{enums.ContentCommentStatusPending},
+				limit:    10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no statuses",
+			args: args{
+				ctx:   ctx,
+				limit: 10,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid status",
+			args: args{
+				ctx:      ctx,
+				statuses: []enums.ContentCommentStatus{"invalid"},
+				limit:    10,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to list content comments",
+			args: args{
+				ctx:      ctx,
+				statuses: []enums.ContentCommentStatus{enums.ContentCommentStatusPending},
+				limit:    10,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to list content comments" {
+				fakeGorm.MockListContentCommentsByStatusFn = func(ctx context.Context, statuses []enums.ContentCommentStatus, limit int) ([]*gorm.ContentCommentDetails, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ListContentCommentsByStatus(tt.args.ctx, tt.args.statuses, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListContentCommentsByStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected content comments to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetBulkInviteJob(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx   context.Context
+		jobID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:   ctx,
+				jobID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:   ctx,
+				jobID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no jobID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockGetBulkInviteJobFn = func(ctx context.Context, jobID string) (*gorm.BulkInviteJob, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.GetBulkInviteJob(tt.args.ctx, tt.args.jobID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetBulkInviteJob() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.ID != tt.args.jobID || len(got.Rows) == 0) {
+				t.Errorf("expected job %v with its rows, got %v", tt.args.jobID, got)
+			}
+		})
+	}
+}
@@ -5,10 +5,64 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
 )
 
+// UpdateFacility changes the details of a facility. changedBy is the ID of the user making the change
+func (d *MyCareHubDb) UpdateFacility(ctx context.Context, mflCode int, input *dto.FacilityUpdateInput, changedBy string) (*domain.Facility, error) {
+	if mflCode == 0 {
+		return nil, fmt.Errorf("facility's MFL Code cannot be empty")
+	}
+	if changedBy == "" {
+		return nil, fmt.Errorf("the user changing the facility must be defined")
+	}
+	if input == nil {
+		return nil, fmt.Errorf("facility update input must be provided")
+	}
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	facility, err := d.update.UpdateFacility(ctx, mflCode, input, changedBy)
+	if err != nil {
+		return nil, err
+	}
+	return d.mapFacilityObjectToDomain(facility), nil
+}
+
+// UpsertFacilities creates or updates the supplied facilities using their MFL codes. Every facility must be valid.
+func (d *MyCareHubDb) UpsertFacilities(ctx context.Context, facilities []*dto.FacilityInput, changedBy string, dryRun bool) ([]*domain.FacilityImportRow, error) {
+	if changedBy == "" {
+		return nil, fmt.Errorf("the user importing the facilities must be defined")
+	}
+
+	facilityObjs := []*gorm.Facility{}
+	for _, facility := range facilities {
+		if err := facility.Validate(); err != nil {
+			return nil, fmt.Errorf("facility %v input validation failed: %v", facility.Code, err)
+		}
+		facilityObjs = append(facilityObjs, &gorm.Facility{
+			Name:        facility.Name,
+			Code:        facility.Code,
+			Active:      facility.Active,
+			County:      facility.County,
+			Phone:       facility.Phone,
+			Description: facility.Description,
+			Latitude:    facility.Latitude,
+			Longitude:   facility.Longitude,
+			Address:     facility.Address,
+		})
+	}
+
+	return d.update.UpsertFacilities(ctx, facilityObjs, changedBy, dryRun)
+}
+
 // ReactivateFacility changes the status of an active facility from false to true
 func (d *MyCareHubDb) ReactivateFacility(ctx context.Context, mflCode *int) (bool, error) {
 	if mflCode == nil {
@@ -77,6 +131,22 @@ func (d *MyCareHubDb) SetNickName(ctx context.Context, userID *string, nickname
 	return d.update.SetNickName(ctx, userID, nickname)
 }
 
+// UpdateUserLanguages updates the user's preferred languages. The first language is the most preferred one
+func (d *MyCareHubDb) UpdateUserLanguages(ctx context.Context, userID string, languages []enumutils.Language) (bool, error) {
+	if userID == "" {
+		return false, fmt.Errorf("userID must be defined")
+	}
+	userLanguages := []string{}
+	for _, language := range languages {
+		if !language.IsValid() {
+			return false, fmt.Errorf("invalid language: %v", language)
+		}
+		userLanguages = append(userLanguages, language.String())
+	}
+
+	return d.update.UpdateUserLanguages(ctx, userID, userLanguages)
+}
+
 // UpdateUserPinChangeRequiredStatus updates the user's pin change required from true to false. It'll be used to
 // determine the onboarding journey for a user.
 func (d *MyCareHubDb) UpdateUserPinChangeRequiredStatus(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error) {
@@ -103,12 +173,83 @@ func (d *MyCareHubDb) UpdateIsCorrectSecurityQuestionResponse(ctx context.Contex
 	return d.update.UpdateIsCorrectSecurityQuestionResponse(ctx, userID, isCorrectSecurityQuestionResponse)
 }
 
-// ShareContent updates content share count
-func (d *MyCareHubDb) ShareContent(ctx context.Context, input dto.ShareContentInput) (bool, error) {
-	if input.Validate() != nil {
-		return false, fmt.Errorf("input cannot be empty")
+// ShareContent updates content share count and creates a link for the share
+func (d *MyCareHubDb) ShareContent(ctx context.Context, input dto.ShareContentInput) (*domain.ContentShareLink, error) {
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid share content input: %v", err)
+	}
+	shareLink, err := d.update.ShareContent(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return mapContentShareLinkToDomain(shareLink), nil
+}
+
+// RecordContentShareOpen records that a shared link was opened by the user, if any, and returns the link
+func (d *MyCareHubDb) RecordContentShareOpen(ctx context.Context, shareLinkID string, openedByID *string) (*domain.ContentShareLink, error) {
+	if shareLinkID == "" {
+		return nil, fmt.Errorf("shareLinkID cannot be empty")
+	}
+	shareLink, err := d.update.RecordContentShareOpen(ctx, shareLinkID, openedByID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record content share open: %v", err)
+	}
+	return mapContentShareLinkToDomain(shareLink), nil
+}
+
+// CreateContentComment saves a user's comment on a content item. The parent is set when the comment is a reply
+func (d *MyCareHubDb) CreateContentComment(
+	ctx context.Context,
+	userID string,
+	contentID int,
+	parentID *string,
+	body string,
+	status enums.ContentCommentStatus,
+) (*domain.ContentComment, error) {
+	if !status.IsValid() {
+		return nil, fmt.Errorf("invalid content comment status: %v", status)
 	}
-	return d.update.ShareContent(ctx, input)
+	comment := &gorm.ContentComment{
+		ContentID: contentID,
+		UserID:    userID,
+		ParentID:  parentID,
+		Body:      body,
+		Status:    status,
+	}
+	created, err := d.update.CreateContentComment(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+	return mapContentCommentToDomain(created), nil
+}
+
+// EditContentComment replaces the body of a content comment and moves it to the supplied status
+func (d *MyCareHubDb) EditContentComment(ctx context.Context, commentID string, body string, status enums.ContentCommentStatus) (*domain.ContentComment, error) {
+	if !status.IsValid() {
+		return nil, fmt.Errorf("invalid content comment status: %v", status)
+	}
+	comment, err := d.update.EditContentComment(ctx, commentID, body, status)
+	if err != nil {
+		return nil, err
+	}
+	return mapContentCommentToDomain(comment), nil
+}
+
+// DeleteContentComment marks a content comment as deleted
+func (d *MyCareHubDb) DeleteContentComment(ctx context.Context, commentID string) (bool, error) {
+	return d.update.DeleteContentComment(ctx, commentID)
+}
+
+// ModerateContentComment moves a content comment to the status chosen by a moderator
+func (d *MyCareHubDb) ModerateContentComment(ctx context.Context, commentID string, status enums.ContentCommentStatus, moderatorID string) (*domain.ContentComment, error) {
+	if !status.IsValid() {
+		return nil, fmt.Errorf("invalid content comment status: %v", status)
+	}
+	comment, err := d.update.ModerateContentComment(ctx, commentID, status, moderatorID)
+	if err != nil {
+		return nil, err
+	}
+	return mapContentCommentToDomain(comment), nil
 }
 
 //BookmarkContent updates the user's bookmark status for a content
@@ -149,3 +290,223 @@ func (d *MyCareHubDb) UnlikeContent(ctx context.Context, userID string, contentI
 func (d *MyCareHubDb) ViewContent(ctx context.Context, userID string, contentID int) (bool, error) {
 	return d.update.ViewContent(ctx, userID, contentID)
 }
+
+// RecordContentReadDuration records how long a user spent reading a content item
+func (d *MyCareHubDb) RecordContentReadDuration(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error) {
+	return d.update.RecordContentReadDuration(ctx, userID, contentID, durationSeconds)
+}
+
+// RecordContentProgress saves a user's report of their progress with a content item and completes the item when the
+// progress reaches the threshold
+func (d *MyCareHubDb) RecordContentProgress(
+	ctx context.Context,
+	userID string,
+	contentID int,
+	secondsSpent int,
+	percentScrolled float64,
+	threshold domain.ContentCompletionThreshold,
+) (*domain.ContentProgressUpdate, error) {
+	report := &gorm.ContentProgress{
+		UserID:          userID,
+		ContentID:       contentID,
+		SecondsSpent:    secondsSpent,
+		PercentScrolled: percentScrolled,
+	}
+	progress, justCompleted, err := d.update.RecordContentProgress(ctx, report, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record content progress: %v", err)
+	}
+	return &domain.ContentProgressUpdate{
+		Progress:      *mapContentProgressToDomain(progress),
+		JustCompleted: justCompleted,
+	}, nil
+}
+
+// UpdateInvitationStatus moves an invitation to a new status
+func (d *MyCareHubDb) UpdateInvitationStatus(ctx context.Context, invitationID string, status enums.InvitationStatus) (bool, error) {
+	if invitationID == "" {
+		return false, fmt.Errorf("invitationID must be defined")
+	}
+	if !status.IsValid() {
+		return false, fmt.Errorf("invalid invitation status: %v", status)
+	}
+	return d.update.UpdateInvitationStatus(ctx, invitationID, status)
+}
+
+// CloseUserInvitations marks all of a user's pending invitations as accepted or expired
+func (d *MyCareHubDb) CloseUserInvitations(ctx context.Context, userID string, flavour feedlib.Flavour, status enums.InvitationStatus) (bool, error) {
+	if userID == "" {
+		return false, fmt.Errorf("userID must be defined")
+	}
+	if !flavour.IsValid() {
+		return false, fmt.Errorf("invalid flavour: %v", flavour)
+	}
+	if status != enums.InvitationStatusAccepted && status != enums.InvitationStatusExpired {
+		return false, fmt.Errorf("invitations can only be closed as accepted or expired, got %v", status)
+	}
+	return d.update.CloseUserInvitations(ctx, userID, flavour, status)
+}
+
+// ExpireOverdueInvitations expires the pending invitations that are past their expiry time and
+// invalidates the temporary PINs that were sent with them
+func (d *MyCareHubDb) ExpireOverdueInvitations(ctx context.Context) (bool, error) {
+	return d.update.ExpireOverdueInvitations(ctx)
+}
+
+// UpdateStaffProfile changes the supplied fields of a staff profile
+func (d *MyCareHubDb) UpdateStaffProfile(ctx context.Context, staffID string, input *dto.StaffProfileUpdateInput) (bool, error) {
+	if staffID == "" {
+		return false, fmt.Errorf("staffID must be defined")
+	}
+	if input == nil {
+		return false, fmt.Errorf("staff profile update input must be provided")
+	}
+	if err := input.Validate(); err != nil {
+		return false, err
+	}
+	return d.update.UpdateStaffProfile(ctx, staffID, input)
+}
+
+// AddStaffFacilities gives a staff member access to the supplied facilities
+func (d *MyCareHubDb) AddStaffFacilities(ctx context.Context, staffID string, facilityIDs []string) (bool, error) {
+	if staffID == "" || len(facilityIDs) == 0 {
+		return false, fmt.Errorf("staffID and facility IDs must be defined")
+	}
+	return d.update.AddStaffFacilities(ctx, staffID, facilityIDs)
+}
+
+// RemoveStaffFacilities revokes a staff member's access to the supplied facilities
+func (d *MyCareHubDb) RemoveStaffFacilities(ctx context.Context, staffID string, facilityIDs []string) (bool, error) {
+	if staffID == "" || len(facilityIDs) == 0 {
+		return false, fmt.Errorf("staffID and facility IDs must be defined")
+	}
+	return d.update.RemoveStaffFacilities(ctx, staffID, facilityIDs)
+}
+
+// UpdateOrganisation updates the fields and settings of an organisation that are set in the input
+func (d *MyCareHubDb) UpdateOrganisation(ctx context.Context, organisationID string, input *dto.OrganisationUpdateInput) (bool, error) {
+	if organisationID == "" {
+		return false, fmt.Errorf("organisation ID must be defined")
+	}
+	if input == nil {
+		return false, fmt.Errorf("organisation update input must be provided")
+	}
+	if err := input.Validate(); err != nil {
+		return false, err
+	}
+	return d.update.UpdateOrganisation(ctx, organisationID, input)
+}
+
+// DeactivateOrganisation marks an organisation as inactive
+func (d *MyCareHubDb) DeactivateOrganisation(ctx context.Context, organisationID string) (bool, error) {
+	if organisationID == "" {
+		return false, fmt.Errorf("organisation ID must be defined")
+	}
+	return d.update.DeactivateOrganisation(ctx, organisationID)
+}
+
+// SetFacilityOpeningHours replaces a facility's weekly opening hours. A facility without opening hours is closed.
+func (d *MyCareHubDb) SetFacilityOpeningHours(ctx context.Context, mflCode int, openingHours []*dto.FacilityOpeningHoursInput) (bool, error) {
+	if mflCode == 0 {
+		return false, fmt.Errorf("facility's MFL Code cannot be empty")
+	}
+	if err := dto.ValidateFacilityOpeningHours(openingHours); err != nil {
+		return false, err
+	}
+
+	hours := []*gorm.FacilityOpeningHours{}
+	for _, h := range openingHours {
+		hours = append(hours, &gorm.FacilityOpeningHours{
+			DayOfWeek: h.DayOfWeek,
+			OpensAt:   h.OpensAt,
+			ClosesAt:  h.ClosesAt,
+		})
+	}
+	if err := d.update.SetFacilityOpeningHours(ctx, mflCode, hours); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetFacilityHoursException changes a facility's opening hours on a date
+func (d *MyCareHubDb) SetFacilityHoursException(ctx context.Context, mflCode int, input *dto.FacilityHoursExceptionInput) (bool, error) {
+	if mflCode == 0 {
+		return false, fmt.Errorf("facility's MFL Code cannot be empty")
+	}
+	if input == nil {
+		return false, fmt.Errorf("facility hours exception input must be provided")
+	}
+	if err := input.Validate(); err != nil {
+		return false, err
+	}
+
+	date, err := time.Parse(common.DateLayout, input.Date)
+	if err != nil {
+		return false, fmt.Errorf("invalid date %v: %v", input.Date, err)
+	}
+	exception := &gorm.FacilityHoursException{
+		Date:     date,
+		Closed:   input.Closed,
+		OpensAt:  input.OpensAt,
+		ClosesAt: input.ClosesAt,
+		Reason:   input.Reason,
+	}
+	if err := d.update.SetFacilityHoursException(ctx, mflCode, exception); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetFacilityServices replaces the services that a facility offers using the services' codes
+func (d *MyCareHubDb) SetFacilityServices(ctx context.Context, mflCode int, serviceCodes []string) (bool, error) {
+	if mflCode == 0 {
+		return false, fmt.Errorf("facility's MFL Code cannot be empty")
+	}
+	if err := d.update.SetFacilityServices(ctx, mflCode, serviceCodes); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RespondToClientTransfer accepts or declines a pending client transfer on behalf of the receiving facility
+func (d *MyCareHubDb) RespondToClientTransfer(ctx context.Context, transferID string, status enums.ClientTransferStatus, respondedByID string, note string) (*domain.ClientTransfer, error) {
+	if transferID == "" || respondedByID == "" {
+		return nil, fmt.Errorf("client transfer ID and responder ID must be defined")
+	}
+	transfer, err := d.update.RespondToClientTransfer(ctx, transferID, status, respondedByID, note)
+	if err != nil {
+		return nil, err
+	}
+	return mapClientTransferToDomain(transfer), nil
+}
+
+// ReconcileContentEngagement compares the content engagement counters with the engagement recorded per user and,
+// unless it is a dry run, rewrites the counters that have drifted
+func (d *MyCareHubDb) ReconcileContentEngagement(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error) {
+	report, err := d.update.ReconcileContentEngagement(ctx, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile content engagement: %v", err)
+	}
+	return report, nil
+}
+
+// UpdateBulkInviteJobStatus moves a bulk invite job to a new status
+func (d *MyCareHubDb) UpdateBulkInviteJobStatus(ctx context.Context, jobID string, status enums.BulkInviteJobStatus) (bool, error) {
+	return d.update.UpdateBulkInviteJobStatus(ctx, jobID, status)
+}
+
+// FailInterruptedBulkInviteJobs fails the bulk invite jobs that have not recorded any progress within the stale period
+func (d *MyCareHubDb) FailInterruptedBulkInviteJobs(ctx context.Context, staleAfter time.Duration) (int, error) {
+	return d.update.FailInterruptedBulkInviteJobs(ctx, staleAfter)
+}
+
+// RecordBulkInviteRowOutcome saves whether a row of a bulk invite job was invited and counts it against the job
+func (d *MyCareHubDb) RecordBulkInviteRowOutcome(
+	ctx context.Context,
+	jobID string,
+	rowID string,
+	status enums.BulkInviteRowStatus,
+	errorMessage string,
+) (bool, error) {
+	return d.update.RecordBulkInviteRowOutcome(ctx, jobID, rowID, status, errorMessage)
+}
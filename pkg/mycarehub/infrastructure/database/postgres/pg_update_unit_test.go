@@ -8,8 +8,12 @@ import (
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
 	gormMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm/mock"
 	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
 	"github.com/segmentio/ksuid"
@@ -844,7 +848,6 @@ func TestMyCareHubDb_ShareContent(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		want    bool
 		wantErr bool
 	}{
 		{
@@ -854,10 +857,9 @@ func TestMyCareHubDb_ShareContent(t *testing.T) {
 				input: dto.ShareContentInput{
 					UserID:    uuid.New().String(),
 					ContentID: 1,
-					Channel:   "SMS",
+					Channel:   enums.ContentShareChannelSMS,
 				},
 			},
-			want:    true,
 			wantErr: false,
 		},
 		{
@@ -866,10 +868,9 @@ func TestMyCareHubDb_ShareContent(t *testing.T) {
 				ctx: ctx,
 				input: dto.ShareContentInput{
 					ContentID: 1,
-					Channel:   "SMS",
+					Channel:   enums.ContentShareChannelSMS,
 				},
 			},
-			want:    false,
 			wantErr: true,
 		},
 		{
@@ -878,10 +879,9 @@ func TestMyCareHubDb_ShareContent(t *testing.T) {
 				ctx: ctx,
 				input: dto.ShareContentInput{
 					UserID:  uuid.New().String(),
-					Channel: "SMS",
+					Channel: enums.ContentShareChannelSMS,
 				},
 			},
-			want:    false,
 			wantErr: true,
 		},
 		{
@@ -893,7 +893,30 @@ func TestMyCareHubDb_ShareContent(t *testing.T) {
 					ContentID: 1,
 				},
 			},
-			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "invalid: unknown channel",
+			args: args{
+				ctx: ctx,
+				input: dto.ShareContentInput{
+					UserID:    uuid.New().String(),
+					ContentID: 1,
+					Channel:   enums.ContentShareChannel("TELEGRAM"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: failed to share content",
+			args: args{
+				ctx: ctx,
+				input: dto.ShareContentInput{
+					UserID:    uuid.New().String(),
+					ContentID: 1,
+					Channel:   enums.ContentShareChannelSMS,
+				},
+			},
 			wantErr: true,
 		},
 		{
@@ -901,7 +924,6 @@ func TestMyCareHubDb_ShareContent(t *testing.T) {
 			args: args{
 				ctx: ctx,
 			},
-			want:    false,
 			wantErr: true,
 		},
 	}
@@ -910,13 +932,22 @@ func TestMyCareHubDb_ShareContent(t *testing.T) {
 			var fakeGorm = gormMock.NewGormMock()
 			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
 
+			if tt.name == "invalid: failed to share content" {
+				fakeGorm.MockShareContentFn = func(ctx context.Context, input dto.ShareContentInput) (*gorm.ContentShareLink, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
 			got, err := d.ShareContent(tt.args.ctx, tt.args.input)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("MyCareHubDb.ShareContent() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != tt.want {
-				t.Errorf("MyCareHubDb.ShareContent() = %v, want %v", got, tt.want)
+			if tt.wantErr {
+				return
+			}
+			if got.ID == "" || got.Channel != tt.args.input.Channel {
+				t.Errorf("expected a share link on %v, got %v", tt.args.input.Channel, got)
 			}
 		})
 	}
@@ -1396,3 +1427,1901 @@ func TestMyCareHubDb_UnBookmarkContent(t *testing.T) {
 		})
 	}
 }
+
+func TestMyCareHubDb_UpdateUserLanguages(t *testing.T) {
+	ctx := context.Background()
+
+	userID := ksuid.New().String()
+
+	type args struct {
+		ctx       context.Context
+		userID    string
+		languages []enumutils.Language
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				languages: []enumutils.Language{enumutils.LanguageSw, enumutils.LanguageEn},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				languages: []enumutils.Language{enumutils.LanguageSw},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx:       ctx,
+				languages: []enumutils.Language{enumutils.LanguageSw},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid language",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				languages: []enumutils.Language{"invalid"},
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockUpdateUserLanguagesFn = func(ctx context.Context, userID string, languages []string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.UpdateUserLanguages(tt.args.ctx, tt.args.userID, tt.args.languages)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.UpdateUserLanguages() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.UpdateUserLanguages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_UpdateInvitationStatus(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx          context.Context
+		invitationID string
+		status       enums.InvitationStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:          ctx,
+				invitationID: uuid.New().String(),
+				status:       enums.InvitationStatusDelivered,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:          ctx,
+				invitationID: uuid.New().String(),
+				status:       enums.InvitationStatusDelivered,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no invitationID",
+			args: args{
+				ctx:    ctx,
+				status: enums.InvitationStatusDelivered,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid status",
+			args: args{
+				ctx:          ctx,
+				invitationID: uuid.New().String(),
+				status:       "invalid",
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockUpdateInvitationStatusFn = func(ctx context.Context, invitationID string, status enums.InvitationStatus) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.UpdateInvitationStatus(tt.args.ctx, tt.args.invitationID, tt.args.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.UpdateInvitationStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.UpdateInvitationStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CloseUserInvitations(t *testing.T) {
+	ctx := context.Background()
+
+	userID := ksuid.New().String()
+
+	type args struct {
+		ctx     context.Context
+		userID  string
+		flavour feedlib.Flavour
+		status  enums.InvitationStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				userID:  userID,
+				flavour: feedlib.FlavourConsumer,
+				status:  enums.InvitationStatusAccepted,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:     ctx,
+				userID:  userID,
+				flavour: feedlib.FlavourConsumer,
+				status:  enums.InvitationStatusExpired,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx:     ctx,
+				flavour: feedlib.FlavourConsumer,
+				status:  enums.InvitationStatusExpired,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid flavour",
+			args: args{
+				ctx:     ctx,
+				userID:  userID,
+				flavour: "invalid",
+				status:  enums.InvitationStatusExpired,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - pending status",
+			args: args{
+				ctx:     ctx,
+				userID:  userID,
+				flavour: feedlib.FlavourConsumer,
+				status:  enums.InvitationStatusDelivered,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockCloseUserInvitationsFn = func(ctx context.Context, userID string, flavour feedlib.Flavour, status enums.InvitationStatus) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.CloseUserInvitations(tt.args.ctx, tt.args.userID, tt.args.flavour, tt.args.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CloseUserInvitations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.CloseUserInvitations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ExpireOverdueInvitations(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "Happy case",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case",
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockExpireOverdueInvitationsFn = func(ctx context.Context) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ExpireOverdueInvitations(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ExpireOverdueInvitations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.ExpireOverdueInvitations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_UpdateStaffProfile(t *testing.T) {
+	ctx := context.Background()
+	cadre := enums.StaffCadreCounsellor
+	invalidCadre := enums.StaffCadre("invalid")
+
+	type args struct {
+		ctx     context.Context
+		staffID string
+		input   *dto.StaffProfileUpdateInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				staffID: uuid.New().String(),
+				input:   &dto.StaffProfileUpdateInput{Cadre: &cadre},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:     ctx,
+				staffID: uuid.New().String(),
+				input:   &dto.StaffProfileUpdateInput{Cadre: &cadre},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no staffID",
+			args: args{
+				ctx:   ctx,
+				input: &dto.StaffProfileUpdateInput{Cadre: &cadre},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no input",
+			args: args{
+				ctx:     ctx,
+				staffID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid input",
+			args: args{
+				ctx:     ctx,
+				staffID: uuid.New().String(),
+				input:   &dto.StaffProfileUpdateInput{Cadre: &invalidCadre},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockUpdateStaffProfileFn = func(ctx context.Context, staffID string, input *dto.StaffProfileUpdateInput) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.UpdateStaffProfile(tt.args.ctx, tt.args.staffID, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.UpdateStaffProfile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.UpdateStaffProfile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_AddStaffFacilities(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx         context.Context
+		staffID     string
+		facilityIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:         ctx,
+				staffID:     uuid.New().String(),
+				facilityIDs: []string{uuid.New().String()},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:         ctx,
+				staffID:     uuid.New().String(),
+				facilityIDs: []string{uuid.New().String()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no facilities",
+			args: args{
+				ctx:     ctx,
+				staffID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockAddStaffFacilitiesFn = func(ctx context.Context, staffID string, facilityIDs []string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.AddStaffFacilities(tt.args.ctx, tt.args.staffID, tt.args.facilityIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.AddStaffFacilities() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.AddStaffFacilities() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_RemoveStaffFacilities(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx         context.Context
+		staffID     string
+		facilityIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:         ctx,
+				staffID:     uuid.New().String(),
+				facilityIDs: []string{uuid.New().String()},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:         ctx,
+				staffID:     uuid.New().String(),
+				facilityIDs: []string{uuid.New().String()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no staffID",
+			args: args{
+				ctx:         ctx,
+				facilityIDs: []string{uuid.New().String()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockRemoveStaffFacilitiesFn = func(ctx context.Context, staffID string, facilityIDs []string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.RemoveStaffFacilities(tt.args.ctx, tt.args.staffID, tt.args.facilityIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.RemoveStaffFacilities() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.RemoveStaffFacilities() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_UpdateOrganisation(t *testing.T) {
+	ctx := context.Background()
+	name := gofakeit.Company()
+	empty := ""
+
+	type args struct {
+		ctx            context.Context
+		organisationID string
+		input          *dto.OrganisationUpdateInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+				input:          &dto.OrganisationUpdateInput{Name: &name},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+				input:          &dto.OrganisationUpdateInput{Name: &name},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no organisationID",
+			args: args{
+				ctx:   ctx,
+				input: &dto.OrganisationUpdateInput{Name: &name},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no input",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid input",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+				input:          &dto.OrganisationUpdateInput{Name: &empty},
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockUpdateOrganisationFn = func(ctx context.Context, organisationID string, input *dto.OrganisationUpdateInput) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.UpdateOrganisation(tt.args.ctx, tt.args.organisationID, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.UpdateOrganisation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.UpdateOrganisation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_DeactivateOrganisation(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx            context.Context
+		organisationID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no organisationID",
+			args: args{
+				ctx: ctx,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockDeactivateOrganisationFn = func(ctx context.Context, organisationID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.DeactivateOrganisation(tt.args.ctx, tt.args.organisationID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.DeactivateOrganisation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.DeactivateOrganisation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_UpdateFacility(t *testing.T) {
+	ctx := context.Background()
+	name := gofakeit.Company()
+	empty := ""
+
+	type args struct {
+		ctx       context.Context
+		mflCode   int
+		input     *dto.FacilityUpdateInput
+		changedBy string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				mflCode:   1234,
+				input:     &dto.FacilityUpdateInput{Name: &name},
+				changedBy: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:       ctx,
+				mflCode:   1234,
+				input:     &dto.FacilityUpdateInput{Name: &name},
+				changedBy: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no MFL code",
+			args: args{
+				ctx:       ctx,
+				input:     &dto.FacilityUpdateInput{Name: &name},
+				changedBy: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no changedBy",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				input:   &dto.FacilityUpdateInput{Name: &name},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no input",
+			args: args{
+				ctx:       ctx,
+				mflCode:   1234,
+				changedBy: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid input",
+			args: args{
+				ctx:       ctx,
+				mflCode:   1234,
+				input:     &dto.FacilityUpdateInput{Name: &empty},
+				changedBy: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockUpdateFacilityFn = func(ctx context.Context, mflCode int, input *dto.FacilityUpdateInput, changedBy string) (*gorm.Facility, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.UpdateFacility(tt.args.ctx, tt.args.mflCode, tt.args.input, tt.args.changedBy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.UpdateFacility() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected a facility to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_UpsertFacilities(t *testing.T) {
+	ctx := context.Background()
+
+	facility := &dto.FacilityInput{
+		Name:        gofakeit.Company(),
+		Code:        1234,
+		Phone:       "+254711223344",
+		Active:      true,
+		County:      "Nairobi",
+		Description: gofakeit.HipsterSentence(15),
+	}
+
+	type args struct {
+		ctx        context.Context
+		facilities []*dto.FacilityInput
+		changedBy  string
+		dryRun     bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				facilities: []*dto.FacilityInput{facility},
+				changedBy:  uuid.New().String(),
+				dryRun:     true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:        ctx,
+				facilities: []*dto.FacilityInput{facility},
+				changedBy:  uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no changedBy",
+			args: args{
+				ctx:        ctx,
+				facilities: []*dto.FacilityInput{facility},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid facility",
+			args: args{
+				ctx:        ctx,
+				facilities: []*dto.FacilityInput{{Code: 1234}},
+				changedBy:  uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockUpsertFacilitiesFn = func(ctx context.Context, facilities []*gorm.Facility, changedBy string, dryRun bool) ([]*domain.FacilityImportRow, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.UpsertFacilities(tt.args.ctx, tt.args.facilities, tt.args.changedBy, tt.args.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.UpsertFacilities() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != len(tt.args.facilities) {
+				t.Errorf("expected a result for every facility, got %v", len(got))
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_SetFacilityOpeningHours(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx          context.Context
+		mflCode      int
+		openingHours []*dto.FacilityOpeningHoursInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				openingHours: []*dto.FacilityOpeningHoursInput{
+					{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "08:00", ClosesAt: "13:00"},
+					{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "14:00", ClosesAt: "17:00"},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - clear opening hours",
+			args: args{
+				ctx:          ctx,
+				mflCode:      1234,
+				openingHours: []*dto.FacilityOpeningHoursInput{},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - empty MFL code",
+			args: args{
+				ctx: ctx,
+				openingHours: []*dto.FacilityOpeningHoursInput{
+					{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "08:00", ClosesAt: "17:00"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - overlapping hours",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				openingHours: []*dto.FacilityOpeningHoursInput{
+					{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "08:00", ClosesAt: "13:00"},
+					{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "12:00", ClosesAt: "17:00"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to set opening hours",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				openingHours: []*dto.FacilityOpeningHoursInput{
+					{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "08:00", ClosesAt: "17:00"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to set opening hours" {
+				fakeGorm.MockSetFacilityOpeningHoursFn = func(ctx context.Context, mflCode int, openingHours []*gorm.FacilityOpeningHours) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.SetFacilityOpeningHours(tt.args.ctx, tt.args.mflCode, tt.args.openingHours)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.SetFacilityOpeningHours() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.SetFacilityOpeningHours() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_SetFacilityHoursException(t *testing.T) {
+	ctx := context.Background()
+	opensAt := "09:00"
+	closesAt := "12:00"
+
+	type args struct {
+		ctx     context.Context
+		mflCode int
+		input   *dto.FacilityHoursExceptionInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case - closed",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				input: &dto.FacilityHoursExceptionInput{
+					Date:   "2021-12-25",
+					Closed: true,
+					Reason: "Christmas",
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - shorter hours",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				input: &dto.FacilityHoursExceptionInput{
+					Date:     "2021-12-24",
+					OpensAt:  &opensAt,
+					ClosesAt: &closesAt,
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - empty MFL code",
+			args: args{
+				ctx: ctx,
+				input: &dto.FacilityHoursExceptionInput{
+					Date:   "2021-12-25",
+					Closed: true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - nil input",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid date",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				input: &dto.FacilityHoursExceptionInput{
+					Date:   "25-12-2021",
+					Closed: true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to set hours exception",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				input: &dto.FacilityHoursExceptionInput{
+					Date:   "2021-12-25",
+					Closed: true,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to set hours exception" {
+				fakeGorm.MockSetFacilityHoursExceptionFn = func(ctx context.Context, mflCode int, exception *gorm.FacilityHoursException) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.SetFacilityHoursException(tt.args.ctx, tt.args.mflCode, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.SetFacilityHoursException() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.SetFacilityHoursException() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_SetFacilityServices(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx          context.Context
+		mflCode      int
+		serviceCodes []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:          ctx,
+				mflCode:      1234,
+				serviceCodes: []string{"ART_REFILL", "HTS"},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - empty MFL code",
+			args: args{
+				ctx:          ctx,
+				serviceCodes: []string{"ART_REFILL"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to set services",
+			args: args{
+				ctx:          ctx,
+				mflCode:      1234,
+				serviceCodes: []string{"UNKNOWN"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to set services" {
+				fakeGorm.MockSetFacilityServicesFn = func(ctx context.Context, mflCode int, serviceCodes []string) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.SetFacilityServices(tt.args.ctx, tt.args.mflCode, tt.args.serviceCodes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.SetFacilityServices() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.SetFacilityServices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_RespondToClientTransfer(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx           context.Context
+		transferID    string
+		status        enums.ClientTransferStatus
+		respondedByID string
+		note          string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:           ctx,
+				transferID:    uuid.New().String(),
+				status:        enums.ClientTransferStatusAccepted,
+				respondedByID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - missing responder",
+			args: args{
+				ctx:        ctx,
+				transferID: uuid.New().String(),
+				status:     enums.ClientTransferStatusAccepted,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to respond to transfer",
+			args: args{
+				ctx:           ctx,
+				transferID:    uuid.New().String(),
+				status:        enums.ClientTransferStatusDeclined,
+				respondedByID: uuid.New().String(),
+				note:          "The facility is full",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to respond to transfer" {
+				fakeGorm.MockRespondToClientTransferFn = func(ctx context.Context, transferID string, status enums.ClientTransferStatus, respondedByID, note string) (*gorm.ClientTransfer, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.RespondToClientTransfer(tt.args.ctx, tt.args.transferID, tt.args.status, tt.args.respondedByID, tt.args.note)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.RespondToClientTransfer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Status != tt.args.status {
+				t.Errorf("expected the transfer status to be %v, got %v", tt.args.status, got.Status)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ReconcileContentEngagement(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx    context.Context
+		dryRun bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case - dry run",
+			args: args{
+				ctx:    ctx,
+				dryRun: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - rewrite the counters",
+			args: args{
+				ctx:    ctx,
+				dryRun: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - failed to reconcile content engagement",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to reconcile content engagement" {
+				fakeGorm.MockReconcileContentEngagementFn = func(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ReconcileContentEngagement(tt.args.ctx, tt.args.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ReconcileContentEngagement() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got.Drift) == 0 {
+				t.Errorf("expected the drift to be reported")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_RecordContentReadDuration(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx             context.Context
+		userID          string
+		contentID       int
+		durationSeconds int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:             ctx,
+				userID:          uuid.New().String(),
+				contentID:       10,
+				durationSeconds: 90,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - failed to record read duration",
+			args: args{
+				ctx:             ctx,
+				userID:          uuid.New().String(),
+				contentID:       10,
+				durationSeconds: 90,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to record read duration" {
+				fakeGorm.MockRecordContentReadDurationFn = func(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.RecordContentReadDuration(tt.args.ctx, tt.args.userID, tt.args.contentID, tt.args.durationSeconds)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.RecordContentReadDuration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.RecordContentReadDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_RecordContentProgress(t *testing.T) {
+	ctx := context.Background()
+	threshold := domain.ContentCompletionThreshold{PercentScrolled: 90, ShareOfTimeEstimate: 0.5}
+
+	type args struct {
+		ctx             context.Context
+		userID          string
+		contentID       int
+		secondsSpent    int
+		percentScrolled float64
+	}
+	tests := []struct {
+		name              string
+		args              args
+		wantJustCompleted bool
+		wantErr           bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:             ctx,
+				userID:          uuid.New().String(),
+				contentID:       10,
+				secondsSpent:    30,
+				percentScrolled: 40,
+			},
+			wantJustCompleted: false,
+			wantErr:           false,
+		},
+		{
+			name: "Happy case - completed",
+			args: args{
+				ctx:             ctx,
+				userID:          uuid.New().String(),
+				contentID:       10,
+				secondsSpent:    60,
+				percentScrolled: 100,
+			},
+			wantJustCompleted: true,
+			wantErr:           false,
+		},
+		{
+			name: "Sad case - failed to record content progress",
+			args: args{
+				ctx:             ctx,
+				userID:          uuid.New().String(),
+				contentID:       10,
+				secondsSpent:    30,
+				percentScrolled: 40,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Happy case - completed" {
+				fakeGorm.MockRecordContentProgressFn = func(ctx context.Context, report *gorm.ContentProgress, threshold domain.ContentCompletionThreshold) (*gorm.ContentProgress, bool, error) {
+					completedAt := time.Now()
+					report.CompletedAt = &completedAt
+					return report, true, nil
+				}
+			}
+			if tt.name == "Sad case - failed to record content progress" {
+				fakeGorm.MockRecordContentProgressFn = func(ctx context.Context, report *gorm.ContentProgress, threshold domain.ContentCompletionThreshold) (*gorm.ContentProgress, bool, error) {
+					return nil, false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.RecordContentProgress(tt.args.ctx, tt.args.userID, tt.args.contentID, tt.args.secondsSpent, tt.args.percentScrolled, threshold)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.RecordContentProgress() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.JustCompleted != tt.wantJustCompleted || got.Progress.Completed != tt.wantJustCompleted {
+				t.Errorf("expected just completed to be %v, got %v", tt.wantJustCompleted, got.JustCompleted)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_RecordContentShareOpen(t *testing.T) {
+	ctx := context.Background()
+	openedByID := uuid.New().String()
+
+	type args struct {
+		ctx         context.Context
+		shareLinkID string
+		openedByID  *string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:         ctx,
+				shareLinkID: uuid.New().String(),
+				openedByID:  &openedByID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no share link ID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to record content share open",
+			args: args{
+				ctx:         ctx,
+				shareLinkID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to record content share open" {
+				fakeGorm.MockRecordContentShareOpenFn = func(ctx context.Context, shareLinkID string, openedByID *string) (*gorm.ContentShareLink, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.RecordContentShareOpen(tt.args.ctx, tt.args.shareLinkID, tt.args.openedByID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.RecordContentShareOpen() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.ID != tt.args.shareLinkID {
+				t.Errorf("expected share link %v, got %v", tt.args.shareLinkID, got.ID)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CreateContentComment(t *testing.T) {
+	ctx := context.Background()
+	parentID := uuid.New().String()
+
+	type args struct {
+		ctx       context.Context
+		userID    string
+		contentID int
+		parentID  *string
+		body      string
+		status    enums.ContentCommentStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				userID:    uuid.New().String(),
+				contentID: 10,
+				body:      "How long should I take the medication?",
+				status:    enums.ContentCommentStatusApproved,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - reply",
+			args: args{
+				ctx:       ctx,
+				userID:    uuid.New().String(),
+				contentID: 10,
+				parentID:  &parentID,
+				body:      "Ask your clinician",
+				status:    enums.ContentCommentStatusApproved,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - invalid status",
+			args: args{
+				ctx:       ctx,
+				userID:    uuid.New().String(),
+				contentID: 10,
+				body:      "How long should I take the medication?",
+				status:    "invalid",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to create content comment",
+			args: args{
+				ctx:       ctx,
+				userID:    uuid.New().String(),
+				contentID: 10,
+				body:      "How long should I take the medication?",
+				status:    enums.ContentCommentStatusApproved,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to create content comment" {
+				fakeGorm.MockCreateContentCommentFn = func(ctx context.Context, comment *gorm.ContentComment) (*gorm.ContentCommentDetails, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.CreateContentComment(tt.args.ctx, tt.args.userID, tt.args.contentID, tt.args.parentID, tt.args.body, tt.args.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CreateContentComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.Body != tt.args.body || got.ParentID != tt.args.parentID) {
+				t.Errorf("expected the created comment to match the input, got %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_EditContentComment(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx       context.Context
+		commentID string
+		body      string
+		status    enums.ContentCommentStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				commentID: uuid.New().String(),
+				body:      "How long should I take the medication for?",
+				status:    enums.ContentCommentStatusApproved,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - invalid status",
+			args: args{
+				ctx:       ctx,
+				commentID: uuid.New().String(),
+				body:      "How long should I take the medication for?",
+				status:    "invalid",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to edit content comment",
+			args: args{
+				ctx:       ctx,
+				commentID: uuid.New().String(),
+				body:      "How long should I take the medication for?",
+				status:    enums.ContentCommentStatusApproved,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to edit content comment" {
+				fakeGorm.MockEditContentCommentFn = func(ctx context.Context, commentID string, body string, status enums.ContentCommentStatus) (*gorm.ContentCommentDetails, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.EditContentComment(tt.args.ctx, tt.args.commentID, tt.args.body, tt.args.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.EditContentComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.Body != tt.args.body || got.EditedAt == nil) {
+				t.Errorf("expected the comment to be edited, got %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_DeleteContentComment(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx       context.Context
+		commentID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				commentID: uuid.New().String(),
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - failed to delete content comment",
+			args: args{
+				ctx:       ctx,
+				commentID: uuid.New().String(),
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to delete content comment" {
+				fakeGorm.MockDeleteContentCommentFn = func(ctx context.Context, commentID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.DeleteContentComment(tt.args.ctx, tt.args.commentID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.DeleteContentComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.DeleteContentComment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ModerateContentComment(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx         context.Context
+		commentID   string
+		status      enums.ContentCommentStatus
+		moderatorID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:         ctx,
+				commentID:   uuid.New().String(),
+				status:      enums.ContentCommentStatusRejected,
+				moderatorID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - invalid status",
+			args: args{
+				ctx:         ctx,
+				commentID:   uuid.New().String(),
+				status:      "invalid",
+				moderatorID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to moderate content comment",
+			args: args{
+				ctx:         ctx,
+				commentID:   uuid.New().String(),
+				status:      enums.ContentCommentStatusRejected,
+				moderatorID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case - failed to moderate content comment" {
+				fakeGorm.MockModerateContentCommentFn = func(ctx context.Context, commentID string, status enums.ContentCommentStatus, moderatorID string) (*gorm.ContentCommentDetails, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ModerateContentComment(tt.args.ctx, tt.args.commentID, tt.args.status, tt.args.moderatorID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ModerateContentComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Status != tt.args.status {
+				t.Errorf("expected the comment to be %v, got %v", tt.args.status, got.Status)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_UpdateBulkInviteJobStatus(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx    context.Context
+		jobID  string
+		status enums.BulkInviteJobStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				jobID:  uuid.New().String(),
+				status: enums.BulkInviteJobStatusCompleted,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:    ctx,
+				jobID:  uuid.New().String(),
+				status: enums.BulkInviteJobStatusCompleted,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockUpdateBulkInviteJobStatusFn = func(ctx context.Context, jobID string, status enums.BulkInviteJobStatus) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.UpdateBulkInviteJobStatus(tt.args.ctx, tt.args.jobID, tt.args.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.UpdateBulkInviteJobStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.UpdateBulkInviteJobStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_FailInterruptedBulkInviteJobs(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx        context.Context
+		staleAfter time.Duration
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				staleAfter: time.Minute,
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:        ctx,
+				staleAfter: time.Minute,
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockFailInterruptedBulkInviteJobsFn = func(ctx context.Context, staleAfter time.Duration) (int, error) {
+					return 0, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.FailInterruptedBulkInviteJobs(tt.args.ctx, tt.args.staleAfter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.FailInterruptedBulkInviteJobs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.FailInterruptedBulkInviteJobs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_RecordBulkInviteRowOutcome(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx          context.Context
+		jobID        string
+		rowID        string
+		status       enums.BulkInviteRowStatus
+		errorMessage string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				jobID:  uuid.New().String(),
+				rowID:  uuid.New().String(),
+				status: enums.BulkInviteRowStatusSuccess,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case",
+			args: args{
+				ctx:          ctx,
+				jobID:        uuid.New().String(),
+				rowID:        uuid.New().String(),
+				status:       enums.BulkInviteRowStatusFailed,
+				errorMessage: "invalid phone number",
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case" {
+				fakeGorm.MockRecordBulkInviteRowOutcomeFn = func(ctx context.Context, jobID string, rowID string, status enums.BulkInviteRowStatus, errorMessage string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.RecordBulkInviteRowOutcome(tt.args.ctx, tt.args.jobID, tt.args.rowID, tt.args.status, tt.args.errorMessage)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.RecordBulkInviteRowOutcome() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.RecordBulkInviteRowOutcome() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"time"
 
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 )
 
@@ -20,19 +22,35 @@ type Create interface {
 	SaveSecurityQuestionResponse(ctx context.Context, securityQuestionResponse []*dto.SecurityQuestionResponseInput) error
 	CreateHealthDiaryEntry(ctx context.Context, healthDiaryInput *domain.ClientHealthDiaryEntry) error
 	CreateServiceRequest(ctx context.Context, serviceRequestInput *domain.ClientServiceRequest) error
+	SaveInvitation(ctx context.Context, invitation *domain.Invitation) (*domain.Invitation, error)
+	RegisterClient(ctx context.Context, client *domain.ClientProfile) (*domain.ClientProfile, error)
+	RegisterStaff(ctx context.Context, staff *domain.StaffProfile) (*domain.StaffProfile, error)
+	CreateStaffProfile(ctx context.Context, staff *domain.StaffProfile) (*domain.StaffProfile, error)
+	AssignRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error)
+	AssignFirstSystemAdmin(ctx context.Context, userID string) (bool, error)
+	CreateOrganisation(ctx context.Context, input *dto.OrganisationInput) (*domain.Organisation, error)
+	CreateFacilityService(ctx context.Context, input *dto.FacilityServiceInput) (*domain.FacilityService, error)
+	CreateClientTransfer(ctx context.Context, transfer *domain.ClientTransfer, serviceRequest *domain.ClientServiceRequest) (*domain.ClientTransfer, error)
+	CreateBulkInviteJob(ctx context.Context, job *domain.BulkInviteJob) (*domain.BulkInviteJob, error)
 }
 
 // Delete represents all the deletion action interfaces
 type Delete interface {
 	DeleteFacility(ctx context.Context, id int) (bool, error)
+	RevokeRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error)
+	DeleteFacilityHoursException(ctx context.Context, mflCode int, date string) (bool, error)
 }
 
 // Query contains all query methods
 type Query interface {
 	RetrieveFacility(ctx context.Context, id *string, isActive bool) (*domain.Facility, error)
+	// Deprecated: GetFacilities returns every facility. Use ListFacilities, which is paginated.
 	GetFacilities(ctx context.Context) ([]*domain.Facility, error)
 	RetrieveFacilityByMFLCode(ctx context.Context, MFLCode int, isActive bool) (*domain.Facility, error)
-	ListFacilities(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error)
+	GetFacilityHistory(ctx context.Context, mflCode int) ([]*domain.FacilityHistory, error)
+	ListFacilities(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, filterGroups []*dto.FilterGroupInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error)
+	NearbyFacilities(ctx context.Context, input *dto.NearbyFacilitiesInput) ([]*domain.NearbyFacility, error)
+	ListFacilityServices(ctx context.Context) ([]*domain.FacilityService, error)
 	GetUserProfileByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.User, error)
 	GetUserPINByUserID(ctx context.Context, userID string) (*domain.UserPIN, error)
 	GetUserProfileByUserID(ctx context.Context, userID string) (*domain.User, error)
@@ -50,17 +68,46 @@ type Query interface {
 	GetContactByUserID(ctx context.Context, userID *string, contactType string) (*domain.Contact, error)
 	ListContentCategories(ctx context.Context) ([]*domain.ContentItemCategory, error)
 	GetUserBookmarkedContent(ctx context.Context, userID string) ([]*domain.ContentItem, error)
-	CanRecordHeathDiary(ctx context.Context, clientID string) (bool, error)
+	ListContentItems(ctx context.Context, categoryID *int, contentIDs []int, limit int) (*domain.Content, error)
+	SearchContent(ctx context.Context, input *dto.ContentSearchInput, paginationsInput *dto.PaginationsInput) (*domain.ContentSearchPage, error)
+	ListRecommendedContent(ctx context.Context, userID string, limit int) (*domain.Content, error)
+	ListContentFeed(ctx context.Context, categoryID *int, sort enums.ContentSortOrder, trendingDays int, after *domain.ContentFeedPosition, before *domain.ContentFeedPosition, limit int) (*domain.ContentFeedPage, error)
+	GetContentAnalytics(ctx context.Context, input *dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error)
+	ListContentInProgress(ctx context.Context, userID string, limit int) ([]*domain.ContentProgress, error)
+	GetContentCommentByID(ctx context.Context, commentID string) (*domain.ContentComment, error)
+	ListContentComments(ctx context.Context, contentID int) ([]*domain.ContentComment, error)
+	ListContentCommentsByStatus(ctx context.Context, statuses []enums.ContentCommentStatus, limit int) ([]*domain.ContentComment, error)
+	CanRecordHeathDiary(ctx context.Context, clientID string, cadence time.Duration) (bool, error)
 	GetClientHealthDiaryQuote(ctx context.Context) (*domain.ClientHealthDiaryQuote, error)
 	CheckIfUserBookmarkedContent(ctx context.Context, userID string, contentID int) (bool, error)
 	GetClientHealthDiaryEntries(ctx context.Context, clientID string) ([]*domain.ClientHealthDiaryEntry, error)
 	GetFAQContent(ctx context.Context, flavour feedlib.Flavour, limit *int) ([]*domain.FAQ, error)
+	GetLatestUserInvitation(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.Invitation, error)
+	ListPendingInvitations(ctx context.Context, facilityID string) ([]*domain.Invitation, error)
+	GetStaffProfileByUserID(ctx context.Context, userID string) (*domain.StaffProfile, error)
+	GetStaffProfileByStaffID(ctx context.Context, staffID string) (*domain.StaffProfile, error)
+	GetStaffFacilities(ctx context.Context, staffID string) ([]*domain.Facility, error)
+	GetUserRoles(ctx context.Context, userID string) ([]enums.UserRoleType, error)
+	GetClientProfileByClientID(ctx context.Context, clientID string) (*domain.ClientProfile, error)
+	GetOrganisation(ctx context.Context, organisationID string) (*domain.Organisation, error)
+	ListOrganisations(ctx context.Context) ([]*domain.Organisation, error)
+	GetOrganisationSettings(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error)
+	GetClientTransferByID(ctx context.Context, transferID string) (*domain.ClientTransfer, error)
+	CheckClientHasPendingTransfer(ctx context.Context, clientID string) (bool, error)
+	ListPendingClientTransfers(ctx context.Context, facilityID string) ([]*domain.ClientTransfer, error)
+	GetClientFacilityHistory(ctx context.Context, clientID string) ([]*domain.ClientFacilityPeriod, error)
+	GetBulkInviteJob(ctx context.Context, jobID string) (*domain.BulkInviteJob, error)
 }
 
 // Update represents all the update action interfaces
 type Update interface {
 	InactivateFacility(ctx context.Context, mflCode *int) (bool, error)
 	ReactivateFacility(ctx context.Context, mflCode *int) (bool, error)
+	UpdateFacility(ctx context.Context, mflCode int, input *dto.FacilityUpdateInput, changedBy string) (*domain.Facility, error)
+	UpsertFacilities(ctx context.Context, facilities []*dto.FacilityInput, changedBy string, dryRun bool) ([]*domain.FacilityImportRow, error)
+	SetFacilityOpeningHours(ctx context.Context, mflCode int, openingHours []*dto.FacilityOpeningHoursInput) (bool, error)
+	SetFacilityHoursException(ctx context.Context, mflCode int, input *dto.FacilityHoursExceptionInput) (bool, error)
+	SetFacilityServices(ctx context.Context, mflCode int, serviceCodes []string) (bool, error)
 	AcceptTerms(ctx context.Context, userID *string, termsID *int) (bool, error)
 	UpdateUserFailedLoginCount(ctx context.Context, userID string, failedLoginAttempts int) error
 	UpdateUserLastFailedLoginTime(ctx context.Context, userID string) error
@@ -70,10 +117,31 @@ type Update interface {
 	UpdateUserPinChangeRequiredStatus(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error)
 	InvalidatePIN(ctx context.Context, userID string) (bool, error)
 	UpdateIsCorrectSecurityQuestionResponse(ctx context.Context, userID string, isCorrectSecurityQuestionResponse bool) (bool, error)
-	ShareContent(ctx context.Context, input dto.ShareContentInput) (bool, error)
+	ShareContent(ctx context.Context, input dto.ShareContentInput) (*domain.ContentShareLink, error)
+	RecordContentShareOpen(ctx context.Context, shareLinkID string, openedByID *string) (*domain.ContentShareLink, error)
+	CreateContentComment(ctx context.Context, userID string, contentID int, parentID *string, body string, status enums.ContentCommentStatus) (*domain.ContentComment, error)
+	EditContentComment(ctx context.Context, commentID string, body string, status enums.ContentCommentStatus) (*domain.ContentComment, error)
+	DeleteContentComment(ctx context.Context, commentID string) (bool, error)
+	ModerateContentComment(ctx context.Context, commentID string, status enums.ContentCommentStatus, moderatorID string) (*domain.ContentComment, error)
 	BookmarkContent(ctx context.Context, userID string, contentID int) (bool, error)
 	UnBookmarkContent(ctx context.Context, userID string, contentID int) (bool, error)
 	LikeContent(ctx context.Context, userID string, contentID int) (bool, error)
 	UnlikeContent(ctx context.Context, userID string, contentID int) (bool, error)
 	ViewContent(ctx context.Context, userID string, contentID int) (bool, error)
+	RecordContentReadDuration(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error)
+	RecordContentProgress(ctx context.Context, userID string, contentID int, secondsSpent int, percentScrolled float64, threshold domain.ContentCompletionThreshold) (*domain.ContentProgressUpdate, error)
+	UpdateUserLanguages(ctx context.Context, userID string, languages []enumutils.Language) (bool, error)
+	UpdateInvitationStatus(ctx context.Context, invitationID string, status enums.InvitationStatus) (bool, error)
+	CloseUserInvitations(ctx context.Context, userID string, flavour feedlib.Flavour, status enums.InvitationStatus) (bool, error)
+	ExpireOverdueInvitations(ctx context.Context) (bool, error)
+	UpdateStaffProfile(ctx context.Context, staffID string, input *dto.StaffProfileUpdateInput) (bool, error)
+	AddStaffFacilities(ctx context.Context, staffID string, facilityIDs []string) (bool, error)
+	RemoveStaffFacilities(ctx context.Context, staffID string, facilityIDs []string) (bool, error)
+	UpdateOrganisation(ctx context.Context, organisationID string, input *dto.OrganisationUpdateInput) (bool, error)
+	DeactivateOrganisation(ctx context.Context, organisationID string) (bool, error)
+	RespondToClientTransfer(ctx context.Context, transferID string, status enums.ClientTransferStatus, respondedByID string, note string) (*domain.ClientTransfer, error)
+	ReconcileContentEngagement(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error)
+	UpdateBulkInviteJobStatus(ctx context.Context, jobID string, status enums.BulkInviteJobStatus) (bool, error)
+	FailInterruptedBulkInviteJobs(ctx context.Context, staleAfter time.Duration) (int, error)
+	RecordBulkInviteRowOutcome(ctx context.Context, jobID string, rowID string, status enums.BulkInviteRowStatus, errorMessage string) (bool, error)
 }
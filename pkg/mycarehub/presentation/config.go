@@ -14,6 +14,7 @@ import (
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/savannahghi/firebasetools"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	externalExtension "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
@@ -21,11 +22,14 @@ import (
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/presentation/graph/generated"
 	internalRest "github.com/savannahghi/mycarehub/pkg/mycarehub/presentation/rest"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/clienttransfer"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/content"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/facility"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/faq"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/feedback"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/healthdiary"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/organisation"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/securityquestions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/servicerequest"
@@ -75,8 +79,17 @@ func Router(ctx context.Context) (*mux.Router, error) {
 
 	otpUseCase := otp.NewOTPUseCase(db, db, externalExt)
 
+	authorityUseCase := authority.NewUseCasesAuthority(db, db, db, externalExt)
+
 	// Initialize user usecase
-	userUsecase := user.NewUseCasesUserImpl(db, db, db, db, externalExt, otpUseCase)
+	userUsecase := user.NewUseCasesUserImpl(db, db, db, db, externalExt, otpUseCase, authorityUseCase)
+
+	// the bulk invite jobs that a previous run of the service was sending are not resumed
+	if failed, err := userUsecase.FailInterruptedBulkInviteJobs(helpers.ContextForAllOrganisations(ctx)); err != nil {
+		log.Errorf("%v", err)
+	} else if failed > 0 {
+		log.Warnf("failed %d interrupted bulk invite jobs", failed)
+	}
 
 	termsUsecase := terms.NewUseCasesTermsOfService(db, db)
 
@@ -92,10 +105,14 @@ func Router(ctx context.Context) (*mux.Router, error) {
 
 	serviceRequestUseCase := servicerequest.NewUseCaseServiceRequestImpl(db)
 
+	organisationUseCase := organisation.NewUseCasesOrganisation(db, db, db)
+
+	clientTransferUseCase := clienttransfer.NewUseCasesClientTransfer(db, db, db, externalExt, authorityUseCase)
+
 	useCase := usecases.NewMyCareHubUseCase(
 		userUsecase, termsUsecase, facilityUseCase,
 		securityQuestionsUsecase, otpUseCase, contentUseCase, feedbackUsecase, healthDiaryUseCase,
-		faq, serviceRequestUseCase,
+		faq, serviceRequestUseCase, authorityUseCase, organisationUseCase, clientTransferUseCase,
 	)
 
 	internalHandlers := internalRest.NewMyCareHubHandlersInterfaces(*useCase)
@@ -121,57 +138,70 @@ func Router(ctx context.Context) (*mux.Router, error) {
 	r.Path("/login_by_phone").Methods(
 		http.MethodOptions,
 		http.MethodPost,
-	).HandlerFunc(internalHandlers.LoginByPhone())
+	).HandlerFunc(withoutOrganisationScope(internalHandlers.LoginByPhone()))
 
 	r.Path("/refresh_token").Methods(
 		http.MethodPost,
 		http.MethodOptions,
-	).HandlerFunc(internalHandlers.RefreshToken())
+	).HandlerFunc(withoutOrganisationScope(internalHandlers.RefreshToken()))
 
 	r.Path("/verify_security_questions").Methods(
 		http.MethodPost,
 		http.MethodOptions,
-	).HandlerFunc(internalHandlers.VerifySecurityQuestions())
+	).HandlerFunc(withoutOrganisationScope(internalHandlers.VerifySecurityQuestions()))
 
 	r.Path("/verify_phone").Methods(
 		http.MethodOptions,
 		http.MethodPost,
-	).HandlerFunc(internalHandlers.VerifyPhone())
+	).HandlerFunc(withoutOrganisationScope(internalHandlers.VerifyPhone()))
 
 	r.Path("/verify_otp").Methods(
 		http.MethodOptions,
 		http.MethodPost,
-	).HandlerFunc(internalHandlers.VerifyOTP())
+	).HandlerFunc(withoutOrganisationScope(internalHandlers.VerifyOTP()))
 
 	r.Path("/send_otp").Methods(
 		http.MethodOptions,
 		http.MethodPost,
-	).HandlerFunc(internalHandlers.SendOTP())
+	).HandlerFunc(withoutOrganisationScope(internalHandlers.SendOTP()))
 
 	// PIN routes
 	r.Path("/request_pin_reset").Methods(
 		http.MethodPost,
 		http.MethodOptions,
-	).HandlerFunc(internalHandlers.RequestPINReset())
+	).HandlerFunc(withoutOrganisationScope(internalHandlers.RequestPINReset()))
 
 	r.Path("/reset_pin").Methods(
 		http.MethodPost,
 		http.MethodOptions,
-	).HandlerFunc(internalHandlers.ResetPIN())
+	).HandlerFunc(withoutOrganisationScope(internalHandlers.ResetPIN()))
 
 	r.Path("/send_retry_otp").Methods(
 		http.MethodOptions,
 		http.MethodPost,
-	).HandlerFunc(internalHandlers.SendRetryOTP())
+	).HandlerFunc(withoutOrganisationScope(internalHandlers.SendRetryOTP()))
 
 	r.Path("/get_user_responded_security_questions").Methods(
 		http.MethodOptions,
 		http.MethodPost,
-	).HandlerFunc(internalHandlers.GetUserRespondedSecurityQuestions())
+	).HandlerFunc(withoutOrganisationScope(internalHandlers.GetUserRespondedSecurityQuestions()))
+
+	// CMS webhooks
+	r.Path("/content_webhook").Methods(
+		http.MethodOptions,
+		http.MethodPost,
+	).HandlerFunc(withoutOrganisationScope(internalHandlers.ContentWebhook()))
+
+	// Shared content links opened on the public web view
+	r.Path("/open_shared_content").Methods(
+		http.MethodOptions,
+		http.MethodPost,
+	).HandlerFunc(withoutOrganisationScope(internalHandlers.OpenSharedContent()))
 
 	// Graphql route
 	authR := r.Path("/graphql").Subrouter()
 	authR.Use(firebasetools.AuthenticationMiddleware(firebaseApp))
+	authR.Use(IdentityMiddleware(useCase.Authority))
 	authR.Methods(
 		http.MethodPost,
 		http.MethodGet,
@@ -229,6 +259,9 @@ func GQLHandler(ctx context.Context,
 		generated.NewExecutableSchema(
 			generated.Config{
 				Resolvers: resolver,
+				Directives: generated.DirectiveRoot{
+					HasPermission: resolver.HasPermission,
+				},
 			},
 		),
 	)
@@ -236,3 +269,33 @@ func GQLHandler(ctx context.Context,
 		server.ServeHTTP(w, r)
 	}
 }
+
+// IdentityMiddleware maps the Firebase user set on the context by the authentication middleware
+// to their mycarehub user, client and staff IDs and places them on the request's context.
+// Requests whose identity cannot be resolved are rejected since the database is scoped to the
+// logged in user's organisation.
+func IdentityMiddleware(authorityUseCase authority.UseCasesAuthority) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				identity, err := authorityUseCase.ResolveIdentity(r.Context())
+				if err != nil {
+					log.Warnf("failed to resolve the identity of the logged in user: %v", err)
+					serverutils.WriteJSONResponse(w, []map[string]string{{"error": "the logged in user could not be identified"}}, http.StatusUnauthorized)
+					return
+				}
+
+				r = r.WithContext(helpers.ContextWithIdentity(r.Context(), identity))
+				next.ServeHTTP(w, r)
+			},
+		)
+	}
+}
+
+// withoutOrganisationScope marks the requests of a route that is called before the user has logged in, e.g to
+// log in or reset a PIN, as spanning all the organisations since the user's organisation is not known yet
+func withoutOrganisationScope(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(helpers.ContextForAllOrganisations(r.Context())))
+	}
+}
@@ -0,0 +1,35 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/presentation/graph/generated"
+)
+
+func (r *mutationResolver) AssignRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Authority.AssignRoles(ctx, userID, roles)
+}
+
+func (r *mutationResolver) RevokeRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Authority.RevokeRoles(ctx, userID, roles)
+}
+
+func (r *queryResolver) GetUserRoles(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Authority.GetUserRoles(ctx, userID)
+}
+
+// Mutation returns generated.MutationResolver implementation.
+func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
+
+// Query returns generated.QueryResolver implementation.
+func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
+
+type mutationResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
@@ -0,0 +1,35 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+func (r *mutationResolver) TransferClient(ctx context.Context, clientID string, toFacilityMflcode int, reason string) (*domain.ClientTransfer, error) {
+	r.checkPreconditions()
+	return r.mycarehub.ClientTransfer.TransferClient(ctx, clientID, toFacilityMflcode, reason)
+}
+
+func (r *mutationResolver) AcceptClientTransfer(ctx context.Context, transferID string) (*domain.ClientTransfer, error) {
+	r.checkPreconditions()
+	return r.mycarehub.ClientTransfer.AcceptClientTransfer(ctx, transferID)
+}
+
+func (r *mutationResolver) DeclineClientTransfer(ctx context.Context, transferID string, reason string) (*domain.ClientTransfer, error) {
+	r.checkPreconditions()
+	return r.mycarehub.ClientTransfer.DeclineClientTransfer(ctx, transferID, reason)
+}
+
+func (r *queryResolver) PendingClientTransfers(ctx context.Context, facilityID string) ([]*domain.ClientTransfer, error) {
+	r.checkPreconditions()
+	return r.mycarehub.ClientTransfer.ListPendingClientTransfers(ctx, facilityID)
+}
+
+func (r *queryResolver) ClientFacilityHistory(ctx context.Context, clientID string) ([]*domain.ClientFacilityPeriod, error) {
+	r.checkPreconditions()
+	return r.mycarehub.ClientTransfer.GetClientFacilityHistory(ctx, clientID)
+}
@@ -6,42 +6,133 @@ package graph
 import (
 	"context"
 
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
-	"github.com/savannahghi/mycarehub/pkg/mycarehub/presentation/graph/generated"
 )
 
-func (r *mutationResolver) ShareContent(ctx context.Context, input dto.ShareContentInput) (bool, error) {
+func (r *mutationResolver) ShareContent(ctx context.Context, input dto.ShareContentInput) (*domain.ContentShareLink, error) {
+	r.checkPreconditions()
+	userID, err := r.mycarehub.Authority.ResolveUserID(ctx, &input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	input.UserID = userID
 	return r.mycarehub.Content.ShareContent(ctx, input)
 }
 
-func (r *mutationResolver) BookmarkContent(ctx context.Context, userID string, contentItemID int) (bool, error) {
-	return r.mycarehub.Content.BookmarkContent(ctx, userID, contentItemID)
+func (r *mutationResolver) OpenSharedContent(ctx context.Context, token string) (int, error) {
+	r.checkPreconditions()
+	openedByID, err := r.mycarehub.Authority.ResolveUserID(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return r.mycarehub.Content.OpenSharedContent(ctx, token, &openedByID, "")
 }
 
-func (r *mutationResolver) UnBookmarkContent(ctx context.Context, userID string, contentItemID int) (bool, error) {
-	return r.mycarehub.Content.UnBookmarkContent(ctx, userID, contentItemID)
+func (r *mutationResolver) BookmarkContent(ctx context.Context, userID *string, contentItemID int) (bool, error) {
+	r.checkPreconditions()
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.Content.BookmarkContent(ctx, resolvedUserID, contentItemID)
 }
 
-func (r *mutationResolver) LikeContent(ctx context.Context, userID string, contentID int) (bool, error) {
+func (r *mutationResolver) UnBookmarkContent(ctx context.Context, userID *string, contentItemID int) (bool, error) {
 	r.checkPreconditions()
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.Content.UnBookmarkContent(ctx, resolvedUserID, contentItemID)
+}
 
-	return r.mycarehub.Content.LikeContent(ctx, userID, contentID)
+func (r *mutationResolver) LikeContent(ctx context.Context, userID *string, contentID int) (bool, error) {
+	r.checkPreconditions()
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.Content.LikeContent(ctx, resolvedUserID, contentID)
+}
+
+func (r *mutationResolver) UnlikeContent(ctx context.Context, userID *string, contentID int) (bool, error) {
+	r.checkPreconditions()
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.Content.UnlikeContent(ctx, resolvedUserID, contentID)
+}
+
+func (r *mutationResolver) ViewContent(ctx context.Context, userID *string, contentID int) (bool, error) {
+	r.checkPreconditions()
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.Content.ViewContent(ctx, resolvedUserID, contentID)
 }
 
-func (r *mutationResolver) UnlikeContent(ctx context.Context, userID string, contentID int) (bool, error) {
+func (r *mutationResolver) RecordContentReadDuration(ctx context.Context, userID *string, contentID int, durationSeconds int) (bool, error) {
 	r.checkPreconditions()
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.Content.RecordContentReadDuration(ctx, resolvedUserID, contentID, durationSeconds)
+}
+
+func (r *mutationResolver) RecordContentProgress(ctx context.Context, userID *string, contentID int, secondsSpent int, percentScrolled float64) (*domain.ContentProgressUpdate, error) {
+	r.checkPreconditions()
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.mycarehub.Content.RecordContentProgress(ctx, resolvedUserID, contentID, secondsSpent, percentScrolled)
+}
+
+func (r *mutationResolver) CreateContentComment(ctx context.Context, contentID int, parentID *string, body string) (*domain.ContentComment, error) {
+	r.checkPreconditions()
+	userID, err := r.mycarehub.Authority.ResolveUserID(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.mycarehub.Content.CreateContentComment(ctx, userID, contentID, parentID, body)
+}
+
+func (r *mutationResolver) EditContentComment(ctx context.Context, commentID string, body string) (*domain.ContentComment, error) {
+	r.checkPreconditions()
+	userID, err := r.mycarehub.Authority.ResolveUserID(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.mycarehub.Content.EditContentComment(ctx, userID, commentID, body)
+}
 
-	return r.mycarehub.Content.UnlikeContent(ctx, userID, contentID)
+func (r *mutationResolver) DeleteContentComment(ctx context.Context, commentID string) (bool, error) {
+	r.checkPreconditions()
+	userID, err := r.mycarehub.Authority.ResolveUserID(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.Content.DeleteContentComment(ctx, userID, commentID)
 }
 
-func (r *mutationResolver) ViewContent(ctx context.Context, userID string, contentID int) (bool, error) {
-	return r.mycarehub.Content.ViewContent(ctx, userID, contentID)
+func (r *mutationResolver) ModerateContentComment(ctx context.Context, commentID string, status enums.ContentCommentStatus) (*domain.ContentComment, error) {
+	r.checkPreconditions()
+	moderatorID, err := r.mycarehub.Authority.ResolveUserID(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.mycarehub.Content.ModerateContentComment(ctx, moderatorID, commentID, status)
 }
 
-func (r *queryResolver) GetContent(ctx context.Context, categoryID *int, limit string) (*domain.Content, error) {
+func (r *queryResolver) GetContent(ctx context.Context, categoryID *int, limit int, sort *enums.ContentSortOrder, trendingDays *int, cursor *string) (*domain.Content, error) {
 	r.checkPreconditions()
-	return r.mycarehub.Content.GetContent(ctx, categoryID, limit)
+	return r.mycarehub.Content.GetContent(ctx, categoryID, limit, sort, trendingDays, cursor)
 }
 
 func (r *queryResolver) ListContentCategories(ctx context.Context) ([]*domain.ContentItemCategory, error) {
@@ -49,25 +140,76 @@ func (r *queryResolver) ListContentCategories(ctx context.Context) ([]*domain.Co
 	return r.mycarehub.Content.ListContentCategories(ctx)
 }
 
-func (r *queryResolver) GetUserBookmarkedContent(ctx context.Context, userID string) (*domain.Content, error) {
+func (r *queryResolver) GetUserBookmarkedContent(ctx context.Context, userID *string) (*domain.Content, error) {
 	r.checkPreconditions()
-	return r.mycarehub.Content.GetUserBookmarkedContent(ctx, userID)
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.mycarehub.Content.GetUserBookmarkedContent(ctx, resolvedUserID)
 }
 
-func (r *queryResolver) CheckIfUserHasLikedContent(ctx context.Context, userID string, contentID int) (bool, error) {
+func (r *queryResolver) CheckIfUserHasLikedContent(ctx context.Context, userID *string, contentID int) (bool, error) {
 	r.checkPreconditions()
-	return r.mycarehub.Content.CheckWhetherUserHasLikedContent(ctx, userID, contentID)
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.Content.CheckWhetherUserHasLikedContent(ctx, resolvedUserID, contentID)
 }
 
-func (r *queryResolver) CheckIfUserBookmarkedContent(ctx context.Context, userID string, contentID int) (bool, error) {
-	return r.mycarehub.Content.CheckIfUserBookmarkedContent(ctx, userID, contentID)
+func (r *queryResolver) CheckIfUserBookmarkedContent(ctx context.Context, userID *string, contentID int) (bool, error) {
+	r.checkPreconditions()
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.Content.CheckIfUserBookmarkedContent(ctx, resolvedUserID, contentID)
 }
 
-// Mutation returns generated.MutationResolver implementation.
-func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
+func (r *queryResolver) SearchContent(ctx context.Context, query string, categoryIDs []int, itemType *string, language *enumutils.Language, paginationInput dto.PaginationsInput) (*domain.ContentSearchPage, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Content.SearchContent(ctx, query, categoryIDs, itemType, language, paginationInput)
+}
 
-// Query returns generated.QueryResolver implementation.
-func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
+func (r *queryResolver) RecommendedContent(ctx context.Context, userID *string, limit *int) (*domain.Content, error) {
+	r.checkPreconditions()
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.mycarehub.Content.RecommendedContent(ctx, resolvedUserID, limit)
+}
 
-type mutationResolver struct{ *Resolver }
-type queryResolver struct{ *Resolver }
+func (r *queryResolver) ContentAnalytics(ctx context.Context, input dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Content.GetContentAnalytics(ctx, input)
+}
+
+func (r *queryResolver) ExportContentAnalytics(ctx context.Context, input dto.ContentAnalyticsInput) (string, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Content.ExportContentAnalytics(ctx, input)
+}
+
+func (r *queryResolver) ContinueReading(ctx context.Context, userID *string, limit *int) ([]*domain.ContentProgress, error) {
+	r.checkPreconditions()
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.mycarehub.Content.ContinueReading(ctx, resolvedUserID, limit)
+}
+
+func (r *queryResolver) ContentComments(ctx context.Context, userID *string, contentID int) ([]*domain.ContentComment, error) {
+	r.checkPreconditions()
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.mycarehub.Content.ListContentComments(ctx, resolvedUserID, contentID)
+}
+
+func (r *queryResolver) ContentCommentModerationQueue(ctx context.Context, status *enums.ContentCommentStatus, limit *int) ([]*domain.ContentComment, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Content.ContentCommentModerationQueue(ctx, status, limit)
+}
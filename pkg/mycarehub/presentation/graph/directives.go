@@ -0,0 +1,18 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+)
+
+// HasPermission implements the `@hasPermission` directive. The field is only resolved when one of the
+// logged in user's roles grants the permission.
+func (r *Resolver) HasPermission(ctx context.Context, obj interface{}, next graphql.Resolver, permission enums.PermissionType) (interface{}, error) {
+	r.checkPreconditions()
+	if _, err := r.mycarehub.Authority.CheckUserPermission(ctx, permission); err != nil {
+		return nil, err
+	}
+	return next(ctx)
+}
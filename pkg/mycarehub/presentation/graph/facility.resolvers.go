@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 )
 
@@ -30,8 +31,44 @@ func (r *mutationResolver) InactivateFacility(ctx context.Context, mflCode int)
 	return r.mycarehub.Facility.InactivateFacility(ctx, &mflCode)
 }
 
+func (r *mutationResolver) UpdateFacility(ctx context.Context, mflCode int, input dto.FacilityUpdateInput) (*domain.Facility, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Facility.UpdateFacility(ctx, mflCode, input)
+}
+
+func (r *mutationResolver) ImportFacilities(ctx context.Context, content string, format enums.FacilityImportFormat, dryRun bool) (*domain.FacilityImportReport, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Facility.ImportFacilities(ctx, content, format, dryRun)
+}
+
+func (r *mutationResolver) SetFacilityOpeningHours(ctx context.Context, mflCode int, openingHours []*dto.FacilityOpeningHoursInput) (bool, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Facility.SetFacilityOpeningHours(ctx, mflCode, openingHours)
+}
+
+func (r *mutationResolver) SetFacilityHoursException(ctx context.Context, mflCode int, input dto.FacilityHoursExceptionInput) (bool, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Facility.SetFacilityHoursException(ctx, mflCode, input)
+}
+
+func (r *mutationResolver) DeleteFacilityHoursException(ctx context.Context, mflCode int, date string) (bool, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Facility.DeleteFacilityHoursException(ctx, mflCode, date)
+}
+
+func (r *mutationResolver) CreateFacilityService(ctx context.Context, input dto.FacilityServiceInput) (*domain.FacilityService, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Facility.CreateFacilityService(ctx, input)
+}
+
+func (r *mutationResolver) SetFacilityServices(ctx context.Context, mflCode int, serviceCodes []string) (bool, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Facility.SetFacilityServices(ctx, mflCode, serviceCodes)
+}
+
 func (r *queryResolver) FetchFacilities(ctx context.Context) ([]*domain.Facility, error) {
 	r.checkPreconditions()
+	//lint:ignore SA1019 the query is deprecated in the schema and is kept until clients move to listFacilities
 	return r.mycarehub.Facility.FetchFacilities(ctx)
 }
 
@@ -45,6 +82,21 @@ func (r *queryResolver) RetrieveFacilityByMFLCode(ctx context.Context, mflCode i
 	return r.mycarehub.Facility.RetrieveFacilityByMFLCode(ctx, mflCode, isActive)
 }
 
-func (r *queryResolver) ListFacilities(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, paginationInput dto.PaginationsInput) (*domain.FacilityPage, error) {
-	return r.mycarehub.Facility.ListFacilities(ctx, searchTerm, filterInput, &paginationInput)
+func (r *queryResolver) FacilityHistory(ctx context.Context, mflCode int) ([]*domain.FacilityHistory, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Facility.GetFacilityHistory(ctx, mflCode)
+}
+
+func (r *queryResolver) ListFacilities(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, filterGroups []*dto.FilterGroupInput, paginationInput dto.PaginationsInput) (*domain.FacilityPage, error) {
+	return r.mycarehub.Facility.ListFacilities(ctx, searchTerm, filterInput, filterGroups, &paginationInput)
+}
+
+func (r *queryResolver) NearbyFacilities(ctx context.Context, lat float64, lng float64, radiusKm float64, limit *int, filterInput []*dto.FiltersInput, sort *dto.SortsInput) ([]*domain.NearbyFacility, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Facility.NearbyFacilities(ctx, lat, lng, radiusKm, limit, filterInput, sort)
+}
+
+func (r *queryResolver) ListFacilityServices(ctx context.Context) ([]*domain.FacilityService, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Facility.ListFacilityServices(ctx)
 }
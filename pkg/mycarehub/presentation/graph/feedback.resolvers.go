@@ -12,5 +12,10 @@ import (
 func (r *mutationResolver) SendFeedback(ctx context.Context, input dto.FeedbackResponseInput) (bool, error) {
 	r.checkPreconditions()
 
+	userID, err := r.mycarehub.Authority.ResolveUserID(ctx, &input.UserID)
+	if err != nil {
+		return false, err
+	}
+	input.UserID = userID
 	return r.mycarehub.Feedback.SendFeedback(ctx, &input)
 }
@@ -6,16 +6,26 @@ package graph
 import (
 	"context"
 
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 )
 
-func (r *mutationResolver) CreateHealthDiaryEntry(ctx context.Context, clientID string, note *string, mood string, reportToStaff bool) (bool, error) {
+func (r *mutationResolver) CreateHealthDiaryEntry(ctx context.Context, clientID *string, note *string, mood string, reportToStaff bool) (bool, error) {
 	r.checkPreconditions()
-	return r.mycarehub.HealthDiary.CreateHealthDiaryEntry(ctx, clientID, note, mood, reportToStaff)
+	resolvedClientID, err := r.mycarehub.Authority.ResolveClientID(ctx, clientID, enums.PermissionTypeCanViewClientHealthDiary)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.HealthDiary.CreateHealthDiaryEntry(ctx, resolvedClientID, note, mood, reportToStaff)
 }
 
-func (r *queryResolver) CanRecordMood(ctx context.Context, clientID string) (bool, error) {
-	return r.mycarehub.HealthDiary.CanRecordHeathDiary(ctx, clientID)
+func (r *queryResolver) CanRecordMood(ctx context.Context, clientID *string) (bool, error) {
+	r.checkPreconditions()
+	resolvedClientID, err := r.mycarehub.Authority.ResolveClientID(ctx, clientID, enums.PermissionTypeCanViewClientHealthDiary)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.HealthDiary.CanRecordHeathDiary(ctx, resolvedClientID)
 }
 
 func (r *queryResolver) GetHealthDiaryQuote(ctx context.Context) (*domain.ClientHealthDiaryQuote, error) {
@@ -23,7 +33,11 @@ func (r *queryResolver) GetHealthDiaryQuote(ctx context.Context) (*domain.Client
 	return r.mycarehub.HealthDiary.GetClientHealthDiaryQuote(ctx)
 }
 
-func (r *queryResolver) GetClientHealthDiaryEntries(ctx context.Context, clientID string) ([]*domain.ClientHealthDiaryEntry, error) {
+func (r *queryResolver) GetClientHealthDiaryEntries(ctx context.Context, clientID *string) ([]*domain.ClientHealthDiaryEntry, error) {
 	r.checkPreconditions()
-	return r.mycarehub.HealthDiary.GetClientHealthDiaryEntries(ctx, clientID)
+	resolvedClientID, err := r.mycarehub.Authority.ResolveClientID(ctx, clientID, enums.PermissionTypeCanViewClientHealthDiary)
+	if err != nil {
+		return nil, err
+	}
+	return r.mycarehub.HealthDiary.GetClientHealthDiaryEntries(ctx, resolvedClientID)
 }
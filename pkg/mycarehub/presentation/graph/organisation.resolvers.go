@@ -0,0 +1,36 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+func (r *mutationResolver) CreateOrganisation(ctx context.Context, input dto.OrganisationInput) (*domain.Organisation, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Organisation.CreateOrganisation(ctx, input)
+}
+
+func (r *mutationResolver) UpdateOrganisation(ctx context.Context, organisationID string, input dto.OrganisationUpdateInput) (bool, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Organisation.UpdateOrganisation(ctx, organisationID, input)
+}
+
+func (r *mutationResolver) DeactivateOrganisation(ctx context.Context, organisationID string) (bool, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Organisation.DeactivateOrganisation(ctx, organisationID)
+}
+
+func (r *queryResolver) ListOrganisations(ctx context.Context) ([]*domain.Organisation, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Organisation.ListOrganisations(ctx)
+}
+
+func (r *queryResolver) GetOrganisation(ctx context.Context, organisationID string) (*domain.Organisation, error) {
+	r.checkPreconditions()
+	return r.mycarehub.Organisation.GetOrganisation(ctx, organisationID)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 )
 
 func (r *mutationResolver) InviteUser(ctx context.Context, userID string, phoneNumber string, flavour feedlib.Flavour) (bool, error) {
@@ -15,5 +16,41 @@ func (r *mutationResolver) InviteUser(ctx context.Context, userID string, phoneN
 }
 
 func (r *mutationResolver) SetUserPin(ctx context.Context, input *dto.PINInput) (bool, error) {
+	r.checkPreconditions()
+	userID, err := r.mycarehub.Authority.ResolveUserID(ctx, input.UserID)
+	if err != nil {
+		return false, err
+	}
+	input.UserID = &userID
 	return r.mycarehub.User.SetUserPIN(ctx, *input)
 }
+
+func (r *mutationResolver) BulkInviteUsers(ctx context.Context, csvContent string, flavour feedlib.Flavour) (string, error) {
+	r.checkPreconditions()
+	return r.mycarehub.User.BulkInviteUsers(ctx, csvContent, flavour)
+}
+
+func (r *mutationResolver) ResendInvite(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error) {
+	r.checkPreconditions()
+	return r.mycarehub.User.ResendInvite(ctx, userID, flavour)
+}
+
+func (r *mutationResolver) RegisterClient(ctx context.Context, input dto.ClientRegistrationInput) (*domain.ClientProfile, error) {
+	r.checkPreconditions()
+	return r.mycarehub.User.RegisterClient(ctx, input)
+}
+
+func (r *mutationResolver) RegisterStaff(ctx context.Context, input dto.StaffRegistrationInput) (*domain.StaffProfile, error) {
+	r.checkPreconditions()
+	return r.mycarehub.User.RegisterStaff(ctx, input)
+}
+
+func (r *queryResolver) GetBulkInviteJob(ctx context.Context, jobID string) (*domain.BulkInviteJob, error) {
+	r.checkPreconditions()
+	return r.mycarehub.User.GetBulkInviteJob(ctx, jobID)
+}
+
+func (r *queryResolver) ListPendingInvitations(ctx context.Context, facilityID string) ([]*domain.Invitation, error) {
+	r.checkPreconditions()
+	return r.mycarehub.User.ListPendingInvitations(ctx, facilityID)
+}
@@ -13,6 +13,13 @@ import (
 
 func (r *mutationResolver) RecordSecurityQuestionResponses(ctx context.Context, input []*dto.SecurityQuestionResponseInput) ([]*domain.RecordSecurityQuestionResponse, error) {
 	r.checkPreconditions()
+	for _, response := range input {
+		userID, err := r.mycarehub.Authority.ResolveUserID(ctx, &response.UserID)
+		if err != nil {
+			return nil, err
+		}
+		response.UserID = userID
+	}
 	return r.mycarehub.SecurityQuestions.RecordSecurityQuestionResponses(ctx, input)
 }
 
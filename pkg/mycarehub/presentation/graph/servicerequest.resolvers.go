@@ -5,9 +5,15 @@ package graph
 
 import (
 	"context"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 )
 
-func (r *mutationResolver) CreateServiceRequest(ctx context.Context, clientID string, requestType string, request *string) (bool, error) {
+func (r *mutationResolver) CreateServiceRequest(ctx context.Context, clientID *string, requestType string, request *string) (bool, error) {
 	r.checkPreconditions()
-	return r.mycarehub.ServiceRequest.CreateServiceRequest(ctx, clientID, requestType, *request)
+	resolvedClientID, err := r.mycarehub.Authority.ResolveClientID(ctx, clientID, enums.PermissionTypeCanActOnBehalfOfClient)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.ServiceRequest.CreateServiceRequest(ctx, resolvedClientID, requestType, *request)
 }
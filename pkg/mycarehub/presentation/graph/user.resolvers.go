@@ -6,23 +6,45 @@ package graph
 import (
 	"context"
 
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 )
 
-func (r *mutationResolver) AcceptTerms(ctx context.Context, userID string, termsID int) (bool, error) {
+func (r *mutationResolver) AcceptTerms(ctx context.Context, userID *string, termsID int) (bool, error) {
 	r.checkPreconditions()
-	return r.mycarehub.Terms.AcceptTerms(ctx, &userID, &termsID)
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.Terms.AcceptTerms(ctx, &resolvedUserID, &termsID)
 }
 
-func (r *mutationResolver) SetNickName(ctx context.Context, userID string, nickname string) (bool, error) {
+func (r *mutationResolver) SetNickName(ctx context.Context, userID *string, nickname string) (bool, error) {
 	r.checkPreconditions()
-	return r.mycarehub.User.SetNickName(ctx, &userID, &nickname)
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.User.SetNickName(ctx, &resolvedUserID, &nickname)
 }
 
-func (r *mutationResolver) CompleteOnboardingTour(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error) {
+func (r *mutationResolver) SetUserPreferredLanguage(ctx context.Context, userID *string, language enumutils.Language) (bool, error) {
 	r.checkPreconditions()
-	return r.mycarehub.User.CompleteOnboardingTour(ctx, userID, flavour)
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.User.SetUserPreferredLanguage(ctx, resolvedUserID, language)
+}
+
+func (r *mutationResolver) CompleteOnboardingTour(ctx context.Context, userID *string, flavour feedlib.Flavour) (bool, error) {
+	r.checkPreconditions()
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.User.CompleteOnboardingTour(ctx, resolvedUserID, flavour)
 }
 
 func (r *queryResolver) GetCurrentTerms(ctx context.Context) (*domain.TermsOfService, error) {
@@ -30,6 +52,11 @@ func (r *queryResolver) GetCurrentTerms(ctx context.Context) (*domain.TermsOfSer
 	return r.mycarehub.Terms.GetCurrentTerms(ctx)
 }
 
-func (r *queryResolver) VerifyPin(ctx context.Context, userID string, flavour feedlib.Flavour, pin string) (bool, error) {
-	return r.mycarehub.User.VerifyPIN(ctx, userID, flavour, pin)
+func (r *queryResolver) VerifyPin(ctx context.Context, userID *string, flavour feedlib.Flavour, pin string) (bool, error) {
+	r.checkPreconditions()
+	resolvedUserID, err := r.mycarehub.Authority.ResolveUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return r.mycarehub.User.VerifyPIN(ctx, resolvedUserID, flavour, pin)
 }
@@ -1,12 +1,14 @@
 package rest
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"net/http"
 
 	"github.com/savannahghi/errorcodeutil"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases"
 	"github.com/savannahghi/serverutils"
 )
@@ -23,6 +25,8 @@ type MyCareHubHandlersInterfaces interface {
 	GetUserRespondedSecurityQuestions() http.HandlerFunc
 	ResetPIN() http.HandlerFunc
 	RefreshToken() http.HandlerFunc
+	ContentWebhook() http.HandlerFunc
+	OpenSharedContent() http.HandlerFunc
 }
 
 // MyCareHubHandlersInterfacesImpl represents the usecase implementation object
@@ -371,3 +375,71 @@ func (h *MyCareHubHandlersInterfacesImpl) RefreshToken() http.HandlerFunc {
 		serverutils.WriteJSONResponse(w, response, http.StatusOK)
 	}
 }
+
+// ContentWebhook is called by the CMS when a content page is published, unpublished or deleted so that
+// the cached copies of that content are dropped. The CMS authenticates using the same token we use to call it
+func (h *MyCareHubHandlersInterfacesImpl) ContentWebhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		expected := "Token " + serverutils.MustGetEnvVar(utils.DjangoAuthorizationToken)
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(expected)) != 1 {
+			err := fmt.Errorf("invalid authorization token")
+			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
+				Message: err.Error(),
+			}, http.StatusUnauthorized)
+			return
+		}
+
+		payload := &dto.ContentWebhookPayload{}
+		serverutils.DecodeJSONToTargetStruct(w, r, payload)
+		if payload.ContentID <= 0 {
+			err := fmt.Errorf("expected `contentID` to be defined")
+			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.usecase.Content.InvalidateContentCache(ctx, payload.ContentID)
+		if err != nil {
+			serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusBadRequest)
+			return
+		}
+
+		response := helpers.RestAPIResponseHelper("invalidateContentCache", resp)
+		serverutils.WriteJSONResponse(w, response, http.StatusOK)
+	}
+}
+
+// OpenSharedContent is called by the public web view when someone opens a shared content link so that the open
+// is attributed to the user who shared it. The person opening the link may not have an account hence the route
+// is unauthenticated and the signed token is what is trusted. Opening the same link again from the same address
+// is only recorded once within a window
+func (h *MyCareHubHandlersInterfacesImpl) OpenSharedContent() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		payload := &dto.OpenSharedContentPayload{}
+		serverutils.DecodeJSONToTargetStruct(w, r, payload)
+		if payload.Token == "" {
+			err := fmt.Errorf("expected `token` to be defined")
+			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+
+		contentID, err := h.usecase.Content.OpenSharedContent(ctx, payload.Token, nil, clientAddress(r))
+		if err != nil {
+			serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusBadRequest)
+			return
+		}
+
+		response := helpers.RestAPIResponseHelper("openSharedContent", contentID)
+		serverutils.WriteJSONResponse(w, response, http.StatusOK)
+	}
+}
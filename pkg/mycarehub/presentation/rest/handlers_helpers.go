@@ -1 +1,22 @@
 package rest
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// clientAddress returns the address of the client that made the request. Behind a load balancer it is the last
+// address in the `X-Forwarded-For` header since that is the one the load balancer added, while the earlier ones
+// are sent by the client and can't be trusted.
+func clientAddress(r *http.Request) string {
+	if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+		addresses := strings.Split(forwardedFor, ",")
+		return strings.TrimSpace(addresses[len(addresses)-1])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
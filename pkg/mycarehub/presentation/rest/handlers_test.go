@@ -13,6 +13,8 @@ import (
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/serverutils"
 )
 
 func createSendOTPPayload(phonenumber string, flavour feedlib.Flavour) []byte {
@@ -995,3 +997,201 @@ func TestMyCareHubHandlersInterfacesImpl_RefreshToken(t *testing.T) {
 		})
 	}
 }
+
+func TestMyCareHubHandlersInterfacesImpl_ContentWebhook(t *testing.T) {
+	validPayload, err := json.Marshal(&dto.ContentWebhookPayload{ContentID: 1})
+	if err != nil {
+		t.Errorf("failed to marshal payload")
+		return
+	}
+	invalidPayload, err := json.Marshal(&dto.ContentWebhookPayload{})
+	if err != nil {
+		t.Errorf("failed to marshal payload")
+		return
+	}
+	token := "Token " + serverutils.MustGetEnvVar(utils.DjangoAuthorizationToken)
+
+	type args struct {
+		url        string
+		httpMethod string
+		body       io.Reader
+		token      string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name: "Happy Case - Invalidate content",
+			args: args{
+				url:        fmt.Sprintf("%s/content_webhook", baseURL),
+				httpMethod: http.MethodPost,
+				body:       bytes.NewBuffer(validPayload),
+				token:      token,
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name: "Sad Case - Missing content ID",
+			args: args{
+				url:        fmt.Sprintf("%s/content_webhook", baseURL),
+				httpMethod: http.MethodPost,
+				body:       bytes.NewBuffer(invalidPayload),
+				token:      token,
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name: "Sad Case - Invalid token",
+			args: args{
+				url:        fmt.Sprintf("%s/content_webhook", baseURL),
+				httpMethod: http.MethodPost,
+				body:       bytes.NewBuffer(validPayload),
+				token:      "Token invalid",
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := http.NewRequest(
+				tt.args.httpMethod,
+				tt.args.url,
+				tt.args.body,
+			)
+			if err != nil {
+				t.Errorf("unable to compose request: %s", err)
+				return
+			}
+
+			r.Header.Add("Accept", "application/json")
+			r.Header.Add("Content-Type", "application/json")
+			r.Header.Add("Authorization", tt.args.token)
+
+			client := http.DefaultClient
+			resp, err := client.Do(r)
+			if err != nil {
+				t.Errorf("request error: %s", err)
+				return
+			}
+
+			dataResponse, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Errorf("can't read request body: %s", err)
+				return
+			}
+
+			data := map[string]interface{}{}
+			err = json.Unmarshal(dataResponse, &data)
+			if err != nil {
+				t.Errorf("bad data returned: %v", err)
+				return
+			}
+
+			if tt.wantErr {
+				if _, ok := data["error"]; !ok {
+					t.Errorf("expected an error in the response")
+					return
+				}
+			}
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("expected status %d, got %s", tt.wantStatus, resp.Status)
+				return
+			}
+		})
+	}
+}
+
+func TestMyCareHubHandlersInterfacesImpl_OpenSharedContent(t *testing.T) {
+	invalidTokenPayload, err := json.Marshal(&dto.OpenSharedContentPayload{Token: "invalid.token"})
+	if err != nil {
+		t.Errorf("failed to marshal payload")
+		return
+	}
+	missingTokenPayload, err := json.Marshal(&dto.OpenSharedContentPayload{})
+	if err != nil {
+		t.Errorf("failed to marshal payload")
+		return
+	}
+
+	type args struct {
+		url        string
+		httpMethod string
+		body       io.Reader
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+	}{
+		{
+			name: "Sad Case - Missing token",
+			args: args{
+				url:        fmt.Sprintf("%s/open_shared_content", baseURL),
+				httpMethod: http.MethodPost,
+				body:       bytes.NewBuffer(missingTokenPayload),
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Sad Case - Invalid token",
+			args: args{
+				url:        fmt.Sprintf("%s/open_shared_content", baseURL),
+				httpMethod: http.MethodPost,
+				body:       bytes.NewBuffer(invalidTokenPayload),
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := http.NewRequest(
+				tt.args.httpMethod,
+				tt.args.url,
+				tt.args.body,
+			)
+			if err != nil {
+				t.Errorf("unable to compose request: %s", err)
+				return
+			}
+
+			r.Header.Add("Accept", "application/json")
+			r.Header.Add("Content-Type", "application/json")
+
+			client := http.DefaultClient
+			resp, err := client.Do(r)
+			if err != nil {
+				t.Errorf("request error: %s", err)
+				return
+			}
+
+			dataResponse, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Errorf("can't read request body: %s", err)
+				return
+			}
+
+			data := map[string]interface{}{}
+			err = json.Unmarshal(dataResponse, &data)
+			if err != nil {
+				t.Errorf("bad data returned: %v", err)
+				return
+			}
+			if _, ok := data["error"]; !ok {
+				t.Errorf("expected an error in the response")
+				return
+			}
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("expected status %d, got %s", tt.wantStatus, resp.Status)
+				return
+			}
+		})
+	}
+}
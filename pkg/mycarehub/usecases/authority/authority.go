@@ -0,0 +1,356 @@
+package authority
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
+	log "github.com/sirupsen/logrus"
+)
+
+// Users are given roles e.g CLINICIAN or FACILITY_ADMIN and every role grants a fixed set of permissions.
+// Operations that need a permission check that at least one of the logged in user's roles grants it.
+// Operations on a client's records additionally check that the logged in user is the client or a staff member
+// who can access the client's facility.
+
+// ICheckPermission checks whether the logged in user has been granted a permission
+type ICheckPermission interface {
+	CheckUserPermission(ctx context.Context, permission enums.PermissionType) (bool, error)
+}
+
+// ICheckClientAccess checks whether the logged in user can act on a client's records
+type ICheckClientAccess interface {
+	CheckClientAccess(ctx context.Context, clientID string, permission enums.PermissionType) (bool, error)
+}
+
+// ICheckFacilityAccess checks whether the logged in user can act on the records of a facility
+type ICheckFacilityAccess interface {
+	CheckFacilityAccess(ctx context.Context, facilityID string, permission enums.PermissionType) (bool, error)
+}
+
+// IManageRoles contains the methods used to manage the roles assigned to users
+type IManageRoles interface {
+	AssignRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error)
+	RevokeRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error)
+	GetUserRoles(ctx context.Context, userID string) ([]enums.UserRoleType, error)
+}
+
+// IResolveIdentity maps the logged in user to their mycarehub identity. It also resolves the user or client
+// that an operation acts on: the logged in user by default, or the supplied ID when staff act on behalf of a client.
+type IResolveIdentity interface {
+	ResolveIdentity(ctx context.Context) (*domain.Identity, error)
+	ResolveUserID(ctx context.Context, userID *string) (string, error)
+	ResolveClientID(ctx context.Context, clientID *string, permission enums.PermissionType) (string, error)
+}
+
+// UseCasesAuthority groups all the interfaces of the authority usecase
+type UseCasesAuthority interface {
+	ICheckPermission
+	ICheckClientAccess
+	ICheckFacilityAccess
+	IManageRoles
+	IResolveIdentity
+}
+
+// UseCasesAuthorityImpl represents the authority implementation
+type UseCasesAuthorityImpl struct {
+	Create      infrastructure.Create
+	Query       infrastructure.Query
+	Delete      infrastructure.Delete
+	ExternalExt extension.ExternalMethodsExtension
+}
+
+// NewUseCasesAuthority is the controller function for the authority usecase
+func NewUseCasesAuthority(
+	create infrastructure.Create,
+	query infrastructure.Query,
+	delete infrastructure.Delete,
+	externalExt extension.ExternalMethodsExtension,
+) *UseCasesAuthorityImpl {
+	return &UseCasesAuthorityImpl{
+		Create:      create,
+		Query:       query,
+		Delete:      delete,
+		ExternalExt: externalExt,
+	}
+}
+
+// getLoggedInUserRoles returns the ID and the roles of the logged in user
+func (u *UseCasesAuthorityImpl) getLoggedInUserRoles(ctx context.Context) (string, []enums.UserRoleType, error) {
+	uid, err := u.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		return "", nil, exceptions.UnauthorizedErr(fmt.Errorf("failed to get logged in user: %v", err))
+	}
+
+	roles, err := u.Query.GetUserRoles(ctx, uid)
+	if err != nil {
+		return "", nil, exceptions.InternalErr(fmt.Errorf("failed to get roles of user %s: %v", uid, err))
+	}
+	return uid, roles, nil
+}
+
+// hasPermission returns true if any of the roles grants the permission
+func hasPermission(roles []enums.UserRoleType, permission enums.PermissionType) bool {
+	for _, role := range roles {
+		if role.HasPermission(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckUserPermission returns an unauthorized error if none of the logged in user's roles grants the permission
+func (u *UseCasesAuthorityImpl) CheckUserPermission(ctx context.Context, permission enums.PermissionType) (bool, error) {
+	if !permission.IsValid() {
+		return false, exceptions.InputValidationErr(fmt.Errorf("invalid permission: %v", permission))
+	}
+
+	uid, roles, err := u.getLoggedInUserRoles(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if !hasPermission(roles, permission) {
+		return false, exceptions.UnauthorizedErr(fmt.Errorf("user %s does not have the %s permission", uid, permission))
+	}
+	return true, nil
+}
+
+// CheckClientAccess allows a client to act on their own records. Other users need the supplied permission and,
+// unless they are system admins, access to the facility that the client is assigned to.
+func (u *UseCasesAuthorityImpl) CheckClientAccess(ctx context.Context, clientID string, permission enums.PermissionType) (bool, error) {
+	if clientID == "" {
+		return false, exceptions.InputValidationErr(fmt.Errorf("clientID must be provided"))
+	}
+
+	uid, roles, err := u.getLoggedInUserRoles(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	client, err := u.Query.GetClientProfileByClientID(ctx, clientID)
+	if err != nil {
+		return false, exceptions.ItemNotFoundErr(fmt.Errorf("failed to get client %s: %v", clientID, err))
+	}
+	if client.UserID == uid {
+		return true, nil
+	}
+	return u.checkFacilityAccess(ctx, uid, roles, client.FacilityID, permission)
+}
+
+// CheckFacilityAccess requires the logged in user to have the supplied permission and, unless they are
+// system admins, access to the facility
+func (u *UseCasesAuthorityImpl) CheckFacilityAccess(ctx context.Context, facilityID string, permission enums.PermissionType) (bool, error) {
+	if facilityID == "" {
+		return false, exceptions.InputValidationErr(fmt.Errorf("facilityID must be provided"))
+	}
+
+	uid, roles, err := u.getLoggedInUserRoles(ctx)
+	if err != nil {
+		return false, err
+	}
+	return u.checkFacilityAccess(ctx, uid, roles, facilityID, permission)
+}
+
+// checkFacilityAccess returns an unauthorized error unless the roles grant the permission and the user is
+// a system admin or a staff member who can access the facility
+func (u *UseCasesAuthorityImpl) checkFacilityAccess(ctx context.Context, uid string, roles []enums.UserRoleType, facilityID string, permission enums.PermissionType) (bool, error) {
+	if !hasPermission(roles, permission) {
+		return false, exceptions.UnauthorizedErr(fmt.Errorf("user %s does not have the %s permission", uid, permission))
+	}
+	for _, role := range roles {
+		if role == enums.UserRoleTypeSystemAdmin {
+			return true, nil
+		}
+	}
+
+	staff, err := u.Query.GetStaffProfileByUserID(ctx, uid)
+	if err != nil {
+		return false, exceptions.UnauthorizedErr(fmt.Errorf("user %s is not a staff member: %v", uid, err))
+	}
+	for _, facility := range staff.Facilities {
+		if facility.ID != nil && *facility.ID == facilityID {
+			return true, nil
+		}
+	}
+	return false, exceptions.UnauthorizedErr(fmt.Errorf("user %s cannot access facility %s", uid, facilityID))
+}
+
+// validateRoles ensures that a non empty list of valid roles has been supplied
+func validateRoles(userID string, roles []enums.UserRoleType) error {
+	if userID == "" || len(roles) == 0 {
+		return exceptions.InputValidationErr(fmt.Errorf("userID and roles must be provided"))
+	}
+	for _, role := range roles {
+		if !role.IsValid() {
+			return exceptions.InputValidationErr(fmt.Errorf("invalid role: %v", role))
+		}
+	}
+	return nil
+}
+
+// AssignRoles gives a user the supplied roles. Only users who can manage roles are allowed to do this.
+func (u *UseCasesAuthorityImpl) AssignRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+	if err := validateRoles(userID, roles); err != nil {
+		return false, err
+	}
+	if _, err := u.CheckUserPermission(ctx, enums.PermissionTypeCanManageRoles); err != nil {
+		return false, err
+	}
+
+	ok, err := u.Create.AssignRoles(ctx, userID, roles)
+	if err != nil {
+		return false, exceptions.FailedToSaveItemErr(fmt.Errorf("failed to assign roles: %v", err))
+	}
+	return ok, nil
+}
+
+// RevokeRoles removes the supplied roles from a user. Only users who can manage roles are allowed to do this.
+func (u *UseCasesAuthorityImpl) RevokeRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+	if err := validateRoles(userID, roles); err != nil {
+		return false, err
+	}
+	if _, err := u.CheckUserPermission(ctx, enums.PermissionTypeCanManageRoles); err != nil {
+		return false, err
+	}
+
+	ok, err := u.Delete.RevokeRoles(ctx, userID, roles)
+	if err != nil {
+		return false, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to revoke roles: %v", err))
+	}
+	return ok, nil
+}
+
+// GetUserRoles returns the roles of a user. Users can read their own roles while
+// reading another user's roles requires the permission to manage roles.
+func (u *UseCasesAuthorityImpl) GetUserRoles(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+	if userID == "" {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("userID must be provided"))
+	}
+
+	uid, roles, err := u.getLoggedInUserRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if uid == userID {
+		return roles, nil
+	}
+	if !hasPermission(roles, enums.PermissionTypeCanManageRoles) {
+		return nil, exceptions.UnauthorizedErr(fmt.Errorf("user %s cannot read the roles of other users", uid))
+	}
+
+	userRoles, err := u.Query.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to get roles of user %s: %v", userID, err))
+	}
+	return userRoles, nil
+}
+
+// ResolveIdentity looks up the mycarehub user behind the logged in Firebase user together with their
+// client or staff profile. Firebase users are created with the mycarehub user ID as their UID.
+// A user who has not been given a profile yet still resolves, with an empty client or staff ID, so
+// operations that need a profile must check for it.
+func (u *UseCasesAuthorityImpl) ResolveIdentity(ctx context.Context) (*domain.Identity, error) {
+	uid, err := u.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		return nil, exceptions.UnauthorizedErr(fmt.Errorf("failed to get logged in user: %v", err))
+	}
+
+	// the user's organisation is not known until their profile has been read
+	ctx = helpers.ContextForAllOrganisations(ctx)
+
+	user, err := u.Query.GetUserProfileByUserID(ctx, uid)
+	if err != nil {
+		return nil, exceptions.UserNotFoundError(fmt.Errorf("failed to get user profile of %s: %v", uid, err))
+	}
+
+	identity := &domain.Identity{
+		UID:            uid,
+		UserID:         *user.ID,
+		UserType:       user.UserType,
+		OrganisationID: user.OrganisationID,
+	}
+
+	switch user.UserType {
+	case enums.ClientUser:
+		client, err := u.Query.GetClientProfileByUserID(ctx, identity.UserID)
+		if err != nil {
+			log.Warnf("failed to get client profile of %s: %v", uid, err)
+			break
+		}
+		identity.ClientID = *client.ID
+	case enums.HealthcareWorkerUser:
+		staff, err := u.Query.GetStaffProfileByUserID(ctx, identity.UserID)
+		if err != nil {
+			log.Warnf("failed to get staff profile of %s: %v", uid, err)
+			break
+		}
+		identity.StaffID = *staff.ID
+	}
+	return identity, nil
+}
+
+// getIdentity returns the identity placed on the context by the identity middleware and resolves it
+// when the middleware did not run
+func (u *UseCasesAuthorityImpl) getIdentity(ctx context.Context) (*domain.Identity, error) {
+	identity, err := helpers.GetIdentityFromContext(ctx)
+	if err == nil {
+		return identity, nil
+	}
+	return u.ResolveIdentity(ctx)
+}
+
+// ResolveUserID returns the ID of the user that an operation acts on. It is the logged in user unless a
+// staff member passes the user ID of a client whose facility they can access.
+func (u *UseCasesAuthorityImpl) ResolveUserID(ctx context.Context, userID *string) (string, error) {
+	identity, err := u.getIdentity(ctx)
+	if err != nil {
+		return "", err
+	}
+	if userID == nil || *userID == "" || *userID == identity.UserID {
+		return identity.UserID, nil
+	}
+
+	if identity.StaffID == "" {
+		return "", exceptions.UnauthorizedErr(fmt.Errorf("user %s cannot act on behalf of user %s", identity.UserID, *userID))
+	}
+	client, err := u.Query.GetClientProfileByUserID(ctx, *userID)
+	if err != nil {
+		return "", exceptions.UnauthorizedErr(fmt.Errorf("staff can only act on behalf of clients: %v", err))
+	}
+	if _, err := u.CheckClientAccess(ctx, *client.ID, enums.PermissionTypeCanActOnBehalfOfClient); err != nil {
+		return "", err
+	}
+	return *userID, nil
+}
+
+// ResolveClientID returns the ID of the client that an operation acts on. Clients always act on their own
+// records while staff must pass the client ID and have the permission to act on the client's records.
+func (u *UseCasesAuthorityImpl) ResolveClientID(ctx context.Context, clientID *string, permission enums.PermissionType) (string, error) {
+	identity, err := u.getIdentity(ctx)
+	if err != nil {
+		return "", err
+	}
+	if clientID == nil || *clientID == "" {
+		if identity.ClientID == "" {
+			return "", exceptions.InputValidationErr(fmt.Errorf("clientID must be provided when acting on behalf of a client"))
+		}
+		return identity.ClientID, nil
+	}
+	if *clientID == identity.ClientID {
+		return identity.ClientID, nil
+	}
+
+	if identity.StaffID == "" {
+		return "", exceptions.UnauthorizedErr(fmt.Errorf("user %s cannot act on behalf of client %s", identity.UserID, *clientID))
+	}
+	if _, err := u.CheckClientAccess(ctx, *clientID, permission); err != nil {
+		return "", err
+	}
+	return *clientID, nil
+}
@@ -0,0 +1,1051 @@
+package authority_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority"
+)
+
+func TestUseCasesAuthorityImpl_CheckUserPermission(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx        context.Context
+		permission enums.PermissionType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				permission: enums.PermissionTypeCanInviteUser,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - role does not grant permission",
+			args: args{
+				ctx:        ctx,
+				permission: enums.PermissionTypeCanManageFacility,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid permission",
+			args: args{
+				ctx:        ctx,
+				permission: enums.PermissionType("invalid"),
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to get logged in user",
+			args: args{
+				ctx:        ctx,
+				permission: enums.PermissionTypeCanInviteUser,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to get user roles",
+			args: args{
+				ctx:        ctx,
+				permission: enums.PermissionTypeCanInviteUser,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			u := authority.NewUseCasesAuthority(fakeDB, fakeDB, fakeDB, fakeExtension)
+
+			if tt.name == "Sad case - fail to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("failed to get logged in user")
+				}
+			}
+			if tt.name == "Sad case - fail to get user roles" {
+				fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+					return nil, fmt.Errorf("failed to get user roles")
+				}
+			}
+
+			got, err := u.CheckUserPermission(tt.args.ctx, tt.args.permission)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesAuthorityImpl.CheckUserPermission() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesAuthorityImpl.CheckUserPermission() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesAuthorityImpl_CheckClientAccess(t *testing.T) {
+	ctx := context.Background()
+
+	loggedInUserID := uuid.New().String()
+	clientID := uuid.New().String()
+	facilityID := uuid.New().String()
+	otherFacilityID := uuid.New().String()
+
+	type args struct {
+		ctx        context.Context
+		clientID   string
+		permission enums.PermissionType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case - staff can access the client's facility",
+			args: args{
+				ctx:        ctx,
+				clientID:   clientID,
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - client owns the records",
+			args: args{
+				ctx:        ctx,
+				clientID:   clientID,
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - system admin",
+			args: args{
+				ctx:        ctx,
+				clientID:   clientID,
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - staff cannot access the client's facility",
+			args: args{
+				ctx:        ctx,
+				clientID:   clientID,
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - another client",
+			args: args{
+				ctx:        ctx,
+				clientID:   clientID,
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - user is not staff",
+			args: args{
+				ctx:        ctx,
+				clientID:   clientID,
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - client not found",
+			args: args{
+				ctx:        ctx,
+				clientID:   clientID,
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no clientID",
+			args: args{
+				ctx:        ctx,
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to get logged in user",
+			args: args{
+				ctx:        ctx,
+				clientID:   clientID,
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			u := authority.NewUseCasesAuthority(fakeDB, fakeDB, fakeDB, fakeExtension)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return loggedInUserID, nil
+			}
+			fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+				return &domain.ClientProfile{
+					ID:         &clientID,
+					UserID:     uuid.New().String(),
+					FacilityID: facilityID,
+				}, nil
+			}
+			fakeDB.MockGetStaffProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{
+					UserID:     userID,
+					Facilities: []*domain.Facility{{ID: &facilityID}},
+				}, nil
+			}
+
+			if tt.name == "Happy case - client owns the records" {
+				fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+					return []enums.UserRoleType{enums.UserRoleTypeClient}, nil
+				}
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+					return &domain.ClientProfile{
+						ID:         &clientID,
+						UserID:     loggedInUserID,
+						FacilityID: facilityID,
+					}, nil
+				}
+			}
+			if tt.name == "Happy case - system admin" {
+				fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+					return []enums.UserRoleType{enums.UserRoleTypeSystemAdmin}, nil
+				}
+				fakeDB.MockGetStaffProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("staff not found")
+				}
+			}
+			if tt.name == "Sad case - staff cannot access the client's facility" {
+				fakeDB.MockGetStaffProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{
+						UserID:     userID,
+						Facilities: []*domain.Facility{{ID: &otherFacilityID}},
+					}, nil
+				}
+			}
+			if tt.name == "Sad case - another client" {
+				fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+					return []enums.UserRoleType{enums.UserRoleTypeClient}, nil
+				}
+			}
+			if tt.name == "Sad case - user is not staff" {
+				fakeDB.MockGetStaffProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("staff not found")
+				}
+			}
+			if tt.name == "Sad case - client not found" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("client not found")
+				}
+			}
+			if tt.name == "Sad case - fail to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("failed to get logged in user")
+				}
+			}
+
+			got, err := u.CheckClientAccess(tt.args.ctx, tt.args.clientID, tt.args.permission)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesAuthorityImpl.CheckClientAccess() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesAuthorityImpl.CheckClientAccess() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesAuthorityImpl_CheckFacilityAccess(t *testing.T) {
+	ctx := context.Background()
+
+	facilityID := uuid.New().String()
+	otherFacilityID := uuid.New().String()
+
+	type args struct {
+		ctx        context.Context
+		facilityID string
+		permission enums.PermissionType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case - staff can access the facility",
+			args: args{
+				ctx:        ctx,
+				facilityID: facilityID,
+				permission: enums.PermissionTypeCanTransferClient,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - system admin",
+			args: args{
+				ctx:        ctx,
+				facilityID: otherFacilityID,
+				permission: enums.PermissionTypeCanTransferClient,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - staff cannot access the facility",
+			args: args{
+				ctx:        ctx,
+				facilityID: otherFacilityID,
+				permission: enums.PermissionTypeCanTransferClient,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - missing permission",
+			args: args{
+				ctx:        ctx,
+				facilityID: facilityID,
+				permission: enums.PermissionTypeCanTransferClient,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no facilityID",
+			args: args{
+				ctx:        ctx,
+				permission: enums.PermissionTypeCanTransferClient,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to get logged in user",
+			args: args{
+				ctx:        ctx,
+				facilityID: facilityID,
+				permission: enums.PermissionTypeCanTransferClient,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			u := authority.NewUseCasesAuthority(fakeDB, fakeDB, fakeDB, fakeExtension)
+
+			fakeDB.MockGetStaffProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{
+					UserID:     userID,
+					Facilities: []*domain.Facility{{ID: &facilityID}},
+				}, nil
+			}
+
+			if tt.name == "Happy case - system admin" {
+				fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+					return []enums.UserRoleType{enums.UserRoleTypeSystemAdmin}, nil
+				}
+			}
+			if tt.name == "Sad case - missing permission" {
+				fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+					return []enums.UserRoleType{enums.UserRoleTypeCHV}, nil
+				}
+			}
+			if tt.name == "Sad case - fail to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("failed to get logged in user")
+				}
+			}
+
+			got, err := u.CheckFacilityAccess(tt.args.ctx, tt.args.facilityID, tt.args.permission)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesAuthorityImpl.CheckFacilityAccess() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesAuthorityImpl.CheckFacilityAccess() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesAuthorityImpl_AssignRoles(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		roles  []enums.UserRoleType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				roles:  []enums.UserRoleType{enums.UserRoleTypeFacilityAdmin},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - cannot manage roles",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				roles:  []enums.UserRoleType{enums.UserRoleTypeFacilityAdmin},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid role",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				roles:  []enums.UserRoleType{"invalid"},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to assign roles",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				roles:  []enums.UserRoleType{enums.UserRoleTypeFacilityAdmin},
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			u := authority.NewUseCasesAuthority(fakeDB, fakeDB, fakeDB, fakeExtension)
+
+			fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+				return []enums.UserRoleType{enums.UserRoleTypeSystemAdmin}, nil
+			}
+
+			if tt.name == "Sad case - cannot manage roles" {
+				fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+					return []enums.UserRoleType{enums.UserRoleTypeFacilityAdmin}, nil
+				}
+			}
+			if tt.name == "Sad case - fail to assign roles" {
+				fakeDB.MockAssignRolesFn = func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+					return false, fmt.Errorf("failed to assign roles")
+				}
+			}
+
+			got, err := u.AssignRoles(tt.args.ctx, tt.args.userID, tt.args.roles)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesAuthorityImpl.AssignRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesAuthorityImpl.AssignRoles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesAuthorityImpl_RevokeRoles(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		roles  []enums.UserRoleType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				roles:  []enums.UserRoleType{enums.UserRoleTypeFacilityAdmin},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no roles",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - cannot manage roles",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				roles:  []enums.UserRoleType{enums.UserRoleTypeFacilityAdmin},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to revoke roles",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				roles:  []enums.UserRoleType{enums.UserRoleTypeFacilityAdmin},
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			u := authority.NewUseCasesAuthority(fakeDB, fakeDB, fakeDB, fakeExtension)
+
+			fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+				return []enums.UserRoleType{enums.UserRoleTypeSystemAdmin}, nil
+			}
+
+			if tt.name == "Sad case - cannot manage roles" {
+				fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+					return []enums.UserRoleType{enums.UserRoleTypeClinician}, nil
+				}
+			}
+			if tt.name == "Sad case - fail to revoke roles" {
+				fakeDB.MockRevokeRolesFn = func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+					return false, fmt.Errorf("failed to revoke roles")
+				}
+			}
+
+			got, err := u.RevokeRoles(tt.args.ctx, tt.args.userID, tt.args.roles)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesAuthorityImpl.RevokeRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesAuthorityImpl.RevokeRoles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesAuthorityImpl_GetUserRoles(t *testing.T) {
+	ctx := context.Background()
+
+	loggedInUserID := uuid.New().String()
+
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case - own roles",
+			args: args{
+				ctx:    ctx,
+				userID: loggedInUserID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - another user's roles",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - cannot read another user's roles",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to get another user's roles",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			u := authority.NewUseCasesAuthority(fakeDB, fakeDB, fakeDB, fakeExtension)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return loggedInUserID, nil
+			}
+
+			if tt.name == "Happy case - another user's roles" {
+				fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+					return []enums.UserRoleType{enums.UserRoleTypeSystemAdmin}, nil
+				}
+			}
+			if tt.name == "Sad case - fail to get another user's roles" {
+				fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+					if userID == loggedInUserID {
+						return []enums.UserRoleType{enums.UserRoleTypeSystemAdmin}, nil
+					}
+					return nil, fmt.Errorf("failed to get user roles")
+				}
+			}
+
+			got, err := u.GetUserRoles(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesAuthorityImpl.GetUserRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected user roles to be returned")
+			}
+		})
+	}
+}
+
+func TestUseCasesAuthorityImpl_ResolveIdentity(t *testing.T) {
+	ctx := context.Background()
+	organisationID := uuid.New().String()
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case - client",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - staff",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - fail to get logged in user",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - user not found",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Happy case - client profile not found",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - staff profile not found",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			u := authority.NewUseCasesAuthority(fakeDB, fakeDB, fakeDB, fakeExtension)
+
+			staffUser := func(ctx context.Context, userID string) (*domain.User, error) {
+				return &domain.User{
+					ID:             &userID,
+					UserType:       enums.HealthcareWorkerUser,
+					OrganisationID: organisationID,
+				}, nil
+			}
+
+			if tt.name == "Happy case - staff" {
+				fakeDB.MockGetUserProfileByUserIDFn = staffUser
+			}
+			if tt.name == "Sad case - fail to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("failed to get logged in user")
+				}
+			}
+			if tt.name == "Sad case - user not found" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("user not found")
+				}
+			}
+			if tt.name == "Happy case - client profile not found" {
+				fakeDB.MockGetClientProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("client not found")
+				}
+			}
+			if tt.name == "Happy case - staff profile not found" {
+				fakeDB.MockGetUserProfileByUserIDFn = staffUser
+				fakeDB.MockGetStaffProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("staff not found")
+				}
+			}
+
+			got, err := u.ResolveIdentity(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesAuthorityImpl.ResolveIdentity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.name == "Happy case - client" && (got.ClientID == "" || got.StaffID != "") {
+				t.Errorf("expected only the client ID to be set, got %v", got)
+			}
+			if tt.name == "Happy case - staff" && (got.StaffID == "" || got.ClientID != "") {
+				t.Errorf("expected only the staff ID to be set, got %v", got)
+			}
+			if strings.HasSuffix(tt.name, "profile not found") && (got.ClientID != "" || got.StaffID != "") {
+				t.Errorf("expected no profile IDs to be set, got %v", got)
+			}
+			if tt.name == "Happy case - staff" && got.OrganisationID != organisationID {
+				t.Errorf("expected the identity to belong to organisation %v, got %v", organisationID, got.OrganisationID)
+			}
+		})
+	}
+}
+
+func TestUseCasesAuthorityImpl_ResolveUserID(t *testing.T) {
+	clientIdentity := &domain.Identity{
+		UID:      uuid.New().String(),
+		UserID:   uuid.New().String(),
+		UserType: enums.ClientUser,
+		ClientID: uuid.New().String(),
+	}
+	staffIdentity := &domain.Identity{
+		UID:      uuid.New().String(),
+		UserID:   uuid.New().String(),
+		UserType: enums.HealthcareWorkerUser,
+		StaffID:  uuid.New().String(),
+	}
+	otherUserID := uuid.New().String()
+
+	type args struct {
+		ctx    context.Context
+		userID *string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Happy case - no userID passed",
+			args: args{
+				ctx: helpers.ContextWithIdentity(context.Background(), clientIdentity),
+			},
+			want:    clientIdentity.UserID,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - own userID passed",
+			args: args{
+				ctx:    helpers.ContextWithIdentity(context.Background(), clientIdentity),
+				userID: &clientIdentity.UserID,
+			},
+			want:    clientIdentity.UserID,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - staff acting on behalf of a client",
+			args: args{
+				ctx:    helpers.ContextWithIdentity(context.Background(), staffIdentity),
+				userID: &otherUserID,
+			},
+			want:    otherUserID,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - identity not on the context",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - client passes another user's ID",
+			args: args{
+				ctx:    helpers.ContextWithIdentity(context.Background(), clientIdentity),
+				userID: &otherUserID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - staff passes a user who is not a client",
+			args: args{
+				ctx:    helpers.ContextWithIdentity(context.Background(), staffIdentity),
+				userID: &otherUserID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - staff cannot access the client",
+			args: args{
+				ctx:    helpers.ContextWithIdentity(context.Background(), staffIdentity),
+				userID: &otherUserID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to resolve identity",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			u := authority.NewUseCasesAuthority(fakeDB, fakeDB, fakeDB, fakeExtension)
+
+			if tt.name == "Happy case - staff acting on behalf of a client" {
+				fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+					return []enums.UserRoleType{enums.UserRoleTypeSystemAdmin}, nil
+				}
+			}
+			if tt.name == "Sad case - staff passes a user who is not a client" {
+				fakeDB.MockGetClientProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("client not found")
+				}
+			}
+			if tt.name == "Sad case - staff cannot access the client" {
+				fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+					return []enums.UserRoleType{enums.UserRoleTypeClient}, nil
+				}
+			}
+			if tt.name == "Sad case - fail to resolve identity" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("failed to get logged in user")
+				}
+			}
+
+			got, err := u.ResolveUserID(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesAuthorityImpl.ResolveUserID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.want != "" && got != tt.want {
+				t.Errorf("UseCasesAuthorityImpl.ResolveUserID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesAuthorityImpl_ResolveClientID(t *testing.T) {
+	clientIdentity := &domain.Identity{
+		UID:      uuid.New().String(),
+		UserID:   uuid.New().String(),
+		UserType: enums.ClientUser,
+		ClientID: uuid.New().String(),
+	}
+	staffIdentity := &domain.Identity{
+		UID:      uuid.New().String(),
+		UserID:   uuid.New().String(),
+		UserType: enums.HealthcareWorkerUser,
+		StaffID:  uuid.New().String(),
+	}
+	otherClientID := uuid.New().String()
+
+	type args struct {
+		ctx        context.Context
+		clientID   *string
+		permission enums.PermissionType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Happy case - client without clientID",
+			args: args{
+				ctx:        helpers.ContextWithIdentity(context.Background(), clientIdentity),
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			want:    clientIdentity.ClientID,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - client passes own clientID",
+			args: args{
+				ctx:        helpers.ContextWithIdentity(context.Background(), clientIdentity),
+				clientID:   &clientIdentity.ClientID,
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			want:    clientIdentity.ClientID,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - staff passes clientID",
+			args: args{
+				ctx:        helpers.ContextWithIdentity(context.Background(), staffIdentity),
+				clientID:   &otherClientID,
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			want:    otherClientID,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - staff without clientID",
+			args: args{
+				ctx:        helpers.ContextWithIdentity(context.Background(), staffIdentity),
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - client passes another client's ID",
+			args: args{
+				ctx:        helpers.ContextWithIdentity(context.Background(), clientIdentity),
+				clientID:   &otherClientID,
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - staff cannot access the client",
+			args: args{
+				ctx:        helpers.ContextWithIdentity(context.Background(), staffIdentity),
+				clientID:   &otherClientID,
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to resolve identity",
+			args: args{
+				ctx:        context.Background(),
+				permission: enums.PermissionTypeCanViewClientHealthDiary,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			u := authority.NewUseCasesAuthority(fakeDB, fakeDB, fakeDB, fakeExtension)
+
+			if tt.name == "Happy case - staff passes clientID" {
+				fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+					return []enums.UserRoleType{enums.UserRoleTypeSystemAdmin}, nil
+				}
+			}
+			if tt.name == "Sad case - staff cannot access the client" {
+				fakeDB.MockGetUserRolesFn = func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+					return []enums.UserRoleType{enums.UserRoleTypeClient}, nil
+				}
+			}
+			if tt.name == "Sad case - fail to resolve identity" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("failed to get logged in user")
+				}
+			}
+
+			got, err := u.ResolveClientID(tt.args.ctx, tt.args.clientID, tt.args.permission)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesAuthorityImpl.ResolveClientID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesAuthorityImpl.ResolveClientID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
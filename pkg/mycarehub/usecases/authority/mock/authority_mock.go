@@ -0,0 +1,106 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+// AuthorityUseCaseMock mocks the implementation of the authority usecase
+type AuthorityUseCaseMock struct {
+	MockCheckUserPermissionFn func(ctx context.Context, permission enums.PermissionType) (bool, error)
+	MockCheckClientAccessFn   func(ctx context.Context, clientID string, permission enums.PermissionType) (bool, error)
+	MockAssignRolesFn         func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error)
+	MockRevokeRolesFn         func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error)
+	MockGetUserRolesFn        func(ctx context.Context, userID string) ([]enums.UserRoleType, error)
+	MockResolveIdentityFn     func(ctx context.Context) (*domain.Identity, error)
+	MockResolveUserIDFn       func(ctx context.Context, userID *string) (string, error)
+	MockResolveClientIDFn     func(ctx context.Context, clientID *string, permission enums.PermissionType) (string, error)
+	MockCheckFacilityAccessFn func(ctx context.Context, facilityID string, permission enums.PermissionType) (bool, error)
+}
+
+// NewAuthorityUseCaseMock initializes a new instance mock of the authority usecase
+func NewAuthorityUseCaseMock() *AuthorityUseCaseMock {
+	return &AuthorityUseCaseMock{
+		MockCheckUserPermissionFn: func(ctx context.Context, permission enums.PermissionType) (bool, error) {
+			return true, nil
+		},
+		MockCheckClientAccessFn: func(ctx context.Context, clientID string, permission enums.PermissionType) (bool, error) {
+			return true, nil
+		},
+		MockAssignRolesFn: func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+			return true, nil
+		},
+		MockRevokeRolesFn: func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+			return true, nil
+		},
+		MockGetUserRolesFn: func(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+			return []enums.UserRoleType{enums.UserRoleTypeClinician}, nil
+		},
+		MockResolveIdentityFn: func(ctx context.Context) (*domain.Identity, error) {
+			id := uuid.New().String()
+			return &domain.Identity{
+				UID:      id,
+				UserID:   id,
+				UserType: enums.ClientUser,
+				ClientID: id,
+			}, nil
+		},
+		MockResolveUserIDFn: func(ctx context.Context, userID *string) (string, error) {
+			return uuid.New().String(), nil
+		},
+		MockResolveClientIDFn: func(ctx context.Context, clientID *string, permission enums.PermissionType) (string, error) {
+			return uuid.New().String(), nil
+		},
+		MockCheckFacilityAccessFn: func(ctx context.Context, facilityID string, permission enums.PermissionType) (bool, error) {
+			return true, nil
+		},
+	}
+}
+
+// CheckUserPermission mocks the implementation of checking the logged in user's permission
+func (a *AuthorityUseCaseMock) CheckUserPermission(ctx context.Context, permission enums.PermissionType) (bool, error) {
+	return a.MockCheckUserPermissionFn(ctx, permission)
+}
+
+// CheckClientAccess mocks the implementation of checking whether the logged in user can act on a client's records
+func (a *AuthorityUseCaseMock) CheckClientAccess(ctx context.Context, clientID string, permission enums.PermissionType) (bool, error) {
+	return a.MockCheckClientAccessFn(ctx, clientID, permission)
+}
+
+// AssignRoles mocks the implementation of assigning roles to a user
+func (a *AuthorityUseCaseMock) AssignRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+	return a.MockAssignRolesFn(ctx, userID, roles)
+}
+
+// RevokeRoles mocks the implementation of revoking a user's roles
+func (a *AuthorityUseCaseMock) RevokeRoles(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+	return a.MockRevokeRolesFn(ctx, userID, roles)
+}
+
+// GetUserRoles mocks the implementation of getting the roles of a user
+func (a *AuthorityUseCaseMock) GetUserRoles(ctx context.Context, userID string) ([]enums.UserRoleType, error) {
+	return a.MockGetUserRolesFn(ctx, userID)
+}
+
+// ResolveIdentity mocks the implementation of resolving the identity of the logged in user
+func (a *AuthorityUseCaseMock) ResolveIdentity(ctx context.Context) (*domain.Identity, error) {
+	return a.MockResolveIdentityFn(ctx)
+}
+
+// ResolveUserID mocks the implementation of resolving the user that an operation acts on
+func (a *AuthorityUseCaseMock) ResolveUserID(ctx context.Context, userID *string) (string, error) {
+	return a.MockResolveUserIDFn(ctx, userID)
+}
+
+// ResolveClientID mocks the implementation of resolving the client that an operation acts on
+func (a *AuthorityUseCaseMock) ResolveClientID(ctx context.Context, clientID *string, permission enums.PermissionType) (string, error) {
+	return a.MockResolveClientIDFn(ctx, clientID, permission)
+}
+
+// CheckFacilityAccess mocks the implementation of checking whether the logged in user can act on a facility's records
+func (a *AuthorityUseCaseMock) CheckFacilityAccess(ctx context.Context, facilityID string, permission enums.PermissionType) (bool, error) {
+	return a.MockCheckFacilityAccessFn(ctx, facilityID, permission)
+}
@@ -0,0 +1,271 @@
+package clienttransfer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority"
+	log "github.com/sirupsen/logrus"
+)
+
+// Clients are transferred when they move their care to another facility e.g after relocating. Staff at the client's
+// current facility request the transfer and a service request is raised with the receiving facility. The client
+// only moves once staff at the receiving facility accept the transfer, after which the client is sent an SMS with
+// the new facility's contact details. The periods a client spent at each facility make up their facility history.
+
+// clientTransferRequestType is the type of the service request raised with the receiving facility
+const clientTransferRequestType = "CLIENT_TRANSFER"
+
+// ITransferClient contains the method used to request a client's transfer to another facility
+type ITransferClient interface {
+	TransferClient(ctx context.Context, clientID string, toFacilityMFLCode int, reason string) (*domain.ClientTransfer, error)
+}
+
+// IRespondToClientTransfer contains the methods the receiving facility uses to respond to a transfer
+type IRespondToClientTransfer interface {
+	AcceptClientTransfer(ctx context.Context, transferID string) (*domain.ClientTransfer, error)
+	DeclineClientTransfer(ctx context.Context, transferID string, reason string) (*domain.ClientTransfer, error)
+}
+
+// IRetrieveClientTransfers contains the methods used to fetch pending transfers and a client's facility history
+type IRetrieveClientTransfers interface {
+	ListPendingClientTransfers(ctx context.Context, facilityID string) ([]*domain.ClientTransfer, error)
+	GetClientFacilityHistory(ctx context.Context, clientID string) ([]*domain.ClientFacilityPeriod, error)
+}
+
+// UseCasesClientTransfer groups all the interfaces of the client transfer usecase
+type UseCasesClientTransfer interface {
+	ITransferClient
+	IRespondToClientTransfer
+	IRetrieveClientTransfers
+}
+
+// UseCasesClientTransferImpl represents the client transfer implementation
+type UseCasesClientTransferImpl struct {
+	Create      infrastructure.Create
+	Query       infrastructure.Query
+	Update      infrastructure.Update
+	ExternalExt extension.ExternalMethodsExtension
+	Authority   authority.UseCasesAuthority
+}
+
+// NewUseCasesClientTransfer is the controller function for the client transfer usecase
+func NewUseCasesClientTransfer(
+	create infrastructure.Create,
+	query infrastructure.Query,
+	update infrastructure.Update,
+	externalExt extension.ExternalMethodsExtension,
+	authority authority.UseCasesAuthority,
+) *UseCasesClientTransferImpl {
+	return &UseCasesClientTransferImpl{
+		Create:      create,
+		Query:       query,
+		Update:      update,
+		ExternalExt: externalExt,
+		Authority:   authority,
+	}
+}
+
+// getStaffIdentity returns the identity of the logged in user, who must be a staff member
+func getStaffIdentity(ctx context.Context) (*domain.Identity, error) {
+	identity, err := helpers.GetIdentityFromContext(ctx)
+	if err != nil {
+		return nil, exceptions.UnauthorizedErr(err)
+	}
+	if identity.StaffID == "" {
+		return nil, exceptions.UnauthorizedErr(fmt.Errorf("user %s is not a staff member", identity.UserID))
+	}
+	return identity, nil
+}
+
+// TransferClient requests the transfer of a client to the facility with the supplied MFL code. The logged in staff
+// member must be able to transfer the clients of the client's current facility. A client can only have one pending
+// transfer at a time.
+func (u *UseCasesClientTransferImpl) TransferClient(ctx context.Context, clientID string, toFacilityMFLCode int, reason string) (*domain.ClientTransfer, error) {
+	reason = strings.TrimSpace(reason)
+	if clientID == "" || toFacilityMFLCode == 0 || reason == "" {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("clientID, the receiving facility's MFL code and a reason must be provided"))
+	}
+
+	identity, err := getStaffIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.Authority.CheckClientAccess(ctx, clientID, enums.PermissionTypeCanTransferClient); err != nil {
+		return nil, err
+	}
+
+	client, err := u.Query.GetClientProfileByClientID(ctx, clientID)
+	if err != nil {
+		return nil, exceptions.ClientProfileNotFoundErr(err)
+	}
+
+	facility, err := u.Query.RetrieveFacilityByMFLCode(ctx, toFacilityMFLCode, true)
+	if err != nil {
+		return nil, exceptions.ItemNotFoundErr(fmt.Errorf("failed to get active facility with MFL code %v: %v", toFacilityMFLCode, err))
+	}
+	if *facility.ID == client.FacilityID {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("client %s is already assigned to facility %v", clientID, toFacilityMFLCode))
+	}
+
+	pending, err := u.Query.CheckClientHasPendingTransfer(ctx, clientID)
+	if err != nil {
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to check pending transfers: %v", err))
+	}
+	if pending {
+		return nil, exceptions.ItemAlreadyExistsErr(fmt.Errorf("client %s already has a pending transfer", clientID))
+	}
+
+	now := time.Now()
+	transfer := &domain.ClientTransfer{
+		ClientID:       clientID,
+		FromFacilityID: client.FacilityID,
+		ToFacilityID:   *facility.ID,
+		Reason:         reason,
+		RequestedByID:  identity.StaffID,
+		RequestedAt:    now,
+	}
+	serviceRequest := &domain.ClientServiceRequest{
+		Active:       true,
+		RequestType:  clientTransferRequestType,
+		Request:      fmt.Sprintf("Accept the transfer of a client into %s. Reason: %s", facility.Name, reason),
+		Status:       "PENDING",
+		InProgressAt: now,
+		ClientID:     clientID,
+		FacilityID:   *facility.ID,
+	}
+	created, err := u.Create.CreateClientTransfer(ctx, transfer, serviceRequest)
+	if err != nil {
+		return nil, exceptions.FailedToSaveItemErr(fmt.Errorf("failed to create client transfer: %v", err))
+	}
+	return created, nil
+}
+
+// getPendingTransfer fetches a transfer that the logged in user can respond to on behalf of the receiving facility
+func (u *UseCasesClientTransferImpl) getPendingTransfer(ctx context.Context, transferID string) (*domain.ClientTransfer, error) {
+	transfer, err := u.Query.GetClientTransferByID(ctx, transferID)
+	if err != nil {
+		return nil, exceptions.ItemNotFoundErr(fmt.Errorf("failed to get client transfer: %v", err))
+	}
+	if transfer.Status != enums.ClientTransferStatusPending {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("client transfer %s is %s", transferID, transfer.Status))
+	}
+	if _, err := u.Authority.CheckFacilityAccess(ctx, transfer.ToFacilityID, enums.PermissionTypeCanTransferClient); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// AcceptClientTransfer moves the client of a pending transfer to the receiving facility and sends the client an SMS
+// with the facility's contact details. Failing to send the SMS does not undo the transfer.
+func (u *UseCasesClientTransferImpl) AcceptClientTransfer(ctx context.Context, transferID string) (*domain.ClientTransfer, error) {
+	if transferID == "" {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("transferID must be provided"))
+	}
+
+	identity, err := getStaffIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	transfer, err := u.getPendingTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	facility, err := u.Query.RetrieveFacility(ctx, &transfer.ToFacilityID, true)
+	if err != nil {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("the receiving facility is no longer active: %v", err))
+	}
+
+	accepted, err := u.Update.RespondToClientTransfer(ctx, transferID, enums.ClientTransferStatusAccepted, identity.StaffID, "")
+	if err != nil {
+		return nil, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to accept client transfer: %v", err))
+	}
+
+	if err := u.notifyTransferredClient(ctx, accepted.ClientID, facility); err != nil {
+		log.Warnf("failed to notify client %s of transfer %s: %v", accepted.ClientID, transferID, err)
+	}
+	return accepted, nil
+}
+
+// DeclineClientTransfer turns down a pending transfer. The client stays at their current facility.
+func (u *UseCasesClientTransferImpl) DeclineClientTransfer(ctx context.Context, transferID string, reason string) (*domain.ClientTransfer, error) {
+	reason = strings.TrimSpace(reason)
+	if transferID == "" || reason == "" {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("transferID and a reason must be provided"))
+	}
+
+	identity, err := getStaffIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.getPendingTransfer(ctx, transferID); err != nil {
+		return nil, err
+	}
+
+	declined, err := u.Update.RespondToClientTransfer(ctx, transferID, enums.ClientTransferStatusDeclined, identity.StaffID, reason)
+	if err != nil {
+		return nil, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to decline client transfer: %v", err))
+	}
+	return declined, nil
+}
+
+// notifyTransferredClient sends a client an SMS, in their preferred language, with the contact details of the
+// facility they have been transferred to
+func (u *UseCasesClientTransferImpl) notifyTransferredClient(ctx context.Context, clientID string, facility *domain.Facility) error {
+	client, err := u.Query.GetClientProfileByClientID(ctx, clientID)
+	if err != nil {
+		return fmt.Errorf("failed to get client profile: %v", err)
+	}
+
+	phone, err := u.Query.GetContactByUserID(ctx, &client.UserID, "PHONE")
+	if err != nil {
+		return fmt.Errorf("failed to get client's phone number: %v", err)
+	}
+
+	settings, err := u.Query.GetOrganisationSettings(ctx, client.OrganisationID)
+	if err != nil {
+		return fmt.Errorf("failed to get organisation settings: %v", err)
+	}
+
+	message, err := helpers.CreateClientTransferMessage(client.User, facility)
+	if err != nil {
+		return fmt.Errorf("failed to create client transfer message: %v", err)
+	}
+	return u.ExternalExt.SendInviteSMS(ctx, phone.ContactValue, message, settings.SMSSenderID)
+}
+
+// ListPendingClientTransfers returns the transfers into a facility that are awaiting its response
+func (u *UseCasesClientTransferImpl) ListPendingClientTransfers(ctx context.Context, facilityID string) ([]*domain.ClientTransfer, error) {
+	if _, err := u.Authority.CheckFacilityAccess(ctx, facilityID, enums.PermissionTypeCanTransferClient); err != nil {
+		return nil, err
+	}
+
+	transfers, err := u.Query.ListPendingClientTransfers(ctx, facilityID)
+	if err != nil {
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to list pending client transfers: %v", err))
+	}
+	return transfers, nil
+}
+
+// GetClientFacilityHistory returns the periods a client was assigned to each facility, most recent first.
+// Clients can read their own history.
+func (u *UseCasesClientTransferImpl) GetClientFacilityHistory(ctx context.Context, clientID string) ([]*domain.ClientFacilityPeriod, error) {
+	if _, err := u.Authority.CheckClientAccess(ctx, clientID, enums.PermissionTypeCanTransferClient); err != nil {
+		return nil, err
+	}
+
+	history, err := u.Query.GetClientFacilityHistory(ctx, clientID)
+	if err != nil {
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to get client facility history: %v", err))
+	}
+	return history, nil
+}
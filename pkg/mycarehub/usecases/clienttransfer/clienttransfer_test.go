@@ -0,0 +1,528 @@
+package clienttransfer_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/clienttransfer"
+)
+
+func staffContext() context.Context {
+	return helpers.ContextWithIdentity(context.Background(), &domain.Identity{
+		UserID:   uuid.New().String(),
+		UserType: enums.HealthcareWorkerUser,
+		StaffID:  uuid.New().String(),
+	})
+}
+
+func TestUseCasesClientTransferImpl_TransferClient(t *testing.T) {
+	ctx := staffContext()
+	clientID := uuid.New().String()
+	mflCode := 1234
+
+	type args struct {
+		ctx               context.Context
+		clientID          string
+		toFacilityMFLCode int
+		reason            string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:               ctx,
+				clientID:          clientID,
+				toFacilityMFLCode: mflCode,
+				reason:            "Client relocated to Kisumu",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - missing reason",
+			args: args{
+				ctx:               ctx,
+				clientID:          clientID,
+				toFacilityMFLCode: mflCode,
+				reason:            "  ",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - logged in user is not staff",
+			args: args{
+				ctx: helpers.ContextWithIdentity(context.Background(), &domain.Identity{
+					UserID:   uuid.New().String(),
+					UserType: enums.ClientUser,
+					ClientID: clientID,
+				}),
+				clientID:          clientID,
+				toFacilityMFLCode: mflCode,
+				reason:            "Client relocated to Kisumu",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - staff cannot access the client",
+			args: args{
+				ctx:               ctx,
+				clientID:          clientID,
+				toFacilityMFLCode: mflCode,
+				reason:            "Client relocated to Kisumu",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - receiving facility not found",
+			args: args{
+				ctx:               ctx,
+				clientID:          clientID,
+				toFacilityMFLCode: mflCode,
+				reason:            "Client relocated to Kisumu",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - client is already at the facility",
+			args: args{
+				ctx:               ctx,
+				clientID:          clientID,
+				toFacilityMFLCode: mflCode,
+				reason:            "Client relocated to Kisumu",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - client has a pending transfer",
+			args: args{
+				ctx:               ctx,
+				clientID:          clientID,
+				toFacilityMFLCode: mflCode,
+				reason:            "Client relocated to Kisumu",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to create transfer",
+			args: args{
+				ctx:               ctx,
+				clientID:          clientID,
+				toFacilityMFLCode: mflCode,
+				reason:            "Client relocated to Kisumu",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			u := clienttransfer.NewUseCasesClientTransfer(fakeDB, fakeDB, fakeDB, fakeExtension, fakeAuthority)
+
+			var serviceRequest *domain.ClientServiceRequest
+			fakeDB.MockCreateClientTransferFn = func(ctx context.Context, transfer *domain.ClientTransfer, request *domain.ClientServiceRequest) (*domain.ClientTransfer, error) {
+				serviceRequest = request
+				return transfer, nil
+			}
+
+			if tt.name == "Sad case - staff cannot access the client" {
+				fakeAuthority.MockCheckClientAccessFn = func(ctx context.Context, clientID string, permission enums.PermissionType) (bool, error) {
+					return false, fmt.Errorf("unauthorized")
+				}
+			}
+			if tt.name == "Sad case - receiving facility not found" {
+				fakeDB.MockRetrieveFacilityByMFLCodeFn = func(ctx context.Context, MFLCode int, isActive bool) (*domain.Facility, error) {
+					return nil, fmt.Errorf("facility not found")
+				}
+			}
+			if tt.name == "Sad case - client is already at the facility" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+					facility, _ := fakeDB.RetrieveFacilityByMFLCode(ctx, mflCode, true)
+					return &domain.ClientProfile{ID: &clientID, FacilityID: *facility.ID}, nil
+				}
+			}
+			if tt.name == "Sad case - client has a pending transfer" {
+				fakeDB.MockCheckClientHasPendingTransferFn = func(ctx context.Context, clientID string) (bool, error) {
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case - failed to create transfer" {
+				fakeDB.MockCreateClientTransferFn = func(ctx context.Context, transfer *domain.ClientTransfer, request *domain.ClientServiceRequest) (*domain.ClientTransfer, error) {
+					return nil, fmt.Errorf("failed to create transfer")
+				}
+			}
+
+			got, err := u.TransferClient(tt.args.ctx, tt.args.clientID, tt.args.toFacilityMFLCode, tt.args.reason)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesClientTransferImpl.TransferClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.ToFacilityID == "" || got.RequestedByID == "" {
+				t.Errorf("expected the receiving facility and the requester to be recorded, got %v", got)
+			}
+			if serviceRequest.FacilityID != got.ToFacilityID {
+				t.Errorf("expected the service request to be raised with facility %v, got %v", got.ToFacilityID, serviceRequest.FacilityID)
+			}
+		})
+	}
+}
+
+func TestUseCasesClientTransferImpl_AcceptClientTransfer(t *testing.T) {
+	ctx := staffContext()
+	transferID := uuid.New().String()
+
+	type args struct {
+		ctx        context.Context
+		transferID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantSMS bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				transferID: transferID,
+			},
+			wantSMS: true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - failing to send the SMS does not undo the transfer",
+			args: args{
+				ctx:        ctx,
+				transferID: transferID,
+			},
+			wantSMS: true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - missing transfer ID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - transfer already declined",
+			args: args{
+				ctx:        ctx,
+				transferID: transferID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - staff cannot access the receiving facility",
+			args: args{
+				ctx:        ctx,
+				transferID: transferID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - receiving facility is inactive",
+			args: args{
+				ctx:        ctx,
+				transferID: transferID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to accept transfer",
+			args: args{
+				ctx:        ctx,
+				transferID: transferID,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			u := clienttransfer.NewUseCasesClientTransfer(fakeDB, fakeDB, fakeDB, fakeExtension, fakeAuthority)
+
+			smsSent := false
+			fakeExtension.MockSendInviteSMSFn = func(ctx context.Context, phoneNumber, message string, senderID enumutils.SenderID) error {
+				smsSent = true
+				return nil
+			}
+
+			if tt.name == "Happy case - failing to send the SMS does not undo the transfer" {
+				fakeExtension.MockSendInviteSMSFn = func(ctx context.Context, phoneNumber, message string, senderID enumutils.SenderID) error {
+					smsSent = true
+					return fmt.Errorf("failed to send SMS")
+				}
+			}
+			if tt.name == "Sad case - transfer already declined" {
+				fakeDB.MockGetClientTransferByIDFn = func(ctx context.Context, transferID string) (*domain.ClientTransfer, error) {
+					return &domain.ClientTransfer{ID: transferID, Status: enums.ClientTransferStatusDeclined}, nil
+				}
+			}
+			if tt.name == "Sad case - staff cannot access the receiving facility" {
+				fakeAuthority.MockCheckFacilityAccessFn = func(ctx context.Context, facilityID string, permission enums.PermissionType) (bool, error) {
+					return false, fmt.Errorf("unauthorized")
+				}
+			}
+			if tt.name == "Sad case - receiving facility is inactive" {
+				fakeDB.MockRetrieveFacilityFn = func(ctx context.Context, id *string, isActive bool) (*domain.Facility, error) {
+					return nil, fmt.Errorf("facility not found")
+				}
+			}
+			if tt.name == "Sad case - failed to accept transfer" {
+				fakeDB.MockRespondToClientTransferFn = func(ctx context.Context, transferID string, status enums.ClientTransferStatus, respondedByID, note string) (*domain.ClientTransfer, error) {
+					return nil, fmt.Errorf("failed to accept transfer")
+				}
+			}
+
+			got, err := u.AcceptClientTransfer(tt.args.ctx, tt.args.transferID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesClientTransferImpl.AcceptClientTransfer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if smsSent != tt.wantSMS {
+				t.Errorf("expected SMS sent to be %v, got %v", tt.wantSMS, smsSent)
+			}
+			if !tt.wantErr && got.Status != enums.ClientTransferStatusAccepted {
+				t.Errorf("expected the transfer to be accepted, got %v", got.Status)
+			}
+		})
+	}
+}
+
+func TestUseCasesClientTransferImpl_DeclineClientTransfer(t *testing.T) {
+	ctx := staffContext()
+	transferID := uuid.New().String()
+
+	type args struct {
+		ctx        context.Context
+		transferID string
+		reason     string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				transferID: transferID,
+				reason:     "The facility does not offer the client's treatment",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - missing reason",
+			args: args{
+				ctx:        ctx,
+				transferID: transferID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no identity on the context",
+			args: args{
+				ctx:        context.Background(),
+				transferID: transferID,
+				reason:     "The facility does not offer the client's treatment",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - transfer not found",
+			args: args{
+				ctx:        ctx,
+				transferID: transferID,
+				reason:     "The facility does not offer the client's treatment",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to decline transfer",
+			args: args{
+				ctx:        ctx,
+				transferID: transferID,
+				reason:     "The facility does not offer the client's treatment",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			u := clienttransfer.NewUseCasesClientTransfer(fakeDB, fakeDB, fakeDB, fakeExtension, fakeAuthority)
+
+			if tt.name == "Sad case - transfer not found" {
+				fakeDB.MockGetClientTransferByIDFn = func(ctx context.Context, transferID string) (*domain.ClientTransfer, error) {
+					return nil, fmt.Errorf("transfer not found")
+				}
+			}
+			if tt.name == "Sad case - failed to decline transfer" {
+				fakeDB.MockRespondToClientTransferFn = func(ctx context.Context, transferID string, status enums.ClientTransferStatus, respondedByID, note string) (*domain.ClientTransfer, error) {
+					return nil, fmt.Errorf("failed to decline transfer")
+				}
+			}
+
+			got, err := u.DeclineClientTransfer(tt.args.ctx, tt.args.transferID, tt.args.reason)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesClientTransferImpl.DeclineClientTransfer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Status != enums.ClientTransferStatusDeclined {
+				t.Errorf("expected the transfer to be declined, got %v", got.Status)
+			}
+		})
+	}
+}
+
+func TestUseCasesClientTransferImpl_ListPendingClientTransfers(t *testing.T) {
+	ctx := staffContext()
+	facilityID := uuid.New().String()
+
+	type args struct {
+		ctx        context.Context
+		facilityID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				facilityID: facilityID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - staff cannot access the facility",
+			args: args{
+				ctx:        ctx,
+				facilityID: facilityID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to list transfers",
+			args: args{
+				ctx:        ctx,
+				facilityID: facilityID,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			u := clienttransfer.NewUseCasesClientTransfer(fakeDB, fakeDB, fakeDB, fakeExtension, fakeAuthority)
+
+			if tt.name == "Sad case - staff cannot access the facility" {
+				fakeAuthority.MockCheckFacilityAccessFn = func(ctx context.Context, facilityID string, permission enums.PermissionType) (bool, error) {
+					return false, fmt.Errorf("unauthorized")
+				}
+			}
+			if tt.name == "Sad case - failed to list transfers" {
+				fakeDB.MockListPendingClientTransfersFn = func(ctx context.Context, facilityID string) ([]*domain.ClientTransfer, error) {
+					return nil, fmt.Errorf("failed to list transfers")
+				}
+			}
+
+			_, err := u.ListPendingClientTransfers(tt.args.ctx, tt.args.facilityID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesClientTransferImpl.ListPendingClientTransfers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUseCasesClientTransferImpl_GetClientFacilityHistory(t *testing.T) {
+	ctx := staffContext()
+	clientID := uuid.New().String()
+
+	type args struct {
+		ctx      context.Context
+		clientID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:      ctx,
+				clientID: clientID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - cannot access the client",
+			args: args{
+				ctx:      ctx,
+				clientID: clientID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to get history",
+			args: args{
+				ctx:      ctx,
+				clientID: clientID,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			u := clienttransfer.NewUseCasesClientTransfer(fakeDB, fakeDB, fakeDB, fakeExtension, fakeAuthority)
+
+			if tt.name == "Sad case - cannot access the client" {
+				fakeAuthority.MockCheckClientAccessFn = func(ctx context.Context, clientID string, permission enums.PermissionType) (bool, error) {
+					return false, fmt.Errorf("unauthorized")
+				}
+			}
+			if tt.name == "Sad case - failed to get history" {
+				fakeDB.MockGetClientFacilityHistoryFn = func(ctx context.Context, clientID string) ([]*domain.ClientFacilityPeriod, error) {
+					return nil, fmt.Errorf("failed to get history")
+				}
+			}
+
+			_, err := u.GetClientFacilityHistory(tt.args.ctx, tt.args.clientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesClientTransferImpl.GetClientFacilityHistory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
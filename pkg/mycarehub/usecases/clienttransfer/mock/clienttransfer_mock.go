@@ -0,0 +1,104 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+// ClientTransferUseCaseMock mocks the implementation of the client transfer usecase
+type ClientTransferUseCaseMock struct {
+	MockTransferClientFn             func(ctx context.Context, clientID string, toFacilityMFLCode int, reason string) (*domain.ClientTransfer, error)
+	MockAcceptClientTransferFn       func(ctx context.Context, transferID string) (*domain.ClientTransfer, error)
+	MockDeclineClientTransferFn      func(ctx context.Context, transferID string, reason string) (*domain.ClientTransfer, error)
+	MockListPendingClientTransfersFn func(ctx context.Context, facilityID string) ([]*domain.ClientTransfer, error)
+	MockGetClientFacilityHistoryFn   func(ctx context.Context, clientID string) ([]*domain.ClientFacilityPeriod, error)
+}
+
+// NewClientTransferUseCaseMock initializes a new instance mock of the client transfer usecase
+func NewClientTransferUseCaseMock() *ClientTransferUseCaseMock {
+	serviceRequestID := uuid.New().String()
+	transfer := &domain.ClientTransfer{
+		ID:               uuid.New().String(),
+		ClientID:         uuid.New().String(),
+		FromFacilityID:   uuid.New().String(),
+		ToFacilityID:     uuid.New().String(),
+		Reason:           "Client relocated",
+		Status:           enums.ClientTransferStatusPending,
+		RequestedByID:    uuid.New().String(),
+		RequestedAt:      time.Now(),
+		ServiceRequestID: &serviceRequestID,
+	}
+
+	return &ClientTransferUseCaseMock{
+		MockTransferClientFn: func(ctx context.Context, clientID string, toFacilityMFLCode int, reason string) (*domain.ClientTransfer, error) {
+			return transfer, nil
+		},
+		MockAcceptClientTransferFn: func(ctx context.Context, transferID string) (*domain.ClientTransfer, error) {
+			return &domain.ClientTransfer{
+				ID:             transferID,
+				ClientID:       transfer.ClientID,
+				FromFacilityID: transfer.FromFacilityID,
+				ToFacilityID:   transfer.ToFacilityID,
+				Reason:         transfer.Reason,
+				Status:         enums.ClientTransferStatusAccepted,
+				RequestedByID:  transfer.RequestedByID,
+				RequestedAt:    transfer.RequestedAt,
+			}, nil
+		},
+		MockDeclineClientTransferFn: func(ctx context.Context, transferID string, reason string) (*domain.ClientTransfer, error) {
+			return &domain.ClientTransfer{
+				ID:             transferID,
+				ClientID:       transfer.ClientID,
+				FromFacilityID: transfer.FromFacilityID,
+				ToFacilityID:   transfer.ToFacilityID,
+				Reason:         transfer.Reason,
+				Status:         enums.ClientTransferStatusDeclined,
+				RequestedByID:  transfer.RequestedByID,
+				RequestedAt:    transfer.RequestedAt,
+				ResponseNote:   reason,
+			}, nil
+		},
+		MockListPendingClientTransfersFn: func(ctx context.Context, facilityID string) ([]*domain.ClientTransfer, error) {
+			return []*domain.ClientTransfer{transfer}, nil
+		},
+		MockGetClientFacilityHistoryFn: func(ctx context.Context, clientID string) ([]*domain.ClientFacilityPeriod, error) {
+			return []*domain.ClientFacilityPeriod{
+				{
+					ID:         uuid.New().String(),
+					ClientID:   clientID,
+					FacilityID: transfer.FromFacilityID,
+					StartDate:  time.Now(),
+				},
+			}, nil
+		},
+	}
+}
+
+// TransferClient mocks the implementation of requesting a client's transfer to another facility
+func (c *ClientTransferUseCaseMock) TransferClient(ctx context.Context, clientID string, toFacilityMFLCode int, reason string) (*domain.ClientTransfer, error) {
+	return c.MockTransferClientFn(ctx, clientID, toFacilityMFLCode, reason)
+}
+
+// AcceptClientTransfer mocks the implementation of accepting a client transfer
+func (c *ClientTransferUseCaseMock) AcceptClientTransfer(ctx context.Context, transferID string) (*domain.ClientTransfer, error) {
+	return c.MockAcceptClientTransferFn(ctx, transferID)
+}
+
+// DeclineClientTransfer mocks the implementation of declining a client transfer
+func (c *ClientTransferUseCaseMock) DeclineClientTransfer(ctx context.Context, transferID string, reason string) (*domain.ClientTransfer, error) {
+	return c.MockDeclineClientTransferFn(ctx, transferID, reason)
+}
+
+// ListPendingClientTransfers mocks the implementation of listing the pending transfers into a facility
+func (c *ClientTransferUseCaseMock) ListPendingClientTransfers(ctx context.Context, facilityID string) ([]*domain.ClientTransfer, error) {
+	return c.MockListPendingClientTransfersFn(ctx, facilityID)
+}
+
+// GetClientFacilityHistory mocks the implementation of fetching the facilities a client has been assigned to
+func (c *ClientTransferUseCaseMock) GetClientFacilityHistory(ctx context.Context, clientID string) ([]*domain.ClientFacilityPeriod, error) {
+	return c.MockGetClientFacilityHistoryFn(ctx, clientID)
+}
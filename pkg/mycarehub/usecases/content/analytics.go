@@ -0,0 +1,102 @@
+package content
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+// maxContentReadSeconds is the longest read that is recorded. Longer reads are usually an item left open on the
+// phone's screen and would skew the average time spent on the item.
+const maxContentReadSeconds = 60 * 60
+
+// contentAnalyticsCSVHeader names the columns of the content analytics export. Each row is either a content item or
+// a category, as given by the type column.
+var contentAnalyticsCSVHeader = []string{
+	"type", "id", "name", "content_items", "views", "unique_viewers", "likes", "bookmarks", "shares", "average_read_seconds",
+}
+
+// RecordContentReadDuration records how long a user spent reading a content item. Reads longer than an hour are
+// recorded as an hour.
+func (u *UseCasesContentImpl) RecordContentReadDuration(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error) {
+	if userID == "" || contentID == 0 {
+		return false, exceptions.EmptyInputErr(fmt.Errorf("userID and contentID cannot be empty"))
+	}
+	if durationSeconds <= 0 {
+		return false, exceptions.InputValidationErr(fmt.Errorf("the read duration must be greater than zero"))
+	}
+	if durationSeconds > maxContentReadSeconds {
+		durationSeconds = maxContentReadSeconds
+	}
+	return u.Update.RecordContentReadDuration(ctx, userID, contentID, durationSeconds)
+}
+
+// GetContentAnalytics reports the views, unique viewers, likes, bookmarks, shares and average time spent reading
+// each content item and each category over a period
+func (u *UseCasesContentImpl) GetContentAnalytics(ctx context.Context, input dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error) {
+	if err := input.Validate(); err != nil {
+		return nil, exceptions.InputValidationErr(err)
+	}
+	return u.Query.GetContentAnalytics(ctx, &input)
+}
+
+// ExportContentAnalytics returns the content analytics for a period as CSV, with the content items followed by
+// the categories
+func (u *UseCasesContentImpl) ExportContentAnalytics(ctx context.Context, input dto.ContentAnalyticsInput) (string, error) {
+	analytics, err := u.GetContentAnalytics(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	records := [][]string{contentAnalyticsCSVHeader}
+	for _, item := range analytics.Items {
+		records = append(records, []string{
+			"content_item",
+			strconv.Itoa(item.ContentID),
+			csvSafe(item.Title),
+			"",
+			strconv.Itoa(item.Views),
+			strconv.Itoa(item.UniqueViewers),
+			strconv.Itoa(item.Likes),
+			strconv.Itoa(item.Bookmarks),
+			strconv.Itoa(item.Shares),
+			strconv.FormatFloat(item.AverageReadSeconds, 'f', 1, 64),
+		})
+	}
+	for _, category := range analytics.Categories {
+		records = append(records, []string{
+			"category",
+			strconv.Itoa(category.CategoryID),
+			csvSafe(category.Name),
+			strconv.Itoa(category.ContentItems),
+			strconv.Itoa(category.Views),
+			strconv.Itoa(category.UniqueViewers),
+			strconv.Itoa(category.Likes),
+			strconv.Itoa(category.Bookmarks),
+			strconv.Itoa(category.Shares),
+			strconv.FormatFloat(category.AverageReadSeconds, 'f', 1, 64),
+		})
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return "", fmt.Errorf("failed to write content analytics CSV: %v", err)
+	}
+	return buffer.String(), nil
+}
+
+// csvSafe stops spreadsheet applications from running a title or name that starts like a formula when the
+// export is opened
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
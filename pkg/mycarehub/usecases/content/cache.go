@@ -0,0 +1,188 @@
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+// contentCacheTTL is how long a response from the CMS is served without asking the CMS whether it has changed
+const contentCacheTTL = 5 * time.Minute
+
+// contentCacheSize is the most responses from the CMS that are kept at a time
+const contentCacheSize = 1000
+
+// cachedContent is a response from the CMS content API together with the validators needed to revalidate it.
+// Entries are not changed once they are cached so that they can be read without holding the cache's lock.
+type cachedContent struct {
+	body         []byte
+	etag         string
+	lastModified string
+	fetchedAt    time.Time
+
+	// listing marks responses that list content e.g the feed. A newly published page can appear in any of them
+	listing bool
+	itemIDs map[int]bool
+}
+
+// contentCache keeps responses from the Wagtail content API in memory keyed by the request URL.
+// Fresh entries are served without a request to the CMS. Stale entries are revalidated using
+// their `ETag` and `Last-Modified` validators so that an unchanged response is not downloaded again.
+//
+// Every instance of the service keeps its own cache and invalidation only clears the local one. The other
+// instances keep serving what they have cached for at most the TTL, after which they revalidate with the CMS.
+type contentCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	size    int
+	entries map[string]*cachedContent
+	// generation changes on every invalidation so that a response fetched before the invalidation is not cached
+	generation int
+
+	now   func() time.Time
+	fetch func(ctx context.Context, path string, headers http.Header) (*http.Response, error)
+}
+
+func newContentCache(ttl time.Duration, size int) *contentCache {
+	return &contentCache{
+		ttl:     ttl,
+		size:    size,
+		entries: map[string]*cachedContent{},
+		now:     time.Now,
+		fetch: func(ctx context.Context, path string, headers http.Header) (*http.Response, error) {
+			return utils.MakeRequestWithHeaders(ctx, http.MethodGet, path, nil, headers)
+		},
+	}
+}
+
+// get returns the content at the given path, from the cache when possible
+func (c *contentCache) get(ctx context.Context, path string, listing bool) (*domain.Content, error) {
+	c.mu.RLock()
+	entry, ok := c.entries[path]
+	generation := c.generation
+	c.mu.RUnlock()
+
+	if ok && c.now().Sub(entry.fetchedAt) < c.ttl {
+		return decodeContent(entry.body)
+	}
+
+	headers := http.Header{}
+	if ok {
+		if entry.etag != "" {
+			headers.Set("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" {
+			headers.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+
+	resp, err := c.fetch(ctx, path, headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && ok {
+		revalidated := *entry
+		revalidated.fetchedAt = c.now()
+		c.store(path, &revalidated, generation)
+		return decodeContent(entry.body)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("content API responded with status code %d", resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %v", err)
+	}
+
+	content, err := decodeContent(body)
+	if err != nil {
+		return nil, err
+	}
+
+	itemIDs := map[int]bool{}
+	for _, item := range content.Items {
+		itemIDs[item.ID] = true
+	}
+
+	c.store(path, &cachedContent{
+		body:         body,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		fetchedAt:    c.now(),
+		listing:      listing,
+		itemIDs:      itemIDs,
+	}, generation)
+
+	return content, nil
+}
+
+// store caches the entry unless the cache has been invalidated since the entry was fetched. When the cache is
+// full the stale entries are dropped and, if that is not enough, the entry that was fetched the longest ago
+func (c *contentCache) store(path string, entry *cachedContent, generation int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	if _, ok := c.entries[path]; !ok && len(c.entries) >= c.size {
+		c.evict()
+	}
+	c.entries[path] = entry
+}
+
+// evict makes room for a new entry. It must be called with the lock held
+func (c *contentCache) evict() {
+	now := c.now()
+	oldestPath := ""
+	var oldest time.Time
+	for path, entry := range c.entries {
+		if now.Sub(entry.fetchedAt) >= c.ttl {
+			delete(c.entries, path)
+			continue
+		}
+		if oldestPath == "" || entry.fetchedAt.Before(oldest) {
+			oldestPath, oldest = path, entry.fetchedAt
+		}
+	}
+	if len(c.entries) >= c.size {
+		delete(c.entries, oldestPath)
+	}
+}
+
+// invalidate drops every cached response that contains the content item as well as all listings
+// since the item may have been added to, or removed from, any of them
+func (c *contentCache) invalidate(contentID int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for path, entry := range c.entries {
+		if entry.listing || entry.itemIDs[contentID] {
+			delete(c.entries, path)
+		}
+	}
+}
+
+// decodeContent unmarshals a cached body afresh for every caller so that callers can't modify the cached copy
+func decodeContent(body []byte) (*domain.Content, error) {
+	var content *domain.Content
+	if err := json.Unmarshal(body, &content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+	}
+	if content == nil {
+		return nil, fmt.Errorf("content API returned an empty response")
+	}
+	return content, nil
+}
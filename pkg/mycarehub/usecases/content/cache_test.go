@@ -0,0 +1,379 @@
+package content
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+func contentResponse(t *testing.T, status int, headers http.Header, ids ...int) *http.Response {
+	content := domain.Content{}
+	for _, id := range ids {
+		content.Items = append(content.Items, domain.ContentItem{ID: id, Title: fmt.Sprintf("item %d", id)})
+	}
+	content.Meta.TotalCount = len(content.Items)
+
+	body, err := json.Marshal(content)
+	if err != nil {
+		t.Fatalf("failed to marshal content: %v", err)
+	}
+	if headers == nil {
+		headers = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     headers,
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func TestContentCache_get(t *testing.T) {
+	ctx := context.Background()
+	path := "http://cms.example.com/api/pages/?type=content.ContentItem"
+
+	tests := []struct {
+		name string
+		// age is how old the cached entry is when the second request is made
+		age            time.Duration
+		secondResponse func(t *testing.T, headers http.Header) *http.Response
+		wantRequests   int
+		wantIDs        []int
+		wantErr        bool
+	}{
+		{
+			name:         "Happy case - fresh entry is served from the cache",
+			age:          time.Minute,
+			wantRequests: 1,
+			wantIDs:      []int{1},
+		},
+		{
+			name: "Happy case - stale entry is revalidated",
+			age:  contentCacheTTL + time.Minute,
+			secondResponse: func(t *testing.T, headers http.Header) *http.Response {
+				if headers.Get("If-None-Match") != `"v1"` || headers.Get("If-Modified-Since") == "" {
+					t.Errorf("expected the cached validators to be sent, got %v", headers)
+				}
+				return &http.Response{
+					StatusCode: http.StatusNotModified,
+					Header:     http.Header{},
+					Body:       ioutil.NopCloser(strings.NewReader("")),
+				}
+			},
+			wantRequests: 2,
+			wantIDs:      []int{1},
+		},
+		{
+			name: "Happy case - stale entry is replaced when the content changed",
+			age:  contentCacheTTL + time.Minute,
+			secondResponse: func(t *testing.T, headers http.Header) *http.Response {
+				return contentResponse(t, http.StatusOK, http.Header{"Etag": []string{`"v2"`}}, 1, 2)
+			},
+			wantRequests: 2,
+			wantIDs:      []int{1, 2},
+		},
+		{
+			name: "Sad case - CMS error",
+			age:  contentCacheTTL + time.Minute,
+			secondResponse: func(t *testing.T, headers http.Header) *http.Response {
+				return contentResponse(t, http.StatusInternalServerError, nil)
+			},
+			wantRequests: 2,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			requests := 0
+
+			c := newContentCache(contentCacheTTL, contentCacheSize)
+			c.now = func() time.Time { return now }
+			c.fetch = func(ctx context.Context, path string, headers http.Header) (*http.Response, error) {
+				requests++
+				if requests == 1 {
+					return contentResponse(t, http.StatusOK, http.Header{
+						"Etag":          []string{`"v1"`},
+						"Last-Modified": []string{now.UTC().Format(http.TimeFormat)},
+					}, 1), nil
+				}
+				return tt.secondResponse(t, headers), nil
+			}
+
+			if _, err := c.get(ctx, path, true); err != nil {
+				t.Errorf("contentCache.get() unexpected error = %v", err)
+				return
+			}
+
+			now = now.Add(tt.age)
+			got, err := c.get(ctx, path, true)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("contentCache.get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("expected %v requests to the CMS, got %v", tt.wantRequests, requests)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got.Items) != len(tt.wantIDs) {
+				t.Errorf("expected %v items, got %v", len(tt.wantIDs), len(got.Items))
+				return
+			}
+			for i, id := range tt.wantIDs {
+				if got.Items[i].ID != id {
+					t.Errorf("expected item %v to have ID %v, got %v", i, id, got.Items[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestContentCache_invalidate(t *testing.T) {
+	ctx := context.Background()
+
+	requests := map[string]int{}
+	c := newContentCache(contentCacheTTL, contentCacheSize)
+	c.fetch = func(ctx context.Context, path string, headers http.Header) (*http.Response, error) {
+		requests[path]++
+		switch path {
+		case "listing":
+			return contentResponse(t, http.StatusOK, nil, 1, 2), nil
+		case "item-1":
+			return contentResponse(t, http.StatusOK, nil, 1), nil
+		default:
+			return contentResponse(t, http.StatusOK, nil, 3), nil
+		}
+	}
+
+	fetchAll := func() {
+		for _, path := range []string{"listing", "item-1", "item-3"} {
+			if _, err := c.get(ctx, path, path == "listing"); err != nil {
+				t.Errorf("contentCache.get() unexpected error = %v", err)
+			}
+		}
+	}
+
+	fetchAll()
+	c.invalidate(1)
+	fetchAll()
+
+	want := map[string]int{"listing": 2, "item-1": 2, "item-3": 1}
+	for path, count := range want {
+		if requests[path] != count {
+			t.Errorf("expected %v requests for %v, got %v", count, path, requests[path])
+		}
+	}
+}
+
+func TestContentCache_size(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	c := newContentCache(contentCacheTTL, 2)
+	c.now = func() time.Time { return now }
+	c.fetch = func(ctx context.Context, path string, headers http.Header) (*http.Response, error) {
+		return contentResponse(t, http.StatusOK, nil, 1), nil
+	}
+
+	for _, path := range []string{"first", "second", "third"} {
+		if _, err := c.get(ctx, path, false); err != nil {
+			t.Errorf("contentCache.get() unexpected error = %v", err)
+		}
+		now = now.Add(time.Second)
+	}
+
+	if len(c.entries) != 2 {
+		t.Errorf("expected the cache to keep 2 entries, got %v", len(c.entries))
+	}
+	if _, ok := c.entries["first"]; ok {
+		t.Errorf("expected the entry that was fetched the longest ago to be evicted")
+	}
+}
+
+// TestContentCache_concurrentAccess is meant to be run with the race detector i.e `go test -race`
+func TestContentCache_concurrentAccess(t *testing.T) {
+	ctx := context.Background()
+
+	body, err := json.Marshal(domain.Content{Items: []domain.ContentItem{{ID: 1}}})
+	if err != nil {
+		t.Fatalf("failed to marshal content: %v", err)
+	}
+
+	// entries are stale as soon as they are cached so that every get revalidates them
+	c := newContentCache(0, 5)
+	c.fetch = func(ctx context.Context, path string, headers http.Header) (*http.Response, error) {
+		// yield so that requests interleave even on a single CPU
+		runtime.Gosched()
+		status := http.StatusOK
+		if headers.Get("If-None-Match") != "" {
+			status = http.StatusNotModified
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Etag": []string{`"v1"`}},
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if _, err := c.get(ctx, strconv.Itoa((i+j)%10), j%2 == 0); err != nil {
+					t.Errorf("contentCache.get() unexpected error = %v", err)
+					return
+				}
+				if j%10 == 0 {
+					c.invalidate(1)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(c.entries) > 5 {
+		t.Errorf("expected the cache to keep at most 5 entries, got %v", len(c.entries))
+	}
+}
+
+func TestUseCasesContentImpl_getContentByContentItemIDs(t *testing.T) {
+	ctx := context.Background()
+
+	contentIDs := []int{}
+	for id := 1; id <= contentBatchSize+5; id++ {
+		contentIDs = append(contentIDs, id)
+	}
+	// the last item has been unpublished hence the CMS does not return it
+	unpublished := contentIDs[len(contentIDs)-1]
+
+	u := NewUseCasesContentImplementation(nil, nil)
+	requests := 0
+	u.cache.fetch = func(ctx context.Context, path string, headers http.Header) (*http.Response, error) {
+		requests++
+		parsed, err := url.Parse(path)
+		if err != nil {
+			return nil, err
+		}
+		ids := []int{}
+		for _, id := range strings.Split(parsed.Query().Get("id__in"), ",") {
+			contentID, err := strconv.Atoi(id)
+			if err != nil {
+				return nil, err
+			}
+			if contentID != unpublished {
+				ids = append(ids, contentID)
+			}
+		}
+		// the CMS orders items by its own ordering rather than the requested one
+		for i, j := 0, len(ids)-1; i < j; i, j = i+1, j-1 {
+			ids[i], ids[j] = ids[j], ids[i]
+		}
+		return contentResponse(t, http.StatusOK, nil, ids...), nil
+	}
+
+	got, err := u.getContentByContentItemIDs(ctx, contentIDs)
+	if err != nil {
+		t.Errorf("UseCasesContentImpl.getContentByContentItemIDs() error = %v", err)
+		return
+	}
+	if requests != 2 {
+		t.Errorf("expected the items to be fetched in 2 batches, got %v requests", requests)
+	}
+	if len(got) != len(contentIDs)-1 {
+		t.Errorf("expected %v items, got %v", len(contentIDs)-1, len(got))
+		return
+	}
+	for i, item := range got {
+		if item.ID != contentIDs[i] {
+			t.Errorf("expected item %v to have ID %v, got %v", i, contentIDs[i], item.ID)
+		}
+	}
+}
+
+func TestUseCasesContentImpl_readContent(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name             string
+		readFromDatabase bool
+		cmsErr           error
+		dbErr            error
+		wantCMSRequests  int
+		wantDBReads      int
+		wantErr          bool
+	}{
+		{
+			name:            "Happy case - content is read from the CMS",
+			wantCMSRequests: 1,
+		},
+		{
+			name:            "Happy case - database is used when the CMS is unreachable",
+			cmsErr:          fmt.Errorf("connection refused"),
+			wantCMSRequests: 1,
+			wantDBReads:     1,
+		},
+		{
+			name:             "Happy case - database is configured as the primary source",
+			readFromDatabase: true,
+			wantDBReads:      1,
+		},
+		{
+			name:            "Sad case - both the CMS and the database fail",
+			cmsErr:          fmt.Errorf("connection refused"),
+			dbErr:           fmt.Errorf("an error occurred"),
+			wantCMSRequests: 1,
+			wantDBReads:     1,
+			wantErr:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewUseCasesContentImplementation(nil, nil)
+			u.readFromDatabase = tt.readFromDatabase
+
+			cmsRequests := 0
+			u.cache.fetch = func(ctx context.Context, path string, headers http.Header) (*http.Response, error) {
+				cmsRequests++
+				if tt.cmsErr != nil {
+					return nil, tt.cmsErr
+				}
+				return contentResponse(t, http.StatusOK, nil, 1), nil
+			}
+			dbReads := 0
+			fromDatabase := func(ctx context.Context) (*domain.Content, error) {
+				dbReads++
+				if tt.dbErr != nil {
+					return nil, tt.dbErr
+				}
+				return &domain.Content{Items: []domain.ContentItem{{ID: 1}}}, nil
+			}
+
+			got, err := u.readContent(ctx, "listing", true, fromDatabase)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.readContent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if cmsRequests != tt.wantCMSRequests || dbReads != tt.wantDBReads {
+				t.Errorf("expected %v CMS requests and %v database reads, got %v and %v", tt.wantCMSRequests, tt.wantDBReads, cmsRequests, dbReads)
+			}
+			if !tt.wantErr && len(got.Items) != 1 {
+				t.Errorf("expected 1 content item, got %v", len(got.Items))
+			}
+		})
+	}
+}
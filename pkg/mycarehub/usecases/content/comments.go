@@ -0,0 +1,234 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+// maxContentCommentLength is the most characters that a content comment can have
+const maxContentCommentLength = 2000
+
+// defaultModerationQueueSize is the number of comments in the moderation queue when the moderator doesn't pick one
+const defaultModerationQueueSize = 50
+
+// maxModerationQueueSize is the most comments that can be fetched from the moderation queue at once
+const maxModerationQueueSize = 100
+
+// ListContentComments returns the threads of comments on a content item, oldest first. Users see the approved
+// comments together with their own comments that are awaiting moderation. A thread whose first comment was
+// deleted or removed by a moderator is kept, without the comment's body, when it has replies that can be seen.
+func (u *UseCasesContentImpl) ListContentComments(ctx context.Context, userID string, contentID int) ([]*domain.ContentComment, error) {
+	if userID == "" || contentID == 0 {
+		return nil, exceptions.EmptyInputErr(fmt.Errorf("userID and contentID cannot be empty"))
+	}
+	comments, err := u.Query.ListContentComments(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	return contentCommentThreads(comments, userID), nil
+}
+
+// CreateContentComment saves a user's comment on a content item. A reply to a reply joins the thread of the
+// comment that started it. Comments are published straight away unless they trip the profanity filter, in which
+// case they wait for a moderator.
+func (u *UseCasesContentImpl) CreateContentComment(
+	ctx context.Context,
+	userID string,
+	contentID int,
+	parentID *string,
+	body string,
+) (*domain.ContentComment, error) {
+	if userID == "" || contentID == 0 {
+		return nil, exceptions.EmptyInputErr(fmt.Errorf("userID and contentID cannot be empty"))
+	}
+	body, err := validateContentCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID != nil {
+		parent, err := u.Query.GetContentCommentByID(ctx, *parentID)
+		if err != nil {
+			return nil, exceptions.InputValidationErr(fmt.Errorf("the comment being replied to does not exist: %v", err))
+		}
+		if parent.ContentID != contentID {
+			return nil, exceptions.InputValidationErr(fmt.Errorf("the comment being replied to is on another content item"))
+		}
+		if parent.Deleted || parent.Status == enums.ContentCommentStatusRejected {
+			return nil, exceptions.InputValidationErr(fmt.Errorf("the comment being replied to has been removed"))
+		}
+		if parent.ParentID != nil {
+			parentID = parent.ParentID
+		}
+	}
+
+	status := enums.ContentCommentStatusApproved
+	if containsProfanity(body) {
+		status = enums.ContentCommentStatusPending
+	}
+	comment, err := u.Update.CreateContentComment(ctx, userID, contentID, parentID, body, status)
+	if err != nil {
+		return nil, err
+	}
+	comment.IsAuthor = true
+	return comment, nil
+}
+
+// EditContentComment replaces the body of a user's own comment. An approved comment that trips the profanity
+// filter after the edit goes back to waiting for a moderator.
+func (u *UseCasesContentImpl) EditContentComment(ctx context.Context, userID string, commentID string, body string) (*domain.ContentComment, error) {
+	if userID == "" || commentID == "" {
+		return nil, exceptions.EmptyInputErr(fmt.Errorf("userID and commentID cannot be empty"))
+	}
+	body, err := validateContentCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := u.Query.GetContentCommentByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.UserID != userID {
+		return nil, exceptions.UnauthorizedErr(fmt.Errorf("users can only edit their own comments"))
+	}
+	if comment.Deleted || comment.Status == enums.ContentCommentStatusRejected {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("the comment has been removed"))
+	}
+
+	status := comment.Status
+	if status == enums.ContentCommentStatusApproved && containsProfanity(body) {
+		status = enums.ContentCommentStatusPending
+	}
+	edited, err := u.Update.EditContentComment(ctx, commentID, body, status)
+	if err != nil {
+		return nil, err
+	}
+	edited.IsAuthor = true
+	return edited, nil
+}
+
+// DeleteContentComment deletes a user's own comment. A comment that was already deleted can't be deleted again.
+func (u *UseCasesContentImpl) DeleteContentComment(ctx context.Context, userID string, commentID string) (bool, error) {
+	if userID == "" || commentID == "" {
+		return false, exceptions.EmptyInputErr(fmt.Errorf("userID and commentID cannot be empty"))
+	}
+	comment, err := u.Query.GetContentCommentByID(ctx, commentID)
+	if err != nil {
+		return false, err
+	}
+	if comment.UserID != userID {
+		return false, exceptions.UnauthorizedErr(fmt.Errorf("users can only delete their own comments"))
+	}
+	if comment.Deleted {
+		return false, exceptions.InputValidationErr(fmt.Errorf("the comment has already been deleted"))
+	}
+	return u.Update.DeleteContentComment(ctx, commentID)
+}
+
+// ContentCommentModerationQueue returns the comments that are waiting for a moderator, oldest first. Without a
+// status the queue has the comments held by the profanity filter and those flagged by moderators.
+func (u *UseCasesContentImpl) ContentCommentModerationQueue(ctx context.Context, status *enums.ContentCommentStatus, limit *int) ([]*domain.ContentComment, error) {
+	statuses := []enums.ContentCommentStatus{enums.ContentCommentStatusPending, enums.ContentCommentStatusFlagged}
+	if status != nil {
+		if !status.IsValid() {
+			return nil, exceptions.InputValidationErr(fmt.Errorf("invalid content comment status: %v", *status))
+		}
+		statuses = []enums.ContentCommentStatus{*status}
+	}
+
+	size := defaultModerationQueueSize
+	if limit != nil {
+		if *limit < 1 || *limit > maxModerationQueueSize {
+			return nil, exceptions.InputValidationErr(fmt.Errorf("limit must be between 1 and %d", maxModerationQueueSize))
+		}
+		size = *limit
+	}
+	return u.Query.ListContentCommentsByStatus(ctx, statuses, size)
+}
+
+// ModerateContentComment approves, rejects or flags a comment. Approved comments can be seen by every user, flagged
+// comments only by their author and rejected comments by no one.
+func (u *UseCasesContentImpl) ModerateContentComment(
+	ctx context.Context,
+	moderatorID string,
+	commentID string,
+	status enums.ContentCommentStatus,
+) (*domain.ContentComment, error) {
+	if moderatorID == "" || commentID == "" {
+		return nil, exceptions.EmptyInputErr(fmt.Errorf("moderatorID and commentID cannot be empty"))
+	}
+	switch status {
+	case enums.ContentCommentStatusApproved, enums.ContentCommentStatusRejected, enums.ContentCommentStatusFlagged:
+	default:
+		return nil, exceptions.InputValidationErr(fmt.Errorf("a comment can only be approved, rejected or flagged, not %v", status))
+	}
+	return u.Update.ModerateContentComment(ctx, commentID, status, moderatorID)
+}
+
+// validateContentCommentBody trims the body of a comment and checks that it is neither empty nor too long
+func validateContentCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", exceptions.EmptyInputErr(fmt.Errorf("the comment cannot be empty"))
+	}
+	if utf8.RuneCountInString(body) > maxContentCommentLength {
+		return "", exceptions.InputValidationErr(fmt.Errorf("the comment cannot be longer than %d characters", maxContentCommentLength))
+	}
+	return body, nil
+}
+
+// contentCommentThreads arranges the comments on a content item, oldest first, into the threads that the user
+// can see
+func contentCommentThreads(comments []*domain.ContentComment, userID string) []*domain.ContentComment {
+	canSee := func(comment *domain.ContentComment) bool {
+		if comment.Deleted {
+			return false
+		}
+		if comment.UserID == userID {
+			return comment.Status != enums.ContentCommentStatusRejected
+		}
+		return comment.Status == enums.ContentCommentStatusApproved
+	}
+
+	threadsByID := map[string]*domain.ContentComment{}
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			threadsByID[comment.ID] = comment
+		}
+	}
+	for _, comment := range comments {
+		if comment.ParentID == nil || !canSee(comment) {
+			continue
+		}
+		if thread, ok := threadsByID[*comment.ParentID]; ok {
+			comment.IsAuthor = comment.UserID == userID
+			thread.Replies = append(thread.Replies, comment)
+		}
+	}
+
+	threads := []*domain.ContentComment{}
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			switch {
+			case canSee(comment):
+				comment.IsAuthor = comment.UserID == userID
+			case len(comment.Replies) > 0:
+				comment.Removed = true
+				comment.Body = ""
+				comment.Nickname = ""
+				comment.EditedAt = nil
+			default:
+				continue
+			}
+			threads = append(threads, comment)
+		}
+	}
+	return threads
+}
@@ -2,28 +2,45 @@ package content
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"net/url"
+	"os"
 	"strconv"
+	"strings"
 
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
-	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
 	"github.com/savannahghi/serverutils"
+	log "github.com/sirupsen/logrus"
 )
 
 var (
 	contentAPIEndpoint = serverutils.MustGetEnvVar("CONTENT_API_URL")
 )
 
+// ContentFromDatabase is the environment variable that makes the database the primary source of content
+const ContentFromDatabase = "CONTENT_FROM_DATABASE"
+
+// contentBatchSize is the most content items requested at once. It matches the maximum page size of the Wagtail API
+const contentBatchSize = 20
+
+// maxRecommendedContent is the most content items that can be recommended at once
+const maxRecommendedContent = 50
+
 // IGetContent is used to fetch content from the CMS
 type IGetContent interface {
-	GetContent(ctx context.Context, categoryID *int, limit string) (*domain.Content, error)
+	GetContent(
+		ctx context.Context,
+		categoryID *int,
+		limit int,
+		sort *enums.ContentSortOrder,
+		trendingDays *int,
+		cursor *string,
+	) (*domain.Content, error)
 	GetContentByContentItemID(ctx context.Context, contentID int) (*domain.Content, error)
 }
 
@@ -42,12 +59,10 @@ type IContentCategoryList interface {
 	ListContentCategories(ctx context.Context) ([]*domain.ContentItemCategory, error)
 }
 
-// IShareContent is the interface for the ShareContent
+// IShareContent is the interface for sharing content and attributing opened links to the sharer
 type IShareContent interface {
-	// TODO: update share count (increment)
-	// TODO: add / check entry in ContentShares table
-	// TODO: metrics
-	ShareContent(ctx context.Context, input dto.ShareContentInput) (bool, error)
+	ShareContent(ctx context.Context, input dto.ShareContentInput) (*domain.ContentShareLink, error)
+	OpenSharedContent(ctx context.Context, token string, openedByID *string, client string) (int, error)
 }
 
 // IBookmarkContent is used to bookmark content
@@ -99,6 +114,74 @@ type UseCasesContent interface {
 	IUnlikeContent
 	IViewContent
 	ICheckIfUserBookmarkedContent
+	IInvalidateContentCache
+	ISearchContent
+	IRecommendedContent
+	IReconcileContentEngagement
+	IRecordContentReadDuration
+	IContentAnalytics
+	IContentProgress
+	IContentComments
+	IModerateContentComments
+}
+
+// IInvalidateContentCache is used to drop cached CMS content when it changes in the CMS
+type IInvalidateContentCache interface {
+	InvalidateContentCache(ctx context.Context, contentID int) (bool, error)
+}
+
+// ISearchContent is used to search published content
+type ISearchContent interface {
+	SearchContent(
+		ctx context.Context,
+		query string,
+		categoryIDs []int,
+		itemType *string,
+		language *enumutils.Language,
+		paginationInput dto.PaginationsInput,
+	) (*domain.ContentSearchPage, error)
+}
+
+// IRecommendedContent is used to recommend content to a user based on their engagement history
+type IRecommendedContent interface {
+	RecommendedContent(ctx context.Context, userID string, limit *int) (*domain.Content, error)
+}
+
+// IReconcileContentEngagement is used to check that the content engagement counters match the engagement
+// recorded per user
+type IReconcileContentEngagement interface {
+	ReconcileContentEngagement(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error)
+}
+
+// IRecordContentReadDuration is used to record how long a user spent reading a content item
+type IRecordContentReadDuration interface {
+	RecordContentReadDuration(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error)
+}
+
+// IContentAnalytics is used by content editors to see how clients engage with content
+type IContentAnalytics interface {
+	GetContentAnalytics(ctx context.Context, input dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error)
+	ExportContentAnalytics(ctx context.Context, input dto.ContentAnalyticsInput) (string, error)
+}
+
+// IContentProgress is used to track how far users have got with content and to pick up where they left off
+type IContentProgress interface {
+	RecordContentProgress(ctx context.Context, userID string, contentID int, secondsSpent int, percentScrolled float64) (*domain.ContentProgressUpdate, error)
+	ContinueReading(ctx context.Context, userID string, limit *int) ([]*domain.ContentProgress, error)
+}
+
+// IContentComments is used by users to discuss content items in threads of comments
+type IContentComments interface {
+	ListContentComments(ctx context.Context, userID string, contentID int) ([]*domain.ContentComment, error)
+	CreateContentComment(ctx context.Context, userID string, contentID int, parentID *string, body string) (*domain.ContentComment, error)
+	EditContentComment(ctx context.Context, userID string, commentID string, body string) (*domain.ContentComment, error)
+	DeleteContentComment(ctx context.Context, userID string, commentID string) (bool, error)
+}
+
+// IModerateContentComments is used by staff to work through the comments that are awaiting moderation
+type IModerateContentComments interface {
+	ContentCommentModerationQueue(ctx context.Context, status *enums.ContentCommentStatus, limit *int) ([]*domain.ContentComment, error)
+	ModerateContentComment(ctx context.Context, moderatorID string, commentID string, status enums.ContentCommentStatus) (*domain.ContentComment, error)
 }
 
 // IViewContent gets a content ite and updates the view count
@@ -112,6 +195,11 @@ type IViewContent interface {
 type UseCasesContentImpl struct {
 	Update infrastructure.Update
 	Query  infrastructure.Query
+
+	cache      *contentCache
+	shareOpens *recentShareOpens
+	// readFromDatabase makes the tables shared with the CMS the primary source of content rather than the CMS API
+	readFromDatabase bool
 }
 
 // NewUseCasesContentImplementation initializes a new contents service
@@ -120,10 +208,25 @@ func NewUseCasesContentImplementation(
 	query infrastructure.Query,
 ) *UseCasesContentImpl {
 	return &UseCasesContentImpl{
-		Update: update,
-		Query:  query,
+		Update:     update,
+		Query:      query,
+		cache:      newContentCache(contentCacheTTL, contentCacheSize),
+		shareOpens: newRecentShareOpens(contentShareOpenWindow, contentShareOpenSize),
+
+		readFromDatabase: contentFromDatabase(),
+	}
+
+}
+
+// contentFromDatabase returns true if the service is configured to read content from the database
+// rather than from the CMS API
+func contentFromDatabase() bool {
+	fromDatabase, err := strconv.ParseBool(os.Getenv(ContentFromDatabase))
+	if err != nil {
+		return false
 	}
 
+	return fromDatabase
 }
 
 // LikeContent implements the content liking api
@@ -141,36 +244,140 @@ func (u UseCasesContentImpl) UnlikeContent(ctx context.Context, userID string, c
 	return u.Update.UnlikeContent(ctx, userID, contentID)
 }
 
-// GetContent fetches content from wagtail CMS. The category ID is optional and it is used to return content based
-// on the category it belongs to. The limit field describes how many items will be rendered on the front end side.
-func (u UseCasesContentImpl) GetContent(ctx context.Context, categoryID *int, limit string) (*domain.Content, error) {
+// GetContent fetches a page of the content feed. The category ID is optional and it is used to return content based
+// on the category it belongs to. The feed is sorted newest first unless another order is chosen, with trending
+// content ranked by its engagement over the last trending days. The cursor is the next or previous cursor of a
+// page that was fetched earlier and it is left out to get the first page. Cursors point at the item a page
+// starts after, or ends before, so pages don't repeat or skip items when content is published in the meantime.
+func (u UseCasesContentImpl) GetContent(
+	ctx context.Context,
+	categoryID *int,
+	limit int,
+	sort *enums.ContentSortOrder,
+	trendingDays *int,
+	cursor *string,
+) (*domain.Content, error) {
+	if limit <= 0 || limit > contentBatchSize {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("limit must be between 1 and %d", contentBatchSize))
+	}
+
+	feed := contentCursor{Sort: enums.ContentSortOrderNewest}
+	if sort != nil {
+		feed.Sort = *sort
+	}
+	if !feed.Sort.IsValid() {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("invalid content sort order: %v", feed.Sort))
+	}
+	if feed.Sort == enums.ContentSortOrderTrending {
+		feed.TrendingDays = defaultTrendingDays
+		if trendingDays != nil {
+			feed.TrendingDays = *trendingDays
+		}
+		if feed.TrendingDays <= 0 || feed.TrendingDays > maxTrendingDays {
+			return nil, exceptions.InputValidationErr(fmt.Errorf("trending days must be between 1 and %d", maxTrendingDays))
+		}
+	}
+
+	var from *contentCursor
+	var after, before *domain.ContentFeedPosition
+	if cursor != nil && *cursor != "" {
+		decoded, err := decodeContentCursor(*cursor)
+		if err != nil {
+			return nil, exceptions.InputValidationErr(err)
+		}
+		if decoded.Sort != feed.Sort || decoded.TrendingDays != feed.TrendingDays {
+			return nil, exceptions.InputValidationErr(fmt.Errorf("the cursor belongs to a feed in a different order"))
+		}
+		from = decoded
+		if from.Before {
+			before = &from.Position
+		} else {
+			after = &from.Position
+		}
+	}
+
+	fromDatabase := func(ctx context.Context) (*domain.ContentFeedPage, error) {
+		return u.Query.ListContentFeed(ctx, categoryID, feed.Sort, feed.TrendingDays, after, before, limit)
+	}
+
+	var page *domain.ContentFeedPage
+	var err error
+	if feed.Sort == enums.ContentSortOrderNewest && from == nil {
+		// the CMS API pages content by offset, so only the start of the newest content is read from it and the
+		// pages that follow are read from the database by their feed
+		page, err = u.readNewestContent(ctx, categoryID, limit, fromDatabase)
+	} else {
+		// the CMS can't rank content by the engagement recorded by this service hence these orders are read
+		// from the database
+		page, err = fromDatabase(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	content := page.Content
+	content.Meta.NextCursor, content.Meta.PreviousCursor = feed.pageCursors(from, page)
+	return content, nil
+}
+
+// readNewestContent reads the first page of the newest content from the CMS API, or from the database when the
+// CMS API can't be used
+func (u UseCasesContentImpl) readNewestContent(
+	ctx context.Context,
+	categoryID *int,
+	limit int,
+	fromDatabase func(ctx context.Context) (*domain.ContentFeedPage, error),
+) (*domain.ContentFeedPage, error) {
 	params := url.Values{}
 	params.Add("type", "content.ContentItem")
-	params.Add("limit", limit)
+	params.Add("limit", strconv.Itoa(limit))
 	params.Add("order", "-first_published_at")
 	params.Add("fields", "'*")
 	if categoryID != nil {
 		params.Add("category", strconv.Itoa(*categoryID))
 	}
 
-	getContentEndpoint := fmt.Sprintf(contentAPIEndpoint + "/?" + params.Encode())
-	var contentItems *domain.Content
-	resp, err := utils.MakeRequest(ctx, http.MethodGet, getContentEndpoint, nil)
+	var databasePage *domain.ContentFeedPage
+	content, err := u.readContent(ctx, contentAPIEndpoint+"/?"+params.Encode(), true, func(ctx context.Context) (*domain.Content, error) {
+		page, err := fromDatabase(ctx)
+		if err != nil {
+			return nil, err
+		}
+		databasePage = page
+		return page.Content, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request")
+		return nil, err
 	}
+	if databasePage != nil {
+		return databasePage, nil
+	}
+	return newestContentPage(content)
+}
 
-	dataResponse, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read request body: %v", err)
+// readContent reads content from the CMS API. The content is read from the tables shared with the CMS instead
+// when the service is configured to do so, or when the CMS API can't be reached
+func (u UseCasesContentImpl) readContent(
+	ctx context.Context,
+	endpoint string,
+	listing bool,
+	fromDatabase func(ctx context.Context) (*domain.Content, error),
+) (*domain.Content, error) {
+	if u.readFromDatabase {
+		return fromDatabase(ctx)
 	}
 
-	err = json.Unmarshal(dataResponse, &contentItems)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+	content, err := u.cache.get(ctx, endpoint, listing)
+	if err == nil {
+		return content, nil
 	}
 
-	return contentItems, nil
+	log.Warnf("failed to fetch content from the CMS, reading it from the database instead: %v", err)
+	content, dbErr := fromDatabase(ctx)
+	if dbErr != nil {
+		return nil, fmt.Errorf("failed to fetch content from the CMS: %v and from the database: %v", err, dbErr)
+	}
+	return content, nil
 }
 
 // ListContentCategories gets the list of all content categories
@@ -178,11 +385,6 @@ func (u *UseCasesContentImpl) ListContentCategories(ctx context.Context) ([]*dom
 	return u.Query.ListContentCategories(ctx)
 }
 
-// ShareContent enables a user to share a content
-func (u *UseCasesContentImpl) ShareContent(ctx context.Context, input dto.ShareContentInput) (bool, error) {
-	return u.Update.ShareContent(ctx, input)
-}
-
 // BookmarkContent increments the bookmark count for a content item
 func (u UseCasesContentImpl) BookmarkContent(ctx context.Context, userID string, contentID int) (bool, error) {
 	return u.Update.BookmarkContent(ctx, userID, contentID)
@@ -210,14 +412,20 @@ func (u *UseCasesContentImpl) GetUserBookmarkedContent(ctx context.Context, user
 	}
 
 	userBookmarkedContent := &domain.Content{}
+	if len(content) == 0 {
+		return userBookmarkedContent, nil
+	}
 
+	contentIDs := []int{}
 	for _, contentItem := range content {
-		bookmarkedContent, err := u.GetContentByContentItemID(ctx, contentItem.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch bookmarked content")
-		}
-		userBookmarkedContent.Items = append(userBookmarkedContent.Items, bookmarkedContent.Items...)
+		contentIDs = append(contentIDs, contentItem.ID)
+	}
+
+	bookmarkedContent, err := u.getContentByContentItemIDs(ctx, contentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookmarked content: %v", err)
 	}
+	userBookmarkedContent.Items = bookmarkedContent
 	userBookmarkedContent.Meta.TotalCount = len(userBookmarkedContent.Items)
 
 	return userBookmarkedContent, nil
@@ -234,23 +442,52 @@ func (u *UseCasesContentImpl) GetContentByContentItemID(ctx context.Context, con
 	params.Add("fields", "'*")
 
 	getContentEndpoint := fmt.Sprintf(contentAPIEndpoint + "/?" + params.Encode())
-	var contentItems *domain.Content
-	resp, err := utils.MakeRequest(ctx, http.MethodGet, getContentEndpoint, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request")
-	}
+	return u.readContent(ctx, getContentEndpoint, false, func(ctx context.Context) (*domain.Content, error) {
+		return u.Query.ListContentItems(ctx, nil, []int{contentID}, 1)
+	})
+}
 
-	dataResponse, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read request body: %v", err)
-	}
+// getContentByContentItemIDs fetches several content items in batches rather than one request per item.
+// The items are returned in the order of the provided IDs. Items that are no longer published are left out.
+func (u *UseCasesContentImpl) getContentByContentItemIDs(ctx context.Context, contentIDs []int) ([]domain.ContentItem, error) {
+	itemsByID := map[int]domain.ContentItem{}
+	for start := 0; start < len(contentIDs); start += contentBatchSize {
+		end := start + contentBatchSize
+		if end > len(contentIDs) {
+			end = len(contentIDs)
+		}
 
-	err = json.Unmarshal(dataResponse, &contentItems)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+		ids := []string{}
+		for _, contentID := range contentIDs[start:end] {
+			ids = append(ids, strconv.Itoa(contentID))
+		}
+
+		params := url.Values{}
+		params.Add("type", "content.ContentItem")
+		params.Add("id__in", strings.Join(ids, ","))
+		params.Add("limit", strconv.Itoa(len(ids)))
+		params.Add("fields", "'*")
+
+		getContentEndpoint := contentAPIEndpoint + "/?" + params.Encode()
+		batch := contentIDs[start:end]
+		content, err := u.readContent(ctx, getContentEndpoint, false, func(ctx context.Context) (*domain.Content, error) {
+			return u.Query.ListContentItems(ctx, nil, batch, len(batch))
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range content.Items {
+			itemsByID[item.ID] = item
+		}
 	}
 
-	return contentItems, nil
+	items := []domain.ContentItem{}
+	for _, contentID := range contentIDs {
+		if item, ok := itemsByID[contentID]; ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
 }
 
 // ViewContent gets a content item and updates the view count
@@ -268,3 +505,66 @@ func (u *UseCasesContentImpl) CheckIfUserBookmarkedContent(ctx context.Context,
 	}
 	return u.Query.CheckIfUserBookmarkedContent(ctx, userID, contentID)
 }
+
+// InvalidateContentCache drops the cached CMS responses affected by a change to a content item.
+// It is called when the CMS notifies us that a page has been published, unpublished or deleted
+func (u *UseCasesContentImpl) InvalidateContentCache(ctx context.Context, contentID int) (bool, error) {
+	if contentID <= 0 {
+		return false, exceptions.EmptyInputErr(fmt.Errorf("a valid content ID must be defined"))
+	}
+	u.cache.invalidate(contentID)
+	return true, nil
+}
+
+// SearchContent searches the title, intro, body and tags of published content for the query. The query supports
+// "quoted phrases", OR and -excluded words. Words are stemmed using the chosen language which defaults to English.
+// The categories and item type are optional filters
+func (u *UseCasesContentImpl) SearchContent(
+	ctx context.Context,
+	query string,
+	categoryIDs []int,
+	itemType *string,
+	language *enumutils.Language,
+	paginationInput dto.PaginationsInput,
+) (*domain.ContentSearchPage, error) {
+	input := &dto.ContentSearchInput{
+		Query:       strings.TrimSpace(query),
+		CategoryIDs: categoryIDs,
+		Language:    enumutils.LanguageEn,
+	}
+	if itemType != nil {
+		input.ItemType = *itemType
+	}
+	if language != nil {
+		input.Language = *language
+	}
+	if err := input.Validate(); err != nil {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("invalid content search: %v", err))
+	}
+
+	return u.Query.SearchContent(ctx, input, &paginationInput)
+}
+
+// RecommendedContent returns the published content that the user hasn't viewed yet, ranked by how closely it matches
+// the categories and tags of the content they have liked, bookmarked, shared and viewed and by how popular it is
+// with clients of the same type. The limit defaults to a page of the feed
+func (u *UseCasesContentImpl) RecommendedContent(ctx context.Context, userID string, limit *int) (*domain.Content, error) {
+	if userID == "" {
+		return nil, exceptions.EmptyInputErr(fmt.Errorf("userID must be defined"))
+	}
+	pageSize := contentBatchSize
+	if limit != nil {
+		pageSize = *limit
+	}
+	if pageSize <= 0 || pageSize > maxRecommendedContent {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("limit must be between 1 and %d", maxRecommendedContent))
+	}
+
+	return u.Query.ListRecommendedContent(ctx, userID, pageSize)
+}
+
+// ReconcileContentEngagement reports the content items whose like, bookmark, share and view counts have drifted from
+// the number of users who have engaged with them. Unless it is a dry run, the counters that have drifted are rewritten.
+func (u *UseCasesContentImpl) ReconcileContentEngagement(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error) {
+	return u.Update.ReconcileContentEngagement(ctx, dryRun)
+}
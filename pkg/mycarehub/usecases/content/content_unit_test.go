@@ -3,11 +3,16 @@ package content_test
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/content"
@@ -185,7 +190,6 @@ func TestUseCaseContentImpl_ShareContent(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		want    bool
 		wantErr bool
 	}{
 		{
@@ -195,12 +199,47 @@ func TestUseCaseContentImpl_ShareContent(t *testing.T) {
 				input: dto.ShareContentInput{
 					ContentID: gofakeit.Number(1, 100),
 					UserID:    uuid.New().String(),
-					Channel:   "SMS",
+					Channel:   enums.ContentShareChannelSMS,
 				},
 			},
-			want:    true,
 			wantErr: false,
 		},
+		{
+			name: "Sad Case - invalid channel",
+			args: args{
+				ctx: ctx,
+				input: dto.ShareContentInput{
+					ContentID: gofakeit.Number(1, 100),
+					UserID:    uuid.New().String(),
+					Channel:   enums.ContentShareChannel("TELEGRAM"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - share links not configured",
+			args: args{
+				ctx: ctx,
+				input: dto.ShareContentInput{
+					ContentID: gofakeit.Number(1, 100),
+					UserID:    uuid.New().String(),
+					Channel:   enums.ContentShareChannelSMS,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - failed to share content",
+			args: args{
+				ctx: ctx,
+				input: dto.ShareContentInput{
+					ContentID: gofakeit.Number(1, 100),
+					UserID:    uuid.New().String(),
+					Channel:   enums.ContentShareChannelSMS,
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -208,16 +247,160 @@ func TestUseCaseContentImpl_ShareContent(t *testing.T) {
 			fakeDB := pgMock.NewPostgresMock()
 			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
 
-			got, err := c.Update.ShareContent(tt.args.ctx, tt.args.input)
+			t.Setenv(content.ContentShareBaseURL, "https://mycarehub.example.com/")
+			t.Setenv(content.ContentShareSigningKey, "secret")
+
+			if tt.name == "Sad Case - share links not configured" {
+				t.Setenv(content.ContentShareSigningKey, "")
+			}
+			if tt.name == "Sad Case - failed to share content" {
+				fakeDB.MockShareContentFn = func(ctx context.Context, input dto.ShareContentInput) (*domain.ContentShareLink, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := c.ShareContent(tt.args.ctx, tt.args.input)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UseCaseContentImpl.ShareContent() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErr {
+				return
+			}
+			wantPrefix := fmt.Sprintf("https://mycarehub.example.com/content/%d?share=%s.", tt.args.input.ContentID, got.ID)
+			if !strings.HasPrefix(got.Link, wantPrefix) {
+				t.Errorf("expected the share link to start with %v, got %v", wantPrefix, got.Link)
+			}
+		})
+	}
+}
+
+func TestUseCaseContentImpl_OpenSharedContent(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv(content.ContentShareBaseURL, "https://mycarehub.example.com")
+	t.Setenv(content.ContentShareSigningKey, "secret")
+
+	fakeDB := pgMock.NewPostgresMock()
+	c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+	shareLink, err := c.ShareContent(ctx, dto.ShareContentInput{
+		ContentID: 10,
+		UserID:    uuid.New().String(),
+		Channel:   enums.ContentShareChannelWhatsApp,
+	})
+	if err != nil {
+		t.Errorf("failed to share content: %v", err)
+		return
+	}
+	link, err := url.Parse(shareLink.Link)
+	if err != nil {
+		t.Errorf("failed to parse share link: %v", err)
+		return
+	}
+	token := link.Query().Get("share")
+	openedByID := uuid.New().String()
+
+	type args struct {
+		ctx        context.Context
+		token      string
+		openedByID *string
+		client     string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				token:      token,
+				openedByID: &openedByID,
+			},
+			want:    10,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - opened on the web",
+			args: args{
+				ctx:    ctx,
+				token:  token,
+				client: "192.0.2.1",
+			},
+			want:    10,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - empty token",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - tampered token",
+			args: args{
+				ctx:   ctx,
+				token: uuid.New().String() + token[strings.Index(token, "."):],
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - unsigned token",
+			args: args{
+				ctx:   ctx,
+				token: shareLink.ID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to record content share open",
+			args: args{
+				ctx:   ctx,
+				token: token,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to record content share open" {
+				fakeDB.MockRecordContentShareOpenFn = func(ctx context.Context, shareLinkID string, openedByID *string) (*domain.ContentShareLink, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := c.OpenSharedContent(tt.args.ctx, tt.args.token, tt.args.openedByID, tt.args.client)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseContentImpl.OpenSharedContent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
 			if got != tt.want {
-				t.Errorf("UseCaseContentImpl.ShareContent() = %v, want %v", got, tt.want)
+				t.Errorf("UseCaseContentImpl.OpenSharedContent() = %v, want %v", got, tt.want)
 			}
 		})
 	}
+
+	// opening the link again on the web from the same client isn't recorded again
+	recorded := 0
+	fakeDB.MockRecordContentShareOpenFn = func(ctx context.Context, shareLinkID string, openedByID *string) (*domain.ContentShareLink, error) {
+		recorded++
+		return &domain.ContentShareLink{ID: shareLinkID, ContentID: 10}, nil
+	}
+	for _, client := range []string{"192.0.2.1", "192.0.2.1", "192.0.2.2"} {
+		got, err := c.OpenSharedContent(ctx, token, nil, client)
+		if err != nil || got != 10 {
+			t.Errorf("UseCaseContentImpl.OpenSharedContent() = %v, %v", got, err)
+			return
+		}
+	}
+	if recorded != 2 {
+		t.Errorf("expected an open to be recorded once per client, got %v opens", recorded)
+	}
 }
 
 func TestUseCasesContentImpl_UnlikeContent(t *testing.T) {
@@ -419,10 +602,20 @@ func TestUseCasesContentImpl_GetUserBookmarkedContent(t *testing.T) {
 func TestUseCasesContentImpl_GetContent(t *testing.T) {
 	ctx := context.Background()
 	categoryID := 1
+	mostLiked := enums.ContentSortOrderMostLiked
+	trending := enums.ContentSortOrderTrending
+	invalidSort := enums.ContentSortOrder("invalid")
+	trendingDays := 30
+	invalidTrendingDays := 0
+	invalidCursor := "not a cursor"
+
 	type args struct {
-		ctx        context.Context
-		categoryID *int
-		limit      string
+		ctx          context.Context
+		categoryID   *int
+		limit        int
+		sort         *enums.ContentSortOrder
+		trendingDays *int
+		cursor       *string
 	}
 	tests := []struct {
 		name    string
@@ -433,18 +626,116 @@ func TestUseCasesContentImpl_GetContent(t *testing.T) {
 			name: "Happy Case - Successfully get content",
 			args: args{
 				ctx:        ctx,
-				limit:      "10",
+				limit:      10,
+				categoryID: &categoryID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy Case - Successfully get content from the database",
+			args: args{
+				ctx:        ctx,
+				limit:      10,
 				categoryID: &categoryID,
 			},
 			wantErr: false,
 		},
+		{
+			name: "Happy Case - Successfully get the most liked content",
+			args: args{
+				ctx:   ctx,
+				limit: 10,
+				sort:  &mostLiked,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy Case - Successfully get trending content",
+			args: args{
+				ctx:          ctx,
+				limit:        10,
+				sort:         &trending,
+				trendingDays: &trendingDays,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad Case - Fail to get content from the database",
+			args: args{
+				ctx:   ctx,
+				limit: 10,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - Invalid limit",
+			args: args{
+				ctx:   ctx,
+				limit: 0,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - Limit is larger than a page",
+			args: args{
+				ctx:   ctx,
+				limit: 21,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - Invalid sort order",
+			args: args{
+				ctx:   ctx,
+				limit: 10,
+				sort:  &invalidSort,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - Invalid trending days",
+			args: args{
+				ctx:          ctx,
+				limit:        10,
+				sort:         &trending,
+				trendingDays: &invalidTrendingDays,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - Invalid cursor",
+			args: args{
+				ctx:    ctx,
+				limit:  10,
+				cursor: &invalidCursor,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.name != "Happy Case - Successfully get content" {
+				t.Setenv(content.ContentFromDatabase, "true")
+			}
+
 			fakeDB := pgMock.NewPostgresMock()
 			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
 
-			got, err := c.GetContent(tt.args.ctx, tt.args.categoryID, tt.args.limit)
+			if tt.name == "Happy Case - Successfully get trending content" {
+				fakeDB.MockListContentFeedFn = func(ctx context.Context, categoryID *int, sort enums.ContentSortOrder, days int, after *domain.ContentFeedPosition, before *domain.ContentFeedPosition, limit int) (*domain.ContentFeedPage, error) {
+					if sort != trending || days != trendingDays {
+						return nil, fmt.Errorf("expected trending content over %v days, got %v over %v days", trendingDays, sort, days)
+					}
+					return &domain.ContentFeedPage{Content: &domain.Content{}}, nil
+				}
+			}
+			if tt.name == "Sad Case - Fail to get content from the database" {
+				fakeDB.MockListContentFeedFn = func(ctx context.Context, categoryID *int, sort enums.ContentSortOrder, trendingDays int, after *domain.ContentFeedPosition, before *domain.ContentFeedPosition, limit int) (*domain.ContentFeedPage, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := c.GetContent(tt.args.ctx, tt.args.categoryID, tt.args.limit, tt.args.sort, tt.args.trendingDays, tt.args.cursor)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UseCasesContentImpl.GetContent() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -952,3 +1243,1313 @@ func TestUseCasesContentImpl_UnBookmarkContent(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCasesContentImpl_InvalidateContentCache(t *testing.T) {
+	ctx := context.Background()
+	type args struct {
+		ctx       context.Context
+		contentID int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				contentID: 1,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no contentID",
+			args: args{
+				ctx: ctx,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			got, err := c.InvalidateContentCache(tt.args.ctx, tt.args.contentID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.InvalidateContentCache() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesContentImpl.InvalidateContentCache() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_SearchContent(t *testing.T) {
+	ctx := context.Background()
+	itemType := "ARTICLE"
+	swahili := enumutils.LanguageSw
+	invalidLanguage := enumutils.Language("invalid")
+
+	type args struct {
+		ctx             context.Context
+		query           string
+		categoryIDs     []int
+		itemType        *string
+		language        *enumutils.Language
+		paginationInput dto.PaginationsInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:             ctx,
+				query:           "  side effects ",
+				categoryIDs:     []int{1},
+				itemType:        &itemType,
+				language:        &swahili,
+				paginationInput: dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - blank query",
+			args: args{
+				ctx:             ctx,
+				query:           "   ",
+				paginationInput: dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid language",
+			args: args{
+				ctx:             ctx,
+				query:           "PrEP",
+				language:        &invalidLanguage,
+				paginationInput: dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to search content",
+			args: args{
+				ctx:             ctx,
+				query:           "PrEP",
+				paginationInput: dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			if tt.name == "Happy case" {
+				fakeDB.MockSearchContentFn = func(ctx context.Context, input *dto.ContentSearchInput, paginationsInput *dto.PaginationsInput) (*domain.ContentSearchPage, error) {
+					if input.Query != "side effects" || input.ItemType != itemType || input.Language != swahili {
+						return nil, fmt.Errorf("unexpected search input %+v", input)
+					}
+					return &domain.ContentSearchPage{
+						Results: []*domain.ContentSearchResult{{Item: domain.ContentItem{ID: 1}, Rank: 0.5}},
+					}, nil
+				}
+			}
+			if tt.name == "Sad case - failed to search content" {
+				fakeDB.MockSearchContentFn = func(ctx context.Context, input *dto.ContentSearchInput, paginationsInput *dto.PaginationsInput) (*domain.ContentSearchPage, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := c.SearchContent(tt.args.ctx, tt.args.query, tt.args.categoryIDs, tt.args.itemType, tt.args.language, tt.args.paginationInput)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.SearchContent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got.Results) != 1 {
+				t.Errorf("expected 1 search result, got %v", len(got.Results))
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_RecommendedContent(t *testing.T) {
+	ctx := context.Background()
+	limit := 5
+	invalidLimit := 51
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		limit  *int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				limit:  &limit,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - default limit",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx:   ctx,
+				limit: &limit,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - limit is too large",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				limit:  &invalidLimit,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to list recommended content",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				limit:  &limit,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			if tt.name == "Happy case - default limit" {
+				fakeDB.MockListRecommendedContentFn = func(ctx context.Context, userID string, limit int) (*domain.Content, error) {
+					if limit != 20 {
+						return nil, fmt.Errorf("expected the default limit, got %v", limit)
+					}
+					return &domain.Content{}, nil
+				}
+			}
+			if tt.name == "Sad case - failed to list recommended content" {
+				fakeDB.MockListRecommendedContentFn = func(ctx context.Context, userID string, limit int) (*domain.Content, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			_, err := c.RecommendedContent(tt.args.ctx, tt.args.userID, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.RecommendedContent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_ReconcileContentEngagement(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx    context.Context
+		dryRun bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case - dry run",
+			args: args{
+				ctx:    ctx,
+				dryRun: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - rewrite the counters",
+			args: args{
+				ctx:    ctx,
+				dryRun: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - failed to reconcile content engagement",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to reconcile content engagement" {
+				fakeDB.MockReconcileContentEngagementFn = func(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			_, err := c.ReconcileContentEngagement(tt.args.ctx, tt.args.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.ReconcileContentEngagement() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_RecordContentReadDuration(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx             context.Context
+		userID          string
+		contentID       int
+		durationSeconds int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:             ctx,
+				userID:          uuid.New().String(),
+				contentID:       10,
+				durationSeconds: 90,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - long reads are capped",
+			args: args{
+				ctx:             ctx,
+				userID:          uuid.New().String(),
+				contentID:       10,
+				durationSeconds: 8 * 60 * 60,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx:             ctx,
+				contentID:       10,
+				durationSeconds: 90,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no duration",
+			args: args{
+				ctx:       ctx,
+				userID:    uuid.New().String(),
+				contentID: 10,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to record read duration",
+			args: args{
+				ctx:             ctx,
+				userID:          uuid.New().String(),
+				contentID:       10,
+				durationSeconds: 90,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			if tt.name == "Happy case - long reads are capped" {
+				fakeDB.MockRecordContentReadDurationFn = func(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error) {
+					if durationSeconds != 60*60 {
+						return false, fmt.Errorf("expected the read to be capped at an hour, got %v seconds", durationSeconds)
+					}
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case - failed to record read duration" {
+				fakeDB.MockRecordContentReadDurationFn = func(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := c.RecordContentReadDuration(tt.args.ctx, tt.args.userID, tt.args.contentID, tt.args.durationSeconds)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.RecordContentReadDuration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesContentImpl.RecordContentReadDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_GetContentAnalytics(t *testing.T) {
+	ctx := context.Background()
+	from := time.Now().AddDate(0, -1, 0)
+
+	type args struct {
+		ctx   context.Context
+		input dto.ContentAnalyticsInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:   ctx,
+				input: dto.ContentAnalyticsInput{From: from, To: time.Now(), CategoryIDs: []int{1}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - invalid period",
+			args: args{
+				ctx:   ctx,
+				input: dto.ContentAnalyticsInput{From: time.Now(), To: from},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to get content analytics",
+			args: args{
+				ctx:   ctx,
+				input: dto.ContentAnalyticsInput{From: from, To: time.Now()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to get content analytics" {
+				fakeDB.MockGetContentAnalyticsFn = func(ctx context.Context, input *dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			_, err := c.GetContentAnalytics(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.GetContentAnalytics() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_ExportContentAnalytics(t *testing.T) {
+	ctx := context.Background()
+	input := dto.ContentAnalyticsInput{From: time.Now().AddDate(0, -1, 0), To: time.Now()}
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			want: "type,id,name,content_items,views,unique_viewers,likes,bookmarks,shares,average_read_seconds\n" +
+				"content_item,10,PrEP and you,,10,4,2,1,1,95.5\n" +
+				"category,1,Prevention,1,10,4,2,1,1,95.5\n",
+			wantErr: false,
+		},
+		{
+			name: "Happy case - titles that look like formulas are escaped",
+			want: "type,id,name,content_items,views,unique_viewers,likes,bookmarks,shares,average_read_seconds\n" +
+				"content_item,10,\"'=HYPERLINK(\"\"http://example.com\"\")\",,0,0,0,0,0,0.0\n",
+			wantErr: false,
+		},
+		{
+			name:    "Sad case - failed to get content analytics",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			if tt.name == "Happy case - titles that look like formulas are escaped" {
+				fakeDB.MockGetContentAnalyticsFn = func(ctx context.Context, input *dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error) {
+					return &domain.ContentAnalytics{
+						Items: []*domain.ContentItemAnalytics{
+							{ContentID: 10, Title: `=HYPERLINK("http://example.com")`},
+						},
+					}, nil
+				}
+			}
+			if tt.name == "Sad case - failed to get content analytics" {
+				fakeDB.MockGetContentAnalyticsFn = func(ctx context.Context, input *dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := c.ExportContentAnalytics(ctx, input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.ExportContentAnalytics() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesContentImpl.ExportContentAnalytics() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_RecordContentProgress(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx             context.Context
+		userID          string
+		contentID       int
+		secondsSpent    int
+		percentScrolled float64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:             ctx,
+				userID:          uuid.New().String(),
+				contentID:       10,
+				secondsSpent:    30,
+				percentScrolled: 40,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - long reads are capped",
+			args: args{
+				ctx:             ctx,
+				userID:          uuid.New().String(),
+				contentID:       10,
+				secondsSpent:    8 * 60 * 60,
+				percentScrolled: 100,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no contentID",
+			args: args{
+				ctx:             ctx,
+				userID:          uuid.New().String(),
+				secondsSpent:    30,
+				percentScrolled: 40,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - negative seconds spent",
+			args: args{
+				ctx:             ctx,
+				userID:          uuid.New().String(),
+				contentID:       10,
+				secondsSpent:    -1,
+				percentScrolled: 40,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - scrolled past the end",
+			args: args{
+				ctx:             ctx,
+				userID:          uuid.New().String(),
+				contentID:       10,
+				secondsSpent:    30,
+				percentScrolled: 101,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to record content progress",
+			args: args{
+				ctx:             ctx,
+				userID:          uuid.New().String(),
+				contentID:       10,
+				secondsSpent:    30,
+				percentScrolled: 40,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			if tt.name == "Happy case - long reads are capped" {
+				fakeDB.MockRecordContentProgressFn = func(ctx context.Context, userID string, contentID int, secondsSpent int, percentScrolled float64, threshold domain.ContentCompletionThreshold) (*domain.ContentProgressUpdate, error) {
+					if secondsSpent != 60*60 {
+						return nil, fmt.Errorf("expected the time spent to be capped at an hour, got %v seconds", secondsSpent)
+					}
+					return &domain.ContentProgressUpdate{}, nil
+				}
+			}
+			if tt.name == "Sad case - failed to record content progress" {
+				fakeDB.MockRecordContentProgressFn = func(ctx context.Context, userID string, contentID int, secondsSpent int, percentScrolled float64, threshold domain.ContentCompletionThreshold) (*domain.ContentProgressUpdate, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			_, err := c.RecordContentProgress(tt.args.ctx, tt.args.userID, tt.args.contentID, tt.args.secondsSpent, tt.args.percentScrolled)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.RecordContentProgress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_ContinueReading(t *testing.T) {
+	ctx := context.Background()
+	limit := 5
+	invalidLimit := 21
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		limit  *int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				limit:  &limit,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - default limit",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx:   ctx,
+				limit: &limit,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - limit is too large",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				limit:  &invalidLimit,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to list content in progress",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				limit:  &limit,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to list content in progress" {
+				fakeDB.MockListContentInProgressFn = func(ctx context.Context, userID string, limit int) ([]*domain.ContentProgress, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			_, err := c.ContinueReading(tt.args.ctx, tt.args.userID, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.ContinueReading() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_ListContentComments(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+	otherUserID := uuid.New().String()
+
+	threadID := uuid.New().String()
+	deletedThreadID := uuid.New().String()
+	comments := []*domain.ContentComment{
+		{ID: threadID, ContentID: 10, UserID: otherUserID, Nickname: "amani", Body: "How long should I take the medication?", Status: enums.ContentCommentStatusApproved},
+		{ID: uuid.New().String(), ContentID: 10, UserID: otherUserID, ParentID: &threadID, Nickname: "amani", Body: "Ask your clinician", Status: enums.ContentCommentStatusApproved},
+		{ID: uuid.New().String(), ContentID: 10, UserID: otherUserID, ParentID: &threadID, Nickname: "amani", Body: "Held by the filter", Status: enums.ContentCommentStatusPending},
+		{ID: uuid.New().String(), ContentID: 10, UserID: userID, ParentID: &threadID, Nickname: "baraka", Body: "Held by the filter", Status: enums.ContentCommentStatusPending},
+		{ID: deletedThreadID, ContentID: 10, UserID: otherUserID, Nickname: "amani", Body: "Deleted question", Status: enums.ContentCommentStatusApproved, Deleted: true},
+		{ID: uuid.New().String(), ContentID: 10, UserID: userID, ParentID: &deletedThreadID, Nickname: "baraka", Body: "An answer", Status: enums.ContentCommentStatusApproved},
+		{ID: uuid.New().String(), ContentID: 10, UserID: otherUserID, Nickname: "amani", Body: "Rejected comment", Status: enums.ContentCommentStatusRejected},
+	}
+
+	type args struct {
+		ctx       context.Context
+		userID    string
+		contentID int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				contentID: 10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no userID",
+			args: args{
+				ctx:       ctx,
+				contentID: 10,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to list content comments",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				contentID: 10,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			fakeDB.MockListContentCommentsFn = func(ctx context.Context, contentID int) ([]*domain.ContentComment, error) {
+				listed := []*domain.ContentComment{}
+				for _, comment := range comments {
+					copied := *comment
+					copied.Replies = []*domain.ContentComment{}
+					listed = append(listed, &copied)
+				}
+				return listed, nil
+			}
+			if tt.name == "Sad case - failed to list content comments" {
+				fakeDB.MockListContentCommentsFn = func(ctx context.Context, contentID int) ([]*domain.ContentComment, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := c.ListContentComments(tt.args.ctx, tt.args.userID, tt.args.contentID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.ListContentComments() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != 2 {
+				t.Errorf("expected 2 threads, got %v", len(got))
+				return
+			}
+			if got[0].ID != threadID || len(got[0].Replies) != 2 || !got[0].Replies[1].IsAuthor {
+				t.Errorf("expected the thread to have the approved reply and the user's own pending reply, got %v", got[0].Replies)
+			}
+			if got[1].ID != deletedThreadID || !got[1].Removed || got[1].Body != "" || got[1].Nickname != "" {
+				t.Errorf("expected the deleted comment to be kept without its body for its replies, got %v", got[1])
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_CreateContentComment(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+	parentID := uuid.New().String()
+	threadID := uuid.New().String()
+
+	type args struct {
+		ctx       context.Context
+		userID    string
+		contentID int
+		parentID  *string
+		body      string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantStatus   enums.ContentCommentStatus
+		wantParentID *string
+		wantErr      bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				contentID: 10,
+				body:      "  How long should I take the medication?  ",
+			},
+			wantStatus: enums.ContentCommentStatusApproved,
+			wantErr:    false,
+		},
+		{
+			name: "Happy case - profane comment is held for moderation",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				contentID: 10,
+				body:      "This medication is shit",
+			},
+			wantStatus: enums.ContentCommentStatusPending,
+			wantErr:    false,
+		},
+		{
+			name: "Happy case - reply",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				contentID: 10,
+				parentID:  &parentID,
+				body:      "Ask your clinician",
+			},
+			wantStatus:   enums.ContentCommentStatusApproved,
+			wantParentID: &parentID,
+			wantErr:      false,
+		},
+		{
+			name: "Happy case - reply to a reply joins the thread",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				contentID: 10,
+				parentID:  &parentID,
+				body:      "Ask your clinician",
+			},
+			wantStatus:   enums.ContentCommentStatusApproved,
+			wantParentID: &threadID,
+			wantErr:      false,
+		},
+		{
+			name: "Sad case - empty comment",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				contentID: 10,
+				body:      "   ",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - comment is too long",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				contentID: 10,
+				body:      strings.Repeat("a", 2001),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - parent is on another content item",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				contentID: 11,
+				parentID:  &parentID,
+				body:      "Ask your clinician",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - parent has been removed",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				contentID: 10,
+				parentID:  &parentID,
+				body:      "Ask your clinician",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to create content comment",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				contentID: 10,
+				body:      "How long should I take the medication?",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			if tt.name == "Happy case - reply to a reply joins the thread" {
+				fakeDB.MockGetContentCommentByIDFn = func(ctx context.Context, commentID string) (*domain.ContentComment, error) {
+					return &domain.ContentComment{ID: commentID, ContentID: 10, ParentID: &threadID, Status: enums.ContentCommentStatusApproved}, nil
+				}
+			}
+			if tt.name == "Sad case - parent has been removed" {
+				fakeDB.MockGetContentCommentByIDFn = func(ctx context.Context, commentID string) (*domain.ContentComment, error) {
+					return &domain.ContentComment{ID: commentID, ContentID: 10, Status: enums.ContentCommentStatusRejected}, nil
+				}
+			}
+			if tt.name == "Sad case - failed to create content comment" {
+				fakeDB.MockCreateContentCommentFn = func(ctx context.Context, userID string, contentID int, parentID *string, body string, status enums.ContentCommentStatus) (*domain.ContentComment, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := c.CreateContentComment(tt.args.ctx, tt.args.userID, tt.args.contentID, tt.args.parentID, tt.args.body)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.CreateContentComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("expected the comment to be %v, got %v", tt.wantStatus, got.Status)
+			}
+			if got.Body != strings.TrimSpace(tt.args.body) || !got.IsAuthor {
+				t.Errorf("expected the trimmed comment by its author, got %v", got)
+			}
+			if (got.ParentID == nil) != (tt.wantParentID == nil) || (got.ParentID != nil && *got.ParentID != *tt.wantParentID) {
+				t.Errorf("expected parent %v, got %v", tt.wantParentID, got.ParentID)
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_EditContentComment(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+
+	type args struct {
+		ctx       context.Context
+		userID    string
+		commentID string
+		body      string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus enums.ContentCommentStatus
+		wantErr    bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				commentID: uuid.New().String(),
+				body:      "How long should I take the medication for?",
+			},
+			wantStatus: enums.ContentCommentStatusApproved,
+			wantErr:    false,
+		},
+		{
+			name: "Happy case - profane edit is held for moderation",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				commentID: uuid.New().String(),
+				body:      "This medication is shit",
+			},
+			wantStatus: enums.ContentCommentStatusPending,
+			wantErr:    false,
+		},
+		{
+			name: "Sad case - not the author",
+			args: args{
+				ctx:       ctx,
+				userID:    uuid.New().String(),
+				commentID: uuid.New().String(),
+				body:      "How long should I take the medication for?",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - comment has been deleted",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				commentID: uuid.New().String(),
+				body:      "How long should I take the medication for?",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - empty comment",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				commentID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to get content comment",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				commentID: uuid.New().String(),
+				body:      "How long should I take the medication for?",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			fakeDB.MockGetContentCommentByIDFn = func(ctx context.Context, commentID string) (*domain.ContentComment, error) {
+				return &domain.ContentComment{
+					ID:        commentID,
+					ContentID: 10,
+					UserID:    userID,
+					Status:    enums.ContentCommentStatusApproved,
+					Deleted:   tt.name == "Sad case - comment has been deleted",
+				}, nil
+			}
+			if tt.name == "Sad case - failed to get content comment" {
+				fakeDB.MockGetContentCommentByIDFn = func(ctx context.Context, commentID string) (*domain.ContentComment, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := c.EditContentComment(tt.args.ctx, tt.args.userID, tt.args.commentID, tt.args.body)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.EditContentComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Status != tt.wantStatus {
+				t.Errorf("expected the comment to be %v, got %v", tt.wantStatus, got.Status)
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_DeleteContentComment(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+
+	type args struct {
+		ctx       context.Context
+		userID    string
+		commentID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				commentID: uuid.New().String(),
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - already deleted",
+			args: args{
+				ctx:       ctx,
+				userID:    userID,
+				commentID: uuid.New().String(),
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - not the author",
+			args: args{
+				ctx:       ctx,
+				userID:    uuid.New().String(),
+				commentID: uuid.New().String(),
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no commentID",
+			args: args{
+				ctx:    ctx,
+				userID: userID,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			fakeDB.MockGetContentCommentByIDFn = func(ctx context.Context, commentID string) (*domain.ContentComment, error) {
+				return &domain.ContentComment{
+					ID:        commentID,
+					ContentID: 10,
+					UserID:    userID,
+					Status:    enums.ContentCommentStatusApproved,
+					Deleted:   tt.name == "Sad case - already deleted",
+				}, nil
+			}
+			if tt.name == "Sad case - already deleted" {
+				fakeDB.MockDeleteContentCommentFn = func(ctx context.Context, commentID string) (bool, error) {
+					return false, fmt.Errorf("a deleted comment should not be deleted again")
+				}
+			}
+
+			got, err := c.DeleteContentComment(tt.args.ctx, tt.args.userID, tt.args.commentID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.DeleteContentComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesContentImpl.DeleteContentComment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_ContentCommentModerationQueue(t *testing.T) {
+	ctx := context.Background()
+	flagged := enums.ContentCommentStatusFlagged
+	invalidStatus := enums.ContentCommentStatus("invalid")
+	limit := 10
+	invalidLimit := 101
+
+	type args struct {
+		ctx    context.Context
+		status *enums.ContentCommentStatus
+		limit  *int
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantStatuses []enums.ContentCommentStatus
+		wantLimit    int
+		wantErr      bool
+	}{
+		{
+			name: "Happy case - default queue",
+			args: args{
+				ctx: ctx,
+			},
+			wantStatuses: []enums.ContentCommentStatus{enums.ContentCommentStatusPending, enums.ContentCommentStatusFlagged},
+			wantLimit:    50,
+			wantErr:      false,
+		},
+		{
+			name: "Happy case - flagged comments",
+			args: args{
+				ctx:    ctx,
+				status: &flagged,
+				limit:  &limit,
+			},
+			wantStatuses: []enums.ContentCommentStatus{enums.ContentCommentStatusFlagged},
+			wantLimit:    10,
+			wantErr:      false,
+		},
+		{
+			name: "Sad case - invalid status",
+			args: args{
+				ctx:    ctx,
+				status: &invalidStatus,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - limit is too large",
+			args: args{
+				ctx:   ctx,
+				limit: &invalidLimit,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			var gotStatuses []enums.ContentCommentStatus
+			var gotLimit int
+			fakeDB.MockListContentCommentsByStatusFn = func(ctx context.Context, statuses []enums.ContentCommentStatus, limit int) ([]*domain.ContentComment, error) {
+				gotStatuses = statuses
+				gotLimit = limit
+				return []*domain.ContentComment{}, nil
+			}
+
+			_, err := c.ContentCommentModerationQueue(tt.args.ctx, tt.args.status, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.ContentCommentModerationQueue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if fmt.Sprint(gotStatuses) != fmt.Sprint(tt.wantStatuses) || gotLimit != tt.wantLimit {
+				t.Errorf("expected %v comments in %v, got %v comments in %v", tt.wantLimit, tt.wantStatuses, gotLimit, gotStatuses)
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_ModerateContentComment(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx         context.Context
+		moderatorID string
+		commentID   string
+		status      enums.ContentCommentStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case - approve",
+			args: args{
+				ctx:         ctx,
+				moderatorID: uuid.New().String(),
+				commentID:   uuid.New().String(),
+				status:      enums.ContentCommentStatusApproved,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - flag",
+			args: args{
+				ctx:         ctx,
+				moderatorID: uuid.New().String(),
+				commentID:   uuid.New().String(),
+				status:      enums.ContentCommentStatusFlagged,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - cannot move a comment back to pending",
+			args: args{
+				ctx:         ctx,
+				moderatorID: uuid.New().String(),
+				commentID:   uuid.New().String(),
+				status:      enums.ContentCommentStatusPending,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no commentID",
+			args: args{
+				ctx:         ctx,
+				moderatorID: uuid.New().String(),
+				status:      enums.ContentCommentStatusRejected,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to moderate content comment",
+			args: args{
+				ctx:         ctx,
+				moderatorID: uuid.New().String(),
+				commentID:   uuid.New().String(),
+				status:      enums.ContentCommentStatusRejected,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			c := content.NewUseCasesContentImplementation(fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to moderate content comment" {
+				fakeDB.MockModerateContentCommentFn = func(ctx context.Context, commentID string, status enums.ContentCommentStatus, moderatorID string) (*domain.ContentComment, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := c.ModerateContentComment(tt.args.ctx, tt.args.moderatorID, tt.args.commentID, tt.args.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesContentImpl.ModerateContentComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Status != tt.args.status {
+				t.Errorf("expected the comment to be %v, got %v", tt.args.status, got.Status)
+			}
+		})
+	}
+}
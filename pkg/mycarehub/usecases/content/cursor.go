@@ -0,0 +1,104 @@
+package content
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+const (
+	// defaultTrendingDays is how far back engagement counts towards trending content when no period is chosen
+	defaultTrendingDays = 7
+
+	// maxTrendingDays is the longest period that engagement can count towards trending content
+	maxTrendingDays = 90
+)
+
+// contentCursor is a position in the content feed. Cursors are handed to clients as opaque strings so that
+// how a position is worked out can change without changing the API.
+type contentCursor struct {
+	Sort         enums.ContentSortOrder     `json:"sort"`
+	TrendingDays int                        `json:"trendingDays,omitempty"`
+	Position     domain.ContentFeedPosition `json:"position"`
+
+	// Before marks a cursor to the page that ends before the position instead of the page that starts after it
+	Before bool `json:"before,omitempty"`
+}
+
+// encode turns the cursor into the opaque string handed to clients
+func (c contentCursor) encode() string {
+	// marshalling a struct of strings, integers and a time can't fail
+	encoded, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// decodeContentCursor reads a cursor that was handed to a client
+func decodeContentCursor(cursor string) (*contentCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+	var position contentCursor
+	if err := json.Unmarshal(decoded, &position); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+	if !position.Sort.IsValid() || position.Position.ContentID <= 0 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &position, nil
+}
+
+// pageCursors returns the cursors of the pages after and before a page of the feed in the cursor's order. The
+// page was read from the given cursor, or from the start of the feed when there is none. A cursor is nil when
+// there is no page to move to.
+func (c contentCursor) pageCursors(from *contentCursor, page *domain.ContentFeedPage) (next *string, previous *string) {
+	cursorAt := func(position domain.ContentFeedPosition, before bool) *string {
+		cursor := contentCursor{Sort: c.Sort, TrendingDays: c.TrendingDays, Position: position, Before: before}.encode()
+		return &cursor
+	}
+
+	if len(page.Positions) == 0 {
+		// the way back from an empty page is the other side of the position it was read from
+		if from != nil && from.Before {
+			next = cursorAt(from.Position, false)
+		} else if from != nil {
+			previous = cursorAt(from.Position, true)
+		}
+		return next, previous
+	}
+
+	// a page read backwards from a cursor always has the page it was read from after it
+	hasNext, hasPrevious := page.HasMore, from != nil
+	if from != nil && from.Before {
+		hasNext, hasPrevious = true, page.HasMore
+	}
+	if hasNext {
+		next = cursorAt(*page.Positions[len(page.Positions)-1], false)
+	}
+	if hasPrevious {
+		previous = cursorAt(*page.Positions[0], true)
+	}
+	return next, previous
+}
+
+// newestContentPage works out the position in the feed of each item of a page of the newest content read from
+// the CMS API
+func newestContentPage(content *domain.Content) (*domain.ContentFeedPage, error) {
+	page := &domain.ContentFeedPage{
+		Content:   content,
+		Positions: []*domain.ContentFeedPosition{},
+		HasMore:   content.Meta.TotalCount > len(content.Items),
+	}
+	for _, item := range content.Items {
+		publishedAt, err := time.Parse(time.RFC3339, item.Meta.FirstPublishedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid publication date of content item %d: %v", item.ID, err)
+		}
+		page.Positions = append(page.Positions, &domain.ContentFeedPosition{FirstPublishedAt: publishedAt, ContentID: item.ID})
+	}
+	return page, nil
+}
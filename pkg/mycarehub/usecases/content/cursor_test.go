@@ -0,0 +1,226 @@
+package content
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+)
+
+func TestDecodeContentCursor(t *testing.T) {
+	position := contentCursor{
+		Sort:         enums.ContentSortOrderTrending,
+		TrendingDays: 7,
+		Position: domain.ContentFeedPosition{
+			SortKey:          12,
+			FirstPublishedAt: time.Date(2021, 11, 3, 10, 22, 1, 123456000, time.UTC),
+			ContentID:        20,
+		},
+		Before: true,
+	}
+
+	tests := []struct {
+		name    string
+		cursor  string
+		want    contentCursor
+		wantErr bool
+	}{
+		{
+			name:   "Happy case",
+			cursor: position.encode(),
+			want:   position,
+		},
+		{
+			name:    "Sad case - not base64",
+			cursor:  "not a cursor",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case - not a position",
+			cursor:  base64.RawURLEncoding.EncodeToString([]byte("20")),
+			wantErr: true,
+		},
+		{
+			name:    "Sad case - invalid sort order",
+			cursor:  contentCursor{Sort: "invalid", Position: domain.ContentFeedPosition{ContentID: 20}}.encode(),
+			wantErr: true,
+		},
+		{
+			name:    "Sad case - no content item",
+			cursor:  contentCursor{Sort: enums.ContentSortOrderNewest}.encode(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeContentCursor(tt.cursor)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decodeContentCursor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.Sort != tt.want.Sort || got.TrendingDays != tt.want.TrendingDays || got.Before != tt.want.Before ||
+				got.Position.SortKey != tt.want.Position.SortKey || got.Position.ContentID != tt.want.Position.ContentID ||
+				!got.Position.FirstPublishedAt.Equal(tt.want.Position.FirstPublishedAt)) {
+				t.Errorf("decodeContentCursor() = %v, want %v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentCursor_pageCursors(t *testing.T) {
+	feed := contentCursor{Sort: enums.ContentSortOrderMostLiked}
+	positions := []*domain.ContentFeedPosition{
+		{SortKey: 9, FirstPublishedAt: time.Now(), ContentID: 3},
+		{SortKey: 5, FirstPublishedAt: time.Now(), ContentID: 2},
+	}
+	after := &contentCursor{Sort: feed.Sort, Position: domain.ContentFeedPosition{SortKey: 10, ContentID: 4}}
+	before := &contentCursor{Sort: feed.Sort, Position: domain.ContentFeedPosition{SortKey: 4, ContentID: 1}, Before: true}
+
+	// cursorTo describes the position a cursor points at and which side of it the page is
+	type cursorTo struct {
+		contentID int
+		before    bool
+	}
+	tests := []struct {
+		name         string
+		from         *contentCursor
+		page         *domain.ContentFeedPage
+		wantNext     *cursorTo
+		wantPrevious *cursorTo
+	}{
+		{
+			name:     "first page",
+			page:     &domain.ContentFeedPage{Positions: positions, HasMore: true},
+			wantNext: &cursorTo{contentID: 2},
+		},
+		{
+			name: "only page",
+			page: &domain.ContentFeedPage{Positions: positions},
+		},
+		{
+			name:         "page after a cursor",
+			from:         after,
+			page:         &domain.ContentFeedPage{Positions: positions, HasMore: true},
+			wantNext:     &cursorTo{contentID: 2},
+			wantPrevious: &cursorTo{contentID: 3, before: true},
+		},
+		{
+			name:     "first page read back from a cursor",
+			from:     before,
+			page:     &domain.ContentFeedPage{Positions: positions},
+			wantNext: &cursorTo{contentID: 2},
+		},
+		{
+			name:         "empty page after a cursor",
+			from:         after,
+			page:         &domain.ContentFeedPage{Positions: []*domain.ContentFeedPosition{}},
+			wantPrevious: &cursorTo{contentID: 4, before: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, previous := feed.pageCursors(tt.from, tt.page)
+
+			for _, cursor := range []struct {
+				name string
+				got  *string
+				want *cursorTo
+			}{
+				{name: "next", got: next, want: tt.wantNext},
+				{name: "previous", got: previous, want: tt.wantPrevious},
+			} {
+				if (cursor.got == nil) != (cursor.want == nil) {
+					t.Errorf("expected a %v cursor to be %v, got %v", cursor.name, cursor.want != nil, cursor.got != nil)
+					continue
+				}
+				if cursor.got == nil {
+					continue
+				}
+				page, err := decodeContentCursor(*cursor.got)
+				if err != nil {
+					t.Errorf("failed to decode the %v cursor: %v", cursor.name, err)
+					continue
+				}
+				if page.Position.ContentID != cursor.want.contentID || page.Before != cursor.want.before || page.Sort != feed.Sort {
+					t.Errorf("expected the %v cursor to be at %v, got %v", cursor.name, *cursor.want, page)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCasesContentImpl_GetContent_cursor(t *testing.T) {
+	ctx := context.Background()
+	publishedAt := time.Date(2021, 11, 3, 10, 22, 1, 123456000, time.UTC)
+
+	fakeDB := pgMock.NewPostgresMock()
+	u := NewUseCasesContentImplementation(fakeDB, fakeDB)
+	u.readFromDatabase = false
+	u.cache.fetch = func(ctx context.Context, path string, headers http.Header) (*http.Response, error) {
+		return contentResponse(t, http.StatusOK, nil, 1, 2), nil
+	}
+
+	// the CMS response has no publication dates
+	if _, err := u.GetContent(ctx, nil, 2, nil, nil, nil); err == nil {
+		t.Errorf("expected content without a publication date to be rejected")
+	}
+
+	var gotAfter *domain.ContentFeedPosition
+	fakeDB.MockListContentFeedFn = func(ctx context.Context, categoryID *int, sort enums.ContentSortOrder, trendingDays int, after *domain.ContentFeedPosition, before *domain.ContentFeedPosition, limit int) (*domain.ContentFeedPage, error) {
+		gotAfter = after
+		return &domain.ContentFeedPage{
+			Content:   &domain.Content{Items: []domain.ContentItem{{ID: 1}}},
+			Positions: []*domain.ContentFeedPosition{{FirstPublishedAt: publishedAt.Add(-time.Hour), ContentID: 1}},
+		}, nil
+	}
+
+	cursor := contentCursor{
+		Sort:     enums.ContentSortOrderNewest,
+		Position: domain.ContentFeedPosition{FirstPublishedAt: publishedAt, ContentID: 2},
+	}.encode()
+	got, err := u.GetContent(ctx, nil, 2, nil, nil, &cursor)
+	if err != nil {
+		t.Errorf("UseCasesContentImpl.GetContent() error = %v", err)
+		return
+	}
+	if gotAfter == nil || gotAfter.ContentID != 2 || !gotAfter.FirstPublishedAt.Equal(publishedAt) {
+		t.Errorf("expected the page after content item 2 to be read from the database, got the page after %v", gotAfter)
+	}
+	if got.Meta.PreviousCursor == nil || got.Meta.NextCursor != nil {
+		t.Errorf("expected only a cursor to the previous page")
+	}
+
+	mostLiked := contentCursor{Sort: enums.ContentSortOrderMostLiked, Position: domain.ContentFeedPosition{ContentID: 2}}.encode()
+	if _, err := u.GetContent(ctx, nil, 2, nil, nil, &mostLiked); err == nil {
+		t.Errorf("expected a cursor from a feed in a different order to be rejected")
+	}
+}
+
+func TestNewestContentPage(t *testing.T) {
+	content := &domain.Content{
+		Meta: domain.Meta{TotalCount: 3},
+		Items: []domain.ContentItem{
+			{ID: 2, Meta: domain.ContentMeta{FirstPublishedAt: "2021-11-03T10:22:01.123456+03:00"}},
+			{ID: 1, Meta: domain.ContentMeta{FirstPublishedAt: "2021-11-02T08:00:00+03:00"}},
+		},
+	}
+
+	page, err := newestContentPage(content)
+	if err != nil {
+		t.Errorf("newestContentPage() error = %v", err)
+		return
+	}
+	if !page.HasMore || len(page.Positions) != 2 {
+		t.Errorf("expected the positions of 2 items with more content after them, got %v", page)
+		return
+	}
+	want := time.Date(2021, 11, 3, 7, 22, 1, 123456000, time.UTC)
+	if page.Positions[0].ContentID != 2 || !page.Positions[0].FirstPublishedAt.Equal(want) {
+		t.Errorf("expected content item 2 published at %v first, got %v", want, page.Positions[0])
+	}
+}
@@ -2,23 +2,42 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 )
 
 // ContentUsecaseMock contains the mock of contentusecase methods
 type ContentUsecaseMock struct {
 	MockListContentCategoriesFn           func(ctx context.Context) ([]*domain.ContentItemCategory, error)
-	MockShareContentFn                    func(ctx context.Context, input dto.ShareContentInput) (bool, error)
-	MockGetContentFn                      func(ctx context.Context, categoryID *int, limit string) (*domain.Content, error)
+	MockShareContentFn                    func(ctx context.Context, input dto.ShareContentInput) (*domain.ContentShareLink, error)
+	MockGetContentFn                      func(ctx context.Context, categoryID *int, limit int, sort *enums.ContentSortOrder, trendingDays *int, cursor *string) (*domain.Content, error)
 	MockGetUserBookmarkedContentFn        func(ctx context.Context, userID string) (*domain.Content, error)
 	MockGetContentByContentItemIDFn       func(ctx context.Context, contentID int) (*domain.Content, error)
 	MockLikeContentFn                     func(ctx context.Context, userID string, contentID string) (bool, error)
 	MockCheckWhetherUserHasLikedContentFn func(ctx context.Context, userID string, contentID int) (bool, error)
 	MockUnlikeContentFn                   func(ctx context.Context, userID string, contentID int) (bool, error)
+	MockInvalidateContentCacheFn          func(ctx context.Context, contentID int) (bool, error)
+	MockSearchContentFn                   func(ctx context.Context, query string, categoryIDs []int, itemType *string, language *enumutils.Language, paginationInput dto.PaginationsInput) (*domain.ContentSearchPage, error)
+	MockRecommendedContentFn              func(ctx context.Context, userID string, limit *int) (*domain.Content, error)
+	MockReconcileContentEngagementFn      func(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error)
+	MockRecordContentReadDurationFn       func(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error)
+	MockGetContentAnalyticsFn             func(ctx context.Context, input dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error)
+	MockExportContentAnalyticsFn          func(ctx context.Context, input dto.ContentAnalyticsInput) (string, error)
+	MockRecordContentProgressFn           func(ctx context.Context, userID string, contentID int, secondsSpent int, percentScrolled float64) (*domain.ContentProgressUpdate, error)
+	MockContinueReadingFn                 func(ctx context.Context, userID string, limit *int) ([]*domain.ContentProgress, error)
+	MockOpenSharedContentFn               func(ctx context.Context, token string, openedByID *string, client string) (int, error)
+	MockListContentCommentsFn             func(ctx context.Context, userID string, contentID int) ([]*domain.ContentComment, error)
+	MockCreateContentCommentFn            func(ctx context.Context, userID string, contentID int, parentID *string, body string) (*domain.ContentComment, error)
+	MockEditContentCommentFn              func(ctx context.Context, userID string, commentID string, body string) (*domain.ContentComment, error)
+	MockDeleteContentCommentFn            func(ctx context.Context, userID string, commentID string) (bool, error)
+	MockContentCommentModerationQueueFn   func(ctx context.Context, status *enums.ContentCommentStatus, limit *int) ([]*domain.ContentComment, error)
+	MockModerateContentCommentFn          func(ctx context.Context, moderatorID string, commentID string, status enums.ContentCommentStatus) (*domain.ContentComment, error)
 }
 
 // NewContentUsecaseMock instantiates all the content usecase mock methods
@@ -42,10 +61,17 @@ func NewContentUsecaseMock() *ContentUsecaseMock {
 		MockListContentCategoriesFn: func(ctx context.Context) ([]*domain.ContentItemCategory, error) {
 			return []*domain.ContentItemCategory{contentItemCategory}, nil
 		},
-		MockShareContentFn: func(ctx context.Context, input dto.ShareContentInput) (bool, error) {
-			return true, nil
+		MockShareContentFn: func(ctx context.Context, input dto.ShareContentInput) (*domain.ContentShareLink, error) {
+			return &domain.ContentShareLink{
+				ID:        uuid.New().String(),
+				ContentID: input.ContentID,
+				UserID:    input.UserID,
+				Channel:   input.Channel,
+				Link:      "https://example.com/content/10?share=token",
+				CreatedAt: time.Now(),
+			}, nil
 		},
-		MockGetContentFn: func(ctx context.Context, categoryID *int, limit string) (*domain.Content, error) {
+		MockGetContentFn: func(ctx context.Context, categoryID *int, limit int, sort *enums.ContentSortOrder, trendingDays *int, cursor *string) (*domain.Content, error) {
 			return &domain.Content{
 				Items: []domain.ContentItem{
 					{
@@ -69,6 +95,129 @@ func NewContentUsecaseMock() *ContentUsecaseMock {
 		MockUnlikeContentFn: func(ctx context.Context, userID string, contentID int) (bool, error) {
 			return true, nil
 		},
+		MockInvalidateContentCacheFn: func(ctx context.Context, contentID int) (bool, error) {
+			return true, nil
+		},
+		MockSearchContentFn: func(ctx context.Context, query string, categoryIDs []int, itemType *string, language *enumutils.Language, paginationInput dto.PaginationsInput) (*domain.ContentSearchPage, error) {
+			return &domain.ContentSearchPage{
+				Pagination: domain.Pagination{Limit: 10, CurrentPage: 1, Count: 1, TotalPages: 1},
+				Results: []*domain.ContentSearchResult{
+					{
+						Item:    content.Items[0],
+						Rank:    0.5,
+						Snippet: "a healthy <mark>diet</mark>",
+					},
+				},
+			}, nil
+		},
+		MockRecommendedContentFn: func(ctx context.Context, userID string, limit *int) (*domain.Content, error) {
+			return content, nil
+		},
+		MockReconcileContentEngagementFn: func(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error) {
+			return &domain.ContentEngagementReport{
+				Drift: []*domain.ContentCounterDrift{},
+			}, nil
+		},
+		MockRecordContentReadDurationFn: func(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error) {
+			return true, nil
+		},
+		MockGetContentAnalyticsFn: func(ctx context.Context, input dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error) {
+			return &domain.ContentAnalytics{
+				From:       input.From,
+				To:         input.To,
+				Items:      []*domain.ContentItemAnalytics{},
+				Categories: []*domain.ContentCategoryAnalytics{},
+			}, nil
+		},
+		MockExportContentAnalyticsFn: func(ctx context.Context, input dto.ContentAnalyticsInput) (string, error) {
+			return "type,id,name,content_items,views,unique_viewers,likes,bookmarks,shares,average_read_seconds\n", nil
+		},
+		MockRecordContentProgressFn: func(ctx context.Context, userID string, contentID int, secondsSpent int, percentScrolled float64) (*domain.ContentProgressUpdate, error) {
+			return &domain.ContentProgressUpdate{
+				Progress: domain.ContentProgress{
+					ContentID:       contentID,
+					SecondsSpent:    secondsSpent,
+					PercentScrolled: percentScrolled,
+				},
+			}, nil
+		},
+		MockContinueReadingFn: func(ctx context.Context, userID string, limit *int) ([]*domain.ContentProgress, error) {
+			return []*domain.ContentProgress{
+				{
+					ContentID:       10,
+					SecondsSpent:    60,
+					PercentScrolled: 40,
+					Item:            &domain.ContentItem{ID: 10},
+				},
+			}, nil
+		},
+		MockOpenSharedContentFn: func(ctx context.Context, token string, openedByID *string, client string) (int, error) {
+			return 10, nil
+		},
+		MockListContentCommentsFn: func(ctx context.Context, userID string, contentID int) ([]*domain.ContentComment, error) {
+			return []*domain.ContentComment{
+				{
+					ID:        uuid.New().String(),
+					ContentID: 10,
+					Nickname:  gofakeit.Username(),
+					Body:      "How long should I take the medication?",
+					Status:    enums.ContentCommentStatusApproved,
+					CreatedAt: time.Now(),
+					Replies:   []*domain.ContentComment{},
+				},
+			}, nil
+		},
+		MockCreateContentCommentFn: func(ctx context.Context, userID string, contentID int, parentID *string, body string) (*domain.ContentComment, error) {
+			return &domain.ContentComment{
+				ID:        uuid.New().String(),
+				ContentID: 10,
+				Nickname:  gofakeit.Username(),
+				Body:      "How long should I take the medication?",
+				Status:    enums.ContentCommentStatusApproved,
+				IsAuthor:  true,
+				CreatedAt: time.Now(),
+				Replies:   []*domain.ContentComment{},
+			}, nil
+		},
+		MockEditContentCommentFn: func(ctx context.Context, userID string, commentID string, body string) (*domain.ContentComment, error) {
+			return &domain.ContentComment{
+				ID:        uuid.New().String(),
+				ContentID: 10,
+				Nickname:  gofakeit.Username(),
+				Body:      "How long should I take the medication?",
+				Status:    enums.ContentCommentStatusApproved,
+				IsAuthor:  true,
+				CreatedAt: time.Now(),
+				Replies:   []*domain.ContentComment{},
+			}, nil
+		},
+		MockDeleteContentCommentFn: func(ctx context.Context, userID string, commentID string) (bool, error) {
+			return true, nil
+		},
+		MockContentCommentModerationQueueFn: func(ctx context.Context, status *enums.ContentCommentStatus, limit *int) ([]*domain.ContentComment, error) {
+			return []*domain.ContentComment{
+				{
+					ID:        uuid.New().String(),
+					ContentID: 10,
+					Nickname:  gofakeit.Username(),
+					Body:      "How long should I take the medication?",
+					Status:    enums.ContentCommentStatusPending,
+					CreatedAt: time.Now(),
+					Replies:   []*domain.ContentComment{},
+				},
+			}, nil
+		},
+		MockModerateContentCommentFn: func(ctx context.Context, moderatorID string, commentID string, status enums.ContentCommentStatus) (*domain.ContentComment, error) {
+			return &domain.ContentComment{
+				ID:        commentID,
+				ContentID: 10,
+				Nickname:  gofakeit.Username(),
+				Body:      "How long should I take the medication?",
+				Status:    status,
+				CreatedAt: time.Now(),
+				Replies:   []*domain.ContentComment{},
+			}, nil
+		},
 	}
 }
 
@@ -78,13 +227,13 @@ func (cm *ContentUsecaseMock) ListContentCategories(ctx context.Context) ([]*dom
 }
 
 // ShareContent mocks the implementation of `gorm's` ShareContent method.
-func (cm *ContentUsecaseMock) ShareContent(ctx context.Context, input dto.ShareContentInput) (bool, error) {
+func (cm *ContentUsecaseMock) ShareContent(ctx context.Context, input dto.ShareContentInput) (*domain.ContentShareLink, error) {
 	return cm.MockShareContentFn(ctx, input)
 }
 
 // GetContent mocks the implementation of making an API call to fetch content from our APIs
-func (cm *ContentUsecaseMock) GetContent(ctx context.Context, categoryID *int, limit string) (*domain.Content, error) {
-	return cm.MockGetContentFn(ctx, categoryID, limit)
+func (cm *ContentUsecaseMock) GetContent(ctx context.Context, categoryID *int, limit int, sort *enums.ContentSortOrder, trendingDays *int, cursor *string) (*domain.Content, error) {
+	return cm.MockGetContentFn(ctx, categoryID, limit, sort, trendingDays, cursor)
 }
 
 // GetUserBookmarkedContent mocks the implementation of getting a users bookmarked content
@@ -112,3 +261,83 @@ func (cm *ContentUsecaseMock) CheckWhetherUserHasLikedContent(ctx context.Contex
 func (cm *ContentUsecaseMock) UnlikeContent(ctx context.Context, userID string, contentID int) (bool, error) {
 	return cm.MockUnlikeContentFn(ctx, userID, contentID)
 }
+
+// InvalidateContentCache mocks the implementation of dropping cached CMS content
+func (cm *ContentUsecaseMock) InvalidateContentCache(ctx context.Context, contentID int) (bool, error) {
+	return cm.MockInvalidateContentCacheFn(ctx, contentID)
+}
+
+// SearchContent mocks the implementation of searching content
+func (cm *ContentUsecaseMock) SearchContent(ctx context.Context, query string, categoryIDs []int, itemType *string, language *enumutils.Language, paginationInput dto.PaginationsInput) (*domain.ContentSearchPage, error) {
+	return cm.MockSearchContentFn(ctx, query, categoryIDs, itemType, language, paginationInput)
+}
+
+// RecommendedContent mocks the implementation of recommending content to a user
+func (cm *ContentUsecaseMock) RecommendedContent(ctx context.Context, userID string, limit *int) (*domain.Content, error) {
+	return cm.MockRecommendedContentFn(ctx, userID, limit)
+}
+
+// ReconcileContentEngagement mocks the implementation of reconciling the content engagement counters
+func (cm *ContentUsecaseMock) ReconcileContentEngagement(ctx context.Context, dryRun bool) (*domain.ContentEngagementReport, error) {
+	return cm.MockReconcileContentEngagementFn(ctx, dryRun)
+}
+
+// RecordContentReadDuration mocks the implementation of recording how long a user spent reading content
+func (cm *ContentUsecaseMock) RecordContentReadDuration(ctx context.Context, userID string, contentID int, durationSeconds int) (bool, error) {
+	return cm.MockRecordContentReadDurationFn(ctx, userID, contentID, durationSeconds)
+}
+
+// GetContentAnalytics mocks the implementation of getting content analytics
+func (cm *ContentUsecaseMock) GetContentAnalytics(ctx context.Context, input dto.ContentAnalyticsInput) (*domain.ContentAnalytics, error) {
+	return cm.MockGetContentAnalyticsFn(ctx, input)
+}
+
+// ExportContentAnalytics mocks the implementation of exporting content analytics as CSV
+func (cm *ContentUsecaseMock) ExportContentAnalytics(ctx context.Context, input dto.ContentAnalyticsInput) (string, error) {
+	return cm.MockExportContentAnalyticsFn(ctx, input)
+}
+
+// RecordContentProgress mocks the implementation of recording a user's progress with content
+func (cm *ContentUsecaseMock) RecordContentProgress(ctx context.Context, userID string, contentID int, secondsSpent int, percentScrolled float64) (*domain.ContentProgressUpdate, error) {
+	return cm.MockRecordContentProgressFn(ctx, userID, contentID, secondsSpent, percentScrolled)
+}
+
+// ContinueReading mocks the implementation of listing the content a user has started reading
+func (cm *ContentUsecaseMock) ContinueReading(ctx context.Context, userID string, limit *int) ([]*domain.ContentProgress, error) {
+	return cm.MockContinueReadingFn(ctx, userID, limit)
+}
+
+// OpenSharedContent mocks the implementation of opening a shared content link
+func (cm *ContentUsecaseMock) OpenSharedContent(ctx context.Context, token string, openedByID *string, client string) (int, error) {
+	return cm.MockOpenSharedContentFn(ctx, token, openedByID, client)
+}
+
+// ListContentComments mocks the implementation of listing the threads of comments on a content item
+func (cm *ContentUsecaseMock) ListContentComments(ctx context.Context, userID string, contentID int) ([]*domain.ContentComment, error) {
+	return cm.MockListContentCommentsFn(ctx, userID, contentID)
+}
+
+// CreateContentComment mocks the implementation of commenting on a content item
+func (cm *ContentUsecaseMock) CreateContentComment(ctx context.Context, userID string, contentID int, parentID *string, body string) (*domain.ContentComment, error) {
+	return cm.MockCreateContentCommentFn(ctx, userID, contentID, parentID, body)
+}
+
+// EditContentComment mocks the implementation of editing a content comment
+func (cm *ContentUsecaseMock) EditContentComment(ctx context.Context, userID string, commentID string, body string) (*domain.ContentComment, error) {
+	return cm.MockEditContentCommentFn(ctx, userID, commentID, body)
+}
+
+// DeleteContentComment mocks the implementation of deleting a content comment
+func (cm *ContentUsecaseMock) DeleteContentComment(ctx context.Context, userID string, commentID string) (bool, error) {
+	return cm.MockDeleteContentCommentFn(ctx, userID, commentID)
+}
+
+// ContentCommentModerationQueue mocks the implementation of fetching the content comments that are awaiting moderation
+func (cm *ContentUsecaseMock) ContentCommentModerationQueue(ctx context.Context, status *enums.ContentCommentStatus, limit *int) ([]*domain.ContentComment, error) {
+	return cm.MockContentCommentModerationQueueFn(ctx, status, limit)
+}
+
+// ModerateContentComment mocks the implementation of moderating a content comment
+func (cm *ContentUsecaseMock) ModerateContentComment(ctx context.Context, moderatorID string, commentID string, status enums.ContentCommentStatus) (*domain.ContentComment, error) {
+	return cm.MockModerateContentCommentFn(ctx, moderatorID, commentID, status)
+}
@@ -0,0 +1,68 @@
+package content
+
+import (
+	"strings"
+	"unicode"
+)
+
+// profaneWords are the words that hold a content comment back for moderation. Anatomical words are left out since
+// clients use them to ask about their health.
+var profaneWords = map[string]bool{
+	"arsehole":     true,
+	"asshole":      true,
+	"bastard":      true,
+	"bitch":        true,
+	"bullshit":     true,
+	"cunt":         true,
+	"dickhead":     true,
+	"fuck":         true,
+	"fucked":       true,
+	"fucker":       true,
+	"fucking":      true,
+	"motherfucker": true,
+	"shit":         true,
+	"slut":         true,
+	"twat":         true,
+	"wanker":       true,
+	"whore":        true,
+}
+
+// obfuscationReplacer undoes the common character swaps used to sneak words past a filter e.g "sh1t"
+var obfuscationReplacer = strings.NewReplacer(
+	"0", "o",
+	"1", "i",
+	"3", "e",
+	"4", "a",
+	"5", "s",
+	"7", "t",
+	"@", "a",
+	"$", "s",
+)
+
+// containsProfanity returns true if any word in the text is a profane word. Words are compared in lower case,
+// with swapped characters undone and with letters that are repeated to stretch a word collapsed.
+func containsProfanity(text string) bool {
+	normalised := obfuscationReplacer.Replace(strings.ToLower(text))
+	words := strings.FieldsFunc(normalised, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		if profaneWords[word] || profaneWords[collapseRepeatedLetters(word)] {
+			return true
+		}
+	}
+	return false
+}
+
+// collapseRepeatedLetters replaces each run of a repeated letter with a single letter e.g "shiiit" becomes "shit"
+func collapseRepeatedLetters(word string) string {
+	var collapsed strings.Builder
+	var previous rune
+	for _, r := range word {
+		if r != previous {
+			collapsed.WriteRune(r)
+		}
+		previous = r
+	}
+	return collapsed.String()
+}
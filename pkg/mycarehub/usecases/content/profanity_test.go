@@ -0,0 +1,54 @@
+package content
+
+import "testing"
+
+func TestContainsProfanity(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{
+			name: "clean comment",
+			text: "How long should I take the medication?",
+			want: false,
+		},
+		{
+			name: "anatomical words are allowed",
+			text: "Is it normal to have pain in my breast and penis after the injection?",
+			want: false,
+		},
+		{
+			name: "profane word",
+			text: "This is bullshit",
+			want: true,
+		},
+		{
+			name: "profane word in capitals with punctuation",
+			text: "SHIT! I missed a dose",
+			want: true,
+		},
+		{
+			name: "profane word with swapped characters",
+			text: "what a b1tch",
+			want: true,
+		},
+		{
+			name: "profane word with repeated letters",
+			text: "shiiiit",
+			want: true,
+		},
+		{
+			name: "profane word inside another word is allowed",
+			text: "I live in Scunthorpe",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := containsProfanity(tt.text); got != tt.want {
+				t.Errorf("containsProfanity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package content
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+// contentCompletionThreshold is how far a user has to get with a content item for it to count as completed. They
+// must scroll through most of the item and spend at least half of its estimated reading time on it.
+var contentCompletionThreshold = domain.ContentCompletionThreshold{
+	PercentScrolled:     90,
+	ShareOfTimeEstimate: 0.5,
+}
+
+// RecordContentProgress saves how far a user has got with a content item. The seconds spent are those spent on the
+// item since the last report and the percent scrolled is how far down the item the user has scrolled. A completion
+// event is recorded the first time the user's progress crosses the completion threshold.
+func (u *UseCasesContentImpl) RecordContentProgress(
+	ctx context.Context,
+	userID string,
+	contentID int,
+	secondsSpent int,
+	percentScrolled float64,
+) (*domain.ContentProgressUpdate, error) {
+	if userID == "" || contentID == 0 {
+		return nil, exceptions.EmptyInputErr(fmt.Errorf("userID and contentID cannot be empty"))
+	}
+	if secondsSpent < 0 {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("the seconds spent cannot be negative"))
+	}
+	if percentScrolled < 0 || percentScrolled > 100 {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("the percent scrolled must be between 0 and 100"))
+	}
+	if secondsSpent > maxContentReadSeconds {
+		secondsSpent = maxContentReadSeconds
+	}
+	return u.Update.RecordContentProgress(ctx, userID, contentID, secondsSpent, percentScrolled, contentCompletionThreshold)
+}
+
+// ContinueReading returns the published content items that the user has started but not completed, the most
+// recently read first
+func (u *UseCasesContentImpl) ContinueReading(ctx context.Context, userID string, limit *int) ([]*domain.ContentProgress, error) {
+	if userID == "" {
+		return nil, exceptions.EmptyInputErr(fmt.Errorf("userID cannot be empty"))
+	}
+	size := contentBatchSize
+	if limit != nil {
+		size = *limit
+	}
+	if size < 1 || size > contentBatchSize {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("limit must be between 1 and %v", contentBatchSize))
+	}
+	return u.Query.ListContentInProgress(ctx, userID, size)
+}
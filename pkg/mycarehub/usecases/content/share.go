@@ -0,0 +1,198 @@
+package content
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+const (
+	// ContentShareBaseURL is the environment variable with the base URL of shared content links. The domain must
+	// be registered as an app link so that the links open in the app when it is installed and in the public web
+	// view otherwise.
+	ContentShareBaseURL = "CONTENT_SHARE_BASE_URL"
+
+	// ContentShareSigningKey is the environment variable with the key that shared content links are signed with
+	ContentShareSigningKey = "CONTENT_SHARE_SIGNING_KEY"
+)
+
+// contentShareTokenParam is the query parameter of a shared content link that carries the signed share token
+const contentShareTokenParam = "share"
+
+// contentShareOpenWindow is how long the opens of a shared link by the same client are only recorded once
+const contentShareOpenWindow = time.Hour
+
+// contentShareOpenSize is the most opens of shared links that are remembered at a time
+const contentShareOpenSize = 10000
+
+// ShareContent records that a user shared a content item on a channel and returns a signed link to the item that
+// they can send on the channel
+func (u *UseCasesContentImpl) ShareContent(ctx context.Context, input dto.ShareContentInput) (*domain.ContentShareLink, error) {
+	if err := input.Validate(); err != nil {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("invalid share content input: %v", err))
+	}
+	baseURL, signingKey, err := contentShareLinkConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	shareLink, err := u.Update.ShareContent(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := url.Parse(strings.TrimSuffix(baseURL, "/") + "/content/" + strconv.Itoa(shareLink.ContentID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid content share base URL: %v", err)
+	}
+	link.RawQuery = url.Values{contentShareTokenParam: {signContentShareToken(signingKey, shareLink.ID)}}.Encode()
+	shareLink.Link = link.String()
+	return shareLink, nil
+}
+
+// OpenSharedContent records that a shared content link was opened and returns the ID of the content item that it
+// links to. The open is attributed to the user who shared the link. The opener is empty when the link was opened
+// on the public web view by someone who isn't logged in, in which case the client e.g its address is used to
+// record repeated opens of the link by the same client once.
+func (u *UseCasesContentImpl) OpenSharedContent(ctx context.Context, token string, openedByID *string, client string) (int, error) {
+	if token == "" {
+		return 0, exceptions.EmptyInputErr(fmt.Errorf("the share token cannot be empty"))
+	}
+	_, signingKey, err := contentShareLinkConfig()
+	if err != nil {
+		return 0, err
+	}
+	shareLinkID, ok := verifyContentShareToken(signingKey, token)
+	if !ok {
+		return 0, exceptions.InputValidationErr(fmt.Errorf("invalid share token"))
+	}
+
+	anonymous := openedByID == nil && client != ""
+	if anonymous {
+		if contentID, ok := u.shareOpens.recent(shareLinkID, client); ok {
+			return contentID, nil
+		}
+	}
+
+	shareLink, err := u.Update.RecordContentShareOpen(ctx, shareLinkID, openedByID)
+	if err != nil {
+		return 0, err
+	}
+	if anonymous {
+		u.shareOpens.remember(shareLinkID, client, shareLink.ContentID)
+	}
+	return shareLink.ContentID, nil
+}
+
+// shareOpen is a client's open of a shared link
+type shareOpen struct {
+	contentID int
+	openedAt  time.Time
+}
+
+// recentShareOpens remembers the shared links that clients opened recently so that a client reloading or
+// replaying a link isn't recorded as another open. Every instance of the service keeps its own record hence a
+// client whose requests reach different instances may be recorded once per instance.
+type recentShareOpens struct {
+	mu     sync.Mutex
+	window time.Duration
+	size   int
+	opens  map[string]shareOpen
+
+	now func() time.Time
+}
+
+func newRecentShareOpens(window time.Duration, size int) *recentShareOpens {
+	return &recentShareOpens{
+		window: window,
+		size:   size,
+		opens:  map[string]shareOpen{},
+		now:    time.Now,
+	}
+}
+
+// recent returns the content item of the shared link if the client opened it within the window
+func (r *recentShareOpens) recent(shareLinkID string, client string) (int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	open, ok := r.opens[shareLinkID+" "+client]
+	if !ok || r.now().Sub(open.openedAt) >= r.window {
+		return 0, false
+	}
+	return open.contentID, true
+}
+
+// remember records that the client opened the shared link. The opens that are past the window are dropped
+// when the record is full, followed by the oldest open if it is still full.
+func (r *recentShareOpens) remember(shareLinkID string, client string, contentID int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if len(r.opens) >= r.size {
+		oldestKey := ""
+		var oldest time.Time
+		for key, open := range r.opens {
+			if now.Sub(open.openedAt) >= r.window {
+				delete(r.opens, key)
+				continue
+			}
+			if oldestKey == "" || open.openedAt.Before(oldest) {
+				oldestKey, oldest = key, open.openedAt
+			}
+		}
+		if len(r.opens) >= r.size {
+			delete(r.opens, oldestKey)
+		}
+	}
+	r.opens[shareLinkID+" "+client] = shareOpen{contentID: contentID, openedAt: now}
+}
+
+// contentShareLinkConfig reads the base URL and signing key of shared content links
+func contentShareLinkConfig() (string, string, error) {
+	baseURL := os.Getenv(ContentShareBaseURL)
+	signingKey := os.Getenv(ContentShareSigningKey)
+	if baseURL == "" || signingKey == "" {
+		return "", "", fmt.Errorf("the %s and %s environment variables must be set to share content", ContentShareBaseURL, ContentShareSigningKey)
+	}
+	return baseURL, signingKey, nil
+}
+
+// signContentShareToken returns a token made up of the share link ID and its signature
+func signContentShareToken(signingKey string, shareLinkID string) string {
+	return shareLinkID + "." + contentShareSignature(signingKey, shareLinkID)
+}
+
+// verifyContentShareToken returns the share link ID in the token if its signature is valid
+func verifyContentShareToken(signingKey string, token string) (string, bool) {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", false
+	}
+	shareLinkID, signature := parts[0], parts[1]
+	expected := contentShareSignature(signingKey, shareLinkID)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return "", false
+	}
+	return shareLinkID, true
+}
+
+// contentShareSignature is the URL safe HMAC-SHA256 of the share link ID
+func contentShareSignature(signingKey string, shareLinkID string) string {
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(shareLinkID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,35 @@
+package content
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecentShareOpens(t *testing.T) {
+	now := time.Now()
+	opens := newRecentShareOpens(time.Hour, 2)
+	opens.now = func() time.Time { return now }
+
+	opens.remember("link", "192.0.2.1", 10)
+	if contentID, ok := opens.recent("link", "192.0.2.1"); !ok || contentID != 10 {
+		t.Errorf("expected the open of content item 10 to be recent, got %v, %v", contentID, ok)
+	}
+	if _, ok := opens.recent("link", "192.0.2.2"); ok {
+		t.Errorf("expected the open to be recent for the client that opened the link only")
+	}
+
+	now = now.Add(time.Minute)
+	opens.remember("link", "192.0.2.2", 10)
+	opens.remember("link", "192.0.2.3", 10)
+	if len(opens.opens) != 2 {
+		t.Errorf("expected at most 2 opens to be remembered, got %v", len(opens.opens))
+	}
+	if _, ok := opens.recent("link", "192.0.2.1"); ok {
+		t.Errorf("expected the oldest open to be dropped when the record is full")
+	}
+
+	now = now.Add(time.Hour)
+	if _, ok := opens.recent("link", "192.0.2.3"); ok {
+		t.Errorf("expected an open past the window not to be recent")
+	}
+}
@@ -2,10 +2,19 @@ package facility
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
 )
@@ -18,6 +27,11 @@ type UseCasesFacility interface {
 	IFacilityDelete
 	IFacilityInactivate
 	IFacilityReactivate
+	IFacilityUpdate
+	IFacilityImport
+	IFacilityNearby
+	IFacilityOpeningHours
+	IFacilityServices
 }
 
 // IFacilityCreate contains the method used to create a facility
@@ -44,10 +58,43 @@ type IFacilityReactivate interface {
 	ReactivateFacility(ctx context.Context, mflCode *int) (bool, error)
 }
 
+// IFacilityUpdate contains the methods to change the details of a facility and to see who changed them
+type IFacilityUpdate interface {
+	UpdateFacility(ctx context.Context, mflCode int, input dto.FacilityUpdateInput) (*domain.Facility, error)
+	GetFacilityHistory(ctx context.Context, mflCode int) ([]*domain.FacilityHistory, error)
+}
+
+// IFacilityImport contains the method to import facilities from a Kenya Master Facility List export
+type IFacilityImport interface {
+	ImportFacilities(ctx context.Context, content string, format enums.FacilityImportFormat, dryRun bool) (*domain.FacilityImportReport, error)
+}
+
+// IFacilityNearby contains the method to find the facilities near a location
+type IFacilityNearby interface {
+	NearbyFacilities(
+		ctx context.Context, latitude, longitude, radiusKm float64, limit *int, filterInput []*dto.FiltersInput, sort *dto.SortsInput,
+	) ([]*domain.NearbyFacility, error)
+}
+
+// IFacilityOpeningHours contains the methods to manage when a facility is open
+type IFacilityOpeningHours interface {
+	SetFacilityOpeningHours(ctx context.Context, mflCode int, openingHours []*dto.FacilityOpeningHoursInput) (bool, error)
+	SetFacilityHoursException(ctx context.Context, mflCode int, input dto.FacilityHoursExceptionInput) (bool, error)
+	DeleteFacilityHoursException(ctx context.Context, mflCode int, date string) (bool, error)
+}
+
+// IFacilityServices contains the methods to manage the catalogue of services and the services that facilities offer
+type IFacilityServices interface {
+	CreateFacilityService(ctx context.Context, input dto.FacilityServiceInput) (*domain.FacilityService, error)
+	ListFacilityServices(ctx context.Context) ([]*domain.FacilityService, error)
+	SetFacilityServices(ctx context.Context, mflCode int, serviceCodes []string) (bool, error)
+}
+
 // IFacilityList contains the method to list of facilities
 type IFacilityList interface {
 	// TODO Document: callers should specify active
-	ListFacilities(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error)
+	ListFacilities(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, filterGroups []*dto.FilterGroupInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error)
+	// Deprecated: FetchFacilities returns every facility. Use ListFacilities, which is paginated.
 	FetchFacilities(ctx context.Context) ([]*domain.Facility, error)
 }
 
@@ -57,6 +104,28 @@ type IFacilityRetrieve interface {
 	RetrieveFacilityByMFLCode(ctx context.Context, MFLCode int, isActive bool) (*domain.Facility, error)
 }
 
+const (
+	// maxFacilityImportRows is the maximum number of facilities that can be imported from a single KMFL export
+	maxFacilityImportRows = 20000
+
+	// defaultNearbyFacilitiesLimit is the number of nearby facilities returned when a limit is not requested
+	defaultNearbyFacilitiesLimit = 10
+)
+
+// kmflColumns maps the facility fields to the column names used for them in KMFL exports, in order of preference.
+// Column names are compared after normalising them with normaliseKMFLColumn.
+var kmflColumns = map[string][]string{
+	"code":        {"code", "mflcode"},
+	"name":        {"name", "officialname"},
+	"county":      {"county", "countyname"},
+	"phone":       {"phone", "phonenumber"},
+	"description": {"description", "facilitytype", "facilitytypename"},
+	"active":      {"active", "operationstatus", "operationstatusname"},
+	"latitude":    {"latitude", "lat"},
+	"longitude":   {"longitude", "long", "lng"},
+	"address":     {"address", "physicaladdress", "town"},
+}
+
 // UseCaseFacilityImpl represents facility implementation object
 type UseCaseFacilityImpl struct {
 	Create infrastructure.Create
@@ -75,18 +144,171 @@ func NewFacilityUsecase(create infrastructure.Create, query infrastructure.Query
 	}
 }
 
-// GetOrCreateFacility creates a new facility
+// facilityMatchesInput returns true if a saved facility has the same details as the input
+func facilityMatchesInput(facility *domain.Facility, input *dto.FacilityInput) bool {
+	return facility.Name == input.Name &&
+		facility.Phone == input.Phone &&
+		facility.Active == input.Active &&
+		facility.County == input.County &&
+		facility.Description == input.Description
+}
+
+// GetOrCreateFacility creates a new facility. An existing facility with the same MFL code is only returned
+// when its details match the input, otherwise the details should be changed using UpdateFacility.
 func (f *UseCaseFacilityImpl) GetOrCreateFacility(ctx context.Context, facility *dto.FacilityInput) (*domain.Facility, error) {
 	fetchedFacility, err := f.RetrieveFacilityByMFLCode(ctx, facility.Code, facility.Active)
 	if err != nil {
-		if strings.Contains(err.Error(), "failed query and retrieve facility by MFLCode") {
-			return f.Create.GetOrCreateFacility(ctx, facility)
+		if !strings.Contains(err.Error(), "failed query and retrieve facility by MFLCode") {
+			return nil, fmt.Errorf("failed to retrieve facility")
+		}
+		// the facility may have been saved with a different active status
+		fetchedFacility, err = f.RetrieveFacilityByMFLCode(ctx, facility.Code, !facility.Active)
+		if err != nil {
+			if strings.Contains(err.Error(), "failed query and retrieve facility by MFLCode") {
+				return f.Create.GetOrCreateFacility(ctx, facility)
+			}
+			return nil, fmt.Errorf("failed to retrieve facility")
 		}
-		return nil, fmt.Errorf("failed to retrieve facility")
+	}
+
+	if !facilityMatchesInput(fetchedFacility, facility) {
+		return nil, exceptions.ItemAlreadyExistsErr(
+			fmt.Errorf("a facility with MFL code %v already exists with different details", facility.Code),
+		)
 	}
 	return fetchedFacility, nil
 }
 
+// UpdateFacility changes the details of a facility. The change is recorded against the logged in user
+func (f *UseCaseFacilityImpl) UpdateFacility(ctx context.Context, mflCode int, input dto.FacilityUpdateInput) (*domain.Facility, error) {
+	if mflCode == 0 {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("facility MFL code cannot be empty"))
+	}
+	if err := input.Validate(); err != nil {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("invalid facility input: %v", err))
+	}
+
+	identity, err := helpers.GetIdentityFromContext(ctx)
+	if err != nil {
+		return nil, exceptions.UnauthorizedErr(err)
+	}
+
+	facility, err := f.Update.UpdateFacility(ctx, mflCode, &input, identity.UserID)
+	if err != nil {
+		return nil, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to update facility: %v", err))
+	}
+	return facility, nil
+}
+
+// GetFacilityHistory returns the changes made to the details of a facility, the most recent first
+func (f *UseCaseFacilityImpl) GetFacilityHistory(ctx context.Context, mflCode int) ([]*domain.FacilityHistory, error) {
+	if mflCode == 0 {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("facility MFL code cannot be empty"))
+	}
+
+	history, err := f.Query.GetFacilityHistory(ctx, mflCode)
+	if err != nil {
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to get facility history: %v", err))
+	}
+	return history, nil
+}
+
+// SetFacilityOpeningHours replaces a facility's weekly opening hours. Days without opening hours are days that
+// the facility is closed.
+func (f *UseCaseFacilityImpl) SetFacilityOpeningHours(ctx context.Context, mflCode int, openingHours []*dto.FacilityOpeningHoursInput) (bool, error) {
+	if mflCode == 0 {
+		return false, exceptions.InputValidationErr(fmt.Errorf("facility MFL code cannot be empty"))
+	}
+	if err := dto.ValidateFacilityOpeningHours(openingHours); err != nil {
+		return false, exceptions.InputValidationErr(fmt.Errorf("invalid facility opening hours: %v", err))
+	}
+
+	ok, err := f.Update.SetFacilityOpeningHours(ctx, mflCode, openingHours)
+	if err != nil {
+		return false, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to set facility opening hours: %v", err))
+	}
+	return ok, nil
+}
+
+// SetFacilityHoursException changes a facility's opening hours on a date, such as a public holiday, replacing
+// any other change on the same date
+func (f *UseCaseFacilityImpl) SetFacilityHoursException(ctx context.Context, mflCode int, input dto.FacilityHoursExceptionInput) (bool, error) {
+	if mflCode == 0 {
+		return false, exceptions.InputValidationErr(fmt.Errorf("facility MFL code cannot be empty"))
+	}
+	if err := input.Validate(); err != nil {
+		return false, exceptions.InputValidationErr(fmt.Errorf("invalid facility hours exception: %v", err))
+	}
+
+	ok, err := f.Update.SetFacilityHoursException(ctx, mflCode, &input)
+	if err != nil {
+		return false, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to set facility hours exception: %v", err))
+	}
+	return ok, nil
+}
+
+// DeleteFacilityHoursException removes a facility's hours exception on a date so that its weekly opening hours apply
+func (f *UseCaseFacilityImpl) DeleteFacilityHoursException(ctx context.Context, mflCode int, date string) (bool, error) {
+	if mflCode == 0 {
+		return false, exceptions.InputValidationErr(fmt.Errorf("facility MFL code cannot be empty"))
+	}
+	if _, err := time.Parse(common.DateLayout, date); err != nil {
+		return false, exceptions.InputValidationErr(fmt.Errorf("invalid date %v, it must be in the YYYY-MM-DD format", date))
+	}
+
+	ok, err := f.Delete.DeleteFacilityHoursException(ctx, mflCode, date)
+	if err != nil {
+		return false, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to delete facility hours exception: %v", err))
+	}
+	return ok, nil
+}
+
+// CreateFacilityService adds a service to the catalogue of services that facilities can offer
+func (f *UseCaseFacilityImpl) CreateFacilityService(ctx context.Context, input dto.FacilityServiceInput) (*domain.FacilityService, error) {
+	if err := input.Validate(); err != nil {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("invalid facility service input: %v", err))
+	}
+
+	service, err := f.Create.CreateFacilityService(ctx, &input)
+	if err != nil {
+		return nil, exceptions.FailedToSaveItemErr(fmt.Errorf("failed to create facility service: %v", err))
+	}
+	return service, nil
+}
+
+// ListFacilityServices returns the catalogue of services that facilities can offer
+func (f *UseCaseFacilityImpl) ListFacilityServices(ctx context.Context) ([]*domain.FacilityService, error) {
+	services, err := f.Query.ListFacilityServices(ctx)
+	if err != nil {
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to list facility services: %v", err))
+	}
+	return services, nil
+}
+
+// SetFacilityServices replaces the services that a facility offers. The services are referred to by their codes
+// and must be in the catalogue.
+func (f *UseCaseFacilityImpl) SetFacilityServices(ctx context.Context, mflCode int, serviceCodes []string) (bool, error) {
+	if mflCode == 0 {
+		return false, exceptions.InputValidationErr(fmt.Errorf("facility MFL code cannot be empty"))
+	}
+	seen := map[string]bool{}
+	for _, code := range serviceCodes {
+		if code == "" {
+			return false, exceptions.InputValidationErr(fmt.Errorf("service code cannot be empty"))
+		}
+		if seen[code] {
+			return false, exceptions.InputValidationErr(fmt.Errorf("service %v is repeated", code))
+		}
+		seen[code] = true
+	}
+
+	ok, err := f.Update.SetFacilityServices(ctx, mflCode, serviceCodes)
+	if err != nil {
+		return false, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to set facility services: %v", err))
+	}
+	return ok, nil
+}
+
 // DeleteFacility deletes a facility from the database usinng the MFL Code
 func (f *UseCaseFacilityImpl) DeleteFacility(ctx context.Context, id int) (bool, error) {
 	return f.Delete.DeleteFacility(ctx, id)
@@ -122,7 +344,10 @@ func (f *UseCaseFacilityImpl) RetrieveFacility(ctx context.Context, id *string,
 }
 
 // FetchFacilities fetches healthcare facilities in platform
+//
+// Deprecated: FetchFacilities loads every facility at once. Use ListFacilities, which is paginated.
 func (f *UseCaseFacilityImpl) FetchFacilities(ctx context.Context) ([]*domain.Facility, error) {
+	//lint:ignore SA1019 fetching every facility is what this deprecated method does
 	return f.Query.GetFacilities(ctx)
 }
 
@@ -134,8 +359,10 @@ func (f *UseCaseFacilityImpl) RetrieveFacilityByMFLCode(ctx context.Context, MFL
 	return f.Query.RetrieveFacilityByMFLCode(ctx, MFLCode, isActive)
 }
 
-//ListFacilities is responsible for returning a list of paginated facilities
-func (f *UseCaseFacilityImpl) ListFacilities(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
+//ListFacilities is responsible for returning a list of paginated facilities. The facilities match the search
+// term and all the filters and filter groups. An invalid filter is reported with a *domain.FilterValidationError
+// that names it.
+func (f *UseCaseFacilityImpl) ListFacilities(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, filterGroups []*dto.FilterGroupInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
 	if searchTerm == nil {
 		return nil, fmt.Errorf("search term cannot be nil")
 	}
@@ -148,5 +375,283 @@ func (f *UseCaseFacilityImpl) ListFacilities(ctx context.Context, searchTerm *st
 		return nil, fmt.Errorf("filter input cannot be nil")
 	}
 
-	return f.Query.ListFacilities(ctx, searchTerm, filterInput, paginationsInput)
+	return f.Query.ListFacilities(ctx, searchTerm, filterInput, filterGroups, paginationsInput)
+}
+
+// NearbyFacilities returns the facilities within radiusKm kilometres of a location, nearest first by default,
+// together with their distance from it. Facilities without coordinates are not returned.
+func (f *UseCaseFacilityImpl) NearbyFacilities(
+	ctx context.Context, latitude, longitude, radiusKm float64, limit *int, filterInput []*dto.FiltersInput, sort *dto.SortsInput,
+) ([]*domain.NearbyFacility, error) {
+	input := &dto.NearbyFacilitiesInput{
+		Latitude:    latitude,
+		Longitude:   longitude,
+		RadiusKm:    radiusKm,
+		Limit:       defaultNearbyFacilitiesLimit,
+		FilterInput: filterInput,
+		Sort:        sort,
+	}
+	if limit != nil {
+		input.Limit = *limit
+	}
+	if err := input.Validate(); err != nil {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("invalid nearby facilities input: %v", err))
+	}
+
+	facilities, err := f.Query.NearbyFacilities(ctx, input)
+	if err != nil {
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to get nearby facilities: %v", err))
+	}
+	return facilities, nil
+}
+
+// ImportFacilities creates or updates the facilities in a KMFL CSV or JSON export using their MFL codes.
+// Rows that cannot be read are reported as invalid while the rest are imported. The changes are recorded
+// against the logged in user and a dry run reports what would change without saving anything.
+func (f *UseCaseFacilityImpl) ImportFacilities(ctx context.Context, content string, format enums.FacilityImportFormat, dryRun bool) (*domain.FacilityImportReport, error) {
+	if !format.IsValid() {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("invalid facility import format: %v", format))
+	}
+
+	identity, err := helpers.GetIdentityFromContext(ctx)
+	if err != nil {
+		return nil, exceptions.UnauthorizedErr(err)
+	}
+
+	records, err := parseKMFLExport(content, format)
+	if err != nil {
+		return nil, exceptions.InputValidationErr(err)
+	}
+
+	report := &domain.FacilityImportReport{
+		DryRun: dryRun,
+		Total:  len(records),
+		Rows:   []*domain.FacilityImportRow{},
+	}
+
+	facilities := []*dto.FacilityInput{}
+	facilityRows := []*domain.FacilityImportRow{}
+	seen := map[int]int{}
+	for i, record := range records {
+		row := &domain.FacilityImportRow{RowNumber: i + 1}
+		report.Rows = append(report.Rows, row)
+
+		facility, err := facilityFromKMFLRecord(record)
+		if facility != nil {
+			row.MFLCode = facility.Code
+			row.Name = facility.Name
+		}
+		if err == nil {
+			if previousRow, ok := seen[facility.Code]; ok {
+				err = fmt.Errorf("MFL code %v is repeated from row %v", facility.Code, previousRow)
+			}
+		}
+		if err != nil {
+			row.Status = enums.FacilityImportStatusInvalid
+			row.Error = err.Error()
+			continue
+		}
+
+		seen[facility.Code] = row.RowNumber
+		facilities = append(facilities, facility)
+		facilityRows = append(facilityRows, row)
+	}
+
+	if len(facilities) > 0 {
+		results, err := f.Update.UpsertFacilities(ctx, facilities, identity.UserID, dryRun)
+		if err != nil {
+			return nil, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to import facilities: %v", err))
+		}
+		if len(results) != len(facilityRows) {
+			return nil, exceptions.InternalErr(fmt.Errorf("expected %d facility import results, got %d", len(facilityRows), len(results)))
+		}
+		for i, result := range results {
+			facilityRows[i].Status = result.Status
+			facilityRows[i].Error = result.Error
+		}
+	}
+
+	for _, row := range report.Rows {
+		switch row.Status {
+		case enums.FacilityImportStatusCreated:
+			report.Created++
+		case enums.FacilityImportStatusUpdated:
+			report.Updated++
+		case enums.FacilityImportStatusUnchanged:
+			report.Unchanged++
+		default:
+			report.Invalid++
+		}
+	}
+	return report, nil
+}
+
+// parseKMFLExport reads the facilities in a KMFL export into records keyed by their normalised column names
+func parseKMFLExport(content string, format enums.FacilityImportFormat) ([]map[string]string, error) {
+	var records []map[string]string
+	var err error
+	switch format {
+	case enums.FacilityImportFormatCSV:
+		records, err = parseKMFLCSV(content)
+	case enums.FacilityImportFormatJSON:
+		records, err = parseKMFLJSON(content)
+	default:
+		return nil, fmt.Errorf("invalid facility import format: %v", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("the export has no facilities")
+	}
+	if len(records) > maxFacilityImportRows {
+		return nil, fmt.Errorf("the export has %d facilities, the maximum allowed is %d", len(records), maxFacilityImportRows)
+	}
+	return records, nil
+}
+
+// parseKMFLCSV reads a KMFL CSV export. The first row must name the columns.
+func parseKMFLCSV(content string) ([]map[string]string, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %v", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("the CSV file has no header row")
+	}
+
+	header := []string{}
+	for _, column := range rows[0] {
+		header = append(header, normaliseKMFLColumn(column))
+	}
+
+	records := []map[string]string{}
+	for _, row := range rows[1:] {
+		record := map[string]string{}
+		for i, value := range row {
+			if i < len(header) {
+				record[header[i]] = strings.TrimSpace(value)
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// parseKMFLJSON reads a KMFL JSON export. This is either an array of facilities or a page of
+// the KMFL API response with the facilities in its `results`.
+func parseKMFLJSON(content string) ([]map[string]string, error) {
+	decoder := json.NewDecoder(strings.NewReader(content))
+	// keep the MFL codes as written instead of converting them to floats
+	decoder.UseNumber()
+
+	var export interface{}
+	if err := decoder.Decode(&export); err != nil {
+		return nil, fmt.Errorf("failed to read JSON: %v", err)
+	}
+	if page, ok := export.(map[string]interface{}); ok {
+		export = page["results"]
+	}
+	facilities, ok := export.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a list of facilities")
+	}
+
+	records := []map[string]string{}
+	for _, facility := range facilities {
+		record := map[string]string{}
+		if fields, ok := facility.(map[string]interface{}); ok {
+			for key, value := range fields {
+				if value != nil {
+					record[normaliseKMFLColumn(key)] = strings.TrimSpace(fmt.Sprint(value))
+				}
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// facilityFromKMFLRecord converts a KMFL record to a facility. The facility is returned with the error
+// when it was read but is not valid.
+func facilityFromKMFLRecord(record map[string]string) (*dto.FacilityInput, error) {
+	value := func(field string) string {
+		for _, column := range kmflColumns[field] {
+			if v := record[column]; v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	code, err := strconv.Atoi(value("code"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MFL code %q", value("code"))
+	}
+
+	active := true
+	if status := value("active"); status != "" {
+		if parsed, err := strconv.ParseBool(status); err == nil {
+			active = parsed
+		} else {
+			active = strings.EqualFold(status, "operational")
+		}
+	}
+
+	facility := &dto.FacilityInput{
+		Name:        value("name"),
+		Code:        code,
+		Phone:       value("phone"),
+		Active:      active,
+		County:      kmflCounty(value("county")),
+		Description: value("description"),
+		Address:     value("address"),
+	}
+
+	if facility.Latitude, err = parseKMFLCoordinate("latitude", value("latitude")); err != nil {
+		return facility, err
+	}
+	if facility.Longitude, err = parseKMFLCoordinate("longitude", value("longitude")); err != nil {
+		return facility, err
+	}
+	return facility, facility.Validate()
+}
+
+// parseKMFLCoordinate reads a latitude or longitude. A facility without the coordinate has no location.
+func parseKMFLCoordinate(field string, value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	coordinate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", field, value)
+	}
+	return &coordinate, nil
+}
+
+// kmflCounty returns the county that a KMFL county name refers to e.g `MURANG'A` is `Muranga`.
+// Names that do not match a county are returned unchanged.
+func kmflCounty(name string) string {
+	for _, county := range enums.KenyanCounties {
+		if normaliseKMFLColumn(county.String()) == normaliseKMFLColumn(name) {
+			return county.String()
+		}
+	}
+	return name
+}
+
+// normaliseKMFLColumn lower cases a name and removes everything other than letters and digits so that
+// `Official Name`, `official_name` and `officialName` are treated the same
+func normaliseKMFLColumn(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
 }
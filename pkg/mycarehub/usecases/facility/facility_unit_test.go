@@ -2,12 +2,14 @@ package facility_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"testing"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
@@ -75,6 +77,34 @@ func TestUnit_CreateFacility(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Sad case - existing facility with different details",
+			args: args{
+				ctx: ctx,
+				facility: dto.FacilityInput{
+					Name:        name,
+					Code:        code,
+					Active:      true,
+					County:      county,
+					Description: description,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to retrieve facility",
+			args: args{
+				ctx: ctx,
+				facility: dto.FacilityInput{
+					Name:        name,
+					Code:        code,
+					Active:      true,
+					County:      county,
+					Description: description,
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -84,6 +114,22 @@ func TestUnit_CreateFacility(t *testing.T) {
 
 			f := facility.NewFacilityUsecase(fakeDB, fakeDB, fakeDB, fakeDB)
 
+			if tt.name == "happy case - valid payload" {
+				fakeDB.MockRetrieveFacilityByMFLCodeFn = func(ctx context.Context, MFLCode int, isActive bool) (*domain.Facility, error) {
+					return &domain.Facility{
+						Name:        name,
+						Code:        code,
+						Active:      true,
+						County:      county,
+						Description: description,
+					}, nil
+				}
+			}
+			if tt.name == "Sad case - failed to retrieve facility" {
+				fakeDB.MockRetrieveFacilityByMFLCodeFn = func(ctx context.Context, MFLCode int, isActive bool) (*domain.Facility, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
 			if tt.name == "Happy case - Create facility" {
 				fakeDB.MockRetrieveFacilityByMFLCodeFn = func(ctx context.Context, MFLCode int, isActive bool) (*domain.Facility, error) {
 					return nil, fmt.Errorf("failed query and retrieve facility by MFLCode")
@@ -251,6 +297,22 @@ func TestUnit_ListFacilities(t *testing.T) {
 		},
 	}
 
+	filterGroups := []*dto.FilterGroupInput{
+		{
+			Operator: enums.FilterOperatorOr,
+			Filters: []*dto.FiltersInput{
+				{
+					DataType: enums.FilterSortDataTypeCounty,
+					Value:    enums.CountyTypeNairobi.String(),
+				},
+				{
+					DataType: enums.FilterSortDataTypeCounty,
+					Value:    enums.CountyTypeKiambu.String(),
+				},
+			},
+		},
+	}
+
 	paginationInput := dto.PaginationsInput{
 		Limit:       1,
 		CurrentPage: 1,
@@ -260,6 +322,7 @@ func TestUnit_ListFacilities(t *testing.T) {
 		ctx              context.Context
 		searchTerm       *string
 		filterInput      []*dto.FiltersInput
+		filterGroups     []*dto.FilterGroupInput
 		paginationsInput *dto.PaginationsInput
 	}
 	tests := []struct {
@@ -277,6 +340,27 @@ func TestUnit_ListFacilities(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Happy case - filter groups",
+			args: args{
+				ctx:              ctx,
+				searchTerm:       &searchTerm,
+				filterInput:      filterInput,
+				filterGroups:     filterGroups,
+				paginationsInput: &paginationInput,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - invalid filter",
+			args: args{
+				ctx:              ctx,
+				searchTerm:       &searchTerm,
+				filterInput:      filterInput,
+				paginationsInput: &paginationInput,
+			},
+			wantErr: true,
+		},
 		{
 			name: "Sad case- empty search term",
 			args: args{
@@ -317,28 +401,38 @@ func TestUnit_ListFacilities(t *testing.T) {
 			f := facility.NewFacilityUsecase(fakeDB, fakeDB, fakeDB, fakeDB)
 
 			if tt.name == "Sad case- empty search term" {
-				fakeFacility.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
+				fakeFacility.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, filterGroups []*dto.FilterGroupInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
 					return nil, fmt.Errorf("failed to list facilities")
 				}
 			}
 
 			if tt.name == "Sad case- nil filter input" {
-				fakeFacility.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
+				fakeFacility.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, filterGroups []*dto.FilterGroupInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
 					return nil, fmt.Errorf("failed to list facilities")
 				}
 			}
 
 			if tt.name == "Sad case- nil pagination input" {
-				fakeFacility.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
+				fakeFacility.MockListFacilitiesFn = func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, filterGroups []*dto.FilterGroupInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
 					return nil, fmt.Errorf("failed to list facilities")
 				}
 			}
+			if tt.name == "Sad case - invalid filter" {
+				fakeDB.ListFacilitiesFn = func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, filterGroups []*dto.FilterGroupInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
+					return nil, &domain.FilterValidationError{Filter: enums.FilterSortDataTypeName, Err: fmt.Errorf("name cannot be empty")}
+				}
+			}
 
-			got, err := f.ListFacilities(tt.args.ctx, tt.args.searchTerm, tt.args.filterInput, tt.args.paginationsInput)
+			got, err := f.ListFacilities(tt.args.ctx, tt.args.searchTerm, tt.args.filterInput, tt.args.filterGroups, tt.args.paginationsInput)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("OnboardingDb.ListFacilities() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			var filterErr *domain.FilterValidationError
+			if tt.name == "Sad case - invalid filter" && !errors.As(err, &filterErr) {
+				t.Errorf("expected the invalid filter to be named in the error, got %v", err)
+				return
+			}
 			if tt.wantErr && got != nil {
 				t.Errorf("expected facilities to be nil for %v", tt.name)
 				return
@@ -598,6 +692,158 @@ func TestUseCaseFacilityImpl_DeleteFacility(t *testing.T) {
 	}
 }
 
+func TestUseCaseFacilityImpl_UpdateFacility(t *testing.T) {
+	ctx := helpers.ContextWithIdentity(context.Background(), &domain.Identity{
+		UserID: uuid.New().String(),
+	})
+	name := gofakeit.Company()
+	county := enums.CountyTypeNairobi
+
+	type args struct {
+		ctx     context.Context
+		mflCode int
+		input   dto.FacilityUpdateInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				input: dto.FacilityUpdateInput{
+					Name:   &name,
+					County: &county,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - empty MFL code",
+			args: args{
+				ctx: ctx,
+				input: dto.FacilityUpdateInput{
+					Name: &name,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid input",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				input:   dto.FacilityUpdateInput{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no logged in user",
+			args: args{
+				ctx:     context.Background(),
+				mflCode: 1234,
+				input: dto.FacilityUpdateInput{
+					Name: &name,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to update facility",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				input: dto.FacilityUpdateInput{
+					Name: &name,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+
+			f := facility.NewFacilityUsecase(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to update facility" {
+				fakeDB.MockUpdateFacilityFn = func(ctx context.Context, mflCode int, input *dto.FacilityUpdateInput, changedBy string) (*domain.Facility, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := f.UpdateFacility(tt.args.ctx, tt.args.mflCode, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseFacilityImpl.UpdateFacility() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected a facility to be returned")
+			}
+		})
+	}
+}
+
+func TestUseCaseFacilityImpl_GetFacilityHistory(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx     context.Context
+		mflCode int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - empty MFL code",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to get facility history",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+
+			f := facility.NewFacilityUsecase(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to get facility history" {
+				fakeDB.MockGetFacilityHistoryFn = func(ctx context.Context, mflCode int) ([]*domain.FacilityHistory, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			_, err := f.GetFacilityHistory(tt.args.ctx, tt.args.mflCode)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseFacilityImpl.GetFacilityHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
 func TestUseCaseFacilityImpl_FetchFacilities(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
@@ -672,3 +918,711 @@ func TestUseCaseFacilityImpl_FetchFacilities_Unittest(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCaseFacilityImpl_ImportFacilities(t *testing.T) {
+	ctx := helpers.ContextWithIdentity(context.Background(), &domain.Identity{
+		UserID: uuid.New().String(),
+	})
+
+	csvExport := "Code,Name,County,Phone,Facility Type,Operation status\n" +
+		"10001,Kanairo Dispensary,NAIROBI,+254711223344,Dispensary,Operational\n" +
+		"10002,Muranga Health Centre,MURANG'A,+254711223355,Health Centre,Closed\n"
+	jsonExport := `[{"code": 10001, "name": "Kanairo Dispensary", "county_name": "Nairobi", "phone": "+254711223344", "facility_type_name": "Dispensary", "lat": -1.2921, "long": 36.8219}]`
+	jsonPage := `{"count": 1, "results": [{"code": 10001, "official_name": "Kanairo Dispensary", "county": "Tana River", "phone": "+254711223344", "description": "Dispensary"}]}`
+	invalidRowsExport := "Code,Name,County,Phone,Description,Latitude,Longitude\n" +
+		"10001,Kanairo Dispensary,Nairobi,+254711223344,Dispensary\n" +
+		"10001,Kanairo Clinic,Nairobi,+254711223344,Dispensary\n" +
+		"not a code,Kanairo Clinic,Nairobi,+254711223344,Dispensary\n" +
+		"10003,Kanairo Clinic,Gotham,+254711223344,Dispensary\n" +
+		"10004,Kanairo Clinic,Nairobi,+254711223344,Dispensary,north,36.8219\n" +
+		"10005,Kanairo Clinic,Nairobi,+254711223344,Dispensary,-1.2921,\n"
+
+	type args struct {
+		ctx     context.Context
+		content string
+		format  enums.FacilityImportFormat
+		dryRun  bool
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantCreated int
+		wantInvalid int
+		wantErr     bool
+	}{
+		{
+			name: "Happy case - CSV export",
+			args: args{
+				ctx:     ctx,
+				content: csvExport,
+				format:  enums.FacilityImportFormatCSV,
+			},
+			wantCreated: 2,
+			wantErr:     false,
+		},
+		{
+			name: "Happy case - JSON export",
+			args: args{
+				ctx:     ctx,
+				content: jsonExport,
+				format:  enums.FacilityImportFormatJSON,
+				dryRun:  true,
+			},
+			wantCreated: 1,
+			wantErr:     false,
+		},
+		{
+			name: "Happy case - KMFL API page",
+			args: args{
+				ctx:     ctx,
+				content: jsonPage,
+				format:  enums.FacilityImportFormatJSON,
+			},
+			wantCreated: 1,
+			wantErr:     false,
+		},
+		{
+			name: "Happy case - invalid and repeated rows",
+			args: args{
+				ctx:     ctx,
+				content: invalidRowsExport,
+				format:  enums.FacilityImportFormatCSV,
+			},
+			wantCreated: 1,
+			wantInvalid: 5,
+			wantErr:     false,
+		},
+		{
+			name: "Happy case - facility rejected by the database",
+			args: args{
+				ctx:     ctx,
+				content: jsonExport,
+				format:  enums.FacilityImportFormatJSON,
+			},
+			wantInvalid: 1,
+			wantErr:     false,
+		},
+		{
+			name: "Sad case - invalid format",
+			args: args{
+				ctx:     ctx,
+				content: csvExport,
+				format:  enums.FacilityImportFormat("XML"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - no logged in user",
+			args: args{
+				ctx:     context.Background(),
+				content: csvExport,
+				format:  enums.FacilityImportFormatCSV,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - export without facilities",
+			args: args{
+				ctx:     ctx,
+				content: "Code,Name,County,Phone,Description\n",
+				format:  enums.FacilityImportFormatCSV,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - malformed JSON",
+			args: args{
+				ctx:     ctx,
+				content: `{"results": "facilities"}`,
+				format:  enums.FacilityImportFormatJSON,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to upsert facilities",
+			args: args{
+				ctx:     ctx,
+				content: csvExport,
+				format:  enums.FacilityImportFormatCSV,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - missing upsert results",
+			args: args{
+				ctx:     ctx,
+				content: csvExport,
+				format:  enums.FacilityImportFormatCSV,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+
+			f := facility.NewFacilityUsecase(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Happy case - facility rejected by the database" {
+				fakeDB.MockUpsertFacilitiesFn = func(ctx context.Context, facilities []*dto.FacilityInput, changedBy string, dryRun bool) ([]*domain.FacilityImportRow, error) {
+					return []*domain.FacilityImportRow{
+						{Status: enums.FacilityImportStatusInvalid, Error: "duplicate facility name"},
+					}, nil
+				}
+			}
+			if tt.name == "Sad case - failed to upsert facilities" {
+				fakeDB.MockUpsertFacilitiesFn = func(ctx context.Context, facilities []*dto.FacilityInput, changedBy string, dryRun bool) ([]*domain.FacilityImportRow, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case - missing upsert results" {
+				fakeDB.MockUpsertFacilitiesFn = func(ctx context.Context, facilities []*dto.FacilityInput, changedBy string, dryRun bool) ([]*domain.FacilityImportRow, error) {
+					return []*domain.FacilityImportRow{}, nil
+				}
+			}
+
+			got, err := f.ImportFacilities(tt.args.ctx, tt.args.content, tt.args.format, tt.args.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseFacilityImpl.ImportFacilities() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Created != tt.wantCreated || got.Invalid != tt.wantInvalid {
+				t.Errorf("expected %v created and %v invalid facilities, got %+v", tt.wantCreated, tt.wantInvalid, got)
+			}
+			if got.DryRun != tt.args.dryRun || got.Total != len(got.Rows) {
+				t.Errorf("unexpected import report %+v", got)
+			}
+		})
+	}
+}
+
+func TestUseCaseFacilityImpl_NearbyFacilities(t *testing.T) {
+	ctx := context.Background()
+	limit := 5
+	invalidLimit := 0
+
+	type args struct {
+		ctx         context.Context
+		latitude    float64
+		longitude   float64
+		radiusKm    float64
+		limit       *int
+		filterInput []*dto.FiltersInput
+		sort        *dto.SortsInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:       ctx,
+				latitude:  -1.2921,
+				longitude: 36.8219,
+				radiusKm:  10,
+				limit:     &limit,
+				filterInput: []*dto.FiltersInput{
+					{DataType: enums.FilterSortDataTypeActive, Value: "true"},
+				},
+				sort: &dto.SortsInput{Field: enums.FilterSortDataTypeDistance, Direction: enums.SortDataTypeAsc},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case - default limit",
+			args: args{
+				ctx:       ctx,
+				latitude:  -1.2921,
+				longitude: 36.8219,
+				radiusKm:  10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - invalid limit",
+			args: args{
+				ctx:       ctx,
+				latitude:  -1.2921,
+				longitude: 36.8219,
+				radiusKm:  10,
+				limit:     &invalidLimit,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid location",
+			args: args{
+				ctx:       ctx,
+				latitude:  100,
+				longitude: 36.8219,
+				radiusKm:  10,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to get nearby facilities",
+			args: args{
+				ctx:       ctx,
+				latitude:  -1.2921,
+				longitude: 36.8219,
+				radiusKm:  10,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+
+			f := facility.NewFacilityUsecase(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Happy case - default limit" {
+				fakeDB.MockNearbyFacilitiesFn = func(ctx context.Context, input *dto.NearbyFacilitiesInput) ([]*domain.NearbyFacility, error) {
+					if input.Limit != 10 {
+						return nil, fmt.Errorf("expected the default limit, got %v", input.Limit)
+					}
+					return []*domain.NearbyFacility{}, nil
+				}
+			}
+			if tt.name == "Sad case - failed to get nearby facilities" {
+				fakeDB.MockNearbyFacilitiesFn = func(ctx context.Context, input *dto.NearbyFacilitiesInput) ([]*domain.NearbyFacility, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			_, err := f.NearbyFacilities(tt.args.ctx, tt.args.latitude, tt.args.longitude, tt.args.radiusKm, tt.args.limit, tt.args.filterInput, tt.args.sort)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseFacilityImpl.NearbyFacilities() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestUseCaseFacilityImpl_SetFacilityOpeningHours(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx          context.Context
+		mflCode      int
+		openingHours []*dto.FacilityOpeningHoursInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				openingHours: []*dto.FacilityOpeningHoursInput{
+					{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "08:00", ClosesAt: "17:00"},
+					{DayOfWeek: enums.DayOfWeekSaturday, OpensAt: "09:00", ClosesAt: "13:00"},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - empty MFL code",
+			args: args{
+				ctx: ctx,
+				openingHours: []*dto.FacilityOpeningHoursInput{
+					{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "08:00", ClosesAt: "17:00"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - overlapping opening hours",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				openingHours: []*dto.FacilityOpeningHoursInput{
+					{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "08:00", ClosesAt: "17:00"},
+					{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "16:00", ClosesAt: "20:00"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to set opening hours",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				openingHours: []*dto.FacilityOpeningHoursInput{
+					{DayOfWeek: enums.DayOfWeekMonday, OpensAt: "08:00", ClosesAt: "17:00"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+
+			f := facility.NewFacilityUsecase(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to set opening hours" {
+				fakeDB.MockSetFacilityOpeningHoursFn = func(ctx context.Context, mflCode int, openingHours []*dto.FacilityOpeningHoursInput) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := f.SetFacilityOpeningHours(tt.args.ctx, tt.args.mflCode, tt.args.openingHours)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseFacilityImpl.SetFacilityOpeningHours() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCaseFacilityImpl.SetFacilityOpeningHours() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCaseFacilityImpl_SetFacilityHoursException(t *testing.T) {
+	ctx := context.Background()
+	opensAt := "09:00"
+	closesAt := "12:00"
+
+	type args struct {
+		ctx     context.Context
+		mflCode int
+		input   dto.FacilityHoursExceptionInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				input:   dto.FacilityHoursExceptionInput{Date: "2021-12-24", OpensAt: &opensAt, ClosesAt: &closesAt},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - empty MFL code",
+			args: args{
+				ctx:   ctx,
+				input: dto.FacilityHoursExceptionInput{Date: "2021-12-25", Closed: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid date",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				input:   dto.FacilityHoursExceptionInput{Date: "Christmas", Closed: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to set hours exception",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				input:   dto.FacilityHoursExceptionInput{Date: "2021-12-25", Closed: true},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+
+			f := facility.NewFacilityUsecase(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to set hours exception" {
+				fakeDB.MockSetFacilityHoursExceptionFn = func(ctx context.Context, mflCode int, input *dto.FacilityHoursExceptionInput) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := f.SetFacilityHoursException(tt.args.ctx, tt.args.mflCode, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseFacilityImpl.SetFacilityHoursException() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCaseFacilityImpl.SetFacilityHoursException() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCaseFacilityImpl_DeleteFacilityHoursException(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx     context.Context
+		mflCode int
+		date    string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				date:    "2021-12-25",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - empty MFL code",
+			args: args{
+				ctx:  ctx,
+				date: "2021-12-25",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid date",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				date:    "25-12-2021",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to delete hours exception",
+			args: args{
+				ctx:     ctx,
+				mflCode: 1234,
+				date:    "2021-12-25",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+
+			f := facility.NewFacilityUsecase(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to delete hours exception" {
+				fakeDB.MockDeleteFacilityHoursExceptionFn = func(ctx context.Context, mflCode int, date string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := f.DeleteFacilityHoursException(tt.args.ctx, tt.args.mflCode, tt.args.date)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseFacilityImpl.DeleteFacilityHoursException() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCaseFacilityImpl.DeleteFacilityHoursException() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCaseFacilityImpl_CreateFacilityService(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx   context.Context
+		input dto.FacilityServiceInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:   ctx,
+				input: dto.FacilityServiceInput{Name: "Viral load testing", Code: "VIRAL_LOAD"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - invalid code",
+			args: args{
+				ctx:   ctx,
+				input: dto.FacilityServiceInput{Name: "Viral load testing", Code: "viral load"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to create service",
+			args: args{
+				ctx:   ctx,
+				input: dto.FacilityServiceInput{Name: "Viral load testing", Code: "VIRAL_LOAD"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+
+			f := facility.NewFacilityUsecase(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to create service" {
+				fakeDB.MockCreateFacilityServiceFn = func(ctx context.Context, input *dto.FacilityServiceInput) (*domain.FacilityService, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := f.CreateFacilityService(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseFacilityImpl.CreateFacilityService() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Code != tt.args.input.Code {
+				t.Errorf("UseCaseFacilityImpl.CreateFacilityService() code = %v, want %v", got.Code, tt.args.input.Code)
+			}
+		})
+	}
+}
+
+func TestUseCaseFacilityImpl_ListFacilityServices(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr bool
+	}{
+		{
+			name:    "Happy case",
+			ctx:     ctx,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case - failed to list services",
+			ctx:     ctx,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+
+			f := facility.NewFacilityUsecase(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to list services" {
+				fakeDB.MockListFacilityServicesFn = func(ctx context.Context) ([]*domain.FacilityService, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			_, err := f.ListFacilityServices(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseFacilityImpl.ListFacilityServices() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestUseCaseFacilityImpl_SetFacilityServices(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx          context.Context
+		mflCode      int
+		serviceCodes []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:          ctx,
+				mflCode:      1234,
+				serviceCodes: []string{"ART_REFILL", "PREP"},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case - remove all services",
+			args: args{
+				ctx:          ctx,
+				mflCode:      1234,
+				serviceCodes: []string{},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - empty MFL code",
+			args: args{
+				ctx:          ctx,
+				serviceCodes: []string{"ART_REFILL"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - repeated service",
+			args: args{
+				ctx:          ctx,
+				mflCode:      1234,
+				serviceCodes: []string{"ART_REFILL", "ART_REFILL"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to set services",
+			args: args{
+				ctx:          ctx,
+				mflCode:      1234,
+				serviceCodes: []string{"ART_REFILL"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+
+			f := facility.NewFacilityUsecase(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to set services" {
+				fakeDB.MockSetFacilityServicesFn = func(ctx context.Context, mflCode int, serviceCodes []string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := f.SetFacilityServices(tt.args.ctx, tt.args.mflCode, tt.args.serviceCodes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseFacilityImpl.SetFacilityServices() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCaseFacilityImpl.SetFacilityServices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
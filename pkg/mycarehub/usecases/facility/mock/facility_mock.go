@@ -2,24 +2,36 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 )
 
 // FacilityUsecaseMock mocks the implementation of facility usecase methods
 type FacilityUsecaseMock struct {
-	MockGetOrCreateFacilityFn       func(ctx context.Context, facility *dto.FacilityInput) (*domain.Facility, error)
-	MockRetrieveFacilityFn          func(ctx context.Context, id *string, isActive bool) (*domain.Facility, error)
-	MockRetrieveFacilityByMFLCodeFn func(ctx context.Context, MFLCode int, isActive bool) (*domain.Facility, error)
-	MockGetFacilitiesFn             func(ctx context.Context) ([]*domain.Facility, error)
-	MockListFacilitiesFn            func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error)
-	DeleteFacilityFn                func(ctx context.Context, id int) (bool, error)
-	FetchFacilitiesFn               func(ctx context.Context) ([]*domain.Facility, error)
-	MockInactivateFacilityFn        func(ctx context.Context, mflCode *int) (bool, error)
+	MockGetOrCreateFacilityFn          func(ctx context.Context, facility *dto.FacilityInput) (*domain.Facility, error)
+	MockRetrieveFacilityFn             func(ctx context.Context, id *string, isActive bool) (*domain.Facility, error)
+	MockRetrieveFacilityByMFLCodeFn    func(ctx context.Context, MFLCode int, isActive bool) (*domain.Facility, error)
+	MockGetFacilitiesFn                func(ctx context.Context) ([]*domain.Facility, error)
+	MockListFacilitiesFn               func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, filterGroups []*dto.FilterGroupInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error)
+	DeleteFacilityFn                   func(ctx context.Context, id int) (bool, error)
+	FetchFacilitiesFn                  func(ctx context.Context) ([]*domain.Facility, error)
+	MockInactivateFacilityFn           func(ctx context.Context, mflCode *int) (bool, error)
+	MockUpdateFacilityFn               func(ctx context.Context, mflCode int, input dto.FacilityUpdateInput) (*domain.Facility, error)
+	MockGetFacilityHistoryFn           func(ctx context.Context, mflCode int) ([]*domain.FacilityHistory, error)
+	MockImportFacilitiesFn             func(ctx context.Context, content string, format enums.FacilityImportFormat, dryRun bool) (*domain.FacilityImportReport, error)
+	MockNearbyFacilitiesFn             func(ctx context.Context, latitude, longitude, radiusKm float64, limit *int, filterInput []*dto.FiltersInput, sort *dto.SortsInput) ([]*domain.NearbyFacility, error)
+	MockSetFacilityOpeningHoursFn      func(ctx context.Context, mflCode int, openingHours []*dto.FacilityOpeningHoursInput) (bool, error)
+	MockSetFacilityHoursExceptionFn    func(ctx context.Context, mflCode int, input dto.FacilityHoursExceptionInput) (bool, error)
+	MockDeleteFacilityHoursExceptionFn func(ctx context.Context, mflCode int, date string) (bool, error)
+	MockCreateFacilityServiceFn        func(ctx context.Context, input dto.FacilityServiceInput) (*domain.FacilityService, error)
+	MockListFacilityServicesFn         func(ctx context.Context) ([]*domain.FacilityService, error)
+	MockSetFacilityServicesFn          func(ctx context.Context, mflCode int, serviceCodes []string) (bool, error)
 }
 
 // NewFacilityUsecaseMock initializes a new instance of `GormMock` then mocking the case of success.
@@ -84,7 +96,7 @@ func NewFacilityUsecaseMock() *FacilityUsecaseMock {
 		MockGetFacilitiesFn: func(ctx context.Context) ([]*domain.Facility, error) {
 			return facilitiesList, nil
 		},
-		MockListFacilitiesFn: func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
+		MockListFacilitiesFn: func(ctx context.Context, searchTerm *string, filterInput []*dto.FiltersInput, filterGroups []*dto.FilterGroupInput, paginationsInput *dto.PaginationsInput) (*domain.FacilityPage, error) {
 			return facilitiesPage, nil
 		},
 
@@ -98,6 +110,77 @@ func NewFacilityUsecaseMock() *FacilityUsecaseMock {
 		MockInactivateFacilityFn: func(ctx context.Context, mflCode *int) (bool, error) {
 			return true, nil
 		},
+		MockUpdateFacilityFn: func(ctx context.Context, mflCode int, input dto.FacilityUpdateInput) (*domain.Facility, error) {
+			return facilityInput, nil
+		},
+		MockGetFacilityHistoryFn: func(ctx context.Context, mflCode int) ([]*domain.FacilityHistory, error) {
+			return []*domain.FacilityHistory{
+				{
+					FacilityID: ID,
+					Field:      "name",
+					OldValue:   gofakeit.Name(),
+					NewValue:   name,
+					ChangedBy:  uuid.New().String(),
+					ChangedAt:  time.Now(),
+				},
+			}, nil
+		},
+		MockImportFacilitiesFn: func(ctx context.Context, content string, format enums.FacilityImportFormat, dryRun bool) (*domain.FacilityImportReport, error) {
+			return &domain.FacilityImportReport{
+				DryRun:  dryRun,
+				Total:   1,
+				Created: 1,
+				Rows: []*domain.FacilityImportRow{
+					{
+						RowNumber: 1,
+						MFLCode:   code,
+						Name:      name,
+						Status:    enums.FacilityImportStatusCreated,
+					},
+				},
+			}, nil
+		},
+		MockNearbyFacilitiesFn: func(ctx context.Context, latitude, longitude, radiusKm float64, limit *int, filterInput []*dto.FiltersInput, sort *dto.SortsInput) ([]*domain.NearbyFacility, error) {
+			return []*domain.NearbyFacility{
+				{
+					Facility:   *facilityInput,
+					DistanceKm: 1.5,
+				},
+			}, nil
+		},
+		MockSetFacilityOpeningHoursFn: func(ctx context.Context, mflCode int, openingHours []*dto.FacilityOpeningHoursInput) (bool, error) {
+			return true, nil
+		},
+		MockSetFacilityHoursExceptionFn: func(ctx context.Context, mflCode int, input dto.FacilityHoursExceptionInput) (bool, error) {
+			return true, nil
+		},
+		MockDeleteFacilityHoursExceptionFn: func(ctx context.Context, mflCode int, date string) (bool, error) {
+			return true, nil
+		},
+		MockCreateFacilityServiceFn: func(ctx context.Context, input dto.FacilityServiceInput) (*domain.FacilityService, error) {
+			id := uuid.New().String()
+			return &domain.FacilityService{
+				ID:          &id,
+				Name:        input.Name,
+				Code:        input.Code,
+				Description: input.Description,
+				Active:      true,
+			}, nil
+		},
+		MockListFacilityServicesFn: func(ctx context.Context) ([]*domain.FacilityService, error) {
+			id := uuid.New().String()
+			return []*domain.FacilityService{
+				{
+					ID:     &id,
+					Name:   "ART refill",
+					Code:   "ART_REFILL",
+					Active: true,
+				},
+			}, nil
+		},
+		MockSetFacilityServicesFn: func(ctx context.Context, mflCode int, serviceCodes []string) (bool, error) {
+			return true, nil
+		},
 	}
 }
 
@@ -126,9 +209,10 @@ func (f *FacilityUsecaseMock) ListFacilities(
 	ctx context.Context,
 	searchTerm *string,
 	filterInput []*dto.FiltersInput,
+	filterGroups []*dto.FilterGroupInput,
 	paginationsInput *dto.PaginationsInput,
 ) (*domain.FacilityPage, error) {
-	return f.MockListFacilitiesFn(ctx, searchTerm, filterInput, paginationsInput)
+	return f.MockListFacilitiesFn(ctx, searchTerm, filterInput, filterGroups, paginationsInput)
 }
 
 // DeleteFacility mocks the implementation of deleting a facility by ID
@@ -145,3 +229,53 @@ func (f *FacilityUsecaseMock) FetchFacilities(ctx context.Context) ([]*domain.Fa
 func (f *FacilityUsecaseMock) InactivateFacility(ctx context.Context, mflCode *int) (bool, error) {
 	return f.MockInactivateFacilityFn(ctx, mflCode)
 }
+
+// UpdateFacility mocks the implementation of updating the details of a facility
+func (f *FacilityUsecaseMock) UpdateFacility(ctx context.Context, mflCode int, input dto.FacilityUpdateInput) (*domain.Facility, error) {
+	return f.MockUpdateFacilityFn(ctx, mflCode, input)
+}
+
+// GetFacilityHistory mocks the implementation of fetching the changes made to a facility
+func (f *FacilityUsecaseMock) GetFacilityHistory(ctx context.Context, mflCode int) ([]*domain.FacilityHistory, error) {
+	return f.MockGetFacilityHistoryFn(ctx, mflCode)
+}
+
+// ImportFacilities mocks the implementation of importing facilities from a KMFL export
+func (f *FacilityUsecaseMock) ImportFacilities(ctx context.Context, content string, format enums.FacilityImportFormat, dryRun bool) (*domain.FacilityImportReport, error) {
+	return f.MockImportFacilitiesFn(ctx, content, format, dryRun)
+}
+
+// NearbyFacilities mocks the implementation of finding the facilities near a location
+func (f *FacilityUsecaseMock) NearbyFacilities(ctx context.Context, latitude, longitude, radiusKm float64, limit *int, filterInput []*dto.FiltersInput, sort *dto.SortsInput) ([]*domain.NearbyFacility, error) {
+	return f.MockNearbyFacilitiesFn(ctx, latitude, longitude, radiusKm, limit, filterInput, sort)
+}
+
+// SetFacilityOpeningHours mocks the implementation of replacing a facility's opening hours
+func (f *FacilityUsecaseMock) SetFacilityOpeningHours(ctx context.Context, mflCode int, openingHours []*dto.FacilityOpeningHoursInput) (bool, error) {
+	return f.MockSetFacilityOpeningHoursFn(ctx, mflCode, openingHours)
+}
+
+// SetFacilityHoursException mocks the implementation of changing a facility's opening hours on a date
+func (f *FacilityUsecaseMock) SetFacilityHoursException(ctx context.Context, mflCode int, input dto.FacilityHoursExceptionInput) (bool, error) {
+	return f.MockSetFacilityHoursExceptionFn(ctx, mflCode, input)
+}
+
+// DeleteFacilityHoursException mocks the implementation of removing a facility's hours exception
+func (f *FacilityUsecaseMock) DeleteFacilityHoursException(ctx context.Context, mflCode int, date string) (bool, error) {
+	return f.MockDeleteFacilityHoursExceptionFn(ctx, mflCode, date)
+}
+
+// CreateFacilityService mocks the implementation of adding a service to the catalogue
+func (f *FacilityUsecaseMock) CreateFacilityService(ctx context.Context, input dto.FacilityServiceInput) (*domain.FacilityService, error) {
+	return f.MockCreateFacilityServiceFn(ctx, input)
+}
+
+// ListFacilityServices mocks the implementation of listing the catalogue of facility services
+func (f *FacilityUsecaseMock) ListFacilityServices(ctx context.Context) ([]*domain.FacilityService, error) {
+	return f.MockListFacilityServicesFn(ctx)
+}
+
+// SetFacilityServices mocks the implementation of replacing the services a facility offers
+func (f *FacilityUsecaseMock) SetFacilityServices(ctx context.Context, mflCode int, serviceCodes []string) (bool, error) {
+	return f.MockSetFacilityServicesFn(ctx, mflCode, serviceCodes)
+}
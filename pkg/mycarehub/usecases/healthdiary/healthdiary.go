@@ -16,9 +16,9 @@ import (
 // from VERY_HAPPY, HAPPY, NEUTRAL, SAD, VERY_SAD. When a client fills the mood board, a health diary
 // entry is recorded in the database. In cases where the client is VERY_SAD, the client is asked if they
 // want to report it to a healthcare worker and if they do, a service request is created. The service request
-// is a task for the healthcare worker on the platform. All this should happen within a 24 hour time window. If
-// a health diary was filled within the past 24 hours, the client is shown an inspirational post on the frontend
-// and if it hasn't been filled, we show them the health diary.
+// is a task for the healthcare worker on the platform. All this should happen within the diary cadence set by the
+// client's organisation, which is 24 hours by default. If a health diary was filled within the cadence, the client
+// is shown an inspirational post on the frontend and if it hasn't been filled, we show them the health diary.
 
 // ICreateHealthDiaryEntry is an interface that holds the method signature for creating a health diary entry
 type ICreateHealthDiaryEntry interface {
@@ -130,7 +130,18 @@ func (h UseCasesHealthDiaryImpl) CanRecordHeathDiary(ctx context.Context, client
 	if clientID == "" {
 		return false, exceptions.EmptyInputErr(fmt.Errorf("empty client ID value passed in input"))
 	}
-	return h.Query.CanRecordHeathDiary(ctx, clientID)
+
+	client, err := h.Query.GetClientProfileByClientID(ctx, clientID)
+	if err != nil {
+		return false, exceptions.ClientProfileNotFoundErr(err)
+	}
+
+	settings, err := h.Query.GetOrganisationSettings(ctx, client.OrganisationID)
+	if err != nil {
+		return false, exceptions.InternalErr(fmt.Errorf("failed to get organisation settings: %v", err))
+	}
+
+	return h.Query.CanRecordHeathDiary(ctx, clientID, time.Duration(settings.DiaryCadenceHours)*time.Hour)
 }
 
 // GetClientHealthDiaryQuote gets a quote from the database to display on the UI. This happens after a client has already
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
@@ -201,6 +202,15 @@ func TestUsecaseHealthDiaryImpl_CanRecordHeathDiary(t *testing.T) {
 			want:    true,
 			wantErr: false,
 		},
+		{
+			name: "happy case: uses the organisation's diary cadence",
+			args: args{
+				ctx:      context.Background(),
+				clientID: uuid.New().String(),
+			},
+			want:    true,
+			wantErr: false,
+		},
 		{
 			name: "invalid: missing user ID",
 			args: args{
@@ -209,12 +219,52 @@ func TestUsecaseHealthDiaryImpl_CanRecordHeathDiary(t *testing.T) {
 			want:    false,
 			wantErr: true,
 		},
+		{
+			name: "invalid: client not found",
+			args: args{
+				ctx:      context.Background(),
+				clientID: uuid.New().String(),
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "invalid: failed to get organisation settings",
+			args: args{
+				ctx:      context.Background(),
+				clientID: uuid.New().String(),
+			},
+			want:    false,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeDB := pgMock.NewPostgresMock()
 			healthdiary := healthdiary.NewUseCaseHealthDiaryImpl(fakeDB, fakeDB)
 
+			if tt.name == "happy case: uses the organisation's diary cadence" {
+				fakeDB.MockGetOrganisationSettingsFn = func(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error) {
+					return &domain.OrganisationSettings{DiaryCadenceHours: 12}, nil
+				}
+				fakeDB.MockCanRecordHeathDiaryFn = func(ctx context.Context, userID string, cadence time.Duration) (bool, error) {
+					if cadence != 12*time.Hour {
+						return false, fmt.Errorf("expected a cadence of 12 hours, got %v", cadence)
+					}
+					return true, nil
+				}
+			}
+			if tt.name == "invalid: client not found" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("client not found")
+				}
+			}
+			if tt.name == "invalid: failed to get organisation settings" {
+				fakeDB.MockGetOrganisationSettingsFn = func(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
 			got, err := healthdiary.CanRecordHeathDiary(tt.args.ctx, tt.args.clientID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UsecaseHealthDiaryImpl.CanRecordHeathDiary() error = %v, wantErr %v", err, tt.wantErr)
@@ -0,0 +1,94 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/google/uuid"
+	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+// OrganisationUseCaseMock mocks the implementation of the organisation usecase
+type OrganisationUseCaseMock struct {
+	MockCreateOrganisationFn      func(ctx context.Context, input dto.OrganisationInput) (*domain.Organisation, error)
+	MockUpdateOrganisationFn      func(ctx context.Context, organisationID string, input dto.OrganisationUpdateInput) (bool, error)
+	MockDeactivateOrganisationFn  func(ctx context.Context, organisationID string) (bool, error)
+	MockGetOrganisationFn         func(ctx context.Context, organisationID string) (*domain.Organisation, error)
+	MockListOrganisationsFn       func(ctx context.Context) ([]*domain.Organisation, error)
+	MockGetOrganisationSettingsFn func(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error)
+}
+
+// NewOrganisationUseCaseMock initializes a new instance mock of the organisation usecase
+func NewOrganisationUseCaseMock() *OrganisationUseCaseMock {
+	id := uuid.New().String()
+	settings := &domain.OrganisationSettings{
+		PINExpiryDays:       30,
+		InvitePINExpiryDays: 7,
+		DiaryCadenceHours:   24,
+		SMSSenderID:         enumutils.SenderIDBewell,
+	}
+	organisation := &domain.Organisation{
+		ID:             &id,
+		Active:         true,
+		OrgCode:        "test",
+		Code:           1,
+		Name:           gofakeit.Company(),
+		EmailAddress:   gofakeit.Email(),
+		PhoneNumber:    "+254711223344",
+		DefaultCountry: "KEN",
+		Settings:       settings,
+	}
+
+	return &OrganisationUseCaseMock{
+		MockCreateOrganisationFn: func(ctx context.Context, input dto.OrganisationInput) (*domain.Organisation, error) {
+			return organisation, nil
+		},
+		MockUpdateOrganisationFn: func(ctx context.Context, organisationID string, input dto.OrganisationUpdateInput) (bool, error) {
+			return true, nil
+		},
+		MockDeactivateOrganisationFn: func(ctx context.Context, organisationID string) (bool, error) {
+			return true, nil
+		},
+		MockGetOrganisationFn: func(ctx context.Context, organisationID string) (*domain.Organisation, error) {
+			return organisation, nil
+		},
+		MockListOrganisationsFn: func(ctx context.Context) ([]*domain.Organisation, error) {
+			return []*domain.Organisation{organisation}, nil
+		},
+		MockGetOrganisationSettingsFn: func(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error) {
+			return settings, nil
+		},
+	}
+}
+
+// CreateOrganisation mocks the implementation of creating an organisation
+func (o *OrganisationUseCaseMock) CreateOrganisation(ctx context.Context, input dto.OrganisationInput) (*domain.Organisation, error) {
+	return o.MockCreateOrganisationFn(ctx, input)
+}
+
+// UpdateOrganisation mocks the implementation of updating an organisation
+func (o *OrganisationUseCaseMock) UpdateOrganisation(ctx context.Context, organisationID string, input dto.OrganisationUpdateInput) (bool, error) {
+	return o.MockUpdateOrganisationFn(ctx, organisationID, input)
+}
+
+// DeactivateOrganisation mocks the implementation of deactivating an organisation
+func (o *OrganisationUseCaseMock) DeactivateOrganisation(ctx context.Context, organisationID string) (bool, error) {
+	return o.MockDeactivateOrganisationFn(ctx, organisationID)
+}
+
+// GetOrganisation mocks the implementation of fetching an organisation
+func (o *OrganisationUseCaseMock) GetOrganisation(ctx context.Context, organisationID string) (*domain.Organisation, error) {
+	return o.MockGetOrganisationFn(ctx, organisationID)
+}
+
+// ListOrganisations mocks the implementation of listing organisations
+func (o *OrganisationUseCaseMock) ListOrganisations(ctx context.Context) ([]*domain.Organisation, error) {
+	return o.MockListOrganisationsFn(ctx)
+}
+
+// GetOrganisationSettings mocks the implementation of fetching the settings of an organisation
+func (o *OrganisationUseCaseMock) GetOrganisationSettings(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error) {
+	return o.MockGetOrganisationSettingsFn(ctx, organisationID)
+}
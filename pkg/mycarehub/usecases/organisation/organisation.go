@@ -0,0 +1,137 @@
+package organisation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
+)
+
+// An organisation is a partner program running on the platform. Users, facilities and content belong to
+// an organisation. Each organisation can tune the PIN expiry, the invite PIN expiry, the health diary cadence
+// and the SMS sender ID of its users. Settings that an organisation has not set follow the platform defaults.
+
+// ICreateOrganisation contains the method used to create an organisation
+type ICreateOrganisation interface {
+	CreateOrganisation(ctx context.Context, input dto.OrganisationInput) (*domain.Organisation, error)
+}
+
+// IUpdateOrganisation contains the methods used to change an organisation
+type IUpdateOrganisation interface {
+	UpdateOrganisation(ctx context.Context, organisationID string, input dto.OrganisationUpdateInput) (bool, error)
+	DeactivateOrganisation(ctx context.Context, organisationID string) (bool, error)
+}
+
+// IRetrieveOrganisation contains the methods used to fetch organisations and their settings
+type IRetrieveOrganisation interface {
+	GetOrganisation(ctx context.Context, organisationID string) (*domain.Organisation, error)
+	ListOrganisations(ctx context.Context) ([]*domain.Organisation, error)
+	GetOrganisationSettings(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error)
+}
+
+// UseCasesOrganisation groups all the interfaces of the organisation usecase
+type UseCasesOrganisation interface {
+	ICreateOrganisation
+	IUpdateOrganisation
+	IRetrieveOrganisation
+}
+
+// UseCasesOrganisationImpl represents the organisation implementation
+type UseCasesOrganisationImpl struct {
+	Create infrastructure.Create
+	Query  infrastructure.Query
+	Update infrastructure.Update
+}
+
+// NewUseCasesOrganisation is the controller function for the organisation usecase
+func NewUseCasesOrganisation(
+	create infrastructure.Create,
+	query infrastructure.Query,
+	update infrastructure.Update,
+) *UseCasesOrganisationImpl {
+	return &UseCasesOrganisationImpl{
+		Create: create,
+		Query:  query,
+		Update: update,
+	}
+}
+
+// CreateOrganisation creates a new organisation
+func (o *UseCasesOrganisationImpl) CreateOrganisation(ctx context.Context, input dto.OrganisationInput) (*domain.Organisation, error) {
+	if err := input.Validate(); err != nil {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("invalid organisation input: %v", err))
+	}
+
+	organisation, err := o.Create.CreateOrganisation(ctx, &input)
+	if err != nil {
+		return nil, exceptions.FailedToSaveItemErr(fmt.Errorf("failed to create organisation: %v", err))
+	}
+	return organisation, nil
+}
+
+// UpdateOrganisation changes the details and settings of an organisation that are set in the input
+func (o *UseCasesOrganisationImpl) UpdateOrganisation(ctx context.Context, organisationID string, input dto.OrganisationUpdateInput) (bool, error) {
+	if organisationID == "" {
+		return false, exceptions.InputValidationErr(fmt.Errorf("organisation ID must be provided"))
+	}
+	if err := input.Validate(); err != nil {
+		return false, exceptions.InputValidationErr(fmt.Errorf("invalid organisation input: %v", err))
+	}
+
+	ok, err := o.Update.UpdateOrganisation(ctx, organisationID, &input)
+	if err != nil {
+		return false, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to update organisation: %v", err))
+	}
+	return ok, nil
+}
+
+// DeactivateOrganisation marks an organisation as inactive
+func (o *UseCasesOrganisationImpl) DeactivateOrganisation(ctx context.Context, organisationID string) (bool, error) {
+	if organisationID == "" {
+		return false, exceptions.InputValidationErr(fmt.Errorf("organisation ID must be provided"))
+	}
+
+	ok, err := o.Update.DeactivateOrganisation(ctx, organisationID)
+	if err != nil {
+		return false, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to deactivate organisation: %v", err))
+	}
+	return ok, nil
+}
+
+// GetOrganisation fetches an organisation together with its effective settings
+func (o *UseCasesOrganisationImpl) GetOrganisation(ctx context.Context, organisationID string) (*domain.Organisation, error) {
+	if organisationID == "" {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("organisation ID must be provided"))
+	}
+
+	organisation, err := o.Query.GetOrganisation(ctx, organisationID)
+	if err != nil {
+		return nil, exceptions.ItemNotFoundErr(fmt.Errorf("failed to get organisation: %v", err))
+	}
+	return organisation, nil
+}
+
+// ListOrganisations fetches all the organisations on the platform
+func (o *UseCasesOrganisationImpl) ListOrganisations(ctx context.Context) ([]*domain.Organisation, error) {
+	organisations, err := o.Query.ListOrganisations(ctx)
+	if err != nil {
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to list organisations: %v", err))
+	}
+	return organisations, nil
+}
+
+// GetOrganisationSettings returns the settings that apply to the users of an organisation
+func (o *UseCasesOrganisationImpl) GetOrganisationSettings(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error) {
+	if organisationID == "" {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("organisation ID must be provided"))
+	}
+
+	settings, err := o.Query.GetOrganisationSettings(ctx, organisationID)
+	if err != nil {
+		return nil, exceptions.ItemNotFoundErr(fmt.Errorf("failed to get organisation settings: %v", err))
+	}
+	return settings, nil
+}
@@ -0,0 +1,384 @@
+package organisation_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/organisation"
+)
+
+func TestUseCasesOrganisationImpl_CreateOrganisation(t *testing.T) {
+	ctx := context.Background()
+
+	validInput := dto.OrganisationInput{
+		Name:           gofakeit.Company(),
+		OrgCode:        "TEST",
+		Code:           1,
+		EmailAddress:   gofakeit.Email(),
+		PhoneNumber:    "+254711223344",
+		DefaultCountry: "KEN",
+	}
+
+	type args struct {
+		ctx   context.Context
+		input dto.OrganisationInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - invalid input",
+			args: args{
+				ctx:   ctx,
+				input: dto.OrganisationInput{Name: gofakeit.Company()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to create organisation",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			o := organisation.NewUseCasesOrganisation(fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to create organisation" {
+				fakeDB.MockCreateOrganisationFn = func(ctx context.Context, input *dto.OrganisationInput) (*domain.Organisation, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := o.CreateOrganisation(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOrganisationImpl.CreateOrganisation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected an organisation to be returned")
+			}
+		})
+	}
+}
+
+func TestUseCasesOrganisationImpl_UpdateOrganisation(t *testing.T) {
+	ctx := context.Background()
+	name := gofakeit.Company()
+	zero := 0
+
+	type args struct {
+		ctx            context.Context
+		organisationID string
+		input          dto.OrganisationUpdateInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+				input:          dto.OrganisationUpdateInput{Name: &name},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no organisation ID",
+			args: args{
+				ctx:   ctx,
+				input: dto.OrganisationUpdateInput{Name: &name},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid settings",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+				input: dto.OrganisationUpdateInput{
+					Settings: &dto.OrganisationSettingsInput{PINExpiryDays: &zero},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to update organisation",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+				input:          dto.OrganisationUpdateInput{Name: &name},
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			o := organisation.NewUseCasesOrganisation(fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to update organisation" {
+				fakeDB.MockUpdateOrganisationFn = func(ctx context.Context, organisationID string, input *dto.OrganisationUpdateInput) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := o.UpdateOrganisation(tt.args.ctx, tt.args.organisationID, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOrganisationImpl.UpdateOrganisation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesOrganisationImpl.UpdateOrganisation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesOrganisationImpl_DeactivateOrganisation(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx            context.Context
+		organisationID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no organisation ID",
+			args: args{
+				ctx: ctx,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to deactivate organisation",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			o := organisation.NewUseCasesOrganisation(fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to deactivate organisation" {
+				fakeDB.MockDeactivateOrganisationFn = func(ctx context.Context, organisationID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := o.DeactivateOrganisation(tt.args.ctx, tt.args.organisationID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOrganisationImpl.DeactivateOrganisation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesOrganisationImpl.DeactivateOrganisation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesOrganisationImpl_GetOrganisation(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx            context.Context
+		organisationID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no organisation ID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - organisation not found",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			o := organisation.NewUseCasesOrganisation(fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Sad case - organisation not found" {
+				fakeDB.MockGetOrganisationFn = func(ctx context.Context, organisationID string) (*domain.Organisation, error) {
+					return nil, fmt.Errorf("organisation not found")
+				}
+			}
+
+			got, err := o.GetOrganisation(tt.args.ctx, tt.args.organisationID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOrganisationImpl.GetOrganisation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected an organisation to be returned")
+			}
+		})
+	}
+}
+
+func TestUseCasesOrganisationImpl_ListOrganisations(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case",
+			wantErr: false,
+		},
+		{
+			name:    "Sad case - failed to list organisations",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			o := organisation.NewUseCasesOrganisation(fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to list organisations" {
+				fakeDB.MockListOrganisationsFn = func(ctx context.Context) ([]*domain.Organisation, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := o.ListOrganisations(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOrganisationImpl.ListOrganisations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected organisations to be returned")
+			}
+		})
+	}
+}
+
+func TestUseCasesOrganisationImpl_GetOrganisationSettings(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx            context.Context
+		organisationID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no organisation ID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - failed to get settings",
+			args: args{
+				ctx:            ctx,
+				organisationID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			o := organisation.NewUseCasesOrganisation(fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "Sad case - failed to get settings" {
+				fakeDB.MockGetOrganisationSettingsFn = func(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := o.GetOrganisationSettings(tt.args.ctx, tt.args.organisationID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOrganisationImpl.GetOrganisationSettings() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected organisation settings to be returned")
+			}
+		})
+	}
+}
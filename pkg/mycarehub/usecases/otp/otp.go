@@ -9,6 +9,7 @@ import (
 	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/interserviceclient"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
@@ -19,10 +20,6 @@ import (
 	"github.com/savannahghi/profileutils"
 )
 
-const (
-	otpMessage = "%s is your MyCareHub verification code"
-)
-
 // IGenerateOTP specifies the method signature for generating an OTP
 type IGenerateOTP interface {
 	// TODO: ensure generated OTP is valid e.g valid until > generated at
@@ -119,7 +116,10 @@ func (o *UseCaseOTPImpl) GenerateAndSendOTP(
 		return "", fmt.Errorf("failed to generate an OTP")
 	}
 
-	message := fmt.Sprintf(otpMessage, otp)
+	message, err := helpers.RenderMessage(userProfile, helpers.OTPMessage, otp)
+	if err != nil {
+		return "", exceptions.InternalErr(fmt.Errorf("failed to render OTP message: %v", err))
+	}
 	otp, err = o.SendOTP(ctx, *phone, otp, message)
 	if err != nil {
 		return "", err
@@ -179,7 +179,10 @@ func (o *UseCaseOTPImpl) VerifyPhoneNumber(ctx context.Context, phone string, fl
 		return nil, fmt.Errorf("failed to generate an OTP")
 	}
 
-	message := fmt.Sprintf(otpMessage, otp)
+	message, err := helpers.RenderMessage(userProfile, helpers.OTPMessage, otp)
+	if err != nil {
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to render OTP message: %v", err))
+	}
 	otp, err = o.SendOTP(ctx, phone, otp, message)
 	if err != nil {
 		return nil, err
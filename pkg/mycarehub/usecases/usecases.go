@@ -1,11 +1,14 @@
 package usecases
 
 import (
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/clienttransfer"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/content"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/facility"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/faq"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/feedback"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/healthdiary"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/organisation"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/securityquestions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/servicerequest"
@@ -25,6 +28,9 @@ type MyCareHub struct {
 	HealthDiary       healthdiary.UseCasesHealthDiary
 	FAQ               faq.UsecaseFAQ
 	ServiceRequest    servicerequest.UseCaseServiceRequest
+	Authority         authority.UseCasesAuthority
+	Organisation      organisation.UseCasesOrganisation
+	ClientTransfer    clienttransfer.UseCasesClientTransfer
 }
 
 // NewMyCareHubUseCase initializes a new my care hub instance
@@ -39,6 +45,9 @@ func NewMyCareHubUseCase(
 	healthDiary healthdiary.UseCasesHealthDiary,
 	faq faq.UsecaseFAQ,
 	servicerequest servicerequest.UseCaseServiceRequest,
+	authority authority.UseCasesAuthority,
+	organisation organisation.UseCasesOrganisation,
+	clientTransfer clienttransfer.UseCasesClientTransfer,
 ) *MyCareHub {
 	return &MyCareHub{
 		User:              user,
@@ -51,5 +60,8 @@ func NewMyCareHubUseCase(
 		HealthDiary:       healthDiary,
 		FAQ:               faq,
 		ServiceRequest:    servicerequest,
+		Authority:         authority,
+		Organisation:      organisation,
+		ClientTransfer:    clientTransfer,
 	}
 }
@@ -6,22 +6,32 @@ import (
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 )
 
 // UserUseCaseMock mocks the implementation of usecase methods.
 type UserUseCaseMock struct {
-	MockLoginFn           func(ctx context.Context, phoneNumber string, pin string, flavour feedlib.Flavour) (*domain.LoginResponse, int, error)
-	MockInviteUserFn      func(ctx context.Context, userID string, phoneNumber string, flavour feedlib.Flavour) (bool, error)
-	MockSavePinFn         func(ctx context.Context, input dto.PINInput) (bool, error)
-	MockVerifyLoginPINFn  func(ctx context.Context, userID string, pin string) (bool, int, error)
-	MockSetNickNameFn     func(ctx context.Context, userID *string, nickname *string) (bool, error)
-	MockRequestPINResetFn func(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (string, error)
-	MockResetPINFn        func(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error)
-	MockRefreshTokenFn    func(ctx context.Context, userID string) (*domain.AuthCredentials, error)
-	MockVerifyPINFn       func(ctx context.Context, userID string, flavour feedlib.Flavour, pin string) (bool, error)
+	MockLoginFn                         func(ctx context.Context, phoneNumber string, pin string, flavour feedlib.Flavour) (*domain.LoginResponse, int, error)
+	MockInviteUserFn                    func(ctx context.Context, userID string, phoneNumber string, flavour feedlib.Flavour) (bool, error)
+	MockSavePinFn                       func(ctx context.Context, input dto.PINInput) (bool, error)
+	MockVerifyLoginPINFn                func(ctx context.Context, userID string, pin string) (bool, int, error)
+	MockSetNickNameFn                   func(ctx context.Context, userID *string, nickname *string) (bool, error)
+	MockRequestPINResetFn               func(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (string, error)
+	MockResetPINFn                      func(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error)
+	MockRefreshTokenFn                  func(ctx context.Context, userID string) (*domain.AuthCredentials, error)
+	MockVerifyPINFn                     func(ctx context.Context, userID string, flavour feedlib.Flavour, pin string) (bool, error)
+	MockSetUserPreferredLanguageFn      func(ctx context.Context, userID string, language enumutils.Language) (bool, error)
+	MockBulkInviteUsersFn               func(ctx context.Context, csvContent string, flavour feedlib.Flavour) (string, error)
+	MockGetBulkInviteJobFn              func(ctx context.Context, jobID string) (*domain.BulkInviteJob, error)
+	MockResendInviteFn                  func(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error)
+	MockListPendingInvitationsFn        func(ctx context.Context, facilityID string) ([]*domain.Invitation, error)
+	MockRegisterClientFn                func(ctx context.Context, input dto.ClientRegistrationInput) (*domain.ClientProfile, error)
+	MockRegisterStaffFn                 func(ctx context.Context, input dto.StaffRegistrationInput) (*domain.StaffProfile, error)
+	MockFailInterruptedBulkInviteJobsFn func(ctx context.Context) (int, error)
 }
 
 // NewUserUseCaseMock creates in itializes create type mocks
@@ -80,6 +90,68 @@ func NewUserUseCaseMock() *UserUseCaseMock {
 		MockVerifyPINFn: func(ctx context.Context, userID string, flavour feedlib.Flavour, pin string) (bool, error) {
 			return true, nil
 		},
+		MockSetUserPreferredLanguageFn: func(ctx context.Context, userID string, language enumutils.Language) (bool, error) {
+			return true, nil
+		},
+		MockBulkInviteUsersFn: func(ctx context.Context, csvContent string, flavour feedlib.Flavour) (string, error) {
+			return uuid.New().String(), nil
+		},
+		MockGetBulkInviteJobFn: func(ctx context.Context, jobID string) (*domain.BulkInviteJob, error) {
+			return &domain.BulkInviteJob{
+				ID:        jobID,
+				Status:    enums.BulkInviteJobStatusCompleted,
+				Flavour:   feedlib.FlavourConsumer,
+				Total:     1,
+				Succeeded: 1,
+				Rows: []*domain.BulkInviteRow{
+					{
+						RowNumber:   1,
+						UserID:      uuid.New().String(),
+						PhoneNumber: gofakeit.Phone(),
+						Status:      enums.BulkInviteRowStatusSuccess,
+					},
+				},
+			}, nil
+		},
+		MockResendInviteFn: func(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error) {
+			return true, nil
+		},
+		MockListPendingInvitationsFn: func(ctx context.Context, facilityID string) ([]*domain.Invitation, error) {
+			return []*domain.Invitation{
+				{
+					ID:          uuid.New().String(),
+					UserID:      uuid.New().String(),
+					PhoneNumber: gofakeit.Phone(),
+					Flavour:     feedlib.FlavourConsumer,
+					Status:      enums.InvitationStatusIssued,
+					IssuedAt:    time.Now(),
+					ExpiresAt:   time.Now().Add(time.Hour * 24),
+				},
+			}, nil
+		},
+		MockRegisterClientFn: func(ctx context.Context, input dto.ClientRegistrationInput) (*domain.ClientProfile, error) {
+			id := uuid.New().String()
+			return &domain.ClientProfile{
+				ID:         &id,
+				UserID:     uuid.New().String(),
+				Active:     true,
+				ClientType: input.ClientType,
+				FacilityID: input.FacilityID,
+			}, nil
+		},
+		MockRegisterStaffFn: func(ctx context.Context, input dto.StaffRegistrationInput) (*domain.StaffProfile, error) {
+			id := uuid.New().String()
+			return &domain.StaffProfile{
+				ID:                &id,
+				UserID:            uuid.New().String(),
+				Active:            true,
+				StaffNumber:       input.StaffNumber,
+				DefaultFacilityID: input.FacilityID,
+			}, nil
+		},
+		MockFailInterruptedBulkInviteJobsFn: func(ctx context.Context) (int, error) {
+			return 1, nil
+		},
 	}
 }
 
@@ -127,3 +199,43 @@ func (f *UserUseCaseMock) RefreshToken(ctx context.Context, userID string) (*dom
 func (f *UserUseCaseMock) VerifyPIN(ctx context.Context, userID string, flavour feedlib.Flavour, pin string) (bool, error) {
 	return f.MockVerifyPINFn(ctx, userID, flavour, pin)
 }
+
+// SetUserPreferredLanguage mocks the implementation of setting a user's preferred language
+func (f *UserUseCaseMock) SetUserPreferredLanguage(ctx context.Context, userID string, language enumutils.Language) (bool, error) {
+	return f.MockSetUserPreferredLanguageFn(ctx, userID, language)
+}
+
+// BulkInviteUsers mocks the implementation of inviting users from a CSV file
+func (f *UserUseCaseMock) BulkInviteUsers(ctx context.Context, csvContent string, flavour feedlib.Flavour) (string, error) {
+	return f.MockBulkInviteUsersFn(ctx, csvContent, flavour)
+}
+
+// GetBulkInviteJob mocks the implementation of getting the progress of a bulk invite job
+func (f *UserUseCaseMock) GetBulkInviteJob(ctx context.Context, jobID string) (*domain.BulkInviteJob, error) {
+	return f.MockGetBulkInviteJobFn(ctx, jobID)
+}
+
+// ResendInvite mocks the implementation of resending an invite
+func (f *UserUseCaseMock) ResendInvite(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error) {
+	return f.MockResendInviteFn(ctx, userID, flavour)
+}
+
+// ListPendingInvitations mocks the implementation of listing the pending invitations of a facility
+func (f *UserUseCaseMock) ListPendingInvitations(ctx context.Context, facilityID string) ([]*domain.Invitation, error) {
+	return f.MockListPendingInvitationsFn(ctx, facilityID)
+}
+
+// RegisterClient mocks the implementation of registering a client
+func (f *UserUseCaseMock) RegisterClient(ctx context.Context, input dto.ClientRegistrationInput) (*domain.ClientProfile, error) {
+	return f.MockRegisterClientFn(ctx, input)
+}
+
+// RegisterStaff mocks the implementation of registering a staff member
+func (f *UserUseCaseMock) RegisterStaff(ctx context.Context, input dto.StaffRegistrationInput) (*domain.StaffProfile, error) {
+	return f.MockRegisterStaffFn(ctx, input)
+}
+
+// FailInterruptedBulkInviteJobs mocks the implementation of failing the bulk invite jobs that were interrupted
+func (f *UserUseCaseMock) FailInterruptedBulkInviteJobs(ctx context.Context) (int, error) {
+	return f.MockFailInterruptedBulkInviteJobsFn(ctx)
+}
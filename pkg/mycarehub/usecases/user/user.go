@@ -2,22 +2,41 @@ package user
 
 import (
 	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/savannahghi/converterandformatter"
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
 	utilsExt "github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
-	"github.com/savannahghi/serverutils"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxBulkInviteRows is the maximum number of users that can be invited from a single CSV file
+	maxBulkInviteRows = 1000
+
+	// bulkInviteSMSInterval is the minimum time between two invite SMSs sent by a bulk invite job
+	bulkInviteSMSInterval = time.Second / 5
+
+	// bulkInviteJobStaleAfter is how long a bulk invite job can go without recording progress before it is
+	// considered interrupted
+	bulkInviteJobStaleAfter = 10 * time.Minute
 )
 
 // ILogin is an interface that contans login related methods
@@ -51,6 +70,11 @@ type ISetNickName interface {
 	SetNickName(ctx context.Context, userID *string, nickname *string) (bool, error)
 }
 
+// ISetUserPreferredLanguage is used to set the language that the user's messages are sent in
+type ISetUserPreferredLanguage interface {
+	SetUserPreferredLanguage(ctx context.Context, userID string, language enumutils.Language) (bool, error)
+}
+
 // IRequestPinReset defines a method signature that is used to request a pin reset
 type IRequestPinReset interface {
 	RequestPINReset(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (string, error)
@@ -66,17 +90,40 @@ type IResetPIN interface {
 	ResetPIN(ctx context.Context, input dto.UserResetPinInput) (bool, error)
 }
 
+// IBulkInviteUsers is used by staff to invite the users listed in a CSV file
+type IBulkInviteUsers interface {
+	BulkInviteUsers(ctx context.Context, csvContent string, flavour feedlib.Flavour) (string, error)
+	GetBulkInviteJob(ctx context.Context, jobID string) (*domain.BulkInviteJob, error)
+	FailInterruptedBulkInviteJobs(ctx context.Context) (int, error)
+}
+
+// IManageInvitations is used by staff to follow up on the invites that have not been accepted
+type IManageInvitations interface {
+	ResendInvite(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error)
+	ListPendingInvitations(ctx context.Context, facilityID string) ([]*domain.Invitation, error)
+}
+
+// IRegisterUser is used by staff to register new clients and staff members
+type IRegisterUser interface {
+	RegisterClient(ctx context.Context, input dto.ClientRegistrationInput) (*domain.ClientProfile, error)
+	RegisterStaff(ctx context.Context, input dto.StaffRegistrationInput) (*domain.StaffProfile, error)
+}
+
 // UseCasesUser group all business logic usecases related to user
 type UseCasesUser interface {
 	ILogin
 	ISetUserPIN
 	IVerifyLoginPIN
 	ISetNickName
+	ISetUserPreferredLanguage
 	IRequestPinReset
 	ICompleteOnboardingTour
 	IResetPIN
 	IRefreshToken
 	IVerifyPIN
+	IBulkInviteUsers
+	IManageInvitations
+	IRegisterUser
 }
 
 // UseCasesUserImpl represents user implementation object
@@ -87,6 +134,7 @@ type UseCasesUserImpl struct {
 	Update      infrastructure.Update
 	ExternalExt extension.ExternalMethodsExtension
 	OTP         otp.UsecaseOTP
+	Authority   authority.UseCasesAuthority
 }
 
 // NewUseCasesUserImpl returns a new user service
@@ -97,6 +145,7 @@ func NewUseCasesUserImpl(
 	update infrastructure.Update,
 	externalExt extension.ExternalMethodsExtension,
 	otp otp.UsecaseOTP,
+	authority authority.UseCasesAuthority,
 ) *UseCasesUserImpl {
 	return &UseCasesUserImpl{
 		Create:      create,
@@ -105,6 +154,7 @@ func NewUseCasesUserImpl(
 		Update:      update,
 		ExternalExt: externalExt,
 		OTP:         otp,
+		Authority:   authority,
 	}
 }
 
@@ -191,6 +241,11 @@ func (us *UseCasesUserImpl) Login(ctx context.Context, phoneNumber string, pin s
 		return nil, int(exceptions.Internal), fmt.Errorf("please try again after a while")
 	}
 
+	// a temporary PIN sent with an invite that has expired cannot be used to log in
+	if err := us.checkUserInvitation(ctx, *userProfile.ID, flavour); err != nil {
+		return nil, int(exceptions.ExpiredPinError), err
+	}
+
 	_, statusCode, err := us.VerifyLoginPIN(ctx, *userProfile.ID, pin)
 	if err != nil {
 		return nil, statusCode, err
@@ -231,9 +286,68 @@ func (us *UseCasesUserImpl) Login(ctx context.Context, phoneNumber string, pin s
 	return loginResponse, int(exceptions.OK), nil
 }
 
+// checkUserInvitation returns an error when the user's latest invite is still pending but has expired. The invite
+// is expired and its temporary PIN invalidated on the spot. Users who were never invited are not affected.
+func (us *UseCasesUserImpl) checkUserInvitation(ctx context.Context, userID string, flavour feedlib.Flavour) error {
+	invitation, err := us.Query.GetLatestUserInvitation(ctx, userID, flavour)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return exceptions.InternalErr(fmt.Errorf("failed to get the invite of user %s: %v", userID, err))
+	}
+	if invitation.Status != enums.InvitationStatusIssued && invitation.Status != enums.InvitationStatusDelivered {
+		return nil
+	}
+	if time.Now().Before(invitation.ExpiresAt) {
+		return nil
+	}
+
+	// the overdue invite is also caught by the scheduled expiry of invites hence a failure here only needs to be logged
+	if _, err := us.Update.InvalidatePIN(ctx, userID); err != nil {
+		log.Warnf("failed to invalidate the temporary PIN of user %s: %v", userID, err)
+	}
+	if _, err := us.Update.CloseUserInvitations(ctx, userID, flavour, enums.InvitationStatusExpired); err != nil {
+		log.Warnf("failed to expire the invite of user %s: %v", userID, err)
+	}
+	return exceptions.ExpiredPinErr(fmt.Errorf("the invite has expired, ask your facility to send a new one"))
+}
+
 // InviteUser is used to invite a user to the application. The invite link that is sent to the
 // user will open the app if installed OR goes to the store if not installed.
+// Only staff who can access the facility of the user are allowed to invite them.
 func (us *UseCasesUserImpl) InviteUser(ctx context.Context, userID string, phoneNumber string, flavour feedlib.Flavour) (bool, error) {
+	if err := us.checkUserAccess(ctx, userID, flavour, enums.PermissionTypeCanInviteUser); err != nil {
+		return false, err
+	}
+	return us.inviteUser(ctx, userID, phoneNumber, flavour)
+}
+
+// checkUserAccess ensures that the logged in user can act on the records of the client or staff member that
+// the flavour refers to. Clients are checked against their facility and staff against their default facility.
+func (us *UseCasesUserImpl) checkUserAccess(ctx context.Context, userID string, flavour feedlib.Flavour, permission enums.PermissionType) error {
+	switch flavour {
+	case feedlib.FlavourConsumer:
+		client, err := us.Query.GetClientProfileByUserID(ctx, userID)
+		if err != nil {
+			return exceptions.ClientProfileNotFoundErr(fmt.Errorf("failed to get client profile of user %s: %v", userID, err))
+		}
+		_, err = us.Authority.CheckClientAccess(ctx, *client.ID, permission)
+		return err
+	case feedlib.FlavourPro:
+		staff, err := us.Query.GetStaffProfileByUserID(ctx, userID)
+		if err != nil {
+			return exceptions.ProfileNotFoundErr(fmt.Errorf("failed to get staff profile of user %s: %v", userID, err))
+		}
+		_, err = us.Authority.CheckFacilityAccess(ctx, staff.DefaultFacilityID, permission)
+		return err
+	default:
+		return exceptions.InvalidFlavourDefinedErr(fmt.Errorf("flavour is not valid"))
+	}
+}
+
+// inviteUser sends the invite once the caller has checked that the logged in user can invite the user
+func (us *UseCasesUserImpl) inviteUser(ctx context.Context, userID string, phoneNumber string, flavour feedlib.Flavour) (bool, error) {
 	phone, err := converterandformatter.NormalizeMSISDN(phoneNumber)
 	if err != nil {
 		return false, exceptions.NormalizeMSISDNError(err)
@@ -253,14 +367,12 @@ func (us *UseCasesUserImpl) InviteUser(ctx context.Context, userID string, phone
 		return false, exceptions.GeneratePinErr(fmt.Errorf("failed to generate temporary pin: %v", err))
 	}
 
-	pinExpiryDays := serverutils.MustGetEnvVar("INVITE_PIN_EXPIRY_DAYS")
-
-	pinExpiryDaysInt, err := strconv.Atoi(pinExpiryDays)
+	settings, err := us.Query.GetOrganisationSettings(ctx, userProfile.OrganisationID)
 	if err != nil {
-		return false, exceptions.InternalErr(fmt.Errorf("failed to convert invite pin expiry days to int"))
+		return false, exceptions.InternalErr(fmt.Errorf("failed to get organisation settings: %v", err))
 	}
 
-	pinExpiryDate := time.Now().AddDate(0, 0, pinExpiryDaysInt)
+	pinExpiryDate := time.Now().AddDate(0, 0, settings.InvitePINExpiryDays)
 
 	salt, encryptedTempPin := us.ExternalExt.EncryptPIN(tempPin, nil)
 	pinPayload := &domain.UserPIN{
@@ -283,18 +395,44 @@ func (us *UseCasesUserImpl) InviteUser(ctx context.Context, userID string, phone
 		return false, exceptions.SaveUserPinError(err)
 	}
 
+	// a new invite supersedes any earlier invite whose temporary PIN has just been invalidated
+	_, err = us.Update.CloseUserInvitations(ctx, userID, flavour, enums.InvitationStatusExpired)
+	if err != nil {
+		return false, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to expire previous invitations: %v", err))
+	}
+
+	invitation, err := us.Create.SaveInvitation(ctx, &domain.Invitation{
+		UserID:      userID,
+		PhoneNumber: *phone,
+		Flavour:     flavour,
+		Status:      enums.InvitationStatusIssued,
+		IssuedAt:    time.Now(),
+		ExpiresAt:   pinExpiryDate,
+	})
+	if err != nil {
+		return false, exceptions.FailedToSaveItemErr(fmt.Errorf("failed to save invitation: %v", err))
+	}
+
 	inviteLink, err := helpers.GetInviteLink(flavour)
 	if err != nil {
 		return false, exceptions.GetInviteLinkErr(err)
 	}
 
-	message := helpers.CreateInviteMessage(userProfile, inviteLink, tempPin)
+	message, err := helpers.CreateInviteMessage(userProfile, inviteLink, tempPin)
+	if err != nil {
+		return false, exceptions.InternalErr(fmt.Errorf("failed to create invite message: %v", err))
+	}
 
-	err = us.ExternalExt.SendInviteSMS(ctx, *phone, message)
+	err = us.ExternalExt.SendInviteSMS(ctx, *phone, message, settings.SMSSenderID)
 	if err != nil {
 		return false, exceptions.SendSMSErr(fmt.Errorf("failed to send invite SMS: %v", err))
 	}
 
+	_, err = us.Update.UpdateInvitationStatus(ctx, invitation.ID, enums.InvitationStatusDelivered)
+	if err != nil {
+		return false, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to mark invitation as delivered: %v", err))
+	}
+
 	return true, nil
 }
 
@@ -321,16 +459,16 @@ func (us *UseCasesUserImpl) SetUserPIN(ctx context.Context, input dto.PINInput)
 		return false, exceptions.PinMismatchError(fmt.Errorf("the provided PINs do not match"))
 	}
 
-	expiryDate, err := helpers.GetPinExpiryDate()
+	settings, err := us.Query.GetOrganisationSettings(ctx, userProfile.OrganisationID)
 	if err != nil {
-		return false, exceptions.InternalErr(err)
+		return false, exceptions.InternalErr(fmt.Errorf("failed to get organisation settings: %v", err))
 	}
 
 	pinDataPayload := &domain.UserPIN{
 		UserID:    *userProfile.ID,
 		HashedPIN: encryptedPIN,
 		ValidFrom: time.Now(),
-		ValidTo:   *expiryDate,
+		ValidTo:   time.Now().AddDate(0, 0, settings.PINExpiryDays),
 		Flavour:   input.Flavour,
 		IsValid:   true,
 		Salt:      salt,
@@ -346,6 +484,12 @@ func (us *UseCasesUserImpl) SetUserPIN(ctx context.Context, input dto.PINInput)
 		return false, exceptions.SaveUserPinError(fmt.Errorf("failed to save user pin: %v", err))
 	}
 
+	// setting a PIN of their own is how a user accepts the invite
+	_, err = us.Update.CloseUserInvitations(ctx, *userProfile.ID, input.Flavour, enums.InvitationStatusAccepted)
+	if err != nil {
+		return false, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to accept user invitations: %v", err))
+	}
+
 	return true, nil
 }
 
@@ -358,6 +502,32 @@ func (us *UseCasesUserImpl) SetNickName(ctx context.Context, userID *string, nic
 	return ok, err
 }
 
+// SetUserPreferredLanguage moves the given language to the top of the user's ordered list of languages.
+// OTP, invite and PIN reset messages are rendered in this language.
+func (us *UseCasesUserImpl) SetUserPreferredLanguage(ctx context.Context, userID string, language enumutils.Language) (bool, error) {
+	if !language.IsValid() {
+		return false, exceptions.InputValidationErr(fmt.Errorf("invalid language: %v", language))
+	}
+
+	userProfile, err := us.Query.GetUserProfileByUserID(ctx, userID)
+	if err != nil {
+		return false, exceptions.UserNotFoundError(err)
+	}
+
+	languages := []enumutils.Language{language}
+	for _, userLanguage := range userProfile.Languages {
+		if userLanguage != language {
+			languages = append(languages, userLanguage)
+		}
+	}
+
+	ok, err := us.Update.UpdateUserLanguages(ctx, userID, languages)
+	if err != nil {
+		return false, exceptions.FailedToUpdateItemErr(fmt.Errorf("failed to set user preferred language: %v", err))
+	}
+	return ok, nil
+}
+
 // RequestPINReset sends an OTP to the phone number that is provided. It begins the workflow of resetting a pin
 func (us *UseCasesUserImpl) RequestPINReset(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (string, error) {
 	phone, err := converterandformatter.NormalizeMSISDN(phoneNumber)
@@ -379,9 +549,19 @@ func (us *UseCasesUserImpl) RequestPINReset(ctx context.Context, phoneNumber str
 		return "", exceptions.ExistingPINError(err)
 	}
 
-	code, err := us.OTP.GenerateAndSendOTP(ctx, *phone, flavour)
+	code, err := us.OTP.GenerateOTP(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate an OTP")
+	}
+
+	message, err := helpers.RenderMessage(userProfile, helpers.PINResetMessage, code)
+	if err != nil {
+		return "", exceptions.InternalErr(fmt.Errorf("failed to render PIN reset message: %v", err))
+	}
+
+	code, err = us.OTP.SendOTP(ctx, *phone, code, message)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate and send OTP")
+		return "", fmt.Errorf("failed to send OTP: %v", err)
 	}
 
 	otpDataPayload := &domain.OTP{
@@ -473,9 +653,9 @@ func (us *UseCasesUserImpl) ResetPIN(ctx context.Context, input dto.UserResetPin
 	}
 
 	salt, encryptedPin := us.ExternalExt.EncryptPIN(input.PIN, nil)
-	expiryDate, err := helpers.GetPinExpiryDate()
+	settings, err := us.Query.GetOrganisationSettings(ctx, userProfile.OrganisationID)
 	if err != nil {
-		return false, exceptions.InternalErr(fmt.Errorf("failed to get pin expiry date: %v", err))
+		return false, exceptions.InternalErr(fmt.Errorf("failed to get organisation settings: %v", err))
 	}
 
 	pinPayload := &domain.UserPIN{
@@ -483,7 +663,7 @@ func (us *UseCasesUserImpl) ResetPIN(ctx context.Context, input dto.UserResetPin
 		HashedPIN: encryptedPin,
 		Salt:      salt,
 		ValidFrom: time.Now(),
-		ValidTo:   *expiryDate,
+		ValidTo:   time.Now().AddDate(0, 0, settings.PINExpiryDays),
 		Flavour:   input.Flavour,
 		IsValid:   true,
 	}
@@ -551,3 +731,400 @@ func (us *UseCasesUserImpl) VerifyPIN(ctx context.Context, userID string, flavou
 	}
 	return true, nil
 }
+
+// BulkInviteUsers parses a CSV file of user IDs and phone numbers and invites each of the users in a background job.
+// The invite SMSs are throttled. The returned job ID is used to poll for the outcome of every row.
+func (us *UseCasesUserImpl) BulkInviteUsers(ctx context.Context, csvContent string, flavour feedlib.Flavour) (string, error) {
+	if !flavour.IsValid() {
+		return "", exceptions.InvalidFlavourDefinedErr(fmt.Errorf("flavour is not valid"))
+	}
+
+	staffID, err := us.checkIsStaff(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	rows, err := parseBulkInviteCSV(csvContent)
+	if err != nil {
+		return "", exceptions.InputValidationErr(err)
+	}
+
+	// rows that could not be read from the file have already failed. The access checks need the logged in
+	// user hence rows whose user the staff member cannot invite are failed before the job is started.
+	failed := 0
+	for _, row := range rows {
+		if row.Status == enums.BulkInviteRowStatusPending {
+			if err := us.checkUserAccess(ctx, row.UserID, flavour, enums.PermissionTypeCanInviteUser); err != nil {
+				row.Status, row.Error = enums.BulkInviteRowStatusFailed, err.Error()
+			}
+		}
+		if row.Status == enums.BulkInviteRowStatusFailed {
+			failed++
+		}
+	}
+
+	job, err := us.Create.CreateBulkInviteJob(ctx, &domain.BulkInviteJob{
+		Status:    enums.BulkInviteJobStatusPending,
+		Flavour:   flavour,
+		Total:     len(rows),
+		Failed:    failed,
+		Rows:      rows,
+		CreatedBy: staffID,
+	})
+	if err != nil {
+		return "", exceptions.FailedToSaveItemErr(fmt.Errorf("failed to save bulk invite job: %v", err))
+	}
+
+	// the request context is cancelled once the response is written. The job keeps the staff member's
+	// identity so that the invites are scoped to their organisation.
+	jobCtx := context.Background()
+	if identity, err := helpers.GetIdentityFromContext(ctx); err == nil {
+		jobCtx = helpers.ContextWithIdentity(jobCtx, identity)
+	}
+	go us.runBulkInviteJob(jobCtx, job)
+
+	return job.ID, nil
+}
+
+// GetBulkInviteJob returns the progress of a bulk invite job
+func (us *UseCasesUserImpl) GetBulkInviteJob(ctx context.Context, jobID string) (*domain.BulkInviteJob, error) {
+	if _, err := us.checkIsStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	job, err := us.Query.GetBulkInviteJob(ctx, jobID)
+	if err != nil {
+		return nil, exceptions.ItemNotFoundErr(fmt.Errorf("bulk invite job %s not found: %v", jobID, err))
+	}
+	return job, nil
+}
+
+// ResendInvite sends a fresh invite and temporary PIN to a user who has not accepted their earlier invite.
+// Any pending invite is expired and its temporary PIN invalidated.
+func (us *UseCasesUserImpl) ResendInvite(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error) {
+	if !flavour.IsValid() {
+		return false, exceptions.InvalidFlavourDefinedErr(fmt.Errorf("flavour is not valid"))
+	}
+
+	if err := us.checkUserAccess(ctx, userID, flavour, enums.PermissionTypeCanInviteUser); err != nil {
+		return false, err
+	}
+
+	invitation, err := us.Query.GetLatestUserInvitation(ctx, userID, flavour)
+	if err != nil {
+		return false, exceptions.ItemNotFoundErr(fmt.Errorf("user %s has not been invited: %v", userID, err))
+	}
+
+	if invitation.Status == enums.InvitationStatusAccepted {
+		return false, exceptions.InputValidationErr(fmt.Errorf("user %s has already accepted their invite", userID))
+	}
+
+	userProfile, err := us.Query.GetUserProfileByUserID(ctx, userID)
+	if err != nil {
+		return false, exceptions.UserNotFoundError(err)
+	}
+
+	// prefer the user's current contact in case it has changed since the last invite
+	phoneNumber := invitation.PhoneNumber
+	if userProfile.Contacts != nil && userProfile.Contacts.ContactValue != "" {
+		phoneNumber = userProfile.Contacts.ContactValue
+	}
+
+	return us.inviteUser(ctx, userID, phoneNumber, flavour)
+}
+
+// ListPendingInvitations returns the invites sent to the clients of a facility that are yet to be accepted.
+// Only staff who can access the facility are allowed to list them.
+func (us *UseCasesUserImpl) ListPendingInvitations(ctx context.Context, facilityID string) ([]*domain.Invitation, error) {
+	if facilityID == "" {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("facility ID is required"))
+	}
+
+	if _, err := us.Authority.CheckFacilityAccess(ctx, facilityID, enums.PermissionTypeCanInviteUser); err != nil {
+		return nil, err
+	}
+
+	invitations, err := us.Query.ListPendingInvitations(ctx, facilityID)
+	if err != nil {
+		return nil, exceptions.ItemNotFoundErr(fmt.Errorf("failed to list pending invitations: %v", err))
+	}
+	return invitations, nil
+}
+
+// RegisterClient creates a client together with their user and contact records then invites them to the app.
+// Only staff who can access the client's facility are allowed to register them.
+func (us *UseCasesUserImpl) RegisterClient(ctx context.Context, input dto.ClientRegistrationInput) (*domain.ClientProfile, error) {
+	if err := input.Validate(); err != nil {
+		return nil, exceptions.InputValidationErr(err)
+	}
+
+	if _, err := us.Authority.CheckFacilityAccess(ctx, input.FacilityID, enums.PermissionTypeCanRegisterUser); err != nil {
+		return nil, err
+	}
+
+	phone, err := us.validateRegistration(ctx, input.PhoneNumber, input.FacilityID, input.Gender)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &domain.ClientProfile{
+		Active:                  true,
+		ClientType:              input.ClientType,
+		TreatmentEnrollmentDate: input.EnrollmentDate,
+		ClientCounselled:        input.Counselled,
+		FacilityID:              input.FacilityID,
+		User:                    newRegistrationUser(input.Username, input.FirstName, input.MiddleName, input.LastName, input.Gender, *phone, enums.ClientUser, feedlib.FlavourConsumer),
+	}
+
+	registeredClient, err := us.Create.RegisterClient(ctx, client)
+	if err != nil {
+		return nil, registrationErr(err, input.Username, *phone, "failed to register client")
+	}
+
+	_, err = us.Create.AssignRoles(ctx, registeredClient.UserID, []enums.UserRoleType{enums.UserRoleTypeClient})
+	if err != nil {
+		return nil, exceptions.FailedToSaveItemErr(fmt.Errorf("client %s was registered but could not be assigned a role: %v", registeredClient.UserID, err))
+	}
+
+	_, err = us.inviteUser(ctx, registeredClient.UserID, *phone, feedlib.FlavourConsumer)
+	if err != nil {
+		return nil, fmt.Errorf("client %s was registered but could not be invited: %w", registeredClient.UserID, err)
+	}
+
+	return registeredClient, nil
+}
+
+// RegisterStaff creates a staff member together with their user and contact records then invites them to the app.
+// Only staff who can access the staff member's default facility are allowed to register them.
+func (us *UseCasesUserImpl) RegisterStaff(ctx context.Context, input dto.StaffRegistrationInput) (*domain.StaffProfile, error) {
+	if err := input.Validate(); err != nil {
+		return nil, exceptions.InputValidationErr(err)
+	}
+
+	if _, err := us.Authority.CheckFacilityAccess(ctx, input.FacilityID, enums.PermissionTypeCanRegisterUser); err != nil {
+		return nil, err
+	}
+
+	phone, err := us.validateRegistration(ctx, input.PhoneNumber, input.FacilityID, input.Gender)
+	if err != nil {
+		return nil, err
+	}
+
+	staff := &domain.StaffProfile{
+		Active:            true,
+		StaffNumber:       input.StaffNumber,
+		Cadre:             input.Cadre,
+		DefaultFacilityID: input.FacilityID,
+		User:              newRegistrationUser(input.Username, input.FirstName, input.MiddleName, input.LastName, input.Gender, *phone, enums.HealthcareWorkerUser, feedlib.FlavourPro),
+	}
+
+	registeredStaff, err := us.Create.RegisterStaff(ctx, staff)
+	if err != nil {
+		return nil, registrationErr(err, input.Username, *phone, "failed to register staff")
+	}
+
+	_, err = us.Create.AssignRoles(ctx, registeredStaff.UserID, []enums.UserRoleType{staffCadreRole(input.Cadre)})
+	if err != nil {
+		return nil, exceptions.FailedToSaveItemErr(fmt.Errorf("staff %s was registered but could not be assigned a role: %v", registeredStaff.UserID, err))
+	}
+
+	_, err = us.inviteUser(ctx, registeredStaff.UserID, *phone, feedlib.FlavourPro)
+	if err != nil {
+		return nil, fmt.Errorf("staff %s was registered but could not be invited: %w", registeredStaff.UserID, err)
+	}
+
+	return registeredStaff, nil
+}
+
+// staffCadreRole returns the role that a newly registered staff member of a cadre is given.
+// Admin roles are never given at registration.
+func staffCadreRole(cadre enums.StaffCadre) enums.UserRoleType {
+	if cadre == enums.StaffCadreCHV {
+		return enums.UserRoleTypeCHV
+	}
+	return enums.UserRoleTypeClinician
+}
+
+// validateRegistration checks the details shared by client and staff registrations. The facility must be active.
+// It returns the normalized phone number.
+func (us *UseCasesUserImpl) validateRegistration(ctx context.Context, phoneNumber string, facilityID string, gender enumutils.Gender) (*string, error) {
+	if !gender.IsValid() {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("invalid gender: %v", gender))
+	}
+
+	phone, err := converterandformatter.NormalizeMSISDN(phoneNumber)
+	if err != nil {
+		return nil, exceptions.NormalizeMSISDNError(err)
+	}
+
+	_, err = us.Query.RetrieveFacility(ctx, &facilityID, true)
+	if err != nil {
+		return nil, exceptions.ItemNotFoundErr(fmt.Errorf("active facility %s not found: %v", facilityID, err))
+	}
+
+	return phone, nil
+}
+
+// registrationErr reports the unique value that a registration clashed with. The database's unique constraints
+// are relied on, rather than checking beforehand, so that two registrations at the same time cannot both succeed.
+func registrationErr(err error, username string, phone string, message string) error {
+	switch {
+	case errors.Is(err, domain.ErrUsernameTaken):
+		return exceptions.ItemAlreadyExistsErr(fmt.Errorf("username %s is already taken", username))
+	case errors.Is(err, domain.ErrContactTaken):
+		return exceptions.ItemAlreadyExistsErr(fmt.Errorf("phone number %s is already registered", phone))
+	case errors.Is(err, domain.ErrStaffNumberTaken):
+		return exceptions.ItemAlreadyExistsErr(fmt.Errorf("the staff number is already taken"))
+	default:
+		return exceptions.FailedToSaveItemErr(fmt.Errorf("%s: %v", message, err))
+	}
+}
+
+// newRegistrationUser creates the user record of a new client or staff member with their phone as the primary contact
+func newRegistrationUser(username, firstName, middleName, lastName string, gender enumutils.Gender, phone string, userType enums.UsersType, flavour feedlib.Flavour) *domain.User {
+	return &domain.User{
+		Username:   username,
+		FirstName:  firstName,
+		MiddleName: middleName,
+		LastName:   lastName,
+		Gender:     gender,
+		UserType:   userType,
+		Flavour:    flavour,
+		Active:     true,
+		Languages:  []enumutils.Language{helpers.DefaultLanguage},
+		Contacts: &domain.Contact{
+			ContactType:  "PHONE",
+			ContactValue: phone,
+			Active:       true,
+			OptedIn:      true,
+		},
+	}
+}
+
+// checkIsStaff ensures that the logged in user is a healthcare worker and returns their user ID
+func (us *UseCasesUserImpl) checkIsStaff(ctx context.Context) (string, error) {
+	uid, err := us.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		return "", exceptions.UnauthorizedErr(fmt.Errorf("failed to get logged in user: %v", err))
+	}
+
+	userProfile, err := us.Query.GetUserProfileByUserID(ctx, uid)
+	if err != nil {
+		return "", exceptions.UserNotFoundError(err)
+	}
+
+	if userProfile.UserType != enums.HealthcareWorkerUser {
+		return "", exceptions.UnauthorizedErr(fmt.Errorf("user %s is not a healthcare worker", uid))
+	}
+	return uid, nil
+}
+
+// parseBulkInviteCSV reads the user ID and phone number columns of a bulk invite CSV file.
+// An optional header row is skipped. Rows without both columns are marked as failed.
+func parseBulkInviteCSV(csvContent string) ([]*domain.BulkInviteRow, error) {
+	reader := csv.NewReader(strings.NewReader(csvContent))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %v", err)
+	}
+
+	if len(records) > 0 && strings.EqualFold(strings.TrimSpace(records[0][0]), "userID") {
+		records = records[1:]
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("the CSV file has no rows")
+	}
+	if len(records) > maxBulkInviteRows {
+		return nil, fmt.Errorf("the CSV file has %d rows, the maximum allowed is %d", len(records), maxBulkInviteRows)
+	}
+
+	rows := []*domain.BulkInviteRow{}
+	for i, record := range records {
+		row := &domain.BulkInviteRow{
+			RowNumber: i + 1,
+			Status:    enums.BulkInviteRowStatusPending,
+		}
+		if len(record) != 2 {
+			row.Status = enums.BulkInviteRowStatusFailed
+			row.Error = fmt.Sprintf("expected 2 columns, found %d", len(record))
+			rows = append(rows, row)
+			continue
+		}
+		row.UserID = strings.TrimSpace(record[0])
+		row.PhoneNumber = strings.TrimSpace(record[1])
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// runBulkInviteJob validates and invites the pending rows of a bulk invite job one at a time. The outcome of each
+// row is saved as soon as it is known so that the job's progress can be polled from any instance.
+func (us *UseCasesUserImpl) runBulkInviteJob(ctx context.Context, job *domain.BulkInviteJob) {
+	if _, err := us.Update.UpdateBulkInviteJobStatus(ctx, job.ID, enums.BulkInviteJobStatusInProgress); err != nil {
+		log.Errorf("failed to start bulk invite job %s: %v", job.ID, err)
+		return
+	}
+
+	throttle := time.NewTicker(bulkInviteSMSInterval)
+	defer throttle.Stop()
+
+	for _, row := range job.Rows {
+		if row.Status != enums.BulkInviteRowStatusPending {
+			continue
+		}
+
+		status, errorMessage := enums.BulkInviteRowStatusSuccess, ""
+		err := us.validateBulkInviteRow(ctx, row.UserID, row.PhoneNumber)
+		if err == nil {
+			<-throttle.C
+			_, err = us.inviteUser(ctx, row.UserID, row.PhoneNumber, job.Flavour)
+		}
+		if err != nil {
+			status, errorMessage = enums.BulkInviteRowStatusFailed, err.Error()
+		}
+
+		if _, err := us.Update.RecordBulkInviteRowOutcome(ctx, job.ID, row.ID, status, errorMessage); err != nil {
+			log.Errorf("failed to record the outcome of row %d of bulk invite job %s: %v", row.RowNumber, job.ID, err)
+		}
+	}
+
+	if _, err := us.Update.UpdateBulkInviteJobStatus(ctx, job.ID, enums.BulkInviteJobStatusCompleted); err != nil {
+		log.Errorf("failed to complete bulk invite job %s: %v", job.ID, err)
+	}
+}
+
+// FailInterruptedBulkInviteJobs fails the bulk invite jobs that were left pending or in progress e.g by a restart of
+// the instance that was running them. They are failed rather than resumed since a row whose invite was sent just
+// before the interruption would be invited twice. It is run when the service starts.
+func (us *UseCasesUserImpl) FailInterruptedBulkInviteJobs(ctx context.Context) (int, error) {
+	failed, err := us.Update.FailInterruptedBulkInviteJobs(ctx, bulkInviteJobStaleAfter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail interrupted bulk invite jobs: %v", err)
+	}
+	return failed, nil
+}
+
+// validateBulkInviteRow checks that the row's phone number is valid and is the user's existing contact
+func (us *UseCasesUserImpl) validateBulkInviteRow(ctx context.Context, userID string, phoneNumber string) error {
+	if userID == "" {
+		return fmt.Errorf("user ID is required")
+	}
+
+	phone, err := converterandformatter.NormalizeMSISDN(phoneNumber)
+	if err != nil {
+		return fmt.Errorf("invalid phone number %s: %v", phoneNumber, err)
+	}
+
+	userProfile, err := us.Query.GetUserProfileByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user %s not found: %v", userID, err)
+	}
+
+	if userProfile.Contacts == nil || userProfile.Contacts.ContactValue != *phone {
+		return fmt.Errorf("phone number %s is not an existing contact of user %s", *phone, userID)
+	}
+	return nil
+}
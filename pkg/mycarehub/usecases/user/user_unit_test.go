@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,14 +18,17 @@ import (
 	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/user"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/user/mock"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
 	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
 )
 
 func TestUseCasesUserImpl_Login_Unittest(t *testing.T) {
@@ -155,6 +159,46 @@ func TestUseCasesUserImpl_Login_Unittest(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Happy case - user was never invited",
+			args: args{
+				ctx:         ctx,
+				phoneNumber: phoneNumber,
+				pin:         PIN,
+				flavour:     flavour,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad Case - invite has expired",
+			args: args{
+				ctx:         ctx,
+				phoneNumber: phoneNumber,
+				pin:         PIN,
+				flavour:     flavour,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - invite has expired and fails to be closed",
+			args: args{
+				ctx:         ctx,
+				phoneNumber: phoneNumber,
+				pin:         PIN,
+				flavour:     flavour,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - fail to get latest user invitation",
+			args: args{
+				ctx:         ctx,
+				phoneNumber: phoneNumber,
+				pin:         PIN,
+				flavour:     flavour,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -165,7 +209,8 @@ func TestUseCasesUserImpl_Login_Unittest(t *testing.T) {
 			fakeExtension := extensionMock.NewFakeExtension()
 			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
 
-			u := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			u := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
 
 			if tt.name == "Sad case - no phone" {
 				fakeDB.MockGetUserProfileByPhoneNumberFn = func(ctx context.Context, phoneNumber string) (*domain.User, error) {
@@ -227,6 +272,39 @@ func TestUseCasesUserImpl_Login_Unittest(t *testing.T) {
 				}
 			}
 
+			// the global expiry of invites is left to staff actions and must not run on login
+			fakeDB.MockExpireOverdueInvitationsFn = func(ctx context.Context) (bool, error) {
+				return false, fmt.Errorf("overdue invitations should not be expired on login")
+			}
+
+			if tt.name == "Happy case - user was never invited" {
+				fakeDB.MockGetLatestUserInvitationFn = func(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.Invitation, error) {
+					return nil, fmt.Errorf("failed to get latest user invitation: %w", gorm.ErrRecordNotFound)
+				}
+			}
+			if tt.name == "Sad Case - fail to get latest user invitation" {
+				fakeDB.MockGetLatestUserInvitationFn = func(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.Invitation, error) {
+					return nil, fmt.Errorf("failed to get latest user invitation: connection refused")
+				}
+			}
+			if tt.name == "Sad Case - invite has expired" || tt.name == "Sad Case - invite has expired and fails to be closed" {
+				fakeDB.MockGetLatestUserInvitationFn = func(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.Invitation, error) {
+					return &domain.Invitation{
+						ID:        uuid.New().String(),
+						UserID:    userID,
+						Flavour:   flavour,
+						Status:    enums.InvitationStatusDelivered,
+						IssuedAt:  time.Now().AddDate(0, 0, -8),
+						ExpiresAt: time.Now().AddDate(0, 0, -1),
+					}, nil
+				}
+			}
+			if tt.name == "Sad Case - invite has expired and fails to be closed" {
+				fakeDB.MockCloseUserInvitationsFn = func(ctx context.Context, userID string, flavour feedlib.Flavour, status enums.InvitationStatus) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
 			_, _, err := u.Login(tt.args.ctx, tt.args.phoneNumber, tt.args.pin, tt.args.flavour)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UseCasesUserImpl.Login() error = %v, wantErr %v", err, tt.wantErr)
@@ -447,6 +525,94 @@ func TestUnit_InviteUser(t *testing.T) {
 			wantErr: true,
 			want:    false,
 		},
+		{
+			name: "Sad Case - Fail to expire previous invitations",
+			args: args{
+				ctx:         ctx,
+				userID:      userID,
+				phoneNumber: validPhone,
+				flavour:     validFlavour,
+			},
+			wantErr: true,
+			want:    false,
+		},
+		{
+			name: "Sad Case - Fail to save invitation",
+			args: args{
+				ctx:         ctx,
+				userID:      userID,
+				phoneNumber: validPhone,
+				flavour:     validFlavour,
+			},
+			wantErr: true,
+			want:    false,
+		},
+		{
+			name: "Sad Case - Fail to mark invitation as delivered",
+			args: args{
+				ctx:         ctx,
+				userID:      userID,
+				phoneNumber: validPhone,
+				flavour:     validFlavour,
+			},
+			wantErr: true,
+			want:    false,
+		},
+		{
+			name: "valid: invite uses the organisation's settings",
+			args: args{
+				ctx:         ctx,
+				userID:      userID,
+				phoneNumber: validPhone,
+				flavour:     validFlavour,
+			},
+			wantErr: false,
+			want:    true,
+		},
+		{
+			name: "Sad Case - Fail to get organisation settings",
+			args: args{
+				ctx:         ctx,
+				userID:      userID,
+				phoneNumber: validPhone,
+				flavour:     validFlavour,
+			},
+			wantErr: true,
+			want:    false,
+		},
+		{
+			name: "Sad Case - Fail to get client profile",
+			args: args{
+				ctx:         ctx,
+				userID:      userID,
+				phoneNumber: validPhone,
+				flavour:     validFlavour,
+			},
+			wantErr: true,
+			want:    false,
+		},
+		{
+			name: "Sad Case - logged in user cannot access the client",
+			args: args{
+				ctx:         ctx,
+				userID:      userID,
+				phoneNumber: validPhone,
+				flavour:     validFlavour,
+			},
+			wantErr: true,
+			want:    false,
+		},
+		{
+			name: "Sad Case - logged in user cannot access the staff member",
+			args: args{
+				ctx:         ctx,
+				userID:      userID,
+				phoneNumber: validPhone,
+				flavour:     feedlib.FlavourPro,
+			},
+			wantErr: true,
+			want:    false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -456,7 +622,8 @@ func TestUnit_InviteUser(t *testing.T) {
 
 			fakeUserMock := mock.NewUserUseCaseMock()
 			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
 
 			if tt.name == "valid: valid phone number" {
 				fakeUserMock.MockInviteUserFn = func(ctx context.Context, userID string, phoneNumber string, flavour feedlib.Flavour) (bool, error) {
@@ -552,11 +719,65 @@ func TestUnit_InviteUser(t *testing.T) {
 				}
 			}
 			if tt.name == "invalid: send in message error" {
-				fakeExtension.MockSendInviteSMSFn = func(ctx context.Context, phoneNumber, message string) error {
+				fakeExtension.MockSendInviteSMSFn = func(ctx context.Context, phoneNumber, message string, senderID enumutils.SenderID) error {
 					return fmt.Errorf("failed to send SMS")
 				}
 			}
 
+			if tt.name == "Sad Case - Fail to expire previous invitations" {
+				fakeDB.MockCloseUserInvitationsFn = func(ctx context.Context, userID string, flavour feedlib.Flavour, status enums.InvitationStatus) (bool, error) {
+					return false, fmt.Errorf("failed to expire previous invitations")
+				}
+			}
+			if tt.name == "Sad Case - Fail to save invitation" {
+				fakeDB.MockSaveInvitationFn = func(ctx context.Context, invitation *domain.Invitation) (*domain.Invitation, error) {
+					return nil, fmt.Errorf("failed to save invitation")
+				}
+			}
+			if tt.name == "Sad Case - Fail to mark invitation as delivered" {
+				fakeDB.MockUpdateInvitationStatusFn = func(ctx context.Context, invitationID string, status enums.InvitationStatus) (bool, error) {
+					return false, fmt.Errorf("failed to update invitation status")
+				}
+			}
+			if tt.name == "valid: invite uses the organisation's settings" {
+				fakeDB.MockGetOrganisationSettingsFn = func(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error) {
+					return &domain.OrganisationSettings{InvitePINExpiryDays: 3, SMSSenderID: enumutils.SenderIDSLADE360}, nil
+				}
+				fakeDB.MockSaveInvitationFn = func(ctx context.Context, invitation *domain.Invitation) (*domain.Invitation, error) {
+					if invitation.ExpiresAt.After(time.Now().AddDate(0, 0, 3)) {
+						return nil, fmt.Errorf("expected the invitation to expire after 3 days")
+					}
+					invitation.ID = uuid.New().String()
+					return invitation, nil
+				}
+				fakeExtension.MockSendInviteSMSFn = func(ctx context.Context, phoneNumber, message string, senderID enumutils.SenderID) error {
+					if senderID != enumutils.SenderIDSLADE360 {
+						return fmt.Errorf("expected the organisation's sender ID, got %v", senderID)
+					}
+					return nil
+				}
+			}
+			if tt.name == "Sad Case - Fail to get organisation settings" {
+				fakeDB.MockGetOrganisationSettingsFn = func(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error) {
+					return nil, fmt.Errorf("failed to get organisation settings")
+				}
+			}
+			if tt.name == "Sad Case - Fail to get client profile" {
+				fakeDB.MockGetClientProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("failed to get client profile")
+				}
+			}
+			if tt.name == "Sad Case - logged in user cannot access the client" {
+				fakeAuthority.MockCheckClientAccessFn = func(ctx context.Context, clientID string, permission enums.PermissionType) (bool, error) {
+					return false, fmt.Errorf("user cannot access the client's facility")
+				}
+			}
+			if tt.name == "Sad Case - logged in user cannot access the staff member" {
+				fakeAuthority.MockCheckFacilityAccessFn = func(ctx context.Context, facilityID string, permission enums.PermissionType) (bool, error) {
+					return false, fmt.Errorf("user cannot access the staff member's facility")
+				}
+			}
+
 			got, err := us.InviteUser(tt.args.ctx, tt.args.userID, tt.args.phoneNumber, tt.args.flavour)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UseCasesUserImpl.InviteUser() error = %v, wantErr %v", err, tt.wantErr)
@@ -733,6 +954,34 @@ func TestUseCasesUserImpl_SetUserPIN(t *testing.T) {
 			want:    false,
 			wantErr: true,
 		},
+		{
+			name: "Sad Case - Fail to accept invitations",
+			args: args{
+				ctx: ctx,
+				input: dto.PINInput{
+					UserID:     &UserID,
+					PIN:        &PIN,
+					ConfirmPIN: &PIN,
+					Flavour:    flavour,
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - Fail to get organisation settings",
+			args: args{
+				ctx: ctx,
+				input: dto.PINInput{
+					UserID:     &UserID,
+					PIN:        &PIN,
+					ConfirmPIN: &PIN,
+					Flavour:    flavour,
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -742,7 +991,8 @@ func TestUseCasesUserImpl_SetUserPIN(t *testing.T) {
 			fakeExtension := extensionMock.NewFakeExtension()
 
 			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
 
 			if tt.name == "invalid: user not found" {
 				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
@@ -797,6 +1047,18 @@ func TestUseCasesUserImpl_SetUserPIN(t *testing.T) {
 				}
 			}
 
+			if tt.name == "Sad Case - Fail to accept invitations" {
+				fakeDB.MockCloseUserInvitationsFn = func(ctx context.Context, userID string, flavour feedlib.Flavour, status enums.InvitationStatus) (bool, error) {
+					return false, fmt.Errorf("failed to accept invitations")
+				}
+			}
+
+			if tt.name == "Sad Case - Fail to get organisation settings" {
+				fakeDB.MockGetOrganisationSettingsFn = func(ctx context.Context, organisationID string) (*domain.OrganisationSettings, error) {
+					return nil, fmt.Errorf("failed to get organisation settings")
+				}
+			}
+
 			got, err := us.SetUserPIN(tt.args.ctx, tt.args.input)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UseCasesUserImpl.SetUserPIN() error = %v, wantErr %v", err, tt.wantErr)
@@ -900,7 +1162,8 @@ func TestUseCasesUserImpl_VerifyLoginPIN(t *testing.T) {
 			fakeUserMock := mock.NewUserUseCaseMock()
 			fakeExtension := extensionMock.NewFakeExtension()
 			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
-			u := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			u := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
 
 			if tt.name == "Happy Case - Successfully verify pin" {
 				fakeUserMock.MockVerifyLoginPINFn = func(ctx context.Context, userID string, pin string) (bool, int, error) {
@@ -1042,7 +1305,8 @@ func TestUseCasesUserImpl_SetNickName(t *testing.T) {
 			_ = mock.NewUserUseCaseMock()
 			fakeExtension := extensionMock.NewFakeExtension()
 			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
-			u := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			u := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
 
 			if tt.name == "Sad case" {
 				fakeDB.MockSetNickNameFn = func(ctx context.Context, userID, nickname *string) (bool, error) {
@@ -1136,6 +1400,24 @@ func TestUseCasesUserImpl_RequestPINReset(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad Case - Fail to generate otp",
+			args: args{
+				ctx:         ctx,
+				phoneNumber: interserviceclient.TestUserPhoneNumber,
+				flavour:     feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - Fail to send otp",
+			args: args{
+				ctx:         ctx,
+				phoneNumber: interserviceclient.TestUserPhoneNumber,
+				flavour:     feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
 		{
 			name: "Sad Case - Fail to save otp",
 			args: args{
@@ -1152,7 +1434,8 @@ func TestUseCasesUserImpl_RequestPINReset(t *testing.T) {
 			fakeUser := mock.NewUserUseCaseMock()
 			fakeExtension := extensionMock.NewFakeExtension()
 			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
 
 			if tt.name == "Sad Case - Invalid phonenumber" {
 				fakeUser.MockRequestPINResetFn = func(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (string, error) {
@@ -1178,6 +1461,18 @@ func TestUseCasesUserImpl_RequestPINReset(t *testing.T) {
 				}
 			}
 
+			if tt.name == "Sad Case - Fail to generate otp" {
+				fakeExtension.MockGenerateOTPFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("failed to generate otp")
+				}
+			}
+
+			if tt.name == "Sad Case - Fail to send otp" {
+				fakeExtension.MockSendSMSFn = func(ctx context.Context, phoneNumbers string, message string, from enumutils.SenderID) (*openSourceDto.SendMessageResponse, error) {
+					return nil, fmt.Errorf("failed to send sms")
+				}
+			}
+
 			if tt.name == "Sad Case - Fail to save otp" {
 				fakeDB.MockSaveOTPFn = func(ctx context.Context, otpInput *domain.OTP) error {
 					return fmt.Errorf("failed to save otp")
@@ -1370,7 +1665,8 @@ func TestUseCasesUserImpl_ResetPIN(t *testing.T) {
 			// fakeUser := mock.NewUserUseCaseMock()
 			fakeExtension := extensionMock.NewFakeExtension()
 			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
 
 			if tt.name == "Happy Case - Successfully reset pin" {
 				fakeDB.MockGetUserSecurityQuestionsResponsesFn = func(ctx context.Context, userID string) ([]*domain.SecurityQuestionResponse, error) {
@@ -1537,7 +1833,8 @@ func TestUseCasesUserImpl_RefreshToken(t *testing.T) {
 			fakeDB := pgMock.NewPostgresMock()
 			fakeExtension := extensionMock.NewFakeExtension()
 			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
 
 			if tt.name == "Sad Case - Fail to create firebase custom token" {
 				fakeExtension.MockCreateFirebaseCustomTokenFn = func(ctx context.Context, uid string) (string, error) {
@@ -1669,7 +1966,8 @@ func TestUseCasesUserImpl_VerifyPIN(t *testing.T) {
 			fakeDB := pgMock.NewPostgresMock()
 			fakeExtension := extensionMock.NewFakeExtension()
 			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
 
 			if tt.name == "invalid: failed to get user pin by user id" {
 				fakeDB.MockGetUserPINByUserIDFn = func(ctx context.Context, userID string) (*domain.UserPIN, error) {
@@ -1711,3 +2009,1080 @@ func TestUseCasesUserImpl_VerifyPIN(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCasesUserImpl_SetUserPreferredLanguage(t *testing.T) {
+	ctx := context.Background()
+
+	userID := ksuid.New().String()
+
+	type args struct {
+		ctx      context.Context
+		userID   string
+		language enumutils.Language
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:      ctx,
+				userID:   userID,
+				language: enumutils.LanguageSw,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - invalid language",
+			args: args{
+				ctx:      ctx,
+				userID:   userID,
+				language: enumutils.Language("invalid"),
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to get user profile",
+			args: args{
+				ctx:      ctx,
+				userID:   userID,
+				language: enumutils.LanguageSw,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to update user languages",
+			args: args{
+				ctx:      ctx,
+				userID:   userID,
+				language: enumutils.LanguageSw,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			u := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
+
+			if tt.name == "Happy case" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return &domain.User{
+						ID:        &userID,
+						Languages: []enumutils.Language{enumutils.LanguageEn, enumutils.LanguageSw},
+					}, nil
+				}
+				fakeDB.MockUpdateUserLanguagesFn = func(ctx context.Context, userID string, languages []enumutils.Language) (bool, error) {
+					if len(languages) != 2 || languages[0] != enumutils.LanguageSw {
+						return false, fmt.Errorf("expected swahili to be the first of two languages, got %v", languages)
+					}
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case - fail to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case - fail to update user languages" {
+				fakeDB.MockUpdateUserLanguagesFn = func(ctx context.Context, userID string, languages []enumutils.Language) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.SetUserPreferredLanguage(tt.args.ctx, tt.args.userID, tt.args.language)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.SetUserPreferredLanguage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.SetUserPreferredLanguage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_BulkInviteUsers(t *testing.T) {
+	ctx := context.Background()
+
+	staffID := ksuid.New().String()
+	csvContent := fmt.Sprintf("userID,phoneNumber\n%s,%s\n", ksuid.New().String(), interserviceclient.TestUserPhoneNumber)
+
+	type args struct {
+		ctx        context.Context
+		csvContent string
+		flavour    feedlib.Flavour
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				csvContent: csvContent,
+				flavour:    feedlib.FlavourConsumer,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - invalid flavour",
+			args: args{
+				ctx:        ctx,
+				csvContent: csvContent,
+				flavour:    feedlib.Flavour("invalid"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to get logged in user",
+			args: args{
+				ctx:        ctx,
+				csvContent: csvContent,
+				flavour:    feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to get staff profile",
+			args: args{
+				ctx:        ctx,
+				csvContent: csvContent,
+				flavour:    feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - logged in user is not staff",
+			args: args{
+				ctx:        ctx,
+				csvContent: csvContent,
+				flavour:    feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - empty CSV",
+			args: args{
+				ctx:        ctx,
+				csvContent: "userID,phoneNumber\n",
+				flavour:    feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - malformed CSV",
+			args: args{
+				ctx:        ctx,
+				csvContent: "userID,\"phoneNumber\n",
+				flavour:    feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to save bulk invite job",
+			args: args{
+				ctx:        ctx,
+				csvContent: csvContent,
+				flavour:    feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Happy case - rows of clients that the staff member cannot access fail",
+			args: args{
+				ctx:        ctx,
+				csvContent: csvContent,
+				flavour:    feedlib.FlavourConsumer,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				return &domain.User{
+					ID:       &userID,
+					UserType: enums.HealthcareWorkerUser,
+					Contacts: &domain.Contact{
+						ContactValue: interserviceclient.TestUserPhoneNumber,
+					},
+				}, nil
+			}
+
+			if tt.name == "Sad case - fail to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case - fail to get staff profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case - logged in user is not staff" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return &domain.User{ID: &userID, UserType: enums.ClientUser}, nil
+				}
+			}
+			if tt.name == "Sad case - fail to save bulk invite job" {
+				fakeDB.MockCreateBulkInviteJobFn = func(ctx context.Context, job *domain.BulkInviteJob) (*domain.BulkInviteJob, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Happy case - rows of clients that the staff member cannot access fail" {
+				fakeAuthority.MockCheckClientAccessFn = func(ctx context.Context, clientID string, permission enums.PermissionType) (bool, error) {
+					return false, fmt.Errorf("user cannot access the client's facility")
+				}
+				fakeDB.MockCreateBulkInviteJobFn = func(ctx context.Context, job *domain.BulkInviteJob) (*domain.BulkInviteJob, error) {
+					if job.Failed != 1 || job.Rows[0].Status != enums.BulkInviteRowStatusFailed {
+						return nil, fmt.Errorf("expected the row to fail before the job is started")
+					}
+					job.ID = uuid.New().String()
+					return job, nil
+				}
+			}
+
+			got, err := us.BulkInviteUsers(tt.args.ctx, tt.args.csvContent, tt.args.flavour)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.BulkInviteUsers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == "" {
+				t.Errorf("UseCasesUserImpl.BulkInviteUsers() expected a job ID")
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_FailInterruptedBulkInviteJobs(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "Happy case",
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case - failed to fail interrupted jobs",
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
+
+			if tt.name == "Sad case - failed to fail interrupted jobs" {
+				fakeDB.MockFailInterruptedBulkInviteJobsFn = func(ctx context.Context, staleAfter time.Duration) (int, error) {
+					return 0, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.FailInterruptedBulkInviteJobs(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.FailInterruptedBulkInviteJobs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.FailInterruptedBulkInviteJobs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_GetBulkInviteJob(t *testing.T) {
+	ctx := context.Background()
+
+	staffID := ksuid.New().String()
+	validUserID := ksuid.New().String()
+	otherUserID := ksuid.New().String()
+
+	csvContent := fmt.Sprintf(
+		"%s,%s\n%s,%s\n%s,%s\n%s\n",
+		validUserID, interserviceclient.TestUserPhoneNumber,
+		validUserID, "not a phone number",
+		otherUserID, interserviceclient.TestUserPhoneNumber,
+		validUserID,
+	)
+
+	type args struct {
+		ctx   context.Context
+		jobID string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantSucceeded int
+		wantFailed    int
+		wantErr       bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx: ctx,
+			},
+			wantSucceeded: 1,
+			wantFailed:    3,
+			wantErr:       false,
+		},
+		{
+			name: "Sad case - job not found",
+			args: args{
+				ctx:   ctx,
+				jobID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - logged in user is not staff",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				user := &domain.User{
+					ID:       &userID,
+					UserType: enums.ClientUser,
+					Contacts: &domain.Contact{
+						ContactValue: interserviceclient.TestUserPhoneNumber,
+					},
+				}
+				if userID == staffID {
+					user.UserType = enums.HealthcareWorkerUser
+				}
+				if userID == otherUserID {
+					user.Contacts.ContactValue = "+254711111111"
+				}
+				return user, nil
+			}
+
+			// the fake database keeps the job so that its progress can be followed
+			var mu sync.Mutex
+			jobs := map[string]*domain.BulkInviteJob{}
+			fakeDB.MockCreateBulkInviteJobFn = func(ctx context.Context, job *domain.BulkInviteJob) (*domain.BulkInviteJob, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				saved := *job
+				saved.ID = uuid.New().String()
+				saved.Rows = []*domain.BulkInviteRow{}
+				for _, row := range job.Rows {
+					r := *row
+					r.ID = uuid.New().String()
+					saved.Rows = append(saved.Rows, &r)
+				}
+				jobs[saved.ID] = &saved
+				return &saved, nil
+			}
+			fakeDB.MockGetBulkInviteJobFn = func(ctx context.Context, jobID string) (*domain.BulkInviteJob, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				job, ok := jobs[jobID]
+				if !ok {
+					return nil, fmt.Errorf("job not found")
+				}
+				snapshot := *job
+				snapshot.Rows = []*domain.BulkInviteRow{}
+				for _, row := range job.Rows {
+					r := *row
+					snapshot.Rows = append(snapshot.Rows, &r)
+				}
+				return &snapshot, nil
+			}
+			fakeDB.MockUpdateBulkInviteJobStatusFn = func(ctx context.Context, jobID string, status enums.BulkInviteJobStatus) (bool, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				jobs[jobID].Status = status
+				return true, nil
+			}
+			fakeDB.MockRecordBulkInviteRowOutcomeFn = func(ctx context.Context, jobID string, rowID string, status enums.BulkInviteRowStatus, errorMessage string) (bool, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				job := jobs[jobID]
+				for _, row := range job.Rows {
+					if row.ID == rowID {
+						row.Status, row.Error = status, errorMessage
+					}
+				}
+				if status == enums.BulkInviteRowStatusSuccess {
+					job.Succeeded++
+				} else {
+					job.Failed++
+				}
+				return true, nil
+			}
+
+			jobID, err := us.BulkInviteUsers(ctx, csvContent, feedlib.FlavourConsumer)
+			if err != nil {
+				t.Errorf("UseCasesUserImpl.BulkInviteUsers() error = %v", err)
+				return
+			}
+			if tt.args.jobID == "" {
+				tt.args.jobID = jobID
+			}
+
+			if tt.name == "Sad case - logged in user is not staff" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return validUserID, nil
+				}
+			}
+
+			got, err := us.GetBulkInviteJob(tt.args.ctx, tt.args.jobID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.GetBulkInviteJob() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			for i := 0; i < 50 && got.Status != enums.BulkInviteJobStatusCompleted; i++ {
+				time.Sleep(100 * time.Millisecond)
+				got, err = us.GetBulkInviteJob(tt.args.ctx, tt.args.jobID)
+				if err != nil {
+					t.Errorf("UseCasesUserImpl.GetBulkInviteJob() error = %v", err)
+					return
+				}
+			}
+			if got.Status != enums.BulkInviteJobStatusCompleted {
+				t.Errorf("UseCasesUserImpl.GetBulkInviteJob() job did not complete, status = %v", got.Status)
+				return
+			}
+			if got.Succeeded != tt.wantSucceeded || got.Failed != tt.wantFailed {
+				t.Errorf("UseCasesUserImpl.GetBulkInviteJob() succeeded = %v, failed = %v, want %v and %v",
+					got.Succeeded, got.Failed, tt.wantSucceeded, tt.wantFailed)
+			}
+			if got.Rows[0].Status != enums.BulkInviteRowStatusSuccess {
+				t.Errorf("UseCasesUserImpl.GetBulkInviteJob() expected the first row to succeed, got %v", got.Rows[0].Error)
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_ResendInvite(t *testing.T) {
+	ctx := context.Background()
+
+	staffID := ksuid.New().String()
+	userID := ksuid.New().String()
+
+	type args struct {
+		ctx     context.Context
+		userID  string
+		flavour feedlib.Flavour
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:     ctx,
+				userID:  userID,
+				flavour: feedlib.FlavourConsumer,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case - invalid flavour",
+			args: args{
+				ctx:     ctx,
+				userID:  userID,
+				flavour: "invalid",
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - logged in user cannot access the client",
+			args: args{
+				ctx:     ctx,
+				userID:  userID,
+				flavour: feedlib.FlavourConsumer,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - user has not been invited",
+			args: args{
+				ctx:     ctx,
+				userID:  userID,
+				flavour: feedlib.FlavourConsumer,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invite already accepted",
+			args: args{
+				ctx:     ctx,
+				userID:  userID,
+				flavour: feedlib.FlavourConsumer,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to send invite",
+			args: args{
+				ctx:     ctx,
+				userID:  userID,
+				flavour: feedlib.FlavourConsumer,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				user := &domain.User{
+					ID:       &userID,
+					UserType: enums.ClientUser,
+					Contacts: &domain.Contact{
+						ContactValue: interserviceclient.TestUserPhoneNumber,
+					},
+				}
+				if userID == staffID {
+					user.UserType = enums.HealthcareWorkerUser
+				}
+				return user, nil
+			}
+
+			if tt.name == "Sad case - logged in user cannot access the client" {
+				fakeAuthority.MockCheckClientAccessFn = func(ctx context.Context, clientID string, permission enums.PermissionType) (bool, error) {
+					return false, fmt.Errorf("user cannot access the client's facility")
+				}
+			}
+			// overdue invites are expired by the scheduled sweep rather than on each request
+			fakeDB.MockExpireOverdueInvitationsFn = func(ctx context.Context) (bool, error) {
+				return false, fmt.Errorf("overdue invitations should not be expired on each request")
+			}
+			if tt.name == "Sad case - user has not been invited" {
+				fakeDB.MockGetLatestUserInvitationFn = func(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.Invitation, error) {
+					return nil, fmt.Errorf("record not found")
+				}
+			}
+			if tt.name == "Sad case - invite already accepted" {
+				fakeDB.MockGetLatestUserInvitationFn = func(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.Invitation, error) {
+					return &domain.Invitation{
+						ID:      uuid.New().String(),
+						UserID:  userID,
+						Flavour: flavour,
+						Status:  enums.InvitationStatusAccepted,
+					}, nil
+				}
+			}
+			if tt.name == "Sad case - fail to send invite" {
+				fakeExtension.MockSendInviteSMSFn = func(ctx context.Context, phoneNumber, message string, senderID enumutils.SenderID) error {
+					return fmt.Errorf("failed to send SMS")
+				}
+			}
+
+			got, err := us.ResendInvite(tt.args.ctx, tt.args.userID, tt.args.flavour)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.ResendInvite() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.ResendInvite() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_ListPendingInvitations(t *testing.T) {
+	ctx := context.Background()
+
+	staffID := ksuid.New().String()
+	facilityID := uuid.New().String()
+
+	type args struct {
+		ctx        context.Context
+		facilityID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:        ctx,
+				facilityID: facilityID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - no facility ID",
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - logged in user cannot access the facility",
+			args: args{
+				ctx:        ctx,
+				facilityID: facilityID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to list pending invitations",
+			args: args{
+				ctx:        ctx,
+				facilityID: facilityID,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				return &domain.User{
+					ID:       &userID,
+					UserType: enums.HealthcareWorkerUser,
+				}, nil
+			}
+
+			if tt.name == "Sad case - logged in user cannot access the facility" {
+				fakeAuthority.MockCheckFacilityAccessFn = func(ctx context.Context, facilityID string, permission enums.PermissionType) (bool, error) {
+					return false, fmt.Errorf("user cannot access the facility")
+				}
+			}
+			// overdue invites are expired by the scheduled sweep rather than on each request
+			fakeDB.MockExpireOverdueInvitationsFn = func(ctx context.Context) (bool, error) {
+				return false, fmt.Errorf("overdue invitations should not be expired on each request")
+			}
+			if tt.name == "Sad case - fail to list pending invitations" {
+				fakeDB.MockListPendingInvitationsFn = func(ctx context.Context, facilityID string) ([]*domain.Invitation, error) {
+					return nil, fmt.Errorf("failed to list pending invitations")
+				}
+			}
+
+			got, err := us.ListPendingInvitations(tt.args.ctx, tt.args.facilityID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.ListPendingInvitations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("UseCasesUserImpl.ListPendingInvitations() expected pending invitations")
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_RegisterClient(t *testing.T) {
+	ctx := context.Background()
+
+	staffID := ksuid.New().String()
+
+	validInput := dto.ClientRegistrationInput{
+		Username:    gofakeit.Username(),
+		FirstName:   gofakeit.FirstName(),
+		LastName:    gofakeit.LastName(),
+		Gender:      enumutils.GenderFemale,
+		PhoneNumber: interserviceclient.TestUserPhoneNumber,
+		FacilityID:  uuid.New().String(),
+		ClientType:  "PMTCT",
+		Counselled:  true,
+	}
+
+	invalidGender := validInput
+	invalidGender.Gender = "invalid"
+
+	invalidPhone := validInput
+	invalidPhone.PhoneNumber = "invalid"
+
+	type args struct {
+		ctx   context.Context
+		input dto.ClientRegistrationInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - missing required fields",
+			args: args{
+				ctx:   ctx,
+				input: dto.ClientRegistrationInput{Username: gofakeit.Username()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - logged in user cannot access the facility",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid gender",
+			args: args{
+				ctx:   ctx,
+				input: invalidGender,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - invalid phone number",
+			args: args{
+				ctx:   ctx,
+				input: invalidPhone,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - username is taken",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - phone number is registered",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - facility not found",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to register client",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to assign client role",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to invite client",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				return &domain.User{
+					ID:       &userID,
+					UserType: enums.HealthcareWorkerUser,
+				}, nil
+			}
+
+			if tt.name == "Sad case - logged in user cannot access the facility" {
+				fakeAuthority.MockCheckFacilityAccessFn = func(ctx context.Context, facilityID string, permission enums.PermissionType) (bool, error) {
+					return false, fmt.Errorf("user cannot access the facility")
+				}
+			}
+			if tt.name == "Sad case - username is taken" {
+				fakeDB.MockRegisterClientFn = func(ctx context.Context, client *domain.ClientProfile) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("failed to create user: %w", domain.ErrUsernameTaken)
+				}
+			}
+			if tt.name == "Sad case - phone number is registered" {
+				fakeDB.MockRegisterClientFn = func(ctx context.Context, client *domain.ClientProfile) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("failed to create contact: %w", domain.ErrContactTaken)
+				}
+			}
+			if tt.name == "Sad case - facility not found" {
+				fakeDB.MockRetrieveFacilityFn = func(ctx context.Context, id *string, isActive bool) (*domain.Facility, error) {
+					return nil, fmt.Errorf("facility not found")
+				}
+			}
+			if tt.name == "Sad case - fail to register client" {
+				fakeDB.MockRegisterClientFn = func(ctx context.Context, client *domain.ClientProfile) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("failed to register client")
+				}
+			}
+			if tt.name == "Sad case - fail to assign client role" {
+				fakeDB.MockAssignRolesFn = func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+					return false, fmt.Errorf("failed to assign role")
+				}
+			}
+			if tt.name == "Sad case - fail to invite client" {
+				fakeExtension.MockSendInviteSMSFn = func(ctx context.Context, phoneNumber, message string, senderID enumutils.SenderID) error {
+					return fmt.Errorf("failed to send SMS")
+				}
+			}
+
+			got, err := us.RegisterClient(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.RegisterClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.FacilityID != tt.args.input.FacilityID || got.User.UserType != enums.ClientUser {
+				t.Errorf("UseCasesUserImpl.RegisterClient() returned an unexpected client profile: %v", got)
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_RegisterStaff(t *testing.T) {
+	ctx := context.Background()
+
+	staffID := ksuid.New().String()
+
+	validInput := dto.StaffRegistrationInput{
+		Username:    gofakeit.Username(),
+		FirstName:   gofakeit.FirstName(),
+		LastName:    gofakeit.LastName(),
+		Gender:      enumutils.GenderMale,
+		PhoneNumber: interserviceclient.TestUserPhoneNumber,
+		FacilityID:  uuid.New().String(),
+		StaffNumber: ksuid.New().String(),
+		Cadre:       enums.StaffCadreClinician,
+	}
+
+	type args struct {
+		ctx   context.Context
+		input dto.StaffRegistrationInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case - missing staff number",
+			args: args{
+				ctx: ctx,
+				input: dto.StaffRegistrationInput{
+					Username:    validInput.Username,
+					FirstName:   validInput.FirstName,
+					LastName:    validInput.LastName,
+					Gender:      validInput.Gender,
+					PhoneNumber: validInput.PhoneNumber,
+					FacilityID:  validInput.FacilityID,
+					Cadre:       validInput.Cadre,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - logged in user cannot access the facility",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - username is taken",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - staff number is taken",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to register staff",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to assign staff role",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - fail to invite staff",
+			args: args{
+				ctx:   ctx,
+				input: validInput,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			otp := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension)
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, otp, fakeAuthority)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				return &domain.User{
+					ID:       &userID,
+					UserType: enums.HealthcareWorkerUser,
+				}, nil
+			}
+
+			if tt.name == "Sad case - logged in user cannot access the facility" {
+				fakeAuthority.MockCheckFacilityAccessFn = func(ctx context.Context, facilityID string, permission enums.PermissionType) (bool, error) {
+					return false, fmt.Errorf("user cannot access the facility")
+				}
+			}
+			if tt.name == "Sad case - username is taken" {
+				fakeDB.MockRegisterStaffFn = func(ctx context.Context, staff *domain.StaffProfile) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("failed to create user: %w", domain.ErrUsernameTaken)
+				}
+			}
+			if tt.name == "Sad case - staff number is taken" {
+				fakeDB.MockRegisterStaffFn = func(ctx context.Context, staff *domain.StaffProfile) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("failed to create staff profile: %w", domain.ErrStaffNumberTaken)
+				}
+			}
+			if tt.name == "Sad case - fail to register staff" {
+				fakeDB.MockRegisterStaffFn = func(ctx context.Context, staff *domain.StaffProfile) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("failed to register staff")
+				}
+			}
+			if tt.name == "Sad case - fail to assign staff role" {
+				fakeDB.MockAssignRolesFn = func(ctx context.Context, userID string, roles []enums.UserRoleType) (bool, error) {
+					return false, fmt.Errorf("failed to assign role")
+				}
+			}
+			if tt.name == "Sad case - fail to invite staff" {
+				fakeDB.MockSaveTemporaryUserPinFn = func(ctx context.Context, pinData *domain.UserPIN) (bool, error) {
+					return false, fmt.Errorf("failed to save temporary pin")
+				}
+			}
+
+			got, err := us.RegisterStaff(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.RegisterStaff() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.StaffNumber != tt.args.input.StaffNumber {
+				t.Errorf("UseCasesUserImpl.RegisterStaff() staff number = %v, want %v", got.StaffNumber, tt.args.input.StaffNumber)
+			}
+		})
+	}
+}
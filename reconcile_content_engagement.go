@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/content"
+)
+
+// reconcileContentEngagementCommand is the subcommand that rewrites the content engagement counters that don't
+// match the engagement recorded per user and reports them e.g
+//
+//	go run . reconcile-content-engagement -dry-run
+const reconcileContentEngagementCommand = "reconcile-content-engagement"
+
+// parseContentEngagementReconcileArgs reads the reconcile content engagement subcommand's flags and returns
+// whether it is a dry run
+func parseContentEngagementReconcileArgs(args []string, output io.Writer) (bool, error) {
+	flags := flag.NewFlagSet(reconcileContentEngagementCommand, flag.ContinueOnError)
+	flags.SetOutput(output)
+
+	dryRun := flags.Bool("dry-run", false, "report the counters that have drifted without rewriting them")
+
+	if err := flags.Parse(args); err != nil {
+		return false, err
+	}
+	if flags.NArg() > 0 {
+		return false, fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	return *dryRun, nil
+}
+
+// reconcileContentEngagement runs the reconcile content engagement subcommand and writes the drift report to the
+// output as JSON
+func reconcileContentEngagement(ctx context.Context, args []string, output io.Writer) error {
+	dryRun, err := parseContentEngagementReconcileArgs(args, output)
+	if err != nil {
+		return err
+	}
+
+	pg, err := gorm.NewPGInstance()
+	if err != nil {
+		return fmt.Errorf("failed to initialize new PG instance: %v", err)
+	}
+	db := postgres.NewMyCareHubDb(pg, pg, pg, pg)
+	contentUseCase := content.NewUseCasesContentImplementation(db, db)
+
+	// the content of every organisation is reconciled
+	report, err := contentUseCase.ReconcileContentEngagement(helpers.ContextForAllOrganisations(ctx), dryRun)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
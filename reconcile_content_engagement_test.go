@@ -0,0 +1,50 @@
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+func Test_parseContentEngagementReconcileArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantDryRun bool
+		wantErr    bool
+	}{
+		{
+			name:       "Happy case",
+			args:       []string{},
+			wantDryRun: false,
+			wantErr:    false,
+		},
+		{
+			name:       "Happy case - dry run",
+			args:       []string{"-dry-run"},
+			wantDryRun: true,
+			wantErr:    false,
+		},
+		{
+			name:    "Sad case - unknown flag",
+			args:    []string{"-fix"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case - unexpected argument",
+			args:    []string{"now"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseContentEngagementReconcileArgs(tt.args, io.Discard)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseContentEngagementReconcileArgs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.wantDryRun {
+				t.Errorf("parseContentEngagementReconcileArgs() = %v, want %v", got, tt.wantDryRun)
+			}
+		})
+	}
+}
@@ -18,6 +18,43 @@ const waitSeconds = 30
 
 func main() {
 	ctx := context.Background()
+
+	if len(os.Args) > 1 && os.Args[1] == migrateCommand {
+		if err := migrate(ctx, os.Args[2:], os.Stdout); err != nil {
+			log.Printf("failed to migrate the database: %s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if len(os.Args) > 1 && os.Args[1] == importFacilitiesCommand {
+		if err := importFacilities(ctx, os.Args[2:], os.Stdout); err != nil {
+			log.Printf("failed to import facilities: %s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if len(os.Args) > 1 && os.Args[1] == reconcileContentEngagementCommand {
+		if err := reconcileContentEngagement(ctx, os.Args[2:], os.Stdout); err != nil {
+			log.Printf("failed to reconcile content engagement: %s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if len(os.Args) > 1 && os.Args[1] == expireInvitationsCommand {
+		if err := expireInvitations(ctx, os.Args[2:], os.Stdout); err != nil {
+			log.Printf("failed to expire invitations: %s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if len(os.Args) > 1 && os.Args[1] == grantSystemAdminCommand {
+		if err := grantSystemAdmin(ctx, os.Args[2:], os.Stdout); err != nil {
+			log.Printf("failed to grant system admin: %s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	err := serverutils.Sentry()
 	if err != nil {
 		serverutils.LogStartupError(ctx, err)
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/testutils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/segmentio/ksuid"
@@ -261,7 +262,8 @@ func TestCreateFacility(t *testing.T) {
 }
 
 func TestInactivateFacility(t *testing.T) {
-	ctx := context.Background()
+	// the facility is set up directly rather than on behalf of a logged in user
+	ctx := helpers.ContextForAllOrganisations(context.Background())
 
 	i, err := testutils.InitializeTestService(ctx)
 	if err != nil {
@@ -412,7 +414,8 @@ func TestInactivateFacility(t *testing.T) {
 }
 
 func TestReactivateFacility(t *testing.T) {
-	ctx := context.Background()
+	// the facility is set up directly rather than on behalf of a logged in user
+	ctx := helpers.ContextForAllOrganisations(context.Background())
 
 	i, err := testutils.InitializeTestService(ctx)
 	if err != nil {